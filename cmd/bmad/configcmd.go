@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/bundle"
+	"github.com/robertguss/bmad-automate-go/internal/config"
+)
+
+const configUsage = `Usage:
+  bmad config export [--secrets] [-o FILE]
+  bmad config import [--force] <file>
+`
+
+// defaultBundleFile is where "bmad config export" writes unless -o is given
+const defaultBundleFile = "bmad-config.tar.gz"
+
+// runConfigCommand handles the "bmad config" subcommands and returns the
+// process exit code
+func runConfigCommand(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, configUsage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "export":
+		err = configExport(cfg, args[1:], stdout, stderr)
+	case "import":
+		err = configImport(cfg, args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "Unknown config command %q\n\n%s", args[0], configUsage)
+		return 2
+	}
+
+	if err == flag.ErrHelp {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func configExport(cfg *config.Config, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("config export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", defaultBundleFile, "file to write the bundle to")
+	secrets := fs.Bool("secrets", false, "include tokens, keys and passwords from the environment")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprint(stderr, configUsage)
+		return flag.ErrHelp
+	}
+
+	f, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *output, err)
+	}
+	manifest, err := bundle.Export(cfg.DataDir, f, bundle.ExportOptions{
+		Version: cfg.Version,
+		Secrets: *secrets,
+		Environ: os.Environ(),
+	})
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Wrote %s (%d files, %d settings)\n", *output, len(manifest.Files), len(manifest.Env))
+	if len(manifest.Omitted) > 0 {
+		fmt.Fprintf(stdout, "Left out %s - add --secrets to include them\n", strings.Join(manifest.Omitted, ", "))
+	}
+	return nil
+}
+
+func configImport(cfg *config.Config, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("config import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	force := fs.Bool("force", false, "replace files and settings that differ")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(stderr, configUsage)
+		return flag.ErrHelp
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := cfg.EnsureDataDir(); err != nil {
+		return err
+	}
+	result, err := bundle.Import(cfg.DataDir, f, bundle.ImportOptions{Force: *force})
+	if result != nil {
+		for _, name := range result.Written {
+			fmt.Fprintf(stdout, "  wrote   %s\n", name)
+		}
+		for _, name := range result.Skipped {
+			fmt.Fprintf(stdout, "  kept    %s (differs)\n", name)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Imported into %s: %d written, %d unchanged, %d kept\n",
+		cfg.DataDir, len(result.Written), len(result.Unchanged), len(result.Skipped))
+	if len(result.Skipped) > 0 {
+		fmt.Fprintln(stdout, "Run again with --force to replace what differs")
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/export"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/util"
+)
+
+const dbUsage = `Usage:
+  bmad db export [--format parquet|json|csv] [--with-output] [-o DIR]
+  bmad db prune [--max-age-days N] [--max-rows N] [--max-size-mb N]
+  bmad db maintain
+`
+
+// defaultExportDir is where "bmad db export" writes unless -o is given
+const defaultExportDir = "bmad-export"
+
+// runDBCommand handles the "bmad db" subcommands and returns the process
+// exit code
+func runDBCommand(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, dbUsage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "export":
+		err = dbExport(cfg, args[1:], stdout, stderr)
+	case "prune":
+		err = dbPrune(cfg, args[1:], stdout, stderr)
+	case "maintain":
+		err = dbMaintain(cfg, args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "Unknown db command %q\n\n%s", args[0], dbUsage)
+		return 2
+	}
+
+	if err == flag.ErrHelp {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func dbExport(cfg *config.Config, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("db export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", export.FormatParquet, "output format (parquet, json or csv)")
+	dir := fs.String("o", defaultExportDir, "directory to write the export to")
+	withOutput := fs.Bool("with-output", false, "include step output lines (json and csv)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprint(stderr, dbUsage)
+		return flag.ErrHelp
+	}
+	switch *format {
+	case export.FormatParquet, export.FormatJSON, export.FormatCSV:
+	default:
+		return fmt.Errorf("unsupported format %q (supported: %s, %s, %s)",
+			*format, export.FormatParquet, export.FormatJSON, export.FormatCSV)
+	}
+
+	store, err := storage.NewSQLiteStorage(cfg.DatabasePath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	var result *export.Result
+	switch *format {
+	case export.FormatJSON:
+		result, err = export.JSON(ctx, store, *dir, *withOutput)
+	case export.FormatCSV:
+		result, err = export.CSV(ctx, store, *dir, *withOutput)
+	default:
+		result, err = export.Parquet(ctx, store, *dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *format == export.FormatParquet {
+		fmt.Fprintf(stdout, "Exported %d executions, %d steps and %d step outputs\n",
+			result.Executions, result.Steps, result.Outputs)
+	} else {
+		fmt.Fprintf(stdout, "Exported %d executions, %d steps and %d output lines\n",
+			result.Executions, result.Steps, result.Outputs)
+	}
+	for _, f := range result.Files {
+		fmt.Fprintf(stdout, "  %s\n", f)
+	}
+	return nil
+}
+
+// dbPrune applies the history retention policy once. Flags override the
+// configured limits.
+func dbPrune(cfg *config.Config, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("db prune", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	maxAge := fs.Int("max-age-days", cfg.HistoryMaxAgeDays, "delete executions older than this many days (0 = no limit)")
+	maxRows := fs.Int("max-rows", cfg.HistoryMaxRows, "keep only this many of the newest executions (0 = no limit)")
+	maxSize := fs.Int("max-size-mb", cfg.HistoryMaxSizeMB, "delete the oldest executions until history fits (0 = no limit)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprint(stderr, dbUsage)
+		return flag.ErrHelp
+	}
+	policy := storage.NewRetentionPolicy(*maxAge, *maxRows, *maxSize)
+	if policy.IsZero() {
+		return fmt.Errorf("no retention limits set; pass a flag or set BMAD_HISTORY_MAX_AGE_DAYS, BMAD_HISTORY_MAX_ROWS or BMAD_HISTORY_MAX_SIZE_MB")
+	}
+
+	store, err := storage.NewSQLiteStorage(cfg.DatabasePath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	store.SetOutputDir(cfg.OutputDir())
+	store.SetRepoDir(cfg.WorkingDir)
+
+	deleted, err := store.Prune(context.Background(), policy)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "Pruned %d executions\n", deleted)
+	return nil
+}
+
+// dbMaintain checks the database's integrity, then vacuums and analyzes it
+func dbMaintain(cfg *config.Config, args []string, stdout, stderr io.Writer) error {
+	if len(args) != 0 {
+		fmt.Fprint(stderr, dbUsage)
+		return flag.ErrHelp
+	}
+
+	store, err := storage.NewSQLiteStorage(cfg.DatabasePath)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	result, err := store.Maintenance(context.Background())
+	if err != nil {
+		return err
+	}
+	if !result.OK() {
+		for _, problem := range result.Problems {
+			fmt.Fprintf(stderr, "  %s\n", problem)
+		}
+		return fmt.Errorf("integrity check failed; run Repair Database from the command palette")
+	}
+	fmt.Fprintln(stdout, "Integrity check: ok")
+	fmt.Fprintf(stdout, "Vacuumed and analyzed: %s -> %s (%s reclaimed)\n",
+		util.FormatBytes(result.SizeBefore), util.FormatBytes(result.SizeAfter), util.FormatBytes(result.Reclaimed()))
+	return nil
+}
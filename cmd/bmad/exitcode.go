@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/preflight"
+)
+
+// Exit codes of the headless "run" and "pipeline run" commands, so CI can
+// branch on the result without parsing the output
+const (
+	exitSuccess    = 0
+	exitError      = 1 // The run could not start: unknown story, bad workflow, ...
+	exitStepFailed = 2
+	exitTimeout    = 3
+	exitPreflight  = 4
+	exitCancelled  = 5
+	exitUsage      = 64 // As EX_USAGE in sysexits.h
+)
+
+// exitResults names each exit code in --json summaries
+var exitResults = map[int]string{
+	exitSuccess:    "success",
+	exitError:      "error",
+	exitStepFailed: "step_failed",
+	exitTimeout:    "timeout",
+	exitPreflight:  "preflight_failed",
+	exitCancelled:  "cancelled",
+	exitUsage:      "usage_error",
+}
+
+// statusExitCode returns the exit code for a finished run. A failure is a
+// timeout when a step that failed was stopped at its timeout.
+func statusExitCode(status domain.ExecutionStatus, timedOut bool) int {
+	switch status {
+	case domain.ExecutionCompleted:
+		return exitSuccess
+	case domain.ExecutionCancelled:
+		return exitCancelled
+	}
+	if timedOut {
+		return exitTimeout
+	}
+	return exitStepFailed
+}
+
+// checkPreflight runs the pre-flight checks, reporting failures on stderr,
+// and returns whether a run may go ahead
+func checkPreflight(cfg *config.Config, stderr io.Writer) bool {
+	results := preflight.RunAll(cfg)
+	if results.AllPass {
+		return true
+	}
+	for _, check := range results.FailedChecks() {
+		fmt.Fprintf(stderr, "Pre-flight check failed: %s: %s\n", check.Name, check.Error)
+	}
+	fmt.Fprintln(stderr, "Fix the checks above or rerun with --skip-preflight")
+	return false
+}
+
+// runSummary is the --json summary of a headless run
+type runSummary struct {
+	Story       string         `json:"story,omitempty"`
+	Pipeline    string         `json:"pipeline,omitempty"`
+	Result      string         `json:"result"`
+	ExitCode    int            `json:"exit_code"`
+	Status      string         `json:"status,omitempty"`
+	ExecutionID string         `json:"execution_id,omitempty"`
+	Duration    float64        `json:"duration_seconds"`
+	Error       string         `json:"error,omitempty"`
+	Steps       []stepSummary  `json:"steps,omitempty"`
+	Stages      []stageSummary `json:"stages,omitempty"`
+}
+
+type stepSummary struct {
+	Name     string  `json:"name"`
+	Status   string  `json:"status"`
+	Attempts int     `json:"attempts,omitempty"`
+	Duration float64 `json:"duration_seconds"`
+	Error    string  `json:"error,omitempty"`
+	TimedOut bool    `json:"timed_out,omitempty"`
+}
+
+type stageSummary struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+}
+
+// newRunSummary summarizes a run that ended with code; execution is nil when
+// the run did not start
+func newRunSummary(code int, execution *domain.Execution) runSummary {
+	summary := runSummary{Result: exitResults[code], ExitCode: code}
+	if execution == nil {
+		return summary
+	}
+	summary.Story = execution.Story.Key
+	summary.Status = string(execution.Status)
+	summary.ExecutionID = execution.ID
+	summary.Duration = seconds(execution.Duration)
+	summary.Error = execution.Error
+	for _, step := range execution.Steps {
+		summary.Steps = append(summary.Steps, stepSummary{
+			Name:     string(step.Name),
+			Status:   string(step.Status),
+			Attempts: step.Attempt,
+			Duration: seconds(step.Duration),
+			Error:    step.Error,
+			TimedOut: step.TimedOut,
+		})
+	}
+	return summary
+}
+
+// newPipelineSummary summarizes a pipeline run that ended with code
+func newPipelineSummary(code int, name string, run *domain.PipelineRun) runSummary {
+	summary := runSummary{Pipeline: name, Result: exitResults[code], ExitCode: code}
+	if run == nil {
+		return summary
+	}
+	summary.Status = string(run.Status)
+	summary.Duration = seconds(run.Duration)
+	summary.Error = run.Error
+	for _, stage := range run.Stages {
+		summary.Stages = append(summary.Stages, stageSummary{
+			Name:      stage.Name,
+			Status:    string(stage.Status),
+			Succeeded: stage.Succeeded,
+			Failed:    stage.Failed,
+		})
+	}
+	return summary
+}
+
+func seconds(d time.Duration) float64 {
+	return d.Round(time.Millisecond).Seconds()
+}
+
+// batchSummary is the --json summary of "bmad run -": the overall result
+// and the summary of each story that ran, in order
+type batchSummary struct {
+	Result   string       `json:"result"`
+	ExitCode int          `json:"exit_code"`
+	Stories  []runSummary `json:"stories"`
+}
+
+// newBatchSummary summarizes a run of several stories that ended with code
+func newBatchSummary(code int, stories []runSummary) batchSummary {
+	if stories == nil {
+		stories = []runSummary{}
+	}
+	return batchSummary{Result: exitResults[code], ExitCode: code, Stories: stories}
+}
+
+// writeSummary prints summary as indented JSON
+func writeSummary(w io.Writer, summary any) {
+	data, _ := json.MarshalIndent(summary, "", "  ")
+	fmt.Fprintln(w, string(data))
+}
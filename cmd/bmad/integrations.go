@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/deploy"
+)
+
+const integrationsUsage = `Usage:
+  bmad integrations docker [--force] [-o DIR]
+`
+
+// defaultDeployDir is where "bmad integrations docker" writes unless -o is given
+const defaultDeployDir = "deploy"
+
+// runIntegrationsCommand handles the "bmad integrations" subcommands and
+// returns the process exit code
+func runIntegrationsCommand(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, integrationsUsage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "docker":
+		err = integrationsDocker(cfg, args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "Unknown integrations command %q\n\n%s", args[0], integrationsUsage)
+		return 2
+	}
+
+	if err == flag.ErrHelp {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func integrationsDocker(cfg *config.Config, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("integrations docker", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", defaultDeployDir, "directory to write the files to")
+	force := fs.Bool("force", false, "overwrite files generated before")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprint(stderr, integrationsUsage)
+		return flag.ErrHelp
+	}
+
+	opts := deploy.OptionsFrom(cfg, os.Environ(), *output)
+	files, err := deploy.Docker(opts)
+	if err != nil {
+		return err
+	}
+
+	// Check every file first so nothing is half written
+	if !*force {
+		for _, f := range files {
+			path := filepath.Join(*output, f.Name)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+			}
+		}
+	}
+	if err := os.MkdirAll(*output, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", *output, err)
+	}
+	for _, f := range files {
+		path := filepath.Join(*output, f.Name)
+		if err := os.WriteFile(path, f.Data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Fprintf(stdout, "Wrote %s\n", path)
+	}
+
+	// The container only sees the checkout and the data directory
+	for _, path := range []string{cfg.SprintStatusPath, cfg.StoryDir} {
+		if rel, err := filepath.Rel(cfg.WorkingDir, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			fmt.Fprintf(stderr, "Warning: %s is outside %s and will not be visible in the container\n", path, cfg.WorkingDir)
+		}
+	}
+
+	fmt.Fprintf(stdout, "\nPut ANTHROPIC_API_KEY (and any tokens) in %s, then:\n", filepath.Join(*output, ".env"))
+	fmt.Fprintf(stdout, "  cd %s && docker compose up -d\n", *output)
+	fmt.Fprintf(stdout, "The API listens on http://localhost:%d; see %s to start it at boot.\n", opts.APIPort, deploy.UnitName)
+	return nil
+}
@@ -10,6 +10,9 @@ import (
 	"github.com/robertguss/bmad-automate-go/internal/config"
 )
 
+// version is set with -ldflags by the Makefile
+var version = "dev"
+
 func main() {
 	// Capture panic stack traces
 	defer func() {
@@ -27,16 +30,84 @@ func main() {
 
 	// Initialize configuration
 	cfg := config.New()
+	cfg.Version = version
+	if cfg.EnvFileErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring saved settings: %v\n", cfg.EnvFileErr)
+	}
+
+	// Subcommands run without starting the TUI
+	if len(os.Args) > 1 && os.Args[1] == "workflow" {
+		os.Exit(runWorkflowCommand(cfg, os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "run" {
+		os.Exit(runRunCommand(cfg, os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "queue" {
+		os.Exit(runQueueCommand(cfg, os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "pipeline" {
+		os.Exit(runPipelineCommand(cfg, os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "db" {
+		os.Exit(runDBCommand(cfg, os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		os.Exit(runConfigCommand(cfg, os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "integrations" {
+		os.Exit(runIntegrationsCommand(cfg, os.Args[2:], os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "mcp" {
+		os.Exit(runMCPCommand(cfg, os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
+	// "bmad open <execution-id|link>" starts on that history record
+	var openRef string
+	if len(os.Args) > 1 && os.Args[1] == "open" {
+		if len(os.Args) != 3 {
+			fmt.Fprintln(os.Stderr, "Usage: bmad open <execution-id|link>")
+			os.Exit(2)
+		}
+		openRef = os.Args[2]
+	}
+
+	// "bmad --attach [address]" mirrors another instance read-only
+	var attachAddr string
+	if len(os.Args) > 1 && os.Args[1] == "--attach" {
+		if len(os.Args) > 3 {
+			fmt.Fprintln(os.Stderr, "Usage: bmad --attach [http://host:port]")
+			os.Exit(2)
+		}
+		attachAddr = fmt.Sprintf("http://localhost:%d", cfg.APIPort)
+		if len(os.Args) == 3 {
+			attachAddr = os.Args[2]
+		}
+	}
 
 	// Create the application model
 	model := app.New(cfg)
+	if openRef != "" {
+		model.OpenExecution(openRef)
+	}
+	if attachAddr != "" {
+		if err := model.Attach(attachAddr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
 
-	// Create the Bubble Tea program
-	p := tea.NewProgram(
-		model,
-		tea.WithAltScreen(),       // Use alternate screen buffer
-		tea.WithMouseCellMotion(), // Enable mouse support
-	)
+	// Create the Bubble Tea program. Accessible mode renders inline so
+	// screen readers can follow the announced lines in the scrollback.
+	// Focus reports let notifications and sounds skip events the user is
+	// already watching.
+	opts := []tea.ProgramOption{tea.WithReportFocus()}
+	if !cfg.AccessibleMode {
+		opts = append(opts,
+			tea.WithAltScreen(),       // Use alternate screen buffer
+			tea.WithMouseCellMotion(), // Enable mouse support
+		)
+	}
+	p := tea.NewProgram(model, opts...)
 
 	// Set the program on the model's executor so it can send async messages
 	// Note: This works because executor is a pointer, so even though model
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/mcp"
+)
+
+const mcpUsage = `Usage:
+  bmad mcp
+
+Serves the stories, queue and execution history of the project as a Model
+Context Protocol server over stdin and stdout. Register it with an agent,
+for example: claude mcp add bmad -- bmad mcp
+`
+
+// runMCPCommand serves MCP until stdin is closed and returns the process
+// exit code. Stdout carries the protocol, so messages go to stderr.
+func runMCPCommand(cfg *config.Config, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) != 0 {
+		fmt.Fprint(stderr, mcpUsage)
+		return 2
+	}
+
+	store, err := openRunStorage(cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+
+	if err := mcp.New(cfg, store).Serve(context.Background(), stdin, stdout); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
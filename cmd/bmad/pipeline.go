@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/parser"
+	"github.com/robertguss/bmad-automate-go/internal/pipeline"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+const pipelineUsage = `Usage:
+  bmad pipeline list
+  bmad pipeline run [--approve] [--no-history] [--skip-preflight] [--json] <name>
+  bmad pipeline history [-n COUNT]
+`
+
+// runPipelineCommand handles the "bmad pipeline" subcommands and returns
+// the process exit code; run uses the headless codes in exitcode.go
+func runPipelineCommand(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, pipelineUsage)
+		return exitUsage
+	}
+
+	store := pipeline.NewStore(cfg.DataDir)
+	if err := store.Load(); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	switch args[0] {
+	case "list":
+		for _, name := range store.List() {
+			p, _ := store.Get(name)
+			fmt.Fprintf(stdout, "%s\t%d stages\t%s\n", name, len(p.Stages), p.Description)
+		}
+		return 0
+	case "run":
+		return pipelineRun(cfg, store, args[1:], stdout, stderr)
+	case "history":
+		return pipelineHistory(cfg, args[1:], stdout, stderr)
+	default:
+		fmt.Fprintf(stderr, "Unknown pipeline command %q\n\n%s", args[0], pipelineUsage)
+		return exitUsage
+	}
+}
+
+func pipelineRun(cfg *config.Config, store *pipeline.Store, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("pipeline run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	approve := fs.Bool("approve", false, "approve wait steps automatically instead of cancelling")
+	noHistory := fs.Bool("no-history", false, "do not record the run in history")
+	skipPreflight := fs.Bool("skip-preflight", false, "run even if pre-flight checks fail")
+	jsonSummary := fs.Bool("json", false, "print a JSON summary on stdout, with progress on stderr")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(stderr, pipelineUsage)
+		return exitUsage
+	}
+	name := fs.Arg(0)
+
+	// With --json, stdout carries only the summary
+	progress := stdout
+	if *jsonSummary {
+		progress = stderr
+	}
+	code, run := pipelineExecute(cfg, store, name, *approve, !*noHistory, *skipPreflight, progress, stderr)
+	if *jsonSummary {
+		writeSummary(stdout, newPipelineSummary(code, name, run))
+	}
+	return code
+}
+
+// pipelineExecute runs the named pipeline and returns its exit code, and
+// the run when it started
+func pipelineExecute(cfg *config.Config, store *pipeline.Store, name string, approve, record, skipPreflight bool, stdout, stderr io.Writer) (int, *domain.PipelineRun) {
+	p, ok := store.Get(name)
+	if !ok {
+		fmt.Fprintf(stderr, "Error: pipeline %q not found in %s\n", name, store.Dir())
+		return exitError, nil
+	}
+	if !skipPreflight && !checkPreflight(cfg, stderr) {
+		return exitPreflight, nil
+	}
+
+	stories, err := parser.LoadStories(context.Background(), cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to load stories: %v\n", err)
+		return exitError, nil
+	}
+	workflows := workflow.NewWorkflowStore(cfg.DataDir)
+	if err := workflows.Load(); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError, nil
+	}
+
+	var recorder pipeline.Recorder
+	if record {
+		store, err := openRunStorage(cfg)
+		if err != nil {
+			fmt.Fprintf(stderr, "Warning: run not recorded in history: %v\n", err)
+		} else {
+			defer store.Close()
+			recorder = store
+		}
+	}
+
+	runner := pipeline.NewRunner(cfg, workflows, recorder)
+	printer := &runPrinter{exec: runner, approve: approve, stdout: stdout, stderr: stderr}
+	runner.SetMessageHandler(func(msg tea.Msg) {
+		switch msg := msg.(type) {
+		case pipeline.StageStartedMsg:
+			fmt.Fprintf(stdout, "\n=== Stage %d/%d: %s (%d stories)\n", msg.Index+1, len(p.Stages), msg.Name, msg.Stories)
+		case pipeline.StageCompletedMsg:
+			fmt.Fprintf(stdout, "=== Stage %s %s: %d succeeded, %d failed\n", msg.Name, msg.Status, msg.Succeeded, msg.Failed)
+		default:
+			printer.handle(msg)
+		}
+	})
+
+	// Ctrl+C cancels the running story and the rest of the pipeline
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupts)
+	go func() {
+		if _, ok := <-interrupts; ok {
+			fmt.Fprintln(stderr, "Interrupted, cancelling...")
+			runner.Cancel()
+		}
+	}()
+
+	run := runner.Run(p, stories)
+
+	fmt.Fprintf(stdout, "\nPipeline %s %s in %s\n", p.Name, run.Status, run.Duration.Round(time.Second))
+	if run.Error != "" {
+		fmt.Fprintf(stdout, "Error: %s\n", run.Error)
+	}
+	return statusExitCode(run.Status, printer.timedOut), run
+}
+
+func pipelineHistory(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("pipeline history", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	count := fs.Int("n", 20, "number of runs to show")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	store, err := openRunStorage(cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer store.Close()
+
+	runs, err := store.ListPipelineRuns(context.Background(), *count)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, run := range runs {
+		fmt.Fprintf(stdout, "%s  %-20s %-10s %s\n", run.StartTime.Local().Format("2006-01-02 15:04"), run.Pipeline, run.Status, run.Duration.Round(time.Second))
+		for _, stage := range run.Stages {
+			fmt.Fprintf(stdout, "    %-18s %-10s %d ok, %d failed\n", stage.Name, stage.Status, stage.Succeeded, stage.Failed)
+		}
+	}
+	return 0
+}
+
+// openRunStorage opens the history database for a headless command
+func openRunStorage(cfg *config.Config) (*storage.SQLiteStorage, error) {
+	if err := cfg.EnsureDataDir(); err != nil {
+		return nil, err
+	}
+	return storage.NewSQLiteStorage(cfg.DatabasePath)
+}
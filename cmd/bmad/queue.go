@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/inbox"
+	"github.com/robertguss/bmad-automate-go/internal/parser"
+)
+
+const queueUsage = `Usage:
+  bmad queue add [--start] <story-key>...
+  bmad queue add [--start] -              (read keys from stdin)
+`
+
+// runQueueCommand dispatches "bmad queue" subcommands and returns the
+// process exit code
+func runQueueCommand(cfg *config.Config, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "add" {
+		fmt.Fprint(stderr, queueUsage)
+		return exitUsage
+	}
+
+	fs := flag.NewFlagSet("queue add", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	start := fs.Bool("start", false, "start the queue unless a run is already active")
+	if err := fs.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+
+	keys, code := storyKeyArgs(fs.Args(), stdin, stderr)
+	if code != exitSuccess {
+		if code == exitUsage {
+			fmt.Fprint(stderr, queueUsage)
+		}
+		return code
+	}
+	if err := resolveStoryKeys(cfg, keys, stderr); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	name, err := inbox.Drop(cfg.InboxDir(), "cli", inbox.Request{Keys: keys, Start: *start})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
+	}
+	fmt.Fprintf(stdout, "Queued %d stories (inbox/%s)\n", len(keys), name)
+	if !cfg.InboxEnabled {
+		fmt.Fprintln(stderr, "Note: the running instance only reads the inbox with BMAD_INBOX=1; it picks up pending requests when it starts")
+	}
+	return exitSuccess
+}
+
+// storyKeyArgs returns the story keys given on the command line, reading
+// them from stdin when the only argument is "-"
+func storyKeyArgs(args []string, stdin io.Reader, stderr io.Writer) ([]string, int) {
+	if len(args) == 0 {
+		return nil, exitUsage
+	}
+	if len(args) > 1 || args[0] != "-" {
+		return args, exitSuccess
+	}
+	keys, err := readStoryKeys(stdin)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to read stdin: %v\n", err)
+		return nil, exitError
+	}
+	if len(keys) == 0 {
+		fmt.Fprintln(stderr, "Error: no story keys on stdin")
+		return nil, exitUsage
+	}
+	return keys, exitSuccess
+}
+
+// readStoryKeys reads whitespace-separated story keys, one or more per line.
+// Blank lines and lines starting with # are skipped.
+func readStoryKeys(r io.Reader) ([]string, error) {
+	var keys []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		keys = append(keys, strings.Fields(line)...)
+	}
+	return keys, scanner.Err()
+}
+
+// resolveStoryKeys checks every key against the parsed stories, reporting
+// each unknown one on stderr, so nothing is queued or run on a typo
+func resolveStoryKeys(cfg *config.Config, keys []string, stderr io.Writer) error {
+	stories, err := parser.LoadStories(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load stories: %w", err)
+	}
+	var unknown int
+	for _, key := range keys {
+		if _, ok := findStory(stories, key); !ok {
+			fmt.Fprintf(stderr, "Unknown story: %s\n", key)
+			unknown++
+		}
+	}
+	if unknown > 0 {
+		return fmt.Errorf("%d of %d story keys not found", unknown, len(keys))
+	}
+	return nil
+}
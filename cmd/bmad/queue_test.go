@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/testutil"
+)
+
+// newSprintConfig returns a test config whose sprint status lists the
+// stories of testutil.ValidSprintStatusYAML
+func newSprintConfig(t *testing.T) *config.Config {
+	t.Helper()
+	cfg := testutil.NewTestConfig(t)
+	require.NoError(t, os.WriteFile(cfg.SprintStatusPath, []byte(testutil.ValidSprintStatusYAML()), 0644))
+	return cfg
+}
+
+func TestReadStoryKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"one key per line", "3-1-user-auth\n3-2-user-profile\n", []string{"3-1-user-auth", "3-2-user-profile"}},
+		{"several keys on a line", "3-1-user-auth 3-2-user-profile\t4-1-dashboard", []string{"3-1-user-auth", "3-2-user-profile", "4-1-dashboard"}},
+		{"blank and comment lines are skipped", "\n# epic 3\n  3-1-user-auth  \n\n  # later\n", []string{"3-1-user-auth"}},
+		{"nothing but comments", "# none\n\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := readStoryKeys(strings.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, keys)
+		})
+	}
+}
+
+func TestStoryKeyArgs(t *testing.T) {
+	t.Run("returns command line keys as given", func(t *testing.T) {
+		keys, code := storyKeyArgs([]string{"3-1-user-auth", "4-1-dashboard"}, strings.NewReader("ignored"), &bytes.Buffer{})
+		assert.Equal(t, exitSuccess, code)
+		assert.Equal(t, []string{"3-1-user-auth", "4-1-dashboard"}, keys)
+	})
+
+	t.Run("reads stdin for a lone dash", func(t *testing.T) {
+		keys, code := storyKeyArgs([]string{"-"}, strings.NewReader("# queue\n3-1-user-auth\n"), &bytes.Buffer{})
+		assert.Equal(t, exitSuccess, code)
+		assert.Equal(t, []string{"3-1-user-auth"}, keys)
+	})
+
+	t.Run("rejects empty stdin", func(t *testing.T) {
+		var stderr bytes.Buffer
+		keys, code := storyKeyArgs([]string{"-"}, strings.NewReader("\n# nothing\n"), &stderr)
+		assert.Equal(t, exitUsage, code)
+		assert.Empty(t, keys)
+		assert.Contains(t, stderr.String(), "no story keys on stdin")
+	})
+
+	t.Run("needs at least one argument", func(t *testing.T) {
+		_, code := storyKeyArgs(nil, strings.NewReader("3-1-user-auth"), &bytes.Buffer{})
+		assert.Equal(t, exitUsage, code)
+	})
+}
+
+func TestResolveStoryKeys(t *testing.T) {
+	cfg := newSprintConfig(t)
+
+	t.Run("accepts known keys", func(t *testing.T) {
+		var stderr bytes.Buffer
+		require.NoError(t, resolveStoryKeys(cfg, []string{"3-1-user-auth", "4-2-reports"}, &stderr))
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("reports every unknown key", func(t *testing.T) {
+		var stderr bytes.Buffer
+		err := resolveStoryKeys(cfg, []string{"3-1-user-auth", "9-9-nope", "9-8-typo"}, &stderr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2 of 3 story keys not found")
+		assert.Contains(t, stderr.String(), "Unknown story: 9-9-nope")
+		assert.Contains(t, stderr.String(), "Unknown story: 9-8-typo")
+	})
+}
+
+func TestRunQueueCommand_Stdin(t *testing.T) {
+	t.Run("queues the keys read from stdin", func(t *testing.T) {
+		cfg := newSprintConfig(t)
+		var stdout, stderr bytes.Buffer
+		code := runQueueCommand(cfg, []string{"add", "-"}, strings.NewReader("3-1-user-auth\n# skip\n\n3-2-user-profile\n"), &stdout, &stderr)
+		assert.Equal(t, exitSuccess, code, stderr.String())
+		assert.Contains(t, stdout.String(), "Queued 2 stories")
+	})
+
+	t.Run("queues nothing when a key is unknown", func(t *testing.T) {
+		cfg := newSprintConfig(t)
+		var stdout, stderr bytes.Buffer
+		code := runQueueCommand(cfg, []string{"add", "-"}, strings.NewReader("3-1-user-auth\n9-9-nope\n"), &stdout, &stderr)
+		assert.Equal(t, exitError, code)
+		assert.Contains(t, stderr.String(), "Unknown story: 9-9-nope")
+		assert.Empty(t, stdout.String())
+
+		entries, _ := os.ReadDir(cfg.InboxDir())
+		assert.Empty(t, entries)
+	})
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/robertguss/bmad-automate-go/internal/badge"
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/internal/failures"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/parser"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+const runUsage = `Usage:
+  bmad run [--workflow NAME] [--approve] [--no-history] [--skip-preflight] [--json] <story-key>
+  bmad run [flags] -              (run the story keys read from stdin in order)
+`
+
+// runRunCommand executes the full workflow for one story without the TUI,
+// streaming step output, and returns the process exit code (see exitcode.go).
+// With "-" it runs the keys read from stdin one after another, stopping at
+// the first that does not succeed, and --json prints one batch summary.
+func runRunCommand(cfg *config.Config, args []string, stdin io.Reader, stdout, stderr io.Writer) (code int) {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	workflowName := fs.String("workflow", cfg.ActiveWorkflow, "workflow to run")
+	approve := fs.Bool("approve", false, "approve wait steps automatically instead of cancelling")
+	noHistory := fs.Bool("no-history", false, "do not record the run in execution history")
+	skipPreflight := fs.Bool("skip-preflight", false, "run even if pre-flight checks fail")
+	jsonSummary := fs.Bool("json", false, "print a JSON summary on stdout, with progress on stderr")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(stderr, runUsage)
+		return exitUsage
+	}
+
+	// With --json, stdout carries only the summary: the story's for one key,
+	// or one batch summary of every story for keys read from stdin
+	progress := stdout
+	if *jsonSummary {
+		progress = stderr
+	}
+	fromStdin := fs.Arg(0) == "-"
+	var summaries []runSummary
+	finish := func(code int, key string, execution *domain.Execution) int {
+		if !*jsonSummary {
+			return code
+		}
+		if key != "" || execution != nil {
+			summary := newRunSummary(code, execution)
+			if summary.Story == "" {
+				summary.Story = key
+			}
+			summaries = append(summaries, summary)
+		}
+		if !fromStdin {
+			writeSummary(stdout, summaries[0])
+		}
+		return code
+	}
+	if fromStdin && *jsonSummary {
+		defer func() {
+			writeSummary(stdout, newBatchSummary(code, summaries))
+		}()
+	}
+
+	keys := []string{fs.Arg(0)}
+	if fromStdin {
+		var readCode int
+		if keys, readCode = storyKeyArgs(fs.Args(), stdin, stderr); readCode != exitSuccess {
+			return readCode
+		}
+		// Check every key before the first story runs
+		if err := resolveStoryKeys(cfg, keys, stderr); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return finish(exitError, "", nil)
+		}
+	}
+
+	if !*skipPreflight && !checkPreflight(cfg, stderr) {
+		return finish(exitPreflight, keys[0], nil)
+	}
+
+	for _, key := range keys {
+		execution, timedOut, err := runStory(cfg, key, *workflowName, *approve, !*noHistory, progress, stderr)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return finish(exitError, key, nil)
+		}
+		if storyCode := finish(statusExitCode(execution.Status, timedOut), key, execution); storyCode != exitSuccess {
+			return storyCode
+		}
+	}
+	return exitSuccess
+}
+
+// runStory runs the story with key and returns the finished execution and
+// whether a step failed at its timeout
+func runStory(cfg *config.Config, key, workflowName string, approve, record bool, stdout, stderr io.Writer) (*domain.Execution, bool, error) {
+	stories, err := parser.LoadStories(context.Background(), cfg)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load stories: %w", err)
+	}
+	story, ok := findStory(stories, key)
+	if !ok {
+		return nil, false, fmt.Errorf("story %q not found", key)
+	}
+
+	exec := executor.New(cfg)
+	if workflowName != "" {
+		store := workflow.NewWorkflowStore(cfg.DataDir)
+		if err := store.Load(); err != nil {
+			return nil, false, err
+		}
+		w, ok := store.Get(workflowName)
+		if !ok {
+			return nil, false, fmt.Errorf("workflow %q not found", workflowName)
+		}
+		exec.SetWorkflow(w)
+	}
+
+	printer := &runPrinter{exec: exec, approve: approve, stdout: stdout, stderr: stderr}
+	exec.SetMessageHandler(printer.handle)
+
+	// Ctrl+C cancels the run so the step process is stopped and the
+	// result is still reported
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupts)
+	go func() {
+		if _, ok := <-interrupts; ok {
+			fmt.Fprintln(stderr, "Interrupted, cancelling...")
+			exec.Cancel()
+		}
+	}()
+
+	result := exec.Execute(story)()
+	if msg, ok := result.(messages.ErrorMsg); ok {
+		return nil, false, msg.Error
+	}
+	completed := result.(messages.ExecutionCompletedMsg)
+
+	if record {
+		if err := saveRun(cfg, exec.GetExecution()); err != nil {
+			fmt.Fprintf(stderr, "Warning: run not recorded in history: %v\n", err)
+		}
+	}
+
+	fmt.Fprintf(stdout, "\n%s %s in %s\n", story.Key, completed.Status, completed.Duration.Round(time.Second))
+	if completed.Error != "" {
+		fmt.Fprintf(stdout, "Error: %s\n", completed.Error)
+	}
+	reportFailure(cfg, exec.GetExecution(), stdout, stderr)
+	if cfg.StoryBadges {
+		if err := badge.Record(cfg, exec.GetExecution()); err != nil {
+			fmt.Fprintf(stderr, "Warning: badge not written: %v\n", err)
+		}
+	}
+	return exec.GetExecution(), printer.timedOut, nil
+}
+
+func findStory(stories []domain.Story, key string) (domain.Story, bool) {
+	for _, s := range stories {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return domain.Story{}, false
+}
+
+// saveRun records the finished execution so it shows up in History
+func saveRun(cfg *config.Config, execution *domain.Execution) error {
+	store, err := openRunStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	return store.SaveExecution(context.Background(), execution)
+}
+
+// reportFailure files the configured failure report when the run failed
+func reportFailure(cfg *config.Config, execution *domain.Execution, stdout, stderr io.Writer) {
+	if cfg.FailureReport == "" || cfg.FailureReport == config.FailureReportOff {
+		return
+	}
+	report, ok := failures.FromExecution(execution)
+	if !ok {
+		return
+	}
+	location, err := failures.File(context.Background(), cfg, cfg.FailureReport, report)
+	if err != nil {
+		fmt.Fprintf(stderr, "Warning: failure report not filed: %v\n", err)
+		return
+	}
+	fmt.Fprintf(stdout, "Failure reported: %s\n", location)
+}
+
+// runControl is what the printer needs to answer wait steps; both an
+// executor and a pipeline runner provide it
+type runControl interface {
+	Resume()
+	Cancel()
+}
+
+// runPrinter writes executor progress as plain text
+type runPrinter struct {
+	mu       sync.Mutex
+	exec     runControl
+	approve  bool
+	stdout   io.Writer
+	stderr   io.Writer
+	timedOut bool // A step failed at its timeout
+}
+
+func (p *runPrinter) handle(msg tea.Msg) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch msg := msg.(type) {
+	case messages.StepStartedMsg:
+		if msg.Attempt > 1 {
+			fmt.Fprintf(p.stdout, "==> %s (attempt %d)\n", msg.StepName, msg.Attempt)
+		} else {
+			fmt.Fprintf(p.stdout, "==> %s\n", msg.StepName)
+		}
+	case messages.StepOutputMsg:
+		if msg.IsStderr {
+			fmt.Fprintln(p.stderr, msg.Line)
+		} else {
+			fmt.Fprintln(p.stdout, msg.Line)
+		}
+	case messages.StepCompletedMsg:
+		line := fmt.Sprintf("--- %s", msg.Status)
+		if msg.Duration > 0 {
+			line += fmt.Sprintf(" (%s)", msg.Duration.Round(time.Second))
+		}
+		if msg.Error != "" {
+			line += ": " + msg.Error
+		}
+		fmt.Fprintln(p.stdout, line)
+		p.timedOut = p.timedOut || msg.TimedOut
+	case messages.StepStalledMsg:
+		fmt.Fprintf(p.stderr, "No output from %s for %s\n", msg.StepName, msg.Idle.Round(time.Second))
+	case messages.ErrorMsg:
+		fmt.Fprintf(p.stderr, "Warning: %v\n", msg.Error)
+	case messages.StepWaitingMsg:
+		fmt.Fprintln(p.stdout, msg.Message)
+		if p.approve {
+			fmt.Fprintln(p.stdout, "Approved (--approve)")
+			p.exec.Resume()
+			return
+		}
+		fmt.Fprintln(p.stderr, "Wait step needs approval; rerun with --approve to continue past it")
+		p.exec.Cancel()
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRunCommand_StdinJSON(t *testing.T) {
+	t.Run("prints one document when a key is unknown", func(t *testing.T) {
+		cfg := newSprintConfig(t)
+		var stdout, stderr bytes.Buffer
+		code := runRunCommand(cfg, []string{"--json", "-"}, strings.NewReader("3-1-user-auth\n9-9-nope\n"), &stdout, &stderr)
+		assert.Equal(t, exitError, code)
+		assert.Contains(t, stderr.String(), "Unknown story: 9-9-nope")
+
+		var summary batchSummary
+		dec := json.NewDecoder(&stdout)
+		require.NoError(t, dec.Decode(&summary))
+		assert.False(t, dec.More(), "stdout holds a single JSON value")
+		assert.Equal(t, "error", summary.Result)
+		assert.Equal(t, exitError, summary.ExitCode)
+		assert.Empty(t, summary.Stories)
+	})
+
+	t.Run("prints one document when stdin has no keys", func(t *testing.T) {
+		cfg := newSprintConfig(t)
+		var stdout, stderr bytes.Buffer
+		code := runRunCommand(cfg, []string{"--json", "-"}, strings.NewReader("# nothing\n"), &stdout, &stderr)
+		assert.Equal(t, exitUsage, code)
+
+		var summary batchSummary
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &summary))
+		assert.Equal(t, "usage_error", summary.Result)
+		assert.Equal(t, exitUsage, summary.ExitCode)
+	})
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+// installTimeout bounds how long fetching a workflow may take
+const installTimeout = 2 * time.Minute
+
+const workflowUsage = `Usage:
+  bmad workflow install [--name NAME] [--force] <path|url|repo.git#path>
+  bmad workflow export <name> [-o FILE]
+  bmad workflow list
+`
+
+// runWorkflowCommand handles the "bmad workflow" subcommands and returns
+// the process exit code
+func runWorkflowCommand(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, workflowUsage)
+		return 2
+	}
+
+	if err := cfg.EnsureDataDir(); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	store := workflow.NewWorkflowStore(cfg.DataDir)
+	if err := store.Load(); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	var err error
+	switch args[0] {
+	case "install":
+		err = workflowInstall(store, args[1:], stdout, stderr)
+	case "export":
+		err = workflowExport(store, args[1:], stdout, stderr)
+	case "list":
+		names := store.List()
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintln(stdout, name)
+		}
+	default:
+		fmt.Fprintf(stderr, "Unknown workflow command %q\n\n%s", args[0], workflowUsage)
+		return 2
+	}
+
+	if err == flag.ErrHelp {
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func workflowInstall(store *workflow.WorkflowStore, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("workflow install", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	name := fs.String("name", "", "install under this name")
+	force := fs.Bool("force", false, "replace an existing workflow with the same name")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(stderr, workflowUsage)
+		return flag.ErrHelp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), installTimeout)
+	defer cancel()
+
+	data, defaultName, err := workflow.Fetch(ctx, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	w, err := store.Install(data, defaultName, workflow.InstallOptions{Name: *name, Force: *force})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Installed workflow %q (%d steps)\n", w.Name, len(w.Steps))
+	return nil
+}
+
+func workflowExport(store *workflow.WorkflowStore, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("workflow export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", "", "write to FILE instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprint(stderr, workflowUsage)
+		return flag.ErrHelp
+	}
+
+	if *output == "" {
+		return store.Export(fs.Arg(0), stdout)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *output, err)
+	}
+	if err := store.Export(fs.Arg(0), f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
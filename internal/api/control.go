@@ -0,0 +1,140 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// controlError is a control request that cannot be carried out in the
+// current state
+type controlError struct {
+	status  int // HTTP status the REST API answers with
+	message string
+}
+
+func (e *controlError) Error() string {
+	return e.message
+}
+
+func newControlError(status int, format string, args ...any) error {
+	return &controlError{status: status, message: fmt.Sprintf(format, args...)}
+}
+
+// respondControlError answers a REST request that failed with err
+func respondControlError(w http.ResponseWriter, err error) {
+	var ce *controlError
+	if errors.As(err, &ce) {
+		respondError(w, ce.status, ce.message)
+		return
+	}
+	respondError(w, http.StatusInternalServerError, err.Error())
+}
+
+// SetProgram sets the tea.Program that receives the results of runs
+// started through the API, and queue changes made through it
+func (s *Server) SetProgram(p *tea.Program) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.program = p
+}
+
+// send passes a message to the TUI, if there is one
+func (s *Server) send(msg tea.Msg) {
+	s.mu.RLock()
+	p := s.program
+	s.mu.RUnlock()
+	if p != nil && msg != nil {
+		p.Send(msg)
+	}
+}
+
+// run runs an executor command in the background and hands its result to
+// the TUI, as Bubble Tea would for a command returned from Update
+func (s *Server) run(cmd tea.Cmd) {
+	go func() { s.send(cmd()) }()
+}
+
+// queueChanged tells the TUI the queue was edited through the API
+func (s *Server) queueChanged() {
+	s.send(messages.QueueUpdatedMsg{Queue: s.batchExecutor.GetQueue()})
+}
+
+// startQueue starts running the pending stories in the queue
+func (s *Server) startQueue() error {
+	if !s.batchExecutor.GetQueue().HasPending() {
+		return newControlError(http.StatusBadRequest, "no items in queue")
+	}
+	if s.batchExecutor.IsRunning() {
+		return newControlError(http.StatusConflict, "execution already running")
+	}
+	s.run(s.batchExecutor.Start())
+	return nil
+}
+
+// startStory runs one story outside the queue
+func (s *Server) startStory(key string) error {
+	story, ok := s.findStory(key)
+	if !ok {
+		return newControlError(http.StatusNotFound, "story not found")
+	}
+	if executor.IsStoryRunning(story.Key) {
+		return newControlError(http.StatusConflict, "story %s is already running", story.Key)
+	}
+	if exec := s.executor.GetExecution(); exec != nil && exec.Status == domain.ExecutionRunning {
+		return newControlError(http.StatusConflict, "execution already running")
+	}
+	s.run(s.executor.Execute(story))
+	return nil
+}
+
+// pause pauses the queue, or the story running on its own
+func (s *Server) pause() error {
+	if s.batchExecutor.IsRunning() {
+		s.batchExecutor.Pause()
+	} else if exec := s.executor.GetExecution(); exec != nil && exec.Status == domain.ExecutionRunning {
+		s.executor.Pause()
+	} else {
+		return newControlError(http.StatusBadRequest, "no execution running")
+	}
+	return nil
+}
+
+// resume resumes what pause paused
+func (s *Server) resume() error {
+	if s.batchExecutor.IsPaused() {
+		s.batchExecutor.Resume()
+	} else if exec := s.executor.GetExecution(); exec != nil && exec.Status == domain.ExecutionPaused {
+		s.executor.Resume()
+	} else {
+		return newControlError(http.StatusBadRequest, "no execution paused")
+	}
+	return nil
+}
+
+// cancel cancels the queue, or the story running on its own
+func (s *Server) cancel() error {
+	if s.batchExecutor.IsRunning() {
+		s.batchExecutor.Cancel()
+	} else if exec := s.executor.GetExecution(); exec != nil {
+		s.executor.Cancel()
+	} else {
+		return newControlError(http.StatusBadRequest, "no execution to cancel")
+	}
+	return nil
+}
+
+// skip skips the running step
+func (s *Server) skip() error {
+	if exec := s.executor.GetExecution(); exec != nil && exec.Status == domain.ExecutionRunning {
+		s.executor.Skip()
+		return nil
+	}
+	return newControlError(http.StatusBadRequest, "no step to skip")
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+//go:embed web/index.html
+var dashboardHTML []byte
+
+// executionRoute is the dashboard fragment that shows one execution
+const executionRoute = "#/executions/"
+
+// ExecutionLink returns the dashboard deep link for an execution
+func ExecutionLink(port int, id string) string {
+	return fmt.Sprintf("http://localhost:%d/%s%s", port, executionRoute, url.PathEscape(id))
+}
+
+// ParseExecutionRef returns the execution ID from a dashboard deep link, or
+// ref unchanged when it is already a plain ID
+func ParseExecutionRef(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if i := strings.Index(ref, executionRoute); i >= 0 {
+		id := strings.TrimRight(ref[i+len(executionRoute):], "/")
+		if unescaped, err := url.PathUnescape(id); err == nil {
+			return unescaped
+		}
+		return id
+	}
+	return ref
+}
+
+// dashboardHandler serves the read-only history dashboard. The page itself
+// is public; its data comes from the /api routes, which keep their API key
+// and rate limits.
+func (s *Server) dashboardHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'unsafe-inline'; style-src 'unsafe-inline'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, _ = w.Write(dashboardHTML)
+}
+
+// SetTheme sets the theme served to the dashboard, so the browser matches
+// the terminal
+func (s *Server) SetTheme(t theme.Theme) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.theme = t
+}
+
+// getThemeHandler returns the active TUI theme's name and colors
+func (s *Server) getThemeHandler(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	t := s.theme
+	s.mu.RUnlock()
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"name":   t.Name,
+		"colors": t.Palette(),
+	})
+}
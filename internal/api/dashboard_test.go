@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+func TestExecutionLink(t *testing.T) {
+	link := ExecutionLink(8080, "6f1c2a9e-4b7d")
+
+	assert.Equal(t, "http://localhost:8080/#/executions/6f1c2a9e-4b7d", link)
+	assert.Equal(t, "6f1c2a9e-4b7d", ParseExecutionRef(link))
+}
+
+func TestParseExecutionRef(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"6f1c2a9e", "6f1c2a9e"},
+		{"  6f1c2a9e \n", "6f1c2a9e"},
+		{"http://localhost:9000/#/executions/abc-123", "abc-123"},
+		{"http://localhost:9000/#/executions/abc-123/", "abc-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseExecutionRef(tt.ref))
+		})
+	}
+}
+
+func TestDashboardRoutes(t *testing.T) {
+	cfg := config.New()
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	execution := domain.NewExecution(domain.Story{Key: "3-1-test", Epic: 3})
+	execution.Status = domain.ExecutionFailed
+	require.NoError(t, store.SaveExecution(context.Background(), execution))
+
+	server := NewServer(cfg, store, executor.New(cfg), executor.NewBatchExecutor(cfg))
+	router := server.setupRoutes()
+
+	t.Run("serves the dashboard page", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, rr.Body.String(), "#/executions/")
+	})
+
+	t.Run("history accepts a shortened ID", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/history/"+domain.ShortID(execution.ID), nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), execution.ID)
+	})
+
+	t.Run("serves the active theme", func(t *testing.T) {
+		server.SetTheme(theme.Nord)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/theme", nil))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		var body struct {
+			Name   string            `json:"name"`
+			Colors map[string]string `json:"colors"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		assert.Equal(t, theme.Nord.Name, body.Name)
+		assert.Equal(t, string(theme.Nord.Background), body.Colors["background"])
+		assert.Equal(t, string(theme.Nord.Primary), body.Colors["primary"])
+	})
+}
@@ -0,0 +1,386 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/pkg/bmadrpc"
+)
+
+// outputBuffer is how many lines a slow StreamOutput client may fall behind
+// before lines are dropped for it
+const outputBuffer = 256
+
+// outputStreams fans step output out to StreamOutput calls
+type outputStreams struct {
+	mu   sync.Mutex
+	subs map[chan bmadrpc.OutputLine]string // channel -> execution ID, "" for all
+}
+
+func (o *outputStreams) subscribe(executionID string) chan bmadrpc.OutputLine {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.subs == nil {
+		o.subs = make(map[chan bmadrpc.OutputLine]string)
+	}
+	ch := make(chan bmadrpc.OutputLine, outputBuffer)
+	o.subs[ch] = executionID
+	return ch
+}
+
+func (o *outputStreams) unsubscribe(ch chan bmadrpc.OutputLine) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.subs, ch)
+}
+
+// publish hands line to every matching subscriber without blocking the
+// executor on a slow client
+func (o *outputStreams) publish(line bmadrpc.OutputLine) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for ch, executionID := range o.subs {
+		if executionID != "" && executionID != line.ExecutionID {
+			continue
+		}
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+// newGRPCServer builds the gRPC server for the control API
+func (s *Server) newGRPCServer() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcUnaryAuth(s.keys), s.grpcStartLimit),
+		grpc.ChainStreamInterceptor(grpcStreamAuth(s.keys)),
+	)
+	bmadrpc.RegisterControlServer(srv, &controlService{s: s})
+	return srv
+}
+
+// startGRPC listens on port and serves the control API in the background
+func (s *Server) startGRPC(port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC: %w", err)
+	}
+	srv := s.newGRPCServer()
+	s.mu.Lock()
+	s.grpc = srv
+	s.mu.Unlock()
+	go func() { _ = srv.Serve(lis) }()
+	return nil
+}
+
+// grpcReadMethods are the control API methods a read-only key may call
+var grpcReadMethods = map[string]bool{
+	"ListStories":  true,
+	"GetQueue":     true,
+	"GetExecution": true,
+	"StreamOutput": true,
+}
+
+// grpcStartMethods are the control API methods that start runs. They share
+// the per-client budget of the REST start routes.
+var grpcStartMethods = map[string]bool{
+	"StartQueue": true,
+	"StartStory": true,
+}
+
+// grpcRequestKey returns the API key a call carries, mirroring requestKey
+func grpcRequestKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(bmadrpc.APIKeyHeader); len(values) > 0 {
+		return values[0]
+	}
+	if auth := md.Get("authorization"); len(auth) > 0 && strings.HasPrefix(auth[0], "Bearer ") {
+		return strings.TrimPrefix(auth[0], "Bearer ")
+	}
+	return ""
+}
+
+// grpcRateLimitClient names the client a call counts against, like
+// rateLimitClient: its API key when it carries a known one, and otherwise
+// the address it came from
+func (s *Server) grpcRateLimitClient(ctx context.Context) string {
+	if key, ok := s.keys.Authenticate(grpcRequestKey(ctx)); ok {
+		return "key:" + key.Name
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return "ip:" + host
+		}
+		return "ip:" + p.Addr.String()
+	}
+	return "ip:"
+}
+
+// grpcStartLimit rejects start calls with ResourceExhausted once their
+// client has used up the start budget
+func (s *Server) grpcStartLimit(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if grpcStartMethods[path.Base(info.FullMethod)] {
+		if delay := s.starts.reserve(s.grpcRateLimitClient(ctx)); delay > 0 {
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry in %ds", int(math.Ceil(delay.Seconds())))
+		}
+	}
+	return handler(ctx, req)
+}
+
+// grpcAuthorize checks that the call carries a key allowed to call method,
+// in the x-api-key metadata or as a bearer token, mirroring
+// apiKeyAuthMiddleware
+func grpcAuthorize(ctx context.Context, keys *KeyStore, fullMethod string) error {
+	provided := grpcRequestKey(ctx)
+
+	need := RoleControl
+	if grpcReadMethods[path.Base(fullMethod)] {
+		need = RoleRead
+	}
+	ok, unauthenticated := keys.Authorize(provided, need)
+	if unauthenticated {
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	if !ok {
+		return status.Error(codes.PermissionDenied, "forbidden")
+	}
+	return nil
+}
+
+func grpcUnaryAuth(keys *KeyStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := grpcAuthorize(ctx, keys, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func grpcStreamAuth(keys *KeyStore) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := grpcAuthorize(ss.Context(), keys, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// grpcError maps a control error to the gRPC status matching its HTTP one
+func grpcError(err error) error {
+	var ce *controlError
+	if !errors.As(err, &ce) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	code := codes.FailedPrecondition
+	switch ce.status {
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	}
+	return status.Error(code, ce.message)
+}
+
+// controlService implements bmadrpc.ControlServer on top of the server
+type controlService struct {
+	s *Server
+}
+
+func (c *controlService) ListStories(_ context.Context, req *bmadrpc.ListStoriesRequest) (*bmadrpc.ListStoriesReply, error) {
+	c.s.mu.RLock()
+	defer c.s.mu.RUnlock()
+	reply := &bmadrpc.ListStoriesReply{Stories: make([]bmadrpc.Story, 0, len(c.s.stories))}
+	for _, story := range c.s.stories {
+		if req.Epic != 0 && story.Epic != req.Epic {
+			continue
+		}
+		if req.Status != "" && string(story.Status) != req.Status {
+			continue
+		}
+		reply.Stories = append(reply.Stories, rpcStory(story))
+	}
+	return reply, nil
+}
+
+func (c *controlService) GetQueue(context.Context, *bmadrpc.Empty) (*bmadrpc.Queue, error) {
+	return c.queue(), nil
+}
+
+func (c *controlService) AddToQueue(_ context.Context, req *bmadrpc.KeysRequest) (*bmadrpc.Queue, error) {
+	stories := make([]domain.Story, 0, len(req.Keys))
+	for _, key := range req.Keys {
+		story, ok := c.s.findStory(key)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "story not found: %s", key)
+		}
+		stories = append(stories, story)
+	}
+	if len(stories) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no stories given")
+	}
+	c.s.batchExecutor.AddToQueue(stories)
+	c.s.queueChanged()
+	return c.queue(), nil
+}
+
+func (c *controlService) RemoveFromQueue(_ context.Context, req *bmadrpc.KeyRequest) (*bmadrpc.Queue, error) {
+	if !c.s.batchExecutor.RemoveFromQueue(req.Key) {
+		return nil, status.Errorf(codes.NotFound, "story not pending in queue: %s", req.Key)
+	}
+	return c.queue(), nil
+}
+
+func (c *controlService) ClearQueue(context.Context, *bmadrpc.Empty) (*bmadrpc.Queue, error) {
+	c.s.batchExecutor.ClearQueue()
+	return c.queue(), nil
+}
+
+func (c *controlService) MoveQueueItem(_ context.Context, req *bmadrpc.MoveRequest) (*bmadrpc.Queue, error) {
+	switch req.Direction {
+	case bmadrpc.MoveUp:
+		c.s.batchExecutor.MoveUp(req.Index)
+	case bmadrpc.MoveDown:
+		c.s.batchExecutor.MoveDown(req.Index)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid direction %q", req.Direction)
+	}
+	return c.queue(), nil
+}
+
+func (c *controlService) StartQueue(context.Context, *bmadrpc.Empty) (*bmadrpc.Empty, error) {
+	return c.control(c.s.startQueue())
+}
+
+func (c *controlService) StartStory(_ context.Context, req *bmadrpc.KeyRequest) (*bmadrpc.Empty, error) {
+	return c.control(c.s.startStory(req.Key))
+}
+
+func (c *controlService) Pause(context.Context, *bmadrpc.Empty) (*bmadrpc.Empty, error) {
+	return c.control(c.s.pause())
+}
+
+func (c *controlService) Resume(context.Context, *bmadrpc.Empty) (*bmadrpc.Empty, error) {
+	return c.control(c.s.resume())
+}
+
+func (c *controlService) Cancel(context.Context, *bmadrpc.Empty) (*bmadrpc.Empty, error) {
+	return c.control(c.s.cancel())
+}
+
+func (c *controlService) SkipStep(context.Context, *bmadrpc.Empty) (*bmadrpc.Empty, error) {
+	return c.control(c.s.skip())
+}
+
+func (c *controlService) GetExecution(context.Context, *bmadrpc.Empty) (*bmadrpc.Execution, error) {
+	exec := c.s.executor.GetExecution()
+	if exec == nil {
+		return &bmadrpc.Execution{}, nil
+	}
+	return rpcExecution(exec), nil
+}
+
+func (c *controlService) StreamOutput(req *bmadrpc.StreamOutputRequest, stream bmadrpc.OutputStreamServer) error {
+	ch := c.s.outputs.subscribe(req.ExecutionID)
+	defer c.s.outputs.unsubscribe(ch)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case line := <-ch:
+			if err := stream.Send(&line); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *controlService) queue() *bmadrpc.Queue {
+	return rpcQueue(c.s.batchExecutor.GetQueue())
+}
+
+func (c *controlService) control(err error) (*bmadrpc.Empty, error) {
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &bmadrpc.Empty{}, nil
+}
+
+func rpcStory(story domain.Story) bmadrpc.Story {
+	return bmadrpc.Story{Key: story.Key, Epic: story.Epic, Status: string(story.Status), Title: story.Title}
+}
+
+func rpcQueue(queue *domain.Queue) *bmadrpc.Queue {
+	out := &bmadrpc.Queue{
+		Status:  string(queue.Status),
+		Current: queue.Current,
+		Items:   make([]bmadrpc.QueueItem, 0, len(queue.Items)),
+		Pending: queue.PendingCount(),
+		ETA:     queue.EstimatedTimeRemaining(),
+	}
+	for _, item := range queue.Items {
+		rpcItem := bmadrpc.QueueItem{
+			Story:    rpcStory(item.Story),
+			Status:   string(item.Status),
+			Position: item.Position,
+			AddedAt:  item.AddedAt,
+			Deadline: queue.DeadlineOf(item),
+		}
+		if item.Execution != nil {
+			rpcItem.ExecutionID = item.Execution.ID
+		}
+		out.Items = append(out.Items, rpcItem)
+	}
+	return out
+}
+
+func rpcExecution(exec *domain.Execution) *bmadrpc.Execution {
+	out := &bmadrpc.Execution{
+		ID:        exec.ID,
+		Running:   exec.Status == domain.ExecutionRunning,
+		Status:    string(exec.Status),
+		Story:     rpcStory(exec.Story),
+		Current:   exec.Current,
+		StartTime: exec.StartTime,
+		Progress:  exec.ProgressPercent(),
+	}
+	for _, step := range exec.Steps {
+		out.Steps = append(out.Steps, bmadrpc.Step{
+			Name:     string(step.Name),
+			Status:   string(step.Status),
+			Attempt:  step.Attempt,
+			Duration: step.Duration,
+			Error:    step.Error,
+		})
+	}
+	return out
+}
+
+// rpcOutputLine converts a line broadcast over the WebSocket
+func rpcOutputLine(data StepOutputData) bmadrpc.OutputLine {
+	return bmadrpc.OutputLine{
+		ExecutionID: data.ExecutionID,
+		StoryKey:    data.StoryKey,
+		StepIndex:   data.StepIndex,
+		StepName:    data.StepName,
+		Line:        data.Line,
+		IsStderr:    data.IsStderr,
+		Time:        time.Now(),
+	}
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/pkg/bmadrpc"
+)
+
+// serveGRPC serves the control API on a free local port and returns the
+// address to dial
+func serveGRPC(t *testing.T, server *Server) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := server.newGRPCServer()
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func TestGRPCControl(t *testing.T) {
+	cfg := config.New()
+	cfg.DataDir = t.TempDir()
+	cfg.APIKey = "secret"
+	server := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg))
+	server.SetStories([]domain.Story{
+		{Key: "3-1-first", Epic: 3, Status: domain.StatusReadyForDev},
+		{Key: "4-1-other", Epic: 4, Status: domain.StatusBacklog},
+	})
+	addr := serveGRPC(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, conn, err := bmadrpc.Dial(addr, "secret")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	t.Run("rejects calls without the API key", func(t *testing.T) {
+		anon, anonConn, err := bmadrpc.Dial(addr, "")
+		require.NoError(t, err)
+		defer anonConn.Close()
+
+		_, err = anon.GetQueue(ctx)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("read keys cannot control", func(t *testing.T) {
+		_, secret, err := server.keys.Create("viewer", RoleRead)
+		require.NoError(t, err)
+		viewer, viewerConn, err := bmadrpc.Dial(addr, secret)
+		require.NoError(t, err)
+		defer viewerConn.Close()
+
+		_, err = viewer.GetQueue(ctx)
+		assert.NoError(t, err)
+		_, err = viewer.AddToQueue(ctx, "3-1-first")
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("lists stories by epic", func(t *testing.T) {
+		stories, err := client.ListStories(ctx, &bmadrpc.ListStoriesRequest{Epic: 3})
+		require.NoError(t, err)
+		require.Len(t, stories, 1)
+		assert.Equal(t, "3-1-first", stories[0].Key)
+		assert.Equal(t, string(domain.StatusReadyForDev), stories[0].Status)
+	})
+
+	t.Run("manages the queue", func(t *testing.T) {
+		queue, err := client.AddToQueue(ctx, "3-1-first", "4-1-other")
+		require.NoError(t, err)
+		require.Len(t, queue.Items, 2)
+		assert.Equal(t, 2, queue.Pending)
+
+		queue, err = client.MoveQueueItem(ctx, 1, bmadrpc.MoveUp)
+		require.NoError(t, err)
+		assert.Equal(t, "4-1-other", queue.Items[0].Story.Key)
+
+		queue, err = client.RemoveFromQueue(ctx, "4-1-other")
+		require.NoError(t, err)
+		assert.Len(t, queue.Items, 1)
+
+		_, err = client.AddToQueue(ctx, "9-9-missing")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = client.MoveQueueItem(ctx, 0, "sideways")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("maps control errors", func(t *testing.T) {
+		err := client.Pause(ctx)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		err = client.StartStory(ctx, "9-9-missing")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		exec, err := client.GetExecution(ctx)
+		require.NoError(t, err)
+		assert.False(t, exec.Running)
+		assert.Empty(t, exec.ID)
+	})
+
+	t.Run("streams output of the chosen execution", func(t *testing.T) {
+		stream, err := client.StreamOutput(ctx, "exec-1")
+		require.NoError(t, err)
+
+		// The subscription is registered once the server has the request,
+		// so keep broadcasting until a line arrives
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			for {
+				server.BroadcastStepOutput(StepOutputData{ExecutionID: "exec-2", Line: "other"})
+				server.BroadcastStepOutput(StepOutputData{ExecutionID: "exec-1", StoryKey: "3-1-first", Line: "hello"})
+				select {
+				case <-done:
+					return
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+		}()
+
+		line, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "exec-1", line.ExecutionID)
+		assert.Equal(t, "3-1-first", line.StoryKey)
+		assert.Equal(t, "hello", line.Line)
+	})
+}
+
+func TestGRPCCodecRegistration(t *testing.T) {
+	assert.IsType(t, bmadrpc.Codec{}, encoding.GetCodec(bmadrpc.CodecName))
+	if codec := encoding.GetCodec("json"); codec != nil {
+		assert.NotEqual(t, bmadrpc.Codec{}, codec)
+	}
+}
+
+func TestGRPCStartRateLimit(t *testing.T) {
+	cfg := config.New()
+	cfg.DataDir = t.TempDir()
+	cfg.APIKey = "secret"
+	cfg.APIStartRateLimit = 1
+	server := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg))
+	addr := serveGRPC(t, server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, conn, err := bmadrpc.Dial(addr, "secret")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The first start uses the budget, even though the story is unknown
+	err = client.StartStory(ctx, "9-9-missing")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	err = client.StartStory(ctx, "9-9-missing")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	err = client.StartQueue(ctx)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), "starts share one budget")
+
+	_, err = client.GetQueue(ctx)
+	assert.NoError(t, err, "other calls are not limited")
+
+	// Another key has a budget of its own
+	_, secret, err := server.keys.Create("ci", RoleControl)
+	require.NoError(t, err)
+	other, otherConn, err := bmadrpc.Dial(addr, secret)
+	require.NoError(t, err)
+	defer otherConn.Close()
+	err = other.StartQueue(ctx)
+	assert.NotEqual(t, codes.ResourceExhausted, status.Code(err))
+}
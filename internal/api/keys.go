@@ -0,0 +1,343 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// KeysFileName is the API keys file in the data directory
+const KeysFileName = "api-keys.json"
+
+// DefaultKeyName is the name of the key set with BMAD_API_KEY
+const DefaultKeyName = "default"
+
+// Role is what an API key may do
+type Role string
+
+const (
+	// RoleRead may read stories, the queue, history and statistics
+	RoleRead Role = "read"
+	// RoleControl may also change the queue, control executions and
+	// manage API keys
+	RoleControl Role = "control"
+)
+
+// Allows reports whether a key with role r may use a route needing role
+func (r Role) Allows(need Role) bool {
+	return r == RoleControl || r == need
+}
+
+// ParseRole parses a role name
+func ParseRole(s string) (Role, error) {
+	switch Role(s) {
+	case RoleRead, RoleControl:
+		return Role(s), nil
+	}
+	return "", fmt.Errorf("unknown role %q (use %s or %s)", s, RoleRead, RoleControl)
+}
+
+// Errors for key names that are not, or already, defined
+var (
+	ErrKeyNotFound = errors.New("API key not found")
+	ErrKeyExists   = errors.New("API key already exists")
+)
+
+var validKeyName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// APIKey is a named key. Only a hash of the secret is stored; the secret
+// itself is shown once, when the key is created.
+type APIKey struct {
+	Name      string    `json:"name"`
+	Role      Role      `json:"role"`
+	Prefix    string    `json:"prefix"` // First characters of the secret, to tell keys apart
+	Hash      string    `json:"hash"`   // Hex SHA-256 of the secret
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// KeyStore holds the API keys: the one set with BMAD_API_KEY, which has
+// the control role, and the named keys in <data dir>/api-keys.json. With
+// no keys at all the API is open.
+type KeyStore struct {
+	mu     sync.RWMutex
+	path   string
+	legacy string
+	keys   []APIKey
+}
+
+// NewKeyStore creates a store for the keys file in dataDir, with legacy as
+// the default key. An empty dataDir keeps named keys in memory only.
+func NewKeyStore(dataDir, legacy string) *KeyStore {
+	st := &KeyStore{legacy: legacy}
+	if dataDir != "" {
+		st.path = filepath.Join(dataDir, KeysFileName)
+	}
+	return st
+}
+
+// Load reads the keys file. A missing file means no named keys.
+func (st *KeyStore) Load() error {
+	if st.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(st.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read API keys: %w", err)
+	}
+
+	var keys []APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", st.path, err)
+	}
+	for _, k := range keys {
+		if _, err := ParseRole(string(k.Role)); err != nil {
+			return fmt.Errorf("%s: key %s: %w", st.path, k.Name, err)
+		}
+	}
+
+	st.mu.Lock()
+	st.keys = keys
+	st.mu.Unlock()
+	return nil
+}
+
+// save writes the keys file, readable by the owner only; callers hold st.mu
+func (st *KeyStore) save() error {
+	if st.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(st.keys, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal API keys: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := os.WriteFile(st.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write API keys: %w", err)
+	}
+	return nil
+}
+
+// Enabled reports whether any key is defined, so requests must carry one
+func (st *KeyStore) Enabled() bool {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+	return st.legacy != "" || len(st.keys) > 0
+}
+
+// Authenticate returns the key whose secret is secret
+func (st *KeyStore) Authenticate(secret string) (APIKey, bool) {
+	if secret == "" {
+		return APIKey{}, false
+	}
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	if st.legacy != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(st.legacy)) == 1 {
+		return APIKey{Name: DefaultKeyName, Role: RoleControl}, true
+	}
+	hash := hashSecret(secret)
+	for _, k := range st.keys {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(k.Hash)) == 1 {
+			return k, true
+		}
+	}
+	return APIKey{}, false
+}
+
+// Authorize reports whether a request carrying secret may use a route
+// needing role, and whether it failed for lack of a valid key (rather than
+// of permission)
+func (st *KeyStore) Authorize(secret string, need Role) (ok, unauthenticated bool) {
+	if !st.Enabled() {
+		return true, false
+	}
+	key, found := st.Authenticate(secret)
+	if !found {
+		return false, true
+	}
+	return key.Role.Allows(need), false
+}
+
+// List returns the named keys sorted by name, plus the default key when
+// set
+func (st *KeyStore) List() []APIKey {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	list := make([]APIKey, 0, len(st.keys)+1)
+	if st.legacy != "" {
+		list = append(list, APIKey{Name: DefaultKeyName, Role: RoleControl})
+	}
+	list = append(list, st.keys...)
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Create adds a key and returns it with its secret
+func (st *KeyStore) Create(name string, role Role) (APIKey, string, error) {
+	if !validKeyName.MatchString(name) {
+		return APIKey{}, "", fmt.Errorf("key name %q: use lowercase letters, digits, - and _", name)
+	}
+	if _, err := ParseRole(string(role)); err != nil {
+		return APIKey{}, "", err
+	}
+
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return APIKey{}, "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	secret := "bmad_" + hex.EncodeToString(buf)
+	key := APIKey{
+		Name:      name,
+		Role:      role,
+		Prefix:    secret[:9],
+		Hash:      hashSecret(secret),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.index(name) >= 0 || (name == DefaultKeyName && st.legacy != "") {
+		return APIKey{}, "", fmt.Errorf("%w: %s", ErrKeyExists, name)
+	}
+	st.keys = append(st.keys, key)
+	if err := st.save(); err != nil {
+		st.keys = st.keys[:len(st.keys)-1]
+		return APIKey{}, "", err
+	}
+	return key, secret, nil
+}
+
+// Revoke deletes a named key. The default key can only be removed by
+// unsetting BMAD_API_KEY.
+func (st *KeyStore) Revoke(name string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	i := st.index(name)
+	if i < 0 {
+		if name == DefaultKeyName && st.legacy != "" {
+			return fmt.Errorf("the default key is set with BMAD_API_KEY")
+		}
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, name)
+	}
+	removed := st.keys[i]
+	st.keys = append(st.keys[:i], st.keys[i+1:]...)
+	if err := st.save(); err != nil {
+		st.keys = append(st.keys[:i], append([]APIKey{removed}, st.keys[i:]...)...)
+		return err
+	}
+	return nil
+}
+
+func (st *KeyStore) index(name string) int {
+	for i, k := range st.keys {
+		if k.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// keyResponse is an API key as listed, without its hash
+type keyResponse struct {
+	Name      string     `json:"name"`
+	Role      Role       `json:"role"`
+	Prefix    string     `json:"prefix,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Key       string     `json:"key,omitempty"` // The secret, only when created
+}
+
+func newKeyResponse(k APIKey) keyResponse {
+	resp := keyResponse{Name: k.Name, Role: k.Role, Prefix: k.Prefix}
+	if !k.CreatedAt.IsZero() {
+		resp.CreatedAt = &k.CreatedAt
+	}
+	return resp
+}
+
+func (s *Server) listKeysHandler(w http.ResponseWriter, r *http.Request) {
+	keys := s.keys.List()
+	response := make([]keyResponse, 0, len(keys))
+	for _, k := range keys {
+		response = append(response, newKeyResponse(k))
+	}
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"keys":  response,
+		"count": len(response),
+	})
+}
+
+// createKeyHandler creates a key and returns its secret, which cannot be
+// shown again
+func (s *Server) createKeyHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+		Role string `json:"role"`
+	}
+	if err := decodeJSONBody(w, r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	role, err := ParseRole(req.Role)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	key, secret, err := s.keys.Create(req.Name, role)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrKeyExists) {
+			status = http.StatusConflict
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+	response := newKeyResponse(key)
+	response.Key = secret
+	respondJSON(w, http.StatusCreated, response)
+}
+
+func (s *Server) revokeKeyHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	// SEC-012: Validate path parameter
+	if err := validatePathParam(name); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.keys.Revoke(name); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrKeyNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{"status": "revoked", "name": name})
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+)
+
+func TestKeyStore(t *testing.T) {
+	dir := t.TempDir()
+	keys := NewKeyStore(dir, "legacy-secret")
+	require.NoError(t, keys.Load())
+
+	key, secret, err := keys.Create("ci", RoleRead)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, key.Prefix))
+	assert.NotContains(t, key.Hash, secret)
+
+	_, _, err = keys.Create("ci", RoleControl)
+	assert.ErrorIs(t, err, ErrKeyExists)
+	_, _, err = keys.Create(DefaultKeyName, RoleRead)
+	assert.ErrorIs(t, err, ErrKeyExists, "the name of BMAD_API_KEY is taken")
+	_, _, err = keys.Create("Bad Name", RoleRead)
+	assert.Error(t, err)
+	_, _, err = keys.Create("admin", Role("owner"))
+	assert.Error(t, err)
+
+	info, err := os.Stat(filepath.Join(dir, KeysFileName))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err := os.ReadFile(filepath.Join(dir, KeysFileName))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), secret, "only the hash is stored")
+
+	reloaded := NewKeyStore(dir, "legacy-secret")
+	require.NoError(t, reloaded.Load())
+	got, ok := reloaded.Authenticate(secret)
+	require.True(t, ok)
+	assert.Equal(t, "ci", got.Name)
+	assert.Equal(t, RoleRead, got.Role)
+
+	got, ok = reloaded.Authenticate("legacy-secret")
+	require.True(t, ok)
+	assert.Equal(t, RoleControl, got.Role)
+	_, ok = reloaded.Authenticate("wrong")
+	assert.False(t, ok)
+
+	ok, unauthenticated := reloaded.Authorize(secret, RoleControl)
+	assert.False(t, ok)
+	assert.False(t, unauthenticated, "a read key is known but not allowed")
+	ok, unauthenticated = reloaded.Authorize("", RoleRead)
+	assert.False(t, ok)
+	assert.True(t, unauthenticated)
+
+	names := []string{}
+	for _, k := range reloaded.List() {
+		names = append(names, k.Name)
+	}
+	assert.Equal(t, []string{"ci", DefaultKeyName}, names)
+
+	assert.Error(t, reloaded.Revoke(DefaultKeyName))
+	assert.ErrorIs(t, reloaded.Revoke("missing"), ErrKeyNotFound)
+	require.NoError(t, reloaded.Revoke("ci"))
+	_, ok = reloaded.Authenticate(secret)
+	assert.False(t, ok)
+}
+
+func TestKeyStore_OpenWithoutKeys(t *testing.T) {
+	keys := NewKeyStore(t.TempDir(), "")
+	require.NoError(t, keys.Load())
+	assert.False(t, keys.Enabled())
+	ok, _ := keys.Authorize("", RoleControl)
+	assert.True(t, ok)
+}
+
+func TestKeyStore_LoadRejectsUnknownRoles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, KeysFileName), []byte(`[{"name":"x","role":"owner","hash":"00"}]`), 0600))
+	assert.Error(t, NewKeyStore(dir, "").Load())
+}
+
+func TestKeyRoutes(t *testing.T) {
+	cfg := config.New()
+	cfg.DataDir = t.TempDir()
+	cfg.APIKey = "admin-secret"
+	router := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg)).setupRoutes()
+
+	do := func(method, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := do(http.MethodPost, "/api/keys", "admin-secret", `{"name":"dashboard","role":"read"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var created keyResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	require.NotEmpty(t, created.Key)
+
+	t.Run("read keys read", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, "/api/queue", created.Key, "").Code)
+		assert.Equal(t, http.StatusOK, do(http.MethodGet, "/metrics", created.Key, "").Code)
+	})
+
+	t.Run("read keys cannot control", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/queue/clear", created.Key, "").Code)
+		assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/api/execution/pause", created.Key, "").Code)
+		assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/api/keys", created.Key, "").Code)
+	})
+
+	t.Run("unknown keys are unauthorized", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/queue", "", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/queue", "nope", "").Code)
+	})
+
+	t.Run("lists keys without secrets", func(t *testing.T) {
+		rr := do(http.MethodGet, "/api/keys", "admin-secret", "")
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"name":"dashboard"`)
+		assert.NotContains(t, rr.Body.String(), created.Key)
+		assert.NotContains(t, rr.Body.String(), "hash")
+	})
+
+	t.Run("rejects duplicate names and unknown roles", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/api/keys", "admin-secret", `{"name":"dashboard","role":"read"}`).Code)
+		assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/api/keys", "admin-secret", `{"name":"other","role":"owner"}`).Code)
+	})
+
+	t.Run("revokes keys", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, do(http.MethodDelete, "/api/keys/dashboard", "admin-secret", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/api/queue", created.Key, "").Code)
+		assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/api/keys/dashboard", "admin-secret", "").Code)
+	})
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// metricsContentType is the Prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// metricsHandler serves counters and histograms in the Prometheus text
+// format. Totals are read from history on every scrape, so they survive
+// restarts and include runs started with "bmad run".
+func (s *Server) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+
+	if store := s.getStorage(); store != nil {
+		m, err := store.GetMetrics(r.Context())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeHistoryMetrics(&buf, m)
+	}
+
+	queue := s.batchExecutor.GetQueue()
+	items := make(map[domain.ExecutionStatus]int)
+	for _, item := range queue.Items {
+		items[item.Status]++
+	}
+	writeMetricHeader(&buf, "bmad_queue_depth", "gauge", "Stories waiting in the queue.")
+	fmt.Fprintf(&buf, "bmad_queue_depth %d\n", queue.PendingCount())
+	writeMetricHeader(&buf, "bmad_queue_items", "gauge", "Stories in the queue, by status.")
+	for _, status := range sortedKeys(items) {
+		fmt.Fprintf(&buf, "bmad_queue_items{status=%q} %d\n", status, items[domain.ExecutionStatus(status)])
+	}
+
+	running := 0
+	if exec := s.executor.GetExecution(); s.batchExecutor.IsRunning() ||
+		(exec != nil && (exec.Status == domain.ExecutionRunning || exec.Status == domain.ExecutionPaused)) {
+		running = 1
+	}
+	writeMetricHeader(&buf, "bmad_execution_running", "gauge", "1 while a story or the queue is executing.")
+	fmt.Fprintf(&buf, "bmad_execution_running %d\n", running)
+
+	w.Header().Set("Content-Type", metricsContentType)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// writeHistoryMetrics writes the execution and step totals from history
+func writeHistoryMetrics(buf *bytes.Buffer, m *storage.Metrics) {
+	writeMetricHeader(buf, "bmad_executions_total", "counter", "Executions recorded in history, by final status.")
+	for _, status := range sortedKeys(m.Executions) {
+		fmt.Fprintf(buf, "bmad_executions_total{status=%q} %d\n", status, m.Executions[domain.ExecutionStatus(status)])
+	}
+
+	steps := sortedKeys(m.Steps)
+
+	writeMetricHeader(buf, "bmad_steps_total", "counter", "Step runs recorded in history, by step and final status.")
+	for _, step := range steps {
+		runs := m.Steps[domain.StepName(step)].Runs
+		for _, status := range sortedKeys(runs) {
+			fmt.Fprintf(buf, "bmad_steps_total{step=%q,status=%q} %d\n", step, status, runs[domain.StepStatus(status)])
+		}
+	}
+
+	writeMetricHeader(buf, "bmad_step_retries_total", "counter", "Step attempts beyond the first, by step.")
+	for _, step := range steps {
+		fmt.Fprintf(buf, "bmad_step_retries_total{step=%q} %d\n", step, m.Steps[domain.StepName(step)].Retries)
+	}
+
+	writeMetricHeader(buf, "bmad_step_duration_seconds", "histogram", "Duration of step runs that were not skipped, by step.")
+	for _, step := range steps {
+		sm := m.Steps[domain.StepName(step)]
+		for i, bound := range storage.MetricsBuckets {
+			le := strconv.FormatFloat(bound.Seconds(), 'f', -1, 64)
+			fmt.Fprintf(buf, "bmad_step_duration_seconds_bucket{step=%q,le=%q} %d\n", step, le, sm.Buckets[i])
+		}
+		fmt.Fprintf(buf, "bmad_step_duration_seconds_bucket{step=%q,le=\"+Inf\"} %d\n", step, sm.Count)
+		fmt.Fprintf(buf, "bmad_step_duration_seconds_sum{step=%q} %s\n", step, strconv.FormatFloat(sm.Duration.Seconds(), 'f', -1, 64))
+		fmt.Fprintf(buf, "bmad_step_duration_seconds_count{step=%q} %d\n", step, sm.Count)
+	}
+}
+
+// writeMetricHeader writes the HELP and TYPE lines of a metric
+func writeMetricHeader(buf *bytes.Buffer, name, kind, help string) {
+	fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// sortedKeys returns the keys of a map with string-like keys in order, so
+// scrapes list series consistently
+func sortedKeys[K ~string, V any](m map[K]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,310 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// apiOperation describes a route for the OpenAPI document. Paths, methods
+// and path parameters come from the router itself; this adds what the
+// router cannot know.
+type apiOperation struct {
+	Summary string
+	Tag     string
+	Role    Role           // Role a key needs; empty for public routes
+	Query   []apiParam     // Query string parameters
+	Body    map[string]any // JSON schema of the request body, if any
+	Status  int            // Success status, 200 when zero
+	Content string         // Success media type, JSON when empty
+	Starts  bool           // Counts against the start rate limit
+	Hidden  bool           // Served but left out of the document
+}
+
+// apiParam is a query string parameter
+type apiParam struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+// pathParams describes the {name} parameters used in route patterns
+var pathParams = map[string]string{
+	"key":  "Story key",
+	"id":   "Execution ID, or a unique prefix of one",
+	"name": "Schedule or API key name",
+}
+
+// apiOperations documents each route, keyed by "METHOD /path"
+var apiOperations = map[string]apiOperation{
+	"GET /":                 {Hidden: true},
+	"GET /api/docs":         {Hidden: true},
+	"GET /api/openapi.json": {Hidden: true},
+	"GET /health":           {Summary: "Check the server is up", Tag: "Health"},
+	"GET /metrics":          {Summary: "Prometheus metrics", Tag: "Health", Role: RoleRead, Content: "text/plain"},
+
+	"GET /api/stories": {Summary: "List stories", Tag: "Stories", Role: RoleRead, Query: []apiParam{
+		{"epic", "integer", "Only stories of this epic"},
+		{"status", "string", "Only stories with this status"},
+	}},
+	"GET /api/stories/{key}":    {Summary: "Get a story", Tag: "Stories", Role: RoleRead},
+	"POST /api/stories/refresh": {Summary: "Reload stories from their source", Tag: "Stories", Role: RoleControl, Starts: true},
+
+	"GET /api/queue": {Summary: "Get the queue", Tag: "Queue", Role: RoleRead},
+	"POST /api/queue/add": {Summary: "Add stories to the queue", Tag: "Queue", Role: RoleControl,
+		Body: objectSchema(map[string]any{"keys": arraySchema("string"), "tags": arraySchema("string")}, "keys")},
+	"POST /api/queue/add/{key}": {Summary: "Add a story to the queue", Tag: "Queue", Role: RoleControl, Query: []apiParam{
+		{"tags", "string", "Comma-separated tags to save its execution with"},
+	}},
+	"DELETE /api/queue/{key}": {Summary: "Remove a story from the queue", Tag: "Queue", Role: RoleControl},
+	"POST /api/queue/clear":   {Summary: "Clear the queue", Tag: "Queue", Role: RoleControl},
+	"POST /api/queue/reorder": {Summary: "Move a queued story up or down", Tag: "Queue", Role: RoleControl,
+		Body: objectSchema(map[string]any{
+			"index":     map[string]any{"type": "integer"},
+			"direction": map[string]any{"type": "string", "enum": []string{"up", "down"}},
+		}, "index", "direction")},
+	"GET /api/queue/eta": {Summary: "Project when queued stories start and finish", Tag: "Queue", Role: RoleRead, Query: []apiParam{
+		{"stories", "string", "Comma-separated story keys to estimate as if added to the queue"},
+	}},
+
+	"GET /api/execution":              {Summary: "Get the current execution", Tag: "Execution", Role: RoleRead},
+	"POST /api/execution/start":       {Summary: "Start running the queue", Tag: "Execution", Role: RoleControl, Starts: true},
+	"POST /api/execution/start/{key}": {Summary: "Run a single story", Tag: "Execution", Role: RoleControl, Starts: true},
+	"POST /api/execution/pause":       {Summary: "Pause the execution", Tag: "Execution", Role: RoleControl},
+	"POST /api/execution/resume":      {Summary: "Resume a paused execution", Tag: "Execution", Role: RoleControl},
+	"POST /api/execution/cancel":      {Summary: "Cancel the execution", Tag: "Execution", Role: RoleControl},
+	"POST /api/execution/skip":        {Summary: "Skip the current step", Tag: "Execution", Role: RoleControl},
+
+	"GET /api/history": {Summary: "List past executions", Tag: "History", Role: RoleRead, Query: []apiParam{
+		{"limit", "integer", "Most executions to return (1-200, default 50)"},
+		{"cursor", "string", "Continue after the page that returned this next_cursor"},
+		{"offset", "integer", "Executions to skip; ignored with cursor"},
+		{"story", "string", "Only executions of this story"},
+		{"epic", "integer", "Only executions of this epic"},
+		{"status", "string", "Only executions with this status"},
+		{"tag", "string", "Only executions with this tag; repeat to require several"},
+	}},
+	"GET /api/history/{id}": {Summary: "Get an execution with its steps and output", Tag: "History", Role: RoleRead},
+
+	"GET /api/stats": {Summary: "Get execution statistics", Tag: "Statistics", Role: RoleRead, Query: []apiParam{
+		{"range", "string", "Only executions started in this range: 14d, YYYY-MM-DD, YYYY-MM-DD..YYYY-MM-DD or all (default)"},
+	}},
+	"GET /api/step-averages": {Summary: "Get average step durations", Tag: "Statistics", Role: RoleRead},
+
+	"GET /api/schedules":             {Summary: "List schedules", Tag: "Schedules", Role: RoleRead},
+	"POST /api/schedules/{name}/run": {Summary: "Run a schedule now", Tag: "Schedules", Role: RoleControl, Starts: true},
+
+	"GET /api/keys": {Summary: "List API keys", Tag: "API Keys", Role: RoleControl},
+	"POST /api/keys": {Summary: "Create an API key; its secret is only returned here", Tag: "API Keys", Role: RoleControl,
+		Status: http.StatusCreated,
+		Body: objectSchema(map[string]any{
+			"name": map[string]any{"type": "string", "pattern": validKeyName.String()},
+			"role": map[string]any{"type": "string", "enum": []Role{RoleRead, RoleControl}},
+		}, "name", "role")},
+	"DELETE /api/keys/{name}": {Summary: "Revoke an API key", Tag: "API Keys", Role: RoleControl},
+
+	"GET /api/config": {Summary: "Get the server configuration", Tag: "Configuration", Role: RoleRead},
+	"GET /api/theme":  {Summary: "Get the active theme's colors", Tag: "Configuration", Role: RoleRead},
+	"GET /api/ws": {Summary: "Stream execution events over a WebSocket", Tag: "Execution", Role: RoleRead,
+		Status: http.StatusSwitchingProtocols, Query: []apiParam{
+			{"api_key", "string", "API key, for clients that cannot set headers"},
+		}},
+}
+
+var routeParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+// openAPIDocument builds an OpenAPI 3 document for the routes of router,
+// failing on any route that apiOperations does not describe
+func (s *Server) openAPIDocument(router chi.Routes) (map[string]any, error) {
+	paths := map[string]map[string]any{}
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = strings.TrimSuffix(route, "/*")
+		if route != "/" {
+			route = strings.TrimSuffix(route, "/")
+		}
+		op, ok := apiOperations[method+" "+route]
+		if !ok {
+			return fmt.Errorf("route %s %s is not documented", method, route)
+		}
+		if op.Hidden {
+			return nil
+		}
+		if paths[route] == nil {
+			paths[route] = map[string]any{}
+		}
+		paths[route][strings.ToLower(method)] = openAPIOperation(method, route, op)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "BMAD Automate API",
+			"version":     s.config.Version,
+			"description": "Control story execution and read history. See docs/api.md for response bodies.",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"apiKey": map[string]any{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"bearer": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": map[string]any{
+				"Error": objectSchema(map[string]any{"error": map[string]any{"type": "string"}}, "error"),
+			},
+		},
+	}, nil
+}
+
+// openAPIOperation describes one method of a path
+func openAPIOperation(method, route string, op apiOperation) map[string]any {
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	content := op.Content
+	if content == "" {
+		content = "application/json"
+	}
+
+	responses := map[string]any{
+		fmt.Sprint(status): map[string]any{
+			"description": http.StatusText(status),
+			"content":     map[string]any{content: map[string]any{}},
+		},
+	}
+	errorResponse := func(code int, description string) {
+		responses[fmt.Sprint(code)] = map[string]any{
+			"description": description,
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": map[string]any{"$ref": "#/components/schemas/Error"},
+			}},
+		}
+	}
+
+	var params []map[string]any
+	for _, m := range routeParam.FindAllStringSubmatch(route, -1) {
+		params = append(params, map[string]any{
+			"name": m[1], "in": "path", "required": true,
+			"description": pathParams[m[1]],
+			"schema":      map[string]any{"type": "string"},
+		})
+	}
+	if len(params) > 0 {
+		errorResponse(http.StatusBadRequest, "Invalid parameter")
+		errorResponse(http.StatusNotFound, "Not found")
+	}
+	for _, p := range op.Query {
+		params = append(params, map[string]any{
+			"name": p.Name, "in": "query",
+			"description": p.Description,
+			"schema":      map[string]any{"type": p.Type},
+		})
+	}
+
+	doc := map[string]any{
+		"summary":     op.Summary,
+		"tags":        []string{op.Tag},
+		"operationId": operationID(method, route),
+		"responses":   responses,
+	}
+	if len(params) > 0 {
+		doc["parameters"] = params
+	}
+	if op.Body != nil {
+		doc["requestBody"] = map[string]any{
+			"required": true,
+			"content":  map[string]any{"application/json": map[string]any{"schema": op.Body}},
+		}
+		errorResponse(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if op.Role != "" {
+		doc["security"] = []map[string][]string{{"apiKey": {}}, {"bearer": {}}}
+		doc["x-bmad-role"] = op.Role
+		errorResponse(http.StatusUnauthorized, "Missing or unknown API key")
+		if op.Role == RoleControl {
+			errorResponse(http.StatusForbidden, "The key's role does not allow this")
+		}
+	}
+	if strings.HasPrefix(route, "/api/") {
+		limit := "Rate limit exceeded; see the Retry-After header"
+		if op.Starts {
+			limit = "Start rate limit exceeded; see the Retry-After header"
+		}
+		errorResponse(http.StatusTooManyRequests, limit)
+	}
+	return doc
+}
+
+// operationID names an operation after its method and path, e.g.
+// "getStoriesByKey" for GET /api/stories/{key}
+func operationID(method, route string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(strings.TrimPrefix(route, "/api"), "/") {
+		if m := routeParam.FindStringSubmatch(segment); m != nil {
+			b.WriteString("By")
+			segment = m[1]
+		}
+		for _, word := range strings.Split(segment, "-") {
+			if word != "" {
+				b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+			}
+		}
+	}
+	return b.String()
+}
+
+func objectSchema(properties map[string]any, required ...string) map[string]any {
+	return map[string]any{"type": "object", "properties": properties, "required": required}
+}
+
+func arraySchema(itemType string) map[string]any {
+	return map[string]any{"type": "array", "items": map[string]any{"type": itemType}}
+}
+
+// openAPIHandler serves the OpenAPI document for router
+func (s *Server) openAPIHandler(router chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		doc, err := s.openAPIDocument(router)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondJSON(w, http.StatusOK, doc)
+	}
+}
+
+// swaggerUIHTML renders /api/openapi.json with Swagger UI, loaded from a CDN
+const swaggerUIHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>BMAD Automate API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>
+SwaggerUIBundle({ url: "/api/openapi.json", dom_id: "#swagger-ui" });
+</script>
+</body>
+</html>
+`
+
+// apiDocsHandler serves the Swagger UI page. It is only routed when
+// BMAD_API_DOCS is set, since the page loads its scripts from unpkg.com.
+func (s *Server) apiDocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'self'; script-src 'unsafe-inline' https://unpkg.com; style-src https://unpkg.com; img-src 'self' data:")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	_, _ = w.Write([]byte(swaggerUIHTML))
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	cfg := config.New()
+	cfg.DataDir = t.TempDir()
+	cfg.APIDocs = true
+	s := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg))
+	router := s.setupRoutes()
+
+	doc, err := s.openAPIDocument(router)
+	require.NoError(t, err, "every route is documented")
+
+	paths := doc["paths"].(map[string]map[string]any)
+	assert.NotContains(t, paths, "/api/openapi.json")
+	assert.NotContains(t, paths, "/api/docs")
+
+	get := paths["/api/stories/{key}"]["get"].(map[string]any)
+	assert.Equal(t, "getStoriesByKey", get["operationId"])
+	params := get["parameters"].([]map[string]any)
+	require.Len(t, params, 1)
+	assert.Equal(t, "key", params[0]["name"])
+	assert.Equal(t, "path", params[0]["in"])
+
+	create := paths["/api/keys"]["post"].(map[string]any)
+	assert.Contains(t, create, "requestBody")
+	assert.Contains(t, create["responses"], "201")
+	assert.Contains(t, create["responses"], "403")
+	assert.NotContains(t, paths["/health"]["get"], "security")
+
+	t.Run("documented roles match the routes", func(t *testing.T) {
+		_, secret, err := s.keys.Create("reader", RoleRead)
+		require.NoError(t, err)
+
+		err = chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			op := apiOperations[method+" "+route]
+			if op.Role == "" {
+				return nil
+			}
+			path := routeParam.ReplaceAllString(route, "x")
+			req := httptest.NewRequest(method, path, strings.NewReader("{}"))
+			req.Header.Set("X-API-Key", secret)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if op.Role == RoleControl {
+				assert.Equal(t, http.StatusForbidden, rr.Code, "%s %s", method, route)
+			} else {
+				assert.NotEqual(t, http.StatusForbidden, rr.Code, "%s %s", method, route)
+				assert.NotEqual(t, http.StatusUnauthorized, rr.Code, "%s %s", method, route)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	cfg := config.New()
+	cfg.DataDir = t.TempDir()
+	cfg.APIKey = "secret"
+	cfg.Version = "1.2.3"
+	router := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg)).setupRoutes()
+
+	t.Run("serves the document without a key", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+		var doc struct {
+			OpenAPI string `json:"openapi"`
+			Info    struct {
+				Version string `json:"version"`
+			} `json:"info"`
+			Paths map[string]any `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &doc))
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+		assert.Equal(t, "1.2.3", doc.Info.Version)
+		assert.Contains(t, doc.Paths, "/api/execution/start")
+	})
+
+	t.Run("serves Swagger UI only when enabled", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/docs", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.NotEqual(t, http.StatusOK, rr.Code)
+
+		cfg.APIDocs = true
+		router := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg)).setupRoutes()
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "/api/openapi.json")
+	})
+}
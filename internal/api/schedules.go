@@ -0,0 +1,104 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/robertguss/bmad-automate-go/internal/schedule"
+)
+
+// scheduleResponse is a schedule with its next and last run
+type scheduleResponse struct {
+	schedule.Schedule
+	NextRun *time.Time    `json:"next_run,omitempty"`
+	LastRun *schedule.Run `json:"last_run,omitempty"`
+}
+
+// SetSchedules sets the schedules served by the API
+func (s *Server) SetSchedules(store *schedule.Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedules = store
+}
+
+// getSchedules returns the schedule store, or nil
+func (s *Server) getSchedules() *schedule.Store {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.schedules
+}
+
+func (s *Server) listSchedulesHandler(w http.ResponseWriter, r *http.Request) {
+	response := make([]scheduleResponse, 0)
+	if store := s.getSchedules(); store != nil {
+		now := time.Now()
+		for _, sched := range store.List() {
+			item := scheduleResponse{Schedule: sched}
+			if next := sched.Next(now); !next.IsZero() {
+				item.NextRun = &next
+			}
+			if db := s.getStorage(); db != nil {
+				if run, ok, err := schedule.LoadRun(r.Context(), db, sched.Name); err == nil && ok {
+					item.LastRun = &run
+				}
+			}
+			response = append(response, item)
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"schedules": response,
+		"count":     len(response),
+	})
+}
+
+// runScheduleHandler queues the stories of a schedule and starts the queue
+// now, whether or not the schedule is enabled
+func (s *Server) runScheduleHandler(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	// SEC-012: Validate path parameter
+	if err := validatePathParam(name); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	store := s.getSchedules()
+	if store == nil {
+		respondError(w, http.StatusNotFound, "schedule not found")
+		return
+	}
+	sched, err := store.Get(name)
+	if errors.Is(err, schedule.ErrNotFound) {
+		respondError(w, http.StatusNotFound, "schedule not found")
+		return
+	}
+
+	if s.batchExecutor.IsRunning() {
+		respondError(w, http.StatusConflict, "execution already running")
+		return
+	}
+
+	s.mu.RLock()
+	stories := sched.Stories(s.stories)
+	s.mu.RUnlock()
+	if len(stories) == 0 {
+		respondError(w, http.StatusBadRequest, "no stories match the schedule")
+		return
+	}
+
+	s.batchExecutor.AddToQueue(stories)
+	run := s.batchExecutor.Start()
+	go run()
+
+	if db := s.getStorage(); db != nil {
+		_ = schedule.SaveRun(r.Context(), db, sched.Name, schedule.Run{At: time.Now(), Queued: len(stories)})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "started",
+		"queued": len(stories),
+	})
+}
@@ -6,6 +6,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
 )
 
 // TestAPIKeyAuthMiddleware tests SEC-004 API key authentication
@@ -16,7 +20,7 @@ func TestAPIKeyAuthMiddleware(t *testing.T) {
 	})
 
 	t.Run("allows request when no API key configured", func(t *testing.T) {
-		middleware := apiKeyAuthMiddleware("")
+		middleware := apiKeyAuthMiddleware(NewKeyStore("", ""), RoleControl)
 		handler := middleware(nextHandler)
 
 		req := httptest.NewRequest("GET", "/test", nil)
@@ -29,7 +33,7 @@ func TestAPIKeyAuthMiddleware(t *testing.T) {
 	})
 
 	t.Run("blocks request without API key when configured", func(t *testing.T) {
-		middleware := apiKeyAuthMiddleware("secret-key")
+		middleware := apiKeyAuthMiddleware(NewKeyStore("", "secret-key"), RoleControl)
 		handler := middleware(nextHandler)
 
 		req := httptest.NewRequest("GET", "/test", nil)
@@ -42,7 +46,7 @@ func TestAPIKeyAuthMiddleware(t *testing.T) {
 	})
 
 	t.Run("allows request with correct X-API-Key header", func(t *testing.T) {
-		middleware := apiKeyAuthMiddleware("secret-key")
+		middleware := apiKeyAuthMiddleware(NewKeyStore("", "secret-key"), RoleControl)
 		handler := middleware(nextHandler)
 
 		req := httptest.NewRequest("GET", "/test", nil)
@@ -56,7 +60,7 @@ func TestAPIKeyAuthMiddleware(t *testing.T) {
 	})
 
 	t.Run("allows request with correct Bearer token", func(t *testing.T) {
-		middleware := apiKeyAuthMiddleware("secret-key")
+		middleware := apiKeyAuthMiddleware(NewKeyStore("", "secret-key"), RoleControl)
 		handler := middleware(nextHandler)
 
 		req := httptest.NewRequest("GET", "/test", nil)
@@ -70,7 +74,7 @@ func TestAPIKeyAuthMiddleware(t *testing.T) {
 	})
 
 	t.Run("blocks request with wrong API key", func(t *testing.T) {
-		middleware := apiKeyAuthMiddleware("secret-key")
+		middleware := apiKeyAuthMiddleware(NewKeyStore("", "secret-key"), RoleControl)
 		handler := middleware(nextHandler)
 
 		req := httptest.NewRequest("GET", "/test", nil)
@@ -83,7 +87,7 @@ func TestAPIKeyAuthMiddleware(t *testing.T) {
 	})
 
 	t.Run("blocks request with wrong Bearer token", func(t *testing.T) {
-		middleware := apiKeyAuthMiddleware("secret-key")
+		middleware := apiKeyAuthMiddleware(NewKeyStore("", "secret-key"), RoleControl)
 		handler := middleware(nextHandler)
 
 		req := httptest.NewRequest("GET", "/test", nil)
@@ -96,7 +100,7 @@ func TestAPIKeyAuthMiddleware(t *testing.T) {
 	})
 
 	t.Run("prefers X-API-Key over Authorization header", func(t *testing.T) {
-		middleware := apiKeyAuthMiddleware("secret-key")
+		middleware := apiKeyAuthMiddleware(NewKeyStore("", "secret-key"), RoleControl)
 		handler := middleware(nextHandler)
 
 		req := httptest.NewRequest("GET", "/test", nil)
@@ -246,22 +250,23 @@ func TestWebSocketHub_SetSecurityConfig(t *testing.T) {
 	t.Run("stores API key and allowed origins", func(t *testing.T) {
 		hub := NewWebSocketHub()
 
-		hub.SetSecurityConfig("test-key", []string{"http://example.com"})
+		keys := NewKeyStore("", "test-key")
+		hub.SetSecurityConfig(keys, []string{"http://example.com"})
 
 		hub.mu.RLock()
 		defer hub.mu.RUnlock()
-		assert.Equal(t, "test-key", hub.apiKey)
+		assert.Same(t, keys, hub.keys)
 		assert.Equal(t, []string{"http://example.com"}, hub.allowedOrigins)
 	})
 
 	t.Run("allows empty API key for no auth", func(t *testing.T) {
 		hub := NewWebSocketHub()
 
-		hub.SetSecurityConfig("", []string{"http://localhost:*"})
+		hub.SetSecurityConfig(NewKeyStore("", ""), []string{"http://localhost:*"})
 
 		hub.mu.RLock()
 		defer hub.mu.RUnlock()
-		assert.Empty(t, hub.apiKey)
+		assert.False(t, hub.keys.Enabled())
 	})
 }
 
@@ -269,7 +274,7 @@ func TestWebSocketHub_SetSecurityConfig(t *testing.T) {
 func TestWebSocketHub_ServeWs_Auth(t *testing.T) {
 	t.Run("rejects connection without API key when required", func(t *testing.T) {
 		hub := NewWebSocketHub()
-		hub.SetSecurityConfig("secret-key", []string{"*"})
+		hub.SetSecurityConfig(NewKeyStore("", "secret-key"), []string{"*"})
 
 		req := httptest.NewRequest("GET", "/ws", nil)
 		rr := httptest.NewRecorder()
@@ -281,7 +286,7 @@ func TestWebSocketHub_ServeWs_Auth(t *testing.T) {
 
 	t.Run("rejects connection with wrong API key in query", func(t *testing.T) {
 		hub := NewWebSocketHub()
-		hub.SetSecurityConfig("secret-key", []string{"*"})
+		hub.SetSecurityConfig(NewKeyStore("", "secret-key"), []string{"*"})
 
 		req := httptest.NewRequest("GET", "/ws?api_key=wrong-key", nil)
 		rr := httptest.NewRecorder()
@@ -293,7 +298,7 @@ func TestWebSocketHub_ServeWs_Auth(t *testing.T) {
 
 	t.Run("rejects connection with wrong API key in header", func(t *testing.T) {
 		hub := NewWebSocketHub()
-		hub.SetSecurityConfig("secret-key", []string{"*"})
+		hub.SetSecurityConfig(NewKeyStore("", "secret-key"), []string{"*"})
 
 		req := httptest.NewRequest("GET", "/ws", nil)
 		req.Header.Set("X-API-Key", "wrong-key")
@@ -347,3 +352,72 @@ func TestCORSMiddleware_DefaultOrigins(t *testing.T) {
 		})
 	}
 }
+
+// TestRateLimitMiddleware tests SEC-007 per-client rate limiting
+func TestRateLimitMiddleware(t *testing.T) {
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	// One request per minute, so a second one is always over the limit
+	handler := rateLimitMiddleware(rate.Limit(1.0/60), 1, clientIP)(nextHandler)
+
+	do := func(remoteAddr, forwarded string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/test", nil)
+		req.RemoteAddr = remoteAddr
+		if forwarded != "" {
+			req.Header.Set("X-Forwarded-For", forwarded)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assert.Equal(t, http.StatusOK, do("10.0.0.1:5000", "").Code)
+
+	rr := do("10.0.0.1:5001", "")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code, "a new port is the same client")
+	assert.Equal(t, "60", rr.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, do("10.0.0.2:5000", "").Code, "other clients keep their budget")
+	assert.Equal(t, http.StatusOK, do("10.0.0.1:5000", "192.168.1.9, 10.0.0.1").Code, "proxied clients count by origin")
+}
+
+func TestRateLimitClient(t *testing.T) {
+	cfg := config.New()
+	cfg.APIKey = "admin-secret"
+	s := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg))
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	assert.Equal(t, "ip:10.0.0.1", s.rateLimitClient(req))
+
+	req.Header.Set("X-API-Key", "wrong")
+	assert.Equal(t, "ip:10.0.0.1", s.rateLimitClient(req), "unknown keys count by IP")
+
+	req.Header.Set("X-API-Key", "admin-secret")
+	assert.Equal(t, "key:"+DefaultKeyName, s.rateLimitClient(req))
+}
+
+func TestStartRateLimit(t *testing.T) {
+	cfg := config.New()
+	cfg.DataDir = t.TempDir()
+	cfg.APIStartRateLimit = 1
+	router := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg)).setupRoutes()
+
+	refresh := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/stories/refresh", nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assert.NotEqual(t, http.StatusTooManyRequests, refresh().Code)
+	rr := refresh()
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+
+	req := httptest.NewRequest("GET", "/api/queue", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code, "reads have their own budget")
+}
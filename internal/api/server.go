@@ -5,20 +5,26 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/robertguss/bmad-automate-go/internal/config"
 	"github.com/robertguss/bmad-automate-go/internal/domain"
 	"github.com/robertguss/bmad-automate-go/internal/executor"
 	"github.com/robertguss/bmad-automate-go/internal/parser"
+	"github.com/robertguss/bmad-automate-go/internal/schedule"
 	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
 )
 
 // Server is the REST API server
@@ -28,18 +34,33 @@ type Server struct {
 	executor      *executor.Executor
 	batchExecutor *executor.BatchExecutor
 	wsHub         *WebSocketHub
-
-	mu      sync.RWMutex
-	stories []domain.Story
-	server  *http.Server
-	running bool
+	keys          *KeyStore
+	keysErr       error // Set when the keys file could not be read
+
+	mu        sync.RWMutex
+	stories   []domain.Story
+	schedules *schedule.Store
+	theme     theme.Theme
+	program   *tea.Program
+	server    *http.Server
+	grpc      *grpc.Server
+	running   bool
+
+	outputs outputStreams
+
+	// Per-client budget for starting runs and reloading stories, over
+	// REST and gRPC together
+	starts *clientLimiters
 }
 
 // NewServer creates a new API server
 func NewServer(cfg *config.Config, store storage.Storage, exec *executor.Executor, batchExec *executor.BatchExecutor) *Server {
+	keys := NewKeyStore(cfg.DataDir, cfg.APIKey)
+	keysErr := keys.Load()
+
 	wsHub := NewWebSocketHub()
 	// Configure WebSocket security settings (SEC-005/006)
-	wsHub.SetSecurityConfig(cfg.APIKey, cfg.CORSAllowedOrigins)
+	wsHub.SetSecurityConfig(keys, cfg.CORSAllowedOrigins)
 
 	return &Server{
 		config:        cfg,
@@ -47,6 +68,10 @@ func NewServer(cfg *config.Config, store storage.Storage, exec *executor.Executo
 		executor:      exec,
 		batchExecutor: batchExec,
 		wsHub:         wsHub,
+		keys:          keys,
+		keysErr:       keysErr,
+		theme:         theme.Current,
+		starts:        newClientLimiters(rate.Limit(float64(cfg.APIStartRateLimit)/60), cfg.APIStartRateLimit),
 	}
 }
 
@@ -57,6 +82,20 @@ func (s *Server) SetStories(stories []domain.Story) {
 	s.stories = stories
 }
 
+// SetStorage replaces the storage backend, e.g. after the database is repaired
+func (s *Server) SetStorage(store storage.Storage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storage = store
+}
+
+// getStorage returns the current storage backend
+func (s *Server) getStorage() storage.Storage {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.storage
+}
+
 // GetWebSocketHub returns the WebSocket hub
 func (s *Server) GetWebSocketHub() *WebSocketHub {
 	return s.wsHub
@@ -64,6 +103,11 @@ func (s *Server) GetWebSocketHub() *WebSocketHub {
 
 // Start starts the API server on the given port
 func (s *Server) Start(port int) error {
+	// Serving with some keys missing could open routes they should guard
+	if s.keysErr != nil {
+		return s.keysErr
+	}
+
 	s.mu.Lock()
 	if s.running {
 		s.mu.Unlock()
@@ -82,6 +126,16 @@ func (s *Server) Start(port int) error {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// The gRPC control API shares the REST server's lifetime
+	if s.config.GRPCPort > 0 {
+		if err := s.startGRPC(s.config.GRPCPort); err != nil {
+			s.mu.Lock()
+			s.running = false
+			s.mu.Unlock()
+			return err
+		}
+	}
+
 	// Start WebSocket hub
 	go s.wsHub.Run()
 
@@ -100,6 +154,10 @@ func (s *Server) Stop(ctx context.Context) error {
 	s.running = false
 	s.wsHub.Stop()
 
+	if s.grpc != nil {
+		s.grpc.Stop()
+		s.grpc = nil
+	}
 	if s.server != nil {
 		return s.server.Shutdown(ctx)
 	}
@@ -126,50 +184,81 @@ func (s *Server) setupRoutes() *chi.Mux {
 	// Health check (public, no auth required)
 	r.Get("/health", s.healthHandler)
 
-	// API routes (protected by API key if configured)
+	// History dashboard (public page; its data requests are authenticated)
+	r.Get("/", s.dashboardHandler)
+
+	// Every route needs a key with the route's role once keys are defined
+	read := apiKeyAuthMiddleware(s.keys, RoleRead)
+	control := apiKeyAuthMiddleware(s.keys, RoleControl)
+
+	// Prometheus metrics
+	r.With(read).Get("/metrics", s.metricsHandler)
+
+	// Runs and reloads are costly, so they get a budget of their own on top
+	// of the general one, shared with the gRPC start methods
+	starts := limitMiddleware(s.starts, s.rateLimitClient)
+
+	// API routes
 	r.Route("/api", func(r chi.Router) {
-		// Apply API key authentication to all /api routes
-		r.Use(apiKeyAuthMiddleware(s.config.APIKey))
-		// SEC-007: Apply rate limiting (100 req/sec, burst of 200) to protect against DoS
-		r.Use(rateLimitMiddleware(100, 200))
+		// SEC-007: Apply rate limiting to protect against DoS, with bursts
+		// of twice the rate
+		r.Use(rateLimitMiddleware(rate.Limit(s.config.APIRateLimit), 2*s.config.APIRateLimit, s.rateLimitClient))
 		// SEC-012: Limit request body size to prevent memory exhaustion
 		r.Use(bodySizeLimitMiddleware(maxBodySize))
 		// Stories
-		r.Get("/stories", s.listStoriesHandler)
-		r.Get("/stories/{key}", s.getStoryHandler)
-		r.Post("/stories/refresh", s.refreshStoriesHandler)
+		r.With(read).Get("/stories", s.listStoriesHandler)
+		r.With(read).Get("/stories/{key}", s.getStoryHandler)
+		r.With(control, starts).Post("/stories/refresh", s.refreshStoriesHandler)
 
 		// Queue management
-		r.Get("/queue", s.getQueueHandler)
-		r.Post("/queue/add", s.addToQueueHandler)
-		r.Post("/queue/add/{key}", s.addStoryToQueueHandler)
-		r.Delete("/queue/{key}", s.removeFromQueueHandler)
-		r.Post("/queue/clear", s.clearQueueHandler)
-		r.Post("/queue/reorder", s.reorderQueueHandler)
+		r.With(read).Get("/queue", s.getQueueHandler)
+		r.With(control).Post("/queue/add", s.addToQueueHandler)
+		r.With(control).Post("/queue/add/{key}", s.addStoryToQueueHandler)
+		r.With(control).Delete("/queue/{key}", s.removeFromQueueHandler)
+		r.With(control).Post("/queue/clear", s.clearQueueHandler)
+		r.With(control).Post("/queue/reorder", s.reorderQueueHandler)
+		r.With(read).Get("/queue/eta", s.getQueueETAHandler)
 
 		// Execution control
-		r.Get("/execution", s.getExecutionHandler)
-		r.Post("/execution/start", s.startExecutionHandler)
-		r.Post("/execution/start/{key}", s.startStoryExecutionHandler)
-		r.Post("/execution/pause", s.pauseExecutionHandler)
-		r.Post("/execution/resume", s.resumeExecutionHandler)
-		r.Post("/execution/cancel", s.cancelExecutionHandler)
-		r.Post("/execution/skip", s.skipStepHandler)
+		r.With(read).Get("/execution", s.getExecutionHandler)
+		r.With(control, starts).Post("/execution/start", s.startExecutionHandler)
+		r.With(control, starts).Post("/execution/start/{key}", s.startStoryExecutionHandler)
+		r.With(control).Post("/execution/pause", s.pauseExecutionHandler)
+		r.With(control).Post("/execution/resume", s.resumeExecutionHandler)
+		r.With(control).Post("/execution/cancel", s.cancelExecutionHandler)
+		r.With(control).Post("/execution/skip", s.skipStepHandler)
 
 		// History
-		r.Get("/history", s.listHistoryHandler)
-		r.Get("/history/{id}", s.getHistoryHandler)
+		r.With(read).Get("/history", s.listHistoryHandler)
+		r.With(read).Get("/history/{id}", s.getHistoryHandler)
 
 		// Statistics
-		r.Get("/stats", s.getStatsHandler)
+		r.With(read).Get("/stats", s.getStatsHandler)
+		r.With(read).Get("/step-averages", s.getStepAveragesHandler)
+
+		// Schedules
+		r.With(read).Get("/schedules", s.listSchedulesHandler)
+		r.With(control, starts).Post("/schedules/{name}/run", s.runScheduleHandler)
+
+		// API keys
+		r.With(control).Get("/keys", s.listKeysHandler)
+		r.With(control).Post("/keys", s.createKeyHandler)
+		r.With(control).Delete("/keys/{name}", s.revokeKeyHandler)
 
 		// Configuration
-		r.Get("/config", s.getConfigHandler)
+		r.With(read).Get("/config", s.getConfigHandler)
+		r.With(read).Get("/theme", s.getThemeHandler)
 
 		// WebSocket endpoint
-		r.Get("/ws", s.websocketHandler)
+		r.With(read).Get("/ws", s.websocketHandler)
 	})
 
+	// API description, built from the routes above (public, like /health)
+	r.Get("/api/openapi.json", s.openAPIHandler(r))
+	if s.config.APIDocs {
+		r.Get("/api/docs", s.apiDocsHandler)
+	}
+
 	return r
 }
 
@@ -227,29 +316,20 @@ func corsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 	}
 }
 
-// apiKeyAuthMiddleware creates middleware that validates API key from header
+// apiKeyAuthMiddleware creates middleware that requires a key with role
+// need: 401 without a valid key, 403 when the key's role is not enough.
+// With no keys defined every request is allowed (optional auth).
 // SEC-004 fix: Adds authentication to protect API endpoints
-func apiKeyAuthMiddleware(apiKey string) func(http.Handler) http.Handler {
+func apiKeyAuthMiddleware(keys *KeyStore, need Role) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// If no API key is configured, allow all requests (optional auth)
-			if apiKey == "" {
-				next.ServeHTTP(w, r)
+			ok, unauthenticated := keys.Authorize(requestKey(r), need)
+			if unauthenticated {
+				http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
 				return
 			}
-
-			// Check for API key in header
-			providedKey := r.Header.Get("X-API-Key")
-			if providedKey == "" {
-				// Also check Authorization header as Bearer token
-				auth := r.Header.Get("Authorization")
-				if strings.HasPrefix(auth, "Bearer ") {
-					providedKey = strings.TrimPrefix(auth, "Bearer ")
-				}
-			}
-
-			if providedKey != apiKey {
-				http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+			if !ok {
+				http.Error(w, `{"error": "forbidden"}`, http.StatusForbidden)
 				return
 			}
 
@@ -258,60 +338,93 @@ func apiKeyAuthMiddleware(apiKey string) func(http.Handler) http.Handler {
 	}
 }
 
-// rateLimitMiddleware creates a rate limiting middleware using token bucket algorithm
-// SEC-007: Protects against DoS attacks by limiting requests per IP
-func rateLimitMiddleware(requestsPerSecond float64, burst int) func(http.Handler) http.Handler {
-	// Per-IP rate limiters
-	var (
-		mu       sync.RWMutex
-		limiters = make(map[string]*rate.Limiter)
-	)
+// requestKey returns the API key sent in the X-API-Key header or as a
+// bearer token
+func requestKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return ""
+}
+
+// clientLimiters keeps a token bucket of burst requests, refilled at limit
+// per second, for each client
+// SEC-007: Protects against DoS attacks by limiting requests per client
+type clientLimiters struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.RWMutex
+	limiters map[string]*rate.Limiter
+}
+
+// newClientLimiters creates the per-client buckets
+func newClientLimiters(limit rate.Limit, burst int) *clientLimiters {
+	c := &clientLimiters{limit: limit, burst: burst, limiters: make(map[string]*rate.Limiter)}
 
 	// Clean up old limiters periodically to prevent memory growth
 	go func() {
 		for {
 			time.Sleep(10 * time.Minute)
-			mu.Lock()
+			c.mu.Lock()
 			// Reset all limiters - simple approach that works for our use case
-			limiters = make(map[string]*rate.Limiter)
-			mu.Unlock()
+			c.limiters = make(map[string]*rate.Limiter)
+			c.mu.Unlock()
 		}
 	}()
+	return c
+}
 
-	getLimiter := func(ip string) *rate.Limiter {
-		mu.RLock()
-		limiter, exists := limiters[ip]
-		mu.RUnlock()
-
-		if exists {
-			return limiter
-		}
+func (c *clientLimiters) get(client string) *rate.Limiter {
+	c.mu.RLock()
+	limiter, exists := c.limiters[client]
+	c.mu.RUnlock()
 
-		mu.Lock()
-		defer mu.Unlock()
-		// Double-check after acquiring write lock
-		if limiter, exists = limiters[ip]; exists {
-			return limiter
-		}
+	if exists {
+		return limiter
+	}
 
-		limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
-		limiters[ip] = limiter
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Double-check after acquiring write lock
+	if limiter, exists = c.limiters[client]; exists {
 		return limiter
 	}
 
+	limiter = rate.NewLimiter(c.limit, c.burst)
+	c.limiters[client] = limiter
+	return limiter
+}
+
+// reserve takes a token from client's bucket. It returns zero when the
+// request may go ahead, and otherwise how long the client has to wait, in
+// which case no token is taken.
+func (c *clientLimiters) reserve(client string) time.Duration {
+	reservation := c.get(client).Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return delay
+	}
+	return 0
+}
+
+// rateLimitMiddleware creates a rate limiting middleware using token bucket
+// algorithm, with a bucket of burst requests refilled at limit per second
+// for each client that clientKey tells apart
+func rateLimitMiddleware(limit rate.Limit, burst int, clientKey func(*http.Request) string) func(http.Handler) http.Handler {
+	return limitMiddleware(newClientLimiters(limit, burst), clientKey)
+}
+
+// limitMiddleware rejects requests once their client's bucket in limiters
+// is empty
+func limitMiddleware(limiters *clientLimiters, clientKey func(*http.Request) string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Extract IP from request (handle X-Forwarded-For for proxied requests)
-			ip := r.RemoteAddr
-			if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-				// Take the first IP in the chain (original client)
-				ip = strings.Split(forwarded, ",")[0]
-				ip = strings.TrimSpace(ip)
-			}
-
-			limiter := getLimiter(ip)
-			if !limiter.Allow() {
-				w.Header().Set("Retry-After", "1")
+			if delay := limiters.reserve(clientKey(r)); delay > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
 				http.Error(w, `{"error": "rate limit exceeded"}`, http.StatusTooManyRequests)
 				return
 			}
@@ -321,6 +434,29 @@ func rateLimitMiddleware(requestsPerSecond float64, burst int) func(http.Handler
 	}
 }
 
+// rateLimitClient names the client a request counts against: its API key
+// when it carries a known one, so integrations behind one proxy keep their
+// own budgets, and otherwise its IP address
+func (s *Server) rateLimitClient(r *http.Request) string {
+	if key, ok := s.keys.Authenticate(requestKey(r)); ok {
+		return "key:" + key.Name
+	}
+	return "ip:" + clientIP(r)
+}
+
+// clientIP returns the address a request came from, taking the original
+// client from X-Forwarded-For for proxied requests
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		ip, _, _ := strings.Cut(forwarded, ",")
+		return strings.TrimSpace(ip)
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
 // maxBodySize is the maximum allowed request body size (1MB)
 const maxBodySize = 1 << 20
 
@@ -462,17 +598,8 @@ func (s *Server) getStoryHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.mu.RLock()
-	var found *domain.Story
-	for _, story := range s.stories {
-		if story.Key == key {
-			found = &story
-			break
-		}
-	}
-	s.mu.RUnlock()
-
-	if found == nil {
+	found, ok := s.findStory(key)
+	if !ok {
 		respondError(w, http.StatusNotFound, "story not found")
 		return
 	}
@@ -480,8 +607,20 @@ func (s *Server) getStoryHandler(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, found)
 }
 
+// findStory looks up a loaded story by key
+func (s *Server) findStory(key string) (domain.Story, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, story := range s.stories {
+		if story.Key == key {
+			return story, true
+		}
+	}
+	return domain.Story{}, false
+}
+
 func (s *Server) refreshStoriesHandler(w http.ResponseWriter, r *http.Request) {
-	stories, err := parser.ParseSprintStatus(s.config)
+	stories, err := parser.LoadStories(r.Context(), s.config)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -502,12 +641,16 @@ func (s *Server) getQueueHandler(w http.ResponseWriter, r *http.Request) {
 
 	items := make([]map[string]interface{}, 0)
 	for _, item := range queue.Items {
-		items = append(items, map[string]interface{}{
+		entry := map[string]interface{}{
 			"story":    item.Story,
 			"status":   item.Status,
 			"position": item.Position,
 			"added_at": item.AddedAt,
-		})
+		}
+		if len(item.Tags) > 0 {
+			entry["tags"] = item.Tags
+		}
+		items = append(items, entry)
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
@@ -520,9 +663,68 @@ func (s *Server) getQueueHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// getQueueETAHandler projects when each queued story will start and finish.
+// Stories listed in ?stories=key1,key2 are estimated as if added to the end
+// of the queue, so a batch can be sized before it is submitted.
+func (s *Server) getQueueETAHandler(w http.ResponseWriter, r *http.Request) {
+	var proposed []domain.Story
+	if keys := r.URL.Query().Get("stories"); keys != "" {
+		for _, key := range strings.Split(keys, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+			if err := validatePathParam(key); err != nil {
+				respondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			story, ok := s.findStory(key)
+			if !ok {
+				respondError(w, http.StatusNotFound, "story not found: "+key)
+				return
+			}
+			proposed = append(proposed, story)
+		}
+	}
+
+	queue := s.batchExecutor.GetQueue()
+	perStory, spread := queue.StoryEstimate()
+	estimates := queue.EstimateItems(proposed)
+
+	items := make([]map[string]interface{}, 0, len(estimates))
+	var total time.Duration
+	now := time.Now()
+	for _, est := range estimates {
+		item := map[string]interface{}{
+			"story":    est.Story,
+			"status":   est.Status,
+			"proposed": est.Proposed,
+			"duration": est.Duration.Seconds(),
+			"spread":   est.Spread.Seconds(),
+			"start":    est.Start.Seconds(),
+			"finish":   est.Finish.Seconds(),
+		}
+		if !est.Deadline.IsZero() {
+			item["deadline"] = est.Deadline
+			item["risk"] = est.Risk(now)
+		}
+		items = append(items, item)
+		total = est.Finish
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"items":        items,
+		"total":        total.Seconds(),
+		"per_story":    perStory.Seconds(),
+		"spread":       spread.Seconds(),
+		"from_history": len(queue.StepAverages) > 0,
+	})
+}
+
 func (s *Server) addToQueueHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Keys []string `json:"keys"`
+		Tags []string `json:"tags"`
 	}
 
 	// SEC-012: Use safe JSON decoding with validation
@@ -549,6 +751,12 @@ func (s *Server) addToQueueHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	s.batchExecutor.AddToQueue(stories)
+	if tags := domain.ParseTags(strings.Join(req.Tags, ",")); len(tags) > 0 {
+		for _, story := range stories {
+			s.batchExecutor.SetTags(story.Key, tags)
+		}
+	}
+	s.queueChanged()
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"added": len(stories),
@@ -580,6 +788,10 @@ func (s *Server) addStoryToQueueHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	s.batchExecutor.AddToQueue([]domain.Story{*found})
+	if tags := domain.ParseTags(r.URL.Query().Get("tags")); len(tags) > 0 {
+		s.batchExecutor.SetTags(found.Key, tags)
+	}
+	s.queueChanged()
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"added": 1,
@@ -595,15 +807,13 @@ func (s *Server) removeFromQueueHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	queue := s.batchExecutor.GetQueue()
-	queue.Remove(key)
+	s.batchExecutor.RemoveFromQueue(key)
 
 	respondJSON(w, http.StatusOK, map[string]string{"status": "removed"})
 }
 
 func (s *Server) clearQueueHandler(w http.ResponseWriter, r *http.Request) {
-	queue := s.batchExecutor.GetQueue()
-	queue.Clear()
+	s.batchExecutor.ClearQueue()
 
 	respondJSON(w, http.StatusOK, map[string]string{"status": "cleared"})
 }
@@ -620,12 +830,11 @@ func (s *Server) reorderQueueHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	queue := s.batchExecutor.GetQueue()
 	switch req.Direction {
 	case "up":
-		queue.MoveUp(req.Index)
+		s.batchExecutor.MoveUp(req.Index)
 	case "down":
-		queue.MoveDown(req.Index)
+		s.batchExecutor.MoveDown(req.Index)
 	default:
 		respondError(w, http.StatusBadRequest, "invalid direction")
 		return
@@ -655,6 +864,7 @@ func (s *Server) getExecutionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"id":       exec.ID,
 		"running":  exec.Status == domain.ExecutionRunning,
 		"status":   exec.Status,
 		"story":    exec.Story,
@@ -666,20 +876,11 @@ func (s *Server) getExecutionHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) startExecutionHandler(w http.ResponseWriter, r *http.Request) {
-	queue := s.batchExecutor.GetQueue()
-	if !queue.HasPending() {
-		respondError(w, http.StatusBadRequest, "no items in queue")
+	if err := s.startQueue(); err != nil {
+		respondControlError(w, err)
 		return
 	}
 
-	if s.batchExecutor.IsRunning() {
-		respondError(w, http.StatusConflict, "execution already running")
-		return
-	}
-
-	// Start in background
-	go s.batchExecutor.Start()
-
 	respondJSON(w, http.StatusOK, map[string]string{"status": "started"})
 }
 
@@ -691,40 +892,17 @@ func (s *Server) startStoryExecutionHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	s.mu.RLock()
-	var found *domain.Story
-	for _, story := range s.stories {
-		if story.Key == key {
-			found = &story
-			break
-		}
-	}
-	s.mu.RUnlock()
-
-	if found == nil {
-		respondError(w, http.StatusNotFound, "story not found")
-		return
-	}
-
-	if s.executor.GetExecution() != nil &&
-		s.executor.GetExecution().Status == domain.ExecutionRunning {
-		respondError(w, http.StatusConflict, "execution already running")
+	if err := s.startStory(key); err != nil {
+		respondControlError(w, err)
 		return
 	}
 
-	// Start execution in background
-	go s.executor.Execute(*found)
-
 	respondJSON(w, http.StatusOK, map[string]string{"status": "started"})
 }
 
 func (s *Server) pauseExecutionHandler(w http.ResponseWriter, r *http.Request) {
-	if s.batchExecutor.IsRunning() {
-		s.batchExecutor.Pause()
-	} else if exec := s.executor.GetExecution(); exec != nil && exec.Status == domain.ExecutionRunning {
-		s.executor.Pause()
-	} else {
-		respondError(w, http.StatusBadRequest, "no execution running")
+	if err := s.pause(); err != nil {
+		respondControlError(w, err)
 		return
 	}
 
@@ -732,12 +910,8 @@ func (s *Server) pauseExecutionHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) resumeExecutionHandler(w http.ResponseWriter, r *http.Request) {
-	if s.batchExecutor.IsPaused() {
-		s.batchExecutor.Resume()
-	} else if exec := s.executor.GetExecution(); exec != nil && exec.Status == domain.ExecutionPaused {
-		s.executor.Resume()
-	} else {
-		respondError(w, http.StatusBadRequest, "no execution paused")
+	if err := s.resume(); err != nil {
+		respondControlError(w, err)
 		return
 	}
 
@@ -745,12 +919,8 @@ func (s *Server) resumeExecutionHandler(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) cancelExecutionHandler(w http.ResponseWriter, r *http.Request) {
-	if s.batchExecutor.IsRunning() {
-		s.batchExecutor.Cancel()
-	} else if exec := s.executor.GetExecution(); exec != nil {
-		s.executor.Cancel()
-	} else {
-		respondError(w, http.StatusBadRequest, "no execution to cancel")
+	if err := s.cancel(); err != nil {
+		respondControlError(w, err)
 		return
 	}
 
@@ -758,17 +928,17 @@ func (s *Server) cancelExecutionHandler(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *Server) skipStepHandler(w http.ResponseWriter, r *http.Request) {
-	if exec := s.executor.GetExecution(); exec != nil && exec.Status == domain.ExecutionRunning {
-		s.executor.Skip()
-		respondJSON(w, http.StatusOK, map[string]string{"status": "skipping"})
+	if err := s.skip(); err != nil {
+		respondControlError(w, err)
 		return
 	}
 
-	respondError(w, http.StatusBadRequest, "no step to skip")
+	respondJSON(w, http.StatusOK, map[string]string{"status": "skipping"})
 }
 
 func (s *Server) listHistoryHandler(w http.ResponseWriter, r *http.Request) {
-	if s.storage == nil {
+	store := s.getStorage()
+	if store == nil {
 		respondError(w, http.StatusServiceUnavailable, "storage not available")
 		return
 	}
@@ -785,6 +955,18 @@ func (s *Server) listHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		Limit: limit,
 	}
 
+	// Pages continue from a cursor, or skip offset executions
+	if c := r.URL.Query().Get("cursor"); c != "" {
+		filter.Cursor = c
+	} else if o := r.URL.Query().Get("offset"); o != "" {
+		offset, err := strconv.Atoi(o)
+		if err != nil || offset < 0 {
+			respondError(w, http.StatusBadRequest, "offset must be a non-negative integer")
+			return
+		}
+		filter.Offset = offset
+	}
+
 	if q := r.URL.Query().Get("story"); q != "" {
 		filter.StoryKey = q
 	}
@@ -799,15 +981,29 @@ func (s *Server) listHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		filter.Status = domain.ExecutionStatus(s)
 	}
 
-	records, err := s.storage.ListExecutions(r.Context(), filter)
+	// Repeat tag, or separate tags with commas, to require several
+	filter.Tags = domain.ParseTags(strings.Join(r.URL.Query()["tag"], ","))
+
+	// One extra row tells whether there is a next page
+	filter.Limit++
+	records, err := store.ListExecutions(r.Context(), filter)
+	if errors.Is(err, storage.ErrInvalidCursor) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	var nextCursor string
+	if len(records) > limit {
+		records = records[:limit]
+		nextCursor = records[limit-1].Cursor()
+	}
 
 	executions := make([]map[string]interface{}, 0)
 	for _, rec := range records {
-		executions = append(executions, map[string]interface{}{
+		execution := map[string]interface{}{
 			"id":         rec.ID,
 			"story_key":  rec.StoryKey,
 			"story_epic": rec.StoryEpic,
@@ -815,20 +1011,35 @@ func (s *Server) listHistoryHandler(w http.ResponseWriter, r *http.Request) {
 			"start_time": rec.StartTime,
 			"duration":   rec.Duration.Seconds(),
 			"error":      rec.Error,
-		})
+		}
+		if len(rec.Tags) > 0 {
+			execution["tags"] = rec.Tags
+		}
+		if rec.Note != "" {
+			execution["note"] = rec.Note
+		}
+		executions = append(executions, execution)
 	}
 
-	count, _ := s.storage.CountExecutions(r.Context(), filter)
+	// The total counts every match, not just those after the cursor
+	total := *filter
+	total.Cursor = ""
+	count, _ := store.CountExecutions(r.Context(), &total)
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"executions": executions,
 		"count":      len(executions),
 		"total":      count,
-	})
+	}
+	if nextCursor != "" {
+		response["next_cursor"] = nextCursor
+	}
+	respondJSON(w, http.StatusOK, response)
 }
 
 func (s *Server) getHistoryHandler(w http.ResponseWriter, r *http.Request) {
-	if s.storage == nil {
+	store := s.getStorage()
+	if store == nil {
 		respondError(w, http.StatusServiceUnavailable, "storage not available")
 		return
 	}
@@ -840,7 +1051,12 @@ func (s *Server) getHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	record, err := s.storage.GetExecutionWithOutput(r.Context(), id)
+	// Accept shortened IDs as shown in the TUI and dashboard
+	if full, err := store.ResolveExecutionID(r.Context(), id); err == nil {
+		id = full
+	}
+
+	record, err := store.GetExecutionWithOutput(r.Context(), id)
 	if err != nil {
 		respondError(w, http.StatusNotFound, "execution not found")
 		return
@@ -859,7 +1075,7 @@ func (s *Server) getHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	respondJSON(w, http.StatusOK, map[string]interface{}{
+	response := map[string]interface{}{
 		"id":         record.ID,
 		"story_key":  record.StoryKey,
 		"story_epic": record.StoryEpic,
@@ -869,16 +1085,50 @@ func (s *Server) getHistoryHandler(w http.ResponseWriter, r *http.Request) {
 		"duration":   record.Duration.Seconds(),
 		"error":      record.Error,
 		"steps":      steps,
-	})
+	}
+	if record.Branch != "" {
+		response["branch"] = record.Branch
+	}
+	if record.Stash != "" {
+		response["stash"] = record.Stash
+	}
+	if len(record.Tags) > 0 {
+		response["tags"] = record.Tags
+	}
+	if record.Note != "" {
+		response["note"] = record.Note
+	}
+	if ec := record.Context; ec != nil {
+		response["context"] = map[string]interface{}{
+			"git_branch":     ec.GitBranch,
+			"git_commit":     ec.GitCommit,
+			"git_dirty":      ec.GitDirty,
+			"claude_version": ec.ClaudeVersion,
+			"bmad_version":   ec.BmadVersion,
+			"os":             ec.OS,
+			"arch":           ec.Arch,
+			"config_digest":  ec.ConfigDigest,
+			"workflow":       ec.Workflow,
+			"profile":        ec.Profile,
+		}
+	}
+	respondJSON(w, http.StatusOK, response)
 }
 
 func (s *Server) getStatsHandler(w http.ResponseWriter, r *http.Request) {
-	if s.storage == nil {
+	store := s.getStorage()
+	if store == nil {
 		respondError(w, http.StatusServiceUnavailable, "storage not available")
 		return
 	}
 
-	stats, err := s.storage.GetStats(r.Context())
+	dateRange, err := domain.ParseDateRange(r.URL.Query().Get("range"), time.Now())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stats, err := store.GetStats(r.Context(), dateRange)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -912,6 +1162,48 @@ func (s *Server) getStatsHandler(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// getStepAveragesHandler returns the historical duration of each step, with
+// the spread and sample count behind the queue's ETAs
+func (s *Server) getStepAveragesHandler(w http.ResponseWriter, r *http.Request) {
+	store := s.getStorage()
+	if store == nil {
+		respondError(w, http.StatusServiceUnavailable, "storage not available")
+		return
+	}
+
+	averages, err := store.GetStepAverages(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	estimates, err := store.GetStepEstimates(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	steps := make(map[string]interface{}, len(averages))
+	for name, avg := range averages {
+		step := map[string]interface{}{
+			"avg_duration": avg.AvgDuration.Seconds(),
+			"success":      avg.SuccessCount,
+			"failure":      avg.FailureCount,
+			"total":        avg.TotalCount,
+			"last_updated": avg.LastUpdated,
+		}
+		if est, ok := estimates[name]; ok {
+			step["estimate"] = est.Mean.Seconds()
+			step["std_dev"] = est.StdDev().Seconds()
+			step["samples"] = est.Samples
+		}
+		steps[string(name)] = step
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"steps": steps,
+	})
+}
+
 func (s *Server) getConfigHandler(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"working_dir":   s.config.WorkingDir,
@@ -938,3 +1230,25 @@ func (s *Server) BroadcastMessage(msgType string, data interface{}) {
 	}
 	s.wsHub.Broadcast(msg)
 }
+
+// BroadcastExecutionStarted announces an execution, so dashboards can
+// subscribe to its output topic
+func (s *Server) BroadcastExecutionStarted(exec *domain.Execution) {
+	s.BroadcastMessage("execution_started", map[string]interface{}{
+		"execution_id": exec.ID,
+		"story_key":    exec.Story.Key,
+		"story_title":  exec.Story.Title,
+		"topic":        ExecutionTopic(exec.ID),
+	})
+}
+
+// BroadcastStepOutput sends a line of step output on its execution's topic
+func (s *Server) BroadcastStepOutput(data StepOutputData) {
+	s.wsHub.Broadcast(WebSocketMessage{
+		Type:      "step_output",
+		Topic:     ExecutionTopic(data.ExecutionID),
+		Data:      data,
+		Timestamp: time.Now(),
+	})
+	s.outputs.publish(rpcOutputLine(data))
+}
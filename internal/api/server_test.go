@@ -0,0 +1,297 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/internal/schedule"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+func TestEstimateRoutes(t *testing.T) {
+	cfg := config.New()
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	execution := domain.NewExecution(domain.Story{Key: "3-1-test", Epic: 3})
+	execution.Status = domain.ExecutionCompleted
+	for _, step := range execution.Steps {
+		step.Status = domain.StepSuccess
+		step.StartTime = time.Now()
+		step.Duration = time.Minute
+	}
+	require.NoError(t, store.SaveExecution(context.Background(), execution))
+	require.NoError(t, store.UpdateStepAverages(context.Background()))
+
+	batch := executor.NewBatchExecutor(cfg)
+	server := NewServer(cfg, store, executor.New(cfg), batch)
+	server.SetStories([]domain.Story{{Key: "3-2-next", Epic: 3}, {Key: "4-1-later", Epic: 4}})
+	batch.AddToQueue([]domain.Story{{Key: "3-2-next", Epic: 3}})
+	for _, step := range domain.AllSteps() {
+		batch.GetQueue().StepAverages[step] = time.Minute
+	}
+	router := server.setupRoutes()
+
+	get := func(path string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string]interface{}
+		_ = json.Unmarshal(rr.Body.Bytes(), &body)
+		return rr, body
+	}
+
+	t.Run("step averages", func(t *testing.T) {
+		rr, body := get("/api/step-averages")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		steps := body["steps"].(map[string]interface{})
+		dev := steps[string(domain.StepDevStory)].(map[string]interface{})
+		assert.Equal(t, 60.0, dev["avg_duration"])
+		assert.Equal(t, 1.0, dev["samples"])
+	})
+
+	t.Run("queue ETA with a proposed batch", func(t *testing.T) {
+		rr, body := get("/api/queue/eta?stories=4-1-later")
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		items := body["items"].([]interface{})
+		require.Len(t, items, 2)
+		last := items[1].(map[string]interface{})
+		assert.Equal(t, true, last["proposed"])
+		assert.Equal(t, 240.0, last["start"])
+		assert.Equal(t, 480.0, body["total"])
+		assert.Equal(t, true, body["from_history"])
+	})
+
+	t.Run("unknown proposed story", func(t *testing.T) {
+		rr, _ := get("/api/queue/eta?stories=9-9-missing")
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestMetricsRoute(t *testing.T) {
+	cfg := config.New()
+	cfg.APIKey = "secret"
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	execution := domain.NewExecution(domain.Story{Key: "3-1-test", Epic: 3})
+	execution.Status = domain.ExecutionCompleted
+	for _, step := range execution.Steps {
+		step.Status = domain.StepSuccess
+		step.StartTime = time.Now()
+		step.Duration = 90 * time.Second
+		step.Attempt = 2
+	}
+	require.NoError(t, store.SaveExecution(context.Background(), execution))
+
+	batch := executor.NewBatchExecutor(cfg)
+	batch.AddToQueue([]domain.Story{{Key: "3-2-next", Epic: 3}, {Key: "3-3-last", Epic: 3}})
+	router := NewServer(cfg, store, executor.New(cfg), batch).setupRoutes()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, metricsContentType, rr.Header().Get("Content-Type"))
+
+	body := rr.Body.String()
+	assert.Contains(t, body, "# TYPE bmad_step_duration_seconds histogram\n")
+	assert.Contains(t, body, `bmad_executions_total{status="completed"} 1`)
+	assert.Contains(t, body, `bmad_steps_total{step="dev-story",status="success"} 1`)
+	assert.Contains(t, body, `bmad_step_retries_total{step="dev-story"} 1`)
+	assert.Contains(t, body, `bmad_step_duration_seconds_bucket{step="dev-story",le="60"} 0`)
+	assert.Contains(t, body, `bmad_step_duration_seconds_bucket{step="dev-story",le="120"} 1`)
+	assert.Contains(t, body, `bmad_step_duration_seconds_bucket{step="dev-story",le="+Inf"} 1`)
+	assert.Contains(t, body, `bmad_step_duration_seconds_sum{step="dev-story"} 90`)
+	assert.Contains(t, body, "bmad_queue_depth 2\n")
+	assert.Contains(t, body, "bmad_execution_running 0\n")
+}
+
+func TestScheduleRoutes(t *testing.T) {
+	cfg := config.New()
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	schedules := schedule.NewStore(t.TempDir())
+	require.NoError(t, schedules.Add(schedule.Schedule{Name: "nightly", Cron: "@nightly", Select: "ready-for-dev"}))
+	require.NoError(t, schedules.Add(schedule.Schedule{Name: "old", Cron: "@weekly", Select: "epic:9", Disabled: true}))
+	require.NoError(t, schedule.SaveRun(context.Background(), store, "nightly", schedule.Run{At: time.Now(), Queued: 2}))
+
+	server := NewServer(cfg, store, executor.New(cfg), executor.NewBatchExecutor(cfg))
+	server.SetStories([]domain.Story{{Key: "3-1-done", Epic: 3, Status: domain.StatusDone}})
+	server.SetSchedules(schedules)
+	router := server.setupRoutes()
+
+	t.Run("list", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/schedules", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var body struct {
+			Schedules []map[string]interface{} `json:"schedules"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		require.Len(t, body.Schedules, 2)
+		assert.Equal(t, "nightly", body.Schedules[0]["name"])
+		assert.NotNil(t, body.Schedules[0]["next_run"])
+		assert.Equal(t, 2.0, body.Schedules[0]["last_run"].(map[string]interface{})["queued"])
+		assert.Nil(t, body.Schedules[1]["next_run"], "disabled schedules have no next run")
+	})
+
+	t.Run("run", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/schedules/missing/run", nil))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/schedules/nightly/run", nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, "no stories match")
+	})
+}
+
+func TestHistoryPagination(t *testing.T) {
+	cfg := config.New()
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	for i := 0; i < 3; i++ {
+		execution := domain.NewExecution(domain.Story{Key: "3-1-test", Epic: 3})
+		execution.Status = domain.ExecutionCompleted
+		require.NoError(t, store.SaveExecution(context.Background(), execution))
+	}
+	router := NewServer(cfg, store, executor.New(cfg), executor.NewBatchExecutor(cfg)).setupRoutes()
+
+	get := func(path string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		var body map[string]interface{}
+		_ = json.Unmarshal(rr.Body.Bytes(), &body)
+		return rr, body
+	}
+
+	rr, first := get("/api/history?limit=2")
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 2.0, first["count"])
+	assert.Equal(t, 3.0, first["total"])
+	require.NotEmpty(t, first["next_cursor"])
+
+	rr, second := get("/api/history?limit=2&cursor=" + first["next_cursor"].(string))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, 1.0, second["count"])
+	assert.Equal(t, 3.0, second["total"], "the total ignores the cursor")
+	assert.NotContains(t, second, "next_cursor", "the last page has no cursor")
+
+	_, byOffset := get("/api/history?limit=2&offset=2")
+	assert.Equal(t, second["executions"], byOffset["executions"])
+
+	rr, _ = get("/api/history?cursor=bogus")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr, _ = get("/api/history?offset=-1")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestHistoryTagFilter(t *testing.T) {
+	cfg := config.New()
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	for _, tags := range [][]string{{"hotfix", "sprint-12"}, {"sprint-12"}, nil} {
+		execution := domain.NewExecution(domain.Story{Key: "3-1-test", Epic: 3})
+		execution.Status = domain.ExecutionCompleted
+		execution.Tags = tags
+		require.NoError(t, store.SaveExecution(context.Background(), execution))
+	}
+	router := NewServer(cfg, store, executor.New(cfg), executor.NewBatchExecutor(cfg)).setupRoutes()
+
+	get := func(path string) map[string]interface{} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		return body
+	}
+
+	assert.Equal(t, 2.0, get("/api/history?tag=sprint-12")["total"])
+	assert.Equal(t, 1.0, get("/api/history?tag=sprint-12&tag=hotfix")["total"])
+	assert.Equal(t, 1.0, get("/api/history?tag=Sprint-12,hotfix")["total"], "tags may be comma-separated")
+
+	body := get("/api/history?tag=hotfix")
+	executions := body["executions"].([]interface{})
+	require.Len(t, executions, 1)
+	assert.Equal(t, []interface{}{"hotfix", "sprint-12"}, executions[0].(map[string]interface{})["tags"])
+}
+
+func TestStatsRange(t *testing.T) {
+	cfg := config.New()
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	for _, age := range []int{1, 60} {
+		execution := domain.NewExecution(domain.Story{Key: "3-1-test", Epic: 3})
+		execution.Status = domain.ExecutionCompleted
+		execution.StartTime = time.Now().AddDate(0, 0, -age)
+		require.NoError(t, store.SaveExecution(context.Background(), execution))
+	}
+	router := NewServer(cfg, store, executor.New(cfg), executor.NewBatchExecutor(cfg)).setupRoutes()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+	total := func(path string) float64 {
+		rr := get(path)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		return body["total_executions"].(float64)
+	}
+
+	assert.Equal(t, 2.0, total("/api/stats"))
+	assert.Equal(t, 1.0, total("/api/stats?range=30d"))
+	assert.Equal(t, 2.0, total("/api/stats?range=all"))
+	assert.Equal(t, http.StatusBadRequest, get("/api/stats?range=lately").Code)
+}
+
+func TestHistoryNote(t *testing.T) {
+	cfg := config.New()
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	execution := domain.NewExecution(domain.Story{Key: "3-1-test", Epic: 3})
+	execution.Status = domain.ExecutionFailed
+	require.NoError(t, store.SaveExecution(context.Background(), execution))
+	require.NoError(t, store.SetNote(context.Background(), execution.ID, "failed due to flaky test"))
+	router := NewServer(cfg, store, executor.New(cfg), executor.NewBatchExecutor(cfg)).setupRoutes()
+
+	for _, path := range []string{"/api/history/" + execution.ID, "/api/history"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"note":"failed due to flaky test"`, path)
+	}
+}
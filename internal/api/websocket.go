@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,10 +16,16 @@ import (
 // WebSocketMessage represents a message sent over WebSocket
 type WebSocketMessage struct {
 	Type      string      `json:"type"`
+	Topic     string      `json:"topic,omitempty"` // Set on messages only subscribers of the topic need
 	Data      interface{} `json:"data"`
 	Timestamp time.Time   `json:"timestamp"`
 }
 
+// ExecutionTopic is the subscription topic of an execution's output
+func ExecutionTopic(executionID string) string {
+	return "execution:" + executionID
+}
+
 // WebSocketClient represents a connected WebSocket client
 type WebSocketClient struct {
 	hub  *WebSocketHub
@@ -27,6 +34,8 @@ type WebSocketClient struct {
 
 	mu     sync.Mutex
 	closed bool
+	// Topics the client subscribed to; without any it receives everything
+	topics map[string]bool
 }
 
 // WebSocketHub maintains the set of active clients and broadcasts messages
@@ -41,8 +50,10 @@ type WebSocketHub struct {
 	stopCh  chan struct{}
 
 	// Security settings (SEC-005/006)
-	apiKey         string   // API key for authentication (optional)
-	allowedOrigins []string // Allowed WebSocket origins
+	keys           *KeyStore // API keys; nil or empty for no authentication
+	allowedOrigins []string  // Allowed WebSocket origins
+
+	onConnect func() []WebSocketMessage // Messages sent to each new client first
 }
 
 // NewWebSocketHub creates a new WebSocket hub
@@ -58,13 +69,21 @@ func NewWebSocketHub() *WebSocketHub {
 
 // SetSecurityConfig sets the security configuration for the WebSocket hub
 // SEC-005/006 fix: Adds authentication and origin restriction
-func (h *WebSocketHub) SetSecurityConfig(apiKey string, allowedOrigins []string) {
+func (h *WebSocketHub) SetSecurityConfig(keys *KeyStore, allowedOrigins []string) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	h.apiKey = apiKey
+	h.keys = keys
 	h.allowedOrigins = allowedOrigins
 }
 
+// SetConnectHook sets a function whose messages are queued for each new
+// client before it receives any broadcast, e.g. a snapshot of current state
+func (h *WebSocketHub) SetConnectHook(hook func() []WebSocketMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.onConnect = hook
+}
+
 // Run starts the hub's main loop
 func (h *WebSocketHub) Run() {
 	h.mu.Lock()
@@ -100,6 +119,9 @@ func (h *WebSocketHub) Run() {
 		case message := <-h.broadcast:
 			h.mu.RLock()
 			for client := range h.clients {
+				if !client.wants(message.Topic) {
+					continue
+				}
 				select {
 				case client.send <- message:
 				default:
@@ -149,17 +171,18 @@ func (h *WebSocketHub) ClientCount() int {
 // SEC-005/006 fix: Validates API key and restricts origins
 func (h *WebSocketHub) ServeWs(w http.ResponseWriter, r *http.Request) {
 	h.mu.RLock()
-	apiKey := h.apiKey
+	keys := h.keys
 	allowedOrigins := h.allowedOrigins
+	onConnect := h.onConnect
 	h.mu.RUnlock()
 
-	// Validate API key if configured (SEC-005)
-	if apiKey != "" {
+	// Validate API key if configured (SEC-005); any role may watch
+	if keys != nil {
 		providedKey := r.URL.Query().Get("api_key")
 		if providedKey == "" {
 			providedKey = r.Header.Get("X-API-Key")
 		}
-		if providedKey != apiKey {
+		if ok, _ := keys.Authorize(providedKey, RoleRead); !ok {
 			log.Printf("WebSocket connection rejected: invalid API key")
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
@@ -187,6 +210,15 @@ func (h *WebSocketHub) ServeWs(w http.ResponseWriter, r *http.Request) {
 		send: make(chan WebSocketMessage, 64),
 	}
 
+	if onConnect != nil {
+		for _, msg := range onConnect() {
+			select {
+			case client.send <- msg:
+			default:
+			}
+		}
+	}
+
 	h.register <- client
 
 	// Start write pump in goroutine
@@ -204,7 +236,9 @@ func (c *WebSocketClient) readPump() {
 
 	for {
 		var msg map[string]interface{}
-		err := wsjson.Read(c.conn.CloseRead(context.Background()), c.conn, &msg)
+		// CloseRead would start a second reader discarding client messages,
+		// so this loop reads them itself
+		err := wsjson.Read(context.Background(), c.conn, &msg)
 		if err != nil {
 			if websocket.CloseStatus(err) != websocket.StatusNormalClosure &&
 				websocket.CloseStatus(err) != websocket.StatusGoingAway {
@@ -268,16 +302,87 @@ func (c *WebSocketClient) writePump() {
 // handleMessage processes incoming client messages
 func (c *WebSocketClient) handleMessage(msgType string, msg map[string]interface{}) {
 	switch msgType {
-	case "subscribe":
-		// Client wants to subscribe to specific event types
-		// For now, all clients receive all events
+	case "subscribe", "unsubscribe":
+		topics := c.setTopics(messageTopics(msg), msgType == "subscribe")
+		c.reply(WebSocketMessage{
+			Type:      "subscriptions",
+			Data:      map[string]interface{}{"topics": topics},
+			Timestamp: time.Now(),
+		})
 	case "ping":
 		// Respond to ping
-		c.send <- WebSocketMessage{
+		c.reply(WebSocketMessage{
 			Type:      "pong",
 			Timestamp: time.Now(),
+		})
+	}
+}
+
+// messageTopics reads the "topic" or "topics" field of a client message
+func messageTopics(msg map[string]interface{}) []string {
+	var topics []string
+	if topic, ok := msg["topic"].(string); ok && topic != "" {
+		topics = append(topics, topic)
+	}
+	if list, ok := msg["topics"].([]interface{}); ok {
+		for _, item := range list {
+			if topic, ok := item.(string); ok && topic != "" {
+				topics = append(topics, topic)
+			}
 		}
 	}
+	return topics
+}
+
+// setTopics adds or removes subscriptions and returns the remaining ones,
+// sorted
+func (c *WebSocketClient) setTopics(topics []string, subscribe bool) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.topics == nil {
+		c.topics = make(map[string]bool)
+	}
+	for _, topic := range topics {
+		if subscribe {
+			c.topics[topic] = true
+		} else {
+			delete(c.topics, topic)
+		}
+	}
+
+	current := make([]string, 0, len(c.topics))
+	for topic := range c.topics {
+		current = append(current, topic)
+	}
+	sort.Strings(current)
+	return current
+}
+
+// wants reports whether a message on topic is delivered to the client:
+// messages without a topic go to everyone, and clients without
+// subscriptions receive every topic
+func (c *WebSocketClient) wants(topic string) bool {
+	if topic == "" {
+		return true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.topics) == 0 || c.topics[topic]
+}
+
+// reply queues a message for this client only, dropping it when the
+// client is closed or its buffer is full
+func (c *WebSocketClient) reply(msg WebSocketMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return
+	}
+	select {
+	case c.send <- msg:
+	default:
+	}
 }
 
 // close closes the client connection
@@ -312,10 +417,12 @@ type ExecutionUpdateData struct {
 
 // StepOutputData represents step output data
 type StepOutputData struct {
-	StoryKey  string `json:"story_key"`
-	StepIndex int    `json:"step_index"`
-	Line      string `json:"line"`
-	IsStderr  bool   `json:"is_stderr"`
+	ExecutionID string `json:"execution_id"`
+	StoryKey    string `json:"story_key"`
+	StepIndex   int    `json:"step_index"`
+	StepName    string `json:"step_name"`
+	Line        string `json:"line"`
+	IsStderr    bool   `json:"is_stderr"`
 }
 
 // QueueUpdateData represents queue update data
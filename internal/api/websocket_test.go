@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+)
+
+type wsReply struct {
+	Type  string                 `json:"type"`
+	Topic string                 `json:"topic"`
+	Data  map[string]interface{} `json:"data"`
+}
+
+func dialWs(t *testing.T, ctx context.Context, url string) *websocket.Conn {
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(url, "http")+"/api/ws", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+func readWs(t *testing.T, ctx context.Context, conn *websocket.Conn) wsReply {
+	var reply wsReply
+	require.NoError(t, wsjson.Read(ctx, conn, &reply))
+	return reply
+}
+
+func TestWebSocket_StepOutputTopics(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg := config.New()
+	server := NewServer(cfg, nil, executor.New(cfg), executor.NewBatchExecutor(cfg))
+	go server.wsHub.Run()
+	defer server.wsHub.Stop()
+	httpServer := httptest.NewServer(server.setupRoutes())
+	defer httpServer.Close()
+
+	all := dialWs(t, ctx, httpServer.URL)
+	subscriber := dialWs(t, ctx, httpServer.URL)
+
+	require.NoError(t, wsjson.Write(ctx, subscriber, map[string]interface{}{
+		"type":   "subscribe",
+		"topics": []string{ExecutionTopic("exec-a")},
+	}))
+	reply := readWs(t, ctx, subscriber)
+	assert.Equal(t, "subscriptions", reply.Type)
+	assert.Equal(t, []interface{}{"execution:exec-a"}, reply.Data["topics"])
+
+	// Both clients are registered once the hub delivers to them
+	require.Eventually(t, func() bool { return server.wsHub.ClientCount() == 2 }, time.Second, 10*time.Millisecond)
+
+	server.BroadcastStepOutput(StepOutputData{ExecutionID: "exec-b", StoryKey: "3-2-reset", Line: "from b"})
+	server.BroadcastStepOutput(StepOutputData{ExecutionID: "exec-a", StoryKey: "3-1-auth",
+		StepName: string(domain.StepCreateStory), Line: "from a", IsStderr: true})
+
+	first := readWs(t, ctx, all)
+	assert.Equal(t, "step_output", first.Type)
+	assert.Equal(t, "execution:exec-b", first.Topic)
+	assert.Equal(t, "from b", first.Data["line"])
+	assert.Equal(t, "from a", readWs(t, ctx, all).Data["line"], "clients without subscriptions get every topic")
+
+	got := readWs(t, ctx, subscriber)
+	assert.Equal(t, "exec-a", got.Data["execution_id"])
+	assert.Equal(t, "3-1-auth", got.Data["story_key"])
+	assert.Equal(t, 0.0, got.Data["step_index"])
+	assert.Equal(t, string(domain.StepCreateStory), got.Data["step_name"])
+	assert.Equal(t, true, got.Data["is_stderr"])
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// announceChanges prints view switches, execution progress and status
+// updates as plain lines above the inline UI so terminal screen readers
+// pick them up.
+func (m Model) announceChanges(msg tea.Msg, prevView domain.View, prevStatus string) tea.Cmd {
+	var lines []string
+
+	if m.activeView != prevView {
+		lines = append(lines, fmt.Sprintf("View: %s", m.activeView))
+	}
+
+	switch msg := msg.(type) {
+	case messages.StepStartedMsg:
+		lines = append(lines, fmt.Sprintf("Step %s started (attempt %d)", msg.StepName, msg.Attempt))
+	case messages.StepCompletedMsg:
+		line := fmt.Sprintf("Step %d %s in %s", msg.StepIndex+1, msg.Status, formatDuration(msg.Duration))
+		if msg.Error != "" {
+			line += ": " + msg.Error
+		}
+		lines = append(lines, line)
+	case messages.ExecutionCompletedMsg:
+		lines = append(lines, fmt.Sprintf("Execution %s in %s", msg.Status, formatDuration(msg.Duration)))
+	}
+
+	if status := m.statusbar.Message(); status != "" && status != prevStatus {
+		lines = append(lines, fmt.Sprintf("Status: %s", status))
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	cmds := make([]tea.Cmd, len(lines))
+	for i, line := range lines {
+		cmds[i] = tea.Println(line)
+	}
+	return tea.Sequence(cmds...)
+}
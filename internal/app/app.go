@@ -3,8 +3,6 @@ package app
 import (
 	"context"
 	"fmt"
-	"os/exec"
-	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,30 +11,44 @@ import (
 	"github.com/robertguss/bmad-automate-go/internal/components/commandpalette"
 	"github.com/robertguss/bmad-automate-go/internal/components/confetti"
 	"github.com/robertguss/bmad-automate-go/internal/components/header"
+	"github.com/robertguss/bmad-automate-go/internal/components/help"
 	"github.com/robertguss/bmad-automate-go/internal/components/statusbar"
+	"github.com/robertguss/bmad-automate-go/internal/components/steppreview"
+	"github.com/robertguss/bmad-automate-go/internal/components/tour"
 	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/coview"
 	"github.com/robertguss/bmad-automate-go/internal/domain"
 	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/internal/export"
 	"github.com/robertguss/bmad-automate-go/internal/git"
+	"github.com/robertguss/bmad-automate-go/internal/inbox"
 	"github.com/robertguss/bmad-automate-go/internal/messages"
 	"github.com/robertguss/bmad-automate-go/internal/notify"
 	"github.com/robertguss/bmad-automate-go/internal/parser"
 	"github.com/robertguss/bmad-automate-go/internal/preflight"
 	"github.com/robertguss/bmad-automate-go/internal/profile"
+	"github.com/robertguss/bmad-automate-go/internal/schedule"
 	"github.com/robertguss/bmad-automate-go/internal/sound"
 	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/telemetry"
 	"github.com/robertguss/bmad-automate-go/internal/theme"
 	"github.com/robertguss/bmad-automate-go/internal/util"
 	"github.com/robertguss/bmad-automate-go/internal/views/dashboard"
 	"github.com/robertguss/bmad-automate-go/internal/views/diff"
+	"github.com/robertguss/bmad-automate-go/internal/views/epics"
 	"github.com/robertguss/bmad-automate-go/internal/views/execution"
 	"github.com/robertguss/bmad-automate-go/internal/views/history"
+	"github.com/robertguss/bmad-automate-go/internal/views/logs"
+	"github.com/robertguss/bmad-automate-go/internal/views/pipelines"
 	queueview "github.com/robertguss/bmad-automate-go/internal/views/queue"
+	schedulesview "github.com/robertguss/bmad-automate-go/internal/views/schedules"
+	searchview "github.com/robertguss/bmad-automate-go/internal/views/search"
 	"github.com/robertguss/bmad-automate-go/internal/views/settings"
 	"github.com/robertguss/bmad-automate-go/internal/views/stats"
 	"github.com/robertguss/bmad-automate-go/internal/views/storylist"
 	"github.com/robertguss/bmad-automate-go/internal/views/timeline"
 	"github.com/robertguss/bmad-automate-go/internal/watcher"
+	"github.com/robertguss/bmad-automate-go/internal/webhook"
 	"github.com/robertguss/bmad-automate-go/internal/workflow"
 )
 
@@ -58,8 +70,17 @@ type Model struct {
 	stories []domain.Story
 	err     error
 
+	// Sends messages into the running program; shared by every copy of
+	// the model, since SetProgram is called on one made before the run
+	program *programHandle
+
 	// Storage
-	storage storage.Storage
+	storage    storage.Storage
+	storageErr error // Set while running on the in-memory fallback
+	dbOversize bool  // The database has reached DBSizeLimitMB
+
+	// Execution to open on start (set by "bmad open")
+	openExecutionRef string
 
 	// Executors
 	executor         *executor.Executor
@@ -72,31 +93,58 @@ type Model struct {
 
 	// Phase 5: New components
 	commandPalette commandpalette.Model
+	help           help.Model
+	stepPreview    steppreview.Model
 	confetti       confetti.Model
 
+	// Onboarding tour, and the view to return to when it ends
+	tour       tour.Model
+	tourReturn domain.View
+
 	// Phase 5: Services
 	notifier    *notify.Notifier
 	soundPlayer *sound.Player
 	gitStatus   git.Status
 
+	// Opt-in anonymous usage counts
+	telemetry *telemetry.Recorder
+
+	// Execution events POSTed to configured URLs
+	webhooks *webhook.Dispatcher
+
+	// Queue requests dropped into the inbox directory
+	inbox *inbox.Inbox
+
 	// Phase 6: Profile and Workflow
 	profileStore  *profile.ProfileStore
 	workflowStore *workflow.WorkflowStore
 
 	// Phase 6: Watcher
 	watcher *watcher.Watcher
+	// Set while stories reload after a watched file changed
+	watchReload bool
 
 	// Phase 6: API Server
 	apiServer *api.Server
 
+	// Co-viewing: the presenter publishes this instance's screen; the
+	// follower is set instead when attached to another instance
+	presenter *coview.Presenter
+	follower  *coview.Follower
+
 	// Views
 	dashboard dashboard.Model
 	storylist storylist.Model
+	epics     epics.Model
 	execution execution.Model
 	queue     queueview.Model
 	timeline  timeline.Model
 	history   history.Model
 	stats     stats.Model
+	pipelines pipelines.Model
+	logs      logs.Model
+	schedules schedulesview.Model
+	search    searchview.Model
 	diff      diff.Model
 	settings  settings.Model
 
@@ -105,6 +153,52 @@ type Model struct {
 
 	// Pre-flight check results
 	preflightResults *preflight.Results
+
+	// Key of the step last flagged as slow, so each step alerts only once
+	slowStepAlerted string
+
+	// Execution whose workspace restore is waiting for confirmation
+	restoreArmed string
+
+	// Fingerprint of the queue as last persisted
+	savedQueue string
+
+	// Missed deadlines already notified, as "<story key>@<deadline>"
+	deadlineAlerted map[string]bool
+
+	// Delayed queue start: when it fires (zero = none scheduled) and the
+	// generation that invalidates ticks of replaced or cancelled starts
+	queueStartAt  time.Time
+	queueStartGen int
+
+	// Run windows for queue starts, and when a queue paused for its window
+	// resumes (zero = not waiting)
+	runWindows     schedule.Windows
+	windowResumeAt time.Time
+
+	// Cron schedules that start queues, and when they were last checked
+	// for runs that came due
+	scheduleStore     *schedule.Store
+	scheduleCheckedAt time.Time
+
+	// Whether the running execution was minimized to the status bar
+	minimized bool
+
+	// Date range of the stats view; the dashboard always shows all time
+	statsRange domain.DateRange
+}
+
+// newSoundPlayer creates the sound player, using the sound files in the
+// data directory and leaving muted events silent
+func newSoundPlayer(cfg *config.Config) *sound.Player {
+	player := sound.New(cfg.SoundEnabled)
+	player.SetDir(cfg.SoundsDir())
+	for name, muted := range cfg.SoundMuted {
+		if t, ok := sound.ParseEvent(name); ok {
+			player.SetMuted(t, muted)
+		}
+	}
+	return player
 }
 
 // New creates a new application model
@@ -114,13 +208,16 @@ func New(cfg *config.Config) Model {
 	parallelExec := executor.NewParallelExecutor(cfg, cfg.MaxWorkers)
 
 	// Initialize storage
-	var store storage.Storage
-	if err := cfg.EnsureDataDir(); err == nil {
-		store, _ = storage.NewSQLiteStorage(cfg.DatabasePath)
-	}
+	store, storageErr := openStorage(cfg)
 
-	// Apply theme from config
+	// Apply theme from config, which may name a theme file, or else the
+	// one matching the terminal background
+	_, themeErr := loadThemes(cfg)
+	if cfg.Theme == "" {
+		cfg.Theme = defaultTheme(cfg)
+	}
 	theme.SetTheme(cfg.Theme)
+	theme.Accessible = cfg.AccessibleMode
 
 	// Initialize Phase 6: Profile store
 	profileStore := profile.NewProfileStore(cfg.DataDir)
@@ -129,6 +226,11 @@ func New(cfg *config.Config) Model {
 	// Initialize Phase 6: Workflow store
 	workflowStore := workflow.NewWorkflowStore(cfg.DataDir)
 	_ = workflowStore.Load()
+	if w, ok := workflowStore.Get(cfg.ActiveWorkflow); ok {
+		exec.SetWorkflow(w)
+		batchExec.SetWorkflow(w)
+		parallelExec.SetWorkflow(w)
+	}
 
 	// Initialize Phase 6: File watcher
 	fileWatcher := watcher.New(time.Duration(cfg.WatchDebounce) * time.Millisecond)
@@ -137,35 +239,110 @@ func New(cfg *config.Config) Model {
 	// Initialize Phase 6: API server
 	apiServer := api.NewServer(cfg, store, exec, batchExec)
 
-	return Model{
-		activeView:       domain.ViewDashboard,
+	// Schedules are shared with the API, which can trigger them
+	scheduleStore := schedule.NewStore(cfg.DataDir)
+	scheduleErr := scheduleStore.Load()
+	apiServer.SetSchedules(scheduleStore)
+
+	usage := newTelemetry(cfg, store)
+	settingsView := settings.New(cfg)
+	settingsView.SetUsagePreview(usagePreview(usage))
+
+	// Bring back the queue from the last session, opening the queue view
+	// to offer resuming it when stories are still pending
+	activeView := domain.ViewDashboard
+	queueView := queueview.New()
+	if pending := restoreQueue(cfg, store, batchExec.GetQueue()); pending > 0 {
+		activeView = domain.ViewQueue
+		queueView.SetQueue(batchExec.GetQueue())
+		queueView.SetRestored(pending)
+	}
+
+	m := Model{
+		activeView:       activeView,
 		config:           cfg,
+		program:          &programHandle{},
 		storage:          store,
+		storageErr:       storageErr,
 		executor:         exec,
 		batchExecutor:    batchExec,
 		parallelExecutor: parallelExec,
 		header:           header.New(),
 		statusbar:        statusbar.New(),
 		commandPalette:   commandpalette.New(),
+		help:             help.New(),
+		stepPreview:      steppreview.New(),
+		tour:             tour.New(),
 		confetti:         confetti.New(),
 		notifier:         notify.New(cfg.NotificationsEnabled),
-		soundPlayer:      sound.New(cfg.SoundEnabled),
+		soundPlayer:      newSoundPlayer(cfg),
+		telemetry:        usage,
+		webhooks:         webhook.New(cfg.WebhookURLs, cfg.WebhookSecret),
+		inbox:            inbox.New(cfg.InboxDir()),
 		profileStore:     profileStore,
 		workflowStore:    workflowStore,
 		watcher:          fileWatcher,
 		apiServer:        apiServer,
+		presenter:        newPresenter(apiServer),
 		dashboard:        dashboard.New(),
 		storylist:        storylist.New(),
+		epics:            epics.New(),
 		execution:        execution.New(),
-		queue:            queueview.New(),
+		queue:            queueView,
 		timeline:         timeline.New(),
 		history:          history.New(),
 		stats:            stats.New(),
+		pipelines:        pipelines.New(),
+		logs:             logs.New(),
+		schedules:        schedulesview.New(scheduleStore.Path()),
+		search:           searchview.New(),
 		diff:             diff.New(),
-		settings:         settings.New(cfg),
+		settings:         settingsView,
 		styles:           theme.NewStyles(),
 		preflightResults: nil,
+		savedQueue:       queueFingerprint(batchExec.GetQueue()),
+	}
+	m.header.SetActiveView(activeView)
+
+	if cfg.EnvFileErr != nil {
+		m.statusbar.SetMessage("Ignoring saved settings: " + cfg.EnvFileErr.Error())
+	}
+	if windows, err := schedule.ParseWindows(cfg.RunWindows); err != nil {
+		m.statusbar.SetMessage("Ignoring BMAD_RUN_WINDOWS: " + err.Error())
+	} else {
+		m.runWindows = windows
+	}
+	m.scheduleStore = scheduleStore
+	m.scheduleCheckedAt = time.Now()
+	if scheduleErr != nil {
+		m.statusbar.SetMessage("Ignoring schedules: " + scheduleErr.Error())
+	}
+	if themeErr != nil {
+		m.statusbar.SetMessage("Ignoring theme file: " + firstLine(themeErr))
 	}
+	return m
+}
+
+// openStorage opens the SQLite database, falling back to in-memory storage
+// when the data directory or database is unusable. The returned error is
+// non-nil whenever the fallback is in use.
+func openStorage(cfg *config.Config) (storage.Storage, error) {
+	dirErr := cfg.EnsureDataDir()
+	if dirErr != nil {
+		mem, err := storage.NewInMemoryStorage()
+		if err != nil {
+			return nil, dirErr
+		}
+		return mem, dirErr
+	}
+
+	store, err := storage.OpenWithFallback(cfg.DatabasePath)
+	if store == nil {
+		return nil, err
+	}
+	store.SetOutputDir(cfg.OutputDir())
+	store.SetRepoDir(cfg.WorkingDir)
+	return store, err
 }
 
 // SetProgram sets the tea.Program on the executor for async messages
@@ -174,10 +351,22 @@ func (m *Model) SetProgram(p *tea.Program) {
 	m.batchExecutor.SetProgram(p)
 	m.parallelExecutor.SetProgram(p)
 	m.watcher.SetProgram(p)
+	m.inbox.SetProgram(p)
+	m.apiServer.SetProgram(p)
+	if m.follower != nil {
+		m.follower.SetProgram(p)
+	}
+	m.program.send = p.Send
+	m.startPruning()
 }
 
 // Init initializes the application
 func (m Model) Init() tea.Cmd {
+	// A follower only mirrors the presenter, so it runs no checks or services
+	if m.following() {
+		return tea.Batch(m.loadStories, m.followPresenter)
+	}
+
 	cmds := []tea.Cmd{
 		m.loadStories,
 		m.runPreflightChecks,
@@ -185,22 +374,36 @@ func (m Model) Init() tea.Cmd {
 		git.GetStatusCmd(m.config.WorkingDir),
 	}
 
+	// Stats feed the dashboard activity sparkline
+	if m.storage != nil {
+		cmds = append(cmds, m.loadStats(), m.checkDatabaseSize())
+	}
+
+	cmds = append(cmds, m.startAutoRefresh()...)
+
 	// Phase 6: Start watcher if enabled
 	if m.config.WatchEnabled {
 		cmds = append(cmds, m.startWatcher)
 	}
 
+	cmds = append(cmds, scheduleTick())
+
 	// Phase 6: Start API server if enabled
 	if m.config.APIEnabled {
 		cmds = append(cmds, m.startAPIServer)
 	}
 
+	// Deep link from "bmad open <execution-id>"
+	if m.openExecutionRef != "" {
+		cmds = append(cmds, m.openLinkedExecution)
+	}
+
 	return tea.Batch(cmds...)
 }
 
-// loadStories loads stories from sprint-status.yaml
+// loadStories loads stories from the configured story source
 func (m Model) loadStories() tea.Msg {
-	stories, err := parser.ParseSprintStatus(m.config)
+	stories, err := parser.LoadStories(context.Background(), m.config)
 	return messages.StoriesLoadedMsg{Stories: stories, Error: err}
 }
 
@@ -215,28 +418,79 @@ type preflightResultsMsg struct {
 	Results *preflight.Results
 }
 
-// loadHistoricalAverages loads step averages from storage for ETA calculation
+// blockedByPreflight reports whether a failed pre-flight check stops
+// stories from running, naming the first one in the status bar
+func (m *Model) blockedByPreflight() bool {
+	if m.preflightResults == nil {
+		return false
+	}
+	blocking := m.preflightResults.Blocking()
+	if len(blocking) == 0 {
+		return false
+	}
+	m.statusbar.SetMessage(fmt.Sprintf("Cannot execute: %s - %s", blocking[0].Name, blocking[0].Error))
+	return true
+}
+
+// loadHistoricalAverages loads step estimates from storage for ETA calculation
 func (m Model) loadHistoricalAverages() tea.Msg {
 	if m.storage == nil {
 		return nil
 	}
 
-	averages, err := m.storage.GetStepAverages(context.Background())
+	estimates, err := m.storage.GetStepEstimates(context.Background())
 	if err != nil {
 		return nil
 	}
 
-	return historicalAveragesMsg{Averages: averages}
+	return historicalAveragesMsg{Estimates: estimates}
 }
 
-// historicalAveragesMsg carries loaded step averages
+// historicalAveragesMsg carries step estimates built from history
 type historicalAveragesMsg struct {
-	Averages map[domain.StepName]*storage.StepAverage
+	Estimates map[domain.StepName]domain.Estimate
+}
+
+// loadStoryHealth scores stories from their recent runs for the story list
+func (m Model) loadStoryHealth() tea.Msg {
+	if m.storage == nil {
+		return nil
+	}
+
+	health, err := m.storage.GetStoryHealth(context.Background())
+	if err != nil {
+		return nil
+	}
+
+	return storyHealthMsg{Health: health}
+}
+
+// storyHealthMsg carries per-story health scores
+type storyHealthMsg struct {
+	Health map[string]domain.StoryHealth
 }
 
 // Update handles all messages
-// QUAL-001: Refactored to use extracted handlers for better maintainability
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prevView, prevStatus := m.activeView, m.statusbar.Message()
+	next, cmd := m.update(msg)
+	next.persistQueue()
+	if next.presenter != nil {
+		next.presenter.Observe(msg, next.activeView)
+	}
+
+	if !m.config.AccessibleMode {
+		return next, cmd
+	}
+	if announce := next.announceChanges(msg, prevView, prevStatus); announce != nil {
+		cmd = tea.Batch(cmd, announce)
+	}
+	return next, cmd
+}
+
+// update dispatches a message to the appropriate handlers
+// QUAL-001: Refactored to use extracted handlers for better maintainability
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	// Handle command palette messages first if active
@@ -261,30 +515,155 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m = m.handleWindowSizeMsg(msg)
 
+	case tea.FocusMsg:
+		m.notifier.SetFocused(true)
+		m.soundPlayer.SetFocused(true)
+
+	case tea.BlurMsg:
+		m.notifier.SetFocused(false)
+		m.soundPlayer.SetFocused(false)
+
 	case messages.StoriesLoadedMsg:
+		before := m.stories
 		m = m.handleStoriesMsg(msg)
+		if m.watchReload {
+			m.watchReload = false
+			if msg.Error == nil {
+				var cmd tea.Cmd
+				m, cmd = m.handleWatchReload(before)
+				cmds = append(cmds, cmd)
+			}
+		}
+		// The inbox starts once requests can be matched to stories
+		if m.config.InboxEnabled && msg.Error == nil && !m.inbox.IsRunning() && !m.following() {
+			cmds = append(cmds, m.startInbox)
+		}
+		if msg.Error == nil && m.storage != nil {
+			cmds = append(cmds, m.loadStoryHealth)
+		}
+
+	case storyHealthMsg:
+		m.storylist.SetHealth(msg.Health)
 
 	case preflightResultsMsg:
 		m.preflightResults = msg.Results
-		if !msg.Results.AllPass {
-			failed := msg.Results.FailedChecks()
-			if len(failed) > 0 {
-				m.statusbar.SetMessage(fmt.Sprintf("Pre-flight warning: %s", failed[0].Error))
-			}
+		m.dashboard.SetPreflight(msg.Results)
+		if failed := msg.Results.FailedChecks(); len(failed) > 0 {
+			m.statusbar.SetMessage(fmt.Sprintf("Pre-flight warning: %s", failed[0].Error))
+		} else {
+			m.statusbar.SetMessage(fmt.Sprintf("Pre-flight checks passed (%d)", msg.Results.PassedCount()))
+		}
+
+	case messages.ErrorMsg:
+		if msg.Error != nil {
+			m.statusbar.SetMessage(fmt.Sprintf("Error: %v", msg.Error))
 		}
 
+	case storageRepairedMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleStorageRepaired(msg)
+		cmds = append(cmds, cmd)
+
+	case inbox.DropMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleInboxDrop(msg)
+		cmds = append(cmds, cmd)
+
+	case webhookFailedMsg:
+		m.statusbar.SetMessage(fmt.Sprintf("Webhook failed: %v", msg.Error))
+
+	case failureReportedMsg:
+		m = m.handleFailureReported(msg)
+
+	case badgeFailedMsg:
+		m = m.handleBadgeFailed(msg)
+
+	case historyExportedMsg:
+		m = m.handleHistoryExported(msg)
+
+	case statsExportedMsg:
+		m = m.handleStatsExported(msg)
+
+	case settings.ActionMsg:
+		if msg.Name == "Database" {
+			m.statusbar.SetMessage("Running database maintenance...")
+			cmds = append(cmds, m.maintainDatabase())
+		}
+
+	case databaseMaintainedMsg:
+		m = m.handleDatabaseMaintained(msg)
+		cmds = append(cmds, m.checkDatabaseSize())
+
+	case databaseSizeMsg:
+		m = m.handleDatabaseSize(msg)
+
+	case noteSavedMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleNoteSaved(msg)
+		cmds = append(cmds, cmd)
+
+	case steppreview.EditMsg:
+		cmds = append(cmds, m.editPrompt(msg))
+
+	case promptEditedMsg:
+		m = m.handlePromptEdited(msg)
+
+	case steppreview.RunMsg:
+		cmds = append(cmds, m.startExecution(msg.Story, msg.Prompts))
+
+	case historyPrunedMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleHistoryPruned(msg)
+		cmds = append(cmds, cmd, m.checkDatabaseSize())
+
+	case autoRefreshTickMsg:
+		cmds = append(cmds, m.handleAutoRefreshTick(msg))
+
+	case messages.QueueDeadlineMsg:
+		m = m.setDeadline(msg)
+
+	case messages.QueueTagsMsg:
+		m = m.setTags(msg)
+
+	case messages.QueueWindowPausedMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleWindowPaused(msg)
+		cmds = append(cmds, cmd)
+
+	case scheduleTickMsg, scheduleRunSavedMsg, messages.SchedulesRefreshMsg, messages.SchedulesLoadedMsg,
+		messages.ScheduleRunMsg, messages.ScheduleToggleMsg, messages.ScheduleDeleteMsg, messages.ScheduleAddMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleScheduleMsgs(msg)
+		cmds = append(cmds, cmd)
+
+	case windowResumeMsg:
+		m = m.handleWindowResume(msg)
+
+	case delayedStartTickMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleDelayedStartTick(msg)
+		cmds = append(cmds, cmd)
+
+	case workspaceRestoredMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleWorkspaceRestored(msg)
+		cmds = append(cmds, cmd)
+
 	case historicalAveragesMsg:
-		if msg.Averages != nil {
+		if msg.Estimates != nil {
 			queue := m.batchExecutor.GetQueue()
-			for stepName, avg := range msg.Averages {
-				queue.UpdateStepAverage(stepName, avg.AvgDuration)
+			queue.SetStepEstimates(msg.Estimates)
+			stepAverages := make(map[domain.StepName]time.Duration, len(msg.Estimates))
+			for stepName, est := range msg.Estimates {
+				stepAverages[stepName] = est.Mean
 			}
+			m.execution.SetStepAverages(stepAverages)
 		}
 
 	// Execution messages
 	case messages.ExecutionStartMsg, messages.ExecutionStartedMsg, messages.StepStartedMsg,
 		messages.StepOutputMsg, messages.StepCompletedMsg, messages.ExecutionCompletedMsg,
-		messages.ExecutionTickMsg:
+		messages.ExecutionTickMsg, messages.StepStalledMsg, messages.StepWaitingMsg:
 		var execCmds []tea.Cmd
 		m, execCmds = m.handleExecutionMsgs(msg)
 		cmds = append(cmds, execCmds...)
@@ -302,12 +681,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// History, stats, and diff messages
 	case messages.HistoryRefreshMsg, messages.HistoryFilterMsg, messages.HistoryLoadedMsg,
-		messages.HistoryDetailMsg, messages.StatsRefreshMsg, messages.StatsLoadedMsg,
-		messages.DiffRequestMsg, messages.DiffLoadedMsg:
+		messages.HistoryGroupMsg, messages.HistoryGroupsLoadedMsg, messages.HistoryGroupExpandMsg,
+		messages.HistoryGroupExecutionsMsg,
+		messages.HistoryDetailMsg, messages.HistoryLinkMsg, messages.HistoryNoteMsg, messages.StatsRefreshMsg, messages.StatsLoadedMsg, messages.StatsRangeMsg, messages.StatsExportMsg,
+		messages.PipelinesRefreshMsg, messages.PipelineRunsLoadedMsg,
+		messages.LogsRequestMsg, messages.LogsLoadedMsg,
+		messages.DiffRequestMsg, messages.DiffLoadedMsg,
+		messages.SearchRequestMsg, messages.SearchResultsMsg, messages.StoryFocusMsg:
 		var histCmds []tea.Cmd
 		m, histCmds = m.handleHistoryStatsMsgs(msg)
 		cmds = append(cmds, histCmds...)
 
+	// Co-viewing messages
+	case coview.SnapshotMsg, coview.ViewMsg, coview.StatusMsg:
+		m = m.handleCoviewMsgs(msg)
+
 	// Phase 6 messages
 	case messages.ProfileSwitchMsg, messages.ProfileLoadedMsg, messages.WorkflowSwitchMsg,
 		messages.WorkflowLoadedMsg, watcher.RefreshMsg, messages.WatchStatusMsg,
@@ -325,34 +713,52 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-// startExecution begins execution of a story
-func (m *Model) startExecution(story domain.Story) tea.Cmd {
+// startExecution begins execution of a story, sending prompts in place of
+// the workflow's for the steps they name
+func (m *Model) startExecution(story domain.Story, prompts map[domain.StepName]string) tea.Cmd {
 	// Check pre-flight first
-	if m.preflightResults != nil && !m.preflightResults.AllPass {
-		// Find first blocking failure (not Git Clean which is just a warning)
-		for _, check := range m.preflightResults.FailedChecks() {
-			if check.Name != "Git Clean" {
-				m.statusbar.SetMessage(fmt.Sprintf("Cannot execute: %s - %s", check.Name, check.Error))
-				return nil
-			}
-		}
+	if m.blockedByPreflight() {
+		return nil
+	}
+
+	// A minimized run still owns the executor
+	if m.executionActive() {
+		m.statusbar.SetMessage("Cannot execute: another execution is running")
+		return nil
+	}
+
+	if executor.IsStoryRunning(story.Key) {
+		m.statusbar.SetMessage(fmt.Sprintf("Cannot execute: story %s is already running", story.Key))
+		return nil
 	}
 
+	if len(prompts) > 0 {
+		return m.executor.ExecuteWithPrompts(story, prompts)
+	}
 	return m.executor.Execute(story)
 }
 
 // canNavigate returns true if view navigation is allowed
 func (m Model) canNavigate() bool {
-	// Check single story executor
-	exec := m.executor.GetExecution()
-	if exec != nil && (exec.Status == domain.ExecutionRunning || exec.Status == domain.ExecutionPaused) {
-		return false
-	}
-	// Check batch executor
-	if m.batchExecutor.IsRunning() {
-		return false
-	}
-	return true
+	// A minimized execution keeps running in the background
+	return m.minimized || !m.executionActive()
+}
+
+// readOnlyViews only display data, so they stay reachable while an
+// execution runs
+var readOnlyViews = map[domain.View]bool{
+	domain.ViewDashboard: true,
+	domain.ViewHistory:   true,
+	domain.ViewStats:     true,
+	domain.ViewTimeline:  true,
+	domain.ViewPipelines: true,
+	domain.ViewLogs:      true,
+	domain.ViewSearch:    true,
+}
+
+// canView returns true if the view can be opened now
+func (m Model) canView(view domain.View) bool {
+	return readOnlyViews[view] || m.canNavigate()
 }
 
 // View renders the application
@@ -372,6 +778,8 @@ func (m Model) View() string {
 		content = m.dashboard.View()
 	case domain.ViewStoryList:
 		content = m.storylist.View()
+	case domain.ViewEpics:
+		content = m.epics.View()
 	case domain.ViewExecution:
 		content = m.execution.View()
 	case domain.ViewQueue:
@@ -384,6 +792,14 @@ func (m Model) View() string {
 		content = m.history.View()
 	case domain.ViewStats:
 		content = m.stats.View()
+	case domain.ViewPipelines:
+		content = m.pipelines.View()
+	case domain.ViewLogs:
+		content = m.logs.View()
+	case domain.ViewSchedules:
+		content = m.schedules.View()
+	case domain.ViewSearch:
+		content = m.search.View()
 	case domain.ViewSettings:
 		content = m.settings.View()
 	default:
@@ -391,25 +807,44 @@ func (m Model) View() string {
 	}
 
 	// Status bar
+	if m.executionActive() && m.activeView != domain.ViewExecution {
+		m.statusbar.SetProgress(m.executionProgress())
+	} else {
+		m.statusbar.SetProgress("")
+	}
 	statusView := m.statusbar.View()
 
 	// Combine all sections
-	mainView := lipgloss.JoinVertical(lipgloss.Left,
-		headerView,
-		content,
-		statusView,
-	)
+	sections := []string{headerView}
+	if m.storageErr != nil {
+		sections = append(sections, m.renderStorageBanner())
+	}
+	sections = append(sections, content, statusView)
+	mainView := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
 	// Overlay confetti if active
 	if m.confetti.IsActive() {
 		mainView = m.confetti.Overlay(mainView, m.width, m.height)
 	}
 
+	// Overlay the tour callout if running
+	mainView = m.tour.Overlay(mainView)
+
+	// Overlay the step preview if open
+	if m.stepPreview.IsActive() {
+		return m.stepPreview.Overlay(mainView)
+	}
+
 	// Overlay command palette if active
 	if m.commandPalette.IsActive() {
 		return m.commandPalette.Overlay(mainView)
 	}
 
+	// Overlay keyboard help if open
+	if m.help.IsActive() {
+		return m.help.Overlay(mainView)
+	}
+
 	return mainView
 }
 
@@ -434,7 +869,7 @@ func (m Model) renderPlaceholder(title, subtitle string) string {
 	}
 
 	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Padding(2, 4).
 		Render(content)
@@ -482,6 +917,8 @@ func (m Model) loadHistory() tea.Cmd {
 				Duration:  rec.Duration,
 				StepCount: len(rec.Steps),
 				ErrorMsg:  rec.Error,
+				Tags:      rec.Tags,
+				Note:      rec.Note,
 			})
 		}
 
@@ -499,12 +936,7 @@ func (m Model) loadHistoryFiltered(query string, epic *int, status domain.Execut
 			return messages.HistoryLoadedMsg{Error: fmt.Errorf("storage not available")}
 		}
 
-		filter := &storage.ExecutionFilter{
-			StoryKey: query,
-			Epic:     epic,
-			Status:   status,
-			Limit:    100,
-		}
+		filter := historyQueryFilter(query, epic, status)
 
 		records, err := m.storage.ListExecutions(context.Background(), filter)
 		if err != nil {
@@ -524,6 +956,8 @@ func (m Model) loadHistoryFiltered(query string, epic *int, status domain.Execut
 				Duration:  rec.Duration,
 				StepCount: len(rec.Steps),
 				ErrorMsg:  rec.Error,
+				Tags:      rec.Tags,
+				Note:      rec.Note,
 			})
 		}
 
@@ -534,6 +968,83 @@ func (m Model) loadHistoryFiltered(query string, epic *int, status domain.Execut
 	}
 }
 
+// reloadHistory loads the history list, grouped if a grouping is active
+func (m Model) reloadHistory() tea.Cmd {
+	if groupBy := m.history.GroupBy(); groupBy != "" {
+		return m.loadHistoryGroups(groupBy)
+	}
+	return m.loadHistory()
+}
+
+// historyFilter returns the storage filter for the history view's filter
+func (m Model) historyFilter() *storage.ExecutionFilter {
+	return historyQueryFilter(m.history.GetFilter())
+}
+
+// loadHistoryGroups loads per-group aggregates for the history view
+func (m Model) loadHistoryGroups(groupBy string) tea.Cmd {
+	filter := m.historyFilter()
+	return func() tea.Msg {
+		if m.storage == nil {
+			return messages.HistoryGroupsLoadedMsg{GroupBy: groupBy, Error: fmt.Errorf("storage not available")}
+		}
+
+		groups, err := m.storage.GroupExecutions(context.Background(), storage.GroupBy(groupBy), filter)
+		if err != nil {
+			return messages.HistoryGroupsLoadedMsg{GroupBy: groupBy, Error: err}
+		}
+
+		result := make([]*messages.HistoryGroup, 0, len(groups))
+		for _, g := range groups {
+			result = append(result, &messages.HistoryGroup{
+				Key:           g.Key,
+				Count:         g.Count,
+				SuccessCount:  g.SuccessCount,
+				FailedCount:   g.FailedCount,
+				SuccessRate:   g.SuccessRate,
+				AvgDuration:   g.AvgDuration,
+				TotalDuration: g.TotalDuration,
+				LastRun:       g.LastRun,
+			})
+		}
+		return messages.HistoryGroupsLoadedMsg{GroupBy: groupBy, Groups: result}
+	}
+}
+
+// loadHistoryGroup loads the executions in one history group
+func (m Model) loadHistoryGroup(groupBy, key string) tea.Cmd {
+	filter := m.historyFilter()
+	filter.GroupBy = storage.GroupBy(groupBy)
+	filter.GroupKey = key
+	return func() tea.Msg {
+		if m.storage == nil {
+			return messages.HistoryGroupExecutionsMsg{GroupBy: groupBy, Key: key, Error: fmt.Errorf("storage not available")}
+		}
+
+		records, err := m.storage.ListExecutions(context.Background(), filter)
+		if err != nil {
+			return messages.HistoryGroupExecutionsMsg{GroupBy: groupBy, Key: key, Error: err}
+		}
+
+		executions := make([]*messages.HistoryExecution, 0, len(records))
+		for _, rec := range records {
+			executions = append(executions, &messages.HistoryExecution{
+				ID:        rec.ID,
+				StoryKey:  rec.StoryKey,
+				StoryEpic: rec.StoryEpic,
+				Status:    rec.Status,
+				StartTime: rec.StartTime,
+				Duration:  rec.Duration,
+				StepCount: len(rec.Steps),
+				ErrorMsg:  rec.Error,
+				Tags:      rec.Tags,
+				Note:      rec.Note,
+			})
+		}
+		return messages.HistoryGroupExecutionsMsg{GroupBy: groupBy, Key: key, Executions: executions}
+	}
+}
+
 // loadExecutionDetail loads full execution details
 func (m Model) loadExecutionDetail(id string) tea.Cmd {
 	return func() tea.Msg {
@@ -548,6 +1059,7 @@ func (m Model) loadExecutionDetail(id string) tea.Cmd {
 
 		// Convert storage record to domain execution for viewing
 		execution := &domain.Execution{
+			ID: record.ID,
 			Story: domain.Story{
 				Key:    record.StoryKey,
 				Epic:   record.StoryEpic,
@@ -558,20 +1070,33 @@ func (m Model) loadExecutionDetail(id string) tea.Cmd {
 			EndTime:   record.EndTime,
 			Duration:  record.Duration,
 			Error:     record.Error,
+			Snapshot:  record.Snapshot,
+			Context:   record.Context,
+			Branch:    record.Branch,
+			Stash:     record.Stash,
+			Tags:      record.Tags,
 			Steps:     make([]*domain.StepExecution, 0, len(record.Steps)),
 		}
 
 		for _, step := range record.Steps {
+			output := step.Output
+			if step.OutputFile != "" {
+				output = append([]string{fmt.Sprintf("[earlier output truncated - full output in %s]", step.OutputFile)}, output...)
+			}
 			execution.Steps = append(execution.Steps, &domain.StepExecution{
 				Name:      step.StepName,
 				Status:    step.Status,
 				StartTime: step.StartTime,
 				EndTime:   step.EndTime,
 				Duration:  step.Duration,
-				Output:    step.Output,
+				Output:    output,
 				Error:     step.Error,
 				Attempt:   step.Attempt,
 				Command:   step.Command,
+
+				PreviousAttempts: step.PreviousAttempts,
+				Usage:            step.Usage,
+				PromptOverride:   step.PromptOverride,
 			})
 		}
 
@@ -581,18 +1106,31 @@ func (m Model) loadExecutionDetail(id string) tea.Cmd {
 
 // loadStats loads statistics from storage
 func (m Model) loadStats() tea.Cmd {
+	if m.statsRange.IsAllTime() {
+		return m.loadStatsFor(domain.DateRange{})
+	}
+	return tea.Batch(m.loadStatsFor(m.statsRange), m.loadStatsFor(domain.DateRange{}))
+}
+
+// unreliableStoryLimit is how many stories the stats view lists as
+// failing or retrying most
+const unreliableStoryLimit = 10
+
+// loadStatsFor loads the statistics of the executions started within r
+func (m Model) loadStatsFor(r domain.DateRange) tea.Cmd {
 	return func() tea.Msg {
 		if m.storage == nil {
 			return messages.StatsLoadedMsg{Error: fmt.Errorf("storage not available")}
 		}
 
-		storageStats, err := m.storage.GetStats(context.Background())
+		storageStats, err := m.storage.GetStats(context.Background(), r)
 		if err != nil {
 			return messages.StatsLoadedMsg{Error: err}
 		}
 
 		// Convert storage stats to messages stats
 		statsData := &messages.StatsData{
+			Range:            r,
 			TotalExecutions:  storageStats.TotalExecutions,
 			SuccessfulCount:  storageStats.SuccessfulCount,
 			FailedCount:      storageStats.FailedCount,
@@ -607,41 +1145,126 @@ func (m Model) loadStats() tea.Cmd {
 
 		for name, ss := range storageStats.StepStats {
 			statsData.StepStats[name] = &messages.StepStatsData{
-				StepName:     ss.StepName,
-				TotalCount:   ss.TotalCount,
-				SuccessCount: ss.SuccessCount,
-				FailureCount: ss.FailureCount,
-				SkippedCount: ss.SkippedCount,
-				SuccessRate:  ss.SuccessRate,
-				AvgDuration:  ss.AvgDuration,
-				MinDuration:  ss.MinDuration,
-				MaxDuration:  ss.MaxDuration,
+				StepName:          ss.StepName,
+				TotalCount:        ss.TotalCount,
+				SuccessCount:      ss.SuccessCount,
+				FailureCount:      ss.FailureCount,
+				SkippedCount:      ss.SkippedCount,
+				SuccessRate:       ss.SuccessRate,
+				AvgDuration:       ss.AvgDuration,
+				MinDuration:       ss.MinDuration,
+				MaxDuration:       ss.MaxDuration,
+				DurationHistogram: ss.DurationHistogram,
+			}
+			if a := ss.Attempts; a != nil {
+				statsData.StepStats[name].Retries = &messages.RetryStatsData{
+					Attempts:       a.Attempts,
+					Runs:           a.Runs,
+					Retried:        a.Retried,
+					Recovered:      a.Recovered,
+					FirstTryRate:   a.FirstTryRate,
+					AttemptsPerRun: a.AttemptsPerRun,
+				}
+			}
+		}
+
+		if cal, err := m.storage.GetCalibration(context.Background()); err == nil && len(cal.Stories) > 0 {
+			statsData.Calibration = calibrationData(cal)
+		}
+
+		if usage, err := m.storage.GetUsageStats(context.Background()); err == nil && usage.Executions > 0 {
+			statsData.Usage = &messages.UsageData{
+				Executions:   usage.Executions,
+				Total:        usage.Total,
+				PerExecution: usage.PerExecution(),
+				Steps:        usage.Steps,
+			}
+		}
+
+		if trend, err := m.storage.GetDurationTrend(context.Background(), r); err == nil {
+			for _, wd := range trend {
+				statsData.DurationTrend = append(statsData.DurationTrend, &messages.WeeklyDurationData{
+					Week: wd.Week, Count: wd.Count, Avg: wd.Avg, Steps: wd.Steps,
+				})
+			}
+		}
+
+		if stories, err := m.storage.GetStoryReliability(context.Background(), r, unreliableStoryLimit); err == nil {
+			for _, sr := range stories {
+				statsData.Unreliable = append(statsData.Unreliable, &messages.StoryReliabilityData{
+					StoryKey: sr.StoryKey, Runs: sr.Runs, Failures: sr.Failures, Retries: sr.Retries,
+					FailureRate: sr.FailureRate(), LastFailure: sr.LastFailure,
+				})
 			}
 		}
 
+		if dl, err := m.storage.GetDeadlineStats(context.Background()); err == nil && dl.Total > 0 {
+			statsData.Deadlines = &messages.DeadlineData{Total: dl.Total, Met: dl.Met, HitRate: dl.HitRate()}
+		}
+
 		return messages.StatsLoadedMsg{Stats: statsData}
 	}
 }
 
-// loadDiff loads git diff for a story
-func (m Model) loadDiff(storyKey string) tea.Cmd {
+// loadPipelineRuns loads recent pipeline runs from storage
+func (m Model) loadPipelineRuns() tea.Cmd {
 	return func() tea.Msg {
-		// Run git diff command
-		cmd := exec.Command("git", "diff")
-		cmd.Dir = m.config.WorkingDir
+		if m.storage == nil {
+			return messages.PipelineRunsLoadedMsg{Error: fmt.Errorf("storage not available")}
+		}
+		runs, err := m.storage.ListPipelineRuns(context.Background(), 50)
+		return messages.PipelineRunsLoadedMsg{Runs: runs, Error: err}
+	}
+}
 
-		output, err := cmd.Output()
-		if err != nil {
-			return messages.DiffLoadedMsg{
-				StoryKey: storyKey,
-				Error:    err,
-			}
+// calibrationData converts storage calibration for the stats view
+func calibrationData(cal *storage.Calibration) *messages.CalibrationData {
+	data := &messages.CalibrationData{
+		Samples:      len(cal.Stories),
+		MeanAbsError: cal.MeanAbsError,
+		Bias:         cal.Bias,
+		ErrorTrend:   make([]float64, len(cal.Stories)),
+		Steps:        make(map[domain.StepName]*messages.StepCalibrationData, len(cal.Steps)),
+	}
+	for i, rec := range cal.Stories {
+		data.ErrorTrend[i] = rec.PercentError()
+	}
+	for name, sc := range cal.Steps {
+		data.Steps[name] = &messages.StepCalibrationData{
+			StepName:     sc.StepName,
+			Samples:      sc.Samples,
+			AvgPredicted: sc.AvgPredicted,
+			AvgActual:    sc.AvgActual,
+			MeanAbsError: sc.MeanAbsError,
+			Bias:         sc.Bias,
 		}
+	}
+	return data
+}
 
-		return messages.DiffLoadedMsg{
-			StoryKey: storyKey,
-			Content:  strings.TrimSpace(string(output)),
+// loadDiff loads the working tree's changes since base, or those source
+// names (a git.Diff* value) when base is empty
+func (m Model) loadDiff(storyKey, base, source string) tea.Cmd {
+	workDir := m.config.WorkingDir
+	return func() tea.Msg {
+		var content string
+		var err error
+		if base != "" {
+			content, err = git.DiffAgainst(workDir, base)
+		} else {
+			content, err = git.Diff(workDir, source)
 		}
+		return messages.DiffLoadedMsg{StoryKey: storyKey, Source: source, Content: content, Error: err}
+	}
+}
+
+// loadStoryDiff loads what was changed for a story since the configured
+// diff base, from its branch or the commits that mention it
+func (m Model) loadStoryDiff(storyKey string) tea.Cmd {
+	workDir, base, branch := m.config.WorkingDir, m.config.DiffBase, m.config.StoryBranch(storyKey)
+	return func() tea.Msg {
+		content, err := git.StoryDiff(workDir, base, branch, storyKey)
+		return messages.DiffLoadedMsg{StoryKey: storyKey, Base: base, Content: content, Error: err}
 	}
 }
 
@@ -650,8 +1273,10 @@ func (m *Model) refreshAllStyles() {
 	m.styles = theme.NewStyles()
 	m.header = header.New()
 	m.statusbar = statusbar.New()
+	dashboardStats := m.dashboard.Stats()
 	m.dashboard = dashboard.New()
 	m.storylist.RefreshStyles()
+	m.epics.RefreshStyles()
 	m.execution.RefreshStyles()
 	m.queue.RefreshStyles()
 	m.timeline.RefreshStyles()
@@ -660,6 +1285,7 @@ func (m *Model) refreshAllStyles() {
 	m.diff.RefreshStyles()
 	m.settings.RefreshStyles()
 	m.commandPalette = commandpalette.New()
+	m.apiServer.SetTheme(theme.Current)
 
 	// Re-propagate data to views
 	m.header.SetWidth(m.width)
@@ -668,7 +1294,10 @@ func (m *Model) refreshAllStyles() {
 	m.statusbar.SetGitInfo(m.gitStatus.Branch, m.gitStatus.IsClean)
 	m.statusbar.SetStoryCounts(len(m.stories), m.batchExecutor.GetQueue().TotalCount())
 	m.dashboard.SetStories(m.stories)
+	m.dashboard.SetStats(dashboardStats)
+	m.dashboard.SetPreflight(m.preflightResults)
 	m.storylist.SetStories(m.stories)
+	m.epics.SetStories(m.stories)
 }
 
 // handlePaletteAction handles actions from the command palette
@@ -677,11 +1306,33 @@ func (m Model) handlePaletteAction(action string) (Model, tea.Cmd) {
 	case "start_queue":
 		queue := m.batchExecutor.GetQueue()
 		if queue.Status == domain.QueueIdle && queue.HasPending() {
+			if m.blockedByPreflight() {
+				return m, nil
+			}
+			if m, cmd, deferred := m.deferToRunWindow(); deferred {
+				return m, cmd
+			}
 			m.prevView = m.activeView
 			m.activeView = domain.ViewExecution
 			m.header.SetActiveView(m.activeView)
 			return m, m.batchExecutor.Start()
 		}
+	case "start_tour":
+		return m.startTour()
+	case "minimize_execution":
+		return m.minimizeExecution(), nil
+	case "show_execution":
+		return m.showExecution(), nil
+	case "start_queue_5m":
+		return m.scheduleQueueStart(5 * time.Minute)
+	case "start_queue_15m":
+		return m.scheduleQueueStart(15 * time.Minute)
+	case "start_queue_30m":
+		return m.scheduleQueueStart(30 * time.Minute)
+	case "start_queue_60m":
+		return m.scheduleQueueStart(time.Hour)
+	case "cancel_delayed_start":
+		return m.cancelQueueStart(), nil
 	case "pause_queue":
 		if m.batchExecutor.IsRunning() && !m.batchExecutor.IsPaused() {
 			m.batchExecutor.Pause()
@@ -724,6 +1375,39 @@ func (m Model) handlePaletteAction(action string) (Model, tea.Cmd) {
 		} else {
 			m.statusbar.SetMessage("Sequential mode enabled")
 		}
+	case "prune_history":
+		if m.retentionPolicy().IsZero() {
+			m.statusbar.SetMessage("No history retention limits are configured (BMAD_HISTORY_MAX_*)")
+			return m, nil
+		}
+		m.statusbar.SetMessage("Pruning history...")
+		return m, m.pruneHistory()
+	case "repair_database":
+		m.statusbar.SetMessage("Repairing database...")
+		return m, m.repairDatabase
+	case "export_history_json":
+		m.statusbar.SetMessage("Exporting history...")
+		return m, m.exportHistory(export.FormatJSON, false)
+	case "export_history_json_output":
+		m.statusbar.SetMessage("Exporting history...")
+		return m, m.exportHistory(export.FormatJSON, true)
+	case "export_history_csv":
+		m.statusbar.SetMessage("Exporting history...")
+		return m, m.exportHistory(export.FormatCSV, false)
+	case "reload_themes":
+		return m.reloadThemes()
+	case "run_preflight":
+		m.statusbar.SetMessage("Running pre-flight checks...")
+		return m, m.runPreflightChecks
+	case "export_stats_html":
+		m.statusbar.SetMessage("Exporting stats report...")
+		return m, m.exportStatsReport()
+	case "restore_workspace":
+		if m.activeView != domain.ViewExecution {
+			m.statusbar.SetMessage("Open the execution to restore from the execution view")
+			return m, nil
+		}
+		return m.requestWorkspaceRestore()
 	}
 	return m, nil
 }
@@ -769,6 +1453,10 @@ func (m *Model) Cleanup() {
 		_ = m.watcher.Stop()
 	}
 
+	if m.inbox != nil && m.inbox.IsRunning() {
+		_ = m.inbox.Stop()
+	}
+
 	// Stop API server if running
 	if m.apiServer != nil && m.apiServer.IsRunning() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
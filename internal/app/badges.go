@@ -0,0 +1,39 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/badge"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// badgeFailedMsg reports a story file whose badge could not be written
+type badgeFailedMsg struct {
+	StoryKey string
+	Error    error
+}
+
+// recordBadge returns a command writing the result of a finished execution
+// into its story file, or nil when badges are off. A follower leaves the
+// files to the instance it mirrors.
+func (m Model) recordBadge(exec *domain.Execution) tea.Cmd {
+	if !m.config.StoryBadges || m.following() || exec == nil || !exec.IsFinished() {
+		return nil
+	}
+
+	cfg := m.config
+	return func() tea.Msg {
+		if err := badge.Record(cfg, exec); err != nil {
+			return badgeFailedMsg{StoryKey: exec.Story.Key, Error: err}
+		}
+		return nil
+	}
+}
+
+// handleBadgeFailed shows why a story badge was not written
+func (m Model) handleBadgeFailed(msg badgeFailedMsg) Model {
+	m.statusbar.SetMessage(fmt.Sprintf("Badge for %s not written: %v", msg.StoryKey, msg.Error))
+	return m
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/api"
+	"github.com/robertguss/bmad-automate-go/internal/coview"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// newPresenter publishes co-viewing events through the API server's
+// WebSocket and sends each new follower a snapshot first
+func newPresenter(server *api.Server) *coview.Presenter {
+	presenter := coview.NewPresenter(func(ev coview.Event) {
+		server.BroadcastMessage(coview.MessageType, ev)
+	})
+	server.GetWebSocketHub().SetConnectHook(func() []api.WebSocketMessage {
+		return []api.WebSocketMessage{{
+			Type:      coview.MessageType,
+			Data:      presenter.Snapshot(),
+			Timestamp: time.Now(),
+		}}
+	})
+	return presenter
+}
+
+// Attach turns the app into a read-only follower of the instance whose
+// API server is at address. It must be called before the program starts.
+func (m *Model) Attach(address string) error {
+	follower, err := coview.NewFollower(address, m.config.APIKey)
+	if err != nil {
+		return err
+	}
+	m.follower = follower
+	m.presenter = nil
+	m.execution.SetReadOnly(true)
+	return nil
+}
+
+// following reports whether the app mirrors another instance
+func (m Model) following() bool {
+	return m.follower != nil
+}
+
+// followPresenter connects to the presenter and keeps reconnecting
+func (m Model) followPresenter() tea.Msg {
+	go m.follower.Run(context.Background())
+	return nil
+}
+
+// handleFollowerKeys allows only quitting and scrolling the mirrored
+// output; the presenter decides what is on screen
+func (m Model) handleFollowerKeys(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "ctrl+c", "ctrl+q":
+		return m, tea.Quit, true
+	case "up", "down", "pgup", "pgdown", "home", "end":
+		if m.activeView == domain.ViewExecution {
+			return m, nil, false
+		}
+	}
+	return m, nil, true
+}
+
+// handleCoviewMsgs applies the presenter's view and connection changes
+func (m Model) handleCoviewMsgs(msg tea.Msg) Model {
+	switch msg := msg.(type) {
+	case coview.SnapshotMsg:
+		if msg.Execution != nil {
+			m.execution.SetExecution(msg.Execution)
+		}
+		m.activeView = msg.View
+		m.header.SetActiveView(m.activeView)
+
+	case coview.ViewMsg:
+		m.activeView = msg.View
+		m.header.SetActiveView(m.activeView)
+
+	case coview.StatusMsg:
+		if msg.Connected {
+			m.statusbar.SetMessage(fmt.Sprintf("Following %s (read-only)", m.follower.URL()))
+		} else {
+			m.statusbar.SetMessage(fmt.Sprintf("Lost presenter: %v - reconnecting", msg.Error))
+		}
+	}
+	return m
+}
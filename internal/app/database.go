@@ -0,0 +1,166 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+	"github.com/robertguss/bmad-automate-go/internal/util"
+)
+
+// storageRepairedMsg carries the result of a database repair attempt
+type storageRepairedMsg struct {
+	Storage *storage.SQLiteStorage
+	Backup  string // Where the unusable database was moved, if anywhere
+	Error   error
+}
+
+// repairDatabase reopens or recreates the on-disk database
+func (m Model) repairDatabase() tea.Msg {
+	if err := m.config.EnsureDataDir(); err != nil {
+		return storageRepairedMsg{Error: err}
+	}
+	store, backup, err := storage.RepairDatabase(m.config.DatabasePath)
+	return storageRepairedMsg{Storage: store, Backup: backup, Error: err}
+}
+
+// handleStorageRepaired swaps the in-memory fallback for the repaired
+// database. Executions recorded while on the fallback are not carried over.
+func (m Model) handleStorageRepaired(msg storageRepairedMsg) (Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.storageErr = msg.Error
+		m.statusbar.SetMessage(fmt.Sprintf("Database repair failed: %v", msg.Error))
+		return m, nil
+	}
+
+	if m.storage != nil {
+		_ = m.storage.Close()
+	}
+	msg.Storage.SetOutputDir(m.config.OutputDir())
+	msg.Storage.SetRepoDir(m.config.WorkingDir)
+	m.storage = msg.Storage
+	m.storageErr = nil
+	m.apiServer.SetStorage(msg.Storage)
+	m.startPruning()
+
+	status := "Database repaired"
+	if msg.Backup != "" {
+		status += fmt.Sprintf(" - unreadable file saved as %s", msg.Backup)
+	}
+	m.statusbar.SetMessage(status)
+
+	// The banner is gone, so views get its line back
+	if m.ready {
+		m = m.handleWindowSizeMsg(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	}
+
+	return m, tea.Batch(m.loadStats(), m.loadHistoricalAverages, m.checkDatabaseSize())
+}
+
+// bannerHeight is the number of lines taken by the storage warning banner
+func (m Model) bannerHeight() int {
+	if m.storageErr == nil {
+		return 0
+	}
+	return 1
+}
+
+// renderStorageBanner renders the persistent warning shown while history
+// is only kept in memory
+func (m Model) renderStorageBanner() string {
+	t := theme.Current
+
+	text := fmt.Sprintf(" ⚠ Database unavailable (%v) - history will not be saved. Ctrl+P → Repair Database ", m.storageErr)
+
+	return lipgloss.NewStyle().
+		Foreground(t.Background).
+		Background(t.Error).
+		Bold(true).
+		Width(m.width).
+		MaxWidth(m.width).
+		MaxHeight(1).
+		Render(text)
+}
+
+// databaseMaintainedMsg carries the result of database maintenance
+type databaseMaintainedMsg struct {
+	Result *storage.MaintenanceResult
+	Error  error
+}
+
+// maintainDatabase checks, vacuums and analyzes the database
+func (m Model) maintainDatabase() tea.Cmd {
+	store := m.storage
+	return func() tea.Msg {
+		if store == nil {
+			return databaseMaintainedMsg{Error: fmt.Errorf("storage not available")}
+		}
+		result, err := store.Maintenance(context.Background())
+		return databaseMaintainedMsg{Result: result, Error: err}
+	}
+}
+
+// handleDatabaseMaintained reports the reclaimed space, or the integrity
+// problems that stopped maintenance
+func (m Model) handleDatabaseMaintained(msg databaseMaintainedMsg) Model {
+	switch {
+	case msg.Error != nil:
+		m.statusbar.SetMessage(fmt.Sprintf("Database maintenance failed: %v", msg.Error))
+	case !msg.Result.OK():
+		m.statusbar.SetMessage(fmt.Sprintf("Integrity check failed (%s) - run Repair Database from the command palette", msg.Result.Problems[0]))
+	default:
+		m.statusbar.SetMessage(fmt.Sprintf("Database maintained: integrity ok, %s reclaimed (now %s)",
+			util.FormatBytes(msg.Result.Reclaimed()), util.FormatBytes(msg.Result.SizeAfter)))
+	}
+	return m
+}
+
+// databaseSizeMsg carries the size of the database file
+type databaseSizeMsg struct {
+	Size  int64
+	Error error
+}
+
+// checkDatabaseSize reads the database size for the size guard
+func (m Model) checkDatabaseSize() tea.Cmd {
+	store := m.storage
+	if store == nil || m.config.DBSizeLimitMB == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		size, err := store.Size(context.Background())
+		return databaseSizeMsg{Size: size, Error: err}
+	}
+}
+
+// handleDatabaseSize trips the size guard once the database reaches its
+// limit, truncating new step output and pinning a warning that suggests
+// pruning, and resets it once the database is back under
+func (m Model) handleDatabaseSize(msg databaseSizeMsg) Model {
+	if msg.Error != nil {
+		return m
+	}
+	limit := int64(m.config.DBSizeLimitMB) << 20
+	over := msg.Size >= limit
+	if store, ok := m.storage.(*storage.SQLiteStorage); ok {
+		store.TruncateOutput(over)
+	}
+
+	switch {
+	case over:
+		m.statusbar.SetWarning(fmt.Sprintf("DB %s of %s", util.FormatBytes(msg.Size), util.FormatBytes(limit)))
+		if !m.dbOversize {
+			m.statusbar.SetMessage(fmt.Sprintf("Database is over its %s limit - new step output is truncated, full output goes to %s. Ctrl+P → Prune History",
+				util.FormatBytes(limit), m.config.OutputDir()))
+		}
+	case m.dbOversize:
+		m.statusbar.SetWarning("")
+		m.statusbar.SetMessage("Database is back under its size limit - step output is stored in full again")
+	}
+	m.dbOversize = over
+	return m
+}
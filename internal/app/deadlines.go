@@ -0,0 +1,82 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// setDeadline sets or clears the deadline of a queued story, or of the
+// whole queue, and reorders the queue when it runs earliest deadline first
+func (m Model) setDeadline(msg messages.QueueDeadlineMsg) Model {
+	now := time.Now()
+	deadline, err := domain.ParseDeadline(msg.Spec, now)
+	if err != nil {
+		m.statusbar.SetMessage(err.Error())
+		return m
+	}
+
+	queue := m.batchExecutor.GetQueue()
+	target := "queue"
+	if msg.Key != "" {
+		item := queue.GetItem(queue.IndexOf(msg.Key))
+		if item == nil {
+			m.statusbar.SetMessage("Not in the queue: " + msg.Key)
+			return m
+		}
+		item.Deadline = deadline
+		target = msg.Key
+	} else {
+		queue.Deadline = deadline
+	}
+
+	if deadline.IsZero() {
+		m.statusbar.SetMessage("Deadline cleared for " + target)
+		return m
+	}
+	if m.config.QueueOrder == config.QueueOrderDeadline {
+		queue.OrderByDeadline()
+	}
+
+	status := fmt.Sprintf("Deadline for %s: %s", target, deadline.Format("Mon 15:04"))
+	var atRisk []string
+	for _, est := range queue.EstimateItems(nil) {
+		if risk := est.Risk(now); risk == domain.DeadlineAtRisk || risk == domain.DeadlineLate {
+			atRisk = append(atRisk, est.Story.Key)
+		}
+	}
+	if len(atRisk) > 0 {
+		status += fmt.Sprintf(" - at risk: %s", strings.Join(atRisk, ", "))
+	}
+	m.statusbar.SetMessage(status)
+	return m
+}
+
+// checkDeadlines notifies once for each queue item whose deadline has
+// passed without it completing
+func (m Model) checkDeadlines() Model {
+	if m.following() {
+		return m
+	}
+	queue := m.batchExecutor.GetQueue()
+	for _, item := range queue.Breaches(time.Now()) {
+		deadline := queue.DeadlineOf(item)
+		key := item.Story.Key + "@" + deadline.Format(time.RFC3339)
+		if m.deadlineAlerted[key] {
+			continue
+		}
+		if m.deadlineAlerted == nil {
+			m.deadlineAlerted = make(map[string]bool)
+		}
+		m.deadlineAlerted[key] = true
+
+		status := fmt.Sprintf("Deadline missed: %s was due by %s", item.Story.Key, deadline.Format("Mon 15:04"))
+		m.statusbar.SetMessage(status)
+		_ = m.notifier.NotifyError("Deadline Missed", status)
+	}
+	return m
+}
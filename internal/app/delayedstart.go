@@ -0,0 +1,102 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// delayedStartTickMsg updates the countdown of a delayed queue start. Ticks
+// from a start that was cancelled or replaced carry an old generation and
+// are ignored.
+type delayedStartTickMsg struct {
+	Gen int
+}
+
+// delayedStartTick schedules the next countdown tick
+func delayedStartTick(gen int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return delayedStartTickMsg{Gen: gen}
+	})
+}
+
+// scheduleQueueStart arms the queue to start after delay, replacing any
+// start already scheduled. The queue can still be edited until then.
+func (m Model) scheduleQueueStart(delay time.Duration) (Model, tea.Cmd) {
+	if m.batchExecutor.IsRunning() {
+		m.statusbar.SetMessage("Queue is already running")
+		return m, nil
+	}
+
+	m.queueStartAt = time.Now().Add(delay)
+	m.queueStartGen++
+	m.statusbar.SetCountdown(queueCountdown(delay))
+	m.statusbar.SetMessage(fmt.Sprintf("Queue starts at %s - use Cancel Delayed Start to stop it",
+		m.queueStartAt.Format("15:04")))
+	return m, delayedStartTick(m.queueStartGen)
+}
+
+// cancelQueueStart disarms a delayed queue start
+func (m Model) cancelQueueStart() Model {
+	if m.queueStartAt.IsZero() {
+		m.statusbar.SetMessage("No delayed start scheduled")
+		return m
+	}
+	m.queueStartAt = time.Time{}
+	m.queueStartGen++
+	m.statusbar.SetCountdown("")
+	m.statusbar.SetMessage("Delayed start cancelled")
+	return m
+}
+
+// handleDelayedStartTick counts down and starts the queue once the time
+// has come
+func (m Model) handleDelayedStartTick(msg delayedStartTickMsg) (Model, tea.Cmd) {
+	if msg.Gen != m.queueStartGen || m.queueStartAt.IsZero() {
+		return m, nil
+	}
+
+	remaining := time.Until(m.queueStartAt)
+	if remaining > 0 {
+		m.statusbar.SetCountdown(queueCountdown(remaining))
+		return m, delayedStartTick(msg.Gen)
+	}
+
+	m.queueStartAt = time.Time{}
+	m.statusbar.SetCountdown("")
+
+	queue := m.batchExecutor.GetQueue()
+	if m.batchExecutor.IsRunning() || queue.Status != domain.QueueIdle {
+		m.statusbar.SetMessage("Delayed start skipped: queue is already running")
+		return m, nil
+	}
+	if !queue.HasPending() {
+		m.statusbar.SetMessage("Delayed start skipped: queue is empty")
+		return m, nil
+	}
+
+	if m.blockedByPreflight() {
+		return m, nil
+	}
+	if m, cmd, deferred := m.deferToRunWindow(); deferred {
+		return m, cmd
+	}
+	m.statusbar.SetMessage("Starting queue")
+	m.prevView = m.activeView
+	m.activeView = domain.ViewExecution
+	m.header.SetActiveView(m.activeView)
+	return m, m.batchExecutor.Start()
+}
+
+// queueCountdown formats the time left before a delayed start
+func queueCountdown(remaining time.Duration) string {
+	secs := int(remaining.Round(time.Second).Seconds())
+	if secs >= 3600 {
+		// Deferred to a run window, possibly days away
+		return fmt.Sprintf("Queue starts in %d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+	}
+	return fmt.Sprintf("Queue starts in %d:%02d", secs/60, secs%60)
+}
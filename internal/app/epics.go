@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// handleEpicsViewKeys handles keys when in the epic overview
+func (m Model) handleEpicsViewKeys(msg tea.KeyMsg) (bool, keyResult) {
+	switch msg.String() {
+	case "r": // Reload stories
+		return true, keyResult{m, m.refreshView(domain.ViewEpics)}
+	case "q", "enter": // Queue the whole epic
+		if epic, ok := m.epics.Selected(); ok {
+			return true, keyResult{m.queueEpic(epic), nil}
+		}
+	}
+	return false, keyResult{}
+}
+
+// queueEpic adds the stories of epic that are not done or blocked to the
+// queue, in sprint-status order, and shows the queue
+func (m Model) queueEpic(epic int) Model {
+	var stories []domain.Story
+	for _, s := range m.stories {
+		if s.Epic == epic && s.IsActionable() {
+			stories = append(stories, s)
+		}
+	}
+	if len(stories) == 0 {
+		m.statusbar.SetMessage(fmt.Sprintf("Nothing to queue in epic %d", epic))
+		return m
+	}
+
+	before := m.batchExecutor.GetQueue().TotalCount()
+	m.batchExecutor.AddToQueue(stories)
+	added := m.batchExecutor.GetQueue().TotalCount() - before
+	m.statusbar.SetMessage(fmt.Sprintf("Added %d stories of epic %d to queue", added, epic))
+	m.statusbar.SetStoryCounts(len(m.stories), m.batchExecutor.GetQueue().TotalCount())
+	m.prevView = m.activeView
+	m.activeView = domain.ViewQueue
+	m.header.SetActiveView(m.activeView)
+	m.queue.SetQueue(m.batchExecutor.GetQueue())
+	return m
+}
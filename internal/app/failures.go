@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/failures"
+)
+
+// failureReportedMsg carries the result of filing a failure report
+type failureReportedMsg struct {
+	StoryKey string
+	Location string // Issue URL or file path
+	Error    error
+}
+
+// failureReportTarget returns where failures are reported: the active
+// profile's setting, or the configured default
+func (m Model) failureReportTarget() string {
+	if p := m.profileStore.GetActiveProfile(); p != nil && p.FailureReport != "" {
+		return p.FailureReport
+	}
+	return m.config.FailureReport
+}
+
+// reportFailure returns a command filing a report for an execution that
+// failed after all retries, or nil when reports are off or exec did not
+// fail at a step. A follower leaves reporting to the instance it mirrors.
+func (m Model) reportFailure(exec *domain.Execution) tea.Cmd {
+	target := m.failureReportTarget()
+	if target == "" || target == config.FailureReportOff || m.following() {
+		return nil
+	}
+	report, ok := failures.FromExecution(exec)
+	if !ok {
+		return nil
+	}
+
+	cfg := m.config
+	return func() tea.Msg {
+		location, err := failures.File(context.Background(), cfg, target, report)
+		return failureReportedMsg{StoryKey: report.Story.Key, Location: location, Error: err}
+	}
+}
+
+// handleFailureReported shows where a failure report was filed
+func (m Model) handleFailureReported(msg failureReportedMsg) Model {
+	if msg.Error != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Failure report for %s not filed: %v", msg.StoryKey, msg.Error))
+		return m
+	}
+	m.statusbar.SetMessage(fmt.Sprintf("Failure of %s reported: %s", msg.StoryKey, msg.Location))
+	return m
+}
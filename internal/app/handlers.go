@@ -14,6 +14,7 @@ import (
 	"github.com/robertguss/bmad-automate-go/internal/git"
 	"github.com/robertguss/bmad-automate-go/internal/messages"
 	"github.com/robertguss/bmad-automate-go/internal/preflight"
+	"github.com/robertguss/bmad-automate-go/internal/sound"
 	"github.com/robertguss/bmad-automate-go/internal/theme"
 	"github.com/robertguss/bmad-automate-go/internal/views/settings"
 	"github.com/robertguss/bmad-automate-go/internal/watcher"
@@ -39,9 +40,20 @@ func (m Model) handleCommandPaletteMsg(msg tea.Msg) (Model, tea.Cmd, bool) {
 	case commandpalette.CloseMsg:
 		return m, nil, true
 	case commandpalette.NavigateMsg:
+		if !m.canView(msg.View) {
+			m.statusbar.SetMessage("Minimize the execution (m) to open " + msg.View.String())
+			return m, nil, true
+		}
 		m.prevView = m.activeView
 		m.activeView = msg.View
 		m.header.SetActiveView(m.activeView)
+		if msg.View == domain.ViewPipelines {
+			m.pipelines.SetLoading(true)
+			return m, m.loadPipelineRuns(), true
+		}
+		if msg.View == domain.ViewSchedules {
+			return m, m.loadSchedules(false), true
+		}
 		return m, nil, true
 	case commandpalette.ThemeChangeMsg:
 		theme.SetTheme(msg.Theme)
@@ -60,6 +72,35 @@ func (m Model) handleCommandPaletteMsg(msg tea.Msg) (Model, tea.Cmd, bool) {
 // handleKeyMsg handles keyboard input messages
 // Returns (model, cmd, handled)
 func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	// Followers are read-only
+	if m.following() {
+		return m.handleFollowerKeys(msg)
+	}
+
+	// Any other key cancels a workspace restore awaiting confirmation
+	if msg.String() != "u" {
+		m.restoreArmed = ""
+	}
+
+	// The help overlay takes every key until it closes
+	if m.help.IsActive() {
+		m.help, _ = m.help.Update(msg)
+		return m, nil, true
+	}
+
+	// The step preview takes every key until it closes
+	if m.stepPreview.IsActive() {
+		var cmd tea.Cmd
+		m.stepPreview, cmd = m.stepPreview.Update(msg)
+		return m, cmd, true
+	}
+
+	// The tour takes every key until it ends
+	if m.tour.IsActive() {
+		m, cmd := m.handleTourKey(msg)
+		return m, cmd, true
+	}
+
 	// Command palette activation
 	if msg.String() == "ctrl+p" {
 		m.commandPalette.Open()
@@ -67,6 +108,11 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
 		return m, nil, true
 	}
 
+	// Search across stories, history and output
+	if msg.String() == "ctrl+f" {
+		return m.openSearch(), nil, true
+	}
+
 	// View-specific key handling
 	if handled, result := m.handleViewSpecificKeys(msg); handled {
 		return result.model, result.cmd, true
@@ -85,12 +131,66 @@ type keyResult struct {
 // handleViewSpecificKeys handles keys specific to the current view
 func (m Model) handleViewSpecificKeys(msg tea.KeyMsg) (bool, keyResult) {
 	switch m.activeView {
+	case domain.ViewDashboard:
+		return m.handleDashboardViewKeys(msg)
 	case domain.ViewExecution:
 		return m.handleExecutionViewKeys(msg)
 	case domain.ViewStoryList:
 		return m.handleStoryListViewKeys(msg)
 	case domain.ViewQueue:
 		return m.handleQueueViewKeys(msg)
+	case domain.ViewEpics:
+		return m.handleEpicsViewKeys(msg)
+	case domain.ViewLogs:
+		// The search prompt takes every key until it closes
+		if m.logs.IsSearching() {
+			m.logs, _ = m.logs.Update(msg)
+			return true, keyResult{m, nil}
+		}
+	case domain.ViewSearch:
+		// Typing goes to the query; Esc with nothing typed goes back
+		if msg.String() != "esc" || m.search.Query() != "" {
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			return true, keyResult{m, cmd}
+		}
+	case domain.ViewHistory:
+		// The filter and note prompts take every key until they close
+		if m.history.IsPrompting() {
+			var cmd tea.Cmd
+			m.history, cmd = m.history.Update(msg)
+			return true, keyResult{m, cmd}
+		}
+	case domain.ViewStats:
+		// The custom range prompt takes every key until it closes
+		if m.stats.IsPrompting() {
+			var cmd tea.Cmd
+			m.stats, cmd = m.stats.Update(msg)
+			return true, keyResult{m, cmd}
+		}
+	case domain.ViewSchedules:
+		// The add prompt takes every key until it closes
+		if m.schedules.IsPrompting() {
+			var cmd tea.Cmd
+			m.schedules, cmd = m.schedules.Update(msg)
+			return true, keyResult{m, cmd}
+		}
+	}
+	return false, keyResult{}
+}
+
+// handleDashboardViewKeys handles keys when in dashboard view
+func (m Model) handleDashboardViewKeys(msg tea.KeyMsg) (bool, keyResult) {
+	switch msg.String() {
+	case "r":
+		return true, keyResult{m, m.refreshView(domain.ViewDashboard)}
+	case "e":
+		if m.canView(domain.ViewEpics) {
+			m.prevView = m.activeView
+			m.activeView = domain.ViewEpics
+			m.header.SetActiveView(m.activeView)
+		}
+		return true, keyResult{m, nil}
 	}
 	return false, keyResult{}
 }
@@ -98,6 +198,23 @@ func (m Model) handleViewSpecificKeys(msg tea.KeyMsg) (bool, keyResult) {
 // handleExecutionViewKeys handles keys when in execution view
 func (m Model) handleExecutionViewKeys(msg tea.KeyMsg) (bool, keyResult) {
 	switch msg.String() {
+	case "u": // Restore the pre-run workspace
+		if restorable(m.execution.GetExecution()) {
+			m, cmd := m.requestWorkspaceRestore()
+			return true, keyResult{m, cmd}
+		}
+	case "L": // Complete saved output
+		if exec := m.execution.GetExecution(); exec != nil && exec.IsFinished() {
+			m, cmd := m.openLogs(exec.ID)
+			return true, keyResult{m, cmd}
+		}
+	case "v": // Compare the workspace with the pre-run snapshot
+		if exec := m.execution.GetExecution(); exec != nil && exec.Snapshot != nil && exec.IsFinished() {
+			m.prevView = m.activeView
+			m.activeView = domain.ViewDiff
+			m.header.SetActiveView(m.activeView)
+			return true, keyResult{m, m.loadDiff(exec.Story.Key, exec.Snapshot.Ref, "")}
+		}
 	case "p": // Pause
 		if m.executor.GetExecution() != nil &&
 			m.executor.GetExecution().Status == domain.ExecutionRunning {
@@ -105,13 +222,17 @@ func (m Model) handleExecutionViewKeys(msg tea.KeyMsg) (bool, keyResult) {
 			m.statusbar.SetMessage("Execution paused")
 			return true, keyResult{m, nil}
 		}
-	case "r": // Resume
+	case "r": // Resume, or retry from the failed step
 		if m.executor.GetExecution() != nil &&
 			m.executor.GetExecution().Status == domain.ExecutionPaused {
 			m.executor.Resume()
 			m.statusbar.SetMessage("Execution resumed")
 			return true, keyResult{m, nil}
 		}
+		if exec := m.executor.GetExecution(); exec != nil && exec.CanRetry() && m.execution.GetExecution() == exec {
+			m.statusbar.SetMessage(fmt.Sprintf("Retrying %s from %s", exec.Story.Key, exec.FailedStep().Name))
+			return true, keyResult{m, m.executor.RetryFromFailed()}
+		}
 	case "c": // Cancel
 		exec := m.executor.GetExecution()
 		if exec != nil && (exec.Status == domain.ExecutionRunning ||
@@ -131,22 +252,28 @@ func (m Model) handleExecutionViewKeys(msg tea.KeyMsg) (bool, keyResult) {
 		exec := m.executor.GetExecution()
 		if exec != nil && (exec.Status == domain.ExecutionCompleted ||
 			exec.Status == domain.ExecutionFailed ||
-			exec.Status == domain.ExecutionCancelled) {
+			exec.Status == domain.ExecutionCancelled ||
+			exec.Status == domain.ExecutionConflict) {
 			m.prevView = m.activeView
 			m.activeView = domain.ViewStoryList
 			m.header.SetActiveView(m.activeView)
 			return true, keyResult{m, nil}
 		}
+	case "m": // Minimize to the status bar
+		if m.executionActive() {
+			return true, keyResult{m.minimizeExecution(), nil}
+		}
 	case "esc":
 		exec := m.executor.GetExecution()
 		if exec == nil || exec.Status == domain.ExecutionCompleted ||
 			exec.Status == domain.ExecutionFailed ||
-			exec.Status == domain.ExecutionCancelled {
+			exec.Status == domain.ExecutionCancelled ||
+			exec.Status == domain.ExecutionConflict {
 			m.activeView = m.prevView
 			m.header.SetActiveView(m.activeView)
 			return true, keyResult{m, nil}
 		}
-		m.statusbar.SetMessage("Cancel execution first (c) before leaving")
+		m.statusbar.SetMessage("Cancel execution first (c) or minimize it (m) before leaving")
 		return true, keyResult{m, nil}
 	}
 	return false, keyResult{}
@@ -154,11 +281,33 @@ func (m Model) handleExecutionViewKeys(msg tea.KeyMsg) (bool, keyResult) {
 
 // handleStoryListViewKeys handles keys when in story list view
 func (m Model) handleStoryListViewKeys(msg tea.KeyMsg) (bool, keyResult) {
+	// The select-by-query prompt takes every key until it closes
+	if m.storylist.IsQuerying() {
+		m.storylist, _ = m.storylist.Update(msg)
+		m.statusbar.SetSelectedCount(m.storylist.SelectedCount())
+		return true, keyResult{m, nil}
+	}
+
 	switch msg.String() {
+	case "r": // Reload stories
+		return true, keyResult{m, m.refreshView(domain.ViewStoryList)}
 	case "enter":
 		story := m.storylist.GetCurrent()
+		if story != nil && m.config.PreviewSteps {
+			return true, keyResult{m.previewSteps(*story), nil}
+		}
 		if story != nil {
-			return true, keyResult{m, m.startExecution(*story)}
+			return true, keyResult{m, m.startExecution(*story, nil)}
+		}
+	case "p": // Preview the steps before running
+		if story := m.storylist.GetCurrent(); story != nil {
+			return true, keyResult{m.previewSteps(*story), nil}
+		}
+	case "D": // What the story's branch or commits changed
+		if story := m.storylist.GetCurrent(); story != nil {
+			return true, keyResult{m, func() tea.Msg {
+				return messages.DiffRequestMsg{StoryKey: story.Key, Story: true}
+			}}
 		}
 	case "q": // Add selected stories to queue
 		selected := m.storylist.GetSelected()
@@ -184,10 +333,20 @@ func (m Model) handleStoryListViewKeys(msg tea.KeyMsg) (bool, keyResult) {
 			return true, keyResult{m, nil}
 		}
 	case "x": // Execute selected stories immediately
+		// While a run is active, x returns to it instead
+		if m.executionActive() {
+			return false, keyResult{}
+		}
 		selected := m.storylist.GetSelected()
 		if len(selected) > 0 {
 			m.batchExecutor.AddToQueue(selected)
 			m.queue.SetQueue(m.batchExecutor.GetQueue())
+			if m.blockedByPreflight() {
+				return true, keyResult{m, nil}
+			}
+			if m, cmd, deferred := m.deferToRunWindow(); deferred {
+				return true, keyResult{m, cmd}
+			}
 			m.prevView = m.activeView
 			m.activeView = domain.ViewExecution
 			m.header.SetActiveView(m.activeView)
@@ -199,10 +358,23 @@ func (m Model) handleStoryListViewKeys(msg tea.KeyMsg) (bool, keyResult) {
 
 // handleQueueViewKeys handles keys when in queue view
 func (m Model) handleQueueViewKeys(msg tea.KeyMsg) (bool, keyResult) {
+	// The deadline prompt takes every key until it closes
+	if m.queue.IsPrompting() {
+		var cmd tea.Cmd
+		m.queue, cmd = m.queue.Update(msg)
+		return true, keyResult{m, cmd}
+	}
+
 	switch msg.String() {
 	case "enter":
 		queue := m.batchExecutor.GetQueue()
 		if queue.Status == domain.QueueIdle && queue.HasPending() {
+			if m.blockedByPreflight() {
+				return true, keyResult{m, nil}
+			}
+			if m, cmd, deferred := m.deferToRunWindow(); deferred {
+				return true, keyResult{m, cmd}
+			}
 			m.prevView = m.activeView
 			m.activeView = domain.ViewExecution
 			m.header.SetActiveView(m.activeView)
@@ -224,7 +396,7 @@ func (m Model) handleQueueViewKeys(msg tea.KeyMsg) (bool, keyResult) {
 			m.statusbar.SetMessage("Queue cancelled")
 		}
 	case "t": // Navigate to timeline
-		if m.canNavigate() {
+		if m.canView(domain.ViewTimeline) {
 			m.prevView = m.activeView
 			m.activeView = domain.ViewTimeline
 			m.header.SetActiveView(m.activeView)
@@ -247,10 +419,12 @@ func (m Model) handleGlobalKeys(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
 		return m, tea.Quit, true
 
 	case "?":
+		m.help.Open(m.activeView)
+		m.help.SetSize(m.width, m.height)
 		return m, nil, true
 
 	case "d":
-		if m.canNavigate() {
+		if m.canView(domain.ViewDashboard) {
 			m.prevView = m.activeView
 			m.activeView = domain.ViewDashboard
 			m.header.SetActiveView(m.activeView)
@@ -274,17 +448,17 @@ func (m Model) handleGlobalKeys(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
 		return m, nil, true
 
 	case "h":
-		if m.canNavigate() {
+		if m.canView(domain.ViewHistory) {
 			m.prevView = m.activeView
 			m.activeView = domain.ViewHistory
 			m.header.SetActiveView(m.activeView)
 			m.history.SetLoading(true)
-			return m, m.loadHistory(), true
+			return m, m.reloadHistory(), true
 		}
 		return m, nil, true
 
 	case "a":
-		if m.activeView != domain.ViewStoryList && m.canNavigate() {
+		if m.activeView != domain.ViewStoryList && m.canView(domain.ViewStats) {
 			m.prevView = m.activeView
 			m.activeView = domain.ViewStats
 			m.header.SetActiveView(m.activeView)
@@ -293,6 +467,12 @@ func (m Model) handleGlobalKeys(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
 		}
 		return m, nil, false // Don't mark as handled to allow storylist to handle 'a'
 
+	case "x": // Back to the running execution (the queue view removes items with x)
+		if m.executionActive() && m.activeView != domain.ViewExecution && m.activeView != domain.ViewQueue {
+			return m.showExecution(), nil, true
+		}
+		return m, nil, false
+
 	case "o":
 		if m.canNavigate() {
 			m.prevView = m.activeView
@@ -324,13 +504,17 @@ func (m Model) handleWindowSizeMsg(msg tea.WindowSizeMsg) Model {
 
 	// Update component sizes
 	m.header.SetWidth(msg.Width)
+	m.help.SetSize(msg.Width, msg.Height)
+	m.stepPreview.SetSize(msg.Width, msg.Height)
+	m.tour.SetSize(msg.Width, msg.Height)
 	m.statusbar.SetWidth(msg.Width)
 
-	// Calculate content height (total - header - statusbar)
-	contentHeight := msg.Height - 4 // header(2) + statusbar(2)
+	// Calculate content height (total - header - statusbar - storage banner)
+	contentHeight := msg.Height - 4 - m.bannerHeight() // header(2) + statusbar(2)
 
 	m.dashboard.SetSize(msg.Width, contentHeight)
 	m.storylist.SetSize(msg.Width, contentHeight)
+	m.epics.SetSize(msg.Width, contentHeight)
 	m.execution.SetSize(msg.Width, contentHeight)
 	m.queue.SetSize(msg.Width, contentHeight)
 	m.timeline.SetSize(msg.Width, contentHeight)
@@ -342,11 +526,16 @@ func (m Model) handleWindowSizeMsg(msg tea.WindowSizeMsg) Model {
 	sizeMsg := messages.WindowSizeMsg{Width: msg.Width, Height: contentHeight}
 	m.dashboard, _ = m.dashboard.Update(sizeMsg)
 	m.storylist, _ = m.storylist.Update(sizeMsg)
+	m.epics, _ = m.epics.Update(sizeMsg)
 	m.execution, _ = m.execution.Update(sizeMsg)
 	m.queue, _ = m.queue.Update(sizeMsg)
 	m.timeline, _ = m.timeline.Update(sizeMsg)
 	m.history, _ = m.history.Update(sizeMsg)
 	m.stats, _ = m.stats.Update(sizeMsg)
+	m.pipelines, _ = m.pipelines.Update(sizeMsg)
+	m.logs, _ = m.logs.Update(sizeMsg)
+	m.schedules, _ = m.schedules.Update(sizeMsg)
+	m.search, _ = m.search.Update(sizeMsg)
 	m.diff, _ = m.diff.Update(sizeMsg)
 
 	return m
@@ -359,7 +548,7 @@ func (m Model) handleStoriesMsg(msg messages.StoriesLoadedMsg) Model {
 		m.statusbar.SetMessage(fmt.Sprintf("Error: %v", msg.Error))
 	} else {
 		m.stories = msg.Stories
-		m.statusbar.SetStoryCounts(len(m.stories), 0)
+		m.statusbar.SetStoryCounts(len(m.stories), m.batchExecutor.GetQueue().TotalCount())
 
 		branch := preflight.GetGitBranch(m.config.WorkingDir)
 		clean := preflight.IsGitClean(m.config.WorkingDir)
@@ -367,6 +556,7 @@ func (m Model) handleStoriesMsg(msg messages.StoriesLoadedMsg) Model {
 
 		m.dashboard.SetStories(m.stories)
 		m.storylist.SetStories(m.stories)
+		m.epics.SetStories(m.stories)
 	}
 	return m
 }
@@ -375,16 +565,24 @@ func (m Model) handleStoriesMsg(msg messages.StoriesLoadedMsg) Model {
 // Returns (model, cmds) where cmds are any additional commands to run
 func (m Model) handleExecutionMsgs(msg tea.Msg) (Model, []tea.Cmd) {
 	var cmds []tea.Cmd
+	m.recordUsage(msg)
+	m.broadcastExecution(msg)
+	if cmd := m.sendWebhook(msg); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
 
 	switch msg := msg.(type) {
 	case messages.ExecutionStartMsg:
-		cmds = append(cmds, m.startExecution(msg.Story))
+		cmds = append(cmds, m.startExecution(msg.Story, nil))
 
 	case messages.ExecutionStartedMsg:
 		m.execution.SetExecution(msg.Execution)
-		m.prevView = m.activeView
-		m.activeView = domain.ViewExecution
-		m.header.SetActiveView(m.activeView)
+		m.execution.SetRetryable(msg.Execution == m.executor.GetExecution())
+		if !m.minimized {
+			m.prevView = m.activeView
+			m.activeView = domain.ViewExecution
+			m.header.SetActiveView(m.activeView)
+		}
 		m.statusbar.SetMessage(fmt.Sprintf("Executing: %s", msg.Execution.Story.Key))
 
 	case messages.StepStartedMsg:
@@ -396,9 +594,14 @@ func (m Model) handleExecutionMsgs(msg tea.Msg) (Model, []tea.Cmd) {
 	case messages.StepCompletedMsg:
 		m.execution, _ = m.execution.Update(msg)
 		if msg.Status == domain.StepSuccess {
-			m.statusbar.SetMessage(fmt.Sprintf("Step completed: %d/%d", msg.StepIndex+1, 4))
+			total := len(domain.AllSteps())
+			if exec := m.execution.GetExecution(); exec != nil {
+				total = len(exec.Steps)
+			}
+			m.statusbar.SetMessage(fmt.Sprintf("Step completed: %d/%d", msg.StepIndex+1, total))
 		} else if msg.Status == domain.StepFailed {
 			m.statusbar.SetMessage(fmt.Sprintf("Step failed: %s", msg.Error))
+			m.notifyStepFailed(msg)
 		}
 
 	case messages.ExecutionCompletedMsg:
@@ -408,17 +611,99 @@ func (m Model) handleExecutionMsgs(msg tea.Msg) (Model, []tea.Cmd) {
 			m.statusbar.SetMessage(fmt.Sprintf("Execution completed in %s", formatDuration(msg.Duration)))
 		case domain.ExecutionFailed:
 			m.statusbar.SetMessage(fmt.Sprintf("Execution failed: %s", msg.Error))
+			// Queue items are reported from QueueItemCompletedMsg
+			if !m.batchExecutor.IsRunning() {
+				if cmd := m.reportFailure(m.executor.GetExecution()); cmd != nil {
+					cmds = append(cmds, cmd)
+				}
+			}
 		case domain.ExecutionCancelled:
 			m.statusbar.SetMessage("Execution cancelled")
+		case domain.ExecutionConflict:
+			m.statusbar.SetMessage(fmt.Sprintf("Story parked: %s", msg.Error))
+		}
+		// A queue reports once it completes
+		if !m.batchExecutor.IsRunning() {
+			switch msg.Status {
+			case domain.ExecutionCompleted:
+				_ = m.soundPlayer.PlayComplete()
+			case domain.ExecutionFailed, domain.ExecutionConflict:
+				_ = m.soundPlayer.PlayError()
+			}
+			if m.config.StoryCompleteAlerts && msg.Status != domain.ExecutionCancelled {
+				if exec := m.executor.GetExecution(); exec != nil {
+					_ = m.notifier.NotifyStoryComplete(exec.Story.Key, msg.Status == domain.ExecutionCompleted)
+				}
+			}
+			if cmd := m.recordBadge(m.executor.GetExecution()); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			m.minimized = false
+			cmds = append(cmds, m.sendUsageReport)
 		}
 
 	case messages.ExecutionTickMsg:
 		m.execution, _ = m.execution.Update(msg)
+
+	case messages.StepStalledMsg:
+		m.execution, _ = m.execution.Update(msg)
+		status := fmt.Sprintf("Step stalled: %s has produced no output for %s", msg.StepName, formatDuration(msg.Idle))
+		if msg.Killed {
+			status += " - killing and retrying"
+		}
+		m.statusbar.SetMessage(status)
+		if m.config.StallAlerts {
+			_ = m.notifier.NotifyError("Step Stalled", status)
+		}
+
+	case messages.StepWaitingMsg:
+		m.execution, _ = m.execution.Update(msg)
+		m.statusbar.SetMessage(fmt.Sprintf("Waiting for approval: %s - press r to continue", msg.Message))
+		_ = m.notifier.Notify("Approval Needed", msg.Message)
 	}
 
+	m = m.checkSlowStep()
+
 	return m, cmds
 }
 
+// notifyStepFailed sends a notification for a step that failed after its
+// retries, when step failure alerts are on. Steps stopped by cancelling the
+// run are left out, since whoever cancelled it is watching.
+func (m Model) notifyStepFailed(msg messages.StepCompletedMsg) {
+	if !m.config.StepFailureAlerts || msg.Error == "cancelled" {
+		return
+	}
+	step := fmt.Sprintf("step %d", msg.StepIndex+1)
+	if exec := m.execution.GetExecution(); exec != nil && exec.ID == msg.ExecutionID && msg.StepIndex < len(exec.Steps) {
+		step = string(exec.Steps[msg.StepIndex].Name)
+	}
+	_ = m.notifier.NotifyStepFailed(msg.StoryKey, step, msg.Error)
+}
+
+// checkSlowStep surfaces a warning the first time the running step exceeds
+// its historical average by domain.SlowStepRatio
+func (m Model) checkSlowStep() Model {
+	warning := m.execution.SlowStepWarning()
+	if warning == "" {
+		return m
+	}
+
+	exec := m.execution.GetExecution()
+	step := exec.CurrentStep()
+	key := fmt.Sprintf("%s/%d/%d", exec.Story.Key, exec.Current, step.Attempt)
+	if key == m.slowStepAlerted {
+		return m
+	}
+	m.slowStepAlerted = key
+
+	m.statusbar.SetMessage(fmt.Sprintf("Warning: %s", warning))
+	if m.config.SlowStepAlerts {
+		_ = m.notifier.Notify("Slow Step", fmt.Sprintf("%s: %s", exec.Story.Key, warning))
+	}
+	return m
+}
+
 // handleQueueMsgs handles queue-related messages
 func (m Model) handleQueueMsgs(msg tea.Msg) (Model, []tea.Cmd) {
 	var cmds []tea.Cmd
@@ -438,17 +723,30 @@ func (m Model) handleQueueMsgs(msg tea.Msg) (Model, []tea.Cmd) {
 		m.queue, _ = m.queue.Update(msg)
 		if msg.Execution != nil {
 			m.timeline.AddExecution(msg.Execution)
+			if cmd := m.recordBadge(msg.Execution); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 		if msg.Status == domain.ExecutionCompleted {
 			m.statusbar.SetMessage(fmt.Sprintf("Completed: %s", msg.Story.Key))
 		} else if msg.Status == domain.ExecutionFailed {
 			m.statusbar.SetMessage(fmt.Sprintf("Failed: %s - %s", msg.Story.Key, msg.Error))
+			if cmd := m.reportFailure(msg.Execution); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		} else if msg.Status == domain.ExecutionConflict {
+			m.statusbar.SetMessage(fmt.Sprintf("Parked: %s - %s", msg.Story.Key, msg.Error))
 		}
 
 	case messages.QueueCompletedMsg:
 		m.queue, _ = m.queue.Update(messages.QueueUpdatedMsg{Queue: m.batchExecutor.GetQueue()})
-		m.statusbar.SetMessage(fmt.Sprintf("Queue completed: %d/%d succeeded in %s",
-			msg.SuccessCount, msg.TotalItems, formatDuration(msg.TotalDuration)))
+		status := fmt.Sprintf("Queue completed: %d/%d succeeded in %s",
+			msg.SuccessCount, msg.TotalItems, formatDuration(msg.TotalDuration))
+		if msg.ConflictCount > 0 {
+			status += fmt.Sprintf(" (%d parked with merge conflicts)", msg.ConflictCount)
+		}
+		m.statusbar.SetMessage(status)
+		m.minimized = false
 
 		// Save executions to storage
 		if m.storage != nil {
@@ -459,6 +757,7 @@ func (m Model) handleQueueMsgs(msg tea.Msg) (Model, []tea.Cmd) {
 				}
 			}
 			_ = m.storage.UpdateStepAverages(context.Background())
+			cmds = append(cmds, m.loadStats(), m.loadHistoricalAverages, m.loadStoryHealth, m.checkDatabaseSize())
 		}
 
 		// Notifications and feedback
@@ -467,10 +766,16 @@ func (m Model) handleQueueMsgs(msg tea.Msg) (Model, []tea.Cmd) {
 
 		if failedCount == 0 {
 			_ = m.soundPlayer.PlayComplete()
-			cmds = append(cmds, m.confetti.Start(m.width, m.height))
+			if !m.config.ReducedMotion {
+				cmds = append(cmds, m.confetti.Start(m.width, m.height))
+			}
 		} else {
 			_ = m.soundPlayer.PlayWarning()
 		}
+		cmds = append(cmds, m.sendUsageReport)
+		if cmd := m.sendWebhook(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 	}
 
 	return m, cmds
@@ -493,6 +798,17 @@ func (m Model) handleSettingsMsgs(msg tea.Msg) Model {
 			m.notifier.SetEnabled(msg.Value.(bool))
 		case "Sound":
 			m.soundPlayer.SetEnabled(msg.Value.(bool))
+		case "Complete Sound", "Warning Sound", "Failure Sound":
+			event, _ := settings.SoundEvent(msg.Name)
+			if t, ok := sound.ParseEvent(event); ok {
+				m.soundPlayer.SetMuted(t, !msg.Value.(bool))
+			}
+		case "Usage Metrics":
+			m.setTelemetryEnabled(msg.Value.(bool))
+		case "Reduced Motion":
+			if msg.Value.(bool) {
+				m.confetti.Stop()
+			}
 		}
 
 	case confetti.TickMsg:
@@ -508,30 +824,109 @@ func (m Model) handleHistoryStatsMsgs(msg tea.Msg) (Model, []tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case messages.HistoryRefreshMsg:
-		cmds = append(cmds, m.loadHistory())
+		cmds = append(cmds, m.reloadHistory())
 
 	case messages.HistoryFilterMsg:
-		cmds = append(cmds, m.loadHistoryFiltered(msg.Query, msg.Epic, msg.Status))
+		if m.history.GroupBy() != "" {
+			cmds = append(cmds, m.loadHistoryGroups(m.history.GroupBy()))
+		} else {
+			cmds = append(cmds, m.loadHistoryFiltered(msg.Query, msg.Epic, msg.Status))
+		}
+
+	case messages.HistoryGroupMsg:
+		cmds = append(cmds, m.reloadHistory())
+
+	case messages.HistoryGroupExpandMsg:
+		cmds = append(cmds, m.loadHistoryGroup(msg.GroupBy, msg.Key))
+
+	case messages.HistoryGroupsLoadedMsg, messages.HistoryGroupExecutionsMsg:
+		m.history, _ = m.history.Update(msg)
 
 	case messages.HistoryLoadedMsg:
 		m.history.SetExecutions(msg.Executions, msg.TotalCount)
 
 	case messages.HistoryDetailMsg:
+		// Opening a past run would replace the live output
+		if m.executionActive() {
+			m.statusbar.SetMessage("Execution details open once the current run finishes")
+			break
+		}
 		if m.storage != nil {
 			cmds = append(cmds, m.loadExecutionDetail(msg.ID))
 		}
 
+	case messages.HistoryLinkMsg:
+		m.statusbar.SetMessage(m.executionLinkText(msg.ID))
+
+	case messages.HistoryNoteMsg:
+		cmds = append(cmds, m.saveNote(msg))
+
 	case messages.StatsRefreshMsg:
 		cmds = append(cmds, m.loadStats())
 
+	case messages.StatsExportMsg:
+		m.statusbar.SetMessage("Exporting stats report...")
+		cmds = append(cmds, m.exportStatsReport())
+
+	case messages.StatsRangeMsg:
+		m.statsRange = msg.Range
+		m.stats.SetLoading(true)
+		cmds = append(cmds, m.loadStatsFor(msg.Range))
+
 	case messages.StatsLoadedMsg:
-		m.stats.SetStats(msg.Stats)
+		if msg.Error != nil {
+			m.stats.SetStats(nil)
+			break
+		}
+		// With a range set, the all-time stats only feed the dashboard
+		if msg.Stats.Range == m.statsRange {
+			m.stats.SetStats(msg.Stats)
+		}
+		if msg.Stats.Range.IsAllTime() {
+			m.dashboard.SetStats(msg.Stats)
+		}
+
+	case messages.PipelinesRefreshMsg:
+		cmds = append(cmds, m.loadPipelineRuns())
+
+	case messages.PipelineRunsLoadedMsg:
+		m.pipelines, _ = m.pipelines.Update(msg)
+
+	case messages.LogsRequestMsg:
+		var cmd tea.Cmd
+		m, cmd = m.openLogs(msg.ID)
+		if msg.Find != "" {
+			m.logs.Find(msg.Find, msg.Line)
+		}
+		cmds = append(cmds, cmd)
+
+	case messages.LogsLoadedMsg:
+		m.logs, _ = m.logs.Update(msg)
 
 	case messages.DiffRequestMsg:
-		cmds = append(cmds, m.loadDiff(msg.StoryKey))
+		if msg.Story {
+			m.prevView = m.activeView
+			m.activeView = domain.ViewDiff
+			m.header.SetActiveView(m.activeView)
+			m.diff.SetLoading(true)
+			cmds = append(cmds, m.loadStoryDiff(msg.StoryKey))
+			break
+		}
+		cmds = append(cmds, m.loadDiff(msg.StoryKey, msg.Base, msg.Source))
+
+	case messages.SearchRequestMsg:
+		cmds = append(cmds, m.runSearch(msg.Query))
+
+	case messages.SearchResultsMsg:
+		if m.activeView != domain.ViewSearch {
+			m.search, _ = m.search.Update(msg)
+		}
+
+	case messages.StoryFocusMsg:
+		m = m.focusStory(msg.Key)
 
 	case messages.DiffLoadedMsg:
-		m.diff.SetDiff(msg.StoryKey, msg.Content)
+		m.diff, _ = m.diff.Update(msg)
 	}
 
 	return m, cmds
@@ -552,6 +947,15 @@ func (m Model) handlePhase6Msgs(msg tea.Msg) (Model, []tea.Cmd) {
 		}
 
 	case messages.WorkflowSwitchMsg:
+		w, ok := m.workflowStore.Get(msg.WorkflowName)
+		if !ok {
+			m.statusbar.SetMessage(fmt.Sprintf("Workflow not found: %s", msg.WorkflowName))
+			break
+		}
+		m.config.ActiveWorkflow = msg.WorkflowName
+		m.executor.SetWorkflow(w)
+		m.batchExecutor.SetWorkflow(w)
+		m.parallelExecutor.SetWorkflow(w)
 		m.statusbar.SetMessage(fmt.Sprintf("Switched to workflow: %s", msg.WorkflowName))
 
 	case messages.WorkflowLoadedMsg:
@@ -561,6 +965,7 @@ func (m Model) handlePhase6Msgs(msg tea.Msg) (Model, []tea.Cmd) {
 
 	case watcher.RefreshMsg:
 		m.statusbar.SetMessage("Files changed, refreshing stories...")
+		m.watchReload = m.config.WatchEnqueue || m.config.WatchNotify
 		cmds = append(cmds, m.loadStories)
 
 	case messages.WatchStatusMsg:
@@ -597,6 +1002,9 @@ func (m Model) routeToActiveView(msg tea.Msg) (Model, tea.Cmd) {
 		m.dashboard, cmd = m.dashboard.Update(msg)
 	case domain.ViewStoryList:
 		m.storylist, cmd = m.storylist.Update(msg)
+		m.statusbar.SetSelectedCount(m.storylist.SelectedCount())
+	case domain.ViewEpics:
+		m.epics, cmd = m.epics.Update(msg)
 	case domain.ViewExecution:
 		m.execution, cmd = m.execution.Update(msg)
 	case domain.ViewQueue:
@@ -607,6 +1015,14 @@ func (m Model) routeToActiveView(msg tea.Msg) (Model, tea.Cmd) {
 		m.history, cmd = m.history.Update(msg)
 	case domain.ViewStats:
 		m.stats, cmd = m.stats.Update(msg)
+	case domain.ViewPipelines:
+		m.pipelines, cmd = m.pipelines.Update(msg)
+	case domain.ViewLogs:
+		m.logs, cmd = m.logs.Update(msg)
+	case domain.ViewSchedules:
+		m.schedules, cmd = m.schedules.Update(msg)
+	case domain.ViewSearch:
+		m.search, cmd = m.search.Update(msg)
 	case domain.ViewDiff:
 		m.diff, cmd = m.diff.Update(msg)
 	case domain.ViewSettings:
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/export"
+)
+
+// historyExportDir is the folder under the data directory that palette
+// exports are written to, one timestamped subfolder per export
+const historyExportDir = "exports"
+
+// historyExportedMsg carries the result of a history export
+type historyExportedMsg struct {
+	Result *export.Result
+	Dir    string
+	Error  error
+}
+
+// exportHistory writes the execution history as JSON or CSV files into a
+// new folder in the data directory
+func (m Model) exportHistory(format string, withOutput bool) tea.Cmd {
+	store := m.storage
+	dir := filepath.Join(m.config.DataDir, historyExportDir, time.Now().Format("20060102-150405"))
+	return func() tea.Msg {
+		if store == nil {
+			return historyExportedMsg{Error: fmt.Errorf("storage not available")}
+		}
+
+		var result *export.Result
+		var err error
+		switch format {
+		case export.FormatCSV:
+			result, err = export.CSV(context.Background(), store, dir, withOutput)
+		default:
+			result, err = export.JSON(context.Background(), store, dir, withOutput)
+		}
+		return historyExportedMsg{Result: result, Dir: dir, Error: err}
+	}
+}
+
+// handleHistoryExported reports where an export was written
+func (m Model) handleHistoryExported(msg historyExportedMsg) Model {
+	if msg.Error != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("History export failed: %v", msg.Error))
+		return m
+	}
+	m.statusbar.SetMessage(fmt.Sprintf("Exported %d executions to %s", msg.Result.Executions, msg.Dir))
+	return m
+}
+
+// statsExportedMsg carries the result of a stats report export
+type statsExportedMsg struct {
+	Path  string
+	Error error
+}
+
+// exportStatsReport writes the statistics of the stats view's range as an
+// HTML report into the exports folder of the data directory
+func (m Model) exportStatsReport() tea.Cmd {
+	store := m.storage
+	r := m.statsRange
+	dir := filepath.Join(m.config.DataDir, historyExportDir)
+	return func() tea.Msg {
+		if store == nil {
+			return statsExportedMsg{Error: fmt.Errorf("storage not available")}
+		}
+		stats, err := store.GetStats(context.Background(), r)
+		if err != nil {
+			return statsExportedMsg{Error: err}
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return statsExportedMsg{Error: fmt.Errorf("failed to create export directory: %w", err)}
+		}
+		now := time.Now()
+		path := filepath.Join(dir, "stats-"+now.Format("20060102-150405")+".html")
+		return statsExportedMsg{Path: path, Error: export.StatsHTML(path, stats, r, now)}
+	}
+}
+
+// handleStatsExported reports where a stats report was written
+func (m Model) handleStatsExported(msg statsExportedMsg) Model {
+	if msg.Error != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Stats report failed: %v", msg.Error))
+		return m
+	}
+	m.statusbar.SetMessage("Wrote stats report to " + msg.Path)
+	return m
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/inbox"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// startInbox starts watching the inbox directory
+func (m Model) startInbox() tea.Msg {
+	if err := m.inbox.Start(); err != nil {
+		return messages.ErrorMsg{Error: err}
+	}
+	return nil
+}
+
+// handleInboxDrop adds the stories of a dropped request to the queue and
+// starts it when asked to and nothing is running
+func (m Model) handleInboxDrop(msg inbox.DropMsg) (Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Inbox: %v", msg.Error))
+		return m, nil
+	}
+
+	var stories []domain.Story
+	var unknown []string
+	for _, key := range msg.Request.Keys {
+		if story := m.findStory(key); story != nil {
+			stories = append(stories, *story)
+		} else {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(stories) == 0 {
+		m.statusbar.SetMessage(fmt.Sprintf("Inbox: no known stories in %s", msg.File))
+		return m, nil
+	}
+
+	m.batchExecutor.AddToQueue(stories)
+	m.queue.SetQueue(m.batchExecutor.GetQueue())
+	m.statusbar.SetStoryCounts(len(m.stories), m.batchExecutor.GetQueue().TotalCount())
+
+	status := fmt.Sprintf("Inbox: added %d stories from %s", len(stories), msg.File)
+	if len(unknown) > 0 {
+		status += fmt.Sprintf(" (unknown: %s)", strings.Join(unknown, ", "))
+	}
+	m.statusbar.SetMessage(status)
+
+	if !msg.Request.Start {
+		return m, nil
+	}
+	if m.executionActive() || m.blockedByPreflight() {
+		return m, nil
+	}
+	if m, cmd, deferred := m.deferToRunWindow(); deferred {
+		return m, cmd
+	}
+	return m, m.batchExecutor.Start()
+}
+
+// findStory returns the loaded story with key, or nil
+func (m Model) findStory(key string) *domain.Story {
+	for i := range m.stories {
+		if m.stories[i].Key == key {
+			return &m.stories[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/api"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// OpenExecution makes the app open an execution from history on start.
+// ref may be a full or shortened execution ID or a dashboard deep link.
+// It must be called before the program starts.
+func (m *Model) OpenExecution(ref string) {
+	m.openExecutionRef = api.ParseExecutionRef(ref)
+}
+
+// openLinkedExecution resolves the execution requested on the command line
+// and opens it like a history detail
+func (m Model) openLinkedExecution() tea.Msg {
+	if m.storage == nil {
+		return messages.ErrorMsg{Error: fmt.Errorf("cannot open execution %s: no database", m.openExecutionRef)}
+	}
+
+	id, err := m.storage.ResolveExecutionID(context.Background(), m.openExecutionRef)
+	if err != nil {
+		return messages.ErrorMsg{Error: err}
+	}
+	return messages.HistoryDetailMsg{ID: id}
+}
+
+// executionLinkText describes how to share an execution: the dashboard
+// link and the equivalent bmad open command
+func (m Model) executionLinkText(id string) string {
+	text := fmt.Sprintf("Link: %s  |  bmad open %s", api.ExecutionLink(m.config.APIPort, id), domain.ShortID(id))
+	if !m.apiServer.IsRunning() {
+		text += "  (start the API server to use the link)"
+	}
+	return text
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/api"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// broadcastExecution publishes execution starts and step output lines on
+// the API server's WebSocket for external dashboards. Nothing is sent
+// while the server is stopped.
+func (m Model) broadcastExecution(msg tea.Msg) {
+	if m.following() {
+		return
+	}
+
+	switch msg := msg.(type) {
+	case messages.ExecutionStartedMsg:
+		if msg.Execution != nil {
+			m.apiServer.BroadcastExecutionStarted(msg.Execution)
+		}
+	case messages.StepOutputMsg:
+		data := api.StepOutputData{
+			ExecutionID: msg.ExecutionID,
+			StoryKey:    msg.StoryKey,
+			StepIndex:   msg.StepIndex,
+			Line:        msg.Line,
+			IsStderr:    msg.IsStderr,
+		}
+		if exec := m.execution.GetExecution(); exec != nil && msg.Belongs(exec) {
+			data.ExecutionID, data.StoryKey = exec.ID, exec.Story.Key
+			if msg.StepIndex >= 0 && msg.StepIndex < len(exec.Steps) {
+				data.StepName = string(exec.Steps[msg.StepIndex].Name)
+			}
+		}
+		if data.ExecutionID != "" {
+			m.apiServer.BroadcastStepOutput(data)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/util"
+)
+
+// openLogs switches to the log viewer and loads the saved output of an
+// execution
+func (m Model) openLogs(id string) (Model, tea.Cmd) {
+	if m.activeView != domain.ViewLogs {
+		m.prevView = m.activeView
+	}
+	m.activeView = domain.ViewLogs
+	m.header.SetActiveView(m.activeView)
+	m.logs.Load(id)
+	return m, m.loadLogs(id)
+}
+
+// loadLogs loads the complete saved output of an execution
+func (m Model) loadLogs(id string) tea.Cmd {
+	return func() tea.Msg {
+		if m.storage == nil {
+			return messages.LogsLoadedMsg{ExecutionID: id, Error: fmt.Errorf("storage not available")}
+		}
+
+		record, err := m.storage.GetExecutionWithOutput(context.Background(), id)
+		if err != nil {
+			return messages.LogsLoadedMsg{ExecutionID: id, Error: err}
+		}
+		return messages.LogsLoadedMsg{
+			ExecutionID: id,
+			StoryKey:    record.StoryKey,
+			Lines:       executionLogLines(record),
+		}
+	}
+}
+
+// executionLogLines flattens the output of every step attempt into one log,
+// each attempt under a "==> " header line
+func executionLogLines(record *storage.ExecutionRecord) []string {
+	var lines []string
+	header := func(step domain.StepName, attempt int, status domain.StepStatus, duration time.Duration) {
+		line := fmt.Sprintf("==> %s (%s", step, status)
+		if attempt > 1 {
+			line += fmt.Sprintf(", attempt %d", attempt)
+		}
+		if duration > 0 {
+			line += ", " + util.FormatDuration(duration)
+		}
+		lines = append(lines, line+")")
+	}
+
+	for _, step := range record.Steps {
+		for _, prev := range step.PreviousAttempts {
+			header(step.StepName, prev.Number, prev.Status, prev.Duration)
+			lines = append(lines, prev.Output...)
+			if prev.Error != "" {
+				lines = append(lines, "Error: "+prev.Error)
+			}
+		}
+
+		header(step.StepName, step.Attempt, step.Status, step.Duration)
+		lines = append(lines, step.Output...)
+		if step.Error != "" {
+			lines = append(lines, "Error: "+step.Error)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// executionActive reports whether a single story or the queue is running
+func (m Model) executionActive() bool {
+	exec := m.executor.GetExecution()
+	if exec != nil && (exec.Status == domain.ExecutionRunning || exec.Status == domain.ExecutionPaused) {
+		return true
+	}
+	return m.batchExecutor.IsRunning()
+}
+
+// minimizeExecution leaves the execution view while the run continues,
+// pinning its progress to the status bar
+func (m Model) minimizeExecution() Model {
+	if !m.executionActive() {
+		return m
+	}
+	m.minimized = true
+	if m.prevView != domain.ViewStoryList {
+		m.prevView = domain.ViewDashboard
+	}
+	m.activeView = m.prevView
+	m.prevView = domain.ViewExecution
+	m.header.SetActiveView(m.activeView)
+	m.statusbar.SetMessage("Execution minimized - use Show Execution (Ctrl+P) to return")
+	return m
+}
+
+// showExecution returns to the execution view of a minimized run
+func (m Model) showExecution() Model {
+	if m.execution.GetExecution() == nil {
+		m.statusbar.SetMessage("No execution to show")
+		return m
+	}
+	m.minimized = false
+	if m.activeView != domain.ViewExecution {
+		m.prevView = m.activeView
+		m.activeView = domain.ViewExecution
+		m.header.SetActiveView(m.activeView)
+	}
+	return m
+}
+
+// executionProgress is the compact progress shown in the status bar while
+// an execution is minimized: story, step, percent and elapsed time
+func (m Model) executionProgress() string {
+	exec := m.execution.GetExecution()
+	if exec == nil {
+		return ""
+	}
+	elapsed := exec.Duration
+	if !exec.IsFinished() {
+		elapsed = time.Since(exec.StartTime)
+	}
+	progress := fmt.Sprintf("▶ %s", exec.Story.Key)
+	if step := exec.CurrentStep(); step != nil {
+		progress += " · " + string(step.Name)
+	}
+	return progress + fmt.Sprintf(" · %.0f%% · %s", exec.ProgressPercent(), formatDuration(elapsed))
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// noteSavedMsg carries the result of saving a note from the history view
+type noteSavedMsg struct {
+	Cleared bool
+	Error   error
+}
+
+// saveNote stores the note of an execution
+func (m Model) saveNote(msg messages.HistoryNoteMsg) tea.Cmd {
+	return func() tea.Msg {
+		if m.storage == nil {
+			return noteSavedMsg{Error: fmt.Errorf("storage not available")}
+		}
+		err := m.storage.SetNote(context.Background(), msg.ID, msg.Note)
+		return noteSavedMsg{Cleared: msg.Note == "", Error: err}
+	}
+}
+
+// handleNoteSaved reports the save and reloads history to show the note
+func (m Model) handleNoteSaved(msg noteSavedMsg) (Model, tea.Cmd) {
+	switch {
+	case msg.Error != nil:
+		m.statusbar.SetMessage(fmt.Sprintf("Failed to save note: %v", msg.Error))
+		return m, nil
+	case msg.Cleared:
+		m.statusbar.SetMessage("Note removed")
+	default:
+		m.statusbar.SetMessage("Note saved")
+	}
+	if groupBy := m.history.GroupBy(); groupBy != "" {
+		return m, m.loadHistoryGroups(groupBy)
+	}
+	return m, m.loadHistoryFiltered(m.history.GetFilter())
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// queueStateKey holds the queue so it survives a crash or restart
+const queueStateKey = storage.StateQueuePrefix + "items"
+
+// queueDeadlineKey holds the deadline of the whole queue, when one is set
+const queueDeadlineKey = storage.StateQueuePrefix + "deadline"
+
+// savedQueueItem is one queue entry as persisted across restarts
+type savedQueueItem struct {
+	Story    domain.Story           `json:"story"`
+	Status   domain.ExecutionStatus `json:"status"`
+	AddedAt  time.Time              `json:"added_at"`
+	Deadline time.Time              `json:"deadline,omitzero"`
+	Tags     []string               `json:"tags,omitempty"`
+}
+
+// restoreQueue refills q from the last session and returns how many items
+// are pending. Items that were running when the app stopped are pending
+// again, since their run did not finish.
+func restoreQueue(cfg *config.Config, store storage.Storage, q *domain.Queue) int {
+	if store == nil {
+		return 0
+	}
+	var saved []savedQueueItem
+	if ok, err := storage.GetStateJSON(context.Background(), store, queueStateKey, &saved); !ok || err != nil {
+		return 0
+	}
+
+	for _, s := range saved {
+		story := s.Story
+		story.FilePath = cfg.StoryFilePath(story.Key)
+		story.FileExists = cfg.StoryFileExists(story.Key)
+		q.Add(story)
+
+		item := q.Items[len(q.Items)-1]
+		item.AddedAt = s.AddedAt
+		item.Deadline = s.Deadline
+		item.Tags = s.Tags
+		switch s.Status {
+		case domain.ExecutionRunning, domain.ExecutionPaused:
+			item.Status = domain.ExecutionPending
+		default:
+			item.Status = s.Status
+		}
+	}
+	_, _ = storage.GetStateJSON(context.Background(), store, queueDeadlineKey, &q.Deadline)
+	return q.PendingCount()
+}
+
+// queueFingerprint identifies the persisted parts of the queue, so it is
+// only written when something changed
+func queueFingerprint(q *domain.Queue) string {
+	var b strings.Builder
+	if !q.Deadline.IsZero() {
+		b.WriteString(q.Deadline.Format(time.RFC3339))
+		b.WriteByte(';')
+	}
+	for _, item := range q.Items {
+		b.WriteString(item.Story.Key)
+		b.WriteByte('=')
+		b.WriteString(string(item.Status))
+		if !item.Deadline.IsZero() {
+			b.WriteByte('@')
+			b.WriteString(item.Deadline.Format(time.RFC3339))
+		}
+		if len(item.Tags) > 0 {
+			b.WriteByte('#')
+			b.WriteString(strings.Join(item.Tags, ","))
+		}
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+// persistQueue saves the queue's items, order and statuses when they have
+// changed since the last save. An empty queue removes the saved state.
+func (m *Model) persistQueue() {
+	if m.storage == nil || m.following() {
+		return
+	}
+	q := m.batchExecutor.GetQueue()
+	fingerprint := queueFingerprint(q)
+	if fingerprint == m.savedQueue {
+		return
+	}
+
+	ctx := context.Background()
+	var err error
+	if q.Deadline.IsZero() {
+		err = m.storage.DeleteState(ctx, queueDeadlineKey)
+	} else {
+		err = storage.SetStateJSON(ctx, m.storage, queueDeadlineKey, q.Deadline)
+	}
+	if err != nil {
+		return
+	}
+
+	if len(q.Items) == 0 {
+		if m.storage.DeleteState(ctx, queueStateKey) == nil {
+			m.savedQueue = fingerprint
+		}
+		return
+	}
+
+	saved := make([]savedQueueItem, 0, len(q.Items))
+	for _, item := range q.Items {
+		saved = append(saved, savedQueueItem{Story: item.Story, Status: item.Status, AddedAt: item.AddedAt,
+			Deadline: item.Deadline, Tags: item.Tags})
+	}
+	if storage.SetStateJSON(ctx, m.storage, queueStateKey, saved) == nil {
+		m.savedQueue = fingerprint
+	}
+}
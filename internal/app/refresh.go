@@ -0,0 +1,82 @@
+package app
+
+import (
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// refreshViews maps the view names of BMAD_AUTO_REFRESH to views
+var refreshViews = map[string]domain.View{
+	"dashboard": domain.ViewDashboard,
+	"stories":   domain.ViewStoryList,
+	"epics":     domain.ViewEpics,
+	"history":   domain.ViewHistory,
+	"stats":     domain.ViewStats,
+	"pipelines": domain.ViewPipelines,
+}
+
+// autoRefreshTickMsg reloads a view's data if it is visible
+type autoRefreshTickMsg struct {
+	View     domain.View
+	Interval time.Duration
+}
+
+// autoRefreshTick schedules the next auto-refresh of a view
+func autoRefreshTick(view domain.View, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return autoRefreshTickMsg{View: view, Interval: interval}
+	})
+}
+
+// startAutoRefresh schedules the first tick of every view configured to
+// auto-refresh
+func (m Model) startAutoRefresh() []tea.Cmd {
+	names := make([]string, 0, len(m.config.AutoRefresh))
+	for name := range m.config.AutoRefresh {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var cmds []tea.Cmd
+	for _, name := range names {
+		if view, ok := refreshViews[name]; ok {
+			cmds = append(cmds, autoRefreshTick(view, m.config.AutoRefresh[name]))
+		}
+	}
+	return cmds
+}
+
+// handleAutoRefreshTick refreshes the view when it is on screen and not
+// taking input, then schedules the next tick
+func (m Model) handleAutoRefreshTick(msg autoRefreshTickMsg) tea.Cmd {
+	next := autoRefreshTick(msg.View, msg.Interval)
+	if m.activeView != msg.View || m.commandPalette.IsActive() || m.storylist.IsQuerying() {
+		return next
+	}
+	return tea.Batch(m.refreshView(msg.View), next)
+}
+
+// refreshView reloads the data a view shows
+func (m Model) refreshView(view domain.View) tea.Cmd {
+	switch view {
+	case domain.ViewDashboard:
+		// Stats feed the dashboard activity sparkline
+		if m.storage != nil {
+			return tea.Batch(m.loadStories, m.loadStats())
+		}
+		return m.loadStories
+	case domain.ViewStoryList, domain.ViewEpics:
+		return m.loadStories
+	case domain.ViewHistory:
+		return m.reloadHistory()
+	case domain.ViewStats:
+		return m.loadStats()
+	case domain.ViewPipelines:
+		return m.loadPipelineRuns()
+	}
+	return nil
+}
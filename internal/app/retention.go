@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// historyPrunedMsg carries the result of applying the retention policy
+type historyPrunedMsg struct {
+	Deleted int
+	Error   error
+	Manual  bool // From the Prune History action rather than the hourly job
+}
+
+// retentionPolicy returns the configured history limits
+func (m Model) retentionPolicy() storage.RetentionPolicy {
+	return storage.NewRetentionPolicy(m.config.HistoryMaxAgeDays, m.config.HistoryMaxRows, m.config.HistoryMaxSizeMB)
+}
+
+// programHandle holds the function that sends messages into the running
+// program, nil until SetProgram
+type programHandle struct {
+	send func(tea.Msg)
+}
+
+// startPruning applies the retention policy to the current database in the
+// background, reporting what it deletes in the status bar. It is called
+// again when Repair Database swaps the store, since closing the old store
+// stops its pruning.
+func (m Model) startPruning() {
+	store, ok := m.storage.(*storage.SQLiteStorage)
+	if !ok || m.following() || m.program == nil || m.program.send == nil {
+		return
+	}
+	send := m.program.send
+	store.StartPruning(m.retentionPolicy(), storage.DefaultPruneInterval, func(deleted int, err error) {
+		send(historyPrunedMsg{Deleted: deleted, Error: err})
+	})
+}
+
+// pruneHistory applies the retention policy once
+func (m Model) pruneHistory() tea.Cmd {
+	store := m.storage
+	policy := m.retentionPolicy()
+	return func() tea.Msg {
+		if store == nil {
+			return historyPrunedMsg{Error: fmt.Errorf("storage not available"), Manual: true}
+		}
+		deleted, err := store.Prune(context.Background(), policy)
+		return historyPrunedMsg{Deleted: deleted, Error: err, Manual: true}
+	}
+}
+
+// handleHistoryPruned reports a prune and reloads the history it changed
+func (m Model) handleHistoryPruned(msg historyPrunedMsg) (Model, tea.Cmd) {
+	switch {
+	case msg.Error != nil:
+		m.statusbar.SetMessage(fmt.Sprintf("History pruning failed: %v", msg.Error))
+		return m, nil
+	case msg.Deleted == 0:
+		if msg.Manual {
+			m.statusbar.SetMessage("History is within the retention limits")
+		}
+		return m, nil
+	}
+
+	m.statusbar.SetMessage(fmt.Sprintf("Pruned %d old executions from history", msg.Deleted))
+	switch m.activeView {
+	case domain.ViewHistory:
+		return m, m.loadHistory()
+	case domain.ViewStats:
+		return m, m.loadStats()
+	}
+	return m, nil
+}
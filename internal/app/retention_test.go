@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/testutil"
+)
+
+func TestHandleStorageRepaired_RestartsPruning(t *testing.T) {
+	cfg := testutil.NewTestConfig(t)
+	cfg.HistoryMaxRows = 1
+
+	// Three executions on disk, waiting for the repaired store to prune
+	disk, err := storage.NewSQLiteStorage(cfg.DatabasePath)
+	require.NoError(t, err)
+	for _, key := range []string{"1-1-a", "1-2-b", "1-3-c"} {
+		exec := testutil.CreateCompletedExecution(testutil.CreateTestStory(key, domain.StatusDone))
+		require.NoError(t, disk.SaveExecution(context.Background(), exec))
+	}
+	require.NoError(t, disk.Close())
+
+	m := New(cfg)
+	t.Cleanup(func() {
+		if m.storage != nil {
+			_ = m.storage.Close()
+		}
+	})
+
+	// Run on the in-memory fallback, pruning as SetProgram would start it
+	require.NoError(t, m.storage.Close())
+	m.storage = testutil.NewTestStorage(t)
+	m.storageErr = assert.AnError
+	pruned := make(chan historyPrunedMsg, 4)
+	m.program.send = func(msg tea.Msg) {
+		if msg, ok := msg.(historyPrunedMsg); ok {
+			pruned <- msg
+		}
+	}
+	m.startPruning()
+
+	repaired, ok := m.repairDatabase().(storageRepairedMsg)
+	require.True(t, ok)
+	require.NoError(t, repaired.Error)
+	m, _ = m.handleStorageRepaired(repaired)
+	require.NoError(t, m.storageErr)
+
+	select {
+	case msg := <-pruned:
+		require.NoError(t, msg.Error)
+		assert.Equal(t, 2, msg.Deleted)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the repaired store is not being pruned")
+	}
+	count, err := m.storage.CountExecutions(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// windowResumeMsg resumes a queue paused for its run window
+type windowResumeMsg struct {
+	At time.Time
+}
+
+// deferToRunWindow schedules the queue to start when the run window next
+// opens, if it is closed now. It returns false when the queue may start.
+func (m Model) deferToRunWindow() (Model, tea.Cmd, bool) {
+	now := time.Now()
+	if _, open := m.runWindows.Open(now); open {
+		return m, nil, false
+	}
+
+	next := m.runWindows.NextOpen(now)
+	if next.IsZero() {
+		m.statusbar.SetMessage("Queue not started: no run window ever opens (" + m.runWindows.String() + ")")
+		return m, nil, true
+	}
+	m, cmd := m.scheduleQueueStart(next.Sub(now))
+	m.statusbar.SetMessage(fmt.Sprintf("Outside the run window (%s) - queue starts %s",
+		m.runWindows, next.Format("Mon 15:04")))
+	return m, cmd, true
+}
+
+// handleWindowPaused waits for the run window to open again after the queue
+// paused at a story boundary
+func (m Model) handleWindowPaused(msg messages.QueueWindowPausedMsg) (Model, tea.Cmd) {
+	m.windowResumeAt = msg.ResumeAt
+	if msg.ResumeAt.IsZero() {
+		m.statusbar.SetMessage("Queue paused: the run window has closed")
+		return m, nil
+	}
+
+	m.statusbar.SetMessage(fmt.Sprintf("Queue paused for the run window - resumes %s (r resumes now)",
+		msg.ResumeAt.Format("Mon 15:04")))
+	return m, tea.Tick(time.Until(msg.ResumeAt), func(time.Time) tea.Msg {
+		return windowResumeMsg{At: msg.ResumeAt}
+	})
+}
+
+// handleWindowResume resumes the queue unless it was resumed, cancelled or
+// paused for a later window in the meantime
+func (m Model) handleWindowResume(msg windowResumeMsg) Model {
+	if !msg.At.Equal(m.windowResumeAt) {
+		return m
+	}
+	m.windowResumeAt = time.Time{}
+	if m.batchExecutor.IsRunning() && m.batchExecutor.IsPaused() {
+		m.batchExecutor.Resume()
+		m.statusbar.SetMessage("Run window open - queue resumed")
+	}
+	return m
+}
@@ -0,0 +1,224 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/schedule"
+)
+
+// scheduleTickMsg checks for schedules that are due, once a minute
+type scheduleTickMsg struct{}
+
+// scheduleTick fires at the start of the next minute, when cron
+// expressions can next match
+func scheduleTick() tea.Cmd {
+	now := time.Now()
+	return tea.Tick(now.Truncate(time.Minute).Add(time.Minute).Sub(now), func(time.Time) tea.Msg {
+		return scheduleTickMsg{}
+	})
+}
+
+// scheduleRunSavedMsg is sent once a schedule's run has been recorded
+type scheduleRunSavedMsg struct{}
+
+// handleScheduleTick runs every enabled schedule that came due since the
+// last check. Times missed while bmad was not running are not caught up.
+func (m Model) handleScheduleTick() (Model, tea.Cmd) {
+	now := time.Now()
+	since := m.scheduleCheckedAt
+	m.scheduleCheckedAt = now
+
+	cmds := []tea.Cmd{scheduleTick()}
+	for _, s := range m.scheduleStore.List() {
+		next := s.Next(since)
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		var cmd tea.Cmd
+		m, cmd = m.runSchedule(s)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// runSchedule adds the stories matching a schedule to the queue and starts
+// it, unless something is already running. The outcome is recorded as the
+// schedule's last run either way.
+func (m Model) runSchedule(s schedule.Schedule) (Model, tea.Cmd) {
+	run := schedule.Run{At: time.Now()}
+	stories := s.Stories(m.stories)
+
+	switch {
+	case m.executionActive() || m.batchExecutor.GetQueue().Status != domain.QueueIdle || !m.queueStartAt.IsZero():
+		run.Skipped = "a run is in progress"
+	case len(stories) == 0:
+		run.Skipped = "no matching stories"
+	}
+	if run.Skipped != "" {
+		m.statusbar.SetMessage(fmt.Sprintf("Schedule %s skipped: %s", s.Name, run.Skipped))
+		return m, m.saveScheduleRun(s.Name, run)
+	}
+
+	m.batchExecutor.AddToQueue(stories)
+	m.queue.SetQueue(m.batchExecutor.GetQueue())
+	m.statusbar.SetStoryCounts(len(m.stories), m.batchExecutor.GetQueue().TotalCount())
+	run.Queued = len(stories)
+	save := m.saveScheduleRun(s.Name, run)
+
+	if m.blockedByPreflight() {
+		return m, save
+	}
+	if m, cmd, deferred := m.deferToRunWindow(); deferred {
+		return m, tea.Batch(save, cmd)
+	}
+	m.statusbar.SetMessage(fmt.Sprintf("Schedule %s: starting %d stories", s.Name, len(stories)))
+	m.prevView = m.activeView
+	m.activeView = domain.ViewExecution
+	m.header.SetActiveView(m.activeView)
+	return m, tea.Batch(save, m.batchExecutor.Start())
+}
+
+// saveScheduleRun records the last run of a schedule
+func (m Model) saveScheduleRun(name string, run schedule.Run) tea.Cmd {
+	return func() tea.Msg {
+		if m.storage == nil {
+			return scheduleRunSavedMsg{}
+		}
+		if err := schedule.SaveRun(context.Background(), m.storage, name, run); err != nil {
+			return messages.ErrorMsg{Error: err}
+		}
+		return scheduleRunSavedMsg{}
+	}
+}
+
+// loadSchedules lists the schedules with their next and last runs for the
+// schedules view, rereading the schedules file when reload is set
+func (m Model) loadSchedules(reload bool) tea.Cmd {
+	return func() tea.Msg {
+		if reload {
+			if err := m.scheduleStore.Load(); err != nil {
+				return messages.SchedulesLoadedMsg{Error: err}
+			}
+		}
+
+		now := time.Now()
+		var entries []messages.ScheduleEntry
+		for _, s := range m.scheduleStore.List() {
+			entry := messages.ScheduleEntry{
+				Name:     s.Name,
+				Cron:     s.Cron,
+				Select:   s.Select,
+				Enabled:  !s.Disabled,
+				Matching: len(s.Stories(m.stories)),
+				NextRun:  s.Next(now),
+			}
+			if m.storage != nil {
+				if run, ok, err := schedule.LoadRun(context.Background(), m.storage, s.Name); err == nil && ok {
+					entry.LastRun = run.At
+					entry.LastResult = runResult(run)
+				}
+			}
+			entries = append(entries, entry)
+		}
+		return messages.SchedulesLoadedMsg{Schedules: entries}
+	}
+}
+
+// runResult describes the outcome of a schedule run
+func runResult(run schedule.Run) string {
+	if run.Skipped != "" {
+		return "skipped, " + run.Skipped
+	}
+	return fmt.Sprintf("queued %d stories", run.Queued)
+}
+
+// parseScheduleSpec parses "<name> <cron expression> <story query>" as typed
+// in the schedules view. The expression is a macro such as @nightly or
+// five fields.
+func parseScheduleSpec(spec string) (schedule.Schedule, error) {
+	fields := strings.Fields(spec)
+	cronFields := 5
+	if len(fields) > 1 && strings.HasPrefix(fields[1], "@") {
+		cronFields = 1
+	}
+	if len(fields) < 2+cronFields {
+		return schedule.Schedule{}, fmt.Errorf("want <name> <cron or @nightly> <story query>")
+	}
+
+	s := schedule.Schedule{
+		Name:   fields[0],
+		Cron:   strings.Join(fields[1:1+cronFields], " "),
+		Select: strings.Join(fields[1+cronFields:], " "),
+	}
+	return s, s.Validate()
+}
+
+// handleScheduleMsgs handles requests from the schedules view
+func (m Model) handleScheduleMsgs(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case scheduleTickMsg:
+		// Deadlines are minute-granular too, so the same tick checks them
+		return m.checkDeadlines().handleScheduleTick()
+
+	case scheduleRunSavedMsg:
+		if m.activeView == domain.ViewSchedules {
+			return m, m.loadSchedules(false)
+		}
+
+	case messages.SchedulesRefreshMsg:
+		return m, m.loadSchedules(true)
+
+	case messages.SchedulesLoadedMsg:
+		m.schedules, _ = m.schedules.Update(msg)
+
+	case messages.ScheduleRunMsg:
+		s, err := m.scheduleStore.Get(msg.Name)
+		if err != nil {
+			m.statusbar.SetMessage(err.Error())
+			return m, nil
+		}
+		return m.runSchedule(s)
+
+	case messages.ScheduleToggleMsg:
+		if err := m.scheduleStore.SetEnabled(msg.Name, msg.Enabled); err != nil {
+			m.statusbar.SetMessage(fmt.Sprintf("Schedule %s: %v", msg.Name, err))
+			return m, nil
+		}
+		state := "disabled"
+		if msg.Enabled {
+			state = "enabled"
+		}
+		m.statusbar.SetMessage(fmt.Sprintf("Schedule %s %s", msg.Name, state))
+		return m, m.loadSchedules(false)
+
+	case messages.ScheduleDeleteMsg:
+		if err := m.scheduleStore.Remove(msg.Name); err != nil {
+			m.statusbar.SetMessage(fmt.Sprintf("Schedule %s: %v", msg.Name, err))
+			return m, nil
+		}
+		m.statusbar.SetMessage(fmt.Sprintf("Schedule %s deleted", msg.Name))
+		return m, m.loadSchedules(false)
+
+	case messages.ScheduleAddMsg:
+		s, err := parseScheduleSpec(msg.Spec)
+		if err == nil {
+			err = m.scheduleStore.Add(s)
+		}
+		if err != nil {
+			m.statusbar.SetMessage(fmt.Sprintf("Schedule not added: %v", err))
+			return m, nil
+		}
+		m.statusbar.SetMessage(fmt.Sprintf("Schedule %s added, next run %s",
+			s.Name, s.Next(time.Now()).Format("Mon Jan 2 15:04")))
+		return m, m.loadSchedules(false)
+	}
+
+	return m, nil
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// Result limits per group of the search view
+const (
+	searchExecutionLimit = 20
+	searchOutputLimit    = 50
+)
+
+// openSearch switches to the search view, keeping the last query
+func (m Model) openSearch() Model {
+	if m.activeView != domain.ViewSearch {
+		m.prevView = m.activeView
+	}
+	m.activeView = domain.ViewSearch
+	m.header.SetActiveView(m.activeView)
+	return m
+}
+
+// runSearch looks up query in the loaded stories, the execution history and
+// the indexed output
+func (m Model) runSearch(query string) tea.Cmd {
+	stories := matchStories(m.stories, query)
+	return func() tea.Msg {
+		msg := messages.SearchResultsMsg{Query: query, Stories: stories}
+		if m.storage == nil {
+			return msg
+		}
+
+		ctx := context.Background()
+		records, err := m.storage.ListExecutions(ctx, &storage.ExecutionFilter{
+			Text:  strings.TrimSpace(query),
+			Limit: searchExecutionLimit,
+		})
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		for _, rec := range records {
+			msg.Executions = append(msg.Executions, &messages.HistoryExecution{
+				ID:        rec.ID,
+				StoryKey:  rec.StoryKey,
+				StoryEpic: rec.StoryEpic,
+				Status:    rec.Status,
+				StartTime: rec.StartTime,
+				Duration:  rec.Duration,
+				StepCount: len(rec.Steps),
+				ErrorMsg:  rec.Error,
+			})
+		}
+
+		matches, err := m.storage.SearchOutput(ctx, query, searchOutputLimit)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		for _, match := range matches {
+			msg.Output = append(msg.Output, &messages.OutputHit{
+				ExecutionID: match.ExecutionID,
+				StoryKey:    match.StoryKey,
+				StepName:    match.StepName,
+				Line:        match.Line,
+				StartTime:   match.StartTime,
+			})
+		}
+		return msg
+	}
+}
+
+// matchStories returns the stories whose key or title contains every word
+// of query, ignoring case
+func matchStories(stories []domain.Story, query string) []domain.Story {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var matched []domain.Story
+	for _, story := range stories {
+		text := strings.ToLower(story.Key + " " + story.Title)
+		all := true
+		for _, word := range words {
+			if !strings.Contains(text, word) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matched = append(matched, story)
+		}
+	}
+	return matched
+}
+
+// focusStory shows a story in the story list
+func (m Model) focusStory(key string) Model {
+	if !m.canView(domain.ViewStoryList) {
+		m.statusbar.SetMessage("Minimize the execution (m) to open " + domain.ViewStoryList.String())
+		return m
+	}
+	if !m.storylist.Focus(key) {
+		m.statusbar.SetMessage("Story not found: " + key)
+		return m
+	}
+	m.prevView = m.activeView
+	m.activeView = domain.ViewStoryList
+	m.header.SetActiveView(m.activeView)
+	return m
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/internal/git"
+)
+
+// workspaceRestoredMsg carries the result of restoring a pre-run snapshot
+type workspaceRestoredMsg struct {
+	StoryKey string
+	Error    error
+}
+
+// restorable reports whether an execution ended early and has a pre-run
+// snapshot to go back to
+func restorable(exec *domain.Execution) bool {
+	return exec != nil && exec.Snapshot != nil && exec.IsFinished() &&
+		exec.Status != domain.ExecutionCompleted
+}
+
+// requestWorkspaceRestore restores the working tree to how it was before
+// the execution shown in the execution view. Restoring discards changes, so
+// the first request only asks for confirmation.
+func (m Model) requestWorkspaceRestore() (Model, tea.Cmd) {
+	exec := m.execution.GetExecution()
+	if !restorable(exec) {
+		m.statusbar.SetMessage("No pre-run snapshot to restore for this execution")
+		return m, nil
+	}
+	if m.batchExecutor.IsRunning() || executor.IsStoryRunning(exec.Story.Key) {
+		m.statusbar.SetMessage("Cannot restore the workspace while a run is in progress")
+		return m, nil
+	}
+
+	if m.restoreArmed != exec.ID {
+		m.restoreArmed = exec.ID
+		m.statusbar.SetMessage(fmt.Sprintf("Press u to confirm: discards all changes made since %s started",
+			exec.Story.Key))
+		return m, nil
+	}
+
+	m.restoreArmed = ""
+	m.statusbar.SetMessage("Restoring workspace...")
+	workDir, snap, key := m.config.WorkingDir, exec.Snapshot, exec.Story.Key
+	return m, func() tea.Msg {
+		return workspaceRestoredMsg{StoryKey: key, Error: git.RestoreSnapshot(workDir, snap)}
+	}
+}
+
+// handleWorkspaceRestored reports a restore and refreshes the git status
+func (m Model) handleWorkspaceRestored(msg workspaceRestoredMsg) (Model, tea.Cmd) {
+	if msg.Error != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Workspace restore failed: %v", msg.Error))
+	} else {
+		m.statusbar.SetMessage(fmt.Sprintf("Workspace restored to its state before %s ran", msg.StoryKey))
+	}
+	return m, git.GetStatusCmd(m.config.WorkingDir)
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/components/steppreview"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// promptEditedMsg carries a prompt back from the external editor
+type promptEditedMsg struct {
+	Index  int
+	Prompt string
+	Error  error
+}
+
+// previewSteps opens the step preview for a run of story
+func (m Model) previewSteps(story domain.Story) Model {
+	previews := m.executor.PreviewSteps(story)
+	steps := make([]steppreview.Step, len(previews))
+	for i, p := range previews {
+		steps[i] = steppreview.Step{
+			Name:    p.Name,
+			Kind:    string(p.Kind),
+			Command: p.Command,
+			Prompt:  p.Prompt,
+			Skipped: p.Skipped,
+			Error:   p.Error,
+		}
+	}
+	m.stepPreview.Open(story, steps)
+	m.stepPreview.SetSize(m.width, m.height)
+	return m
+}
+
+// editPrompt opens the step's prompt in $VISUAL or $EDITOR, suspending the
+// TUI until the editor exits
+func (m Model) editPrompt(msg steppreview.EditMsg) tea.Cmd {
+	file, err := os.CreateTemp("", "bmad-prompt-*.md")
+	if err != nil {
+		return func() tea.Msg { return promptEditedMsg{Index: msg.Index, Error: err} }
+	}
+	path := file.Name()
+	_, err = file.WriteString(msg.Prompt + "\n")
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return promptEditedMsg{Index: msg.Index, Error: err} }
+	}
+
+	args := append(editorCommand(), path)
+	return tea.ExecProcess(exec.Command(args[0], args[1:]...), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return promptEditedMsg{Index: msg.Index, Error: err}
+		}
+		data, err := os.ReadFile(path)
+		return promptEditedMsg{Index: msg.Index, Prompt: string(data), Error: err}
+	})
+}
+
+// handlePromptEdited keeps the edited prompt for the previewed run
+func (m Model) handlePromptEdited(msg promptEditedMsg) Model {
+	if msg.Error != nil {
+		m.statusbar.SetMessage(fmt.Sprintf("Could not edit prompt: %v", msg.Error))
+		return m
+	}
+	m.stepPreview.SetPrompt(msg.Index, msg.Prompt)
+	return m
+}
+
+// editorCommand returns the user's editor command, split into its words
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// tagFilterPrefix marks a word of the history filter as a tag, as in
+// "tag:hotfix"
+const tagFilterPrefix = "tag:"
+
+// setTags replaces the tags a queued story's execution will be saved with
+func (m Model) setTags(msg messages.QueueTagsMsg) Model {
+	if !m.batchExecutor.SetTags(msg.Key, msg.Tags) {
+		m.statusbar.SetMessage("Not in the queue: " + msg.Key)
+		return m
+	}
+	if len(msg.Tags) == 0 {
+		m.statusbar.SetMessage("Tags cleared for " + msg.Key)
+		return m
+	}
+	m.statusbar.SetMessage("Tags for " + msg.Key + ": " + strings.Join(msg.Tags, ", "))
+	return m
+}
+
+// historyQueryFilter builds the storage filter for the history view's
+// filter text. Words starting with "tag:" must all be tags of the
+// execution; the rest matches the story key.
+func historyQueryFilter(query string, epic *int, status domain.ExecutionStatus) *storage.ExecutionFilter {
+	filter := &storage.ExecutionFilter{
+		Epic:   epic,
+		Status: status,
+		Limit:  100,
+	}
+	var words []string
+	for _, word := range strings.Fields(query) {
+		if tag, ok := strings.CutPrefix(strings.ToLower(word), tagFilterPrefix); ok {
+			filter.Tags = append(filter.Tags, domain.ParseTags(tag)...)
+			continue
+		}
+		words = append(words, word)
+	}
+	filter.StoryKey = strings.Join(words, " ")
+	return filter
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/telemetry"
+)
+
+// telemetryStateKey remembers the Settings opt-in across restarts
+const telemetryStateKey = storage.StateFeaturePrefix + "telemetry"
+
+// newTelemetry creates the usage recorder, letting a choice saved from
+// Settings override the environment default
+func newTelemetry(cfg *config.Config, store storage.Storage) *telemetry.Recorder {
+	if store != nil {
+		if value, err := store.GetState(context.Background(), telemetryStateKey); err == nil {
+			cfg.TelemetryEnabled = string(value) == "on"
+		}
+	}
+	return telemetry.New(cfg.TelemetryEnabled, cfg.TelemetryEndpoint)
+}
+
+// setTelemetryEnabled applies and remembers the Settings opt-in
+func (m Model) setTelemetryEnabled(enabled bool) {
+	m.telemetry.SetEnabled(enabled)
+	if m.storage == nil {
+		return
+	}
+	value := "off"
+	if enabled {
+		value = "on"
+	}
+	_ = m.storage.SetState(context.Background(), telemetryStateKey, []byte(value))
+}
+
+// usagePreview returns the Settings preview of the next usage report
+func usagePreview(rec *telemetry.Recorder) func() string {
+	return func() string {
+		status := "Off - nothing is recorded or sent"
+		if rec.IsEnabled() {
+			if endpoint := rec.Endpoint(); endpoint != "" {
+				status = "Sent to " + endpoint + " when a run finishes"
+			} else {
+				status = "Recording, but not sent: set BMAD_TELEMETRY_ENDPOINT"
+			}
+		}
+		return fmt.Sprintf("%s\n\n%s", status, rec.Preview())
+	}
+}
+
+// recordUsage counts execution messages for the usage report. A follower
+// only mirrors another instance, so it records nothing.
+func (m Model) recordUsage(msg tea.Msg) {
+	if m.following() {
+		return
+	}
+
+	switch msg := msg.(type) {
+	case messages.ExecutionStartedMsg:
+		m.telemetry.RecordFeatures(m.enabledFeatures()...)
+	case messages.StepCompletedMsg:
+		m.telemetry.RecordStep(msg.Status, msg.Error)
+	case messages.ExecutionCompletedMsg:
+		m.telemetry.RecordExecution(msg.Status)
+	}
+}
+
+// enabledFeatures lists the optional features in use for a run
+func (m Model) enabledFeatures() []string {
+	var features []string
+	add := func(on bool, name string) {
+		if on {
+			features = append(features, name)
+		}
+	}
+	add(m.config.ParallelEnabled, telemetry.FeatureParallel)
+	add(m.config.WatchEnabled, telemetry.FeatureWatch)
+	add(m.config.APIEnabled, telemetry.FeatureAPI)
+	add(m.config.ActiveWorkflow != "" && m.config.ActiveWorkflow != "default", telemetry.FeatureCustomWorkflow)
+	add(m.config.ActiveProfile != "", telemetry.FeatureProfile)
+	add(m.config.StallAutoRetry, telemetry.FeatureStallAutoRetry)
+	add(m.config.AdaptiveRetry, telemetry.FeatureAdaptiveRetry)
+	add(m.config.SoundEnabled, telemetry.FeatureSound)
+	add(m.config.NotificationsEnabled, telemetry.FeatureNotifications)
+	return features
+}
+
+// sendUsageReport uploads the recorded counts. Failures are silent; the
+// counts are kept and go out with the next report.
+func (m Model) sendUsageReport() tea.Msg {
+	_ = m.telemetry.Send(context.Background())
+	return nil
+}
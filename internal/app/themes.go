@@ -0,0 +1,80 @@
+package app
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+// loadThemes registers the theme files in the theme directories, and the
+// custom theme file when one is set, returning how many loaded
+func loadThemes(cfg *config.Config) (int, error) {
+	count := 0
+	var errs []error
+	for _, dir := range cfg.ThemeDirs() {
+		names, err := theme.LoadThemeDir(dir)
+		count += len(names)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if cfg.CustomThemePath != "" {
+		name := strings.TrimSuffix(filepath.Base(cfg.CustomThemePath), filepath.Ext(cfg.CustomThemePath))
+		if _, err := theme.RegisterThemeFile(strings.ToLower(name), cfg.CustomThemePath); err != nil {
+			errs = append(errs, err)
+		} else {
+			count++
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// defaultTheme returns the theme for the terminal background when none is
+// configured. Unless the background is set in the config the terminal is
+// asked for its color, which has to happen before Bubble Tea takes over the
+// terminal; terminals that don't answer count as dark.
+func defaultTheme(cfg *config.Config) string {
+	switch cfg.Background {
+	case config.BackgroundDark:
+		return theme.Default(true)
+	case config.BackgroundLight:
+		return theme.Default(false)
+	}
+	return theme.Default(lipgloss.HasDarkBackground())
+}
+
+// reloadThemes loads theme files added or changed since startup and
+// offers them in settings and the command palette
+func (m Model) reloadThemes() (Model, tea.Cmd) {
+	count, err := loadThemes(m.config)
+	theme.SetTheme(m.config.Theme)
+	m.settings.SetConfig(m.config)
+	m.refreshAllStyles()
+	if err != nil {
+		m.statusbar.SetMessage("Some themes did not load: " + firstLine(err))
+		return m, nil
+	}
+	m.statusbar.SetMessage(pluralThemes(count) + " loaded")
+	return m, nil
+}
+
+// firstLine returns the first line of a joined error
+func firstLine(err error) string {
+	line, _, _ := strings.Cut(err.Error(), "\n")
+	return line
+}
+
+// pluralThemes returns "1 theme file" or "n theme files"
+func pluralThemes(n int) string {
+	if n == 1 {
+		return "1 theme file"
+	}
+	return strconv.Itoa(n) + " theme files"
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// startTour opens the onboarding tour at its first view. The tour moves
+// between views, so it waits until nothing is running.
+func (m Model) startTour() (Model, tea.Cmd) {
+	if m.executionActive() {
+		m.statusbar.SetMessage("The tour is available once the running execution finishes")
+		return m, nil
+	}
+	m.tourReturn = m.activeView
+	m.tour.Start()
+	m.tour.SetSize(m.width, m.height)
+	return m.showTourStop()
+}
+
+// handleTourKey moves the tour on, returning to the view it started from
+// once it ends
+func (m Model) handleTourKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	before := m.tour.Current().View
+	m.tour = m.tour.Update(msg)
+
+	if !m.tour.IsActive() {
+		m.activeView = m.tourReturn
+		m.header.SetActiveView(m.activeView)
+		m.statusbar.SetMessage("Tour finished - Ctrl+P lists every action, ? the keys of each view")
+		return m, nil
+	}
+	if m.tour.Current().View == before {
+		return m, nil
+	}
+	return m.showTourStop()
+}
+
+// showTourStop switches to the view of the current stop and loads its data
+func (m Model) showTourStop() (Model, tea.Cmd) {
+	view := m.tour.Current().View
+	m.activeView = view
+	m.header.SetActiveView(view)
+
+	switch view {
+	case domain.ViewHistory:
+		m.history.SetLoading(true)
+	case domain.ViewStats:
+		m.stats.SetLoading(true)
+	case domain.ViewSchedules:
+		return m, m.loadSchedules(false)
+	}
+	return m, m.refreshView(view)
+}
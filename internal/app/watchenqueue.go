@@ -0,0 +1,77 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/watcher"
+	"github.com/robertguss/bmad-automate-go/internal/webhook"
+)
+
+// handleWatchReload acts on the stories that became ready-for-dev since the
+// previous load: they are queued with BMAD_WATCH_ENQUEUE and announced with
+// BMAD_WATCH_NOTIFY. Without a previous load there is nothing to compare
+// against, so nothing happens.
+func (m Model) handleWatchReload(before []domain.Story) (Model, tea.Cmd) {
+	if len(before) == 0 || m.following() {
+		return m, nil
+	}
+	ready := watcher.NewlyReady(before, m.stories)
+	if len(ready) == 0 {
+		return m, nil
+	}
+
+	if m.config.WatchEnqueue {
+		m = m.enqueueNewlyReady(ready)
+	}
+	if !m.config.WatchNotify {
+		return m, nil
+	}
+	if !m.config.WatchEnqueue {
+		m.statusbar.SetMessage(fmt.Sprintf("%d newly ready stories: %s", len(ready), strings.Join(storyKeys(ready), ", ")))
+	}
+	return m, m.notifyNewlyReady(ready)
+}
+
+// enqueueNewlyReady adds the ready stories that are not queued yet
+func (m Model) enqueueNewlyReady(ready []domain.Story) Model {
+	queue := m.batchExecutor.GetQueue()
+	var added []domain.Story
+	for _, story := range ready {
+		if !queue.Contains(story.Key) {
+			added = append(added, story)
+		}
+	}
+	if len(added) == 0 {
+		return m
+	}
+
+	m.batchExecutor.AddToQueue(added)
+	m.queue.SetQueue(m.batchExecutor.GetQueue())
+	m.statusbar.SetStoryCounts(len(m.stories), m.batchExecutor.GetQueue().TotalCount())
+	m.statusbar.SetMessage(fmt.Sprintf("Queued %d newly ready stories: %s", len(added), strings.Join(storyKeys(added), ", ")))
+	return m
+}
+
+// notifyNewlyReady sends a desktop notification and a stories.ready
+// webhook event listing the ready stories
+func (m Model) notifyNewlyReady(ready []domain.Story) tea.Cmd {
+	_ = m.notifier.NotifyStoriesReady(storyKeys(ready))
+
+	event := webhook.Event{Event: webhook.EventStoriesReady}
+	for _, story := range ready {
+		event.Stories = append(event.Stories, webhook.Story{Key: story.Key, Epic: story.Epic, Title: story.Title})
+	}
+	return m.deliverWebhook(event)
+}
+
+func storyKeys(stories []domain.Story) []string {
+	keys := make([]string, len(stories))
+	for i, story := range stories {
+		keys[i] = story.Key
+	}
+	return keys
+}
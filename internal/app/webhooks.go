@@ -0,0 +1,103 @@
+package app
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/webhook"
+)
+
+// webhookFailedMsg reports a webhook delivery that failed on every attempt
+type webhookFailedMsg struct {
+	Error error
+}
+
+// sendWebhook returns a command delivering the webhook event for msg, or
+// nil when msg has no event or no webhook is configured
+func (m Model) sendWebhook(msg tea.Msg) tea.Cmd {
+	event, ok := m.webhookEvent(msg)
+	if !ok {
+		return nil
+	}
+	return m.deliverWebhook(event)
+}
+
+// deliverWebhook returns a command delivering event, or nil when no webhook
+// is configured. A follower only mirrors another instance, which sends its
+// own events.
+func (m Model) deliverWebhook(event webhook.Event) tea.Cmd {
+	if !m.webhooks.Enabled() || m.following() {
+		return nil
+	}
+
+	hooks := m.webhooks
+	return func() tea.Msg {
+		if err := hooks.Send(context.Background(), event); err != nil {
+			return webhookFailedMsg{Error: err}
+		}
+		return nil
+	}
+}
+
+// webhookEvent builds the webhook event for an execution or queue message
+func (m Model) webhookEvent(msg tea.Msg) (webhook.Event, bool) {
+	exec := m.execution.GetExecution()
+
+	switch msg := msg.(type) {
+	case messages.ExecutionStartedMsg:
+		return executionEvent(webhook.EventExecutionStarted, msg.Execution), true
+
+	case messages.StepCompletedMsg:
+		if !msg.Belongs(exec) {
+			exec = nil
+		}
+		event := executionEvent(webhook.EventStepCompleted, exec)
+		if exec == nil && msg.ExecutionID != "" {
+			event.ExecutionID = msg.ExecutionID
+			event.Story = &webhook.Story{Key: msg.StoryKey}
+		}
+		event.Status = string(msg.Status)
+		event.DurationMS = msg.Duration.Milliseconds()
+		event.Error = msg.Error
+		event.Step = &webhook.Step{Index: msg.StepIndex}
+		if exec != nil && msg.StepIndex >= 0 && msg.StepIndex < len(exec.Steps) {
+			step := exec.Steps[msg.StepIndex]
+			event.Step.Name = string(step.Name)
+			event.Step.Attempt = step.Attempt
+		}
+		return event, true
+
+	case messages.ExecutionCompletedMsg:
+		event := executionEvent(webhook.EventExecutionCompleted, exec)
+		event.Status = string(msg.Status)
+		event.DurationMS = msg.Duration.Milliseconds()
+		event.Error = msg.Error
+		return event, true
+
+	case messages.QueueCompletedMsg:
+		return webhook.Event{
+			Event:      webhook.EventQueueCompleted,
+			DurationMS: msg.TotalDuration.Milliseconds(),
+			Queue: &webhook.QueueInfo{
+				Total:     msg.TotalItems,
+				Succeeded: msg.SuccessCount,
+				Failed:    msg.FailedCount,
+				Conflicts: msg.ConflictCount,
+			},
+		}, true
+	}
+	return webhook.Event{}, false
+}
+
+// executionEvent creates an event about exec's story
+func executionEvent(name string, exec *domain.Execution) webhook.Event {
+	event := webhook.Event{Event: name}
+	if exec != nil {
+		event.ExecutionID = exec.ID
+		event.Story = &webhook.Story{Key: exec.Story.Key, Epic: exec.Story.Epic, Title: exec.Story.Title}
+	}
+	return event
+}
@@ -0,0 +1,132 @@
+// Package badge records the result of the latest automated run in the
+// story's markdown file, so the story document shows what was done to it.
+package badge
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/git"
+)
+
+// The section is delimited by these markers so it can be found and
+// replaced on the next run
+const (
+	startMarker = "<!-- bmad-automation -->"
+	endMarker   = "<!-- /bmad-automation -->"
+)
+
+// Badge is the result of one run
+type Badge struct {
+	Status      domain.ExecutionStatus
+	ExecutionID string
+	Duration    time.Duration
+	Commit      string // Empty outside a git repository
+	Branch      string // Story branch, if the run used one
+	Finished    time.Time
+}
+
+// FromExecution returns the badge of a finished execution
+func FromExecution(exec *domain.Execution, commit string) Badge {
+	return Badge{
+		Status:      exec.Status,
+		ExecutionID: exec.ID,
+		Duration:    exec.Duration,
+		Commit:      commit,
+		Branch:      exec.Branch,
+		Finished:    exec.EndTime,
+	}
+}
+
+// Markdown renders the badge section, markers included
+func (b Badge) Markdown() string {
+	commit := "-"
+	if b.Commit != "" {
+		commit = "`" + shorten(b.Commit, 7) + "`"
+		if b.Branch != "" {
+			commit += " on `" + b.Branch + "`"
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(startMarker + "\n")
+	sb.WriteString("## Automation\n\n")
+	sb.WriteString("| Status | Execution | Duration | Commit | Finished |\n")
+	sb.WriteString("| ------ | --------- | -------- | ------ | -------- |\n")
+	fmt.Fprintf(&sb, "| %s | `%s` | %s | %s | %s |\n",
+		b.Status, shorten(b.ExecutionID, 8), b.Duration.Round(time.Second),
+		commit, b.Finished.Local().Format("2006-01-02 15:04"))
+	sb.WriteString(endMarker + "\n")
+	return sb.String()
+}
+
+// Apply returns content with its badge section replaced by b, or with the
+// section appended when there is none. Applying the same badge twice
+// gives the same content.
+func Apply(content string, b Badge) string {
+	section := b.Markdown()
+
+	start := strings.Index(content, startMarker)
+	if start >= 0 {
+		if end := strings.Index(content[start:], endMarker); end >= 0 {
+			rest := content[start+end+len(endMarker):]
+			rest = strings.TrimPrefix(rest, "\n")
+			return content[:start] + section + rest
+		}
+	}
+
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return section
+	}
+	return content + "\n\n" + section
+}
+
+// Write updates the badge section of the story file at path. A missing
+// file is an error; the file is not created.
+func Write(path string, b Badge) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("story file: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read story file: %w", err)
+	}
+
+	updated := Apply(string(data), b)
+	if updated == string(data) {
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(updated), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write story file: %w", err)
+	}
+	return nil
+}
+
+// Record writes the badge of a finished execution into its story file.
+// The commit is the tip of the story branch when the run used one, since
+// the original branch is checked out again afterwards.
+func Record(cfg *config.Config, exec *domain.Execution) error {
+	path := exec.Story.FilePath
+	if path == "" {
+		path = cfg.StoryFilePath(exec.Story.Key)
+	}
+
+	commit := git.HeadCommit(cfg.WorkingDir)
+	if exec.Branch != "" {
+		commit = git.RefCommit(cfg.WorkingDir, exec.Branch)
+	}
+	return Write(path, FromExecution(exec, commit))
+}
+
+func shorten(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,76 @@
+package badge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+var testBadge = Badge{
+	Status:      domain.ExecutionCompleted,
+	ExecutionID: "0f8fad5b-d9cb-469f-a165-70867728950e",
+	Duration:    12*time.Minute + 30*time.Second + 400*time.Millisecond,
+	Commit:      "9fceb02d0ae598e95dc970b74767f19372d61af8",
+	Branch:      "story/3-1-user-auth",
+	Finished:    time.Date(2024, 1, 15, 10, 0, 0, 0, time.Local),
+}
+
+func TestBadge_Markdown(t *testing.T) {
+	md := testBadge.Markdown()
+	assert.True(t, strings.HasPrefix(md, startMarker+"\n## Automation\n"))
+	assert.Contains(t, md, "| completed | `0f8fad5b` | 12m30s | `9fceb02` on `story/3-1-user-auth` | 2024-01-15 10:00 |")
+	assert.True(t, strings.HasSuffix(md, endMarker+"\n"))
+
+	noGit := testBadge
+	noGit.Commit = ""
+	assert.Contains(t, noGit.Markdown(), "| 12m30s | - |")
+}
+
+func TestApply(t *testing.T) {
+	story := "# Story 3.1\n\nAs a user...\n"
+
+	once := Apply(story, testBadge)
+	assert.Equal(t, story+"\n"+testBadge.Markdown(), once)
+	assert.Equal(t, once, Apply(once, testBadge), "applying again changes nothing")
+
+	failed := testBadge
+	failed.Status = domain.ExecutionFailed
+	updated := Apply(once, failed)
+	assert.Equal(t, 1, strings.Count(updated, startMarker))
+	assert.Contains(t, updated, "| failed |")
+	assert.NotContains(t, updated, "| completed |")
+
+	t.Run("content after the section is kept", func(t *testing.T) {
+		content := "# Story\n\n" + testBadge.Markdown() + "\n## Notes\n"
+		updated := Apply(content, failed)
+		assert.Equal(t, "# Story\n\n"+failed.Markdown()+"\n## Notes\n", updated)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		assert.Equal(t, testBadge.Markdown(), Apply("", testBadge))
+	})
+}
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "3-1-user-auth.md")
+	require.Error(t, Write(path, testBadge), "story files are not created")
+
+	require.NoError(t, os.WriteFile(path, []byte("# Story\n"), 0600))
+	require.NoError(t, Write(path, testBadge))
+	require.NoError(t, Write(path, testBadge))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# Story\n\n"+testBadge.Markdown(), string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
@@ -0,0 +1,365 @@
+// Package bundle packs the configuration kept in the data directory into a
+// single archive, and restores it on another machine. History, queue state
+// and other runtime data stay behind.
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/schedule"
+)
+
+// Format is the bundle layout version written to the manifest
+const Format = 1
+
+// ManifestName is the manifest file at the root of a bundle
+const ManifestName = "bundle.yaml"
+
+// maxBundleSize bounds how much an import reads, as configuration files
+// are small
+const maxBundleSize = 16 << 20
+
+// dirs are the data directory folders a bundle carries
+var dirs = []string{"profiles", "workflows", "pipelines", "themes"}
+
+// files are the single files of the data directory a bundle carries
+var files = []string{schedule.FileName}
+
+// Manifest describes a bundle
+type Manifest struct {
+	Format  int       `yaml:"format"`
+	Created time.Time `yaml:"created"`
+	Version string    `yaml:"version"` // bmad version that wrote it
+	Files   []string  `yaml:"files"`
+	Env     []string  `yaml:"env,omitempty"`     // Variables in config.env
+	Omitted []string  `yaml:"omitted,omitempty"` // Secret variables left out
+}
+
+// ExportOptions control what a bundle includes
+type ExportOptions struct {
+	Version string
+	Secrets bool // Include tokens, keys and passwords
+	Environ []string
+}
+
+// Export writes the configuration in dataDir, and the BMAD_* variables of
+// opts.Environ, as a gzipped tar archive
+func Export(dataDir string, w io.Writer, opts ExportOptions) (*Manifest, error) {
+	contents := make(map[string][]byte)
+	for _, dir := range dirs {
+		if err := collectDir(dataDir, dir, contents); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range files {
+		data, err := os.ReadFile(filepath.Join(dataDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		contents[name] = data
+	}
+
+	manifest := &Manifest{Format: Format, Created: time.Now().UTC(), Version: opts.Version}
+	var env []config.EnvVar
+	for _, v := range bmadEnv(opts.Environ) {
+		if !opts.Secrets && config.IsSecretEnv(v.Name) {
+			manifest.Omitted = append(manifest.Omitted, v.Name)
+			continue
+		}
+		env = append(env, v)
+		manifest.Env = append(manifest.Env, v.Name)
+	}
+	if len(env) > 0 {
+		contents[config.EnvFileName] = config.FormatEnvFile(env)
+	}
+
+	for name := range contents {
+		manifest.Files = append(manifest.Files, name)
+	}
+	sort.Strings(manifest.Files)
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeFile(tw, ManifestName, data, manifest.Created); err != nil {
+		return nil, err
+	}
+	for _, name := range manifest.Files {
+		if err := writeFile(tw, name, contents[name], manifest.Created); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+// collectDir adds the regular files of dataDir/dir to contents
+func collectDir(dataDir, dir string, contents map[string][]byte) error {
+	entries, err := os.ReadDir(filepath.Join(dataDir, dir))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dataDir, dir, entry.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read %s/%s: %w", dir, entry.Name(), err)
+		}
+		contents[dir+"/"+entry.Name()] = data
+	}
+	return nil
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// bmadEnv returns the BMAD_* variables of environ, sorted by name
+func bmadEnv(environ []string) []config.EnvVar {
+	var vars []config.EnvVar
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if ok && strings.HasPrefix(name, "BMAD_") {
+			vars = append(vars, config.EnvVar{Name: name, Value: value})
+		}
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+	return vars
+}
+
+// ImportOptions control how a bundle is restored
+type ImportOptions struct {
+	Force bool // Replace files and variables that differ
+}
+
+// ImportResult lists what an import changed
+type ImportResult struct {
+	Manifest  *Manifest
+	Written   []string // Files created or replaced
+	Unchanged []string // Files already identical
+	Skipped   []string // Files and variables that differ, kept without Force
+}
+
+// Import restores a bundle into dataDir. The whole bundle is read and
+// checked before anything is written. Files that exist with other content,
+// and variables already set differently in config.env, are kept unless
+// opts.Force is set.
+func Import(dataDir string, r io.Reader, opts ImportOptions) (*ImportResult, error) {
+	manifest, contents, err := read(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Manifest: manifest}
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data := contents[name]
+		target := filepath.Join(dataDir, filepath.FromSlash(name))
+
+		if name == config.EnvFileName {
+			var skipped []string
+			data, skipped, err = mergeEnv(target, data, opts.Force)
+			if err != nil {
+				return result, err
+			}
+			for _, v := range skipped {
+				result.Skipped = append(result.Skipped, name+": "+v)
+			}
+		}
+
+		existing, err := os.ReadFile(target)
+		switch {
+		case err == nil && bytes.Equal(existing, data):
+			result.Unchanged = append(result.Unchanged, name)
+			continue
+		case err == nil && !opts.Force && name != config.EnvFileName:
+			result.Skipped = append(result.Skipped, name)
+			continue
+		case err != nil && !os.IsNotExist(err):
+			return result, fmt.Errorf("failed to read %s: %w", target, err)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return result, fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		perm := os.FileMode(0644)
+		if name == config.EnvFileName {
+			perm = 0600 // May hold tokens
+		}
+		if err := os.WriteFile(target, data, perm); err != nil {
+			return result, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		result.Written = append(result.Written, name)
+	}
+	return result, nil
+}
+
+// read reads and checks a bundle
+func read(r io.Reader) (*Manifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, errors.New("not a bmad config bundle (want a .tar.gz from bmad config export)")
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(io.LimitReader(gz, maxBundleSize))
+	contents := make(map[string][]byte)
+	var manifest *Manifest
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, nil, fmt.Errorf("bundle entry %s is not a regular file", hdr.Name)
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", hdr.Name, err)
+		}
+		if hdr.Name == ManifestName {
+			manifest = &Manifest{}
+			if err := yaml.Unmarshal(data, manifest); err != nil {
+				return nil, nil, fmt.Errorf("invalid manifest: %w", err)
+			}
+			continue
+		}
+		if !allowed(hdr.Name) {
+			return nil, nil, fmt.Errorf("bundle entry %s is not part of a bmad config bundle", hdr.Name)
+		}
+		contents[hdr.Name] = data
+	}
+
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("not a bmad config bundle: %s is missing", ManifestName)
+	}
+	if manifest.Format > Format {
+		return nil, nil, fmt.Errorf("bundle format %d is newer than this bmad supports (%d)", manifest.Format, Format)
+	}
+	if data, ok := contents[config.EnvFileName]; ok {
+		if _, err := config.ParseEnvFile(data); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", config.EnvFileName, err)
+		}
+	}
+	return manifest, contents, nil
+}
+
+// allowed reports whether a bundle entry is one Export writes: a file
+// directly inside a carried folder, or a carried single file
+func allowed(name string) bool {
+	if name != path.Clean(name) || path.IsAbs(name) || strings.Contains(name, "\\") {
+		return false
+	}
+	if name == config.EnvFileName {
+		return true
+	}
+	for _, f := range files {
+		if name == f {
+			return true
+		}
+	}
+	dir, file := path.Split(name)
+	for _, d := range dirs {
+		if dir == d+"/" && file != "" && file != ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeEnv adds the variables of a bundle's config.env to the one at
+// target. Variables set differently there are kept unless force is set;
+// their names are returned.
+func mergeEnv(target string, data []byte, force bool) ([]byte, []string, error) {
+	incoming, err := config.ParseEnvFile(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	existingData, err := os.ReadFile(target)
+	if os.IsNotExist(err) {
+		return data, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", target, err)
+	}
+	merged, err := config.ParseEnvFile(existingData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", target, err)
+	}
+
+	index := make(map[string]int, len(merged))
+	for i, v := range merged {
+		index[v.Name] = i
+	}
+	var skipped []string
+	changed := false
+	for _, v := range incoming {
+		i, ok := index[v.Name]
+		switch {
+		case !ok:
+			index[v.Name] = len(merged)
+			merged = append(merged, v)
+			changed = true
+		case merged[i].Value == v.Value:
+		case force:
+			merged[i].Value = v.Value
+			changed = true
+		default:
+			skipped = append(skipped, v.Name)
+		}
+	}
+	if !changed {
+		return existingData, skipped, nil // Keep the file as written, comments included
+	}
+	return config.FormatEnvFile(merged), skipped, nil
+}
@@ -0,0 +1,117 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestExportImport(t *testing.T) {
+	src := t.TempDir()
+	writeTestFile(t, filepath.Join(src, "profiles", "work.yaml"), "name: work\n")
+	writeTestFile(t, filepath.Join(src, "profiles", ".active"), "work")
+	writeTestFile(t, filepath.Join(src, "workflows", "quick.yaml"), "name: quick\n")
+	writeTestFile(t, filepath.Join(src, "schedules.yaml"), "schedules: []\n")
+	writeTestFile(t, filepath.Join(src, "bmad.db"), "not carried")
+	writeTestFile(t, filepath.Join(src, "inbox", "req.yaml"), "not carried")
+
+	var buf bytes.Buffer
+	manifest, err := Export(src, &buf, ExportOptions{
+		Version: "1.2.3",
+		Environ: []string{"BMAD_THEME=nord", "BMAD_API_KEY=secret", "HOME=/root", "BMAD_TIMEOUT=600"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"config.env", "profiles/.active", "profiles/work.yaml", "schedules.yaml", "workflows/quick.yaml"}, manifest.Files)
+	assert.Equal(t, []string{"BMAD_THEME", "BMAD_TIMEOUT"}, manifest.Env)
+	assert.Equal(t, []string{"BMAD_API_KEY"}, manifest.Omitted)
+
+	dst := t.TempDir()
+	writeTestFile(t, filepath.Join(dst, "workflows", "quick.yaml"), "name: quick\nsteps: []\n")
+	writeTestFile(t, filepath.Join(dst, "config.env"), "# mine\nBMAD_THEME=dracula\nBMAD_SOUND=1\n")
+
+	result, err := Import(dst, bytes.NewReader(buf.Bytes()), ImportOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", result.Manifest.Version)
+	assert.Equal(t, []string{"config.env", "profiles/.active", "profiles/work.yaml", "schedules.yaml"}, result.Written)
+	assert.Equal(t, []string{"config.env: BMAD_THEME", "workflows/quick.yaml"}, result.Skipped)
+
+	data, err := os.ReadFile(filepath.Join(dst, "profiles", "work.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name: work\n", string(data))
+	env, err := os.ReadFile(filepath.Join(dst, "config.env"))
+	require.NoError(t, err)
+	assert.Equal(t, "BMAD_THEME=\"dracula\"\nBMAD_SOUND=\"1\"\nBMAD_TIMEOUT=\"600\"\n", string(env))
+	assert.NoFileExists(t, filepath.Join(dst, "bmad.db"))
+
+	t.Run("again changes nothing", func(t *testing.T) {
+		result, err := Import(dst, bytes.NewReader(buf.Bytes()), ImportOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, result.Written)
+		assert.Contains(t, result.Unchanged, "profiles/work.yaml")
+	})
+
+	t.Run("force replaces", func(t *testing.T) {
+		result, err := Import(dst, bytes.NewReader(buf.Bytes()), ImportOptions{Force: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"config.env", "workflows/quick.yaml"}, result.Written)
+		assert.Empty(t, result.Skipped)
+	})
+}
+
+func TestExport_Secrets(t *testing.T) {
+	var buf bytes.Buffer
+	manifest, err := Export(t.TempDir(), &buf, ExportOptions{
+		Secrets: true,
+		Environ: []string{"BMAD_GITHUB_TOKEN=ghp_x"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BMAD_GITHUB_TOKEN"}, manifest.Env)
+	assert.Empty(t, manifest.Omitted)
+}
+
+func TestImport_Rejects(t *testing.T) {
+	archive := func(entries map[string]string) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for name, content := range entries {
+			require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+			_, err := tw.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, gz.Close())
+		return buf.Bytes()
+	}
+
+	tests := map[string][]byte{
+		"not gzip":         []byte("plain text"),
+		"no manifest":      archive(map[string]string{"profiles/a.yaml": "name: a"}),
+		"path traversal":   archive(map[string]string{ManifestName: "format: 1", "profiles/../../evil": "x"}),
+		"unknown file":     archive(map[string]string{ManifestName: "format: 1", "bmad.db": "x"}),
+		"nested directory": archive(map[string]string{ManifestName: "format: 1", "profiles/a/b.yaml": "x"}),
+		"newer format":     archive(map[string]string{ManifestName: "format: 99"}),
+		"bad env file":     archive(map[string]string{ManifestName: "format: 1", "config.env": "not an assignment"}),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			dst := t.TempDir()
+			_, err := Import(dst, bytes.NewReader(data), ImportOptions{})
+			assert.Error(t, err)
+			entries, _ := os.ReadDir(dst)
+			assert.Empty(t, entries, "nothing is written")
+		})
+	}
+}
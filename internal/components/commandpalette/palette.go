@@ -1,6 +1,7 @@
 package commandpalette
 
 import (
+	"slices"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -43,11 +44,33 @@ func New() Model {
 	m := Model{
 		styles: theme.NewStyles(),
 	}
-	m.commands = m.defaultCommands()
+	m.commands = withThemeCommands(m.defaultCommands())
 	m.filtered = m.commands
 	return m
 }
 
+// withThemeCommands adds a command for each loaded theme file after the
+// last built-in theme command
+func withThemeCommands(commands []Command) []Command {
+	at := len(commands)
+	for i, cmd := range commands {
+		if cmd.Category == "Theme" {
+			at = i + 1
+		}
+	}
+	var themes []Command
+	for _, name := range theme.CustomThemes() {
+		t, _ := theme.CustomTheme(name)
+		themes = append(themes, Command{
+			Name:        "Theme: " + t.Name,
+			Description: "Switch to the " + name + " theme file",
+			Category:    "Theme",
+			Action:      func() tea.Msg { return ThemeChangeMsg{Theme: name} },
+		})
+	}
+	return slices.Insert(commands, at, themes...)
+}
+
 func (m Model) defaultCommands() []Command {
 	return []Command{
 		// Navigation
@@ -86,6 +109,31 @@ func (m Model) defaultCommands() []Command {
 			Category:    "Navigation",
 			Action:      func() tea.Msg { return NavigateMsg{View: domain.ViewStats} },
 		},
+		{
+			Name:        "Go to Epics",
+			Description: "Progress of each epic; queue a whole epic",
+			Category:    "Navigation",
+			Action:      func() tea.Msg { return NavigateMsg{View: domain.ViewEpics} },
+		},
+		{
+			Name:        "Go to Pipelines",
+			Description: "View pipeline runs",
+			Category:    "Navigation",
+			Action:      func() tea.Msg { return NavigateMsg{View: domain.ViewPipelines} },
+		},
+		{
+			Name:        "Go to Schedules",
+			Description: "Manage cron schedules that start the queue",
+			Category:    "Navigation",
+			Action:      func() tea.Msg { return NavigateMsg{View: domain.ViewSchedules} },
+		},
+		{
+			Name:        "Search",
+			Description: "Search stories, history and saved output",
+			Shortcut:    "Ctrl+F",
+			Category:    "Navigation",
+			Action:      func() tea.Msg { return NavigateMsg{View: domain.ViewSearch} },
+		},
 		{
 			Name:        "Go to Settings",
 			Description: "Configure application settings",
@@ -100,6 +148,12 @@ func (m Model) defaultCommands() []Command {
 			Category:    "Theme",
 			Action:      func() tea.Msg { return ThemeChangeMsg{Theme: "catppuccin"} },
 		},
+		{
+			Name:        "Theme: Catppuccin Latte",
+			Description: "Switch to the light Catppuccin Latte theme",
+			Category:    "Theme",
+			Action:      func() tea.Msg { return ThemeChangeMsg{Theme: "latte"} },
+		},
 		{
 			Name:        "Theme: Dracula",
 			Description: "Switch to Dracula theme",
@@ -112,6 +166,18 @@ func (m Model) defaultCommands() []Command {
 			Category:    "Theme",
 			Action:      func() tea.Msg { return ThemeChangeMsg{Theme: "nord"} },
 		},
+		{
+			Name:        "Theme: Color Blind Safe",
+			Description: "Switch to the color-blind safe theme",
+			Category:    "Theme",
+			Action:      func() tea.Msg { return ThemeChangeMsg{Theme: "colorblind"} },
+		},
+		{
+			Name:        "Reload Themes",
+			Description: "Load theme files added to ~/.bmad/themes or .bmad/themes",
+			Category:    "Theme",
+			Action:      func() tea.Msg { return ActionMsg{Action: "reload_themes"} },
+		},
 		// Actions
 		{
 			Name:        "Start Queue",
@@ -119,6 +185,48 @@ func (m Model) defaultCommands() []Command {
 			Category:    "Actions",
 			Action:      func() tea.Msg { return ActionMsg{Action: "start_queue"} },
 		},
+		{
+			Name:        "Start Queue in 5 Minutes",
+			Description: "Start the queue after a countdown; it can still be edited",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "start_queue_5m"} },
+		},
+		{
+			Name:        "Start Queue in 15 Minutes",
+			Description: "Start the queue after a countdown; it can still be edited",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "start_queue_15m"} },
+		},
+		{
+			Name:        "Start Queue in 30 Minutes",
+			Description: "Start the queue after a countdown; it can still be edited",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "start_queue_30m"} },
+		},
+		{
+			Name:        "Start Queue in 60 Minutes",
+			Description: "Start the queue after a countdown; it can still be edited",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "start_queue_60m"} },
+		},
+		{
+			Name:        "Cancel Delayed Start",
+			Description: "Stop a scheduled queue start",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "cancel_delayed_start"} },
+		},
+		{
+			Name:        "Minimize Execution",
+			Description: "Keep the run going with its progress in the status bar",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "minimize_execution"} },
+		},
+		{
+			Name:        "Show Execution",
+			Description: "Return to the running or last execution",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "show_execution"} },
+		},
 		{
 			Name:        "Pause Queue",
 			Description: "Pause current queue execution",
@@ -133,10 +241,64 @@ func (m Model) defaultCommands() []Command {
 		},
 		{
 			Name:        "Refresh Stories",
-			Description: "Reload stories from sprint-status.yaml",
+			Description: "Reload stories from sprint-status.yaml or the configured tracker",
 			Category:    "Actions",
 			Action:      func() tea.Msg { return ActionMsg{Action: "refresh"} },
 		},
+		{
+			Name:        "Run Pre-flight Checks",
+			Description: "Check the Claude CLI, story files, git repository and disk space again",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "run_preflight"} },
+		},
+		{
+			Name:        "Take the Tour",
+			Description: "Walk through each view, its keys and the concepts behind it",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "start_tour"} },
+		},
+		{
+			Name:        "Restore Workspace",
+			Description: "Undo the shown run's changes using its pre-run snapshot",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "restore_workspace"} },
+		},
+		{
+			Name:        "Export History (JSON)",
+			Description: "Write executions and steps to a JSON file in the data directory",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "export_history_json"} },
+		},
+		{
+			Name:        "Export History with Output (JSON)",
+			Description: "Like Export History (JSON), including every step's output",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "export_history_json_output"} },
+		},
+		{
+			Name:        "Export History (CSV)",
+			Description: "Write executions and steps as CSV files in the data directory",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "export_history_csv"} },
+		},
+		{
+			Name:        "Export Stats Report (HTML)",
+			Description: "Write the statistics of the stats view's date range as a shareable HTML page",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "export_stats_html"} },
+		},
+		{
+			Name:        "Prune History",
+			Description: "Delete executions beyond the configured retention limits",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "prune_history"} },
+		},
+		{
+			Name:        "Repair Database",
+			Description: "Reopen the history database, recreating it if corrupt",
+			Category:    "Actions",
+			Action:      func() tea.Msg { return ActionMsg{Action: "repair_database"} },
+		},
 	}
 }
 
@@ -299,7 +461,7 @@ func (m Model) View() string {
 
 	// Input field
 	inputStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Primary).
 		Padding(0, 1).
 		Width(paletteWidth - 2)
@@ -325,7 +487,7 @@ func (m Model) View() string {
 	resultsList := lipgloss.JoinVertical(lipgloss.Left, resultRows...)
 
 	resultsStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Width(paletteWidth - 2).
 		MaxHeight(maxItems * 2)
@@ -343,7 +505,7 @@ func (m Model) View() string {
 	paletteStyle := lipgloss.NewStyle().
 		Background(t.Background).
 		Padding(1).
-		Border(lipgloss.DoubleBorder()).
+		Border(theme.OverlayBorder()).
 		BorderForeground(t.Primary)
 
 	return lipgloss.Place(
@@ -110,7 +110,7 @@ func (m Model) View() string {
 	border := lipgloss.NewStyle().
 		Foreground(t.Border).
 		Width(m.width).
-		Render(strings.Repeat("─", m.width))
+		Render(theme.Rule(m.width))
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, border)
 }
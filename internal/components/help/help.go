@@ -0,0 +1,148 @@
+package help
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/keymap"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+// Model represents the help overlay
+type Model struct {
+	width  int
+	height int
+	view   domain.View // View whose keys are listed first
+	scroll int
+	active bool
+}
+
+// New creates a new help overlay
+func New() Model {
+	return Model{}
+}
+
+// Open shows the keys of view and the global ones
+func (m *Model) Open(view domain.View) {
+	m.active = true
+	m.view = view
+	m.scroll = 0
+}
+
+// Close hides the overlay
+func (m *Model) Close() {
+	m.active = false
+}
+
+// IsActive returns whether the overlay is open
+func (m Model) IsActive() bool {
+	return m.active
+}
+
+// SetSize sets the overlay dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.active {
+		return m, nil
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc", "?", "q":
+			m.Close()
+		case "up", "k":
+			if m.scroll > 0 {
+				m.scroll--
+			}
+		case "down", "j":
+			if m.scroll < m.maxScroll() {
+				m.scroll++
+			}
+		}
+	}
+	return m, nil
+}
+
+// lines renders the sections, one line per binding
+func (m Model) lines() []string {
+	t := theme.Current
+	titleStyle := lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(t.Accent).Bold(true)
+	helpStyle := lipgloss.NewStyle().Foreground(t.Foreground)
+
+	var lines []string
+	for i, group := range keymap.Groups(m.view) {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, titleStyle.Render(group.Title))
+
+		keyWidth := 0
+		for _, b := range group.Bindings {
+			keyWidth = max(keyWidth, len(b.Keys))
+		}
+		for _, b := range group.Bindings {
+			keys := keyStyle.Render(b.Keys + strings.Repeat(" ", keyWidth-len(b.Keys)))
+			lines = append(lines, "  "+keys+"  "+helpStyle.Render(b.Help))
+		}
+	}
+	return lines
+}
+
+// visibleLines is how many lines fit in the overlay
+func (m Model) visibleLines() int {
+	return max(m.height-10, 5)
+}
+
+func (m Model) maxScroll() int {
+	return max(len(m.lines())-m.visibleLines(), 0)
+}
+
+// View renders the help overlay
+func (m Model) View() string {
+	t := theme.Current
+
+	lines := m.lines()
+	end := min(m.scroll+m.visibleLines(), len(lines))
+	body := strings.Join(lines[m.scroll:end], "\n")
+
+	hint := "Esc or ? to close"
+	if m.maxScroll() > 0 {
+		hint = "Up/Down to scroll | " + hint
+	}
+	footer := lipgloss.NewStyle().Foreground(t.Subtle).Render(hint)
+
+	box := lipgloss.NewStyle().
+		Background(t.Background).
+		Padding(1, 2).
+		Border(theme.OverlayBorder()).
+		BorderForeground(t.Primary).
+		Render(lipgloss.JoinVertical(lipgloss.Left,
+			lipgloss.NewStyle().Foreground(t.Foreground).Bold(true).Render("Keyboard Shortcuts"),
+			"",
+			body,
+			"",
+			footer,
+		))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// Overlay renders the help over content
+func (m Model) Overlay(content string) string {
+	if !m.active {
+		return content
+	}
+	return lipgloss.NewStyle().
+		Background(theme.Current.Background).
+		Width(m.width).
+		Height(m.height).
+		Render(m.View())
+}
@@ -15,6 +15,10 @@ type Model struct {
 	gitClean   bool
 	storyCount int
 	queueCount int
+	selected   int    // Stories selected in the story list
+	countdown  string // Shown next to the counts while a delayed start is pending
+	progress   string // Pinned progress of a minimized execution
+	warning    string // Pinned until the condition behind it clears
 	message    string
 	styles     theme.Styles
 }
@@ -45,11 +49,36 @@ func (m *Model) SetStoryCounts(stories, queue int) {
 	m.queueCount = queue
 }
 
+// SetSelectedCount sets how many stories are selected (0 hides it)
+func (m *Model) SetSelectedCount(n int) {
+	m.selected = n
+}
+
+// SetCountdown sets the delayed start countdown ("" hides it)
+func (m *Model) SetCountdown(countdown string) {
+	m.countdown = countdown
+}
+
+// SetProgress sets the progress of a minimized execution ("" hides it)
+func (m *Model) SetProgress(progress string) {
+	m.progress = progress
+}
+
+// SetWarning pins a warning next to the counts ("" hides it)
+func (m *Model) SetWarning(warning string) {
+	m.warning = warning
+}
+
 // SetMessage sets a temporary status message
 func (m *Model) SetMessage(msg string) {
 	m.message = msg
 }
 
+// Message returns the current status message
+func (m Model) Message() string {
+	return m.message
+}
+
 // ClearMessage clears the status message
 func (m *Model) ClearMessage() {
 	m.message = ""
@@ -63,7 +92,7 @@ func (m Model) View() string {
 	border := lipgloss.NewStyle().
 		Foreground(t.Border).
 		Width(m.width).
-		Render(strings.Repeat("─", m.width))
+		Render(theme.Rule(m.width))
 
 	// Git info
 	gitStatus := "Clean"
@@ -83,6 +112,19 @@ func (m Model) View() string {
 		lipgloss.NewStyle().Foreground(t.Foreground).Bold(true).Render(fmt.Sprintf("%d", m.storyCount)),
 		lipgloss.NewStyle().Foreground(t.Foreground).Bold(true).Render(fmt.Sprintf("%d", m.queueCount)),
 	)
+	if m.selected > 0 {
+		counts += fmt.Sprintf(" | Selected: %s",
+			lipgloss.NewStyle().Foreground(t.Success).Bold(true).Render(fmt.Sprintf("%d", m.selected)))
+	}
+	if m.countdown != "" {
+		counts += " | " + lipgloss.NewStyle().Foreground(t.Accent).Bold(true).Render(m.countdown)
+	}
+	if m.progress != "" {
+		counts += " | " + lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(m.progress)
+	}
+	if m.warning != "" {
+		counts += " | " + lipgloss.NewStyle().Foreground(t.Error).Bold(true).Render(m.warning)
+	}
 
 	// Message or help
 	var rightContent string
@@ -0,0 +1,240 @@
+package steppreview
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+// Step is one step of the run being previewed
+type Step struct {
+	Name    domain.StepName
+	Kind    string // agent, shell, http or wait
+	Command string
+	Prompt  string // The workflow's prompt; "" for steps without one
+	Skipped bool
+	Error   string
+
+	edited string // Prompt for this run, "" = the workflow's
+}
+
+// editable reports whether the step sends a prompt that can be edited
+func (s Step) editable() bool {
+	return s.Prompt != "" && s.Error == ""
+}
+
+// prompt returns the prompt the run will send
+func (s Step) prompt() string {
+	if s.edited != "" {
+		return s.edited
+	}
+	return s.Prompt
+}
+
+// EditMsg asks for the prompt of the step at Index to be edited
+type EditMsg struct {
+	Index  int
+	Prompt string
+}
+
+// RunMsg is sent when the previewed run is confirmed
+type RunMsg struct {
+	Story   domain.Story
+	Prompts map[domain.StepName]string // Edited prompts by step
+}
+
+// Model is the step preview overlay shown before a run starts
+type Model struct {
+	width  int
+	height int
+	active bool
+	story  domain.Story
+	steps  []Step
+	cursor int
+}
+
+// New creates a closed step preview
+func New() Model {
+	return Model{}
+}
+
+// Open previews the steps a run of story would start
+func (m *Model) Open(story domain.Story, steps []Step) {
+	m.active = true
+	m.story = story
+	m.steps = steps
+	m.cursor = 0
+	for i, step := range steps {
+		if step.editable() && !step.Skipped {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// Close hides the overlay, dropping any edits
+func (m *Model) Close() {
+	m.active = false
+	m.steps = nil
+}
+
+// IsActive returns whether the overlay is open
+func (m Model) IsActive() bool {
+	return m.active
+}
+
+// SetSize sets the overlay dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetPrompt sets the prompt the step at index sends in this run. Text equal
+// to the workflow's prompt, or empty, undoes the edit.
+func (m *Model) SetPrompt(index int, prompt string) {
+	if index < 0 || index >= len(m.steps) {
+		return
+	}
+	prompt = strings.TrimSpace(prompt)
+	if prompt == strings.TrimSpace(m.steps[index].Prompt) {
+		prompt = ""
+	}
+	m.steps[index].edited = prompt
+}
+
+// prompts returns the edited prompts by step
+func (m Model) prompts() map[domain.StepName]string {
+	prompts := make(map[domain.StepName]string)
+	for _, step := range m.steps {
+		if step.edited != "" {
+			prompts[step.Name] = step.edited
+		}
+	}
+	return prompts
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !m.active || !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "esc", "q":
+		m.Close()
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.steps)-1 {
+			m.cursor++
+		}
+	case "e":
+		if step := m.steps[m.cursor]; step.editable() {
+			index, prompt := m.cursor, step.prompt()
+			return m, func() tea.Msg { return EditMsg{Index: index, Prompt: prompt} }
+		}
+	case "u":
+		m.steps[m.cursor].edited = ""
+	case "enter":
+		run := RunMsg{Story: m.story, Prompts: m.prompts()}
+		m.Close()
+		return m, func() tea.Msg { return run }
+	}
+	return m, nil
+}
+
+// View renders the step preview
+func (m Model) View() string {
+	t := theme.Current
+	width := max(min(m.width-8, 100), 40)
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+	nameStyle := lipgloss.NewStyle().Foreground(t.Foreground).Bold(true)
+
+	var rows []string
+	for i, step := range m.steps {
+		cursor := "  "
+		style := nameStyle
+		if i == m.cursor {
+			cursor = lipgloss.NewStyle().Foreground(t.Accent).Render("> ")
+			style = style.Foreground(t.Primary)
+		}
+
+		line := cursor + style.Render(fmt.Sprintf("%d. %s", i+1, step.Name)) + "  " + muted.Render(step.Kind)
+		switch {
+		case step.Error != "":
+			line += "  " + lipgloss.NewStyle().Foreground(t.Error).Render("template error")
+		case step.Skipped:
+			line += "  " + muted.Render("skipped")
+		case step.edited != "":
+			line += "  " + lipgloss.NewStyle().Foreground(t.Warning).Bold(true).Render("edited")
+		}
+		rows = append(rows, line)
+
+		detail := step.Command
+		if step.Error != "" {
+			detail = step.Error
+		} else if step.Prompt != "" {
+			detail = step.prompt()
+		}
+		if i == m.cursor {
+			// The selected step shows all of its text, wrapped
+			rows = append(rows, lipgloss.NewStyle().
+				Foreground(t.Foreground).
+				PaddingLeft(5).
+				Width(width).
+				Render(detail), "")
+		} else {
+			rows = append(rows, muted.Render("     "+truncate(detail, width-5)))
+		}
+	}
+
+	title := nameStyle.Render("Run " + m.story.Key)
+	hint := "Up/Down: select | e: edit prompt | u: undo edit | Enter: run | Esc: cancel"
+	footer := muted.Render(hint)
+	note := muted.Render("Edits apply to this run only and are saved with it")
+
+	box := lipgloss.NewStyle().
+		Background(t.Background).
+		Padding(1, 2).
+		Border(theme.OverlayBorder()).
+		BorderForeground(t.Primary).
+		Render(lipgloss.JoinVertical(lipgloss.Left,
+			title,
+			note,
+			"",
+			strings.Join(rows, "\n"),
+			"",
+			footer,
+		))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// Overlay renders the preview over content
+func (m Model) Overlay(content string) string {
+	if !m.active {
+		return content
+	}
+	return lipgloss.NewStyle().
+		Background(theme.Current.Background).
+		Width(m.width).
+		Height(m.height).
+		Render(m.View())
+}
+
+// truncate shortens s to one line of at most width characters
+func truncate(s string, width int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if width < 4 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-3]) + "..."
+}
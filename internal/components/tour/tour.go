@@ -0,0 +1,144 @@
+package tour
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/keymap"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+// maxBindings caps the keys listed in a callout so it leaves most of the
+// view visible; the help overlay has the rest
+const maxBindings = 8
+
+// Model is the onboarding tour: a callout over the bottom of each view in
+// turn, explaining it and listing its keys
+type Model struct {
+	width  int
+	height int
+	stop   int
+	active bool
+}
+
+// New creates a new tour
+func New() Model {
+	return Model{}
+}
+
+// Start opens the tour at its first stop
+func (m *Model) Start() {
+	m.active = true
+	m.stop = 0
+}
+
+// Close ends the tour
+func (m *Model) Close() {
+	m.active = false
+}
+
+// IsActive returns whether the tour is running
+func (m Model) IsActive() bool {
+	return m.active
+}
+
+// Current returns the stop on screen
+func (m Model) Current() keymap.Stop {
+	return keymap.Tour[m.stop]
+}
+
+// SetSize sets the screen dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Update moves between stops. Moving past the last stop ends the tour.
+func (m Model) Update(msg tea.Msg) Model {
+	if !m.active {
+		return m
+	}
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "right", "l", "n", "enter", " ":
+			if m.stop == len(keymap.Tour)-1 {
+				m.Close()
+			} else {
+				m.stop++
+			}
+		case "left", "h", "p", "backspace":
+			m.stop = max(m.stop-1, 0)
+		case "esc", "q":
+			m.Close()
+		}
+	}
+	return m
+}
+
+// callout renders the box for the current stop
+func (m Model) callout() string {
+	t := theme.Current
+	stop := m.Current()
+	keyStyle := lipgloss.NewStyle().Foreground(t.Accent).Bold(true)
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	title := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render(stop.Title) +
+		muted.Render(fmt.Sprintf("  %d/%d", m.stop+1, len(keymap.Tour)))
+
+	lines := []string{title, ""}
+	for _, line := range stop.About {
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Foreground).Render(line))
+	}
+
+	bindings := stop.Bindings()
+	if len(bindings) > maxBindings {
+		bindings = bindings[:maxBindings]
+	}
+	keyWidth := 0
+	for _, b := range bindings {
+		keyWidth = max(keyWidth, len(b.Keys))
+	}
+	lines = append(lines, "")
+	for _, b := range bindings {
+		lines = append(lines, "  "+keyStyle.Render(b.Keys+strings.Repeat(" ", keyWidth-len(b.Keys)))+"  "+b.Help)
+	}
+	if more := len(stop.Bindings()) - len(bindings); more > 0 {
+		lines = append(lines, muted.Render(fmt.Sprintf("  ...and %d more, press ? in this view", more)))
+	}
+
+	next := "Enter/→ next"
+	if m.stop == len(keymap.Tour)-1 {
+		next = "Enter finish"
+	}
+	lines = append(lines, "", muted.Render(next+" | ← back | Esc end the tour"))
+
+	return lipgloss.NewStyle().
+		Background(t.Background).
+		Padding(0, 2).
+		Border(theme.OverlayBorder()).
+		BorderForeground(t.Accent).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// Overlay draws the callout over the bottom of content, above the status
+// bar on its last two lines, leaving the top of the view visible
+func (m Model) Overlay(content string) string {
+	if !m.active {
+		return content
+	}
+
+	screen := strings.Split(content, "\n")
+	box := strings.Split(m.callout(), "\n")
+	start := max(len(screen)-2-len(box), 0)
+	for i, line := range box {
+		if start+i >= len(screen) {
+			screen = append(screen, "")
+		}
+		screen[start+i] = lipgloss.PlaceHorizontal(m.width, lipgloss.Center, line)
+	}
+	return strings.Join(screen, "\n")
+}
@@ -1,8 +1,14 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Default configuration values
@@ -16,10 +22,96 @@ const (
 	DefaultAPIPort       = 8080
 	DefaultMaxWorkers    = 1
 	DefaultWatchDebounce = 500 // milliseconds
+	DefaultStallTimeout  = 0   // Off: unstreamed claude -p is silent until it ends
+
+	// API requests each client may make: any route per second, and runs
+	// started or stories refreshed per minute
+	DefaultAPIRateLimit      = 100
+	DefaultAPIStartRateLimit = 10
+
+	// DefaultStoryBranchPrefix names the branches of branch-per-story runs
+	DefaultStoryBranchPrefix = "story/"
+
+	// DefaultDiffBase is the branch story diffs are compared against
+	DefaultDiffBase = "main"
+
+	// DefaultMinClaudeVersion is the oldest Claude CLI the pre-flight
+	// check accepts
+	DefaultMinClaudeVersion = "1.0.0"
+
+	// DefaultMinFreeDiskMB is the free space the pre-flight check expects
+	// in the data and working directories
+	DefaultMinFreeDiskMB = 500
+
+	// DefaultDBSizeLimitMB is the database size at which new step output
+	// is truncated
+	DefaultDBSizeLimitMB = 1024
+
+	// DefaultCommitTrailer is added to automated commits and PR descriptions
+	DefaultCommitTrailer = "Automated-by: bmad {execution_id}"
+
+	// Token prices in US dollars per million, used to estimate cost when
+	// the Claude CLI does not report it
+	DefaultInputPrice  = 3.0
+	DefaultOutputPrice = 15.0
+)
+
+// Merge conflict strategies applied when the git-commit step leaves
+// unresolved conflicts behind
+const (
+	ConflictResolve = "resolve" // Run a resolve-conflicts agent step
+	ConflictPark    = "park"    // Mark the story as conflicted and move on
+)
+
+// Story sources the story list can be loaded from
+const (
+	StorySourceSprintStatus = "sprint-status" // sprint-status.yaml
+	StorySourceJira         = "jira"          // A Jira JQL query or board
+	StorySourceGitHub       = "github"        // Issues of a GitHub repository
+)
+
+// Failure report targets: where a story that fails after all retries is
+// reported
+const (
+	FailureReportOff    = "off"    // Don't report failures
+	FailureReportGitHub = "github" // Open an issue in GitHubRepo
+	FailureReportFile   = "file"   // Append to FailureFile
+
+	// DefaultFailureFile is the file failures are appended to, relative to
+	// the working directory
+	DefaultFailureFile = "failures.md"
+)
+
+// DefaultGitHubAPIURL is the GitHub REST API base URL
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// DefaultAnthropicAPIURL is the Anthropic API base URL
+const DefaultAnthropicAPIURL = "https://api.anthropic.com"
+
+// Terminal backgrounds the default theme is picked for
+const (
+	BackgroundAuto  = "auto"  // Ask the terminal for its background color
+	BackgroundDark  = "dark"  // Catppuccin Mocha
+	BackgroundLight = "light" // Catppuccin Latte
+)
+
+// Queue orders control which pending story the batch and parallel
+// executors run next
+const (
+	QueueOrderFIFO       = "fifo"        // Queue order
+	QueueOrderRoundRobin = "round-robin" // Epics take turns
+	QueueOrderDeadline   = "deadline"    // Earliest deadline first
 )
 
 // Config holds all application configuration
 type Config struct {
+	// Version of the bmad binary, set by main from the build flags
+	Version string
+
+	// Why the config.env file in the data directory could not be loaded, if
+	// it could not. Its settings are then ignored.
+	EnvFileErr error
+
 	// Paths
 	SprintStatusPath string
 	StoryDir         string
@@ -27,17 +119,137 @@ type Config struct {
 	DataDir          string // Directory for app data (database, etc.)
 	DatabasePath     string // Path to SQLite database
 
+	// Where stories come from (from BMAD_STORY_SOURCE, default sprint-status)
+	StorySource string
+
+	// Jira story source. The token is sent with basic auth when an email
+	// is set (Jira Cloud) and as a bearer token otherwise (Data Center).
+	JiraURL       string            // Base URL, e.g. https://example.atlassian.net
+	JiraEmail     string            // Account email for Jira Cloud
+	JiraToken     string            // API token or personal access token
+	JiraJQL       string            // JQL query selecting the stories
+	JiraBoard     string            // Board ID; combined with JiraJQL when both are set
+	JiraStatusMap map[string]string // Jira status name -> story status overrides
+
+	// GitHub Issues story source
+	GitHubRepo      string // owner/name
+	GitHubToken     string // From BMAD_GITHUB_TOKEN or GITHUB_TOKEN
+	GitHubLabels    string // Comma-separated labels an issue must all have
+	GitHubMilestone string // Milestone number or title
+	GitHubState     string // open (default), closed or all
+	GitHubAPIURL    string // API base URL, for GitHub Enterprise
+
 	// Execution settings
-	Timeout int // seconds
-	Retries int
+	Timeout          int // seconds
+	Retries          int
+	StallTimeout     int    // seconds without output before a step is marked stalled (0 = disabled)
+	StallAutoRetry   bool   // Kill and retry a stalled step instead of just reporting it
+	AdaptiveRetry    bool   // Tell an agent step being retried how its last attempt failed (from BMAD_ADAPTIVE_RETRY)
+	ConflictStrategy string // How to handle merge conflicts after git-commit (resolve or park)
+	QueueOrder       string // How pending queue items are picked (fifo, round-robin or deadline)
+
+	// Record the git working tree before each sequential run so it can be
+	// compared or restored later (disable with BMAD_WORKSPACE_SNAPSHOTS=0)
+	WorkspaceSnapshots bool
+
+	// Run each sequential story on its own branch, <prefix><story key>,
+	// and switch back afterwards (from BMAD_STORY_BRANCHES)
+	StoryBranches     bool
+	StoryBranchPrefix string // From BMAD_STORY_BRANCH_PREFIX (default "story/")
+
+	// Stash uncommitted changes before each sequential story and restore
+	// them afterwards (from BMAD_AUTO_STASH)
+	AutoStash bool
+
+	// Branch or commit a story's changes are diffed against (from
+	// BMAD_DIFF_BASE)
+	DiffBase string
+
+	// Oldest Claude CLI version the pre-flight check accepts (from
+	// BMAD_MIN_CLAUDE_VERSION, "0" accepts any)
+	MinClaudeVersion string
+
+	// Free space, in MB, the data and working directories need for the
+	// pre-flight check to pass (from BMAD_MIN_FREE_DISK_MB, 0 disables)
+	MinFreeDiskMB int
+
+	// Check before runs that the Anthropic API key has credit left and is
+	// not rate limited (from BMAD_QUOTA_CHECK)
+	QuotaCheck       bool
+	AnthropicAPIKey  string // From ANTHROPIC_API_KEY
+	AnthropicBaseURL string // From ANTHROPIC_BASE_URL, for proxies and gateways
+
+	// Show the steps a story would run, with their prompts, before starting
+	// it from the story list (from BMAD_PREVIEW_STEPS)
+	PreviewSteps bool
+
+	// Trailer lines added to automated commits and PR descriptions.
+	// {execution_id}, {story} and {epic} are replaced per execution.
+	CommitTrailers []string
 
 	// UI settings
-	Theme           string
-	CustomThemePath string // Path to custom theme YAML file
+	Theme           string // From BMAD_THEME; empty picks one for the terminal background at startup
+	Background      string // BackgroundAuto, BackgroundDark or BackgroundLight (from BMAD_BACKGROUND)
+	CustomThemePath string // Theme file loaded besides the theme directories, named after the file
+	AccessibleMode  bool   // Screen-reader friendly output (from BMAD_ACCESSIBLE env)
+	ReducedMotion   bool   // No confetti and fewer redraws (from BMAD_REDUCED_MOTION env, implied by AccessibleMode)
 
 	// Feature flags
-	SoundEnabled         bool
+	SoundEnabled         bool            // From BMAD_SOUND or the Settings toggle
+	SoundMuted           map[string]bool // Events that stay silent, e.g. "warning" (from BMAD_SOUND_MUTE, comma-separated)
 	NotificationsEnabled bool
+	SlowStepAlerts       bool // Notify when a step runs well past its historical average (from BMAD_NOTIFY_SLOW_STEPS)
+	StepFailureAlerts    bool // Notify when a step fails after its retries (from BMAD_NOTIFY_STEP_FAILURES)
+	StoryCompleteAlerts  bool // Notify when a story run outside a queue ends (from BMAD_NOTIFY_STORY_COMPLETE)
+	StallAlerts          bool // Notify when a step is flagged as stalled (from BMAD_NOTIFY_STALLS)
+
+	// Usage metrics: anonymous aggregate counts, sent only after opting in
+	TelemetryEnabled  bool   // From BMAD_TELEMETRY or the Settings toggle
+	TelemetryEndpoint string // Where reports are POSTed (from BMAD_TELEMETRY_ENDPOINT)
+
+	// Failure reports for stories that fail after all retries. A profile's
+	// failure_report setting takes precedence.
+	FailureReport string   // off (default), github or file (from BMAD_FAILURE_REPORT)
+	FailureFile   string   // Markdown file for file reports (from BMAD_FAILURE_FILE)
+	FailureLabels []string // Labels for GitHub issues (from BMAD_FAILURE_LABELS, comma-separated)
+
+	// Webhooks: execution events POSTed as JSON to each URL
+	WebhookURLs   []string // From BMAD_WEBHOOK_URLS (comma-separated)
+	WebhookSecret string   // Signs each payload when set (from BMAD_WEBHOOK_SECRET)
+
+	// History retention, applied hourly and by the Prune History action
+	// (0 = unlimited)
+	HistoryMaxAgeDays int // From BMAD_HISTORY_MAX_AGE_DAYS
+	HistoryMaxRows    int // Executions kept (from BMAD_HISTORY_MAX_ROWS)
+	HistoryMaxSizeMB  int // From BMAD_HISTORY_MAX_SIZE_MB
+
+	// Size guard: once the database file grows past this, new step output
+	// keeps only its last lines in the database, the full text going to
+	// files under OutputDir, until pruning brings it back under
+	DBSizeLimitMB int // From BMAD_DB_SIZE_LIMIT_MB (default 1024, 0 = off)
+
+	// Inbox: JSON requests dropped into InboxDir are added to the queue
+	InboxEnabled bool // From BMAD_INBOX
+
+	// Write the result of each run into an Automation section of the story
+	// file (from BMAD_STORY_BADGES)
+	StoryBadges bool
+
+	// Token usage: agent steps run with streamed JSON output so their token
+	// counts and cost can be recorded (from BMAD_USAGE_TRACKING)
+	UsageTracking    bool
+	UsageInputPrice  float64 // Dollars per million input tokens (from BMAD_USAGE_PRICES, "input,output")
+	UsageOutputPrice float64 // Dollars per million output tokens
+
+	// Run windows: queue starts outside them are deferred until the next one
+	// opens, e.g. "22:00-06:00 weekdays" (parsed by the schedule package)
+	RunWindows     string // From BMAD_RUN_WINDOWS
+	RunWindowPause bool   // Pause at a story boundary rather than overrun a window (from BMAD_RUN_WINDOW_PAUSE)
+
+	// Auto-refresh: how often a visible view reloads its data, keyed by view
+	// ("dashboard", "stories", "history", "stats"); views not listed only
+	// refresh on r
+	AutoRefresh map[string]time.Duration // From BMAD_AUTO_REFRESH, e.g. "history=30s;stats=5m"
 
 	// Phase 6: Profile settings
 	ActiveProfile string // Name of active profile
@@ -48,6 +260,8 @@ type Config struct {
 	// Phase 6: Watch mode settings
 	WatchEnabled  bool // Enable file watching
 	WatchDebounce int  // Debounce time in milliseconds
+	WatchEnqueue  bool // Queue stories that change to ready-for-dev
+	WatchNotify   bool // Notify (desktop and webhooks) when stories change to ready-for-dev
 
 	// Phase 6: Parallel execution settings
 	MaxWorkers      int  // Max parallel workers (1 = sequential)
@@ -56,6 +270,12 @@ type Config struct {
 	// Phase 6: API server settings
 	APIEnabled bool // Enable REST API server
 	APIPort    int  // Port for API server
+	GRPCPort   int  // Port for the gRPC control API, served alongside it (0 = off, from BMAD_GRPC_PORT)
+	APIDocs    bool // Serve a Swagger UI page at /api/docs (from BMAD_API_DOCS)
+
+	// Per-client API rate limits, counted per API key or else per IP
+	APIRateLimit      int // Requests per second (from BMAD_API_RATE_LIMIT)
+	APIStartRateLimit int // Executions started and story refreshes per minute (from BMAD_API_START_RATE_LIMIT)
 
 	// Security settings
 	APIKey             string   // API key for authentication (optional, from BMAD_API_KEY env)
@@ -67,28 +287,222 @@ func New() *Config {
 	wd, _ := os.Getwd()
 	dataDir := filepath.Join(wd, DefaultDataDir)
 
-	return &Config{
+	// Settings saved in the data directory fill in for unset variables
+	envFileErr := loadEnvFile(filepath.Join(dataDir, EnvFileName))
+
+	cfg := &Config{
+		Version:              "dev",
+		EnvFileErr:           envFileErr,
 		SprintStatusPath:     filepath.Join(wd, DefaultSprintStatus),
 		StoryDir:             filepath.Join(wd, DefaultStoryDir),
 		WorkingDir:           wd,
+		StorySource:          envDefault("BMAD_STORY_SOURCE", StorySourceSprintStatus),
+		JiraURL:              strings.TrimRight(os.Getenv("BMAD_JIRA_URL"), "/"),
+		JiraEmail:            os.Getenv("BMAD_JIRA_EMAIL"),
+		JiraToken:            os.Getenv("BMAD_JIRA_TOKEN"),
+		JiraJQL:              os.Getenv("BMAD_JIRA_JQL"),
+		JiraBoard:            os.Getenv("BMAD_JIRA_BOARD"),
+		JiraStatusMap:        parseStatusMap(os.Getenv("BMAD_JIRA_STATUS_MAP")),
+		GitHubRepo:           os.Getenv("BMAD_GITHUB_REPO"),
+		GitHubToken:          envDefault("BMAD_GITHUB_TOKEN", os.Getenv("GITHUB_TOKEN")),
+		GitHubLabels:         os.Getenv("BMAD_GITHUB_LABELS"),
+		GitHubMilestone:      os.Getenv("BMAD_GITHUB_MILESTONE"),
+		GitHubState:          envDefault("BMAD_GITHUB_STATE", "open"),
+		GitHubAPIURL:         strings.TrimRight(envDefault("BMAD_GITHUB_API_URL", DefaultGitHubAPIURL), "/"),
 		DataDir:              dataDir,
 		DatabasePath:         filepath.Join(dataDir, DefaultDBName),
 		Timeout:              DefaultTimeout,
 		Retries:              DefaultRetries,
-		Theme:                "catppuccin",
-		SoundEnabled:         false,
+		StallTimeout:         envInt("BMAD_STALL_TIMEOUT", DefaultStallTimeout),
+		StallAutoRetry:       envBool("BMAD_STALL_AUTO_RETRY"),
+		AdaptiveRetry:        envBool("BMAD_ADAPTIVE_RETRY"),
+		ConflictStrategy:     envChoice("BMAD_CONFLICT_STRATEGY", ConflictResolve, ConflictPark),
+		QueueOrder:           envChoice("BMAD_QUEUE_ORDER", QueueOrderFIFO, QueueOrderRoundRobin, QueueOrderDeadline),
+		WorkspaceSnapshots:   os.Getenv("BMAD_WORKSPACE_SNAPSHOTS") != "0",
+		StoryBranches:        envBool("BMAD_STORY_BRANCHES"),
+		StoryBranchPrefix:    envDefault("BMAD_STORY_BRANCH_PREFIX", DefaultStoryBranchPrefix),
+		AutoStash:            envBool("BMAD_AUTO_STASH"),
+		DiffBase:             envDefault("BMAD_DIFF_BASE", DefaultDiffBase),
+		MinClaudeVersion:     envDefault("BMAD_MIN_CLAUDE_VERSION", DefaultMinClaudeVersion),
+		MinFreeDiskMB:        envInt("BMAD_MIN_FREE_DISK_MB", DefaultMinFreeDiskMB),
+		QuotaCheck:           envBool("BMAD_QUOTA_CHECK"),
+		AnthropicAPIKey:      os.Getenv("ANTHROPIC_API_KEY"),
+		AnthropicBaseURL:     envDefault("ANTHROPIC_BASE_URL", DefaultAnthropicAPIURL),
+		PreviewSteps:         envBool("BMAD_PREVIEW_STEPS"),
+		CommitTrailers:       defaultCommitTrailers(),
+		Theme:                os.Getenv("BMAD_THEME"),
+		Background:           envDefault("BMAD_BACKGROUND", BackgroundAuto),
+		AccessibleMode:       envBool("BMAD_ACCESSIBLE"),
+		ReducedMotion:        envBool("BMAD_REDUCED_MOTION") || envBool("BMAD_ACCESSIBLE"),
+		SoundEnabled:         envBool("BMAD_SOUND"),
+		SoundMuted:           parseSet(os.Getenv("BMAD_SOUND_MUTE")),
 		NotificationsEnabled: true,
+		SlowStepAlerts:       envBool("BMAD_NOTIFY_SLOW_STEPS"),
+		StepFailureAlerts:    envBool("BMAD_NOTIFY_STEP_FAILURES"),
+		StoryCompleteAlerts:  envBool("BMAD_NOTIFY_STORY_COMPLETE"),
+		StallAlerts:          envBool("BMAD_NOTIFY_STALLS"),
+		TelemetryEnabled:     envBool("BMAD_TELEMETRY"),
+		TelemetryEndpoint:    os.Getenv("BMAD_TELEMETRY_ENDPOINT"),
+		FailureReport:        envDefault("BMAD_FAILURE_REPORT", FailureReportOff),
+		FailureFile:          envDefault("BMAD_FAILURE_FILE", DefaultFailureFile),
+		FailureLabels:        splitList(os.Getenv("BMAD_FAILURE_LABELS"), ","),
+		WebhookURLs:          splitList(os.Getenv("BMAD_WEBHOOK_URLS"), ","),
+		WebhookSecret:        os.Getenv("BMAD_WEBHOOK_SECRET"),
+		HistoryMaxAgeDays:    envInt("BMAD_HISTORY_MAX_AGE_DAYS", 0),
+		HistoryMaxRows:       envInt("BMAD_HISTORY_MAX_ROWS", 0),
+		HistoryMaxSizeMB:     envInt("BMAD_HISTORY_MAX_SIZE_MB", 0),
+		DBSizeLimitMB:        envInt("BMAD_DB_SIZE_LIMIT_MB", DefaultDBSizeLimitMB),
+		InboxEnabled:         envBool("BMAD_INBOX"),
+		AutoRefresh:          parseIntervals(os.Getenv("BMAD_AUTO_REFRESH")),
+		StoryBadges:          envBool("BMAD_STORY_BADGES"),
+		RunWindows:           os.Getenv("BMAD_RUN_WINDOWS"),
+		RunWindowPause:       envBool("BMAD_RUN_WINDOW_PAUSE"),
+		UsageTracking:        envBool("BMAD_USAGE_TRACKING"),
+		UsageInputPrice:      DefaultInputPrice,
+		UsageOutputPrice:     DefaultOutputPrice,
 		ActiveProfile:        "",
 		ActiveWorkflow:       "default",
 		WatchEnabled:         false,
 		WatchDebounce:        DefaultWatchDebounce,
+		WatchEnqueue:         envBool("BMAD_WATCH_ENQUEUE"),
+		WatchNotify:          envBool("BMAD_WATCH_NOTIFY"),
 		MaxWorkers:           DefaultMaxWorkers,
 		ParallelEnabled:      false,
-		APIEnabled:           false,
-		APIPort:              DefaultAPIPort,
+		APIEnabled:           envBool("BMAD_API"),
+		APIPort:              envInt("BMAD_API_PORT", DefaultAPIPort),
+		GRPCPort:             envInt("BMAD_GRPC_PORT", 0),
+		APIDocs:              envBool("BMAD_API_DOCS"),
+		APIRateLimit:         envInt("BMAD_API_RATE_LIMIT", DefaultAPIRateLimit),
+		APIStartRateLimit:    envInt("BMAD_API_START_RATE_LIMIT", DefaultAPIStartRateLimit),
 		APIKey:               os.Getenv("BMAD_API_KEY"),
 		CORSAllowedOrigins:   defaultCORSOrigins(),
 	}
+	if os.Getenv("BMAD_DB_SIZE_LIMIT_MB") == "0" {
+		cfg.DBSizeLimitMB = 0
+	}
+	if os.Getenv("BMAD_MIN_FREE_DISK_MB") == "0" {
+		cfg.MinFreeDiskMB = 0
+	}
+	cfg.UsageInputPrice, cfg.UsageOutputPrice = parsePrices(os.Getenv("BMAD_USAGE_PRICES"), cfg.UsageInputPrice, cfg.UsageOutputPrice)
+	return cfg
+}
+
+// envBool reports whether an environment variable is set to a truthy value
+func envBool(key string) bool {
+	switch os.Getenv(key) {
+	case "1", "true", "TRUE", "True", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// envDefault returns an environment variable, or def when it is unset or empty
+func envDefault(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// envChoice returns an environment variable when it is def or one of the
+// other choices, or def when it is unset or anything else
+func envChoice(key, def string, choices ...string) string {
+	value := os.Getenv(key)
+	for _, choice := range choices {
+		if value == choice {
+			return value
+		}
+	}
+	return def
+}
+
+// envInt returns an environment variable as a positive number, or def when
+// it is unset or not one
+func envInt(key string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// parseSet parses a comma-separated list into a set of lowercased names
+func parseSet(value string) map[string]bool {
+	set := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// parseStatusMap parses "Name=status;Other Name=status" pairs. Names are
+// lowercased so lookups ignore case.
+func parseStatusMap(value string) map[string]string {
+	statuses := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		name, status, ok := strings.Cut(pair, "=")
+		name, status = strings.TrimSpace(name), strings.TrimSpace(status)
+		if ok && name != "" && status != "" {
+			statuses[strings.ToLower(name)] = status
+		}
+	}
+	return statuses
+}
+
+// parseIntervals parses "name=duration;name=duration" pairs, dropping
+// entries whose duration is invalid or not positive
+func parseIntervals(value string) map[string]time.Duration {
+	intervals := make(map[string]time.Duration)
+	for _, pair := range splitList(value, ";") {
+		name, interval, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || d <= 0 {
+			continue
+		}
+		intervals[strings.ToLower(strings.TrimSpace(name))] = d
+	}
+	return intervals
+}
+
+// parsePrices parses "input,output" token prices, keeping the defaults
+// when value is empty or either price is invalid or negative
+func parsePrices(value string, input, output float64) (float64, float64) {
+	in, out, ok := strings.Cut(value, ",")
+	if !ok {
+		return input, output
+	}
+	inPrice, err1 := strconv.ParseFloat(strings.TrimSpace(in), 64)
+	outPrice, err2 := strconv.ParseFloat(strings.TrimSpace(out), 64)
+	if err1 != nil || err2 != nil || inPrice < 0 || outPrice < 0 {
+		return input, output
+	}
+	return inPrice, outPrice
+}
+
+// splitList splits value on sep, dropping empty entries
+func splitList(value, sep string) []string {
+	var items []string
+	for _, part := range strings.Split(value, sep) {
+		if part = strings.TrimSpace(part); part != "" {
+			items = append(items, part)
+		}
+	}
+	return items
+}
+
+// defaultCommitTrailers returns the commit trailers from BMAD_COMMIT_TRAILERS
+// (semicolon-separated, empty to disable) or the default trailer
+func defaultCommitTrailers() []string {
+	value, ok := os.LookupEnv("BMAD_COMMIT_TRAILERS")
+	if !ok {
+		return []string{DefaultCommitTrailer}
+	}
+
+	return splitList(value, ";")
 }
 
 // defaultCORSOrigins returns the default CORS origins based on environment
@@ -172,3 +586,64 @@ func (c *Config) StoryFileExists(storyKey string) bool {
 	_, err := os.Stat(c.StoryFilePath(storyKey))
 	return err == nil
 }
+
+// StoryBranch returns the branch a story runs on when branch-per-story is
+// enabled
+func (c *Config) StoryBranch(storyKey string) string {
+	return c.StoryBranchPrefix + storyKey
+}
+
+// InboxDir returns the directory watched for dropped queue requests
+func (c *Config) InboxDir() string {
+	return filepath.Join(c.DataDir, "inbox")
+}
+
+// ThemeDirs returns the directories theme files are loaded from: the
+// user's ~/.bmad/themes, then the project's, whose themes win on a clash
+func (c *Config) ThemeDirs() []string {
+	var dirs []string
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, DefaultDataDir, "themes"))
+	}
+	project := filepath.Join(c.DataDir, "themes")
+	if len(dirs) == 0 || dirs[0] != project {
+		dirs = append(dirs, project)
+	}
+	return dirs
+}
+
+// SoundsDir returns the directory custom sound files are loaded from
+func (c *Config) SoundsDir() string {
+	return filepath.Join(c.DataDir, "sounds")
+}
+
+// OutputDir returns the directory full step output is written to while
+// the database is over its size limit
+func (c *Config) OutputDir() string {
+	return filepath.Join(c.DataDir, "output")
+}
+
+// FailureReportPath returns the file failures are appended to. Relative
+// paths are resolved against the working directory.
+func (c *Config) FailureReportPath() string {
+	if filepath.IsAbs(c.FailureFile) {
+		return c.FailureFile
+	}
+	return filepath.Join(c.WorkingDir, c.FailureFile)
+}
+
+// Digest returns a short hash of the settings that change how stories are
+// executed, so two runs can be checked for the same configuration.
+// Paths, credentials and UI settings are left out.
+func (c *Config) Digest() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "timeout=%d\nretries=%d\nstall=%d,%t\nconflicts=%s\norder=%s\n",
+		c.Timeout, c.Retries, c.StallTimeout, c.StallAutoRetry, c.ConflictStrategy, c.QueueOrder)
+	fmt.Fprintf(h, "snapshots=%t\ntrailers=%q\nsource=%s\nworkflow=%s\nprofile=%s\n",
+		c.WorkspaceSnapshots, c.CommitTrailers, c.StorySource, c.ActiveWorkflow, c.ActiveProfile)
+	fmt.Fprintf(h, "workers=%d\n", c.MaxWorkers)
+	fmt.Fprintf(h, "story-branches=%t,%s\n", c.StoryBranches, c.StoryBranchPrefix)
+	fmt.Fprintf(h, "auto-stash=%t\n", c.AutoStash)
+	fmt.Fprintf(h, "adaptive-retry=%t\n", c.AdaptiveRetry)
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
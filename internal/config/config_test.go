@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,8 +21,15 @@ func TestNew(t *testing.T) {
 		assert.Equal(t, DefaultRetries, cfg.Retries)
 	})
 
-	t.Run("sets default theme", func(t *testing.T) {
-		assert.Equal(t, "catppuccin", cfg.Theme)
+	t.Run("leaves stall detection off", func(t *testing.T) {
+		assert.Zero(t, cfg.StallTimeout)
+		assert.False(t, cfg.StallAutoRetry)
+		assert.False(t, cfg.StallAlerts)
+	})
+
+	t.Run("leaves the theme to the terminal background", func(t *testing.T) {
+		assert.Empty(t, cfg.Theme)
+		assert.Equal(t, BackgroundAuto, cfg.Background)
 	})
 
 	t.Run("sets default workflow", func(t *testing.T) {
@@ -48,6 +56,14 @@ func TestNew(t *testing.T) {
 		assert.True(t, cfg.NotificationsEnabled)
 	})
 
+	t.Run("slow step alerts disabled by default", func(t *testing.T) {
+		assert.False(t, cfg.SlowStepAlerts)
+	})
+
+	t.Run("resolves merge conflicts by default", func(t *testing.T) {
+		assert.Equal(t, ConflictResolve, cfg.ConflictStrategy)
+	})
+
 	t.Run("watch disabled by default", func(t *testing.T) {
 		assert.False(t, cfg.WatchEnabled)
 	})
@@ -69,6 +85,259 @@ func TestNew(t *testing.T) {
 	})
 }
 
+func TestNew_AccessibleMode(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"0", false},
+		{"1", true},
+		{"true", true},
+		{"yes", true},
+	}
+
+	for _, tt := range tests {
+		t.Run("BMAD_ACCESSIBLE="+tt.value, func(t *testing.T) {
+			t.Setenv("BMAD_ACCESSIBLE", tt.value)
+			assert.Equal(t, tt.expected, New().AccessibleMode)
+		})
+	}
+}
+
+func TestNew_ReducedMotion(t *testing.T) {
+	t.Setenv("BMAD_REDUCED_MOTION", "")
+	t.Setenv("BMAD_ACCESSIBLE", "")
+	assert.False(t, New().ReducedMotion)
+
+	t.Setenv("BMAD_REDUCED_MOTION", "1")
+	assert.True(t, New().ReducedMotion)
+
+	t.Setenv("BMAD_REDUCED_MOTION", "")
+	t.Setenv("BMAD_ACCESSIBLE", "1")
+	assert.True(t, New().ReducedMotion, "accessible mode implies reduced motion")
+}
+
+func TestNew_HistoryRetention(t *testing.T) {
+	cfg := New()
+	assert.Zero(t, cfg.HistoryMaxAgeDays, "history is kept by default")
+	assert.Zero(t, cfg.HistoryMaxRows)
+	assert.Zero(t, cfg.HistoryMaxSizeMB)
+
+	t.Setenv("BMAD_HISTORY_MAX_AGE_DAYS", "90")
+	t.Setenv("BMAD_HISTORY_MAX_ROWS", "5000")
+	t.Setenv("BMAD_HISTORY_MAX_SIZE_MB", "-1")
+	cfg = New()
+	assert.Equal(t, 90, cfg.HistoryMaxAgeDays)
+	assert.Equal(t, 5000, cfg.HistoryMaxRows)
+	assert.Zero(t, cfg.HistoryMaxSizeMB, "negative values are ignored")
+}
+
+func TestNew_StallDetection(t *testing.T) {
+	cfg := New()
+	assert.Zero(t, cfg.StallTimeout, "stall detection is off by default")
+	assert.False(t, cfg.StallAutoRetry)
+
+	t.Setenv("BMAD_STALL_TIMEOUT", "300")
+	t.Setenv("BMAD_STALL_AUTO_RETRY", "1")
+	cfg = New()
+	assert.Equal(t, 300, cfg.StallTimeout)
+	assert.True(t, cfg.StallAutoRetry)
+}
+
+func TestNew_SlowStepAlerts(t *testing.T) {
+	assert.False(t, New().SlowStepAlerts)
+
+	t.Setenv("BMAD_NOTIFY_SLOW_STEPS", "1")
+	assert.True(t, New().SlowStepAlerts)
+}
+
+func TestNew_ConflictStrategy(t *testing.T) {
+	assert.Equal(t, ConflictResolve, New().ConflictStrategy)
+
+	t.Setenv("BMAD_CONFLICT_STRATEGY", ConflictPark)
+	assert.Equal(t, ConflictPark, New().ConflictStrategy)
+
+	t.Setenv("BMAD_CONFLICT_STRATEGY", "merge")
+	assert.Equal(t, ConflictResolve, New().ConflictStrategy, "unknown strategies fall back to the default")
+}
+
+func TestNew_QueueOrder(t *testing.T) {
+	assert.Equal(t, QueueOrderFIFO, New().QueueOrder)
+
+	for _, order := range []string{QueueOrderRoundRobin, QueueOrderDeadline} {
+		t.Setenv("BMAD_QUEUE_ORDER", order)
+		assert.Equal(t, order, New().QueueOrder)
+	}
+
+	t.Setenv("BMAD_QUEUE_ORDER", "random")
+	assert.Equal(t, QueueOrderFIFO, New().QueueOrder, "unknown orders fall back to fifo")
+}
+
+func TestNew_DBSizeLimit(t *testing.T) {
+	assert.Equal(t, DefaultDBSizeLimitMB, New().DBSizeLimitMB)
+
+	t.Setenv("BMAD_DB_SIZE_LIMIT_MB", "256")
+	assert.Equal(t, 256, New().DBSizeLimitMB)
+
+	t.Setenv("BMAD_DB_SIZE_LIMIT_MB", "0")
+	assert.Zero(t, New().DBSizeLimitMB, "0 turns the guard off")
+}
+
+func TestNew_CommitTrailers(t *testing.T) {
+	t.Run("defaults to the bmad trailer", func(t *testing.T) {
+		assert.Equal(t, []string{DefaultCommitTrailer}, New().CommitTrailers)
+	})
+
+	t.Run("reads semicolon-separated trailers", func(t *testing.T) {
+		t.Setenv("BMAD_COMMIT_TRAILERS", "Automated-by: bmad {execution_id}; Story: {story} ;")
+		assert.Equal(t, []string{"Automated-by: bmad {execution_id}", "Story: {story}"}, New().CommitTrailers)
+	})
+
+	t.Run("empty value disables trailers", func(t *testing.T) {
+		t.Setenv("BMAD_COMMIT_TRAILERS", "")
+		assert.Empty(t, New().CommitTrailers)
+	})
+}
+
+func TestNew_WebhookURLs(t *testing.T) {
+	t.Setenv("BMAD_WEBHOOK_URLS", "https://ci.example.com/hook, ,https://chat.example.com/in")
+	assert.Equal(t, []string{"https://ci.example.com/hook", "https://chat.example.com/in"}, New().WebhookURLs)
+}
+
+func TestNew_Sound(t *testing.T) {
+	t.Setenv("BMAD_SOUND", "1")
+	t.Setenv("BMAD_SOUND_MUTE", "Warning, success,")
+	cfg := New()
+	assert.True(t, cfg.SoundEnabled)
+	assert.Equal(t, map[string]bool{"warning": true, "success": true}, cfg.SoundMuted)
+	assert.Equal(t, filepath.Join(cfg.DataDir, "sounds"), cfg.SoundsDir())
+}
+
+func TestNew_Theme(t *testing.T) {
+	t.Setenv("BMAD_THEME", "nord")
+	t.Setenv("BMAD_BACKGROUND", BackgroundLight)
+	cfg := New()
+	assert.Equal(t, "nord", cfg.Theme)
+	assert.Equal(t, BackgroundLight, cfg.Background)
+}
+
+func TestNew_AutoRefresh(t *testing.T) {
+	t.Setenv("BMAD_AUTO_REFRESH", "History=30s; stats=5m;dashboard=soon;stories=0s;bad")
+	assert.Equal(t, map[string]time.Duration{
+		"history": 30 * time.Second,
+		"stats":   5 * time.Minute,
+	}, New().AutoRefresh)
+}
+
+func TestNew_UsagePrices(t *testing.T) {
+	cfg := New()
+	assert.Equal(t, DefaultInputPrice, cfg.UsageInputPrice)
+	assert.Equal(t, DefaultOutputPrice, cfg.UsageOutputPrice)
+
+	t.Setenv("BMAD_USAGE_PRICES", "15, 75")
+	cfg = New()
+	assert.Equal(t, 15.0, cfg.UsageInputPrice)
+	assert.Equal(t, 75.0, cfg.UsageOutputPrice)
+
+	t.Setenv("BMAD_USAGE_PRICES", "15,cheap")
+	assert.Equal(t, DefaultOutputPrice, New().UsageOutputPrice)
+}
+
+func TestNew_API(t *testing.T) {
+	cfg := New()
+	assert.False(t, cfg.APIEnabled)
+	assert.Equal(t, DefaultAPIPort, cfg.APIPort)
+	assert.Zero(t, cfg.GRPCPort)
+
+	t.Setenv("BMAD_API", "1")
+	t.Setenv("BMAD_API_PORT", "9090")
+	t.Setenv("BMAD_GRPC_PORT", "9091")
+	cfg = New()
+	assert.True(t, cfg.APIEnabled)
+	assert.Equal(t, 9090, cfg.APIPort)
+	assert.Equal(t, 9091, cfg.GRPCPort)
+
+	t.Setenv("BMAD_API_PORT", "-1")
+	assert.Equal(t, DefaultAPIPort, New().APIPort)
+}
+
+func TestNew_EnvFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, DefaultDataDir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultDataDir, EnvFileName),
+		[]byte("# restored\nBMAD_RUN_WINDOWS=\"22:00-06:00\"\nexport BMAD_USAGE_PRICES='9,9'\n"), 0600))
+	t.Chdir(dir)
+
+	t.Setenv("BMAD_RUN_WINDOWS", "")
+	require.NoError(t, os.Unsetenv("BMAD_RUN_WINDOWS"))
+	t.Setenv("BMAD_USAGE_PRICES", "1,2")
+
+	cfg := New()
+	assert.NoError(t, cfg.EnvFileErr)
+	assert.Equal(t, "22:00-06:00", cfg.RunWindows, "unset variables come from the file")
+	assert.Equal(t, 1.0, cfg.UsageInputPrice, "the environment wins")
+}
+
+func TestNew_MalformedEnvFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, DefaultDataDir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DefaultDataDir, EnvFileName),
+		[]byte("BMAD_RUN_WINDOWS=22:00-06:00\nnot a setting\n"), 0600))
+	t.Chdir(dir)
+
+	t.Setenv("BMAD_RUN_WINDOWS", "")
+	require.NoError(t, os.Unsetenv("BMAD_RUN_WINDOWS"))
+
+	cfg := New()
+	require.Error(t, cfg.EnvFileErr)
+	assert.Contains(t, cfg.EnvFileErr.Error(), "line 2")
+	assert.Empty(t, cfg.RunWindows, "nothing is loaded from a malformed file")
+}
+
+func TestParseEnvFile(t *testing.T) {
+	vars, err := ParseEnvFile([]byte("A=1\n\n# comment\nexport B = \"two words\"\nC='x=y'\nD=\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []EnvVar{{"A", "1"}, {"B", "two words"}, {"C", "x=y"}, {"D", ""}}, vars)
+
+	_, err = ParseEnvFile([]byte("A=1\nnot an assignment\n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	round := []EnvVar{{"Q", `say "hi"\n`}}
+	parsed, err := ParseEnvFile(FormatEnvFile(round))
+	require.NoError(t, err)
+	assert.Equal(t, round, parsed)
+}
+
+func TestConfig_InboxDir(t *testing.T) {
+	cfg := New()
+	cfg.DataDir = "/project/.bmad"
+	assert.Equal(t, filepath.Join("/project/.bmad", "inbox"), cfg.InboxDir())
+}
+
+func TestConfig_FailureReportPath(t *testing.T) {
+	cfg := New()
+	cfg.WorkingDir = "/project"
+	assert.Equal(t, filepath.Join("/project", DefaultFailureFile), cfg.FailureReportPath())
+
+	cfg.FailureFile = "/var/log/bmad-failures.md"
+	assert.Equal(t, "/var/log/bmad-failures.md", cfg.FailureReportPath())
+}
+
+func TestConfig_Digest(t *testing.T) {
+	a := New()
+	b := New()
+	assert.Len(t, a.Digest(), 12)
+	assert.Equal(t, a.Digest(), b.Digest())
+
+	b.Theme = "dracula"
+	b.APIKey = "secret"
+	assert.Equal(t, a.Digest(), b.Digest(), "UI settings and credentials do not count")
+
+	b.Retries = a.Retries + 1
+	assert.NotEqual(t, a.Digest(), b.Digest())
+}
+
 func TestConfig_StoryFilePath(t *testing.T) {
 	tests := []struct {
 		name     string
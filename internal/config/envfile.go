@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvFileName is the file in the data directory holding BMAD_* settings,
+// e.g. as restored by "bmad config import"
+const EnvFileName = "config.env"
+
+// secretWords mark variables holding credentials
+var secretWords = []string{"TOKEN", "KEY", "SECRET", "PASSWORD"}
+
+// IsSecretEnv reports whether a variable name looks like it holds a
+// token, key or password
+func IsSecretEnv(name string) bool {
+	for _, word := range secretWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvVar is one NAME=value line of an env file
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// ParseEnvFile parses NAME=value lines. Blank lines and lines starting with
+// # are skipped, an "export " prefix is allowed and values may be quoted.
+func ParseEnvFile(data []byte) ([]EnvVar, error) {
+	var vars []EnvVar
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("line %d: want NAME=value", n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+		vars = append(vars, EnvVar{Name: name, Value: value})
+	}
+	return vars, scanner.Err()
+}
+
+// FormatEnvFile writes vars as NAME="value" lines
+func FormatEnvFile(vars []EnvVar) []byte {
+	var buf bytes.Buffer
+	for _, v := range vars {
+		fmt.Fprintf(&buf, "%s=%q\n", v.Name, v.Value)
+	}
+	return buf.Bytes()
+}
+
+// loadEnvFile sets the variables of an env file that are not already set,
+// so the environment always wins. A missing file is not an error.
+func loadEnvFile(path string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	vars, err := ParseEnvFile(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, v := range vars {
+		if _, set := os.LookupEnv(v.Name); !set {
+			_ = os.Setenv(v.Name, v.Value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+// Package coview mirrors a presenter's TUI into read-only follower
+// instances over the API WebSocket, for pairing on a running story.
+package coview
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// MessageType is the WebSocket message type carrying co-viewing events
+const MessageType = "coview"
+
+// MaxSnapshotLines caps the output kept per step for followers that join late
+const MaxSnapshotLines = 500
+
+// Event kinds
+const (
+	KindSnapshot           = "snapshot"
+	KindView               = "view"
+	KindExecutionStarted   = "execution_started"
+	KindStepStarted        = "step_started"
+	KindStepOutput         = "step_output"
+	KindStepCompleted      = "step_completed"
+	KindStepWaiting        = "step_waiting"
+	KindExecutionCompleted = "execution_completed"
+)
+
+// Event is one change on the presenter's screen
+type Event struct {
+	Kind      string          `json:"kind"`
+	View      domain.View     `json:"view"`
+	Execution *ExecutionState `json:"execution,omitempty"`
+	StepIndex int             `json:"step_index"`
+	StepName  string          `json:"step_name,omitempty"`
+	Command   string          `json:"command,omitempty"`
+	Attempt   int             `json:"attempt,omitempty"`
+	Line      string          `json:"line,omitempty"`
+	IsStderr  bool            `json:"is_stderr,omitempty"`
+	Status    string          `json:"status,omitempty"`
+	Duration  time.Duration   `json:"duration,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Message   string          `json:"message,omitempty"`
+}
+
+// ExecutionState is the presenter's current execution as sent to followers
+type ExecutionState struct {
+	ID        string      `json:"id"`
+	StoryKey  string      `json:"story_key"`
+	StoryEpic int         `json:"story_epic"`
+	Title     string      `json:"title,omitempty"`
+	Status    string      `json:"status"`
+	StartTime time.Time   `json:"start_time"`
+	Current   int         `json:"current"`
+	Error     string      `json:"error,omitempty"`
+	Steps     []StepState `json:"steps"`
+}
+
+// StepState is one step of an ExecutionState
+type StepState struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Attempt  int           `json:"attempt,omitempty"`
+	Command  string        `json:"command,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Output   []string      `json:"output,omitempty"`
+}
+
+// Presenter turns the primary instance's messages into events for
+// followers and keeps enough state to bring a new follower up to date
+type Presenter struct {
+	mu        sync.Mutex
+	view      domain.View
+	execution *ExecutionState
+	publish   func(Event)
+}
+
+// NewPresenter creates a presenter that hands each event to publish
+func NewPresenter(publish func(Event)) *Presenter {
+	return &Presenter{publish: publish}
+}
+
+// Observe records a message handled by the presenter's app. view is the
+// active view after the message was handled.
+func (p *Presenter) Observe(msg tea.Msg, view domain.View) {
+	p.mu.Lock()
+	var events []Event
+	if view != p.view {
+		p.view = view
+		events = append(events, Event{Kind: KindView, View: view})
+	}
+	if ev, ok := p.apply(msg); ok {
+		ev.View = view
+		events = append(events, ev)
+	}
+	p.mu.Unlock()
+
+	for _, ev := range events {
+		p.publish(ev)
+	}
+}
+
+// apply updates the recorded execution and returns the matching event.
+// Callers must hold p.mu.
+func (p *Presenter) apply(msg tea.Msg) (Event, bool) {
+	switch msg := msg.(type) {
+	case messages.ExecutionStartedMsg:
+		if msg.Execution == nil {
+			return Event{}, false
+		}
+		p.execution = stateFromExecution(msg.Execution)
+		return Event{Kind: KindExecutionStarted, Execution: p.copyExecution()}, true
+
+	case messages.StepStartedMsg:
+		if p.other(msg.StepRef) {
+			return Event{}, false
+		}
+		if step := p.step(msg.StepIndex); step != nil {
+			step.Status = string(domain.StepRunning)
+			step.Attempt = msg.Attempt
+			step.Command = msg.Command
+			step.Output = nil
+			p.execution.Current = msg.StepIndex
+		}
+		return Event{Kind: KindStepStarted, StepIndex: msg.StepIndex, StepName: string(msg.StepName),
+			Command: msg.Command, Attempt: msg.Attempt}, true
+
+	case messages.StepOutputMsg:
+		if p.other(msg.StepRef) {
+			return Event{}, false
+		}
+		if step := p.step(msg.StepIndex); step != nil {
+			line := msg.Line
+			if msg.IsStderr {
+				line = "[stderr] " + line
+			}
+			step.Output = append(step.Output, line)
+			if len(step.Output) > MaxSnapshotLines {
+				step.Output = step.Output[len(step.Output)-MaxSnapshotLines:]
+			}
+		}
+		return Event{Kind: KindStepOutput, StepIndex: msg.StepIndex, Line: msg.Line, IsStderr: msg.IsStderr}, true
+
+	case messages.StepCompletedMsg:
+		if p.other(msg.StepRef) {
+			return Event{}, false
+		}
+		if step := p.step(msg.StepIndex); step != nil {
+			step.Status = string(msg.Status)
+			step.Duration = msg.Duration
+			step.Error = msg.Error
+		}
+		return Event{Kind: KindStepCompleted, StepIndex: msg.StepIndex, Status: string(msg.Status),
+			Duration: msg.Duration, Error: msg.Error}, true
+
+	case messages.StepWaitingMsg:
+		if p.other(msg.StepRef) {
+			return Event{}, false
+		}
+		return Event{Kind: KindStepWaiting, StepIndex: msg.StepIndex, StepName: string(msg.StepName),
+			Message: msg.Message}, true
+
+	case messages.ExecutionCompletedMsg:
+		if p.execution != nil {
+			p.execution.Status = string(msg.Status)
+			p.execution.Error = msg.Error
+		}
+		return Event{Kind: KindExecutionCompleted, Status: string(msg.Status), Duration: msg.Duration,
+			Error: msg.Error}, true
+	}
+
+	return Event{}, false
+}
+
+// other reports whether a step message belongs to an execution other than
+// the one on screen, such as a story running in parallel
+func (p *Presenter) other(ref messages.StepRef) bool {
+	return p.execution != nil && ref.ExecutionID != "" && ref.ExecutionID != p.execution.ID
+}
+
+// step returns the recorded step at index, or nil
+func (p *Presenter) step(index int) *StepState {
+	if p.execution == nil || index < 0 || index >= len(p.execution.Steps) {
+		return nil
+	}
+	return &p.execution.Steps[index]
+}
+
+// copyExecution returns a deep copy of the recorded execution, or nil.
+// Callers must hold p.mu.
+func (p *Presenter) copyExecution() *ExecutionState {
+	if p.execution == nil {
+		return nil
+	}
+	exec := *p.execution
+	exec.Steps = make([]StepState, len(p.execution.Steps))
+	for i, step := range p.execution.Steps {
+		step.Output = append([]string(nil), step.Output...)
+		exec.Steps[i] = step
+	}
+	return &exec
+}
+
+// Snapshot returns the event that brings a new follower up to date
+func (p *Presenter) Snapshot() Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return Event{Kind: KindSnapshot, View: p.view, Execution: p.copyExecution()}
+}
+
+// stateFromExecution captures a domain execution for followers
+func stateFromExecution(e *domain.Execution) *ExecutionState {
+	state := &ExecutionState{
+		ID:        e.ID,
+		StoryKey:  e.Story.Key,
+		StoryEpic: e.Story.Epic,
+		Title:     e.Story.Title,
+		Status:    string(e.Status),
+		StartTime: e.StartTime,
+		Current:   e.Current,
+		Error:     e.Error,
+		Steps:     make([]StepState, len(e.Steps)),
+	}
+	for i, step := range e.Steps {
+		state.Steps[i] = StepState{
+			Name:     string(step.Name),
+			Status:   string(step.Status),
+			Attempt:  step.Attempt,
+			Command:  step.Command,
+			Duration: step.Duration,
+			Error:    step.Error,
+		}
+	}
+	return state
+}
+
+// ToExecution rebuilds a domain execution on the follower side
+func (s *ExecutionState) ToExecution() *domain.Execution {
+	exec := &domain.Execution{
+		ID: s.ID,
+		Story: domain.Story{
+			Key:   s.StoryKey,
+			Epic:  s.StoryEpic,
+			Title: s.Title,
+		},
+		Status:    domain.ExecutionStatus(s.Status),
+		StartTime: s.StartTime,
+		Current:   s.Current,
+		Error:     s.Error,
+		Steps:     make([]*domain.StepExecution, len(s.Steps)),
+	}
+	for i, step := range s.Steps {
+		exec.Steps[i] = &domain.StepExecution{
+			Name:     domain.StepName(step.Name),
+			Status:   domain.StepStatus(step.Status),
+			Attempt:  step.Attempt,
+			Command:  step.Command,
+			Duration: step.Duration,
+			Error:    step.Error,
+			Output:   append([]string{}, step.Output...),
+		}
+	}
+	return exec
+}
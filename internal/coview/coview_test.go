@@ -0,0 +1,224 @@
+package coview
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/api"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+func newTestExecution() *domain.Execution {
+	exec := domain.NewExecution(domain.Story{Key: "3-1-login", Epic: 3, Title: "Login"})
+	exec.Status = domain.ExecutionRunning
+	exec.StartTime = time.Now()
+	return exec
+}
+
+func TestPresenter_PublishesEvents(t *testing.T) {
+	var events []Event
+	p := NewPresenter(func(ev Event) { events = append(events, ev) })
+	exec := newTestExecution()
+
+	p.Observe(messages.ExecutionStartedMsg{Execution: exec}, domain.ViewExecution)
+	p.Observe(tea.KeyMsg{}, domain.ViewExecution)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, KindView, events[0].Kind)
+	assert.Equal(t, domain.ViewExecution, events[0].View)
+	assert.Equal(t, KindExecutionStarted, events[1].Kind)
+	require.NotNil(t, events[1].Execution)
+	assert.Equal(t, exec.ID, events[1].Execution.ID)
+	assert.Equal(t, "3-1-login", events[1].Execution.StoryKey)
+}
+
+func TestPresenter_SnapshotCarriesOutput(t *testing.T) {
+	p := NewPresenter(func(Event) {})
+	exec := newTestExecution()
+
+	p.Observe(messages.ExecutionStartedMsg{Execution: exec}, domain.ViewExecution)
+	p.Observe(messages.StepStartedMsg{StepIndex: 1, StepName: domain.StepDevStory, Command: "claude", Attempt: 1}, domain.ViewExecution)
+	p.Observe(messages.StepOutputMsg{StepIndex: 1, Line: "building"}, domain.ViewExecution)
+	p.Observe(messages.StepOutputMsg{StepIndex: 1, Line: "oops", IsStderr: true}, domain.ViewExecution)
+
+	snap := p.Snapshot()
+	assert.Equal(t, KindSnapshot, snap.Kind)
+	assert.Equal(t, domain.ViewExecution, snap.View)
+	require.NotNil(t, snap.Execution)
+	assert.Equal(t, 1, snap.Execution.Current)
+
+	step := snap.Execution.Steps[1]
+	assert.Equal(t, string(domain.StepRunning), step.Status)
+	assert.Equal(t, "claude", step.Command)
+	assert.Equal(t, []string{"building", "[stderr] oops"}, step.Output)
+
+	// The snapshot is a copy
+	snap.Execution.Steps[1].Output[0] = "changed"
+	assert.Equal(t, "building", p.Snapshot().Execution.Steps[1].Output[0])
+}
+
+func TestPresenter_IgnoresOtherExecutions(t *testing.T) {
+	var events []Event
+	p := NewPresenter(func(ev Event) { events = append(events, ev) })
+	exec := newTestExecution()
+	p.Observe(messages.ExecutionStartedMsg{Execution: exec}, domain.ViewExecution)
+
+	other := messages.StepRef{ExecutionID: "other", StoryKey: "3-2-signup"}
+	p.Observe(messages.StepOutputMsg{StepRef: other, StepIndex: 0, Line: "not mine"}, domain.ViewExecution)
+	p.Observe(messages.StepOutputMsg{StepRef: messages.StepRef{ExecutionID: exec.ID}, StepIndex: 0, Line: "mine"}, domain.ViewExecution)
+
+	assert.Equal(t, []string{"mine"}, p.Snapshot().Execution.Steps[0].Output)
+	require.Len(t, events, 3)
+	assert.Equal(t, "mine", events[2].Line)
+}
+
+func TestPresenter_CapsSnapshotOutput(t *testing.T) {
+	p := NewPresenter(func(Event) {})
+	p.Observe(messages.ExecutionStartedMsg{Execution: newTestExecution()}, domain.ViewExecution)
+
+	for i := 0; i < MaxSnapshotLines+10; i++ {
+		p.Observe(messages.StepOutputMsg{StepIndex: 0, Line: "line"}, domain.ViewExecution)
+	}
+
+	assert.Len(t, p.Snapshot().Execution.Steps[0].Output, MaxSnapshotLines)
+}
+
+func TestEvent_ToMsgRoundTrip(t *testing.T) {
+	exec := newTestExecution()
+	exec.Steps[0].Output = []string{"done"}
+	exec.Steps[0].Status = domain.StepSuccess
+
+	tests := []struct {
+		name  string
+		event Event
+		want  tea.Msg
+	}{
+		{"view", Event{Kind: KindView, View: domain.ViewQueue}, ViewMsg{View: domain.ViewQueue}},
+		{"step output", Event{Kind: KindStepOutput, StepIndex: 2, Line: "hi", IsStderr: true},
+			messages.StepOutputMsg{StepIndex: 2, Line: "hi", IsStderr: true}},
+		{"step completed", Event{Kind: KindStepCompleted, StepIndex: 1, Status: "failed", Duration: time.Second, Error: "boom"},
+			messages.StepCompletedMsg{StepIndex: 1, Status: domain.StepFailed, Duration: time.Second, Error: "boom"}},
+		{"execution completed", Event{Kind: KindExecutionCompleted, Status: "completed", Duration: time.Minute},
+			messages.ExecutionCompletedMsg{Status: domain.ExecutionCompleted, Duration: time.Minute}},
+		{"unknown", Event{Kind: "nope"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.event)
+			require.NoError(t, err)
+			var ev Event
+			require.NoError(t, json.Unmarshal(data, &ev))
+			assert.Equal(t, tt.want, ev.ToMsg())
+		})
+	}
+
+	t.Run("snapshot", func(t *testing.T) {
+		msg, ok := Event{Kind: KindSnapshot, View: domain.ViewExecution, Execution: stateFromExecution(exec)}.ToMsg().(SnapshotMsg)
+		require.True(t, ok)
+		assert.Equal(t, domain.ViewExecution, msg.View)
+		require.NotNil(t, msg.Execution)
+		assert.Equal(t, exec.ID, msg.Execution.ID)
+		assert.Equal(t, exec.Story.Key, msg.Execution.Story.Key)
+		assert.Equal(t, domain.StepSuccess, msg.Execution.Steps[0].Status)
+	})
+}
+
+func TestWebSocketURL(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"http://localhost:8080", "ws://localhost:8080/api/ws", false},
+		{"http://localhost:8080/", "ws://localhost:8080/api/ws", false},
+		{"https://pair.example.com", "wss://pair.example.com/api/ws", false},
+		{"ws://10.0.0.2:8080/api/ws", "ws://10.0.0.2:8080/api/ws", false},
+		{"localhost:8080", "", true},
+		{"ftp://host", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := WebSocketURL(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFollower_MirrorsPresenter(t *testing.T) {
+	hub := api.NewWebSocketHub()
+	go hub.Run()
+	defer hub.Stop()
+
+	presenter := NewPresenter(func(ev Event) {
+		hub.Broadcast(api.WebSocketMessage{Type: MessageType, Data: ev, Timestamp: time.Now()})
+	})
+	hub.SetConnectHook(func() []api.WebSocketMessage {
+		return []api.WebSocketMessage{{Type: MessageType, Data: presenter.Snapshot(), Timestamp: time.Now()}}
+	})
+
+	exec := newTestExecution()
+	presenter.Observe(messages.ExecutionStartedMsg{Execution: exec}, domain.ViewExecution)
+	presenter.Observe(messages.StepOutputMsg{StepIndex: 0, Line: "before attach"}, domain.ViewExecution)
+
+	srv := httptest.NewServer(http.HandlerFunc(hub.ServeWs))
+	defer srv.Close()
+
+	follower, err := NewFollower(srv.URL+"/ws", "")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var received []tea.Msg
+	follower.sendFn = func(msg tea.Msg) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, msg)
+	}
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go follower.Run(ctx)
+
+	// Connected status, then the snapshot
+	require.Eventually(t, func() bool { return count() >= 2 }, 5*time.Second, 10*time.Millisecond)
+	for hub.ClientCount() == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	presenter.Observe(messages.StepOutputMsg{StepIndex: 0, Line: "after attach"}, domain.ViewExecution)
+	require.Eventually(t, func() bool { return count() >= 3 }, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, StatusMsg{Connected: true}, received[0])
+
+	snap, ok := received[1].(SnapshotMsg)
+	require.True(t, ok, "expected snapshot, got %T", received[1])
+	assert.Equal(t, domain.ViewExecution, snap.View)
+	assert.Equal(t, []string{"before attach"}, snap.Execution.Steps[0].Output)
+
+	out, ok := received[2].(messages.StepOutputMsg)
+	require.True(t, ok, "expected output, got %T", received[2])
+	assert.Equal(t, "after attach", out.Line)
+}
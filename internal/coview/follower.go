@@ -0,0 +1,184 @@
+package coview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// ReconnectDelay is how long a follower waits before reconnecting
+const ReconnectDelay = 2 * time.Second
+
+// StatusMsg reports the follower's connection to the presenter
+type StatusMsg struct {
+	Connected bool
+	Error     error // Why the connection was lost, if it was
+}
+
+// SnapshotMsg brings a follower up to date with the presenter
+type SnapshotMsg struct {
+	View      domain.View
+	Execution *domain.Execution // nil if the presenter has not run anything yet
+}
+
+// ViewMsg is sent when the presenter switches views
+type ViewMsg struct {
+	View domain.View
+}
+
+// ToMsg converts an event into the message a follower's app handles
+func (e Event) ToMsg() tea.Msg {
+	switch e.Kind {
+	case KindSnapshot:
+		msg := SnapshotMsg{View: e.View}
+		if e.Execution != nil {
+			msg.Execution = e.Execution.ToExecution()
+		}
+		return msg
+	case KindView:
+		return ViewMsg{View: e.View}
+	case KindExecutionStarted:
+		if e.Execution == nil {
+			return nil
+		}
+		return messages.ExecutionStartedMsg{Execution: e.Execution.ToExecution()}
+	case KindStepStarted:
+		return messages.StepStartedMsg{StepIndex: e.StepIndex, StepName: domain.StepName(e.StepName),
+			Command: e.Command, Attempt: e.Attempt}
+	case KindStepOutput:
+		return messages.StepOutputMsg{StepIndex: e.StepIndex, Line: e.Line, IsStderr: e.IsStderr}
+	case KindStepCompleted:
+		return messages.StepCompletedMsg{StepIndex: e.StepIndex, Status: domain.StepStatus(e.Status),
+			Duration: e.Duration, Error: e.Error}
+	case KindStepWaiting:
+		return messages.StepWaitingMsg{StepIndex: e.StepIndex, StepName: domain.StepName(e.StepName),
+			Message: e.Message}
+	case KindExecutionCompleted:
+		return messages.ExecutionCompletedMsg{Status: domain.ExecutionStatus(e.Status),
+			Duration: e.Duration, Error: e.Error}
+	}
+	return nil
+}
+
+// Follower mirrors a presenter into the local TUI
+type Follower struct {
+	url    string
+	apiKey string
+	sendFn func(tea.Msg)
+}
+
+// NewFollower creates a follower for the presenter's API server. base may be
+// an http(s) or ws(s) URL; the WebSocket path is added when missing.
+func NewFollower(base, apiKey string) (*Follower, error) {
+	wsURL, err := WebSocketURL(base)
+	if err != nil {
+		return nil, err
+	}
+	return &Follower{url: wsURL, apiKey: apiKey}, nil
+}
+
+// WebSocketURL converts a presenter address into its WebSocket endpoint
+func WebSocketURL(base string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid presenter address %q", base)
+	}
+
+	switch u.Scheme {
+	case "http", "ws":
+		u.Scheme = "ws"
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("invalid presenter address %q: use http(s) or ws(s)", base)
+	}
+
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/api/ws"
+	}
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+// URL returns the presenter's WebSocket endpoint
+func (f *Follower) URL() string {
+	return f.url
+}
+
+// SetProgram sets the tea.Program that receives mirrored messages
+func (f *Follower) SetProgram(p *tea.Program) {
+	f.sendFn = p.Send
+}
+
+// send forwards a message to the program
+func (f *Follower) send(msg tea.Msg) {
+	if f.sendFn != nil && msg != nil {
+		f.sendFn(msg)
+	}
+}
+
+// Run follows the presenter until ctx is done, reconnecting after errors
+func (f *Follower) Run(ctx context.Context) {
+	for {
+		err := f.follow(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		f.send(StatusMsg{Connected: false, Error: err})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(ReconnectDelay):
+		}
+	}
+}
+
+// follow holds one connection to the presenter, forwarding events
+func (f *Follower) follow(ctx context.Context) error {
+	header := http.Header{}
+	if f.apiKey != "" {
+		header.Set("X-API-Key", f.apiKey)
+	}
+
+	conn, _, err := websocket.Dial(ctx, f.url, &websocket.DialOptions{HTTPHeader: header})
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "follower closing")
+	conn.SetReadLimit(16 * 1024 * 1024) // Snapshots carry recent output
+
+	f.send(StatusMsg{Connected: true})
+
+	for {
+		var msg struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := wsjson.Read(ctx, conn, &msg); err != nil {
+			if websocket.CloseStatus(err) == websocket.StatusNormalClosure {
+				return fmt.Errorf("presenter closed the connection")
+			}
+			return err
+		}
+		if msg.Type != MessageType {
+			continue
+		}
+
+		var ev Event
+		if err := json.Unmarshal(msg.Data, &ev); err != nil {
+			continue
+		}
+		f.send(ev.ToMsg())
+	}
+}
@@ -0,0 +1,242 @@
+// Package deploy generates the files for running bmad as a service in a
+// container: a Dockerfile, a compose file and a systemd unit starting it.
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+)
+
+// Module is the Go module bmad is installed from
+const Module = "github.com/robertguss/bmad-automate-go"
+
+// Names of the generated files
+const (
+	DockerfileName = "Dockerfile"
+	ComposeName    = "compose.yaml"
+	UnitName       = "bmad.service"
+)
+
+// releaseVersion matches the tags go install can resolve
+var releaseVersion = regexp.MustCompile(`^v\d+\.\d+\.\d+$`)
+
+// File is a generated file
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Options parameterize the generated files
+type Options struct {
+	Name       string // Service, container and image name
+	Version    string // bmad version to install; anything but a release installs the latest
+	ProjectDir string // Checkout mounted at /workspace
+	DataDir    string // Mounted over /workspace/.bmad
+	OutputDir  string // Where the files are written; compose paths are relative to it
+	APIPort    int
+	GRPCPort   int             // 0 leaves the gRPC control API off
+	Env        []config.EnvVar // Variables set in the container
+}
+
+// OptionsFrom builds options from the current configuration and the
+// BMAD_* variables of environ
+func OptionsFrom(cfg *config.Config, environ []string, outputDir string) Options {
+	opts := Options{
+		Name:       ServiceName(cfg.WorkingDir),
+		Version:    cfg.Version,
+		ProjectDir: cfg.WorkingDir,
+		DataDir:    cfg.DataDir,
+		OutputDir:  outputDir,
+		APIPort:    cfg.APIPort,
+		GRPCPort:   cfg.GRPCPort,
+	}
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		// The API settings are part of the generated files
+		if !ok || !strings.HasPrefix(name, "BMAD_") || name == "BMAD_API" || name == "BMAD_API_PORT" || name == "BMAD_GRPC_PORT" {
+			continue
+		}
+		opts.Env = append(opts.Env, config.EnvVar{Name: name, Value: value})
+	}
+	sort.Slice(opts.Env, func(i, j int) bool { return opts.Env[i].Name < opts.Env[j].Name })
+	return opts
+}
+
+// ServiceName derives a container-friendly name from a project directory
+func ServiceName(projectDir string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(filepath.Base(projectDir)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	name := strings.Trim(b.String(), "-_")
+	if name == "" {
+		return "bmad"
+	}
+	return "bmad-" + name
+}
+
+// Docker generates the Dockerfile, compose file and systemd unit
+func Docker(opts Options) ([]File, error) {
+	if opts.APIPort <= 0 {
+		return nil, fmt.Errorf("invalid API port %d", opts.APIPort)
+	}
+	outputDir, err := filepath.Abs(opts.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+
+	version := "latest"
+	if releaseVersion.MatchString(opts.Version) {
+		version = opts.Version
+	}
+	data := templateData{
+		Options:     opts,
+		Module:      Module,
+		Install:     version,
+		OutputDir:   outputDir,
+		ProjectPath: relativeTo(outputDir, opts.ProjectDir),
+		DataPath:    relativeTo(outputDir, opts.DataDir),
+	}
+
+	var files []File
+	for _, f := range []struct {
+		name string
+		tmpl *template.Template
+	}{
+		{DockerfileName, dockerfileTemplate},
+		{ComposeName, composeTemplate},
+		{UnitName, unitTemplate},
+	} {
+		var buf bytes.Buffer
+		if err := f.tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", f.name, err)
+		}
+		files = append(files, File{Name: f.name, Data: buf.Bytes()})
+	}
+	return files, nil
+}
+
+// templateData is what the templates see
+type templateData struct {
+	Options
+	Module      string
+	Install     string // Version passed to go install
+	OutputDir   string // Absolute
+	ProjectPath string // Relative to OutputDir where possible
+	DataPath    string
+}
+
+// relativeTo returns path relative to dir, starting with ./ or ../ as
+// compose expects for bind mounts, or path itself when it has no relative
+// form
+func relativeTo(dir, path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(dir, abs)
+	if err != nil {
+		return abs
+	}
+	rel = filepath.ToSlash(rel)
+	switch {
+	case rel == "." || rel == ".." || strings.HasPrefix(rel, "../"):
+		return rel
+	default:
+		return "./" + rel
+	}
+}
+
+var funcs = template.FuncMap{
+	// env renders a compose environment value. Secrets are read from the
+	// host environment or a .env file next to the compose file rather
+	// than written out; "$" is escaped from compose interpolation.
+	"env": func(v config.EnvVar) string {
+		if config.IsSecretEnv(v.Name) {
+			return fmt.Sprintf("${%s:-}", v.Name)
+		}
+		return strconv.Quote(strings.ReplaceAll(v.Value, "$", "$$"))
+	},
+}
+
+var dockerfileTemplate = template.Must(template.New(DockerfileName).Funcs(funcs).Parse(`# Generated by "bmad integrations docker". Runs bmad with its API server
+# against the project mounted at /workspace.
+FROM golang:1.24-alpine AS builder
+RUN CGO_ENABLED=0 go install {{.Module}}/cmd/bmad@{{.Install}}
+
+FROM node:22-alpine
+RUN apk add --no-cache ca-certificates git openssh-client \
+    && npm install -g @anthropic-ai/claude-code \
+    && git config --system --add safe.directory /workspace
+COPY --from=builder /go/bin/bmad /usr/local/bin/bmad
+
+# The data directory is created here so a fresh volume belongs to node
+RUN mkdir -p /workspace/.bmad && chown -R node:node /workspace
+USER node
+WORKDIR /workspace
+
+ENV BMAD_API=1 BMAD_API_PORT={{.APIPort}}{{if .GRPCPort}} BMAD_GRPC_PORT={{.GRPCPort}}{{end}}
+EXPOSE {{.APIPort}}{{if .GRPCPort}} {{.GRPCPort}}{{end}}
+ENTRYPOINT ["bmad"]
+`))
+
+var composeTemplate = template.Must(template.New(ComposeName).Funcs(funcs).Parse(`# Generated by "bmad integrations docker". Start with: docker compose up -d
+# Attach to the TUI with "docker attach {{.Name}}" (detach with Ctrl+P Ctrl+Q).
+services:
+  bmad:
+    build: .
+    image: {{.Name}}
+    container_name: {{.Name}}
+    restart: unless-stopped
+    # bmad is a TUI; the terminal keeps it running without anyone attached
+    tty: true
+    stdin_open: true
+    ports:
+      # Remove 127.0.0.1 to reach the API from other hosts, after setting BMAD_API_KEY
+      - "127.0.0.1:{{.APIPort}}:{{.APIPort}}"
+{{- if .GRPCPort}}
+      - "127.0.0.1:{{.GRPCPort}}:{{.GRPCPort}}"
+{{- end}}
+    volumes:
+      - {{.ProjectPath}}:/workspace
+      - {{.DataPath}}:/workspace/.bmad
+    environment:
+      ANTHROPIC_API_KEY: ${ANTHROPIC_API_KEY:-}
+      BMAD_API_KEY: ${BMAD_API_KEY:-}
+{{- range .Env}}{{if ne .Name "BMAD_API_KEY"}}
+      {{.Name}}: {{env .}}
+{{- end}}{{end}}
+`))
+
+var unitTemplate = template.Must(template.New(UnitName).Funcs(funcs).Parse(`# Generated by "bmad integrations docker". Install with:
+#   sudo cp {{.OutputDir}}/bmad.service /etc/systemd/system/{{.Name}}.service
+#   sudo systemctl enable --now {{.Name}}
+[Unit]
+Description=BMAD Automate for {{.Name}}
+Requires=docker.service
+After=docker.service network-online.target
+Wants=network-online.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+WorkingDirectory={{.OutputDir}}
+ExecStart=/usr/bin/docker compose up -d --build
+ExecStop=/usr/bin/docker compose down
+
+[Install]
+WantedBy=multi-user.target
+`))
@@ -0,0 +1,109 @@
+package deploy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+)
+
+func generate(t *testing.T, opts Options) map[string]string {
+	files, err := Docker(opts)
+	require.NoError(t, err)
+	out := make(map[string]string)
+	for _, f := range files {
+		out[f.Name] = string(f.Data)
+	}
+	return out
+}
+
+func TestOptionsFrom(t *testing.T) {
+	cfg := &config.Config{
+		WorkingDir: "/home/dev/My Shop",
+		DataDir:    "/home/dev/My Shop/.bmad",
+		Version:    "v1.4.0",
+		APIPort:    9090,
+		GRPCPort:   9091,
+	}
+	environ := []string{
+		"HOME=/home/dev",
+		"BMAD_TIMEOUT=900",
+		"BMAD_API=1",
+		"BMAD_API_PORT=9090",
+		"BMAD_GRPC_PORT=9091",
+		"BMAD_GITHUB_TOKEN=ghp_secret",
+	}
+
+	opts := OptionsFrom(cfg, environ, "deploy")
+	assert.Equal(t, "bmad-my-shop", opts.Name)
+	assert.Equal(t, 9090, opts.APIPort)
+	assert.Equal(t, 9091, opts.GRPCPort)
+	assert.Equal(t, []config.EnvVar{
+		{Name: "BMAD_GITHUB_TOKEN", Value: "ghp_secret"},
+		{Name: "BMAD_TIMEOUT", Value: "900"},
+	}, opts.Env)
+}
+
+func TestServiceName(t *testing.T) {
+	assert.Equal(t, "bmad-api_v2", ServiceName("/src/API_v2"))
+	assert.Equal(t, "bmad", ServiceName("/"))
+}
+
+func TestDocker(t *testing.T) {
+	project := t.TempDir()
+	opts := Options{
+		Name:       "bmad-shop",
+		Version:    "v1.4.0",
+		ProjectDir: project,
+		DataDir:    filepath.Join(project, ".bmad"),
+		OutputDir:  filepath.Join(project, "deploy"),
+		APIPort:    9090,
+		Env: []config.EnvVar{
+			{Name: "BMAD_GITHUB_TOKEN", Value: "ghp_secret"},
+			{Name: "BMAD_JIRA_JQL", Value: `project = "SHOP" AND cost > $5`},
+		},
+	}
+	files := generate(t, opts)
+	require.Len(t, files, 3)
+
+	dockerfile := files[DockerfileName]
+	assert.Contains(t, dockerfile, "go install "+Module+"/cmd/bmad@v1.4.0")
+	assert.Contains(t, dockerfile, "BMAD_API=1 BMAD_API_PORT=9090")
+
+	compose := files[ComposeName]
+	assert.Contains(t, compose, "container_name: bmad-shop")
+	assert.Contains(t, compose, `"127.0.0.1:9090:9090"`)
+	assert.Contains(t, compose, "- ..:/workspace\n")
+	assert.Contains(t, compose, "- ../.bmad:/workspace/.bmad\n")
+	assert.Contains(t, compose, "BMAD_GITHUB_TOKEN: ${BMAD_GITHUB_TOKEN:-}")
+	assert.NotContains(t, compose, "ghp_secret")
+	assert.Contains(t, compose, `BMAD_JIRA_JQL: "project = \"SHOP\" AND cost > $$5"`)
+
+	assert.NotContains(t, compose, "9091")
+
+	unit := files[UnitName]
+	assert.Contains(t, unit, "WorkingDirectory="+opts.OutputDir+"\n")
+	assert.Contains(t, unit, "systemctl enable --now bmad-shop")
+}
+
+func TestDocker_GRPC(t *testing.T) {
+	files := generate(t, Options{Name: "bmad", ProjectDir: ".", DataDir: ".bmad", OutputDir: ".", APIPort: 8080, GRPCPort: 9091})
+	assert.Contains(t, files[DockerfileName], "BMAD_API_PORT=8080 BMAD_GRPC_PORT=9091")
+	assert.Contains(t, files[DockerfileName], "EXPOSE 8080 9091")
+	assert.Contains(t, files[ComposeName], `"127.0.0.1:8080:8080"`+"\n      - \"127.0.0.1:9091:9091\"\n")
+}
+
+func TestDocker_Version(t *testing.T) {
+	for _, version := range []string{"dev", "v1.4.0-3-gabc123-dirty", ""} {
+		files := generate(t, Options{Name: "bmad", Version: version, ProjectDir: ".", DataDir: ".bmad", OutputDir: ".", APIPort: 8080})
+		assert.Contains(t, files[DockerfileName], "/cmd/bmad@latest", version)
+	}
+}
+
+func TestDocker_InvalidPort(t *testing.T) {
+	_, err := Docker(Options{OutputDir: "."})
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateRange bounds a period of executions by start time: from Since up to,
+// not including, Until. A zero bound leaves that side open, so the zero
+// DateRange is all time.
+type DateRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// LastDays returns the range of the last n days up to now
+func LastDays(n int, now time.Time) DateRange {
+	return DateRange{Since: now.AddDate(0, 0, -n)}
+}
+
+// IsAllTime reports whether the range is unbounded on both sides
+func (r DateRange) IsAllTime() bool {
+	return r.Since.IsZero() && r.Until.IsZero()
+}
+
+// String describes the range with its dates, e.g. "Mar 1 - Mar 31 2026"
+func (r DateRange) String() string {
+	const layout = "Jan 2 2006"
+	switch {
+	case r.IsAllTime():
+		return "all time"
+	case r.Until.IsZero():
+		return "since " + r.Since.Format(layout)
+	case r.Since.IsZero():
+		return "until " + r.Until.Add(-time.Nanosecond).Format(layout)
+	}
+	return r.Since.Format(layout) + " - " + r.Until.Add(-time.Nanosecond).Format(layout)
+}
+
+// ParseDateRange reads a date range relative to now: a number of days such
+// as "14d", a date to start from such as "2026-03-01", or two dates
+// "2026-03-01..2026-03-31" where either may be left out. The end date is
+// included. "all" or an empty spec is all time.
+func ParseDateRange(spec string, now time.Time) (DateRange, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" || strings.EqualFold(spec, "all") {
+		return DateRange{}, nil
+	}
+
+	if days, ok := strings.CutSuffix(spec, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return LastDays(n, now), nil
+		}
+	}
+
+	from, to, isRange := strings.Cut(spec, "..")
+	var r DateRange
+	var err error
+	if from = strings.TrimSpace(from); from != "" {
+		if r.Since, err = time.ParseInLocation("2006-01-02", from, now.Location()); err != nil {
+			return DateRange{}, invalidDateRange(spec)
+		}
+	}
+	if to = strings.TrimSpace(to); to != "" {
+		if r.Until, err = time.ParseInLocation("2006-01-02", to, now.Location()); err != nil {
+			return DateRange{}, invalidDateRange(spec)
+		}
+		r.Until = r.Until.AddDate(0, 0, 1)
+	}
+	if isRange && r.IsAllTime() {
+		return DateRange{}, invalidDateRange(spec)
+	}
+	if !r.Since.IsZero() && !r.Until.IsZero() && !r.Since.Before(r.Until) {
+		return DateRange{}, fmt.Errorf("invalid date range %q: the end is before the start", spec)
+	}
+	return r, nil
+}
+
+func invalidDateRange(spec string) error {
+	return fmt.Errorf("invalid date range %q: want a number of days such as 14d, YYYY-MM-DD, YYYY-MM-DD..YYYY-MM-DD or all", spec)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2026, 3, 15, 10, 30, 0, 0, time.UTC)
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		spec string
+		want DateRange
+	}{
+		{"", DateRange{}},
+		{"all", DateRange{}},
+		{"14d", DateRange{Since: now.AddDate(0, 0, -14)}},
+		{"2026-03-01", DateRange{Since: day(3, 1)}},
+		{"2026-03-01..2026-03-10", DateRange{Since: day(3, 1), Until: day(3, 11)}},
+		{" ..2026-02-28 ", DateRange{Until: day(3, 1)}},
+		{"2026-03-01..", DateRange{Since: day(3, 1)}},
+	}
+	for _, tt := range tests {
+		r, err := ParseDateRange(tt.spec, now)
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.want, r, tt.spec)
+	}
+
+	for _, spec := range []string{"0d", "yesterday", "..", "2026-03-10..2026-03-01", "2026-13-01"} {
+		_, err := ParseDateRange(spec, now)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestDateRange(t *testing.T) {
+	r := DateRange{
+		Since: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, "Mar 1 2026 - Mar 31 2026", r.String())
+	assert.Equal(t, "since Mar 1 2026", DateRange{Since: r.Since}.String())
+	assert.Equal(t, "until Mar 31 2026", DateRange{Until: r.Until}.String())
+	assert.Equal(t, "all time", DateRange{}.String())
+	assert.True(t, DateRange{}.IsAllTime())
+	assert.False(t, r.IsAllTime())
+}
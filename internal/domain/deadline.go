@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DeadlineRisk grades how likely a queue item is to finish by its deadline
+type DeadlineRisk string
+
+const (
+	DeadlineNone     DeadlineRisk = ""         // No deadline set
+	DeadlineOnTrack  DeadlineRisk = "on-track" // Expected to finish with room to spare
+	DeadlineAtRisk   DeadlineRisk = "at-risk"  // Finishes in time only if it runs no slower than usual
+	DeadlineLate     DeadlineRisk = "late"     // Expected to finish after the deadline
+	DeadlineBreached DeadlineRisk = "breached" // The deadline has passed
+)
+
+// DeadlineOf returns the deadline an item must finish by: its own, or the
+// queue's when it has none. Zero means no deadline.
+func (q *Queue) DeadlineOf(item *QueueItem) time.Time {
+	if !item.Deadline.IsZero() {
+		return item.Deadline
+	}
+	return q.Deadline
+}
+
+// HasDeadlines reports whether the queue or any item has a deadline
+func (q *Queue) HasDeadlines() bool {
+	if !q.Deadline.IsZero() {
+		return true
+	}
+	for _, item := range q.Items {
+		if !item.Deadline.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// Risk grades the estimate against its deadline at now. Items whose
+// expected finish plus one standard deviation is past the deadline are at
+// risk.
+func (e ItemEstimate) Risk(now time.Time) DeadlineRisk {
+	switch {
+	case e.Deadline.IsZero():
+		return DeadlineNone
+	case !now.Before(e.Deadline):
+		return DeadlineBreached
+	case now.Add(e.Finish).After(e.Deadline):
+		return DeadlineLate
+	case now.Add(e.Finish + e.Spread).After(e.Deadline):
+		return DeadlineAtRisk
+	default:
+		return DeadlineOnTrack
+	}
+}
+
+// Breaches returns the items whose deadline has passed at now without them
+// completing in time. Cancelled items are left out.
+func (q *Queue) Breaches(now time.Time) []*QueueItem {
+	var breached []*QueueItem
+	for _, item := range q.Items {
+		deadline := q.DeadlineOf(item)
+		if deadline.IsZero() || deadline.After(now) || item.Status == ExecutionCancelled {
+			continue
+		}
+		if item.Status == ExecutionCompleted && item.Execution != nil && !item.Execution.EndTime.After(deadline) {
+			continue
+		}
+		breached = append(breached, item)
+	}
+	return breached
+}
+
+// OrderByDeadline reorders the pending items earliest deadline first,
+// which keeps the worst lateness as small as possible. Items without a
+// deadline go after those with one, and ties keep their order. Running and
+// finished items stay where they are. It reports whether anything moved.
+func (q *Queue) OrderByDeadline() bool {
+	var slots []int
+	var pending []*QueueItem
+	for i, item := range q.Items {
+		if item.Status == ExecutionPending {
+			slots = append(slots, i)
+			pending = append(pending, item)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		di, dj := q.DeadlineOf(pending[i]), q.DeadlineOf(pending[j])
+		if di.IsZero() {
+			return false
+		}
+		return dj.IsZero() || di.Before(dj)
+	})
+
+	changed := false
+	for k, i := range slots {
+		if q.Items[i] != pending[k] {
+			q.Items[i] = pending[k]
+			changed = true
+		}
+	}
+	q.updatePositions()
+	return changed
+}
+
+// MetDeadline reports whether the execution completed by its deadline. It
+// is false when there was no deadline.
+func (e *Execution) MetDeadline() bool {
+	return !e.Deadline.IsZero() && e.Status == ExecutionCompleted && !e.EndTime.After(e.Deadline)
+}
+
+// ParseDeadline reads a deadline relative to now: a time of day such as
+// "08:00" (the next time it comes round), a date and time such as
+// "2026-03-01 08:00", or a duration from now such as "90m" or "+2h". "none"
+// returns the zero time, clearing a deadline.
+func ParseDeadline(spec string, now time.Time) (time.Time, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.EqualFold(spec, "none") {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.ParseInLocation("15:04", spec, now.Location()); err == nil {
+		deadline := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !deadline.After(now) {
+			deadline = deadline.AddDate(0, 0, 1)
+		}
+		return deadline, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, spec, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if d, err := time.ParseDuration(strings.TrimPrefix(spec, "+")); err == nil && d > 0 {
+		return now.Add(d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid deadline %q: want HH:MM, YYYY-MM-DD HH:MM, a duration such as 2h, or none", spec)
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemEstimate_Risk(t *testing.T) {
+	now := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 1, h, m, 0, 0, time.UTC) }
+	est := ItemEstimate{Finish: time.Hour, Spread: 20 * time.Minute}
+
+	tests := []struct {
+		deadline time.Time
+		want     DeadlineRisk
+	}{
+		{time.Time{}, DeadlineNone},
+		{at(8, 0), DeadlineOnTrack},
+		{at(7, 10), DeadlineAtRisk},
+		{at(6, 30), DeadlineLate},
+		{at(6, 0), DeadlineBreached},
+	}
+	for _, tt := range tests {
+		est.Deadline = tt.deadline
+		assert.Equal(t, tt.want, est.Risk(now), "deadline %s", tt.deadline)
+	}
+}
+
+func TestQueue_DeadlineOf(t *testing.T) {
+	q := NewQueue()
+	q.Add(createTestStory("3-1-a", StatusReadyForDev))
+	q.Add(createTestStory("3-2-b", StatusReadyForDev))
+	assert.False(t, q.HasDeadlines())
+
+	own := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	q.Items[0].Deadline = own
+	assert.True(t, q.HasDeadlines())
+	assert.Equal(t, own, q.DeadlineOf(q.Items[0]))
+	assert.True(t, q.DeadlineOf(q.Items[1]).IsZero())
+
+	q.Deadline = own.Add(time.Hour)
+	assert.Equal(t, own, q.DeadlineOf(q.Items[0]), "an item's own deadline wins")
+	assert.Equal(t, q.Deadline, q.DeadlineOf(q.Items[1]))
+
+	estimates := q.EstimateItems([]Story{createTestStory("3-3-c", StatusReadyForDev)})
+	require.Len(t, estimates, 3)
+	assert.Equal(t, own, estimates[0].Deadline)
+	assert.Equal(t, q.Deadline, estimates[2].Deadline, "proposed stories get the queue's deadline")
+
+	q.Clear()
+	assert.True(t, q.Deadline.IsZero())
+}
+
+func TestQueue_Breaches(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)
+	deadline := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	q := NewQueue()
+	for _, key := range []string{"3-1-intime", "3-2-late", "3-3-running", "3-4-cancelled", "3-5-future"} {
+		q.Add(createTestStory(key, StatusReadyForDev))
+	}
+	q.Deadline = deadline
+	q.Items[0].Status = ExecutionCompleted
+	q.Items[0].Execution = &Execution{Status: ExecutionCompleted, EndTime: deadline.Add(-time.Minute)}
+	q.Items[1].Status = ExecutionCompleted
+	q.Items[1].Execution = &Execution{Status: ExecutionCompleted, EndTime: deadline.Add(time.Minute)}
+	q.Items[2].Status = ExecutionRunning
+	q.Items[3].Status = ExecutionCancelled
+	q.Items[4].Deadline = now.Add(time.Hour)
+
+	var keys []string
+	for _, item := range q.Breaches(now) {
+		keys = append(keys, item.Story.Key)
+	}
+	assert.Equal(t, []string{"3-2-late", "3-3-running"}, keys)
+}
+
+func TestQueue_OrderByDeadline(t *testing.T) {
+	base := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	q := NewQueue()
+	for _, key := range []string{"3-1-done", "3-2-none", "3-3-late", "3-4-early", "3-5-none"} {
+		q.Add(createTestStory(key, StatusReadyForDev))
+	}
+	q.Items[0].Status = ExecutionCompleted
+	q.Items[0].Deadline = base.Add(10 * time.Hour)
+	q.Items[2].Deadline = base.Add(2 * time.Hour)
+	q.Items[3].Deadline = base
+
+	assert.True(t, q.OrderByDeadline())
+	var keys []string
+	for i, item := range q.Items {
+		keys = append(keys, item.Story.Key)
+		assert.Equal(t, i+1, item.Position)
+	}
+	assert.Equal(t, []string{"3-1-done", "3-4-early", "3-3-late", "3-2-none", "3-5-none"}, keys)
+	assert.False(t, q.OrderByDeadline(), "already in deadline order")
+}
+
+func TestExecution_MetDeadline(t *testing.T) {
+	deadline := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	exec := &Execution{Status: ExecutionCompleted, EndTime: deadline}
+	assert.False(t, exec.MetDeadline(), "no deadline")
+
+	exec.Deadline = deadline
+	assert.True(t, exec.MetDeadline())
+	exec.EndTime = deadline.Add(time.Second)
+	assert.False(t, exec.MetDeadline())
+	exec.EndTime = deadline
+	exec.Status = ExecutionFailed
+	assert.False(t, exec.MetDeadline())
+}
+
+func TestParseDeadline(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 15, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"10:00", time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)},
+		{"08:00", time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)},
+		{"2026-03-05 18:30", time.Date(2026, 3, 5, 18, 30, 0, 0, time.UTC)},
+		{"2026-03-05T18:30", time.Date(2026, 3, 5, 18, 30, 0, 0, time.UTC)},
+		{"90m", now.Add(90 * time.Minute)},
+		{" +2h ", now.Add(2 * time.Hour)},
+		{"none", time.Time{}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDeadline(tt.spec, now)
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.want, got, tt.spec)
+	}
+
+	for _, spec := range []string{"", "tomorrow", "25:00", "-1h"} {
+		_, err := ParseDeadline(spec, now)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DependencyCycle returns a cycle in the dependencies among stories, as
+// keys with the first repeated at the end, or nil when there is none.
+// Dependencies on stories not in the list are ignored.
+func DependencyCycle(stories []Story) []string {
+	deps := make(map[string][]string, len(stories))
+	for _, s := range stories {
+		deps[s.Key] = s.DependsOn
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(stories))
+	var path []string
+
+	var visit func(key string) []string
+	visit = func(key string) []string {
+		state[key] = visiting
+		path = append(path, key)
+		for _, dep := range deps[key] {
+			if _, known := deps[dep]; !known {
+				continue
+			}
+			switch state[dep] {
+			case visiting:
+				for i, k := range path {
+					if k == dep {
+						return append(append([]string{}, path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[key] = visited
+		return nil
+	}
+
+	for _, s := range stories {
+		if state[s.Key] == unvisited {
+			if cycle := visit(s.Key); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Blockers returns the dependencies of item that have not completed in
+// the queue, in the order they were declared
+func (q *Queue) Blockers(item *QueueItem) []string {
+	var blockers []string
+	for _, dep := range item.Story.DependsOn {
+		if d := q.GetItem(q.IndexOf(dep)); d == nil || d.Status != ExecutionCompleted {
+			blockers = append(blockers, dep)
+		}
+	}
+	return blockers
+}
+
+// BlockedReason explains why a pending item cannot run once nothing else
+// in the queue can: it is part of a dependency cycle, or waits on stories
+// that failed or are not queued. It returns "" for an item that can run.
+func (q *Queue) BlockedReason(item *QueueItem) string {
+	blockers := q.Blockers(item)
+	if len(blockers) == 0 {
+		return ""
+	}
+	var pending []Story
+	for _, it := range q.Items {
+		if it.Status == ExecutionPending {
+			pending = append(pending, it.Story)
+		}
+	}
+	if cycle := DependencyCycle(pending); cycle != nil && containsKey(cycle, item.Story.Key) {
+		return "dependency cycle: " + strings.Join(cycle, " -> ")
+	}
+	return fmt.Sprintf("depends on %s, which did not complete", strings.Join(blockers, ", "))
+}
+
+// OrderByDependencies reorders the pending items so each comes after the
+// pending items it depends on, otherwise keeping their order. Items in a
+// cycle, and those after them, go last. It reports whether anything moved.
+func (q *Queue) OrderByDependencies() bool {
+	var slots []int
+	var pending []*QueueItem
+	index := make(map[string]int)
+	for i, item := range q.Items {
+		if item.Status == ExecutionPending {
+			index[item.Story.Key] = len(pending)
+			slots = append(slots, i)
+			pending = append(pending, item)
+		}
+	}
+
+	// Repeatedly take the first item whose pending dependencies are
+	// already placed; once none is left, what remains is cyclic
+	placed := make([]bool, len(pending))
+	ordered := make([]*QueueItem, 0, len(pending))
+	for len(ordered) < len(pending) {
+		next := -1
+		for i, item := range pending {
+			if placed[i] {
+				continue
+			}
+			ready := true
+			for _, dep := range item.Story.DependsOn {
+				if j, ok := index[dep]; ok && !placed[j] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			for i, item := range pending {
+				if !placed[i] {
+					placed[i] = true
+					ordered = append(ordered, item)
+				}
+			}
+			break
+		}
+		placed[next] = true
+		ordered = append(ordered, pending[next])
+	}
+
+	changed := false
+	for k, i := range slots {
+		if q.Items[i] != ordered[k] {
+			q.Items[i] = ordered[k]
+			changed = true
+		}
+	}
+	q.updatePositions()
+	return changed
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
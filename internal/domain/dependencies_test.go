@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func storyAfter(key string, deps ...string) Story {
+	story := createTestStory(key, StatusReadyForDev)
+	story.DependsOn = deps
+	return story
+}
+
+func TestDependencyCycle(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		assert.Nil(t, DependencyCycle([]Story{
+			storyAfter("3-1-a"),
+			storyAfter("3-2-b", "3-1-a"),
+			storyAfter("3-3-c", "3-1-a", "3-2-b"),
+		}))
+	})
+
+	t.Run("ignores stories not in the list", func(t *testing.T) {
+		assert.Nil(t, DependencyCycle([]Story{storyAfter("3-2-b", "3-1-a")}))
+	})
+
+	t.Run("finds the cycle", func(t *testing.T) {
+		cycle := DependencyCycle([]Story{
+			storyAfter("3-1-a"),
+			storyAfter("3-2-b", "3-1-a", "3-4-d"),
+			storyAfter("3-3-c", "3-2-b"),
+			storyAfter("3-4-d", "3-3-c"),
+		})
+		assert.Equal(t, []string{"3-2-b", "3-4-d", "3-3-c", "3-2-b"}, cycle)
+	})
+}
+
+func TestQueue_OrderByDependencies(t *testing.T) {
+	q := NewQueue()
+	q.AddMultiple([]Story{
+		storyAfter("3-3-c", "3-2-b"),
+		storyAfter("3-1-a"),
+		storyAfter("3-2-b", "3-1-a"),
+		storyAfter("4-1-d"),
+	})
+
+	assert.True(t, q.OrderByDependencies())
+	assert.Equal(t, []string{"3-1-a", "3-2-b", "3-3-c", "4-1-d"}, queueKeys(q))
+	assert.Equal(t, 1, q.Items[0].Position)
+	assert.False(t, q.OrderByDependencies())
+
+	t.Run("leaves finished items in place", func(t *testing.T) {
+		q := NewQueue()
+		q.AddMultiple([]Story{storyAfter("3-2-b", "3-1-a"), storyAfter("3-1-a"), storyAfter("3-3-c")})
+		q.Items[0].Status = ExecutionFailed
+
+		assert.False(t, q.OrderByDependencies())
+		assert.Equal(t, []string{"3-2-b", "3-1-a", "3-3-c"}, queueKeys(q))
+	})
+
+	t.Run("moves cycles last", func(t *testing.T) {
+		q := NewQueue()
+		q.AddMultiple([]Story{storyAfter("3-1-a", "3-2-b"), storyAfter("3-2-b", "3-1-a"), storyAfter("3-3-c")})
+
+		assert.True(t, q.OrderByDependencies())
+		assert.Equal(t, []string{"3-3-c", "3-1-a", "3-2-b"}, queueKeys(q))
+	})
+}
+
+func TestQueue_Blockers(t *testing.T) {
+	q := NewQueue()
+	q.AddMultiple([]Story{storyAfter("3-1-a"), storyAfter("3-2-b", "3-1-a", "2-1-z")})
+	item := q.Items[1]
+
+	assert.Equal(t, []string{"3-1-a", "2-1-z"}, q.Blockers(item))
+	assert.Equal(t, 0, q.NextPendingIndex(false))
+
+	q.Items[0].Status = ExecutionCompleted
+	assert.Equal(t, []string{"2-1-z"}, q.Blockers(item))
+	assert.Equal(t, -1, q.NextPendingIndex(false))
+	assert.Equal(t, "depends on 2-1-z, which did not complete", q.BlockedReason(item))
+
+	item.Story.DependsOn = []string{"3-1-a"}
+	assert.Empty(t, q.Blockers(item))
+	assert.Equal(t, 1, q.NextPendingIndex(false))
+	assert.Empty(t, q.BlockedReason(item))
+}
+
+func TestQueue_BlockedReason_Cycle(t *testing.T) {
+	q := NewQueue()
+	q.AddMultiple([]Story{storyAfter("3-1-a", "3-2-b"), storyAfter("3-2-b", "3-1-a"), storyAfter("3-3-c", "3-1-a")})
+
+	assert.Equal(t, "dependency cycle: 3-1-a -> 3-2-b -> 3-1-a", q.BlockedReason(q.Items[0]))
+	assert.Equal(t, "depends on 3-1-a, which did not complete", q.BlockedReason(q.Items[2]))
+}
+
+func queueKeys(q *Queue) []string {
+	var keys []string
+	for _, item := range q.Items {
+		keys = append(keys, item.Story.Key)
+	}
+	return keys
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// EstimateWeight is how strongly the newest duration moves an Estimate
+// (0-1). Higher values adapt faster but forget history sooner.
+const EstimateWeight = 0.3
+
+// Estimate is an exponentially weighted mean and variance of a step's
+// duration, so recent runs count more than old ones
+type Estimate struct {
+	Mean     time.Duration
+	Variance float64 // In seconds squared
+	Samples  int
+}
+
+// Observe returns the estimate updated with one more actual duration
+func (e Estimate) Observe(d time.Duration) Estimate {
+	if e.Samples == 0 {
+		return Estimate{Mean: d, Samples: 1}
+	}
+
+	diff := d.Seconds() - e.Mean.Seconds()
+	incr := EstimateWeight * diff
+	return Estimate{
+		Mean:     e.Mean + time.Duration(incr*float64(time.Second)),
+		Variance: (1 - EstimateWeight) * (e.Variance + diff*incr),
+		Samples:  e.Samples + 1,
+	}
+}
+
+// StdDev returns the standard deviation of the estimate
+func (e Estimate) StdDev() time.Duration {
+	return time.Duration(math.Sqrt(e.Variance) * float64(time.Second))
+}
+
+// EstimateFrom builds an estimate from durations in the order they happened
+func EstimateFrom(durations []time.Duration) Estimate {
+	var e Estimate
+	for _, d := range durations {
+		e = e.Observe(d)
+	}
+	return e
+}
+
+// PercentError returns how far actual was from predicted, as a percentage
+// of predicted. Positive means it took longer than predicted.
+func PercentError(predicted, actual time.Duration) float64 {
+	if predicted <= 0 {
+		return 0
+	}
+	return float64(actual-predicted) / float64(predicted) * 100
+}
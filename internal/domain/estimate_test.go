@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimate_Observe(t *testing.T) {
+	e := Estimate{}.Observe(10 * time.Second)
+	assert.Equal(t, 10*time.Second, e.Mean)
+	assert.Zero(t, e.Variance)
+	assert.Equal(t, 1, e.Samples)
+
+	// The newest duration moves the mean by EstimateWeight of the difference
+	e = e.Observe(20 * time.Second)
+	assert.Equal(t, 13*time.Second, e.Mean)
+	assert.InDelta(t, 21.0, e.Variance, 0.001) // 0.7 * (10 * 3)
+	assert.Equal(t, 2, e.Samples)
+}
+
+func TestEstimate_SteadyDurationsHaveNoSpread(t *testing.T) {
+	e := EstimateFrom([]time.Duration{time.Minute, time.Minute, time.Minute})
+
+	assert.Equal(t, time.Minute, e.Mean)
+	assert.Zero(t, e.StdDev())
+}
+
+func TestEstimate_FavorsRecentRuns(t *testing.T) {
+	old := make([]time.Duration, 20)
+	for i := range old {
+		old[i] = time.Minute
+	}
+	e := EstimateFrom(append(old, 5*time.Minute, 5*time.Minute, 5*time.Minute))
+
+	assert.Greater(t, e.Mean, 3*time.Minute)
+	assert.Greater(t, e.StdDev(), time.Duration(0))
+}
+
+func TestPercentError(t *testing.T) {
+	assert.InDelta(t, 50.0, PercentError(2*time.Minute, 3*time.Minute), 0.001)
+	assert.InDelta(t, -25.0, PercentError(4*time.Minute, 3*time.Minute), 0.001)
+	assert.Zero(t, PercentError(0, time.Minute))
+}
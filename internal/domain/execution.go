@@ -2,6 +2,8 @@ package domain
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // ExecutionStatus represents the overall status of a story execution
@@ -14,8 +16,13 @@ const (
 	ExecutionCompleted ExecutionStatus = "completed"
 	ExecutionFailed    ExecutionStatus = "failed"
 	ExecutionCancelled ExecutionStatus = "cancelled"
+	ExecutionConflict  ExecutionStatus = "conflict" // Parked with unresolved merge conflicts
 )
 
+// SlowStepRatio is how far past its historical average a running step may go
+// before it is flagged as slow (1.5 = 150% of the average)
+const SlowStepRatio = 1.5
+
 // StepExecution represents the execution state of a single step
 type StepExecution struct {
 	Name        StepName
@@ -25,10 +32,67 @@ type StepExecution struct {
 	Duration    time.Duration
 	Output      []string // Lines of output
 	Error       string
-	Attempt     int      // Current attempt number (1-based)
-	Command     string   // Display-friendly command string for logging
-	CommandName string   // Actual executable name (e.g., "claude")
-	CommandArgs []string // Command arguments (prevents shell injection)
+	Attempt     int           // Current attempt number (1-based)
+	Stalled     bool          // No output received within the stall timeout
+	TimedOut    bool          // The attempt was stopped at the step timeout
+	Command     string        // Display-friendly command string for logging
+	CommandName string        // Actual executable name (e.g., "claude")
+	CommandArgs []string      // Command arguments (prevents shell injection)
+	Predicted   time.Duration // Estimated duration when the execution started (0 = none)
+	Usage       Usage         // Tokens and cost of every attempt so far
+
+	// PromptOverride replaces the workflow's prompt for this run only, for
+	// an agent step edited in the step preview ("" = workflow prompt)
+	PromptOverride string
+
+	// PreviousAttempts holds the attempts made before the current one,
+	// oldest first
+	PreviousAttempts []*StepAttempt
+}
+
+// StepAttempt records the outcome and output of one attempt at a step
+type StepAttempt struct {
+	Number    int
+	Status    StepStatus
+	StartTime time.Time
+	Duration  time.Duration
+	Error     string
+	Output    []string
+}
+
+// ArchiveAttempt moves the current attempt into PreviousAttempts so the
+// step can be run again without losing its output. An attempt that is run
+// again has failed. It does nothing if the step has not been attempted yet.
+func (s *StepExecution) ArchiveAttempt() {
+	if s.Attempt == 0 {
+		return
+	}
+	s.PreviousAttempts = append(s.PreviousAttempts, &StepAttempt{
+		Number:    s.Attempt,
+		Status:    StepFailed,
+		StartTime: s.StartTime,
+		Duration:  s.Duration,
+		Error:     s.Error,
+		Output:    s.Output,
+	})
+}
+
+// Attempts returns every attempt at the step, oldest first, ending with
+// the current one
+func (s *StepExecution) Attempts() []*StepAttempt {
+	attempts := make([]*StepAttempt, 0, len(s.PreviousAttempts)+1)
+	attempts = append(attempts, s.PreviousAttempts...)
+	if s.Attempt > 0 {
+		attempts = append(attempts, &StepAttempt{
+			Number:    s.Attempt,
+			Status:    s.Status,
+			StartTime: s.StartTime,
+			Duration:  s.Duration,
+			Error:     s.Error,
+			Output:    s.Output,
+		})
+	}
+	return attempts
 }
 
 // IsComplete returns true if the step has finished (success, failed, or skipped)
@@ -36,8 +100,24 @@ func (s *StepExecution) IsComplete() bool {
 	return s.Status == StepSuccess || s.Status == StepFailed || s.Status == StepSkipped
 }
 
+// OverrunRatio returns how long a running step has taken relative to the given
+// historical average (2.0 = twice as long as usual). It returns 0 when the step
+// is not running or no average is known.
+func (s *StepExecution) OverrunRatio(average time.Duration, now time.Time) float64 {
+	if s.Status != StepRunning || s.StartTime.IsZero() || average <= 0 {
+		return 0
+	}
+	return float64(now.Sub(s.StartTime)) / float64(average)
+}
+
+// IsSlow returns true if a running step has exceeded SlowStepRatio of its average
+func (s *StepExecution) IsSlow(average time.Duration, now time.Time) bool {
+	return s.OverrunRatio(average, now) >= SlowStepRatio
+}
+
 // Execution represents the full execution state of a story through all steps
 type Execution struct {
+	ID        string // Stable identifier, also used as the history record ID
 	Story     Story
 	Status    ExecutionStatus
 	Steps     []*StepExecution
@@ -46,12 +126,62 @@ type Execution struct {
 	EndTime   time.Time
 	Duration  time.Duration
 	Error     string
+	Predicted time.Duration // Estimated total duration when it started (0 = none)
+	Deadline  time.Time     // When a queue needed it finished by (zero = none)
+
+	// Snapshot records the workspace before the run, nil if none was taken
+	Snapshot *WorkspaceSnapshot
+
+	// Context records the environment the run started in, nil if unknown
+	Context *ExecutionContext
+
+	// Branch is the story branch the steps ran on, empty unless
+	// branch-per-story is enabled
+	Branch string
+
+	// Stash is the commit holding uncommitted changes auto-stash set aside
+	// before the run. It is cleared once they are restored, so a saved
+	// execution only has one when restoring failed.
+	Stash string
+
+	// Tags label the execution for filtering history, e.g. "sprint-12"
+	Tags []string
+}
+
+// ExecutionContext records the environment an execution started in, so a
+// run that behaves differently from an earlier one can be compared with it
+type ExecutionContext struct {
+	GitBranch     string // Empty outside a git repository, "HEAD" when detached
+	GitCommit     string
+	GitDirty      bool   // Uncommitted or untracked changes were present
+	ClaudeVersion string // Output of "claude --version", empty if unavailable
+	BmadVersion   string
+	OS            string
+	Arch          string
+	ConfigDigest  string // Short hash of the settings that affect execution
+	Workflow      string
+	Profile       string
+}
+
+// WorkspaceSnapshot records the state of the git working tree before an
+// execution started, so the run can be compared or undone later
+type WorkspaceSnapshot struct {
+	Ref       string   // Git ref that keeps the snapshot commits reachable
+	Head      string   // Commit checked out before the run
+	Stash     string   // Stash commit holding uncommitted changes, empty if the tree was clean
+	Untracked []string // Untracked files present before the run
 }
 
 // NewExecution creates a new Execution for a story with all steps initialized
 func NewExecution(story Story) *Execution {
-	steps := make([]*StepExecution, len(AllSteps()))
-	for i, stepName := range AllSteps() {
+	return NewExecutionWithSteps(story, AllSteps())
+}
+
+// NewExecutionWithSteps creates a new execution that runs the given steps
+// in order, for workflows that define their own
+func NewExecutionWithSteps(story Story, names []StepName) *Execution {
+	steps := make([]*StepExecution, len(names))
+	for i, stepName := range names {
 		steps[i] = &StepExecution{
 			Name:    stepName,
 			Status:  StepPending,
@@ -61,6 +191,7 @@ func NewExecution(story Story) *Execution {
 	}
 
 	return &Execution{
+		ID:      uuid.New().String(),
 		Story:   story,
 		Status:  ExecutionPending,
 		Steps:   steps,
@@ -68,6 +199,26 @@ func NewExecution(story Story) *Execution {
 	}
 }
 
+// ShortIDLength is how many characters of an execution ID are shown
+const ShortIDLength = 8
+
+// ShortID returns the abbreviated execution ID shown in the UI
+func ShortID(id string) string {
+	if len(id) > ShortIDLength {
+		return id[:ShortIDLength]
+	}
+	return id
+}
+
+// IsFinished returns true once the execution has stopped for good
+func (e *Execution) IsFinished() bool {
+	switch e.Status {
+	case ExecutionCompleted, ExecutionFailed, ExecutionCancelled, ExecutionConflict:
+		return true
+	}
+	return false
+}
+
 // CurrentStep returns the current step execution, or nil if none
 func (e *Execution) CurrentStep() *StepExecution {
 	if e.Current >= 0 && e.Current < len(e.Steps) {
@@ -113,6 +264,21 @@ func (e *Execution) FailedStep() *StepExecution {
 	return nil
 }
 
+// CanRetry returns true if the execution failed at a step that can be run
+// again
+func (e *Execution) CanRetry() bool {
+	return e.Status == ExecutionFailed && e.FailedStep() != nil
+}
+
+// Usage returns the tokens and cost of all steps
+func (e *Execution) Usage() Usage {
+	var total Usage
+	for _, step := range e.Steps {
+		total.Add(step.Usage)
+	}
+	return total
+}
+
 // TotalDuration returns the total duration of completed steps
 func (e *Execution) TotalDuration() time.Duration {
 	var total time.Duration
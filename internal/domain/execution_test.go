@@ -399,6 +399,94 @@ func TestStepExecution_IsComplete(t *testing.T) {
 	}
 }
 
+func TestStepExecution_OverrunRatio(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		status   StepStatus
+		started  time.Duration // how long ago the step started
+		average  time.Duration
+		expected float64
+		slow     bool
+	}{
+		{
+			name:     "running within budget",
+			status:   StepRunning,
+			started:  time.Minute,
+			average:  time.Minute,
+			expected: 1.0,
+			slow:     false,
+		},
+		{
+			name:     "running past budget",
+			status:   StepRunning,
+			started:  3 * time.Minute,
+			average:  time.Minute,
+			expected: 3.0,
+			slow:     true,
+		},
+		{
+			name:     "no average known",
+			status:   StepRunning,
+			started:  time.Hour,
+			average:  0,
+			expected: 0,
+			slow:     false,
+		},
+		{
+			name:     "completed step is never slow",
+			status:   StepSuccess,
+			started:  time.Hour,
+			average:  time.Minute,
+			expected: 0,
+			slow:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := &StepExecution{
+				Name:      StepDevStory,
+				Status:    tt.status,
+				StartTime: now.Add(-tt.started),
+			}
+			assert.InDelta(t, tt.expected, step.OverrunRatio(tt.average, now), 0.001)
+			assert.Equal(t, tt.slow, step.IsSlow(tt.average, now))
+		})
+	}
+}
+
+func TestStepExecution_Attempts(t *testing.T) {
+	step := &StepExecution{Name: StepDevStory}
+	step.ArchiveAttempt()
+	assert.Empty(t, step.PreviousAttempts, "a step that never ran has no attempts")
+	assert.Empty(t, step.Attempts())
+
+	started := time.Now()
+	step.Attempt = 1
+	step.Status = StepRunning
+	step.StartTime = started
+	step.Duration = time.Second
+	step.Error = "exit status 1"
+	step.Output = []string{"first try"}
+	step.ArchiveAttempt()
+
+	step.Attempt = 2
+	step.Status = StepSuccess
+	step.Duration = 2 * time.Second
+	step.Error = ""
+	step.Output = []string{"second try"}
+
+	attempts := step.Attempts()
+	require.Len(t, attempts, 2)
+	assert.Equal(t, &StepAttempt{Number: 1, Status: StepFailed, StartTime: started, Duration: time.Second,
+		Error: "exit status 1", Output: []string{"first try"}}, attempts[0])
+	assert.Equal(t, &StepAttempt{Number: 2, Status: StepSuccess, StartTime: started, Duration: 2 * time.Second,
+		Output: []string{"second try"}}, attempts[1])
+	assert.Len(t, step.PreviousAttempts, 1)
+}
+
 func TestExecutionStatus_Constants(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -468,3 +556,9 @@ func TestStepExecution_Fields(t *testing.T) {
 	assert.Equal(t, 2, step.Attempt)
 	assert.Equal(t, "test command", step.Command)
 }
+
+func TestShortID(t *testing.T) {
+	assert.Equal(t, "550e8400", ShortID("550e8400-e29b-41d4-a716-446655440000"))
+	assert.Equal(t, "abc", ShortID("abc"))
+	assert.NotEmpty(t, NewExecution(Story{Key: "1-1-a"}).ID)
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// HealthWindow is how many recent runs of a story its health is based on
+const HealthWindow = 10
+
+// HealthRun is one past run of a story as it counts towards its health
+type HealthRun struct {
+	Status    ExecutionStatus
+	Duration  time.Duration
+	Predicted time.Duration // Estimate when the run started, 0 if none
+	Retried   bool          // A step needed more than one attempt
+}
+
+// HealthLevel buckets a health score
+type HealthLevel string
+
+const (
+	HealthUnknown HealthLevel = "unknown" // No finished runs yet
+	HealthGood    HealthLevel = "good"
+	HealthFair    HealthLevel = "fair"
+	HealthPoor    HealthLevel = "poor"
+)
+
+// StoryHealth summarizes how reliably a story has run recently
+type StoryHealth struct {
+	Runs        int
+	FailureRate float64 // Share of runs that failed or were parked
+	Flakiness   float64 // Share of runs where a step had to be retried
+	Overrun     float64 // Mean fraction by which runs exceeded their estimate, capped at 1
+	Score       int     // 0-100, higher is healthier
+}
+
+// NewStoryHealth scores a story from its recent runs. Failures weigh most,
+// then retries, then running over the estimate. Cancelled runs say nothing
+// about the story and are ignored.
+func NewStoryHealth(runs []HealthRun) StoryHealth {
+	var h StoryHealth
+	var failed, retried, estimated int
+	var overrun float64
+	for _, run := range runs {
+		if run.Status == ExecutionCancelled {
+			continue
+		}
+		h.Runs++
+		if run.Status == ExecutionFailed || run.Status == ExecutionConflict {
+			failed++
+		}
+		if run.Retried {
+			retried++
+		}
+		if run.Status == ExecutionCompleted && run.Predicted > 0 {
+			estimated++
+			overrun += math.Min(math.Max(float64(run.Duration)/float64(run.Predicted)-1, 0), 1)
+		}
+	}
+	if h.Runs == 0 {
+		return h
+	}
+
+	h.FailureRate = float64(failed) / float64(h.Runs)
+	h.Flakiness = float64(retried) / float64(h.Runs)
+	if estimated > 0 {
+		h.Overrun = overrun / float64(estimated)
+	}
+	h.Score = int(math.Round(100 - 50*h.FailureRate - 30*h.Flakiness - 20*h.Overrun))
+	return h
+}
+
+// Level returns the bucket of the score
+func (h StoryHealth) Level() HealthLevel {
+	switch {
+	case h.Runs == 0:
+		return HealthUnknown
+	case h.Score >= 80:
+		return HealthGood
+	case h.Score >= 50:
+		return HealthFair
+	default:
+		return HealthPoor
+	}
+}
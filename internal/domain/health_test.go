@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStoryHealth(t *testing.T) {
+	t.Run("no runs", func(t *testing.T) {
+		h := NewStoryHealth(nil)
+		assert.Equal(t, 0, h.Runs)
+		assert.Equal(t, HealthUnknown, h.Level())
+
+		h = NewStoryHealth([]HealthRun{{Status: ExecutionCancelled}})
+		assert.Equal(t, HealthUnknown, h.Level(), "cancelled runs are ignored")
+	})
+
+	t.Run("clean runs", func(t *testing.T) {
+		h := NewStoryHealth([]HealthRun{
+			{Status: ExecutionCompleted, Duration: 10 * time.Minute, Predicted: 12 * time.Minute},
+			{Status: ExecutionCompleted, Duration: 10 * time.Minute},
+		})
+		assert.Equal(t, 2, h.Runs)
+		assert.Equal(t, 100, h.Score)
+		assert.Equal(t, HealthGood, h.Level())
+	})
+
+	t.Run("failures, retries and overruns lower the score", func(t *testing.T) {
+		h := NewStoryHealth([]HealthRun{
+			{Status: ExecutionFailed},
+			{Status: ExecutionConflict},
+			{Status: ExecutionCompleted, Retried: true, Duration: 15 * time.Minute, Predicted: 10 * time.Minute},
+			{Status: ExecutionCompleted, Duration: 30 * time.Minute, Predicted: 10 * time.Minute},
+		})
+		assert.Equal(t, 0.5, h.FailureRate)
+		assert.Equal(t, 0.25, h.Flakiness)
+		assert.Equal(t, 0.75, h.Overrun, "the 3x overrun is capped at 1")
+		assert.Equal(t, 53, h.Score, "100 - 25 - 7.5 - 15, rounded")
+		assert.Equal(t, HealthFair, h.Level())
+	})
+
+	t.Run("always failing", func(t *testing.T) {
+		h := NewStoryHealth([]HealthRun{{Status: ExecutionFailed, Retried: true}, {Status: ExecutionFailed, Retried: true}})
+		assert.Equal(t, 20, h.Score)
+		assert.Equal(t, HealthPoor, h.Level())
+	})
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PipelineRun is one run of a named pipeline: its stages run in order,
+// each as a queue of stories
+type PipelineRun struct {
+	ID        string
+	Pipeline  string
+	Status    ExecutionStatus
+	StartTime time.Time
+	EndTime   time.Time
+	Duration  time.Duration
+	Error     string
+	Stages    []*PipelineStage
+}
+
+// PipelineStage is the progress of one stage of a pipeline run
+type PipelineStage struct {
+	Name         string
+	Workflow     string
+	Status       ExecutionStatus // Pending until the stage is reached
+	Succeeded    int
+	Failed       int
+	ExecutionIDs []string // Executions the stage ran, in order
+}
+
+// NewPipelineRun creates a pending run with one pending entry per stage
+func NewPipelineRun(pipeline string, stages []*PipelineStage) *PipelineRun {
+	for _, stage := range stages {
+		stage.Status = ExecutionPending
+	}
+	return &PipelineRun{
+		ID:       uuid.New().String(),
+		Pipeline: pipeline,
+		Status:   ExecutionPending,
+		Stages:   stages,
+	}
+}
+
+// IsFinished returns true if the run is no longer in progress
+func (r *PipelineRun) IsFinished() bool {
+	return r.Status == ExecutionCompleted || r.Status == ExecutionFailed || r.Status == ExecutionCancelled
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPipelineRun(t *testing.T) {
+	run := NewPipelineRun("release", []*PipelineStage{{Name: "epic-4", Status: ExecutionCompleted}, {Name: "integration"}})
+
+	assert.NotEmpty(t, run.ID)
+	assert.Equal(t, "release", run.Pipeline)
+	assert.Equal(t, ExecutionPending, run.Status)
+	for _, stage := range run.Stages {
+		assert.Equal(t, ExecutionPending, stage.Status)
+	}
+	assert.False(t, run.IsFinished())
+
+	run.Status = ExecutionRunning
+	assert.False(t, run.IsFinished())
+	for _, status := range []ExecutionStatus{ExecutionCompleted, ExecutionFailed, ExecutionCancelled} {
+		run.Status = status
+		assert.True(t, run.IsFinished(), status)
+	}
+}
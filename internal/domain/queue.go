@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"sort"
 	"time"
 )
 
@@ -20,7 +21,9 @@ type QueueItem struct {
 	Status    ExecutionStatus
 	Execution *Execution // Populated when executing/completed
 	AddedAt   time.Time
-	Position  int // Position in queue (1-based for display)
+	Position  int       // Position in queue (1-based for display)
+	Deadline  time.Time // Must finish by; zero falls back to the queue's
+	Tags      []string  // Attached to its execution, e.g. "hotfix"
 }
 
 // Queue manages a list of stories to be executed
@@ -33,17 +36,23 @@ type Queue struct {
 	StartTime time.Time
 	EndTime   time.Time
 
-	// Historical averages for ETA calculation (per step)
-	StepAverages map[StepName]time.Duration
+	// Deadline applies to every item without its own (zero = none)
+	Deadline time.Time
+
+	// Historical averages for ETA calculation (per step). These are the
+	// means of StepEstimates, which also track how much durations vary.
+	StepAverages  map[StepName]time.Duration
+	StepEstimates map[StepName]Estimate
 }
 
 // NewQueue creates a new empty queue
 func NewQueue() *Queue {
 	return &Queue{
-		Items:        make([]*QueueItem, 0),
-		Status:       QueueIdle,
-		Current:      -1,
-		StepAverages: make(map[StepName]time.Duration),
+		Items:         make([]*QueueItem, 0),
+		Status:        QueueIdle,
+		Current:       -1,
+		StepAverages:  make(map[StepName]time.Duration),
+		StepEstimates: make(map[StepName]Estimate),
 	}
 }
 
@@ -103,6 +112,7 @@ func (q *Queue) Clear() {
 	} else {
 		q.Items = make([]*QueueItem, 0)
 		q.Current = -1
+		q.Deadline = time.Time{}
 	}
 	q.updatePositions()
 }
@@ -156,17 +166,38 @@ func (q *Queue) GetPending() []*QueueItem {
 	return pending
 }
 
-// GetCompleted returns all completed items (success or failed)
+// GetCompleted returns all finished items (success, failed or conflicted)
 func (q *Queue) GetCompleted() []*QueueItem {
 	var completed []*QueueItem
 	for _, item := range q.Items {
-		if item.Status == ExecutionCompleted || item.Status == ExecutionFailed {
+		if item.Status == ExecutionCompleted || item.Status == ExecutionFailed ||
+			item.Status == ExecutionConflict {
 			completed = append(completed, item)
 		}
 	}
 	return completed
 }
 
+// FinishedDurations returns the run time of each finished item in the order
+// the items finished
+func (q *Queue) FinishedDurations() []time.Duration {
+	var finished []*Execution
+	for _, item := range q.GetCompleted() {
+		if item.Execution != nil && item.Execution.Duration > 0 {
+			finished = append(finished, item.Execution)
+		}
+	}
+	sort.SliceStable(finished, func(i, j int) bool {
+		return finished[i].EndTime.Before(finished[j].EndTime)
+	})
+
+	durations := make([]time.Duration, len(finished))
+	for i, exec := range finished {
+		durations[i] = exec.Duration
+	}
+	return durations
+}
+
 // CurrentItem returns the currently executing item
 func (q *Queue) CurrentItem() *QueueItem {
 	if q.Current >= 0 && q.Current < len(q.Items) {
@@ -185,6 +216,76 @@ func (q *Queue) NextPending() *QueueItem {
 	return nil
 }
 
+// NextPendingIndex returns the index of the next pending item to run, or -1
+// if none is pending. Items waiting on dependencies are skipped. With interleave set, epics take turns: the item comes
+// from the first epic after the current item's epic, in order of first
+// appearance in the queue, that still has pending work. Otherwise items run
+// in queue order.
+func (q *Queue) NextPendingIndex(interleave bool) int {
+	first := -1
+	firstByEpic := make(map[int]int)
+	var epics []int
+	for i, item := range q.Items {
+		if _, seen := firstByEpic[item.Story.Epic]; !seen {
+			firstByEpic[item.Story.Epic] = -1
+			epics = append(epics, item.Story.Epic)
+		}
+		if item.Status != ExecutionPending || len(q.Blockers(item)) > 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		if firstByEpic[item.Story.Epic] < 0 {
+			firstByEpic[item.Story.Epic] = i
+		}
+	}
+
+	current := q.CurrentItem()
+	if !interleave || first < 0 || current == nil {
+		return first
+	}
+
+	start := 0
+	for i, epic := range epics {
+		if epic == current.Story.Epic {
+			start = i + 1
+			break
+		}
+	}
+	for i := 0; i < len(epics); i++ {
+		if idx := firstByEpic[epics[(start+i)%len(epics)]]; idx >= 0 {
+			return idx
+		}
+	}
+	return first
+}
+
+// InterleaveByEpic returns the indices of stories in round-robin order
+// across epics: the first story of each epic, then the second of each, and
+// so on. Epics keep their order of first appearance and stories keep their
+// order within an epic.
+func InterleaveByEpic(stories []Story) []int {
+	var epics []int
+	byEpic := make(map[int][]int)
+	for i, story := range stories {
+		if _, ok := byEpic[story.Epic]; !ok {
+			epics = append(epics, story.Epic)
+		}
+		byEpic[story.Epic] = append(byEpic[story.Epic], i)
+	}
+
+	order := make([]int, 0, len(stories))
+	for round := 0; len(order) < len(stories); round++ {
+		for _, epic := range epics {
+			if round < len(byEpic[epic]) {
+				order = append(order, byEpic[epic][round])
+			}
+		}
+	}
+	return order
+}
+
 // TotalCount returns the total number of items
 func (q *Queue) TotalCount() int {
 	return len(q.Items)
@@ -223,13 +324,24 @@ func (q *Queue) FailedCount() int {
 	return count
 }
 
+// ConflictCount returns the number of items parked with merge conflicts
+func (q *Queue) ConflictCount() int {
+	count := 0
+	for _, item := range q.Items {
+		if item.Status == ExecutionConflict {
+			count++
+		}
+	}
+	return count
+}
+
 // ProgressPercent returns overall queue progress as percentage
 func (q *Queue) ProgressPercent() float64 {
 	if len(q.Items) == 0 {
 		return 0
 	}
 
-	completed := q.CompletedCount() + q.FailedCount()
+	completed := q.CompletedCount() + q.FailedCount() + q.ConflictCount()
 
 	// Add partial progress from current item
 	currentProgress := 0.0
@@ -274,14 +386,126 @@ func (q *Queue) EstimatedTimeRemaining() time.Duration {
 	return remaining
 }
 
-// UpdateStepAverage updates the average duration for a step
+// UpdateStepAverage folds a step's actual duration into its estimate
 func (q *Queue) UpdateStepAverage(step StepName, duration time.Duration) {
-	if existing, ok := q.StepAverages[step]; ok {
-		// Simple moving average
-		q.StepAverages[step] = (existing + duration) / 2
-	} else {
-		q.StepAverages[step] = duration
+	if q.StepEstimates == nil {
+		q.StepEstimates = make(map[StepName]Estimate)
+	}
+	if q.StepAverages == nil {
+		q.StepAverages = make(map[StepName]time.Duration)
+	}
+
+	est, ok := q.StepEstimates[step]
+	if !ok {
+		if avg, ok := q.StepAverages[step]; ok {
+			est = Estimate{Mean: avg, Samples: 1}
+		}
 	}
+	est = est.Observe(duration)
+	q.StepEstimates[step] = est
+	q.StepAverages[step] = est.Mean
+}
+
+// SetStepEstimates replaces the step estimates, e.g. with ones built from history
+func (q *Queue) SetStepEstimates(estimates map[StepName]Estimate) {
+	q.StepEstimates = make(map[StepName]Estimate, len(estimates))
+	q.StepAverages = make(map[StepName]time.Duration, len(estimates))
+	for step, est := range estimates {
+		q.StepEstimates[step] = est
+		q.StepAverages[step] = est.Mean
+	}
+}
+
+// Predict records the current estimates on an execution that is about to
+// start, so they can later be compared with the actual durations
+func (q *Queue) Predict(exec *Execution) {
+	exec.Predicted = 0
+	for _, step := range exec.Steps {
+		step.Predicted = q.StepAverages[step.Name]
+		exec.Predicted += step.Predicted
+	}
+}
+
+// EstimatedTimeSpread returns the standard deviation of EstimatedTimeRemaining,
+// treating the pending stories as independent. It is 0 without history.
+func (q *Queue) EstimatedTimeSpread() time.Duration {
+	var variance float64
+	for _, stepName := range AllSteps() {
+		variance += q.StepEstimates[stepName].Variance
+	}
+	variance *= float64(q.PendingCount())
+	return Estimate{Variance: variance}.StdDev()
+}
+
+// defaultStepEstimate is assumed for each step until there is history
+const defaultStepEstimate = 5 * time.Minute
+
+// ItemEstimate is the projected timing of one queue item, relative to now
+type ItemEstimate struct {
+	Story    Story
+	Status   ExecutionStatus
+	Proposed bool          // Not queued; estimated as if added to the end
+	Duration time.Duration // Expected time the story still needs
+	Spread   time.Duration // Standard deviation of Duration
+	Start    time.Duration // Expected wait until the story starts
+	Finish   time.Duration // Expected wait until it finishes
+	Deadline time.Time     // Zero when the item has none
+}
+
+// StoryEstimate returns the expected duration of one story's full run and
+// its standard deviation, from the step estimates
+func (q *Queue) StoryEstimate() (time.Duration, time.Duration) {
+	if len(q.StepAverages) == 0 {
+		return time.Duration(len(AllSteps())) * defaultStepEstimate, 0
+	}
+
+	var total time.Duration
+	var variance float64
+	for _, stepName := range AllSteps() {
+		total += q.StepAverages[stepName]
+		variance += q.StepEstimates[stepName].Variance
+	}
+	return total, Estimate{Variance: variance}.StdDev()
+}
+
+// EstimateItems projects when the running item and each pending item will
+// start and finish, in queue order, followed by the proposed stories as if
+// they were added to the end of the queue. Proposed stories already in the
+// queue are not counted twice.
+func (q *Queue) EstimateItems(proposed []Story) []ItemEstimate {
+	perStory, spread := q.StoryEstimate()
+
+	var estimates []ItemEstimate
+	var clock time.Duration
+	add := func(est ItemEstimate) {
+		est.Start = clock
+		clock += est.Duration
+		est.Finish = clock
+		estimates = append(estimates, est)
+	}
+
+	if current := q.CurrentItem(); current != nil && current.Execution != nil && !current.Execution.IsFinished() {
+		remaining := perStory - time.Since(current.Execution.StartTime)
+		add(ItemEstimate{Story: current.Story, Status: current.Status, Duration: max(remaining, 0), Spread: spread,
+			Deadline: q.DeadlineOf(current)})
+	}
+	for _, item := range q.Items {
+		if item.Status == ExecutionPending {
+			add(ItemEstimate{Story: item.Story, Status: item.Status, Duration: perStory, Spread: spread,
+				Deadline: q.DeadlineOf(item)})
+		}
+	}
+	seen := make(map[string]bool, len(proposed))
+	for _, story := range proposed {
+		if seen[story.Key] || q.Contains(story.Key) {
+			continue
+		}
+		seen[story.Key] = true
+		add(ItemEstimate{Story: story, Status: ExecutionPending, Proposed: true, Duration: perStory, Spread: spread,
+			Deadline: q.Deadline})
+	}
+
+	return estimates
 }
 
 // IsEmpty returns true if queue has no items
@@ -551,6 +551,52 @@ func TestQueue_NextPending(t *testing.T) {
 	})
 }
 
+func TestQueue_NextPendingIndex(t *testing.T) {
+	newQueue := func() *Queue {
+		q := NewQueue()
+		for _, key := range []string{"1-1-a", "1-2-b", "1-3-c", "2-1-d", "3-1-e"} {
+			q.Add(createTestStory(key, StatusReadyForDev))
+		}
+		return q
+	}
+
+	// run simulates the batch executor picking and finishing items
+	run := func(q *Queue, interleave bool) []string {
+		var keys []string
+		for idx := q.NextPendingIndex(interleave); idx >= 0; idx = q.NextPendingIndex(interleave) {
+			q.Current = idx
+			q.Items[idx].Status = ExecutionCompleted
+			keys = append(keys, q.Items[idx].Story.Key)
+		}
+		return keys
+	}
+
+	t.Run("queue order", func(t *testing.T) {
+		assert.Equal(t, []string{"1-1-a", "1-2-b", "1-3-c", "2-1-d", "3-1-e"}, run(newQueue(), false))
+	})
+
+	t.Run("epics take turns", func(t *testing.T) {
+		assert.Equal(t, []string{"1-1-a", "2-1-d", "3-1-e", "1-2-b", "1-3-c"}, run(newQueue(), true))
+	})
+
+	t.Run("continues after the current epic", func(t *testing.T) {
+		q := newQueue()
+		q.Items[3].Status = ExecutionCompleted
+		q.Current = 3 // 2-1-d just finished
+		assert.Equal(t, 4, q.NextPendingIndex(true))
+	})
+
+	t.Run("returns -1 when nothing is pending", func(t *testing.T) {
+		assert.Equal(t, -1, NewQueue().NextPendingIndex(true))
+	})
+}
+
+func TestInterleaveByEpic(t *testing.T) {
+	stories := []Story{{Epic: 1}, {Epic: 1}, {Epic: 2}, {Epic: 1}, {Epic: 3}, {Epic: 2}}
+	assert.Equal(t, []int{0, 2, 4, 1, 5, 3}, InterleaveByEpic(stories))
+	assert.Empty(t, InterleaveByEpic(nil))
+}
+
 func TestQueue_ProgressPercent(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -662,6 +708,52 @@ func TestQueue_EstimatedTimeRemaining(t *testing.T) {
 	})
 }
 
+func TestQueue_EstimateItems(t *testing.T) {
+	q := NewQueue()
+	q.Add(createTestStory("3-1-test", StatusInProgress))
+	q.Add(createTestStory("3-2-test", StatusReadyForDev))
+	q.Add(createTestStory("3-3-test", StatusReadyForDev))
+	for _, step := range AllSteps() {
+		q.StepAverages[step] = time.Minute
+	}
+
+	// The first story is done and the second has been running for a minute
+	q.Items[0].Status = ExecutionCompleted
+	q.Items[1].Status = ExecutionRunning
+	q.Items[1].Execution = &Execution{Status: ExecutionRunning, StartTime: time.Now().Add(-time.Minute)}
+	q.Current = 1
+
+	proposed := []Story{createTestStory("3-3-test", StatusReadyForDev), createTestStory("4-1-test", StatusBacklog)}
+	items := q.EstimateItems(proposed)
+
+	require.Len(t, items, 3)
+	assert.Equal(t, "3-2-test", items[0].Story.Key)
+	assert.InDelta(t, (3 * time.Minute).Seconds(), items[0].Duration.Seconds(), 1)
+	assert.Equal(t, "3-3-test", items[1].Story.Key)
+	assert.False(t, items[1].Proposed)
+	assert.Equal(t, items[0].Finish, items[1].Start)
+	assert.Equal(t, 4*time.Minute, items[1].Duration)
+	assert.Equal(t, "4-1-test", items[2].Story.Key, "queued stories are not proposed twice")
+	assert.True(t, items[2].Proposed)
+	assert.Equal(t, items[1].Finish+4*time.Minute, items[2].Finish)
+}
+
+func TestQueue_StoryEstimate(t *testing.T) {
+	q := NewQueue()
+	mean, spread := q.StoryEstimate()
+	assert.Equal(t, 20*time.Minute, mean, "5 minutes per step without history")
+	assert.Zero(t, spread)
+
+	q.SetStepEstimates(map[StepName]Estimate{
+		StepDevStory:    {Mean: 3 * time.Minute, Variance: 9, Samples: 4},
+		StepCodeReview:  {Mean: time.Minute, Variance: 16, Samples: 4},
+		StepCreateStory: {Mean: time.Minute, Samples: 1},
+	})
+	mean, spread = q.StoryEstimate()
+	assert.Equal(t, 5*time.Minute, mean)
+	assert.Equal(t, 5*time.Second, spread)
+}
+
 func TestQueue_UpdateStepAverage(t *testing.T) {
 	t.Run("sets first value", func(t *testing.T) {
 		q := NewQueue()
@@ -670,14 +762,14 @@ func TestQueue_UpdateStepAverage(t *testing.T) {
 		assert.Equal(t, 10*time.Second, q.StepAverages[StepCreateStory])
 	})
 
-	t.Run("calculates moving average", func(t *testing.T) {
+	t.Run("calculates weighted average", func(t *testing.T) {
 		q := NewQueue()
 		q.StepAverages[StepCreateStory] = 10 * time.Second
 
 		q.UpdateStepAverage(StepCreateStory, 20*time.Second)
 
-		// (10 + 20) / 2 = 15 seconds
-		assert.Equal(t, 15*time.Second, q.StepAverages[StepCreateStory])
+		// 10 + 0.3 * (20 - 10) = 13 seconds
+		assert.Equal(t, 13*time.Second, q.StepAverages[StepCreateStory])
 	})
 
 	t.Run("handles multiple updates", func(t *testing.T) {
@@ -687,9 +779,9 @@ func TestQueue_UpdateStepAverage(t *testing.T) {
 		q.UpdateStepAverage(StepCreateStory, 20*time.Second)
 		q.UpdateStepAverage(StepCreateStory, 30*time.Second)
 
-		// (10+20)/2 = 15, then (15+30)/2 = 22.5 -> truncated to 22s
-		expected := (15*time.Second + 30*time.Second) / 2
-		assert.Equal(t, expected, q.StepAverages[StepCreateStory])
+		// 10 -> 13, then 13 + 0.3 * (30 - 13) = 18.1 seconds
+		assert.Equal(t, 18100*time.Millisecond, q.StepAverages[StepCreateStory])
+		assert.Equal(t, 3, q.StepEstimates[StepCreateStory].Samples)
 	})
 }
 
@@ -738,14 +830,16 @@ func TestQueue_CountMethods(t *testing.T) {
 	q.Add(createTestStory("3-2-completed", StatusInProgress))
 	q.Add(createTestStory("3-3-failed", StatusInProgress))
 	q.Add(createTestStory("3-4-running", StatusInProgress))
+	q.Add(createTestStory("3-5-conflict", StatusInProgress))
 
 	q.Items[0].Status = ExecutionPending
 	q.Items[1].Status = ExecutionCompleted
 	q.Items[2].Status = ExecutionFailed
 	q.Items[3].Status = ExecutionRunning
+	q.Items[4].Status = ExecutionConflict
 
 	t.Run("TotalCount", func(t *testing.T) {
-		assert.Equal(t, 4, q.TotalCount())
+		assert.Equal(t, 5, q.TotalCount())
 	})
 
 	t.Run("PendingCount", func(t *testing.T) {
@@ -759,6 +853,10 @@ func TestQueue_CountMethods(t *testing.T) {
 	t.Run("FailedCount", func(t *testing.T) {
 		assert.Equal(t, 1, q.FailedCount())
 	})
+
+	t.Run("ConflictCount", func(t *testing.T) {
+		assert.Equal(t, 1, q.ConflictCount())
+	})
 }
 
 func TestQueue_IsEmpty(t *testing.T) {
@@ -868,3 +966,51 @@ func TestQueueStatus_Constants(t *testing.T) {
 		})
 	}
 }
+
+func TestQueue_PredictAndSpread(t *testing.T) {
+	q := NewQueue()
+	q.SetStepEstimates(map[StepName]Estimate{
+		StepDevStory:   {Mean: 10 * time.Minute, Variance: 3600, Samples: 5},
+		StepCodeReview: {Mean: 5 * time.Minute, Samples: 5},
+	})
+	q.Add(createTestStory("1-1-a", StatusReadyForDev))
+	q.Add(createTestStory("1-2-b", StatusReadyForDev))
+	q.Add(createTestStory("1-3-c", StatusReadyForDev))
+	q.Add(createTestStory("1-4-d", StatusReadyForDev))
+
+	exec := NewExecution(q.Items[0].Story)
+	q.Predict(exec)
+
+	assert.Equal(t, 15*time.Minute, exec.Predicted)
+	for _, step := range exec.Steps {
+		assert.Equal(t, q.StepAverages[step.Name], step.Predicted)
+	}
+
+	// Four pending stories, each with a one minute standard deviation
+	assert.Equal(t, 2*time.Minute, q.EstimatedTimeSpread())
+}
+
+func TestQueue_FinishedDurations(t *testing.T) {
+	q := NewQueue()
+	for _, key := range []string{"1-1-a", "1-2-b", "1-3-c", "1-4-d"} {
+		q.Add(createTestStory(key, StatusReadyForDev))
+	}
+	base := time.Now()
+	finish := func(i int, status ExecutionStatus, d time.Duration, end time.Duration) {
+		exec := NewExecution(q.Items[i].Story)
+		exec.Duration = d
+		exec.EndTime = base.Add(end)
+		q.Items[i].Status = status
+		q.Items[i].Execution = exec
+	}
+
+	assert.Empty(t, q.FinishedDurations())
+
+	// Item 1 finished before item 0; item 3 is still running
+	finish(0, ExecutionCompleted, 3*time.Minute, 2*time.Minute)
+	finish(1, ExecutionFailed, time.Minute, time.Minute)
+	finish(2, ExecutionConflict, 5*time.Minute, 3*time.Minute)
+	finish(3, ExecutionRunning, 4*time.Minute, 0)
+
+	assert.Equal(t, []time.Duration{time.Minute, 3 * time.Minute, 5 * time.Minute}, q.FinishedDurations())
+}
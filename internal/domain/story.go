@@ -19,6 +19,10 @@ type Story struct {
 	Title      string
 	FilePath   string
 	FileExists bool
+
+	// DependsOn lists the stories that must complete before this one
+	// runs. Stories already done when it was loaded are left out.
+	DependsOn []string
 }
 
 // IsActionable returns true if the story can be processed
@@ -36,6 +40,10 @@ const (
 	StepDevStory    StepName = "dev-story"
 	StepCodeReview  StepName = "code-review"
 	StepGitCommit   StepName = "git-commit"
+
+	// StepResolveConflicts is not part of the regular workflow. It is appended
+	// to an execution when the git-commit step leaves merge conflicts behind.
+	StepResolveConflicts StepName = "resolve-conflicts"
 )
 
 // AllSteps returns all workflow steps in order
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ParseTags splits text on commas and spaces into tags, lower-cased, in
+// order and without duplicates
+func ParseTags(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	var tags []string
+	for _, f := range fields {
+		tag := strings.ToLower(f)
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// SetTags replaces the tags of the queued story with key, reporting
+// whether it is in the queue. Tags only reach executions that have not
+// started yet.
+func (q *Queue) SetTags(key string, tags []string) bool {
+	for _, item := range q.Items {
+		if item.Story.Key == key {
+			item.Tags = tags
+			return true
+		}
+	}
+	return false
+}
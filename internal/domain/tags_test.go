@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []string{"hotfix", "sprint-12"}, ParseTags("Hotfix, sprint-12  hotfix,"))
+	assert.Nil(t, ParseTags(" , "))
+}
+
+func TestQueue_SetTags(t *testing.T) {
+	q := NewQueue()
+	q.Add(Story{Key: "1-1-a"})
+
+	assert.True(t, q.SetTags("1-1-a", []string{"hotfix"}))
+	assert.Equal(t, []string{"hotfix"}, q.Items[0].Tags)
+	assert.False(t, q.SetTags("9-9-missing", []string{"hotfix"}))
+}
@@ -0,0 +1,49 @@
+package domain
+
+// Usage is the token usage and cost of Claude CLI calls
+type Usage struct {
+	InputTokens      int64
+	OutputTokens     int64
+	CacheReadTokens  int64
+	CacheWriteTokens int64
+	CostUSD          float64 // As reported by the CLI, or estimated from Pricing
+}
+
+// Add adds other to the usage
+func (u *Usage) Add(other Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CacheWriteTokens += other.CacheWriteTokens
+	u.CostUSD += other.CostUSD
+}
+
+// Tokens returns every token counted, cached ones included
+func (u Usage) Tokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheReadTokens + u.CacheWriteTokens
+}
+
+// IsZero reports whether nothing was recorded
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
+
+// Cache writes and reads are billed relative to the input price
+const (
+	cacheWriteRate = 1.25
+	cacheReadRate  = 0.1
+)
+
+// Pricing is the price of tokens in US dollars per million
+type Pricing struct {
+	InputPerMTok  float64
+	OutputPerMTok float64
+}
+
+// Cost estimates what the usage costs at these prices
+func (p Pricing) Cost(u Usage) float64 {
+	input := float64(u.InputTokens) +
+		cacheWriteRate*float64(u.CacheWriteTokens) +
+		cacheReadRate*float64(u.CacheReadTokens)
+	return (input*p.InputPerMTok + float64(u.OutputTokens)*p.OutputPerMTok) / 1e6
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage_Add(t *testing.T) {
+	var u Usage
+	assert.True(t, u.IsZero())
+
+	u.Add(Usage{InputTokens: 10, OutputTokens: 20, CacheReadTokens: 30, CacheWriteTokens: 40, CostUSD: 0.5})
+	u.Add(Usage{InputTokens: 1, OutputTokens: 2, CostUSD: 0.25})
+	assert.Equal(t, Usage{InputTokens: 11, OutputTokens: 22, CacheReadTokens: 30, CacheWriteTokens: 40, CostUSD: 0.75}, u)
+	assert.Equal(t, int64(103), u.Tokens())
+	assert.False(t, u.IsZero())
+}
+
+func TestPricing_Cost(t *testing.T) {
+	p := Pricing{InputPerMTok: 3, OutputPerMTok: 15}
+	assert.InDelta(t, 18.0, p.Cost(Usage{InputTokens: 1e6, OutputTokens: 1e6}), 1e-9)
+	assert.InDelta(t, 3.75, p.Cost(Usage{CacheWriteTokens: 1e6}), 1e-9)
+	assert.InDelta(t, 0.3, p.Cost(Usage{CacheReadTokens: 1e6}), 1e-9)
+	assert.Zero(t, p.Cost(Usage{}))
+}
+
+func TestExecution_Usage(t *testing.T) {
+	exec := NewExecution(Story{Key: "1-1-test"})
+	exec.Steps[0].Usage = Usage{InputTokens: 100, CostUSD: 0.1}
+	exec.Steps[1].Usage = Usage{OutputTokens: 50, CostUSD: 0.2}
+
+	total := exec.Usage()
+	assert.Equal(t, int64(100), total.InputTokens)
+	assert.Equal(t, int64(50), total.OutputTokens)
+	assert.InDelta(t, 0.3, total.CostUSD, 1e-9)
+}
@@ -13,6 +13,11 @@ const (
 	ViewHistory
 	ViewStats
 	ViewSettings
+	ViewPipelines
+	ViewLogs
+	ViewSchedules
+	ViewSearch
+	ViewEpics
 )
 
 // String returns the display name of the view
@@ -36,6 +41,16 @@ func (v View) String() string {
 		return "Statistics"
 	case ViewSettings:
 		return "Settings"
+	case ViewPipelines:
+		return "Pipelines"
+	case ViewLogs:
+		return "Logs"
+	case ViewSchedules:
+		return "Schedules"
+	case ViewSearch:
+		return "Search"
+	case ViewEpics:
+		return "Epics"
 	default:
 		return "Unknown"
 	}
@@ -12,12 +12,15 @@ import (
 	"github.com/robertguss/bmad-automate-go/internal/config"
 	"github.com/robertguss/bmad-automate-go/internal/domain"
 	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/schedule"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
 )
 
 // BatchExecutor manages sequential execution of multiple stories
 type BatchExecutor struct {
 	config  *config.Config
 	program *tea.Program
+	handler func(tea.Msg)
 	queue   *domain.Queue
 
 	// Pause/resume/cancel control (QUAL-003: shared utility)
@@ -31,16 +34,28 @@ type BatchExecutor struct {
 
 	// Child executor for individual stories
 	executor *Executor
+
+	// Shared step engine
+	engine *stepEngine
+
+	// When stories may start, if the run pauses for its windows
+	windows schedule.Windows
 }
 
 // NewBatchExecutor creates a new BatchExecutor
 func NewBatchExecutor(cfg *config.Config) *BatchExecutor {
-	return &BatchExecutor{
+	b := &BatchExecutor{
 		config:    cfg,
 		queue:     domain.NewQueue(),
 		pauseCtrl: NewPauseController(),
 		executor:  New(cfg),
 	}
+	b.engine = newStepEngine(cfg, b.sendMsg, &b.executor.mu)
+	if cfg.RunWindowPause {
+		// An invalid spec is reported by the app; the run is then unbounded
+		b.windows, _ = schedule.ParseWindows(cfg.RunWindows)
+	}
+	return b
 }
 
 // SetProgram sets the tea.Program for sending messages
@@ -49,6 +64,19 @@ func (b *BatchExecutor) SetProgram(p *tea.Program) {
 	b.executor.SetProgram(p)
 }
 
+// SetMessageHandler routes progress messages to fn instead of a
+// tea.Program, for running without the TUI
+func (b *BatchExecutor) SetMessageHandler(fn func(tea.Msg)) {
+	b.handler = fn
+	b.executor.SetMessageHandler(fn)
+}
+
+// SetWorkflow sets the workflow whose step types decide how steps run
+func (b *BatchExecutor) SetWorkflow(w *workflow.Workflow) {
+	b.engine.setWorkflow(w)
+	b.executor.SetWorkflow(w)
+}
+
 // GetQueue returns the current queue
 func (b *BatchExecutor) GetQueue() *domain.Queue {
 	b.mu.Lock()
@@ -67,12 +95,20 @@ func (b *BatchExecutor) SetQueue(q *domain.Queue) {
 func (b *BatchExecutor) AddToQueue(stories []domain.Story) {
 	b.mu.Lock()
 	b.queue.AddMultiple(stories)
+	b.queue.OrderByDependencies()
 	b.mu.Unlock()
 	// Don't send message here - caller updates UI directly
 	// Sending here would deadlock since tea.Program.Send blocks
 	// while the program is still in Update processing the keypress
 }
 
+// SetTags replaces the tags of a queued story
+func (b *BatchExecutor) SetTags(key string, tags []string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.queue.SetTags(key, tags)
+}
+
 // RemoveFromQueue removes a story from the queue
 func (b *BatchExecutor) RemoveFromQueue(key string) bool {
 	b.mu.Lock()
@@ -158,17 +194,19 @@ func (b *BatchExecutor) Start() tea.Cmd {
 
 			// Find next pending item
 			b.mu.Lock()
-			var nextItem *domain.QueueItem
-			var nextIndex int = -1
-			for i, item := range b.queue.Items {
-				if item.Status == domain.ExecutionPending {
-					nextItem = item
-					nextIndex = i
-					break
-				}
+			if b.config.QueueOrder == config.QueueOrderDeadline {
+				b.queue.OrderByDeadline()
 			}
+			nextIndex := b.queue.NextPendingIndex(b.config.QueueOrder == config.QueueOrderRoundRobin)
+			nextItem := b.queue.GetItem(nextIndex)
 
 			if nextItem == nil {
+				// Whatever is still pending waits on a story that failed
+				// or is not queued, or on itself through a cycle
+				b.mu.Unlock()
+				b.failBlocked()
+				b.mu.Lock()
+
 				// No more pending items
 				b.queue.Status = domain.QueueCompleted
 				b.queue.EndTime = time.Now()
@@ -180,6 +218,14 @@ func (b *BatchExecutor) Start() tea.Cmd {
 			b.queue.Current = nextIndex
 			b.mu.Unlock()
 
+			// Pause for the run window. The story after the wait runs even
+			// if it is expected to outlast the window, so a story longer
+			// than every window still runs.
+			if resumeAt, pause := b.windowPause(time.Now()); pause {
+				b.Pause()
+				b.sendMsg(messages.QueueWindowPausedMsg{ResumeAt: resumeAt})
+			}
+
 			// Wait if paused (QUAL-003: using shared utility)
 			b.pauseCtrl.WaitIfPaused(nil)
 
@@ -205,21 +251,78 @@ func (b *BatchExecutor) Start() tea.Cmd {
 			TotalItems:    queue.TotalCount(),
 			SuccessCount:  queue.CompletedCount(),
 			FailedCount:   queue.FailedCount(),
+			ConflictCount: queue.ConflictCount(),
 			TotalDuration: time.Since(queue.StartTime),
 		}
 	}
 }
 
+// failBlocked fails the pending items, none of which can run, with the
+// reason each is blocked
+func (b *BatchExecutor) failBlocked() {
+	var failed []messages.QueueItemCompletedMsg
+	b.mu.Lock()
+	for i, item := range b.queue.Items {
+		if item.Status != domain.ExecutionPending {
+			continue
+		}
+		execution := b.engine.newExecution(item.Story)
+		execution.Status = domain.ExecutionFailed
+		execution.Error = b.queue.BlockedReason(item)
+		execution.Tags = item.Tags
+		item.Status = domain.ExecutionFailed
+		item.Execution = execution
+		failed = append(failed, messages.QueueItemCompletedMsg{
+			Index:     i,
+			Story:     item.Story,
+			Status:    domain.ExecutionFailed,
+			Error:     execution.Error,
+			Execution: execution,
+		})
+	}
+	b.mu.Unlock()
+
+	for _, msg := range failed {
+		b.sendMsg(msg)
+	}
+}
+
 // executeItem executes a single queue item
 func (b *BatchExecutor) executeItem(index int, item *domain.QueueItem) {
 	// Create execution for this item
-	execution := domain.NewExecution(item.Story)
+	execution := b.engine.newExecution(item.Story)
+
+	// Refuse to run a story that is already executing elsewhere
+	if err := runningStories.acquire(item.Story.Key); err != nil {
+		execution.Status = domain.ExecutionFailed
+		execution.Error = err.Error()
+		b.mu.Lock()
+		item.Status = domain.ExecutionFailed
+		item.Execution = execution
+		b.mu.Unlock()
+		b.sendMsg(messages.QueueItemCompletedMsg{
+			Index:     index,
+			Story:     item.Story,
+			Status:    domain.ExecutionFailed,
+			Error:     execution.Error,
+			Execution: execution,
+		})
+		return
+	}
+	defer runningStories.release(item.Story.Key)
+
 	execution.Status = domain.ExecutionRunning
 	execution.StartTime = time.Now()
+	b.engine.snapshot(execution)
+	b.engine.captureContext(execution)
 
 	b.mu.Lock()
+	b.queue.Predict(execution)
+	execution.Deadline = b.queue.DeadlineOf(item)
+	execution.Tags = item.Tags
 	item.Status = domain.ExecutionRunning
 	item.Execution = execution
+	ctx := b.ctx
 	b.mu.Unlock()
 
 	// Send item started message
@@ -232,48 +335,20 @@ func (b *BatchExecutor) executeItem(index int, item *domain.QueueItem) {
 	// Also send ExecutionStartedMsg for the execution view
 	b.sendMsg(messages.ExecutionStartedMsg{Execution: execution})
 
-	// Execute each step
-	for i, step := range execution.Steps {
-		if b.pauseCtrl.IsCanceled() {
-			execution.Status = domain.ExecutionCancelled
-			break
-		}
-
-		// Wait if paused (QUAL-003: using shared utility)
-		b.pauseCtrl.WaitIfPaused(nil)
-
-		// Check for cancellation after pause
-		if b.pauseCtrl.IsCanceled() {
-			execution.Status = domain.ExecutionCancelled
-			break
-		}
-
-		// Auto-skip create-story if file exists
-		if step.Name == domain.StepCreateStory && item.Story.FileExists {
-			step.Status = domain.StepSkipped
-			b.sendMsg(messages.StepCompletedMsg{
-				StepIndex: i,
-				Status:    domain.StepSkipped,
-			})
-			continue
-		}
-
-		// Execute the step
-		execution.Current = i
-		err := b.executor.executeStep(i, step)
-
-		if err != nil && step.Status == domain.StepFailed {
-			execution.Status = domain.ExecutionFailed
-			execution.Error = err.Error()
-			break
-		}
-
-		// Update step averages for ETA calculation
-		if step.Status == domain.StepSuccess && step.Duration > 0 {
-			b.mu.Lock()
-			b.queue.UpdateStepAverage(step.Name, step.Duration)
-			b.mu.Unlock()
-		}
+	// Execute each step, feeding step averages for ETA calculation
+	controls := runControls{ctx: ctx, pause: b.pauseCtrl, skip: b.executor.skipCh}
+	if restore, err := b.engine.prepareWorkspace(execution); err != nil {
+		execution.Status = domain.ExecutionFailed
+		execution.Error = err.Error()
+	} else {
+		b.engine.runSteps(controls, execution, func(step *domain.StepExecution) {
+			if step.Status == domain.StepSuccess && step.Duration > 0 {
+				b.mu.Lock()
+				b.queue.UpdateStepAverage(step.Name, step.Duration)
+				b.mu.Unlock()
+			}
+		})
+		restore()
 	}
 
 	// Mark completion
@@ -305,6 +380,27 @@ func (b *BatchExecutor) executeItem(index int, item *domain.QueueItem) {
 	})
 }
 
+// windowPause reports whether the queue should pause before its next story
+// because the run window is closed or the story is expected to finish after
+// it closes. It returns when the window next opens.
+func (b *BatchExecutor) windowPause(now time.Time) (time.Time, bool) {
+	if len(b.windows) == 0 {
+		return time.Time{}, false
+	}
+
+	end, open := b.windows.Open(now)
+	if !open {
+		return b.windows.NextOpen(now), true
+	}
+	b.mu.Lock()
+	estimate, _ := b.queue.StoryEstimate()
+	b.mu.Unlock()
+	if now.Add(estimate).After(end) {
+		return b.windows.NextOpen(end), true
+	}
+	return time.Time{}, false
+}
+
 // Pause pauses the batch execution
 func (b *BatchExecutor) Pause() {
 	b.mu.Lock()
@@ -375,8 +471,12 @@ func (b *BatchExecutor) GetExecutor() *Executor {
 	return b.executor
 }
 
-// sendMsg safely sends a message to the tea.Program
+// sendMsg safely sends a message to the handler or tea.Program
 func (b *BatchExecutor) sendMsg(msg tea.Msg) {
+	if b.handler != nil {
+		b.handler(msg)
+		return
+	}
 	if b.program != nil {
 		b.program.Send(msg)
 	}
@@ -4,11 +4,13 @@ import (
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/robertguss/bmad-automate-go/internal/config"
 	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
 )
 
 func TestNewBatchExecutor(t *testing.T) {
@@ -372,3 +374,71 @@ func TestBatchExecutor_QueueStatus(t *testing.T) {
 		assert.Equal(t, domain.QueueRunning, b.queue.Status)
 	})
 }
+
+func TestBatchExecutor_AddToQueueOrdersDependencies(t *testing.T) {
+	b := NewBatchExecutor(&config.Config{})
+	b.AddToQueue([]domain.Story{
+		{Key: "3-2-second", DependsOn: []string{"3-1-first"}},
+		{Key: "3-1-first"},
+	})
+
+	q := b.GetQueue()
+	assert.Equal(t, "3-1-first", q.Items[0].Story.Key)
+	assert.Equal(t, "3-2-second", q.Items[1].Story.Key)
+}
+
+func TestBatchExecutor_FailsBlockedStories(t *testing.T) {
+	b := NewBatchExecutor(&config.Config{})
+	var failed []messages.QueueItemCompletedMsg
+	b.SetMessageHandler(func(msg tea.Msg) {
+		if msg, ok := msg.(messages.QueueItemCompletedMsg); ok {
+			failed = append(failed, msg)
+		}
+	})
+	b.AddToQueue([]domain.Story{
+		{Key: "3-1-a", DependsOn: []string{"3-2-b"}},
+		{Key: "3-2-b", DependsOn: []string{"3-1-a"}},
+		{Key: "3-3-c", DependsOn: []string{"2-1-missing"}},
+	})
+
+	result, ok := b.Start()().(messages.QueueCompletedMsg)
+	require.True(t, ok)
+	assert.Equal(t, 3, result.FailedCount)
+	assert.False(t, b.IsRunning())
+
+	require.Len(t, failed, 3)
+	errs := make(map[string]string)
+	for _, msg := range failed {
+		assert.Equal(t, domain.ExecutionFailed, msg.Status)
+		require.NotNil(t, msg.Execution)
+		errs[msg.Story.Key] = msg.Error
+	}
+	assert.Equal(t, "dependency cycle: 3-1-a -> 3-2-b -> 3-1-a", errs["3-1-a"])
+	assert.Equal(t, "depends on 2-1-missing, which did not complete", errs["3-3-c"])
+}
+
+func TestBatchExecutor_WindowPause(t *testing.T) {
+	b := NewBatchExecutor(&config.Config{RunWindows: "22:00-06:00", RunWindowPause: true})
+	require.Len(t, b.windows, 1)
+	b.queue.StepAverages = map[domain.StepName]time.Duration{domain.StepDevStory: time.Hour}
+
+	day := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	opens := day.Add(22 * time.Hour)
+
+	_, pause := b.windowPause(day.Add(23 * time.Hour))
+	assert.False(t, pause, "the story fits before 06:00")
+
+	resumeAt, pause := b.windowPause(day.Add(29*time.Hour + 30*time.Minute))
+	assert.True(t, pause, "the story would run past 06:00")
+	assert.Equal(t, opens.AddDate(0, 0, 1), resumeAt)
+
+	resumeAt, pause = b.windowPause(day.Add(12 * time.Hour))
+	assert.True(t, pause, "the window is closed")
+	assert.Equal(t, opens, resumeAt)
+
+	t.Run("without pausing the run is unbounded", func(t *testing.T) {
+		b := NewBatchExecutor(&config.Config{RunWindows: "22:00-06:00"})
+		_, pause := b.windowPause(day.Add(12 * time.Hour))
+		assert.False(t, pause)
+	})
+}
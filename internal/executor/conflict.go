@@ -0,0 +1,65 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/git"
+)
+
+// conflictOutcome describes how a git step's merge conflicts were handled
+type conflictOutcome int
+
+const (
+	conflictNone     conflictOutcome = iota // No conflicts detected
+	conflictResolved                        // The resolve-conflicts step cleaned them up
+	conflictParked                          // Conflicts remain and the story is parked
+)
+
+// stepRunner runs one step of an execution with retry logic
+type stepRunner func(index int, step *domain.StepExecution) error
+
+// handleConflicts checks the working tree after the git-commit step. When
+// conflicts are found it either runs a resolve-conflicts step or parks the
+// execution with ExecutionConflict, depending on ConflictStrategy.
+func handleConflicts(cfg *config.Config, execution *domain.Execution, step *domain.StepExecution, run stepRunner) conflictOutcome {
+	files := git.ConflictedFiles(cfg.WorkingDir)
+	// Output is only trusted for failed steps; a successful agent may
+	// mention conflicts it already resolved
+	outputConflict := step.Status == domain.StepFailed && git.OutputIndicatesConflict(step.Output)
+	if len(files) == 0 && !outputConflict {
+		return conflictNone
+	}
+
+	if cfg.ConflictStrategy != config.ConflictPark {
+		resolve := &domain.StepExecution{
+			Name:   domain.StepResolveConflicts,
+			Status: domain.StepPending,
+			Output: make([]string, 0),
+		}
+		execution.Steps = append(execution.Steps, resolve)
+		index := len(execution.Steps) - 1
+		execution.Current = index
+
+		if err := run(index, resolve); err == nil {
+			files = git.ConflictedFiles(cfg.WorkingDir)
+			if len(files) == 0 {
+				return conflictResolved
+			}
+		}
+	}
+
+	execution.Status = domain.ExecutionConflict
+	execution.Error = conflictError(files)
+	return conflictParked
+}
+
+// conflictError describes the files left in conflict
+func conflictError(files []string) string {
+	if len(files) == 0 {
+		return "merge conflict"
+	}
+	return fmt.Sprintf("merge conflict in: %s", strings.Join(files, ", "))
+}
@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestHandleConflicts(t *testing.T) {
+	conflictedStep := func() *domain.StepExecution {
+		return &domain.StepExecution{
+			Name:   domain.StepGitCommit,
+			Status: domain.StepFailed,
+			Output: []string{"CONFLICT (content): Merge conflict in main.go"},
+		}
+	}
+	newConfig := func(strategy string) *config.Config {
+		cfg := createTestConfig()
+		cfg.WorkingDir = t.TempDir() // not a git repo, so no unmerged paths
+		cfg.ConflictStrategy = strategy
+		return cfg
+	}
+
+	t.Run("no conflict leaves execution untouched", func(t *testing.T) {
+		execution := domain.NewExecution(createTestStory())
+		step := &domain.StepExecution{Name: domain.StepGitCommit, Status: domain.StepSuccess}
+
+		outcome := handleConflicts(newConfig(config.ConflictResolve), execution, step, func(int, *domain.StepExecution) error {
+			t.Fatal("resolve step should not run")
+			return nil
+		})
+
+		assert.Equal(t, conflictNone, outcome)
+		assert.Len(t, execution.Steps, len(domain.AllSteps()))
+	})
+
+	t.Run("successful step output is not treated as a conflict", func(t *testing.T) {
+		execution := domain.NewExecution(createTestStory())
+		step := conflictedStep()
+		step.Status = domain.StepSuccess
+
+		outcome := handleConflicts(newConfig(config.ConflictPark), execution, step, nil)
+
+		assert.Equal(t, conflictNone, outcome)
+	})
+
+	t.Run("park strategy marks execution as conflicted", func(t *testing.T) {
+		execution := domain.NewExecution(createTestStory())
+		execution.Status = domain.ExecutionRunning
+
+		outcome := handleConflicts(newConfig(config.ConflictPark), execution, conflictedStep(), nil)
+
+		assert.Equal(t, conflictParked, outcome)
+		assert.Equal(t, domain.ExecutionConflict, execution.Status)
+		assert.Equal(t, "merge conflict", execution.Error)
+	})
+
+	t.Run("resolve strategy runs resolve-conflicts step", func(t *testing.T) {
+		execution := domain.NewExecution(createTestStory())
+		var ranIndex int
+		var ranStep *domain.StepExecution
+
+		outcome := handleConflicts(newConfig(config.ConflictResolve), execution, conflictedStep(), func(index int, step *domain.StepExecution) error {
+			ranIndex = index
+			ranStep = step
+			return nil
+		})
+
+		assert.Equal(t, conflictResolved, outcome)
+		assert.Equal(t, len(domain.AllSteps()), ranIndex)
+		assert.Equal(t, domain.StepResolveConflicts, ranStep.Name)
+		assert.Equal(t, ranStep, execution.Steps[ranIndex])
+		assert.Equal(t, ranIndex, execution.Current)
+	})
+
+	t.Run("failed resolution parks the story", func(t *testing.T) {
+		execution := domain.NewExecution(createTestStory())
+
+		outcome := handleConflicts(newConfig(config.ConflictResolve), execution, conflictedStep(), func(int, *domain.StepExecution) error {
+			return errors.New("agent gave up")
+		})
+
+		assert.Equal(t, conflictParked, outcome)
+		assert.Equal(t, domain.ExecutionConflict, execution.Status)
+	})
+}
+
+func TestConflictError(t *testing.T) {
+	assert.Equal(t, "merge conflict", conflictError(nil))
+	assert.Equal(t, "merge conflict in: a.go, b.go", conflictError([]string{"a.go", "b.go"}))
+}
@@ -0,0 +1,732 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/git"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+// ErrStalled is returned when a step is killed after producing no output
+// for the configured stall timeout
+var ErrStalled = errors.New("step stalled")
+
+// stepEngine runs workflow steps for all three executors: the step loop,
+// retries, timeouts, stall detection, output streaming and merge conflict
+// handling. Executor, BatchExecutor and ParallelExecutor only differ in how
+// they pick stories and report progress.
+type stepEngine struct {
+	config *config.Config
+	send   func(tea.Msg)
+	mu     *sync.Mutex // Guards step output while it is streamed
+
+	// tagRetries prefixes retry notices with the story key, for executors
+	// that interleave output from several stories
+	tagRetries bool
+
+	// workflow holds the step definitions used to pick each step's runner.
+	// Without one every step runs as a built-in agent prompt.
+	workflow atomic.Pointer[workflow.Workflow]
+}
+
+// newStepEngine creates a step engine. mu guards step output and may be
+// shared with the owning executor.
+func newStepEngine(cfg *config.Config, send func(tea.Msg), mu *sync.Mutex) *stepEngine {
+	return &stepEngine{
+		config: cfg,
+		send:   send,
+		mu:     mu,
+	}
+}
+
+// setWorkflow sets the workflow whose step definitions the engine uses
+func (en *stepEngine) setWorkflow(w *workflow.Workflow) {
+	en.workflow.Store(w)
+}
+
+// forJob returns an engine for one of several executions running side by
+// side. It shares the configuration, workflow and message sink but guards
+// output with its own lock, so jobs never wait on or write under another
+// job's lock.
+func (en *stepEngine) forJob() *stepEngine {
+	job := newStepEngine(en.config, en.send, &sync.Mutex{})
+	job.tagRetries = en.tagRetries
+	job.workflow.Store(en.workflow.Load())
+	return job
+}
+
+// stepRef returns the reference step messages of execution carry
+func stepRef(execution *domain.Execution) messages.StepRef {
+	return messages.StepRef{ExecutionID: execution.ID, StoryKey: execution.Story.Key}
+}
+
+// snapshot records the working tree before an execution starts, when
+// enabled. Outside a git repository the execution runs without one.
+func (en *stepEngine) snapshot(execution *domain.Execution) {
+	if !en.config.WorkspaceSnapshots {
+		return
+	}
+	if snap, err := git.CreateSnapshot(en.config.WorkingDir, execution.ID); err == nil {
+		execution.Snapshot = snap
+	}
+}
+
+// checkoutStoryBranch switches to the story's own branch before its steps
+// run, when branch-per-story is enabled, and records it on the execution.
+// The returned function switches back to the branch the run started on.
+func (en *stepEngine) checkoutStoryBranch(execution *domain.Execution) (func(), error) {
+	if !en.config.StoryBranches {
+		return func() {}, nil
+	}
+	branch := en.config.StoryBranch(execution.Story.Key)
+	base, err := git.CheckoutStoryBranch(en.config.WorkingDir, branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check out %s: %w", branch, err)
+	}
+	execution.Branch = branch
+
+	return func() {
+		if base == branch {
+			return
+		}
+		if err := git.Checkout(en.config.WorkingDir, base); err != nil {
+			en.send(messages.ErrorMsg{Error: fmt.Errorf("failed to switch back to %s: %w", base, err)})
+		}
+	}, nil
+}
+
+// stashChanges sets uncommitted changes aside before a story's steps run,
+// when auto-stash is enabled, and records the stash on the execution. The
+// returned function restores them, keeping the stash recorded when they no
+// longer apply.
+func (en *stepEngine) stashChanges(execution *domain.Execution) (func(), error) {
+	if !en.config.AutoStash {
+		return func() {}, nil
+	}
+	commit, err := git.StashChanges(en.config.WorkingDir, "bmad auto-stash "+execution.Story.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stash changes: %w", err)
+	}
+	if commit == "" {
+		return func() {}, nil
+	}
+	execution.Stash = commit
+
+	return func() {
+		if err := git.PopStash(en.config.WorkingDir, commit); err != nil {
+			en.send(messages.ErrorMsg{Error: fmt.Errorf("failed to restore stashed changes, kept as %s: %w", commit[:7], err)})
+			return
+		}
+		execution.Stash = ""
+	}, nil
+}
+
+// prepareWorkspace stashes changes and checks out the story branch, as
+// configured. The returned function undoes both in reverse order.
+func (en *stepEngine) prepareWorkspace(execution *domain.Execution) (func(), error) {
+	unstash, err := en.stashChanges(execution)
+	if err != nil {
+		return nil, err
+	}
+	restore, err := en.checkoutStoryBranch(execution)
+	if err != nil {
+		unstash()
+		return nil, err
+	}
+	return func() {
+		restore()
+		unstash()
+	}, nil
+}
+
+// claudeVersionTimeout bounds "claude --version" when recording the
+// execution context
+const claudeVersionTimeout = 5 * time.Second
+
+// captureContext records the environment the execution is starting in
+func (en *stepEngine) captureContext(execution *domain.Execution) {
+	ec := &domain.ExecutionContext{
+		ClaudeVersion: claudeVersion(en.config.WorkingDir),
+		BmadVersion:   en.config.Version,
+		OS:            runtime.GOOS,
+		Arch:          runtime.GOARCH,
+		ConfigDigest:  en.config.Digest(),
+		Workflow:      en.config.ActiveWorkflow,
+		Profile:       en.config.ActiveProfile,
+	}
+	if w := en.workflow.Load(); w != nil && w.Name != "" {
+		ec.Workflow = w.Name
+	}
+	if status := git.GetStatus(en.config.WorkingDir); status.IsGitRepo {
+		ec.GitBranch = status.Branch
+		ec.GitCommit = git.HeadCommit(en.config.WorkingDir)
+		ec.GitDirty = !status.IsClean
+	}
+	execution.Context = ec
+}
+
+// claudeVersion returns the first line of "claude --version", or "" when
+// the CLI is missing or does not answer in time
+func claudeVersion(workDir string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), claudeVersionTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "claude", "--version")
+	cmd.Dir = workDir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return line
+}
+
+// newExecution creates an execution for story with the steps of the
+// active workflow, or the built-in steps when none is set
+func (en *stepEngine) newExecution(story domain.Story) *domain.Execution {
+	w := en.workflow.Load()
+	if w == nil || len(w.Steps) == 0 {
+		return domain.NewExecution(story)
+	}
+	names := make([]domain.StepName, len(w.Steps))
+	for i, def := range w.Steps {
+		names[i] = def.StepName
+		if names[i] == "" {
+			names[i] = domain.StepName(def.Name)
+		}
+	}
+	return domain.NewExecutionWithSteps(story, names)
+}
+
+// definition returns the workflow definition for a step, or nil
+func (en *stepEngine) definition(name domain.StepName) *workflow.StepDefinition {
+	w := en.workflow.Load()
+	if w == nil {
+		return nil
+	}
+	return w.Step(name)
+}
+
+// templateContext builds the template context for rendering step templates
+func (en *stepEngine) templateContext(story domain.Story) *workflow.TemplateContext {
+	var variables map[string]string
+	if w := en.workflow.Load(); w != nil {
+		variables = w.Variables
+	}
+	return workflow.NewTemplateContext(story, en.config.StoryDir, en.config.WorkingDir, variables)
+}
+
+// runControls are the cancel, pause and skip signals an executor exposes
+// to the engine for one run
+type runControls struct {
+	ctx   context.Context  // Cancelled when the run is cancelled
+	pause *PauseController // Pause/resume/cancel state
+	skip  <-chan struct{}  // Skip requests for the current step (nil = unsupported)
+}
+
+// canceled reports whether the run has been cancelled
+func (c runControls) canceled() bool {
+	return c.pause.IsCanceled() || c.ctx.Err() != nil
+}
+
+// skipRequested reports whether a skip of the current step is pending
+func (c runControls) skipRequested() bool {
+	if c.skip == nil {
+		return false
+	}
+	select {
+	case <-c.skip:
+		return true
+	default:
+		return false
+	}
+}
+
+// runSteps runs every step of execution in order. It sets the execution
+// status to cancelled, failed or conflict when the run stops early and
+// leaves it running otherwise, for the caller to complete. afterStep, if
+// set, is called after each step that ran without failing the execution.
+func (en *stepEngine) runSteps(c runControls, execution *domain.Execution, afterStep func(*domain.StepExecution)) {
+	// Steps appended while running (resolve-conflicts) are run by
+	// handleConflicts, so iterate over the original workflow only
+	steps := execution.Steps
+
+	for i, step := range steps {
+		// Steps finished by an earlier run are kept when retrying
+		if step.Status == domain.StepSuccess || step.Status == domain.StepSkipped {
+			continue
+		}
+
+		if c.canceled() {
+			execution.Status = domain.ExecutionCancelled
+			break
+		}
+
+		// Wait if paused (QUAL-003: using shared utility)
+		c.pause.WaitIfPaused(c.ctx.Done())
+
+		// Check for cancellation after pause
+		if c.canceled() {
+			execution.Status = domain.ExecutionCancelled
+			break
+		}
+
+		// Check for skip request
+		if c.skipRequested() {
+			step.Status = domain.StepSkipped
+			en.send(messages.StepCompletedMsg{
+				StepRef:   stepRef(execution),
+				StepIndex: i,
+				Status:    domain.StepSkipped,
+			})
+			continue
+		}
+
+		def := en.definition(step.Name)
+
+		// Skip steps whose skip condition holds, such as create-story once
+		// the story file exists
+		if skipsStep(def, step.Name, execution.Story) {
+			step.Status = domain.StepSkipped
+			en.send(messages.StepCompletedMsg{
+				StepRef:   stepRef(execution),
+				StepIndex: i,
+				Status:    domain.StepSkipped,
+			})
+			continue
+		}
+
+		// Manual gates wait for the user instead of running anything
+		if stepKind(def) == workflow.StepTypeWait {
+			if !en.runGate(c, execution, i, step, def) {
+				execution.Status = domain.ExecutionCancelled
+				break
+			}
+			if afterStep != nil {
+				afterStep(step)
+			}
+			continue
+		}
+
+		// Execute the step with retries
+		execution.Current = i
+		err := en.runStep(c, execution, i, step)
+
+		if step.Name == domain.StepGitCommit && !c.canceled() {
+			runStep := func(index int, s *domain.StepExecution) error {
+				return en.runStep(c, execution, index, s)
+			}
+			outcome := handleConflicts(en.config, execution, step, runStep)
+			if outcome == conflictParked {
+				break
+			}
+			if outcome == conflictResolved {
+				err = nil
+			}
+		}
+
+		// Optional steps may fail without stopping the run
+		if err != nil && step.Status == domain.StepFailed && !(def != nil && def.AllowFailure) {
+			execution.Status = domain.ExecutionFailed
+			execution.Error = err.Error()
+			break
+		}
+
+		if afterStep != nil {
+			afterStep(step)
+		}
+	}
+}
+
+// runStep runs a single step with retry logic
+func (en *stepEngine) runStep(c runControls, execution *domain.Execution, index int, step *domain.StepExecution) error {
+	story := execution.Story
+	def := en.definition(step.Name)
+
+	retries, timeout := en.config.Retries, en.config.Timeout
+	if def != nil && def.Retries > 0 {
+		retries = def.Retries
+	}
+	if def != nil && def.Timeout > 0 {
+		timeout = def.Timeout
+	}
+	// A failed step being retried continues its attempt count
+	first := 1
+	if step.Status == domain.StepFailed {
+		first = step.Attempt + 1
+	}
+	maxAttempts := first + retries
+
+	for attempt := first; attempt <= maxAttempts; attempt++ {
+		if c.canceled() {
+			return fmt.Errorf("cancelled")
+		}
+
+		step.ArchiveAttempt()
+		step.Attempt = attempt
+		step.Status = domain.StepRunning
+		step.Stalled = false
+		step.TimedOut = false
+		step.StartTime = time.Now()
+		step.Output = make([]string, 0)
+		step.Error = ""
+
+		if err := en.prepareStep(step, execution, def); err != nil {
+			// A template that does not render will not render on retry either
+			step.Status = domain.StepFailed
+			step.Error = err.Error()
+			step.EndTime = time.Now()
+			en.send(messages.StepCompletedMsg{
+				StepRef:   stepRef(execution),
+				StepIndex: index,
+				Status:    domain.StepFailed,
+				Error:     step.Error,
+			})
+			return err
+		}
+
+		en.send(messages.StepStartedMsg{
+			StepRef:   stepRef(execution),
+			StepIndex: index,
+			StepName:  step.Name,
+			Command:   step.Command,
+			Attempt:   attempt,
+		})
+
+		// Execute with timeout
+		ctx, cancel := context.WithTimeout(c.ctx, time.Duration(timeout)*time.Second)
+		err := en.execute(ctx, execution, index, step, def)
+		ctxErr := ctx.Err() // Read before cancel() so plain failures are not reported as cancelled
+		cancel()
+
+		step.EndTime = time.Now()
+		step.Duration = step.EndTime.Sub(step.StartTime)
+
+		if err == nil {
+			step.Status = domain.StepSuccess
+			en.send(messages.StepCompletedMsg{
+				StepRef:   stepRef(execution),
+				StepIndex: index,
+				Status:    domain.StepSuccess,
+				Duration:  step.Duration,
+				Usage:     step.Usage,
+			})
+			return nil
+		}
+
+		// Check if this was a stall kill or a context cancellation (timeout or user cancel)
+		if errors.Is(err, ErrStalled) {
+			step.Error = fmt.Sprintf("stalled: no output for %ds", en.config.StallTimeout)
+		} else if ctxErr == context.DeadlineExceeded {
+			step.Error = fmt.Sprintf("timeout after %ds", timeout)
+			step.TimedOut = true
+		} else if ctxErr == context.Canceled {
+			step.Error = "cancelled"
+		} else {
+			step.Error = err.Error()
+		}
+
+		// If we have retries left, wait before retrying
+		if attempt < maxAttempts {
+			line := fmt.Sprintf("Retrying in 2 seconds (attempt %d/%d)...", attempt+1, maxAttempts)
+			if en.tagRetries {
+				line = fmt.Sprintf("[%s] %s", story.Key, line)
+			}
+			en.send(messages.StepOutputMsg{
+				StepRef:   stepRef(execution),
+				StepIndex: index,
+				Line:      line,
+				IsStderr:  true,
+			})
+			time.Sleep(RetryDelayDuration)
+		} else {
+			step.Status = domain.StepFailed
+			en.send(messages.StepCompletedMsg{
+				StepRef:   stepRef(execution),
+				StepIndex: index,
+				Status:    domain.StepFailed,
+				Duration:  step.Duration,
+				Error:     step.Error,
+				TimedOut:  step.TimedOut,
+				Usage:     step.Usage,
+			})
+		}
+	}
+
+	return fmt.Errorf("%s", step.Error)
+}
+
+// commandOptions overrides where and with what environment a command runs
+type commandOptions struct {
+	dir string   // Working directory ("" = configured working directory)
+	env []string // Full environment (nil = inherit)
+
+	// decode turns a line of stdout into the output lines to record
+	// (nil = record it as it is)
+	decode func(line string) []string
+}
+
+// runCommand executes a command and streams output
+// Uses exec.CommandContext with separate args to prevent shell injection
+func (en *stepEngine) runCommand(ctx context.Context, execution *domain.Execution, stepIndex int, step *domain.StepExecution) error {
+	return en.runCommandWith(ctx, execution, stepIndex, step, commandOptions{})
+}
+
+// runCommandWith executes a command with the given options and streams output
+func (en *stepEngine) runCommandWith(ctx context.Context, execution *domain.Execution, stepIndex int, step *domain.StepExecution, opts commandOptions) error {
+	// Derived context lets the stall watchdog kill the command
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Execute command directly without shell interpolation (SEC-001 fix)
+	cmd := exec.CommandContext(ctx, step.CommandName, step.CommandArgs...)
+	cmd.Dir = en.config.WorkingDir
+	if opts.dir != "" {
+		cmd.Dir = opts.dir
+	}
+	cmd.Env = opts.env
+
+	// Create pipes for stdout and stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+
+	// Start the command
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start command: %w", err)
+	}
+
+	// Track output activity for stall detection
+	activity := newActivityTracker()
+	watchdogDone := make(chan struct{})
+	defer close(watchdogDone)
+	go en.watchForStall(execution, stepIndex, step, activity, cancel, watchdogDone)
+
+	// Stream output in goroutines
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stdout)
+		// Increase buffer size for long lines
+		buf := make([]byte, 0, ScannerInitialBufferSize)
+		scanner.Buffer(buf, ScannerMaxBufferSize)
+		for scanner.Scan() {
+			activity.touch()
+			lines := []string{scanner.Text()}
+			if opts.decode != nil {
+				lines = opts.decode(lines[0])
+			}
+			for _, line := range lines {
+				en.appendOutput(execution, stepIndex, step, line, false)
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		scanner := bufio.NewScanner(stderr)
+		buf := make([]byte, 0, ScannerInitialBufferSize)
+		scanner.Buffer(buf, ScannerMaxBufferSize)
+		for scanner.Scan() {
+			activity.touch()
+			en.appendOutput(execution, stepIndex, step, scanner.Text(), true)
+		}
+	}()
+
+	// Wait for output streams to finish
+	wg.Wait()
+
+	// Wait for command to complete
+	err = cmd.Wait()
+	if activity.killed() {
+		return ErrStalled
+	}
+	return err
+}
+
+// activityTracker records when a command last produced output
+type activityTracker struct {
+	mu         sync.Mutex
+	lastOutput time.Time
+	stalled    bool
+	wasKilled  bool
+}
+
+func newActivityTracker() *activityTracker {
+	return &activityTracker{lastOutput: time.Now()}
+}
+
+// touch records new output and clears any stalled state
+func (a *activityTracker) touch() {
+	a.mu.Lock()
+	a.lastOutput = time.Now()
+	a.stalled = false
+	a.mu.Unlock()
+}
+
+// checkStall reports the idle time if the command has just crossed the
+// stall threshold. It returns false while already stalled so each stall
+// is reported once.
+func (a *activityTracker) checkStall(threshold time.Duration) (time.Duration, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	idle := time.Since(a.lastOutput)
+	if a.stalled || idle < threshold {
+		return idle, false
+	}
+	a.stalled = true
+	return idle, true
+}
+
+func (a *activityTracker) markKilled() {
+	a.mu.Lock()
+	a.wasKilled = true
+	a.mu.Unlock()
+}
+
+func (a *activityTracker) killed() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.wasKilled
+}
+
+// watchForStall reports a step that stops producing output and, when
+// StallAutoRetry is enabled, kills it so runStep can retry
+func (en *stepEngine) watchForStall(execution *domain.Execution, stepIndex int, step *domain.StepExecution, activity *activityTracker, kill context.CancelFunc, done <-chan struct{}) {
+	if en.config.StallTimeout <= 0 {
+		return
+	}
+	threshold := time.Duration(en.config.StallTimeout) * time.Second
+
+	ticker := time.NewTicker(StallCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			idle, stalled := activity.checkStall(threshold)
+			if !stalled {
+				continue
+			}
+
+			autoRetry := en.config.StallAutoRetry
+			if autoRetry {
+				activity.markKilled()
+			}
+			en.send(messages.StepStalledMsg{
+				StepRef:   stepRef(execution),
+				StepIndex: stepIndex,
+				StepName:  step.Name,
+				Idle:      idle,
+				Killed:    autoRetry,
+			})
+			if autoRetry {
+				kill()
+				return
+			}
+		}
+	}
+}
+
+// CommandSpec holds the command name and arguments for safe execution
+type CommandSpec struct {
+	Name string   // Executable name (e.g., "claude")
+	Args []string // Arguments passed directly to exec.Command (no shell interpolation)
+}
+
+// DisplayString returns a human-readable representation of the command for logging
+func (c CommandSpec) DisplayString() string {
+	if len(c.Args) == 0 {
+		return c.Name
+	}
+	// Build a display string (for logging only, not for execution)
+	return fmt.Sprintf("%s %s", c.Name, strings.Join(c.Args, " "))
+}
+
+// buildCommand creates the Claude CLI command specification for a step
+// Returns command name and args separately to prevent shell injection
+func (en *stepEngine) buildCommand(stepName domain.StepName, story domain.Story) CommandSpec {
+	storyPath := en.config.StoryFilePath(story.Key)
+
+	switch stepName {
+	case domain.StepCreateStory:
+		prompt := fmt.Sprintf("/bmad:bmm:workflows:create-story - Create story: %s", story.Key)
+		return CommandSpec{
+			Name: "claude",
+			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
+		}
+
+	case domain.StepDevStory:
+		prompt := fmt.Sprintf(
+			"/bmad:bmm:workflows:dev-story - Work on story file: %s. "+
+				"Complete all tasks. Run tests after each implementation. "+
+				"Do not ask clarifying questions - use best judgment based on existing patterns.",
+			storyPath,
+		)
+		return CommandSpec{
+			Name: "claude",
+			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
+		}
+
+	case domain.StepCodeReview:
+		prompt := fmt.Sprintf(
+			"/bmad:bmm:workflows:code-review - Review story: %s. "+
+				"IMPORTANT: When presenting options, always choose option 1 to "+
+				"auto-fix all issues immediately. Do not wait for user input.",
+			storyPath,
+		)
+		return CommandSpec{
+			Name: "claude",
+			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
+		}
+
+	case domain.StepGitCommit:
+		prompt := fmt.Sprintf(
+			"Commit all changes for story %s with a descriptive message. "+
+				"Then push to the current branch.",
+			story.Key,
+		)
+		return CommandSpec{
+			Name: "claude",
+			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
+		}
+
+	case domain.StepResolveConflicts:
+		prompt := fmt.Sprintf(
+			"Resolve the git merge conflicts left while committing story %s. "+
+				"Keep the intent of both sides, run the tests, then continue the "+
+				"rebase or merge and push to the current branch. "+
+				"Do not ask clarifying questions.",
+			story.Key,
+		)
+		return CommandSpec{
+			Name: "claude",
+			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
+		}
+
+	default:
+		return CommandSpec{}
+	}
+}
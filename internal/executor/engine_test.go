@@ -0,0 +1,193 @@
+package executor
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+// recordingEngine returns an engine that records every message it sends
+func recordingEngine(t *testing.T) (*stepEngine, *[]tea.Msg) {
+	t.Helper()
+	var mu sync.Mutex
+	var sent []tea.Msg
+	en := newStepEngine(createTestConfig(), func(msg tea.Msg) {
+		mu.Lock()
+		sent = append(sent, msg)
+		mu.Unlock()
+	}, &sync.Mutex{})
+	return en, &sent
+}
+
+func singleStepExecution(story domain.Story, name domain.StepName) *domain.Execution {
+	execution := domain.NewExecution(story)
+	execution.Status = domain.ExecutionRunning
+	execution.Steps = []*domain.StepExecution{{Name: name, Status: domain.StepPending}}
+	return execution
+}
+
+func TestStepEngine_RunSteps(t *testing.T) {
+	t.Run("cancelled run stops before any step", func(t *testing.T) {
+		en, sent := recordingEngine(t)
+		pause := NewPauseController()
+		pause.Cancel()
+		execution := domain.NewExecution(createTestStory())
+		execution.Status = domain.ExecutionRunning
+
+		en.runSteps(runControls{ctx: context.Background(), pause: pause}, execution, nil)
+
+		assert.Equal(t, domain.ExecutionCancelled, execution.Status)
+		assert.Empty(t, *sent)
+		for _, step := range execution.Steps {
+			assert.Equal(t, domain.StepPending, step.Status)
+		}
+	})
+
+	t.Run("cancelled context stops the run", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		execution := domain.NewExecution(createTestStory())
+		execution.Status = domain.ExecutionRunning
+
+		en.runSteps(runControls{ctx: ctx, pause: NewPauseController()}, execution, nil)
+
+		assert.Equal(t, domain.ExecutionCancelled, execution.Status)
+	})
+
+	t.Run("auto-skips create-story when the file exists", func(t *testing.T) {
+		en, sent := recordingEngine(t)
+		story := createTestStory()
+		story.FileExists = true
+		execution := singleStepExecution(story, domain.StepCreateStory)
+
+		var after []*domain.StepExecution
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, func(step *domain.StepExecution) {
+			after = append(after, step)
+		})
+
+		assert.Equal(t, domain.ExecutionRunning, execution.Status)
+		assert.Equal(t, domain.StepSkipped, execution.Steps[0].Status)
+		require.Len(t, *sent, 1)
+		assert.Equal(t, messages.StepCompletedMsg{StepRef: stepRef(execution), StepIndex: 0, Status: domain.StepSkipped}, (*sent)[0])
+		assert.Empty(t, after, "afterStep is only called for steps that ran")
+	})
+
+	t.Run("honors a pending skip request", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		execution := singleStepExecution(createTestStory(), domain.StepDevStory)
+		skip := make(chan struct{}, 1)
+		skip <- struct{}{}
+
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController(), skip: skip}, execution, nil)
+
+		assert.Equal(t, domain.StepSkipped, execution.Steps[0].Status)
+	})
+}
+
+func TestStepEngine_RunStepCancelled(t *testing.T) {
+	en, sent := recordingEngine(t)
+	pause := NewPauseController()
+	pause.Cancel()
+	step := &domain.StepExecution{Name: domain.StepDevStory}
+
+	err := en.runStep(runControls{ctx: context.Background(), pause: pause}, domain.NewExecution(createTestStory()), 0, step)
+
+	assert.EqualError(t, err, "cancelled")
+	assert.Empty(t, *sent)
+}
+
+func TestStepEngine_TagRetries(t *testing.T) {
+	en, sent := recordingEngine(t)
+	en.tagRetries = true
+	en.config.Retries = 1
+	step := &domain.StepExecution{Name: "unknown-step"} // empty command fails to start
+
+	err := en.runStep(runControls{ctx: context.Background(), pause: NewPauseController()}, domain.NewExecution(createTestStory()), 0, step)
+
+	require.Error(t, err)
+	assert.Equal(t, domain.StepFailed, step.Status)
+
+	var retryLine string
+	for _, msg := range *sent {
+		if out, ok := msg.(messages.StepOutputMsg); ok {
+			retryLine = out.Line
+		}
+	}
+	assert.Equal(t, "[3-1-test-story] Retrying in 2 seconds (attempt 2/2)...", retryLine)
+}
+
+func TestStepEngine_RunStepTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell steps need sh")
+	}
+
+	en, sent := recordingEngine(t)
+	en.config.Retries = 0
+	en.setWorkflow(&workflow.Workflow{Name: "slow", Steps: []*workflow.StepDefinition{
+		{Name: "wait", StepName: "wait", Type: workflow.StepTypeShell, Command: "exec sleep 5", Timeout: 1},
+	}})
+	step := &domain.StepExecution{Name: "wait"}
+
+	err := en.runStep(runControls{ctx: context.Background(), pause: NewPauseController()}, domain.NewExecution(createTestStory()), 0, step)
+
+	assert.EqualError(t, err, "timeout after 1s")
+	assert.True(t, step.TimedOut)
+	last := (*sent)[len(*sent)-1].(messages.StepCompletedMsg)
+	assert.Equal(t, domain.StepFailed, last.Status)
+	assert.True(t, last.TimedOut)
+}
+
+func TestStepEngine_ForJob(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell steps need sh")
+	}
+
+	en, sent := recordingEngine(t)
+	en.config.WorkingDir = t.TempDir()
+	en.setWorkflow(singleStepWorkflow(&workflow.StepDefinition{
+		Name:    "echo",
+		Type:    workflow.StepTypeShell,
+		Command: `for i in 1 2 3 4 5; do echo "$BMAD_STORY_KEY $i"; done`,
+	}))
+
+	// Two stories run side by side, each on its own job engine
+	var executions []*domain.Execution
+	var wg sync.WaitGroup
+	for _, key := range []string{"3-1-auth", "3-2-reset"} {
+		job := en.forJob()
+		assert.NotSame(t, en.mu, job.mu, "jobs lock their output separately")
+		execution := singleStepExecution(domain.Story{Key: key, Epic: 3}, "echo")
+		executions = append(executions, execution)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+		}()
+	}
+	wg.Wait()
+
+	for _, execution := range executions {
+		key := execution.Story.Key
+		assert.Equal(t, []string{key + " 1", key + " 2", key + " 3", key + " 4", key + " 5"}, execution.Steps[0].Output)
+
+		var lines []string
+		for _, msg := range *sent {
+			if out, ok := msg.(messages.StepOutputMsg); ok && out.ExecutionID == execution.ID {
+				assert.Equal(t, key, out.StoryKey)
+				lines = append(lines, out.Line)
+			}
+		}
+		assert.Equal(t, execution.Steps[0].Output, lines, "messages carry the execution they belong to")
+	}
+}
@@ -1,11 +1,8 @@
 package executor
 
 import (
-	"bufio"
 	"context"
 	"fmt"
-	"os/exec"
-	"strings"
 	"sync"
 	"time"
 
@@ -13,12 +10,14 @@ import (
 	"github.com/robertguss/bmad-automate-go/internal/config"
 	"github.com/robertguss/bmad-automate-go/internal/domain"
 	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
 )
 
 // Executor manages the execution of story workflows
 type Executor struct {
 	config    *config.Config
 	program   *tea.Program
+	handler   func(tea.Msg)
 	execution *domain.Execution
 
 	// Control channels
@@ -27,6 +26,9 @@ type Executor struct {
 	// Pause/resume/cancel control (QUAL-003: shared utility)
 	pauseCtrl *PauseController
 
+	// Shared step engine
+	engine *stepEngine
+
 	// State
 	mu     sync.Mutex
 	ctx    context.Context
@@ -35,11 +37,13 @@ type Executor struct {
 
 // New creates a new Executor
 func New(cfg *config.Config) *Executor {
-	return &Executor{
+	e := &Executor{
 		config:    cfg,
 		skipCh:    make(chan struct{}),
 		pauseCtrl: NewPauseController(),
 	}
+	e.engine = newStepEngine(cfg, e.sendMsg, &e.mu)
+	return e
 }
 
 // SetProgram sets the tea.Program for sending messages
@@ -47,294 +51,110 @@ func (e *Executor) SetProgram(p *tea.Program) {
 	e.program = p
 }
 
+// SetMessageHandler routes progress messages to fn instead of a
+// tea.Program, for running without the TUI
+func (e *Executor) SetMessageHandler(fn func(tea.Msg)) {
+	e.handler = fn
+}
+
+// SetWorkflow sets the workflow whose step types decide how steps run
+func (e *Executor) SetWorkflow(w *workflow.Workflow) {
+	e.engine.setWorkflow(w)
+}
+
 // Execute starts the execution of a story through all workflow steps
 func (e *Executor) Execute(story domain.Story) tea.Cmd {
+	return e.execute(story, nil)
+}
+
+func (e *Executor) execute(story domain.Story, prompts map[domain.StepName]string) tea.Cmd {
 	return func() tea.Msg {
+		if err := runningStories.acquire(story.Key); err != nil {
+			return messages.ErrorMsg{Error: err}
+		}
+		defer runningStories.release(story.Key)
+
 		e.mu.Lock()
-		e.execution = domain.NewExecution(story)
+		e.execution = e.engine.newExecution(story)
+		for _, step := range e.execution.Steps {
+			step.PromptOverride = prompts[step.Name]
+		}
 		e.execution.Status = domain.ExecutionRunning
 		e.execution.StartTime = time.Now()
 		e.pauseCtrl.Reset()
 		e.ctx, e.cancel = context.WithCancel(context.Background())
 		e.mu.Unlock()
 
+		e.engine.snapshot(e.execution)
+		e.engine.captureContext(e.execution)
+
 		// Send execution started message
 		e.sendMsg(messages.ExecutionStartedMsg{Execution: e.execution})
 
 		// Start the execution tick for updating duration display
 		go e.runTicker()
 
-		// Execute each step
-		for i, step := range e.execution.Steps {
-			if e.pauseCtrl.IsCanceled() {
-				e.execution.Status = domain.ExecutionCancelled
-				break
-			}
-
-			// Wait if paused (QUAL-003: using shared utility)
-			e.pauseCtrl.WaitIfPaused(nil)
-
-			// Check for skip request
-			select {
-			case <-e.skipCh:
-				step.Status = domain.StepSkipped
-				e.sendMsg(messages.StepCompletedMsg{
-					StepIndex: i,
-					Status:    domain.StepSkipped,
-				})
-				continue
-			default:
-			}
-
-			// Check if we should auto-skip create-story
-			if step.Name == domain.StepCreateStory && story.FileExists {
-				step.Status = domain.StepSkipped
-				e.sendMsg(messages.StepCompletedMsg{
-					StepIndex: i,
-					Status:    domain.StepSkipped,
-				})
-				continue
-			}
-
-			// Execute the step with retries
-			e.execution.Current = i
-			err := e.executeStep(i, step)
-
-			if err != nil && step.Status == domain.StepFailed {
-				e.execution.Status = domain.ExecutionFailed
-				e.execution.Error = err.Error()
-				break
-			}
-		}
-
-		// Mark completion
-		e.execution.EndTime = time.Now()
-		e.execution.Duration = e.execution.EndTime.Sub(e.execution.StartTime)
-
-		if e.execution.Status == domain.ExecutionRunning {
-			e.execution.Status = domain.ExecutionCompleted
-		}
-
-		return messages.ExecutionCompletedMsg{
-			Status:   e.execution.Status,
-			Duration: e.execution.Duration,
-			Error:    e.execution.Error,
-		}
+		return e.run(0, e.execution.StartTime)
 	}
 }
 
-// executeStep runs a single step with retry logic
-func (e *Executor) executeStep(index int, step *domain.StepExecution) error {
-	maxAttempts := e.config.Retries + 1
-
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		if e.pauseCtrl.IsCanceled() {
-			return fmt.Errorf("cancelled")
-		}
-
-		step.Attempt = attempt
-		step.Status = domain.StepRunning
-		step.StartTime = time.Now()
-		step.Output = make([]string, 0)
-
-		// Build command with separate name and args (prevents shell injection)
-		cmdSpec := e.buildCommand(step.Name, e.execution.Story)
-		step.CommandName = cmdSpec.Name
-		step.CommandArgs = cmdSpec.Args
-		step.Command = cmdSpec.DisplayString() // For logging/display only
-
-		e.sendMsg(messages.StepStartedMsg{
-			StepIndex: index,
-			StepName:  step.Name,
-			Command:   step.Command,
-			Attempt:   attempt,
-		})
-
-		// Execute with timeout
-		ctx, cancel := context.WithTimeout(e.ctx, time.Duration(e.config.Timeout)*time.Second)
-		err := e.runCommand(ctx, index, step)
-		cancel()
-
-		step.EndTime = time.Now()
-		step.Duration = step.EndTime.Sub(step.StartTime)
-
-		if err == nil {
-			step.Status = domain.StepSuccess
-			e.sendMsg(messages.StepCompletedMsg{
-				StepIndex: index,
-				Status:    domain.StepSuccess,
-				Duration:  step.Duration,
-			})
-			return nil
-		}
-
-		// Check if this was a context cancellation (timeout or user cancel)
-		if ctx.Err() == context.DeadlineExceeded {
-			step.Error = fmt.Sprintf("timeout after %ds", e.config.Timeout)
-		} else if ctx.Err() == context.Canceled {
-			step.Error = "cancelled"
-		} else {
-			step.Error = err.Error()
+// RetryFromFailed resumes the last execution at its failed step. Steps that
+// already succeeded are kept, and the failed step's attempts continue from
+// where they stopped.
+func (e *Executor) RetryFromFailed() tea.Cmd {
+	return func() tea.Msg {
+		e.mu.Lock()
+		execution := e.execution
+		e.mu.Unlock()
+		if execution == nil || !execution.CanRetry() {
+			return messages.ErrorMsg{Error: fmt.Errorf("no failed step to retry")}
 		}
 
-		// If we have retries left, wait before retrying
-		if attempt < maxAttempts {
-			e.sendMsg(messages.StepOutputMsg{
-				StepIndex: index,
-				Line:      fmt.Sprintf("Retrying in 2 seconds (attempt %d/%d)...", attempt+1, maxAttempts),
-				IsStderr:  true,
-			})
-			time.Sleep(RetryDelayDuration)
-		} else {
-			step.Status = domain.StepFailed
-			e.sendMsg(messages.StepCompletedMsg{
-				StepIndex: index,
-				Status:    domain.StepFailed,
-				Duration:  step.Duration,
-				Error:     step.Error,
-			})
+		if err := runningStories.acquire(execution.Story.Key); err != nil {
+			return messages.ErrorMsg{Error: err}
 		}
-	}
+		defer runningStories.release(execution.Story.Key)
 
-	return fmt.Errorf("%s", step.Error)
-}
-
-// runCommand executes a command and streams output
-// Uses exec.CommandContext with separate args to prevent shell injection
-func (e *Executor) runCommand(ctx context.Context, stepIndex int, step *domain.StepExecution) error {
-	// Execute command directly without shell interpolation (SEC-001 fix)
-	cmd := exec.CommandContext(ctx, step.CommandName, step.CommandArgs...)
-	cmd.Dir = e.config.WorkingDir
-
-	// Create pipes for stdout and stderr
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
-	}
+		e.mu.Lock()
+		execution.Status = domain.ExecutionRunning
+		execution.Error = ""
+		e.pauseCtrl.Reset()
+		e.ctx, e.cancel = context.WithCancel(context.Background())
+		e.mu.Unlock()
 
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
-	}
+		e.sendMsg(messages.ExecutionStartedMsg{Execution: execution})
+		go e.runTicker()
 
-	// Start the command
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to start command: %w", err)
+		// The time spent between the failure and the retry is not counted
+		return e.run(execution.Duration, time.Now())
 	}
-
-	// Stream output in goroutines
-	var wg sync.WaitGroup
-	wg.Add(2)
-
-	go func() {
-		defer wg.Done()
-		scanner := bufio.NewScanner(stdout)
-		// Increase buffer size for long lines
-		buf := make([]byte, 0, ScannerInitialBufferSize)
-		scanner.Buffer(buf, ScannerMaxBufferSize)
-		for scanner.Scan() {
-			line := scanner.Text()
-			e.mu.Lock()
-			step.Output = append(step.Output, line)
-			e.mu.Unlock()
-			e.sendMsg(messages.StepOutputMsg{
-				StepIndex: stepIndex,
-				Line:      line,
-				IsStderr:  false,
-			})
-		}
-	}()
-
-	go func() {
-		defer wg.Done()
-		scanner := bufio.NewScanner(stderr)
-		buf := make([]byte, 0, ScannerInitialBufferSize)
-		scanner.Buffer(buf, ScannerMaxBufferSize)
-		for scanner.Scan() {
-			line := scanner.Text()
-			e.mu.Lock()
-			step.Output = append(step.Output, "[stderr] "+line)
-			e.mu.Unlock()
-			e.sendMsg(messages.StepOutputMsg{
-				StepIndex: stepIndex,
-				Line:      line,
-				IsStderr:  true,
-			})
-		}
-	}()
-
-	// Wait for output streams to finish
-	wg.Wait()
-
-	// Wait for command to complete
-	return cmd.Wait()
 }
 
-// CommandSpec holds the command name and arguments for safe execution
-type CommandSpec struct {
-	Name string   // Executable name (e.g., "claude")
-	Args []string // Arguments passed directly to exec.Command (no shell interpolation)
-}
-
-// DisplayString returns a human-readable representation of the command for logging
-func (c CommandSpec) DisplayString() string {
-	if len(c.Args) == 0 {
-		return c.Name
+// run executes the steps of the current execution that have not finished
+// and completes it. The execution's duration is prior plus the time since
+// started.
+func (e *Executor) run(prior time.Duration, started time.Time) tea.Msg {
+	controls := runControls{ctx: e.ctx, pause: e.pauseCtrl, skip: e.skipCh}
+	if restore, err := e.engine.prepareWorkspace(e.execution); err != nil {
+		e.execution.Status = domain.ExecutionFailed
+		e.execution.Error = err.Error()
+	} else {
+		e.engine.runSteps(controls, e.execution, nil)
+		restore()
 	}
-	// Build a display string (for logging only, not for execution)
-	return fmt.Sprintf("%s %s", c.Name, strings.Join(c.Args, " "))
-}
 
-// buildCommand creates the Claude CLI command specification for a step
-// Returns command name and args separately to prevent shell injection
-func (e *Executor) buildCommand(stepName domain.StepName, story domain.Story) CommandSpec {
-	storyPath := e.config.StoryFilePath(story.Key)
-
-	switch stepName {
-	case domain.StepCreateStory:
-		prompt := fmt.Sprintf("/bmad:bmm:workflows:create-story - Create story: %s", story.Key)
-		return CommandSpec{
-			Name: "claude",
-			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
-		}
+	// Mark completion
+	e.execution.EndTime = time.Now()
+	e.execution.Duration = prior + e.execution.EndTime.Sub(started)
 
-	case domain.StepDevStory:
-		prompt := fmt.Sprintf(
-			"/bmad:bmm:workflows:dev-story - Work on story file: %s. "+
-				"Complete all tasks. Run tests after each implementation. "+
-				"Do not ask clarifying questions - use best judgment based on existing patterns.",
-			storyPath,
-		)
-		return CommandSpec{
-			Name: "claude",
-			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
-		}
-
-	case domain.StepCodeReview:
-		prompt := fmt.Sprintf(
-			"/bmad:bmm:workflows:code-review - Review story: %s. "+
-				"IMPORTANT: When presenting options, always choose option 1 to "+
-				"auto-fix all issues immediately. Do not wait for user input.",
-			storyPath,
-		)
-		return CommandSpec{
-			Name: "claude",
-			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
-		}
-
-	case domain.StepGitCommit:
-		prompt := fmt.Sprintf(
-			"Commit all changes for story %s with a descriptive message. "+
-				"Then push to the current branch.",
-			story.Key,
-		)
-		return CommandSpec{
-			Name: "claude",
-			Args: []string{"--dangerously-skip-permissions", "-p", prompt},
-		}
+	if e.execution.Status == domain.ExecutionRunning {
+		e.execution.Status = domain.ExecutionCompleted
+	}
 
-	default:
-		return CommandSpec{}
+	return messages.ExecutionCompletedMsg{
+		Status:   e.execution.Status,
+		Duration: e.execution.Duration,
+		Error:    e.execution.Error,
 	}
 }
 
@@ -394,7 +214,11 @@ func (e *Executor) GetExecution() *domain.Execution {
 
 // runTicker sends periodic tick messages for updating duration display
 func (e *Executor) runTicker() {
-	ticker := time.NewTicker(ExecutionTickInterval)
+	interval := ExecutionTickInterval
+	if e.config.ReducedMotion {
+		interval = ReducedMotionTickInterval
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for t := range ticker.C {
@@ -404,7 +228,8 @@ func (e *Executor) runTicker() {
 
 		if execution == nil || execution.Status == domain.ExecutionCompleted ||
 			execution.Status == domain.ExecutionFailed ||
-			execution.Status == domain.ExecutionCancelled {
+			execution.Status == domain.ExecutionCancelled ||
+			execution.Status == domain.ExecutionConflict {
 			return
 		}
 
@@ -412,8 +237,12 @@ func (e *Executor) runTicker() {
 	}
 }
 
-// sendMsg safely sends a message to the tea.Program
+// sendMsg safely sends a message to the handler or tea.Program
 func (e *Executor) sendMsg(msg tea.Msg) {
+	if e.handler != nil {
+		e.handler(msg)
+		return
+	}
 	if e.program != nil {
 		e.program.Send(msg)
 	}
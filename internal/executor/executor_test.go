@@ -6,11 +6,13 @@ import (
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/robertguss/bmad-automate-go/internal/config"
 	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
 )
 
 func createTestConfig() *config.Config {
@@ -83,11 +85,16 @@ func TestExecutor_BuildCommand(t *testing.T) {
 			stepName: domain.StepGitCommit,
 			contains: "Commit",
 		},
+		{
+			name:     "resolve-conflicts command",
+			stepName: domain.StepResolveConflicts,
+			contains: "merge conflicts",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cmdSpec := e.buildCommand(tt.stepName, e.execution.Story)
+			cmdSpec := e.engine.buildCommand(tt.stepName, e.execution.Story)
 			assert.Equal(t, "claude", cmdSpec.Name)
 			assert.Contains(t, cmdSpec.DisplayString(), tt.contains)
 			// Verify args are properly separated (SEC-001 fix)
@@ -97,13 +104,13 @@ func TestExecutor_BuildCommand(t *testing.T) {
 	}
 
 	t.Run("unknown step returns empty CommandSpec", func(t *testing.T) {
-		cmdSpec := e.buildCommand("unknown-step", e.execution.Story)
+		cmdSpec := e.engine.buildCommand("unknown-step", e.execution.Story)
 		assert.Empty(t, cmdSpec.Name)
 		assert.Empty(t, cmdSpec.Args)
 	})
 
 	t.Run("includes story key in prompt arg", func(t *testing.T) {
-		cmdSpec := e.buildCommand(domain.StepCreateStory, e.execution.Story)
+		cmdSpec := e.engine.buildCommand(domain.StepCreateStory, e.execution.Story)
 		// The story key should be in the prompt argument, not as a separate arg
 		assert.Contains(t, cmdSpec.DisplayString(), "3-1-test-story")
 		// Verify the prompt is a single argument (prevents shell injection)
@@ -162,7 +169,7 @@ func TestBuildCommand_PreventShellInjection(t *testing.T) {
 				Status: domain.StatusInProgress,
 			}
 
-			cmdSpec := e.buildCommand(domain.StepCreateStory, story)
+			cmdSpec := e.engine.buildCommand(domain.StepCreateStory, story)
 
 			// The command should always be "claude" (not "sh")
 			assert.Equal(t, "claude", cmdSpec.Name, "command name should be 'claude', not 'sh'")
@@ -211,6 +218,68 @@ func TestRunCommand_UsesExecCommandDirectly(t *testing.T) {
 	// But with exec.Command("echo", "hello; whoami"), the semicolon is literal
 }
 
+func TestActivityTracker_CheckStall(t *testing.T) {
+	a := newActivityTracker()
+
+	t.Run("not stalled before threshold", func(t *testing.T) {
+		_, stalled := a.checkStall(time.Hour)
+		assert.False(t, stalled)
+	})
+
+	t.Run("reports stall once", func(t *testing.T) {
+		a.lastOutput = time.Now().Add(-time.Minute)
+		idle, stalled := a.checkStall(time.Second)
+		assert.True(t, stalled)
+		assert.GreaterOrEqual(t, idle, time.Minute)
+
+		_, stalled = a.checkStall(time.Second)
+		assert.False(t, stalled, "an ongoing stall should not be reported again")
+	})
+
+	t.Run("output clears stalled state", func(t *testing.T) {
+		a.touch()
+		a.lastOutput = time.Now().Add(-time.Minute)
+		_, stalled := a.checkStall(time.Second)
+		assert.True(t, stalled)
+	})
+}
+
+func TestRunCommand_StallAutoRetry(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.StallTimeout = 1
+	cfg.StallAutoRetry = true
+	e := New(cfg)
+
+	step := &domain.StepExecution{
+		Name:        domain.StepDevStory,
+		CommandName: "sleep",
+		CommandArgs: []string{"30"},
+	}
+
+	start := time.Now()
+	err := e.engine.runCommand(context.Background(), domain.NewExecution(createTestStory()), 0, step)
+
+	assert.ErrorIs(t, err, ErrStalled)
+	assert.Less(t, time.Since(start), 10*time.Second, "stalled command should be killed early")
+}
+
+func TestRunCommand_StallDetectionDisabled(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.StallTimeout = 0
+	cfg.StallAutoRetry = true
+	e := New(cfg)
+
+	step := &domain.StepExecution{
+		Name:        domain.StepDevStory,
+		CommandName: "echo",
+		CommandArgs: []string{"done"},
+	}
+
+	err := e.engine.runCommand(context.Background(), domain.NewExecution(createTestStory()), 0, step)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"done"}, step.Output)
+}
+
 func TestExecutor_Pause(t *testing.T) {
 	cfg := createTestConfig()
 	e := New(cfg)
@@ -500,7 +569,7 @@ func TestExecutor_BuildCommandWithDifferentSteps(t *testing.T) {
 	e.execution = domain.NewExecution(story)
 
 	t.Run("dev-story command format", func(t *testing.T) {
-		cmdSpec := e.buildCommand(domain.StepDevStory, story)
+		cmdSpec := e.engine.buildCommand(domain.StepDevStory, story)
 		assert.Equal(t, "claude", cmdSpec.Name)
 		assert.Len(t, cmdSpec.Args, 3)
 		assert.Contains(t, cmdSpec.Args[2], "dev-story")
@@ -508,14 +577,14 @@ func TestExecutor_BuildCommandWithDifferentSteps(t *testing.T) {
 	})
 
 	t.Run("code-review command format", func(t *testing.T) {
-		cmdSpec := e.buildCommand(domain.StepCodeReview, story)
+		cmdSpec := e.engine.buildCommand(domain.StepCodeReview, story)
 		assert.Equal(t, "claude", cmdSpec.Name)
 		assert.Len(t, cmdSpec.Args, 3)
 		assert.Contains(t, cmdSpec.Args[2], "code-review")
 	})
 
 	t.Run("git-commit command format", func(t *testing.T) {
-		cmdSpec := e.buildCommand(domain.StepGitCommit, story)
+		cmdSpec := e.engine.buildCommand(domain.StepGitCommit, story)
 		assert.Equal(t, "claude", cmdSpec.Name)
 		assert.Len(t, cmdSpec.Args, 3)
 		assert.Contains(t, cmdSpec.Args[2], "Commit")
@@ -600,3 +669,17 @@ func TestExecutor_PauseResumeStates(t *testing.T) {
 		assert.False(t, e.pauseCtrl.IsPaused())
 	})
 }
+
+func TestExecutor_SetMessageHandler(t *testing.T) {
+	cfg := createTestConfig()
+	e := New(cfg)
+
+	var got []tea.Msg
+	e.SetMessageHandler(func(msg tea.Msg) {
+		got = append(got, msg)
+	})
+	e.sendMsg(messages.ExecutionTickMsg{})
+
+	require.Len(t, got, 1)
+	assert.IsType(t, messages.ExecutionTickMsg{}, got[0])
+}
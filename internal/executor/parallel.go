@@ -2,7 +2,6 @@ package executor
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"time"
 
@@ -10,6 +9,7 @@ import (
 	"github.com/robertguss/bmad-automate-go/internal/config"
 	"github.com/robertguss/bmad-automate-go/internal/domain"
 	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
 )
 
 // ParallelExecutor manages parallel execution of multiple stories
@@ -29,10 +29,13 @@ type ParallelExecutor struct {
 	cancel    context.CancelFunc
 	running   bool
 	pauseCtrl *PauseController // QUAL-003: shared utility
+	engine    *stepEngine      // Creates executions; each job runs on its own copy
+	outputMu  sync.Mutex       // Guards output written by the engine itself
 
 	// Statistics
 	completed int
 	failed    int
+	conflicts int
 	total     int
 	startTime time.Time
 }
@@ -63,7 +66,7 @@ func NewParallelExecutor(cfg *config.Config, workers int) *ParallelExecutor {
 		workers = MaxParallelWorkers
 	}
 
-	return &ParallelExecutor{
+	p := &ParallelExecutor{
 		config:      cfg,
 		workers:     workers,
 		jobQueue:    make(chan *parallelJob, JobQueueBufferSize),
@@ -71,6 +74,9 @@ func NewParallelExecutor(cfg *config.Config, workers int) *ParallelExecutor {
 		activeJobs:  make(map[string]*parallelJob),
 		pauseCtrl:   NewPauseController(),
 	}
+	p.engine = newStepEngine(cfg, p.sendMsg, &p.outputMu)
+	p.engine.tagRetries = true
+	return p
 }
 
 // SetProgram sets the tea.Program for sending messages
@@ -78,6 +84,11 @@ func (p *ParallelExecutor) SetProgram(prog *tea.Program) {
 	p.program = prog
 }
 
+// SetWorkflow sets the workflow whose step types decide how steps run
+func (p *ParallelExecutor) SetWorkflow(w *workflow.Workflow) {
+	p.engine.setWorkflow(w)
+}
+
 // SetWorkers sets the number of parallel workers
 func (p *ParallelExecutor) SetWorkers(n int) {
 	p.mu.Lock()
@@ -108,6 +119,7 @@ func (p *ParallelExecutor) Execute(stories []domain.Story) tea.Cmd {
 		p.total = len(stories)
 		p.completed = 0
 		p.failed = 0
+		p.conflicts = 0
 		p.startTime = time.Now()
 		p.activeJobs = make(map[string]*parallelJob)
 		p.mu.Unlock()
@@ -122,21 +134,29 @@ func (p *ParallelExecutor) Execute(stories []domain.Story) tea.Cmd {
 		// Start result collector
 		go p.collectResults()
 
+		order := make([]int, len(stories))
+		for i := range order {
+			order[i] = i
+		}
+		if p.config.QueueOrder == config.QueueOrderRoundRobin {
+			order = domain.InterleaveByEpic(stories)
+		}
+
 		// Queue all jobs
-		for i, story := range stories {
+		for _, idx := range order {
 			job := &parallelJob{
-				index:     i,
-				story:     story,
-				execution: domain.NewExecution(story),
+				index:     idx,
+				story:     stories[idx],
+				execution: p.engine.newExecution(stories[idx]),
 			}
 
 			p.mu.Lock()
-			p.activeJobs[story.Key] = job
+			p.activeJobs[job.story.Key] = job
 			p.mu.Unlock()
 
 			p.sendMsg(messages.QueueItemStartedMsg{
-				Index:     i,
-				Story:     story,
+				Index:     job.index,
+				Story:     job.story,
 				Execution: job.execution,
 			})
 
@@ -184,8 +204,23 @@ func (p *ParallelExecutor) worker(id int, wg *sync.WaitGroup) {
 		default:
 		}
 
+		// Refuse to run a story that is already executing elsewhere
+		if err := runningStories.acquire(job.story.Key); err != nil {
+			job.execution.Status = domain.ExecutionFailed
+			job.execution.Error = err.Error()
+			p.resultQueue <- &parallelResult{
+				index:     job.index,
+				story:     job.story,
+				status:    domain.ExecutionFailed,
+				error:     err.Error(),
+				execution: job.execution,
+			}
+			continue
+		}
+
 		// Execute the story
 		result := p.executeStory(job)
+		runningStories.release(job.story.Key)
 		p.resultQueue <- result
 	}
 }
@@ -194,164 +229,33 @@ func (p *ParallelExecutor) worker(id int, wg *sync.WaitGroup) {
 func (p *ParallelExecutor) executeStory(job *parallelJob) *parallelResult {
 	job.execution.Status = domain.ExecutionRunning
 	job.execution.StartTime = time.Now()
+	// Each job streams through its own engine so its output is locked
+	// and tagged separately from the stories running beside it
+	engine := p.engine.forJob()
+	engine.captureContext(job.execution)
 
-	// Execute each step
-	for i, step := range job.execution.Steps {
-		// Check for cancellation
-		select {
-		case <-p.ctx.Done():
-			job.execution.Status = domain.ExecutionCancelled
-			job.execution.EndTime = time.Now()
-			job.execution.Duration = job.execution.EndTime.Sub(job.execution.StartTime)
-			return &parallelResult{
-				index:     job.index,
-				story:     job.story,
-				status:    domain.ExecutionCancelled,
-				duration:  job.execution.Duration,
-				error:     "cancelled",
-				execution: job.execution,
-			}
-		default:
-		}
-
-		// Check if paused (QUAL-003: using shared utility)
-		p.pauseCtrl.WaitIfPaused(p.ctx.Done())
-
-		// Auto-skip create-story if file exists
-		if step.Name == domain.StepCreateStory && job.story.FileExists {
-			step.Status = domain.StepSkipped
-			p.sendMsg(messages.StepCompletedMsg{
-				StepIndex: i,
-				Status:    domain.StepSkipped,
-			})
-			continue
-		}
+	controls := runControls{ctx: p.ctx, pause: p.pauseCtrl}
+	engine.runSteps(controls, job.execution, nil)
 
-		// Execute step
-		job.execution.Current = i
-		err := p.executeStep(job, i, step)
-
-		if err != nil && step.Status == domain.StepFailed {
-			job.execution.Status = domain.ExecutionFailed
-			job.execution.Error = err.Error()
-			job.execution.EndTime = time.Now()
-			job.execution.Duration = job.execution.EndTime.Sub(job.execution.StartTime)
-
-			return &parallelResult{
-				index:     job.index,
-				story:     job.story,
-				status:    domain.ExecutionFailed,
-				duration:  job.execution.Duration,
-				error:     err.Error(),
-				execution: job.execution,
-			}
-		}
-	}
-
-	job.execution.Status = domain.ExecutionCompleted
 	job.execution.EndTime = time.Now()
 	job.execution.Duration = job.execution.EndTime.Sub(job.execution.StartTime)
 
-	return &parallelResult{
+	if job.execution.Status == domain.ExecutionRunning {
+		job.execution.Status = domain.ExecutionCompleted
+	}
+
+	result := &parallelResult{
 		index:     job.index,
 		story:     job.story,
-		status:    domain.ExecutionCompleted,
+		status:    job.execution.Status,
 		duration:  job.execution.Duration,
+		error:     job.execution.Error,
 		execution: job.execution,
 	}
-}
-
-// executeStep executes a single step with retry logic
-func (p *ParallelExecutor) executeStep(job *parallelJob, index int, step *domain.StepExecution) error {
-	maxAttempts := p.config.Retries + 1
-
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		select {
-		case <-p.ctx.Done():
-			return fmt.Errorf("cancelled")
-		default:
-		}
-
-		step.Attempt = attempt
-		step.Status = domain.StepRunning
-		step.StartTime = time.Now()
-		step.Output = make([]string, 0)
-
-		// Build command with separate name and args (prevents shell injection)
-		cmdSpec := p.buildCommand(step.Name, job.story)
-		step.CommandName = cmdSpec.Name
-		step.CommandArgs = cmdSpec.Args
-		step.Command = cmdSpec.DisplayString() // For logging/display only
-
-		p.sendMsg(messages.StepStartedMsg{
-			StepIndex: index,
-			StepName:  step.Name,
-			Command:   step.Command,
-			Attempt:   attempt,
-		})
-
-		// Execute with timeout
-		ctx, cancel := context.WithTimeout(p.ctx, time.Duration(p.config.Timeout)*time.Second)
-		err := p.runCommand(ctx, job, index, step)
-		cancel()
-
-		step.EndTime = time.Now()
-		step.Duration = step.EndTime.Sub(step.StartTime)
-
-		if err == nil {
-			step.Status = domain.StepSuccess
-			p.sendMsg(messages.StepCompletedMsg{
-				StepIndex: index,
-				Status:    domain.StepSuccess,
-				Duration:  step.Duration,
-			})
-			return nil
-		}
-
-		// Handle errors
-		if ctx.Err() == context.DeadlineExceeded {
-			step.Error = fmt.Sprintf("timeout after %ds", p.config.Timeout)
-		} else if ctx.Err() == context.Canceled {
-			step.Error = "cancelled"
-		} else {
-			step.Error = err.Error()
-		}
-
-		// Retry or fail
-		if attempt < maxAttempts {
-			p.sendMsg(messages.StepOutputMsg{
-				StepIndex: index,
-				Line:      fmt.Sprintf("[%s] Retrying in 2s (attempt %d/%d)...", job.story.Key, attempt+1, maxAttempts),
-				IsStderr:  true,
-			})
-			time.Sleep(RetryDelayDuration)
-		} else {
-			step.Status = domain.StepFailed
-			p.sendMsg(messages.StepCompletedMsg{
-				StepIndex: index,
-				Status:    domain.StepFailed,
-				Duration:  step.Duration,
-				Error:     step.Error,
-			})
-		}
+	if result.status == domain.ExecutionCancelled && result.error == "" {
+		result.error = "cancelled"
 	}
-
-	return fmt.Errorf("%s", step.Error)
-}
-
-// runCommand executes a command and streams output (similar to Executor.runCommand)
-func (p *ParallelExecutor) runCommand(ctx context.Context, job *parallelJob, stepIndex int, step *domain.StepExecution) error {
-	// Use the same implementation as the regular executor
-	exec := New(p.config)
-	exec.program = p.program
-	return exec.runCommand(ctx, stepIndex, step)
-}
-
-// buildCommand creates the Claude CLI command specification for a step
-// Returns command name and args separately to prevent shell injection
-func (p *ParallelExecutor) buildCommand(stepName domain.StepName, story domain.Story) CommandSpec {
-	exec := New(p.config)
-	return exec.buildCommand(stepName, story)
+	return result
 }
 
 // collectResults processes results from workers
@@ -360,6 +264,8 @@ func (p *ParallelExecutor) collectResults() {
 		p.mu.Lock()
 		if result.status == domain.ExecutionCompleted {
 			p.completed++
+		} else if result.status == domain.ExecutionConflict {
+			p.conflicts++
 		} else {
 			p.failed++
 		}
@@ -417,6 +323,7 @@ func (p *ParallelExecutor) completionMsg() messages.QueueCompletedMsg {
 		TotalItems:    p.total,
 		SuccessCount:  p.completed,
 		FailedCount:   p.failed,
+		ConflictCount: p.conflicts,
 		TotalDuration: time.Since(p.startTime),
 	}
 }
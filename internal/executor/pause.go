@@ -17,6 +17,13 @@ const (
 
 	// ExecutionTickInterval is the interval for updating duration display
 	ExecutionTickInterval = 1 * time.Second
+
+	// ReducedMotionTickInterval replaces ExecutionTickInterval in reduced
+	// motion mode, so a remote terminal redraws the elapsed time less often
+	ReducedMotionTickInterval = 5 * time.Second
+
+	// StallCheckInterval is how often a running command is checked for output inactivity
+	StallCheckInterval = 1 * time.Second
 )
 
 // Buffer size constants for command output streaming
@@ -182,6 +182,7 @@ func TestExecutorConstants(t *testing.T) {
 		assert.Equal(t, 100*time.Millisecond, PauseCheckInterval)
 		assert.Equal(t, 2*time.Second, RetryDelayDuration)
 		assert.Equal(t, 1*time.Second, ExecutionTickInterval)
+		assert.Equal(t, 5*time.Second, ReducedMotionTickInterval)
 	})
 
 	t.Run("buffer size constants", func(t *testing.T) {
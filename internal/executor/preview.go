@@ -0,0 +1,60 @@
+package executor
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+// StepPreview shows how a step of a story's run would start
+type StepPreview struct {
+	Name    domain.StepName
+	Kind    workflow.StepType
+	Command string // As the run would log it
+	Prompt  string // The prompt an agent step sends, before trailers and retry context
+	Skipped bool   // The step's skip condition holds, so it would not run
+	Error   string // The step's template does not render
+}
+
+// PreviewSteps renders the steps a run of story would start with the
+// active workflow, without running anything
+func (e *Executor) PreviewSteps(story domain.Story) []StepPreview {
+	en := e.engine
+	execution := en.newExecution(story)
+
+	previews := make([]StepPreview, len(execution.Steps))
+	for i, step := range execution.Steps {
+		def := en.definition(step.Name)
+		preview := StepPreview{
+			Name:    step.Name,
+			Kind:    stepKind(def),
+			Skipped: skipsStep(def, step.Name, story),
+		}
+
+		switch preview.Kind {
+		case workflow.StepTypeWait:
+			preview.Command = def.Message
+		default:
+			if err := en.prepareStep(step, execution, def); err != nil {
+				preview.Error = err.Error()
+				break
+			}
+			preview.Command = step.Command
+			if preview.Kind == workflow.StepTypeAgent {
+				if spec, err := en.agentCommand(step.Name, story, def); err == nil && len(spec.Args) > 0 {
+					preview.Prompt = spec.Args[len(spec.Args)-1]
+				}
+			}
+		}
+		previews[i] = preview
+	}
+	return previews
+}
+
+// ExecuteWithPrompts starts a run of story like Execute, sending prompts
+// in place of the workflow's prompts for the agent steps they name. The
+// edited prompts are kept with the execution.
+func (e *Executor) ExecuteWithPrompts(story domain.Story, prompts map[domain.StepName]string) tea.Cmd {
+	return e.execute(story, prompts)
+}
@@ -0,0 +1,56 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+func TestExecutor_PreviewSteps(t *testing.T) {
+	t.Run("renders the built-in steps", func(t *testing.T) {
+		e := New(createTestConfig())
+		story := createTestStory()
+		story.FileExists = true
+
+		previews := e.PreviewSteps(story)
+		require.Len(t, previews, 4)
+		assert.Equal(t, domain.StepCreateStory, previews[0].Name)
+		assert.True(t, previews[0].Skipped, "create-story is skipped once the file exists")
+
+		dev := previews[1]
+		assert.Equal(t, workflow.StepTypeAgent, dev.Kind)
+		assert.Contains(t, dev.Prompt, "dev-story")
+		assert.Contains(t, dev.Command, "claude")
+		assert.False(t, dev.Skipped)
+		assert.Nil(t, e.GetExecution(), "nothing runs")
+	})
+
+	t.Run("shows shell commands and template errors", func(t *testing.T) {
+		e := New(createTestConfig())
+		e.SetWorkflow(&workflow.Workflow{Name: "custom", Steps: []*workflow.StepDefinition{
+			{Name: "lint", StepName: "lint", Type: workflow.StepTypeShell, Command: "make lint"},
+			{Name: "broken", StepName: "broken", PromptTemplate: "{{ .Missing.Field }"},
+		}})
+
+		previews := e.PreviewSteps(createTestStory())
+		require.Len(t, previews, 2)
+		assert.Equal(t, "sh -c make lint", previews[0].Command)
+		assert.Empty(t, previews[0].Prompt)
+		assert.NotEmpty(t, previews[1].Error)
+	})
+}
+
+func TestStepEngine_PromptOverride(t *testing.T) {
+	en, _ := recordingEngine(t)
+	execution := singleStepExecution(createTestStory(), domain.StepDevStory)
+	step := execution.Steps[0]
+	step.PromptOverride = "  Only fix the failing test  "
+
+	require.NoError(t, en.prepareStep(step, execution, nil))
+	assert.Equal(t, "claude", step.CommandName)
+	assert.Equal(t, "Only fix the failing test", step.CommandArgs[len(step.CommandArgs)-1])
+}
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// How much of a failed attempt's output goes into the retry prompt. Agents
+// tend to print the test or build failure last, so the tail is kept.
+const (
+	failureContextLines     = 20
+	failureContextLineWidth = 300
+)
+
+// lastAttempt returns the attempt made before the current one, or nil
+func lastAttempt(step *domain.StepExecution) *domain.StepAttempt {
+	if len(step.PreviousAttempts) == 0 {
+		return nil
+	}
+	return step.PreviousAttempts[len(step.PreviousAttempts)-1]
+}
+
+// failureContext summarizes a failed attempt for the agent retrying it:
+// the error and the last lines of its output
+func failureContext(attempt *domain.StepAttempt) string {
+	var tail []string
+	for i := len(attempt.Output) - 1; i >= 0 && len(tail) < failureContextLines; i-- {
+		line := strings.TrimSpace(attempt.Output[i])
+		if line == "" {
+			continue
+		}
+		if len(line) > failureContextLineWidth {
+			line = line[:failureContextLineWidth] + "..."
+		}
+		tail = append(tail, line)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n\nPrevious attempt %d failed with: %s.", attempt.Number, attempt.Error)
+	if len(tail) > 0 {
+		b.WriteString(" Its last output was:\n")
+		for i := len(tail) - 1; i >= 0; i-- {
+			b.WriteString(tail[i])
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\nFix that first, then carry on with the task.")
+	return b.String()
+}
+
+// withFailureContext appends the failure of the previous attempt to the
+// prompt of an agent command. The prompt is always the last argument.
+func withFailureContext(spec CommandSpec, attempt *domain.StepAttempt) CommandSpec {
+	if attempt == nil || attempt.Error == "" || len(spec.Args) == 0 {
+		return spec
+	}
+
+	args := make([]string, len(spec.Args))
+	copy(args, spec.Args)
+	args[len(args)-1] += failureContext(attempt)
+	return CommandSpec{Name: spec.Name, Args: args}
+}
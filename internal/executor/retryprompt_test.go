@@ -0,0 +1,81 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestFailureContext(t *testing.T) {
+	t.Run("includes the error and the tail of the output", func(t *testing.T) {
+		var output []string
+		for i := 1; i <= 30; i++ {
+			output = append(output, fmt.Sprintf("line %d", i), "")
+		}
+		context := failureContext(&domain.StepAttempt{Number: 1, Error: "exit status 1", Output: output})
+
+		assert.Contains(t, context, "Previous attempt 1 failed with: exit status 1.")
+		assert.Contains(t, context, "line 11\n")
+		assert.Contains(t, context, "line 30\n")
+		assert.NotContains(t, context, "line 10\n")
+		assert.Less(t, strings.Index(context, "line 11"), strings.Index(context, "line 30"), "output keeps its order")
+		assert.True(t, strings.HasSuffix(context, "Fix that first, then carry on with the task."))
+	})
+
+	t.Run("shortens long lines", func(t *testing.T) {
+		context := failureContext(&domain.StepAttempt{Number: 2, Error: "timeout after 600s", Output: []string{strings.Repeat("x", 1000)}})
+
+		assert.Contains(t, context, strings.Repeat("x", failureContextLineWidth)+"...\n")
+		assert.NotContains(t, context, strings.Repeat("x", failureContextLineWidth+1))
+	})
+
+	t.Run("leaves out empty output", func(t *testing.T) {
+		context := failureContext(&domain.StepAttempt{Number: 1, Error: "exit status 2"})
+
+		assert.NotContains(t, context, "last output")
+	})
+}
+
+func TestWithFailureContext(t *testing.T) {
+	spec := CommandSpec{Name: "claude", Args: []string{"-p", "Work on the story."}}
+	attempt := &domain.StepAttempt{Number: 1, Error: "exit status 1", Output: []string{"FAIL: TestLogin"}}
+
+	got := withFailureContext(spec, attempt)
+
+	assert.Equal(t, "-p", got.Args[0])
+	assert.True(t, strings.HasPrefix(got.Args[1], "Work on the story.\n\nPrevious attempt 1 failed"))
+	assert.Contains(t, got.Args[1], "FAIL: TestLogin")
+	assert.Equal(t, "Work on the story.", spec.Args[1], "original spec is not modified")
+
+	assert.Equal(t, spec, withFailureContext(spec, nil))
+	assert.Equal(t, spec, withFailureContext(spec, &domain.StepAttempt{Number: 1}))
+}
+
+func TestStepEngine_PrepareStepAdaptiveRetry(t *testing.T) {
+	en, _ := recordingEngine(t)
+	execution := domain.NewExecution(createTestStory())
+	failed := func() *domain.StepExecution {
+		step := &domain.StepExecution{Name: domain.StepDevStory, Attempt: 1, Error: "exit status 1", Output: []string{"FAIL: TestLogin"}}
+		step.ArchiveAttempt()
+		step.Attempt = 2
+		return step
+	}
+
+	step := failed()
+	en.prepareStep(step, execution, nil)
+	assert.NotContains(t, step.Command, "Previous attempt", "off by default")
+
+	en.config.AdaptiveRetry = true
+	step = failed()
+	en.prepareStep(step, execution, nil)
+	assert.Contains(t, step.Command, "Previous attempt 1 failed with: exit status 1.")
+	assert.Contains(t, step.Command, "FAIL: TestLogin")
+
+	first := &domain.StepExecution{Name: domain.StepDevStory}
+	en.prepareStep(first, execution, nil)
+	assert.NotContains(t, first.Command, "Previous attempt", "first attempts are unchanged")
+}
@@ -0,0 +1,270 @@
+package executor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+// MaxHTTPResponseBytes caps how much of an http step's response body is kept
+const MaxHTTPResponseBytes = 64 * 1024
+
+// stepKind returns the runner kind for a step definition (nil = agent)
+func stepKind(def *workflow.StepDefinition) workflow.StepType {
+	if def == nil {
+		return workflow.StepTypeAgent
+	}
+	return def.Kind()
+}
+
+// skipsStep reports whether a step's skip condition holds. Without a
+// definition only create-story is skipped, once the story file exists.
+func skipsStep(def *workflow.StepDefinition, name domain.StepName, story domain.Story) bool {
+	if def == nil {
+		return name == domain.StepCreateStory && story.FileExists
+	}
+	return def.SkipIf == "file_exists" && story.FileExists
+}
+
+// prepareStep fills in the command run for a step before it starts
+func (en *stepEngine) prepareStep(step *domain.StepExecution, execution *domain.Execution, def *workflow.StepDefinition) error {
+	switch stepKind(def) {
+	case workflow.StepTypeShell:
+		// The command is passed to sh verbatim. Story data is exposed through
+		// BMAD_* environment variables rather than interpolated into the
+		// command line, so story keys cannot inject shell syntax.
+		spec := CommandSpec{Name: "sh", Args: []string{"-c", def.Command}}
+		step.CommandName = spec.Name
+		step.CommandArgs = spec.Args
+		step.Command = spec.DisplayString()
+
+	case workflow.StepTypeHTTP:
+		step.CommandName = ""
+		step.CommandArgs = nil
+		step.Command = fmt.Sprintf("%s %s", httpMethod(def), def.URL)
+
+	default:
+		// Build command with separate name and args (prevents shell injection)
+		cmdSpec := agentSpec(step.PromptOverride)
+		if step.PromptOverride == "" {
+			var err error
+			if cmdSpec, err = en.agentCommand(step.Name, execution.Story, def); err != nil {
+				return err
+			}
+		}
+		if commitsChanges(step.Name) {
+			cmdSpec = withTrailerInstructions(cmdSpec, commitTrailers(en.config.CommitTrailers, execution))
+		}
+		if en.config.AdaptiveRetry {
+			cmdSpec = withFailureContext(cmdSpec, lastAttempt(step))
+		}
+		if en.config.UsageTracking && cmdSpec.Name == "claude" {
+			cmdSpec = withUsageOutput(cmdSpec)
+		}
+		step.CommandName = cmdSpec.Name
+		step.CommandArgs = cmdSpec.Args
+		step.Command = cmdSpec.DisplayString() // For logging/display only
+	}
+	return nil
+}
+
+// agentCommand builds the Claude CLI command for an agent step from its
+// prompt template, falling back to the built-in prompt for steps without one
+func (en *stepEngine) agentCommand(name domain.StepName, story domain.Story, def *workflow.StepDefinition) (CommandSpec, error) {
+	if def == nil || def.PromptTemplate == "" {
+		return en.buildCommand(name, story), nil
+	}
+	prompt, err := def.RenderPrompt(en.templateContext(story))
+	if err != nil {
+		return CommandSpec{}, err
+	}
+	return agentSpec(prompt), nil
+}
+
+// agentSpec returns the Claude CLI command that runs prompt
+func agentSpec(prompt string) CommandSpec {
+	return CommandSpec{
+		Name: "claude",
+		Args: []string{"--dangerously-skip-permissions", "-p", strings.TrimSpace(prompt)},
+	}
+}
+
+// execute runs one attempt of a step with the runner for its kind
+func (en *stepEngine) execute(ctx context.Context, execution *domain.Execution, index int, step *domain.StepExecution, def *workflow.StepDefinition) error {
+	switch stepKind(def) {
+	case workflow.StepTypeShell:
+		return en.runCommandWith(ctx, execution, index, step, en.shellOptions(execution, def))
+	case workflow.StepTypeHTTP:
+		return en.runHTTP(ctx, execution, index, step, def)
+	default:
+		return en.runAgent(ctx, execution, index, step)
+	}
+}
+
+// shellOptions returns the working directory and environment for a shell step
+func (en *stepEngine) shellOptions(execution *domain.Execution, def *workflow.StepDefinition) commandOptions {
+	story := execution.Story
+	dir := en.config.WorkingDir
+	if def.WorkingDir != "" {
+		dir = def.WorkingDir
+	}
+
+	env := append(os.Environ(),
+		"BMAD_STORY_KEY="+story.Key,
+		"BMAD_STORY_EPIC="+strconv.Itoa(story.Epic),
+		"BMAD_STORY_PATH="+en.config.StoryFilePath(story.Key),
+		"BMAD_STEP="+def.Name,
+		"BMAD_EXECUTION_ID="+execution.ID,
+		"BMAD_COMMIT_TRAILERS="+strings.Join(commitTrailers(en.config.CommitTrailers, execution), "\n"),
+	)
+	for k, v := range def.Env {
+		env = append(env, k+"="+v)
+	}
+
+	return commandOptions{dir: dir, env: env}
+}
+
+// httpMethod returns the request method for an http step
+func httpMethod(def *workflow.StepDefinition) string {
+	if def.Method != "" {
+		return strings.ToUpper(def.Method)
+	}
+	if def.Body != "" {
+		return http.MethodPost
+	}
+	return http.MethodGet
+}
+
+// runHTTP performs an http step. The status line and response body are
+// recorded as step output; any non-2xx status fails the step.
+func (en *stepEngine) runHTTP(ctx context.Context, execution *domain.Execution, index int, step *domain.StepExecution, def *workflow.StepDefinition) error {
+	tctx := en.templateContext(execution.Story)
+
+	url, err := def.RenderURL(tctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if def.Body != "" {
+		rendered, err := def.RenderBody(tctx)
+		if err != nil {
+			return err
+		}
+		body = strings.NewReader(rendered)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, httpMethod(def), url, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	for k, v := range def.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	en.appendOutput(execution, index, step, fmt.Sprintf("HTTP %s", resp.Status), false)
+
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, MaxHTTPResponseBytes))
+	buf := make([]byte, 0, ScannerInitialBufferSize)
+	scanner.Buffer(buf, ScannerMaxBufferSize)
+	for scanner.Scan() {
+		en.appendOutput(execution, index, step, scanner.Text(), false)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// appendOutput records a line of step output and streams it to the UI
+func (en *stepEngine) appendOutput(execution *domain.Execution, index int, step *domain.StepExecution, line string, isStderr bool) {
+	stored := line
+	if isStderr {
+		stored = "[stderr] " + line
+	}
+	en.mu.Lock()
+	step.Output = append(step.Output, stored)
+	en.mu.Unlock()
+	en.send(messages.StepOutputMsg{
+		StepRef:   stepRef(execution),
+		StepIndex: index,
+		Line:      line,
+		IsStderr:  isStderr,
+	})
+}
+
+// runGate holds a wait step until the run is resumed. The execution is
+// shown as paused while waiting, so the usual resume key approves the gate.
+// It returns false if the run was cancelled instead.
+func (en *stepEngine) runGate(c runControls, execution *domain.Execution, index int, step *domain.StepExecution, def *workflow.StepDefinition) bool {
+	message := def.Message
+	if message == "" {
+		message = fmt.Sprintf("Approve %s to continue", def.Name)
+	}
+
+	en.mu.Lock()
+	execution.Current = index
+	execution.Status = domain.ExecutionPaused
+	step.Attempt = 1
+	step.Status = domain.StepRunning
+	step.StartTime = time.Now()
+	step.Command = "wait: " + message
+	en.mu.Unlock()
+
+	c.pause.Pause()
+	en.send(messages.StepStartedMsg{
+		StepRef:   stepRef(execution),
+		StepIndex: index,
+		StepName:  step.Name,
+		Command:   step.Command,
+		Attempt:   1,
+	})
+	en.send(messages.StepWaitingMsg{
+		StepRef:   stepRef(execution),
+		StepIndex: index,
+		StepName:  step.Name,
+		Message:   message,
+	})
+
+	c.pause.WaitIfPaused(c.ctx.Done())
+
+	en.mu.Lock()
+	step.EndTime = time.Now()
+	step.Duration = step.EndTime.Sub(step.StartTime)
+	if c.canceled() {
+		step.Status = domain.StepFailed
+		step.Error = "cancelled"
+	} else {
+		step.Status = domain.StepSuccess
+		execution.Status = domain.ExecutionRunning
+	}
+	status := step.Status
+	en.mu.Unlock()
+
+	en.send(messages.StepCompletedMsg{
+		StepRef:   stepRef(execution),
+		StepIndex: index,
+		Status:    status,
+		Duration:  step.Duration,
+		Error:     step.Error,
+	})
+
+	return status == domain.StepSuccess
+}
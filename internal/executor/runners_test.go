@@ -0,0 +1,304 @@
+package executor
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+// singleStepWorkflow returns a workflow holding one step definition
+func singleStepWorkflow(def *workflow.StepDefinition) *workflow.Workflow {
+	def.StepName = domain.StepName(def.Name)
+	return &workflow.Workflow{Name: "custom", Steps: []*workflow.StepDefinition{def}}
+}
+
+func TestStepEngine_ShellStep(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell steps need sh")
+	}
+
+	t.Run("runs the command with story environment", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		en.config.WorkingDir = t.TempDir()
+		en.setWorkflow(singleStepWorkflow(&workflow.StepDefinition{
+			Name:    "tests",
+			Type:    workflow.StepTypeShell,
+			Command: `echo "key=$BMAD_STORY_KEY extra=$EXTRA"`,
+			Env:     map[string]string{"EXTRA": "yes"},
+		}))
+		execution := singleStepExecution(createTestStory(), "tests")
+
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+
+		step := execution.Steps[0]
+		assert.Equal(t, domain.ExecutionRunning, execution.Status)
+		assert.Equal(t, domain.StepSuccess, step.Status)
+		assert.Equal(t, "sh", step.CommandName)
+		assert.Equal(t, []string{"key=3-1-test-story extra=yes"}, step.Output)
+	})
+
+	t.Run("non-zero exit fails the execution", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		en.config.Retries = 0
+		en.setWorkflow(singleStepWorkflow(&workflow.StepDefinition{
+			Name:    "tests",
+			Type:    workflow.StepTypeShell,
+			Command: "exit 3",
+		}))
+		execution := singleStepExecution(createTestStory(), "tests")
+
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+
+		assert.Equal(t, domain.ExecutionFailed, execution.Status)
+		assert.Equal(t, domain.StepFailed, execution.Steps[0].Status)
+	})
+}
+
+func TestStepEngine_HTTPStep(t *testing.T) {
+	t.Run("renders the request and records the response", func(t *testing.T) {
+		var gotPath, gotBody, gotHeader string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotPath = r.URL.Path
+			gotHeader = r.Header.Get("X-Token")
+			body, _ := io.ReadAll(r.Body)
+			gotBody = string(body)
+			_, _ = w.Write([]byte("ok\nqueued"))
+		}))
+		defer server.Close()
+
+		en, _ := recordingEngine(t)
+		en.setWorkflow(singleStepWorkflow(&workflow.StepDefinition{
+			Name:    "notify",
+			Type:    workflow.StepTypeHTTP,
+			URL:     server.URL + "/stories/{{.Story.Key}}",
+			Headers: map[string]string{"X-Token": "secret"},
+			Body:    `{"epic": {{.Story.Epic}}}`,
+		}))
+		execution := singleStepExecution(createTestStory(), "notify")
+
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+
+		step := execution.Steps[0]
+		assert.Equal(t, domain.StepSuccess, step.Status)
+		assert.Equal(t, "/stories/3-1-test-story", gotPath)
+		assert.Equal(t, `{"epic": 3}`, gotBody)
+		assert.Equal(t, "secret", gotHeader)
+		assert.True(t, strings.HasPrefix(step.Command, "POST "))
+		assert.Equal(t, []string{"HTTP 200 OK", "ok", "queued"}, step.Output)
+	})
+
+	t.Run("error status fails the step", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		en, _ := recordingEngine(t)
+		en.config.Retries = 0
+		en.setWorkflow(singleStepWorkflow(&workflow.StepDefinition{
+			Name: "notify",
+			Type: workflow.StepTypeHTTP,
+			URL:  server.URL,
+		}))
+		execution := singleStepExecution(createTestStory(), "notify")
+
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+
+		assert.Equal(t, domain.ExecutionFailed, execution.Status)
+		assert.Contains(t, execution.Error, "500")
+	})
+}
+
+func TestStepEngine_WaitStep(t *testing.T) {
+	gate := &workflow.StepDefinition{Name: "approve", Type: workflow.StepTypeWait, Message: "Check the preview"}
+
+	t.Run("resume approves the gate", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		en.setWorkflow(singleStepWorkflow(gate))
+		pause := NewPauseController()
+		execution := singleStepExecution(createTestStory(), "approve")
+
+		done := make(chan struct{})
+		go func() {
+			en.runSteps(runControls{ctx: context.Background(), pause: pause}, execution, nil)
+			close(done)
+		}()
+
+		require.Eventually(t, pause.IsPaused, time.Second, 10*time.Millisecond)
+		pause.Resume()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("gate did not open after resume")
+		}
+		assert.Equal(t, domain.ExecutionRunning, execution.Status)
+		assert.Equal(t, domain.StepSuccess, execution.Steps[0].Status)
+		assert.Equal(t, "wait: Check the preview", execution.Steps[0].Command)
+	})
+
+	t.Run("cancel while waiting cancels the run", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		en.setWorkflow(singleStepWorkflow(gate))
+		pause := NewPauseController()
+		execution := singleStepExecution(createTestStory(), "approve")
+
+		done := make(chan struct{})
+		go func() {
+			en.runSteps(runControls{ctx: context.Background(), pause: pause}, execution, nil)
+			close(done)
+		}()
+
+		require.Eventually(t, pause.IsPaused, time.Second, 10*time.Millisecond)
+		pause.Cancel()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("gate did not stop after cancel")
+		}
+		assert.Equal(t, domain.ExecutionCancelled, execution.Status)
+		assert.Equal(t, domain.StepFailed, execution.Steps[0].Status)
+	})
+}
+
+func TestExecutor_RetryFromFailed(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell steps need sh")
+	}
+
+	dir := t.TempDir()
+	cfg := createTestConfig()
+	cfg.Retries = 0
+	cfg.WorkingDir = dir
+	e := New(cfg)
+	e.SetWorkflow(&workflow.Workflow{Name: "custom", Steps: []*workflow.StepDefinition{
+		{Name: "build", StepName: "build", Type: workflow.StepTypeShell, Command: "echo built >> build.log"},
+		{Name: "check", StepName: "check", Type: workflow.StepTypeShell, Command: "test -f ready"},
+	}})
+
+	// No retry before anything has failed
+	assert.IsType(t, messages.ErrorMsg{}, e.RetryFromFailed()())
+
+	execution := domain.NewExecution(createTestStory())
+	execution.Steps = []*domain.StepExecution{
+		{Name: "build", Status: domain.StepPending},
+		{Name: "check", Status: domain.StepPending},
+	}
+	execution.Status = domain.ExecutionRunning
+	e.execution = execution
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+	msg := e.run(0, time.Now())
+	require.Equal(t, domain.ExecutionFailed, msg.(messages.ExecutionCompletedMsg).Status)
+	require.True(t, execution.CanRetry())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ready"), nil, 0o644))
+	msg = e.RetryFromFailed()()
+
+	assert.Equal(t, domain.ExecutionCompleted, msg.(messages.ExecutionCompletedMsg).Status)
+	assert.Same(t, execution, e.GetExecution())
+	assert.Equal(t, domain.StepSuccess, execution.Steps[1].Status)
+	assert.Equal(t, 2, execution.Steps[1].Attempt)
+	require.Len(t, execution.Steps[1].PreviousAttempts, 1)
+	assert.Equal(t, 1, execution.Steps[1].PreviousAttempts[0].Number)
+	assert.Contains(t, execution.Steps[1].PreviousAttempts[0].Error, "exit status")
+	assert.Empty(t, execution.Steps[1].Error)
+
+	// The step that had already succeeded is not run again
+	log, err := os.ReadFile(filepath.Join(dir, "build.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "built\n", string(log))
+}
+
+func TestStepEngine_WorkflowSteps(t *testing.T) {
+	t.Run("executions follow the workflow's steps", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		en.setWorkflow(&workflow.Workflow{Name: "custom", Steps: []*workflow.StepDefinition{
+			{Name: "dev-story", StepName: domain.StepDevStory, PromptTemplate: "dev"},
+			{Name: "lint", PromptTemplate: "lint"},
+		}})
+
+		execution := en.newExecution(createTestStory())
+
+		require.Len(t, execution.Steps, 2)
+		assert.Equal(t, domain.StepDevStory, execution.Steps[0].Name)
+		assert.Equal(t, domain.StepName("lint"), execution.Steps[1].Name)
+	})
+
+	t.Run("agent steps render their prompt template", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		def := &workflow.StepDefinition{
+			Name:           "lint",
+			PromptTemplate: "Lint {{.Story.Key}} with {{.Variables.linter}}\n",
+		}
+		w := singleStepWorkflow(def)
+		w.Variables = map[string]string{"linter": "golangci-lint"}
+		en.setWorkflow(w)
+		execution := singleStepExecution(createTestStory(), "lint")
+
+		require.NoError(t, en.prepareStep(execution.Steps[0], execution, def))
+
+		step := execution.Steps[0]
+		assert.Equal(t, "claude", step.CommandName)
+		assert.Equal(t, "Lint 3-1-test-story with golangci-lint", step.CommandArgs[len(step.CommandArgs)-1])
+	})
+
+	t.Run("a template that fails to render fails the step without retrying", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		en.setWorkflow(singleStepWorkflow(&workflow.StepDefinition{Name: "lint", PromptTemplate: "{{.Story.Missing}}"}))
+		execution := singleStepExecution(createTestStory(), "lint")
+
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+
+		assert.Equal(t, domain.ExecutionFailed, execution.Status)
+		assert.Equal(t, 1, execution.Steps[0].Attempt)
+		assert.Contains(t, execution.Error, "template")
+	})
+
+	t.Run("skip_if skips the step when the story file exists", func(t *testing.T) {
+		en, _ := recordingEngine(t)
+		en.setWorkflow(singleStepWorkflow(&workflow.StepDefinition{Name: "draft", PromptTemplate: "x", SkipIf: "file_exists"}))
+		story := createTestStory()
+		story.FileExists = true
+		execution := singleStepExecution(story, "draft")
+
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+
+		assert.Equal(t, domain.StepSkipped, execution.Steps[0].Status)
+	})
+
+	t.Run("allow_failure continues past a failed step", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("shell steps need sh")
+		}
+		en, _ := recordingEngine(t)
+		en.config.Retries = 0
+		en.config.WorkingDir = t.TempDir()
+		en.setWorkflow(&workflow.Workflow{Name: "custom", Steps: []*workflow.StepDefinition{
+			{Name: "lint", StepName: "lint", Type: workflow.StepTypeShell, Command: "exit 1", AllowFailure: true},
+			{Name: "build", StepName: "build", Type: workflow.StepTypeShell, Command: "true"},
+		}})
+		execution := en.newExecution(createTestStory())
+		execution.Status = domain.ExecutionRunning
+
+		en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+
+		assert.Equal(t, domain.ExecutionRunning, execution.Status)
+		assert.Equal(t, domain.StepFailed, execution.Steps[0].Status)
+		assert.Equal(t, domain.StepSuccess, execution.Steps[1].Status)
+	})
+}
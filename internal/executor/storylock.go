@@ -0,0 +1,50 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrStoryRunning is returned when a story is already being executed
+var ErrStoryRunning = errors.New("already running")
+
+// storyLocks tracks which story keys are executing in this process. It is
+// shared by the single, batch and parallel executors so that the TUI and the
+// API cannot run the same story twice at once.
+type storyLocks struct {
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+var runningStories = &storyLocks{running: make(map[string]bool)}
+
+// acquire marks key as running, or returns ErrStoryRunning if it already is
+func (l *storyLocks) acquire(key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.running[key] {
+		return fmt.Errorf("story %s is %w", key, ErrStoryRunning)
+	}
+	l.running[key] = true
+	return nil
+}
+
+// release marks key as no longer running
+func (l *storyLocks) release(key string) {
+	l.mu.Lock()
+	delete(l.running, key)
+	l.mu.Unlock()
+}
+
+// isRunning reports whether key is currently executing
+func (l *storyLocks) isRunning(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.running[key]
+}
+
+// IsStoryRunning reports whether any executor is currently running the story
+func IsStoryRunning(key string) bool {
+	return runningStories.isRunning(key)
+}
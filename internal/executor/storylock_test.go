@@ -0,0 +1,94 @@
+package executor
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+func TestStoryLocks(t *testing.T) {
+	locks := &storyLocks{running: make(map[string]bool)}
+
+	t.Run("acquire and release", func(t *testing.T) {
+		require.NoError(t, locks.acquire("3-1-auth"))
+		assert.True(t, locks.isRunning("3-1-auth"))
+
+		locks.release("3-1-auth")
+		assert.False(t, locks.isRunning("3-1-auth"))
+	})
+
+	t.Run("second acquire reports already running", func(t *testing.T) {
+		require.NoError(t, locks.acquire("3-2-reset"))
+		defer locks.release("3-2-reset")
+
+		err := locks.acquire("3-2-reset")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrStoryRunning))
+		assert.Equal(t, "story 3-2-reset is already running", err.Error())
+	})
+
+	t.Run("different stories do not block each other", func(t *testing.T) {
+		require.NoError(t, locks.acquire("4-1-a"))
+		defer locks.release("4-1-a")
+		assert.NoError(t, locks.acquire("4-2-b"))
+		locks.release("4-2-b")
+	})
+
+	t.Run("only one concurrent acquire wins", func(t *testing.T) {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		wins := 0
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if locks.acquire("5-1-race") == nil {
+					mu.Lock()
+					wins++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		locks.release("5-1-race")
+
+		assert.Equal(t, 1, wins)
+	})
+}
+
+func TestExecutor_ExecuteRejectsRunningStory(t *testing.T) {
+	story := createTestStory()
+	require.NoError(t, runningStories.acquire(story.Key))
+	defer runningStories.release(story.Key)
+
+	e := New(createTestConfig())
+	msg := e.Execute(story)()
+
+	errMsg, ok := msg.(messages.ErrorMsg)
+	require.True(t, ok)
+	assert.ErrorIs(t, errMsg.Error, ErrStoryRunning)
+	assert.Nil(t, e.GetExecution())
+	assert.True(t, IsStoryRunning(story.Key))
+}
+
+func TestBatchExecutor_ExecuteItemRejectsRunningStory(t *testing.T) {
+	story := createTestStory()
+	require.NoError(t, runningStories.acquire(story.Key))
+	defer runningStories.release(story.Key)
+
+	b := NewBatchExecutor(createTestConfig())
+	b.AddToQueue([]domain.Story{story})
+	item := b.GetQueue().Items[0]
+
+	b.executeItem(0, item)
+
+	assert.Equal(t, domain.ExecutionFailed, item.Status)
+	require.NotNil(t, item.Execution)
+	assert.Contains(t, item.Execution.Error, "already running")
+}
@@ -0,0 +1,55 @@
+package executor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// commitsChanges reports whether a built-in step creates commits
+func commitsChanges(name domain.StepName) bool {
+	return name == domain.StepGitCommit || name == domain.StepResolveConflicts
+}
+
+// commitTrailers renders the configured trailer lines for an execution.
+// Placeholders: {execution_id}, {story}, {epic}.
+func commitTrailers(templates []string, execution *domain.Execution) []string {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	r := strings.NewReplacer(
+		"{execution_id}", execution.ID,
+		"{story}", execution.Story.Key,
+		"{epic}", strconv.Itoa(execution.Story.Epic),
+	)
+
+	trailers := make([]string, 0, len(templates))
+	for _, t := range templates {
+		if line := strings.TrimSpace(r.Replace(t)); line != "" {
+			trailers = append(trailers, line)
+		}
+	}
+	return trailers
+}
+
+// withTrailerInstructions appends instructions to add trailers to the
+// prompt of an agent command. The prompt is always the last argument.
+func withTrailerInstructions(spec CommandSpec, trailers []string) CommandSpec {
+	if len(trailers) == 0 || len(spec.Args) == 0 {
+		return spec
+	}
+
+	instructions := fmt.Sprintf(
+		" End every commit message with these git trailers, after a blank line, exactly as written:\n%s\n"+
+			"If you open a pull request, add the same lines to the end of its description.",
+		strings.Join(trailers, "\n"),
+	)
+
+	args := make([]string, len(spec.Args))
+	copy(args, spec.Args)
+	args[len(args)-1] += instructions
+	return CommandSpec{Name: spec.Name, Args: args}
+}
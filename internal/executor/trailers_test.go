@@ -0,0 +1,70 @@
+package executor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+func TestCommitTrailers(t *testing.T) {
+	execution := domain.NewExecution(createTestStory())
+	execution.ID = "exec-123"
+
+	t.Run("replaces placeholders", func(t *testing.T) {
+		trailers := commitTrailers([]string{"Automated-by: bmad {execution_id}", "Story: {story} (epic {epic})"}, execution)
+		assert.Equal(t, []string{"Automated-by: bmad exec-123", "Story: 3-1-test-story (epic 3)"}, trailers)
+	})
+
+	t.Run("no templates means no trailers", func(t *testing.T) {
+		assert.Empty(t, commitTrailers(nil, execution))
+	})
+}
+
+func TestWithTrailerInstructions(t *testing.T) {
+	spec := CommandSpec{Name: "claude", Args: []string{"-p", "Commit the story."}}
+
+	got := withTrailerInstructions(spec, []string{"Automated-by: bmad exec-123"})
+
+	assert.Equal(t, "-p", got.Args[0])
+	assert.Contains(t, got.Args[1], "Commit the story.")
+	assert.Contains(t, got.Args[1], "\nAutomated-by: bmad exec-123\n")
+	assert.Contains(t, got.Args[1], "pull request")
+	assert.Equal(t, "Commit the story.", spec.Args[1], "original spec is not modified")
+
+	assert.Equal(t, spec, withTrailerInstructions(spec, nil))
+}
+
+func TestStepEngine_PrepareStepTrailers(t *testing.T) {
+	en, _ := recordingEngine(t)
+	en.config.CommitTrailers = []string{"Automated-by: bmad {execution_id}"}
+	execution := domain.NewExecution(createTestStory())
+
+	commit := &domain.StepExecution{Name: domain.StepGitCommit}
+	en.prepareStep(commit, execution, nil)
+	assert.Contains(t, commit.Command, "Automated-by: bmad "+execution.ID)
+
+	dev := &domain.StepExecution{Name: domain.StepDevStory}
+	en.prepareStep(dev, execution, nil)
+	assert.NotContains(t, dev.Command, "Automated-by")
+}
+
+func TestStepEngine_ShellStepTrailerEnv(t *testing.T) {
+	en, _ := recordingEngine(t)
+	en.config.CommitTrailers = []string{"Automated-by: bmad {execution_id}"}
+	en.setWorkflow(singleStepWorkflow(&workflow.StepDefinition{
+		Name:    "commit",
+		Type:    workflow.StepTypeShell,
+		Command: `echo "$BMAD_EXECUTION_ID|$BMAD_COMMIT_TRAILERS"`,
+	}))
+	execution := singleStepExecution(createTestStory(), "commit")
+
+	en.runSteps(runControls{ctx: context.Background(), pause: NewPauseController()}, execution, nil)
+
+	require.Len(t, execution.Steps[0].Output, 1)
+	assert.Equal(t, execution.ID+"|Automated-by: bmad "+execution.ID, execution.Steps[0].Output[0])
+}
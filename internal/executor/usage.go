@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// maxToolSummary caps the length of the tool call lines shown for a stream
+const maxToolSummary = 120
+
+// withUsageOutput makes a Claude CLI command stream its output as JSON
+// events, which carry the token usage of the call
+func withUsageOutput(spec CommandSpec) CommandSpec {
+	args := make([]string, len(spec.Args), len(spec.Args)+3)
+	copy(args, spec.Args)
+	args = append(args, "--output-format", "stream-json", "--verbose")
+	return CommandSpec{Name: spec.Name, Args: args}
+}
+
+// streamEvent is the part of a Claude CLI JSON event the decoder reads
+type streamEvent struct {
+	Type    string `json:"type"`
+	Message struct {
+		Content []struct {
+			Type  string         `json:"type"`
+			Text  string         `json:"text"`
+			Name  string         `json:"name"`
+			Input map[string]any `json:"input"`
+		} `json:"content"`
+	} `json:"message"`
+
+	// Result events
+	IsError      bool        `json:"is_error"`
+	Result       string      `json:"result"`
+	TotalCostUSD float64     `json:"total_cost_usd"`
+	CostUSD      float64     `json:"cost_usd"` // Older CLI versions
+	Usage        *eventUsage `json:"usage"`
+}
+
+type eventUsage struct {
+	InputTokens              int64 `json:"input_tokens"`
+	OutputTokens             int64 `json:"output_tokens"`
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+}
+
+// streamDecoder turns the JSON events of a Claude CLI call back into
+// readable output and collects the usage from its result event
+type streamDecoder struct {
+	pricing domain.Pricing
+	usage   domain.Usage
+}
+
+func newStreamDecoder(pricing domain.Pricing) *streamDecoder {
+	return &streamDecoder{pricing: pricing}
+}
+
+// decode returns the output lines for one line of the stream. Lines that
+// are not JSON events are passed through as they are.
+func (d *streamDecoder) decode(line string) []string {
+	if !strings.HasPrefix(strings.TrimSpace(line), "{") {
+		return []string{line}
+	}
+	var event streamEvent
+	if err := json.Unmarshal([]byte(line), &event); err != nil || event.Type == "" {
+		return []string{line}
+	}
+
+	switch event.Type {
+	case "assistant":
+		var lines []string
+		for _, c := range event.Message.Content {
+			switch c.Type {
+			case "text":
+				lines = append(lines, strings.Split(strings.TrimRight(c.Text, "\n"), "\n")...)
+			case "tool_use":
+				lines = append(lines, toolSummary(c.Name, c.Input))
+			}
+		}
+		return lines
+
+	case "result":
+		d.addResult(event)
+		if event.IsError && event.Result != "" {
+			return []string{"Error: " + event.Result}
+		}
+	}
+	return nil
+}
+
+// addResult records the usage of a result event. The cost reported by the
+// CLI is used when there is one, otherwise it is estimated.
+func (d *streamDecoder) addResult(event streamEvent) {
+	var u domain.Usage
+	if event.Usage != nil {
+		u = domain.Usage{
+			InputTokens:      event.Usage.InputTokens,
+			OutputTokens:     event.Usage.OutputTokens,
+			CacheReadTokens:  event.Usage.CacheReadInputTokens,
+			CacheWriteTokens: event.Usage.CacheCreationInputTokens,
+		}
+	}
+	u.CostUSD = event.TotalCostUSD
+	if u.CostUSD == 0 {
+		u.CostUSD = event.CostUSD
+	}
+	if u.CostUSD == 0 {
+		u.CostUSD = d.pricing.Cost(u)
+	}
+	d.usage.Add(u)
+}
+
+// toolSummary describes a tool call in one line, e.g. "→ Bash: go test ./..."
+func toolSummary(name string, input map[string]any) string {
+	summary := "→ " + name
+	for _, key := range []string{"command", "file_path", "pattern", "description"} {
+		if value, ok := input[key].(string); ok && value != "" {
+			summary += ": " + strings.ReplaceAll(value, "\n", " ")
+			break
+		}
+	}
+	if runes := []rune(summary); len(runes) > maxToolSummary {
+		summary = string(runes[:maxToolSummary-3]) + "..."
+	}
+	return summary
+}
+
+// pricing returns the configured token prices
+func (en *stepEngine) pricing() domain.Pricing {
+	return domain.Pricing{
+		InputPerMTok:  en.config.UsageInputPrice,
+		OutputPerMTok: en.config.UsageOutputPrice,
+	}
+}
+
+// runAgent runs an agent step. With usage tracking on, the streamed JSON
+// events are decoded for display and the step's usage is added up.
+func (en *stepEngine) runAgent(ctx context.Context, execution *domain.Execution, index int, step *domain.StepExecution) error {
+	if !en.config.UsageTracking || step.CommandName != "claude" {
+		return en.runCommand(ctx, execution, index, step)
+	}
+
+	decoder := newStreamDecoder(en.pricing())
+	err := en.runCommandWith(ctx, execution, index, step, commandOptions{decode: decoder.decode})
+
+	en.mu.Lock()
+	step.Usage.Add(decoder.usage)
+	en.mu.Unlock()
+	return err
+}
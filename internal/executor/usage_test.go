@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestWithUsageOutput(t *testing.T) {
+	spec := CommandSpec{Name: "claude", Args: []string{"-p", "Review the story."}}
+
+	got := withUsageOutput(spec)
+
+	assert.Equal(t, []string{"-p", "Review the story.", "--output-format", "stream-json", "--verbose"}, got.Args)
+	assert.Len(t, spec.Args, 2, "original spec is not modified")
+}
+
+func TestStreamDecoder(t *testing.T) {
+	pricing := domain.Pricing{InputPerMTok: 3, OutputPerMTok: 15}
+
+	t.Run("decodes events into output", func(t *testing.T) {
+		d := newStreamDecoder(pricing)
+
+		assert.Empty(t, d.decode(`{"type":"system","subtype":"init","session_id":"abc"}`))
+		assert.Equal(t, []string{"Reading the story.", "Then the tests."},
+			d.decode(`{"type":"assistant","message":{"content":[{"type":"text","text":"Reading the story.\nThen the tests.\n"}]}}`))
+		assert.Equal(t, []string{"→ Bash: go test ./..."},
+			d.decode(`{"type":"assistant","message":{"content":[{"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}}]}}`))
+		assert.Equal(t, []string{"→ TodoWrite"},
+			d.decode(`{"type":"assistant","message":{"content":[{"type":"tool_use","name":"TodoWrite","input":{"todos":[]}}]}}`))
+		assert.Empty(t, d.decode(`{"type":"user","message":{"content":[{"type":"tool_result","content":"ok"}]}}`))
+		assert.Equal(t, []string{"plain text", "{not json"}, append(d.decode("plain text"), d.decode("{not json")...))
+	})
+
+	t.Run("records the reported cost", func(t *testing.T) {
+		d := newStreamDecoder(pricing)
+		assert.Empty(t, d.decode(`{"type":"result","subtype":"success","result":"Done","total_cost_usd":0.42,`+
+			`"usage":{"input_tokens":100,"output_tokens":200,"cache_creation_input_tokens":300,"cache_read_input_tokens":400}}`))
+
+		assert.Equal(t, domain.Usage{
+			InputTokens:      100,
+			OutputTokens:     200,
+			CacheWriteTokens: 300,
+			CacheReadTokens:  400,
+			CostUSD:          0.42,
+		}, d.usage)
+	})
+
+	t.Run("estimates the cost when none is reported", func(t *testing.T) {
+		d := newStreamDecoder(pricing)
+		d.decode(`{"type":"result","usage":{"input_tokens":1000000,"output_tokens":1000000}}`)
+		assert.InDelta(t, 18.0, d.usage.CostUSD, 1e-9)
+	})
+
+	t.Run("shows errors", func(t *testing.T) {
+		d := newStreamDecoder(pricing)
+		assert.Equal(t, []string{"Error: Credit balance is too low"},
+			d.decode(`{"type":"result","is_error":true,"result":"Credit balance is too low"}`))
+	})
+}
+
+func TestToolSummary(t *testing.T) {
+	assert.Equal(t, "→ Edit: internal/app/app.go", toolSummary("Edit", map[string]any{"file_path": "internal/app/app.go"}))
+
+	long := toolSummary("Bash", map[string]any{"command": strings.Repeat("é", 200)})
+	assert.Len(t, []rune(long), maxToolSummary)
+	assert.True(t, strings.HasSuffix(long, "..."))
+}
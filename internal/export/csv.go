@@ -0,0 +1,130 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// FormatCSV is the CSV export format
+const FormatCSV = "csv"
+
+// Files written by CSV
+const (
+	ExecutionsCSVFile = "executions.csv"
+	StepsCSVFile      = "steps.csv"
+	OutputsCSVFile    = "outputs.csv"
+)
+
+var executionHeader = []string{"id", "story_key", "story_epic", "story_status", "story_title", "status",
+	"start_time", "end_time", "duration_ms", "step_count", "error"}
+
+var stepHeader = []string{"id", "execution_id", "story_key", "step_name", "status", "start_time", "end_time",
+	"duration_ms", "attempt", "command", "error", "output_lines"}
+
+var outputHeader = []string{"step_id", "execution_id", "step_name", "line", "stream", "content"}
+
+// CSV writes executions and steps as CSV files in dir, creating it if
+// needed. With withOutput, step output is written to a third file with one
+// row per line.
+func CSV(ctx context.Context, store storage.Storage, dir string, withOutput bool) (*Result, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	execFile := newCSVFile(dir, ExecutionsCSVFile, executionHeader)
+	stepFile := newCSVFile(dir, StepsCSVFile, stepHeader)
+	files := []*csvFile{execFile, stepFile}
+	var outFile *csvFile
+	if withOutput {
+		outFile = newCSVFile(dir, OutputsCSVFile, outputHeader)
+		files = append(files, outFile)
+	}
+
+	result := &Result{}
+	err := eachExecution(ctx, store, func(rec *storage.ExecutionRecord) error {
+		execFile.write(rec.ID, rec.StoryKey, strconv.Itoa(rec.StoryEpic), rec.StoryStatus, rec.StoryTitle,
+			string(rec.Status), csvTime(rec.StartTime), csvTime(rec.EndTime),
+			strconv.FormatInt(rec.Duration.Milliseconds(), 10), strconv.Itoa(len(rec.Steps)), rec.Error)
+		result.Executions++
+
+		for _, step := range rec.Steps {
+			stepFile.write(step.ID, rec.ID, rec.StoryKey, string(step.StepName), string(step.Status),
+				csvTime(step.StartTime), csvTime(step.EndTime), strconv.FormatInt(step.Duration.Milliseconds(), 10),
+				strconv.Itoa(step.Attempt), step.Command, step.Error, strconv.Itoa(step.OutputSize))
+			result.Steps++
+
+			if outFile == nil || step.OutputSize == 0 {
+				continue
+			}
+			output, err := store.GetStepOutput(ctx, step.ID)
+			if err != nil {
+				return fmt.Errorf("failed to export output of step %s: %w", step.ID, err)
+			}
+			for i, line := range output {
+				stream := "stdout"
+				if text, ok := strings.CutPrefix(line, "[stderr] "); ok {
+					stream, line = "stderr", text
+				}
+				outFile.write(step.ID, rec.ID, string(step.StepName), strconv.Itoa(i+1), stream, line)
+				result.Outputs++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if err := f.save(); err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, f.path)
+	}
+	return result, nil
+}
+
+// csvFile is a CSV file being built
+type csvFile struct {
+	path string
+	buf  *bytes.Buffer
+	w    *csv.Writer
+}
+
+func newCSVFile(dir, name string, header []string) *csvFile {
+	buf := &bytes.Buffer{}
+	f := &csvFile{path: filepath.Join(dir, name), buf: buf, w: csv.NewWriter(buf)}
+	f.write(header...)
+	return f
+}
+
+// write adds a row. Errors surface from the buffered writer in save.
+func (f *csvFile) write(fields ...string) {
+	_ = f.w.Write(fields)
+}
+
+// save flushes the rows and writes the file to disk
+func (f *csvFile) save() error {
+	f.w.Flush()
+	if err := f.w.Error(); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", f.path, err)
+	}
+	return writeFile(f.path, f.buf.Bytes())
+}
+
+// csvTime formats t as RFC 3339, or "" for a zero time
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func readCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	return rows
+}
+
+func TestCSV(t *testing.T) {
+	store := storeWithOutput(t)
+	dir := t.TempDir()
+
+	result, err := CSV(context.Background(), store, dir, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Executions)
+	assert.Equal(t, 2, result.Outputs)
+	require.Len(t, result.Files, 3)
+
+	executions := readCSV(t, filepath.Join(dir, ExecutionsCSVFile))
+	require.Len(t, executions, 2)
+	assert.Equal(t, executionHeader, executions[0])
+	assert.Equal(t, "1-1-first", executions[1][1])
+
+	steps := readCSV(t, filepath.Join(dir, StepsCSVFile))
+	assert.Len(t, steps, 1+len(domain.AllSteps()))
+
+	outputs := readCSV(t, filepath.Join(dir, OutputsCSVFile))
+	require.Len(t, outputs, 3)
+	assert.Equal(t, []string{"1", "stdout", "one"}, outputs[1][3:])
+	assert.Equal(t, []string{"2", "stderr", "two, with a comma"}, outputs[2][3:])
+}
+
+func TestCSV_WithoutOutput(t *testing.T) {
+	store := storeWithOutput(t)
+	dir := t.TempDir()
+
+	result, err := CSV(context.Background(), store, dir, false)
+	require.NoError(t, err)
+	assert.Len(t, result.Files, 2)
+
+	_, err = os.Stat(filepath.Join(dir, OutputsCSVFile))
+	assert.True(t, os.IsNotExist(err))
+}
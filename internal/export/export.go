@@ -0,0 +1,55 @@
+// Package export writes stored execution history to files for analysis
+// outside BMAD
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// pageSize is how many executions are read from storage at a time
+const pageSize = 500
+
+// Result reports what an export wrote
+type Result struct {
+	Executions int
+	Steps      int
+	Outputs    int
+	Files      []string
+}
+
+// eachExecution calls fn for every stored execution, newest first, reading
+// them from storage a page at a time
+func eachExecution(ctx context.Context, store storage.Storage, fn func(*storage.ExecutionRecord) error) error {
+	for offset := 0; ; offset += pageSize {
+		records, err := store.ListExecutions(ctx, &storage.ExecutionFilter{Limit: pageSize, Offset: offset})
+		if err != nil {
+			return err
+		}
+		for _, rec := range records {
+			if err := fn(rec); err != nil {
+				return err
+			}
+		}
+		if len(records) < pageSize {
+			return nil
+		}
+	}
+}
+
+// writeFile writes data to a temporary file and renames it into place, so
+// a failed export never leaves a truncated file behind
+func writeFile(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+	"github.com/robertguss/bmad-automate-go/internal/util"
+)
+
+// activityDays is how far back the activity chart of a report without a
+// start date goes, matching what GetStats counts by day
+const activityDays = 30
+
+// Activity chart geometry, in SVG user units
+const (
+	chartHeight = 160
+	barWidth    = 18
+	barGap      = 4
+)
+
+// StatsHTML writes stats as a standalone HTML report to path: an overview,
+// step performance and daily activity, with the charts drawn inline so the
+// file can be shared on its own. r is the range the stats cover; the
+// activity chart ends at now when r has no end.
+func StatsHTML(path string, stats *storage.Stats, r domain.DateRange, now time.Time) error {
+	var buf bytes.Buffer
+	if err := statsTemplate.Execute(&buf, newStatsReport(stats, r, now)); err != nil {
+		return fmt.Errorf("failed to render stats report: %w", err)
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+// statsReport is what the report template renders
+type statsReport struct {
+	Range     string
+	Generated string
+	Stats     *storage.Stats
+	Outcomes  []outcome
+	Steps     []stepRow
+	Days      []dayBar
+	MaxDay    int
+	ChartW    int
+	BarW      int
+}
+
+// outcome is a segment of the overview's outcome bar
+type outcome struct {
+	Label   string
+	Count   int
+	Percent float64
+	Class   string
+}
+
+// stepRow is a row of the step performance table
+type stepRow struct {
+	Name        domain.StepName
+	Stats       *storage.StepStats
+	AvgPercent  float64 // Average duration relative to the slowest step
+	AvgDuration string
+	MinDuration string
+	MaxDuration string
+}
+
+// dayBar is a bar of the activity chart
+type dayBar struct {
+	Label  string
+	Count  int
+	X, Y   int
+	Height int
+}
+
+func newStatsReport(stats *storage.Stats, r domain.DateRange, now time.Time) statsReport {
+	label := r.String()
+	report := statsReport{
+		Range:     strings.ToUpper(label[:1]) + label[1:],
+		Generated: now.Format("Jan 2 2006 15:04"),
+		Stats:     stats,
+	}
+
+	for _, o := range []outcome{
+		{Label: "Successful", Count: stats.SuccessfulCount, Class: "ok"},
+		{Label: "Failed", Count: stats.FailedCount, Class: "fail"},
+		{Label: "Cancelled", Count: stats.CancelledCount, Class: "cancel"},
+	} {
+		if o.Count > 0 && stats.TotalExecutions > 0 {
+			o.Percent = float64(o.Count) / float64(stats.TotalExecutions) * 100
+			report.Outcomes = append(report.Outcomes, o)
+		}
+	}
+
+	var slowest time.Duration
+	for _, ss := range stats.StepStats {
+		slowest = max(slowest, ss.AvgDuration)
+	}
+	for _, name := range stepOrder(stats.StepStats) {
+		ss := stats.StepStats[name]
+		row := stepRow{
+			Name:        name,
+			Stats:       ss,
+			AvgDuration: util.FormatDurationExtended(ss.AvgDuration),
+			MinDuration: util.FormatDurationExtended(ss.MinDuration),
+			MaxDuration: util.FormatDurationExtended(ss.MaxDuration),
+		}
+		if slowest > 0 {
+			row.AvgPercent = float64(ss.AvgDuration) / float64(slowest) * 100
+		}
+		report.Steps = append(report.Steps, row)
+	}
+
+	end := now
+	if !r.Until.IsZero() && r.Until.Before(end) {
+		end = r.Until.Add(-time.Nanosecond)
+	}
+	days := activityDays
+	if !r.Since.IsZero() {
+		days = max(int(end.Sub(r.Since).Hours()/24)+1, 1)
+	}
+	counts := util.DailyCounts(stats.ExecutionsByDay, days, end)
+	report.MaxDay = max(slices.Max(counts), 1)
+	report.ChartW = days * (barWidth + barGap)
+	report.BarW = barWidth
+	for i, count := range counts {
+		height := count * chartHeight / report.MaxDay
+		report.Days = append(report.Days, dayBar{
+			Label:  end.AddDate(0, 0, -(days - 1 - i)).Format("Jan 2"),
+			Count:  count,
+			X:      i * (barWidth + barGap),
+			Y:      chartHeight - height,
+			Height: height,
+		})
+	}
+	return report
+}
+
+// stepOrder lists the steps in stats in workflow order, followed by those
+// of custom workflows by name
+func stepOrder(stats map[domain.StepName]*storage.StepStats) []domain.StepName {
+	var order, custom []domain.StepName
+	for _, name := range domain.AllSteps() {
+		if _, ok := stats[name]; ok {
+			order = append(order, name)
+		}
+	}
+	for name := range stats {
+		if !slices.Contains(order, name) {
+			custom = append(custom, name)
+		}
+	}
+	slices.Sort(custom)
+	return append(order, custom...)
+}
+
+var statsTemplate = template.Must(template.New("stats").Funcs(template.FuncMap{
+	"duration": util.FormatDurationExtended,
+	"percent":  func(f float64) string { return fmt.Sprintf("%.1f%%", f) },
+	"mod":      func(a, b int) int { return a % b },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>BMAD Automation Report - {{.Range}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 960px; color: #1f2328; }
+h1 { margin-bottom: 0; }
+h2 { margin-top: 2.5rem; border-bottom: 1px solid #d0d7de; padding-bottom: .3rem; }
+.meta { color: #656d76; }
+.cards { display: flex; gap: 1rem; flex-wrap: wrap; }
+.card { border: 1px solid #d0d7de; border-radius: 6px; padding: .8rem 1.2rem; min-width: 140px; }
+.card .value { font-size: 1.6rem; font-weight: 600; }
+.card .label { color: #656d76; font-size: .85rem; }
+.outcomes { display: flex; height: 24px; border-radius: 6px; overflow: hidden; margin-top: 1rem; }
+.ok { background: #2da44e; }
+.fail { background: #cf222e; }
+.cancel { background: #bf8700; }
+.legend span { display: inline-block; margin-right: 1.2rem; }
+.legend i { display: inline-block; width: .8rem; height: .8rem; border-radius: 2px; margin-right: .3rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: .4rem .6rem; border-bottom: 1px solid #eaeef2; }
+th { color: #656d76; font-weight: 500; }
+.track { background: #eaeef2; border-radius: 3px; height: 10px; min-width: 120px; }
+.track div { height: 10px; border-radius: 3px; }
+.avg { background: #0969da; }
+svg text { font-size: 10px; fill: #656d76; }
+svg rect.day { fill: #0969da; }
+</style>
+</head>
+<body>
+<h1>BMAD Automation Report</h1>
+<p class="meta">{{.Range}} &middot; generated {{.Generated}}</p>
+
+<h2>Overview</h2>
+<div class="cards">
+<div class="card"><div class="value">{{.Stats.TotalExecutions}}</div><div class="label">Executions</div></div>
+<div class="card"><div class="value">{{percent .Stats.SuccessRate}}</div><div class="label">Success rate</div></div>
+<div class="card"><div class="value">{{duration .Stats.AvgDuration}}</div><div class="label">Average duration</div></div>
+<div class="card"><div class="value">{{duration .Stats.TotalDuration}}</div><div class="label">Total time</div></div>
+</div>
+{{- if .Outcomes}}
+<div class="outcomes">
+{{- range .Outcomes}}
+<div class="{{.Class}}" style="width: {{printf "%.2f" .Percent}}%" title="{{.Label}}: {{.Count}}"></div>
+{{- end}}
+</div>
+<p class="legend">
+{{- range .Outcomes}}
+<span><i class="{{.Class}}"></i>{{.Label}} {{.Count}}</span>
+{{- end}}
+</p>
+{{- end}}
+
+<h2>Step Performance</h2>
+{{- if .Steps}}
+<table>
+<tr><th>Step</th><th>Runs</th><th>Succeeded</th><th>Failed</th><th>Success rate</th><th>Average</th><th>Min / Max</th></tr>
+{{- range .Steps}}
+<tr>
+<td>{{.Name}}</td>
+<td>{{.Stats.TotalCount}}</td>
+<td>{{.Stats.SuccessCount}}</td>
+<td>{{.Stats.FailureCount}}</td>
+<td><div class="track" title="{{percent .Stats.SuccessRate}}"><div class="ok" style="width: {{printf "%.2f" .Stats.SuccessRate}}%"></div></div>{{percent .Stats.SuccessRate}}</td>
+<td><div class="track"><div class="avg" style="width: {{printf "%.2f" .AvgPercent}}%"></div></div>{{.AvgDuration}}</td>
+<td>{{.MinDuration}} / {{.MaxDuration}}</td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p class="meta">No steps have run in this range.</p>
+{{- end}}
+
+<h2>Activity</h2>
+<svg width="{{.ChartW}}" height="190" viewBox="0 0 {{.ChartW}} 190" role="img" aria-label="Executions per day">
+{{- range .Days}}
+<g><title>{{.Label}}: {{.Count}}</title><rect class="day" x="{{.X}}" y="{{.Y}}" width="{{$.BarW}}" height="{{.Height}}"></rect></g>
+{{- end}}
+{{- range $i, $d := .Days}}{{if eq (mod $i 7) 0}}
+<text x="{{$d.X}}" y="178">{{$d.Label}}</text>
+{{- end}}{{end}}
+</svg>
+<p class="meta">Executions per day, up to {{.MaxDay}}</p>
+</body>
+</html>
+`))
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestStatsHTML(t *testing.T) {
+	store := storeWithOutput(t)
+	now := time.Now()
+
+	t.Run("all time", func(t *testing.T) {
+		stats, err := store.GetStats(context.Background(), domain.DateRange{})
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "stats.html")
+		require.NoError(t, StatsHTML(path, stats, domain.DateRange{}, now))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		html := string(data)
+
+		assert.Contains(t, html, "<title>BMAD Automation Report - All time</title>")
+		assert.Contains(t, html, "Successful 1")
+		assert.Contains(t, html, "<td>"+string(domain.StepCreateStory)+"</td>")
+		assert.Equal(t, activityDays, strings.Count(html, `<rect class="day"`))
+	})
+
+	t.Run("date range", func(t *testing.T) {
+		r := domain.LastDays(7, now)
+		stats, err := store.GetStats(context.Background(), r)
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "stats.html")
+		require.NoError(t, StatsHTML(path, stats, r, now))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		html := string(data)
+
+		assert.Contains(t, html, "Since "+r.Since.Format("Jan 2 2006"))
+		// Seven days back from now touch eight calendar days
+		assert.Equal(t, 8, strings.Count(html, `<rect class="day"`))
+	})
+
+	t.Run("no executions", func(t *testing.T) {
+		stats, err := store.GetStats(context.Background(), domain.DateRange{Until: now.AddDate(-1, 0, 0)})
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "stats.html")
+		require.NoError(t, StatsHTML(path, stats, domain.DateRange{}, now))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "No steps have run in this range.")
+	})
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// FormatJSON is the JSON export format
+const FormatJSON = "json"
+
+// JSONFile is the file written by JSON
+const JSONFile = "executions.json"
+
+type jsonExecution struct {
+	ID          string     `json:"id"`
+	StoryKey    string     `json:"story_key"`
+	StoryEpic   int        `json:"story_epic"`
+	StoryStatus string     `json:"story_status"`
+	StoryTitle  string     `json:"story_title,omitempty"`
+	Status      string     `json:"status"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	DurationMs  int64      `json:"duration_ms"`
+	Error       string     `json:"error,omitempty"`
+	Steps       []jsonStep `json:"steps"`
+}
+
+type jsonStep struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	StartTime   *time.Time `json:"start_time,omitempty"`
+	EndTime     *time.Time `json:"end_time,omitempty"`
+	DurationMs  int64      `json:"duration_ms"`
+	Attempt     int        `json:"attempt"`
+	Command     string     `json:"command,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	OutputLines int        `json:"output_lines"`
+	Output      []string   `json:"output,omitempty"`
+}
+
+// JSON writes every execution with its steps to one JSON file in dir,
+// creating it if needed. With withOutput, each step includes its output
+// lines; stderr lines keep their "[stderr] " prefix.
+func JSON(ctx context.Context, store storage.Storage, dir string, withOutput bool) (*Result, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	result := &Result{}
+	executions := make([]jsonExecution, 0)
+	err := eachExecution(ctx, store, func(rec *storage.ExecutionRecord) error {
+		exec := jsonExecution{
+			ID:          rec.ID,
+			StoryKey:    rec.StoryKey,
+			StoryEpic:   rec.StoryEpic,
+			StoryStatus: rec.StoryStatus,
+			StoryTitle:  rec.StoryTitle,
+			Status:      string(rec.Status),
+			StartTime:   rec.StartTime,
+			EndTime:     timePtr(rec.EndTime),
+			DurationMs:  rec.Duration.Milliseconds(),
+			Error:       rec.Error,
+			Steps:       make([]jsonStep, 0, len(rec.Steps)),
+		}
+
+		for _, step := range rec.Steps {
+			js := jsonStep{
+				ID:          step.ID,
+				Name:        string(step.StepName),
+				Status:      string(step.Status),
+				StartTime:   timePtr(step.StartTime),
+				EndTime:     timePtr(step.EndTime),
+				DurationMs:  step.Duration.Milliseconds(),
+				Attempt:     step.Attempt,
+				Command:     step.Command,
+				Error:       step.Error,
+				OutputLines: step.OutputSize,
+			}
+			if withOutput && step.OutputSize > 0 {
+				output, err := store.GetStepOutput(ctx, step.ID)
+				if err != nil {
+					return fmt.Errorf("failed to export output of step %s: %w", step.ID, err)
+				}
+				js.Output = output
+				result.Outputs += len(output)
+			}
+			exec.Steps = append(exec.Steps, js)
+		}
+
+		executions = append(executions, exec)
+		result.Steps += len(rec.Steps)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(executions, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode executions: %w", err)
+	}
+	path := filepath.Join(dir, JSONFile)
+	if err := writeFile(path, append(data, '\n')); err != nil {
+		return nil, err
+	}
+
+	result.Executions = len(executions)
+	result.Files = []string{path}
+	return result, nil
+}
+
+// timePtr returns nil for a zero time, so it is left out of the JSON
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// storeWithOutput returns a storage holding one completed execution whose
+// first step printed a stdout and a stderr line
+func storeWithOutput(t *testing.T) storage.Storage {
+	t.Helper()
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+
+	exec := domain.NewExecution(domain.Story{Key: "1-1-first", Epic: 1, Status: domain.StatusDone})
+	exec.Status = domain.ExecutionCompleted
+	exec.StartTime = time.Now().Add(-time.Minute)
+	exec.EndTime = time.Now()
+	exec.Duration = time.Minute
+	exec.Steps[0].Status = domain.StepSuccess
+	exec.Steps[0].Output = []string{"one", "[stderr] two, with a comma"}
+	require.NoError(t, store.SaveExecution(context.Background(), exec))
+	return store
+}
+
+func TestJSON(t *testing.T) {
+	store := storeWithOutput(t)
+
+	t.Run("without output", func(t *testing.T) {
+		dir := t.TempDir()
+		result, err := JSON(context.Background(), store, dir, false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Executions)
+		assert.Equal(t, len(domain.AllSteps()), result.Steps)
+		assert.Zero(t, result.Outputs)
+
+		var executions []jsonExecution
+		data, err := os.ReadFile(filepath.Join(dir, JSONFile))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &executions))
+		require.Len(t, executions, 1)
+		assert.Equal(t, "1-1-first", executions[0].StoryKey)
+		step := stepWithOutput(executions[0])
+		require.NotNil(t, step)
+		assert.Equal(t, 2, step.OutputLines)
+		assert.Nil(t, step.Output)
+	})
+
+	t.Run("with output", func(t *testing.T) {
+		dir := t.TempDir()
+		result, err := JSON(context.Background(), store, dir, true)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Outputs)
+
+		var executions []jsonExecution
+		data, err := os.ReadFile(filepath.Join(dir, JSONFile))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &executions))
+		step := stepWithOutput(executions[0])
+		require.NotNil(t, step)
+		assert.Equal(t, []string{"one", "[stderr] two, with a comma"}, step.Output)
+	})
+}
+
+func stepWithOutput(exec jsonExecution) *jsonStep {
+	for i := range exec.Steps {
+		if exec.Steps[i].OutputLines > 0 {
+			return &exec.Steps[i]
+		}
+	}
+	return nil
+}
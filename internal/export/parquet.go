@@ -0,0 +1,165 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/parquet"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// FormatParquet is the Parquet export format
+const FormatParquet = "parquet"
+
+// Files written by Parquet
+const (
+	ExecutionsFile = "executions.parquet"
+	StepsFile      = "steps.parquet"
+	OutputsFile    = "outputs.parquet"
+)
+
+var executionColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "story_key", Type: parquet.String},
+	{Name: "story_epic", Type: parquet.Int64},
+	{Name: "story_status", Type: parquet.String},
+	{Name: "story_title", Type: parquet.String, Optional: true},
+	{Name: "status", Type: parquet.String},
+	{Name: "start_time", Type: parquet.Timestamp},
+	{Name: "end_time", Type: parquet.Timestamp, Optional: true},
+	{Name: "duration_ms", Type: parquet.Int64},
+	{Name: "step_count", Type: parquet.Int64},
+	{Name: "attempts", Type: parquet.Int64},
+	{Name: "error", Type: parquet.String, Optional: true},
+	{Name: "created_at", Type: parquet.Timestamp, Optional: true},
+}
+
+var stepColumns = []parquet.Column{
+	{Name: "id", Type: parquet.String},
+	{Name: "execution_id", Type: parquet.String},
+	{Name: "story_key", Type: parquet.String},
+	{Name: "step_name", Type: parquet.String},
+	{Name: "status", Type: parquet.String},
+	{Name: "start_time", Type: parquet.Timestamp, Optional: true},
+	{Name: "end_time", Type: parquet.Timestamp, Optional: true},
+	{Name: "duration_ms", Type: parquet.Int64},
+	{Name: "attempt", Type: parquet.Int64},
+	{Name: "command", Type: parquet.String, Optional: true},
+	{Name: "error", Type: parquet.String, Optional: true},
+	{Name: "output_lines", Type: parquet.Int64},
+}
+
+var outputColumns = []parquet.Column{
+	{Name: "step_id", Type: parquet.String},
+	{Name: "execution_id", Type: parquet.String},
+	{Name: "step_name", Type: parquet.String},
+	{Name: "lines", Type: parquet.Int64},
+	{Name: "stderr_lines", Type: parquet.Int64},
+	{Name: "bytes", Type: parquet.Int64},
+	{Name: "last_line", Type: parquet.String, Optional: true},
+}
+
+// Parquet writes executions, steps and a per-step output summary as
+// Parquet files in dir, creating it if needed
+func Parquet(ctx context.Context, store storage.Storage, dir string) (*Result, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	result := &Result{}
+	execFile := newTable(dir, ExecutionsFile, executionColumns)
+	stepFile := newTable(dir, StepsFile, stepColumns)
+
+	err := eachExecution(ctx, store, func(rec *storage.ExecutionRecord) error {
+		attempts := 0
+		for _, step := range rec.Steps {
+			attempts += step.Attempt
+			err := stepFile.w.Write(step.ID, rec.ID, rec.StoryKey, string(step.StepName), string(step.Status),
+				optionalTime(step.StartTime), optionalTime(step.EndTime), step.Duration.Milliseconds(),
+				step.Attempt, optionalString(step.Command), optionalString(step.Error), step.OutputSize)
+			if err != nil {
+				return fmt.Errorf("failed to export step %s: %w", step.ID, err)
+			}
+		}
+
+		err := execFile.w.Write(rec.ID, rec.StoryKey, rec.StoryEpic, rec.StoryStatus, optionalString(rec.StoryTitle),
+			string(rec.Status), rec.StartTime, optionalTime(rec.EndTime), rec.Duration.Milliseconds(),
+			len(rec.Steps), attempts, optionalString(rec.Error), optionalTime(rec.CreatedAt))
+		if err != nil {
+			return fmt.Errorf("failed to export execution %s: %w", rec.ID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := store.ListOutputSummaries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	outFile := newTable(dir, OutputsFile, outputColumns)
+	for _, o := range outputs {
+		err := outFile.w.Write(o.StepID, o.ExecutionID, string(o.StepName), o.Lines, o.StderrLines, o.Bytes,
+			optionalString(o.LastLine))
+		if err != nil {
+			return nil, fmt.Errorf("failed to export output of step %s: %w", o.StepID, err)
+		}
+	}
+
+	for _, t := range []*table{execFile, stepFile, outFile} {
+		if err := t.save(); err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, t.path)
+	}
+	result.Executions = execFile.w.Rows()
+	result.Steps = stepFile.w.Rows()
+	result.Outputs = outFile.w.Rows()
+
+	return result, nil
+}
+
+// table is a Parquet file being built
+type table struct {
+	path string
+	buf  *bytes.Buffer
+	w    *parquet.Writer
+}
+
+func newTable(dir, name string, columns []parquet.Column) *table {
+	buf := &bytes.Buffer{}
+	return &table{
+		path: filepath.Join(dir, name),
+		buf:  buf,
+		w:    parquet.NewWriter(buf, columns),
+	}
+}
+
+// save finishes the table and writes it to disk
+func (t *table) save() error {
+	if err := t.w.Close(); err != nil {
+		return err
+	}
+	return writeFile(t.path, t.buf.Bytes())
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// optionalTime returns nil for a zero time
+func optionalTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
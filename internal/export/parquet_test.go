@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+func TestParquet(t *testing.T) {
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+	ctx := context.Background()
+
+	for _, key := range []string{"1-1-first", "1-2-second"} {
+		exec := domain.NewExecution(domain.Story{Key: key, Epic: 1, Status: domain.StatusDone})
+		exec.Status = domain.ExecutionCompleted
+		exec.StartTime = time.Now().Add(-time.Minute)
+		exec.EndTime = time.Now()
+		exec.Duration = time.Minute
+		exec.Steps[0].Status = domain.StepSuccess
+		exec.Steps[0].Output = []string{"one", "[stderr] two"}
+		require.NoError(t, store.SaveExecution(ctx, exec))
+	}
+
+	dir := filepath.Join(t.TempDir(), "export")
+	result, err := Parquet(ctx, store, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Executions)
+	assert.Equal(t, 2*len(domain.AllSteps()), result.Steps)
+	assert.Equal(t, 2, result.Outputs)
+	require.Len(t, result.Files, 3)
+
+	for _, name := range []string{ExecutionsFile, StepsFile, OutputsFile} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("PAR1")), name)
+		assert.True(t, bytes.HasSuffix(data, []byte("PAR1")), name)
+	}
+}
+
+func TestParquet_EmptyDatabase(t *testing.T) {
+	store, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	dir := t.TempDir()
+	result, err := Parquet(context.Background(), store, dir)
+	require.NoError(t, err)
+	assert.Zero(t, result.Executions)
+
+	_, err = os.Stat(filepath.Join(dir, ExecutionsFile))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,178 @@
+// Package failures files a report when a story fails after all retries,
+// either as a GitHub issue or as an entry appended to a markdown file.
+package failures
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// OutputLines is how many of the failed step's last output lines a
+// report includes
+const OutputLines = 50
+
+// sendTimeout bounds creating a GitHub issue
+const sendTimeout = 30 * time.Second
+
+// Report describes a failed execution
+type Report struct {
+	ExecutionID string
+	Story       domain.Story
+	Step        domain.StepName
+	Attempts    int
+	Error       string
+	Output      []string // Last OutputLines lines of the failed step
+	Time        time.Time
+}
+
+// FromExecution builds the report for a failed execution. It returns false
+// when the execution did not fail at a step, e.g. because it never started.
+func FromExecution(exec *domain.Execution) (Report, bool) {
+	if exec == nil || exec.Status != domain.ExecutionFailed {
+		return Report{}, false
+	}
+	for _, step := range exec.Steps {
+		if step.Status != domain.StepFailed {
+			continue
+		}
+		output := step.Output
+		if len(output) > OutputLines {
+			output = output[len(output)-OutputLines:]
+		}
+		r := Report{
+			ExecutionID: exec.ID,
+			Story:       exec.Story,
+			Step:        step.Name,
+			Attempts:    step.Attempt,
+			Error:       step.Error,
+			Output:      output,
+			Time:        exec.EndTime,
+		}
+		if r.Error == "" {
+			r.Error = exec.Error
+		}
+		if r.Time.IsZero() {
+			r.Time = time.Now()
+		}
+		return r, true
+	}
+	return Report{}, false
+}
+
+// Title is the one-line summary used as the issue title and file heading
+func (r Report) Title() string {
+	return fmt.Sprintf("bmad: %s failed at %s", r.Story.Key, r.Step)
+}
+
+// Markdown renders the report body
+func (r Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "- **Story:** %s", r.Story.Key)
+	if r.Story.Title != "" {
+		fmt.Fprintf(&b, " (%s)", r.Story.Title)
+	}
+	fmt.Fprintf(&b, "\n- **Epic:** %d\n", r.Story.Epic)
+	fmt.Fprintf(&b, "- **Execution:** %s\n", r.ExecutionID)
+	fmt.Fprintf(&b, "- **Step:** %s (%d attempts)\n", r.Step, r.Attempts)
+	fmt.Fprintf(&b, "- **Time:** %s\n", r.Time.Format(time.RFC3339))
+	if r.Error != "" {
+		fmt.Fprintf(&b, "- **Error:** %s\n", r.Error)
+	}
+	if len(r.Output) > 0 {
+		fmt.Fprintf(&b, "\nLast %d lines of output:\n\n```\n%s\n```\n", len(r.Output), strings.Join(r.Output, "\n"))
+	}
+	return b.String()
+}
+
+// File files the report with target (config.FailureReportGitHub or
+// config.FailureReportFile) and returns where it went: the issue URL or
+// the file path
+func File(ctx context.Context, cfg *config.Config, target string, r Report) (string, error) {
+	switch target {
+	case config.FailureReportGitHub:
+		return openIssue(ctx, cfg, r)
+	case config.FailureReportFile:
+		path := cfg.FailureReportPath()
+		return path, appendToFile(path, r)
+	default:
+		return "", fmt.Errorf("unknown failure report target %q", target)
+	}
+}
+
+// appendToFile adds the report to the end of a markdown file, creating it
+// when needed
+func appendToFile(path string, r Report) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open failure file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "## %s\n\n%s\n", r.Title(), r.Markdown()); err != nil {
+		return fmt.Errorf("failed to write failure file: %w", err)
+	}
+	return nil
+}
+
+// openIssue creates an issue in the configured GitHub repository and
+// returns its URL
+func openIssue(ctx context.Context, cfg *config.Config, r Report) (string, error) {
+	owner, repo, ok := strings.Cut(cfg.GitHubRepo, "/")
+	if !ok || owner == "" || repo == "" {
+		return "", fmt.Errorf("github failure reports need BMAD_GITHUB_REPO as owner/name")
+	}
+	if cfg.GitHubToken == "" {
+		return "", fmt.Errorf("github failure reports need BMAD_GITHUB_TOKEN")
+	}
+
+	payload := map[string]any{"title": r.Title(), "body": r.Markdown()}
+	if len(cfg.FailureLabels) > 0 {
+		payload["labels"] = cfg.FailureLabels
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode issue: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	base := cfg.GitHubAPIURL
+	if base == "" {
+		base = config.DefaultGitHubAPIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/repos/"+owner+"/"+repo+"/issues", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create github request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+cfg.GitHubToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to create github issue: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("github issue creation failed: %s", resp.Status)
+	}
+
+	var issue struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return "", fmt.Errorf("failed to decode github response: %w", err)
+	}
+	return issue.HTMLURL, nil
+}
@@ -0,0 +1,110 @@
+package failures
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func failedExecution() *domain.Execution {
+	exec := domain.NewExecution(domain.Story{Key: "3-1-login", Epic: 3, Title: "Login"})
+	exec.Status = domain.ExecutionFailed
+	exec.EndTime = time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	exec.Steps[0].Status = domain.StepSuccess
+	failed := exec.Steps[1]
+	failed.Status = domain.StepFailed
+	failed.Attempt = 2
+	failed.Error = "exit status 1"
+	for i := 0; i < 60; i++ {
+		failed.Output = append(failed.Output, fmt.Sprintf("line %d", i))
+	}
+	return exec
+}
+
+func TestFromExecution(t *testing.T) {
+	r, ok := FromExecution(failedExecution())
+	require.True(t, ok)
+	assert.Equal(t, domain.StepDevStory, r.Step)
+	assert.Equal(t, 2, r.Attempts)
+	assert.Equal(t, "exit status 1", r.Error)
+	require.Len(t, r.Output, OutputLines)
+	assert.Equal(t, "line 10", r.Output[0])
+	assert.Equal(t, "line 59", r.Output[OutputLines-1])
+
+	t.Run("needs a failed step", func(t *testing.T) {
+		exec := domain.NewExecution(domain.Story{Key: "3-1-login"})
+		exec.Status = domain.ExecutionFailed
+		_, ok := FromExecution(exec)
+		assert.False(t, ok)
+
+		exec = failedExecution()
+		exec.Status = domain.ExecutionCancelled
+		_, ok = FromExecution(exec)
+		assert.False(t, ok)
+	})
+}
+
+func TestFile_AppendsToFile(t *testing.T) {
+	cfg := config.New()
+	cfg.WorkingDir = t.TempDir()
+	r, _ := FromExecution(failedExecution())
+
+	for i := 0; i < 2; i++ {
+		path, err := File(context.Background(), cfg, config.FailureReportFile, r)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(cfg.WorkingDir, config.DefaultFailureFile), path)
+	}
+
+	data, err := os.ReadFile(filepath.Join(cfg.WorkingDir, config.DefaultFailureFile))
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "## bmad: 3-1-login failed at dev-story\n")
+	assert.Contains(t, content, "- **Execution:** "+r.ExecutionID)
+	assert.Contains(t, content, "line 59\n```")
+	assert.Equal(t, 2, strings.Count(content, "## bmad: "))
+}
+
+func TestFile_OpensGitHubIssue(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/acme/shop/issues", r.URL.Path)
+		assert.Equal(t, "Bearer tok", r.Header.Get("Authorization"))
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"html_url": "https://github.com/acme/shop/issues/7"}`))
+	}))
+	defer server.Close()
+
+	cfg := config.New()
+	cfg.GitHubAPIURL = server.URL
+	cfg.GitHubRepo = "acme/shop"
+	cfg.GitHubToken = "tok"
+	cfg.FailureLabels = []string{"bmad", "bug"}
+	r, _ := FromExecution(failedExecution())
+
+	url, err := File(context.Background(), cfg, config.FailureReportGitHub, r)
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/shop/issues/7", url)
+	assert.Equal(t, "bmad: 3-1-login failed at dev-story", got["title"])
+	assert.Contains(t, got["body"], "- **Story:** 3-1-login (Login)")
+	assert.Equal(t, []any{"bmad", "bug"}, got["labels"])
+
+	t.Run("needs a repository", func(t *testing.T) {
+		cfg.GitHubRepo = ""
+		_, err := File(context.Background(), cfg, config.FailureReportGitHub, r)
+		assert.ErrorContains(t, err, "BMAD_GITHUB_REPO")
+	})
+}
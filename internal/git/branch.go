@@ -0,0 +1,38 @@
+package git
+
+// CheckoutStoryBranch switches workDir to branch, creating it from HEAD
+// when it does not exist yet. Uncommitted changes are carried over. It
+// returns what was checked out before: the branch name, or the commit when
+// HEAD was detached.
+func CheckoutStoryBranch(workDir, branch string) (string, error) {
+	base, err := gitOutput(workDir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if base == "HEAD" {
+		if base, err = gitOutput(workDir, "rev-parse", "--verify", "HEAD"); err != nil {
+			return "", err
+		}
+	}
+	if base == branch {
+		return base, nil
+	}
+
+	if _, err := gitOutput(workDir, "check-ref-format", "--branch", branch); err != nil {
+		return "", err
+	}
+	args := []string{"checkout", "--quiet", branch}
+	if _, err := gitOutput(workDir, "show-ref", "--verify", "--quiet", "refs/heads/"+branch); err != nil {
+		args = []string{"checkout", "--quiet", "-b", branch}
+	}
+	if _, err := gitOutput(workDir, args...); err != nil {
+		return "", err
+	}
+	return base, nil
+}
+
+// Checkout switches workDir back to a branch or commit
+func Checkout(workDir, ref string) error {
+	_, err := gitOutput(workDir, "checkout", "--quiet", ref)
+	return err
+}
@@ -0,0 +1,55 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckoutStoryBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	run("init", "-q", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "story.md"), []byte("base\n"), 0644))
+	run("add", ".")
+	run("commit", "-q", "-m", "base")
+
+	// The branch is created on the first run
+	base, err := CheckoutStoryBranch(dir, "story/1-1-login")
+	require.NoError(t, err)
+	assert.Equal(t, "main", base)
+	assert.Equal(t, "story/1-1-login", getBranch(dir))
+
+	run("commit", "-q", "--allow-empty", "-m", "story work")
+	require.NoError(t, Checkout(dir, base))
+	assert.Equal(t, "main", getBranch(dir))
+
+	// and reused afterwards, keeping earlier work
+	_, err = CheckoutStoryBranch(dir, "story/1-1-login")
+	require.NoError(t, err)
+	out, err := gitOutput(dir, "log", "-1", "--format=%s")
+	require.NoError(t, err)
+	assert.Equal(t, "story work", out)
+
+	_, err = CheckoutStoryBranch(dir, "story/bad..name")
+	assert.Error(t, err)
+}
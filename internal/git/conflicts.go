@@ -0,0 +1,47 @@
+package git
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// conflictMarkers are phrases git prints when a merge, rebase or pull stops
+// on conflicting changes
+var conflictMarkers = []string{
+	"CONFLICT (",
+	"Automatic merge failed",
+	"needs merge",
+	"fix conflicts and then commit",
+	"Resolve all conflicts manually",
+	"could not apply",
+}
+
+// ConflictedFiles returns the paths with unresolved merge conflicts in the
+// working tree. It returns nil outside a git repository.
+func ConflictedFiles(workDir string) []string {
+	cmd := exec.Command("git", "diff", "--name-only", "--diff-filter=U")
+	cmd.Dir = workDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	text := strings.TrimSpace(string(output))
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// OutputIndicatesConflict reports whether command output contains a git
+// merge conflict message
+func OutputIndicatesConflict(lines []string) bool {
+	for _, line := range lines {
+		for _, marker := range conflictMarkers {
+			if strings.Contains(line, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
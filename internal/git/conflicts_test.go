@@ -0,0 +1,72 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputIndicatesConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected bool
+	}{
+		{"empty output", nil, false},
+		{"clean push", []string{"To github.com:org/repo.git", "   abc123..def456  main -> main"}, false},
+		{"merge conflict", []string{"CONFLICT (content): Merge conflict in main.go"}, true},
+		{"automatic merge failed", []string{"Automatic merge failed; fix conflicts and then commit the result."}, true},
+		{"rebase stopped", []string{"[stderr] error: could not apply 1a2b3c4... add login"}, true},
+		{"rejected push is not a conflict", []string{"! [rejected] main -> main (fetch first)"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, OutputIndicatesConflict(tt.lines))
+		})
+	}
+}
+
+func TestConflictedFiles(t *testing.T) {
+	t.Run("returns nil for non-git directory", func(t *testing.T) {
+		assert.Nil(t, ConflictedFiles(t.TempDir()))
+	})
+
+	t.Run("lists unmerged paths", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not available")
+		}
+
+		dir := t.TempDir()
+		run := func(args ...string) {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			cmd.Env = append(os.Environ(),
+				"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+				"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+			)
+			_ = cmd.Run()
+		}
+		write := func(content string) {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "story.md"), []byte(content), 0644))
+		}
+
+		run("init", "-q", "-b", "main")
+		write("base\n")
+		run("add", ".")
+		run("commit", "-q", "-m", "base")
+		run("checkout", "-q", "-b", "feature")
+		write("feature\n")
+		run("commit", "-q", "-am", "feature")
+		run("checkout", "-q", "main")
+		write("main\n")
+		run("commit", "-q", "-am", "main")
+		run("merge", "-q", "feature")
+
+		assert.Equal(t, []string{"story.md"}, ConflictedFiles(dir))
+	})
+}
@@ -0,0 +1,26 @@
+package git
+
+// What a working tree diff compares
+const (
+	DiffUnstaged = "unstaged" // Working tree against the index
+	DiffStaged   = "staged"   // Index against HEAD
+	DiffHead     = "head"     // Last commit against its parent
+)
+
+// Diff returns the changes of workDir that source names, DiffUnstaged
+// when it is empty
+func Diff(workDir, source string) (string, error) {
+	switch source {
+	case DiffStaged:
+		return gitOutput(workDir, "diff", "--cached")
+	case DiffHead:
+		return gitOutput(workDir, "show", "--format=commit %h %s", "HEAD")
+	default:
+		return gitOutput(workDir, "diff")
+	}
+}
+
+// DiffAgainst returns the changes of workDir's working tree since ref
+func DiffAgainst(workDir, ref string) (string, error) {
+	return gitOutput(workDir, "diff", ref)
+}
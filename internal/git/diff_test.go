@@ -0,0 +1,65 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(file, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+	}
+	run("init", "-q", "-b", "main")
+	write("committed.txt", "one\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "Add committed.txt")
+
+	write("staged.txt", "staged\n")
+	run("add", "staged.txt")
+	write("committed.txt", "one\ntwo\n")
+
+	for _, tc := range []struct {
+		source   string
+		contains string
+		excludes string
+	}{
+		{"", "+two", "staged.txt"},
+		{DiffUnstaged, "+two", "staged.txt"},
+		{DiffStaged, "+staged", "committed.txt"},
+		{DiffHead, "Add committed.txt", "staged.txt"},
+	} {
+		t.Run(tc.source, func(t *testing.T) {
+			out, err := Diff(dir, tc.source)
+			require.NoError(t, err)
+			assert.Contains(t, out, tc.contains)
+			assert.NotContains(t, out, tc.excludes)
+		})
+	}
+
+	out, err := DiffAgainst(dir, "HEAD")
+	require.NoError(t, err)
+	assert.Contains(t, out, "+two")
+	assert.Contains(t, out, "+staged")
+}
@@ -77,6 +77,22 @@ func getBranch(workDir string) string {
 	return strings.TrimSpace(string(output))
 }
 
+// HeadCommit returns the full SHA of the checked out commit, or "" when
+// workDir is not a repository or has no commits yet
+func HeadCommit(workDir string) string {
+	return RefCommit(workDir, "HEAD")
+}
+
+// RefCommit returns the full SHA a branch or other ref points to, or ""
+// when it does not resolve to a commit
+func RefCommit(workDir, ref string) string {
+	sha, err := gitOutput(workDir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return ""
+	}
+	return sha
+}
+
 // hasUncommitted checks for uncommitted changes
 func hasUncommitted(workDir string) (bool, int) {
 	cmd := exec.Command("git", "diff", "--shortstat")
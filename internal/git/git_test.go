@@ -220,6 +220,28 @@ func TestGetBranch(t *testing.T) {
 	})
 }
 
+func TestHeadCommit(t *testing.T) {
+	t.Run("returns the commit in git repo", func(t *testing.T) {
+		wd, err := os.Getwd()
+		require.NoError(t, err)
+
+		// Verify we're in a git repo first
+		cmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+		cmd.Dir = wd
+		if err := cmd.Run(); err != nil {
+			t.Skip("Not running in a git repository")
+		}
+
+		assert.Len(t, HeadCommit(wd), 40)
+		assert.Equal(t, HeadCommit(wd), RefCommit(wd, "HEAD"))
+		assert.Empty(t, RefCommit(wd, "no-such-branch"))
+	})
+
+	t.Run("returns empty outside git repo", func(t *testing.T) {
+		assert.Empty(t, HeadCommit(t.TempDir()))
+	})
+}
+
 func TestGetStatus(t *testing.T) {
 	t.Run("returns status for git repository", func(t *testing.T) {
 		wd, err := os.Getwd()
@@ -0,0 +1,207 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// SnapshotRefPrefix is where pre-run snapshot refs are kept. Refs outside
+// refs/heads and refs/tags stay out of branch and tag listings but keep the
+// commits from being garbage collected.
+const SnapshotRefPrefix = "refs/bmad/snapshots/"
+
+// CreateSnapshot records the working tree before execution id runs. The
+// tree is left untouched: uncommitted changes to tracked files are captured
+// with "git stash create", and untracked files are committed through a
+// temporary index and added as the stash's third parent, the way
+// "git stash push --include-untracked" stores them.
+func CreateSnapshot(workDir, id string) (*domain.WorkspaceSnapshot, error) {
+	head, err := gitOutput(workDir, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	message := "bmad pre-run snapshot " + id
+	stash, err := gitOutput(workDir, "stash", "create", message)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := untrackedFiles(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(untracked) > 0 {
+		if stash, err = stashWithUntracked(workDir, head, stash, message, untracked); err != nil {
+			return nil, err
+		}
+	}
+
+	snap := &domain.WorkspaceSnapshot{
+		Ref:       SnapshotRefPrefix + id,
+		Head:      head,
+		Stash:     stash,
+		Untracked: untracked,
+	}
+
+	// The stash commit has HEAD as its parent, so one ref keeps both
+	target := head
+	if stash != "" {
+		target = stash
+	}
+	if _, err := gitOutput(workDir, "update-ref", "-m", "bmad pre-run snapshot", snap.Ref, target); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// stashWithUntracked returns a stash commit like the one "git stash push
+// --include-untracked" makes: the tracked changes of stash (or none when
+// stash is empty) with a third parent holding the untracked files. The
+// files are added through a temporary index, so the real index and the
+// working tree are not touched.
+func stashWithUntracked(workDir, head, stash, message string, untracked []string) (string, error) {
+	tmp, err := os.MkdirTemp("", "bmad-snapshot-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(tmp, "index")}
+
+	paths := strings.Join(untracked, "\x00") + "\x00"
+	if _, err := gitRun(workDir, env, paths, "update-index", "--add", "-z", "--stdin"); err != nil {
+		return "", err
+	}
+	tree, err := gitRun(workDir, env, "", "write-tree")
+	if err != nil {
+		return "", err
+	}
+	untrackedCommit, err := gitOutput(workDir, "commit-tree", tree, "-m", "untracked files of "+message)
+	if err != nil {
+		return "", err
+	}
+
+	// Without tracked changes the stash is HEAD's tree, with an index
+	// commit that matches HEAD
+	workTree, index := head+"^{tree}", ""
+	if stash != "" {
+		workTree, index = stash+"^{tree}", stash+"^2"
+	} else if index, err = gitOutput(workDir, "commit-tree", workTree, "-p", head, "-m", "index of "+message); err != nil {
+		return "", err
+	}
+	return gitOutput(workDir, "commit-tree", workTree, "-p", head, "-p", index, "-p", untrackedCommit, "-m", message)
+}
+
+// RestoreSnapshot puts the working tree back to how it was before the run:
+// HEAD is reset to the pre-run commit, untracked files are removed, and
+// uncommitted changes and untracked files from before the run are
+// reapplied with their contents. Changes made since the run started are
+// lost. Snapshots taken before untracked contents were recorded only list
+// their untracked files, which are left as they are.
+func RestoreSnapshot(workDir string, snap *domain.WorkspaceSnapshot) error {
+	if snap == nil || snap.Head == "" {
+		return fmt.Errorf("no pre-run snapshot recorded")
+	}
+
+	if _, err := gitOutput(workDir, "reset", "--hard", "--quiet", snap.Head); err != nil {
+		return err
+	}
+
+	untracked, err := untrackedFiles(workDir)
+	if err != nil {
+		return err
+	}
+	// Files in the stash's untracked parent come back from the stash, and
+	// "stash apply" refuses to overwrite them
+	existed := make(map[string]bool, len(snap.Untracked))
+	if snap.Stash == "" || !hasUntrackedParent(workDir, snap.Stash) {
+		for _, path := range snap.Untracked {
+			existed[path] = true
+		}
+	}
+	for _, path := range untracked {
+		if !existed[path] {
+			if err := os.Remove(filepath.Join(workDir, path)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to remove %s: %w", path, err)
+			}
+		}
+	}
+
+	if snap.Stash != "" {
+		if _, err := gitOutput(workDir, "stash", "apply", "--quiet", snap.Stash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasUntrackedParent reports whether stash records untracked files
+func hasUntrackedParent(workDir, stash string) bool {
+	_, err := gitOutput(workDir, "rev-parse", "--verify", "--quiet", stash+"^3")
+	return err == nil
+}
+
+// DeleteSnapshotRefs deletes the given snapshot refs, so git can collect
+// the commits they kept. Refs outside SnapshotRefPrefix are refused, and
+// refs that are already gone are not an error.
+func DeleteSnapshotRefs(workDir string, refs []string) error {
+	var stdin strings.Builder
+	for _, ref := range refs {
+		if !strings.HasPrefix(ref, SnapshotRefPrefix) {
+			return fmt.Errorf("%s is not a snapshot ref", ref)
+		}
+		fmt.Fprintf(&stdin, "delete %s\n", ref)
+	}
+	if stdin.Len() == 0 {
+		return nil
+	}
+	_, err := gitRun(workDir, nil, stdin.String(), "update-ref", "--stdin")
+	return err
+}
+
+// untrackedFiles lists untracked, non-ignored files relative to workDir
+func untrackedFiles(workDir string) ([]string, error) {
+	out, err := gitOutput(workDir, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, path := range strings.Split(out, "\x00") {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// gitOutput runs a git command and returns its trimmed output, including
+// git's error message when it fails
+func gitOutput(workDir string, args ...string) (string, error) {
+	return gitRun(workDir, nil, "", args...)
+}
+
+// gitRun is gitOutput with extra environment variables and stdin
+func gitRun(workDir string, env []string, stdin string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = workDir
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,129 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSnapshot_NotARepo(t *testing.T) {
+	_, err := CreateSnapshot(t.TempDir(), "exec-1")
+	assert.Error(t, err)
+}
+
+// snapshotRepo creates a repository with one commit of story.md and
+// returns its directory with helpers to run git, write and read files
+func snapshotRepo(t *testing.T) (dir string, run func(...string), write func(string, string), read func(string) string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+
+	dir = t.TempDir()
+	run = func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write = func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	read = func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	run("init", "-q", "-b", "main")
+	write("story.md", "base\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "base")
+	return dir, run, write, read
+}
+
+func TestSnapshot_RestoreUndoesRun(t *testing.T) {
+	dir, run, write, read := snapshotRepo(t)
+
+	// Work in progress before the run: a tracked edit and an untracked note
+	write("story.md", "local edit\n")
+	write("notes.txt", "mine\n")
+
+	snap, err := CreateSnapshot(dir, "exec-1")
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotRefPrefix+"exec-1", snap.Ref)
+	assert.NotEmpty(t, snap.Head)
+	assert.NotEmpty(t, snap.Stash)
+	assert.Equal(t, []string{"notes.txt"}, snap.Untracked)
+	assert.Equal(t, "local edit\n", read("story.md"), "snapshot leaves the tree alone")
+
+	// The run edits, creates and commits files before being cancelled
+	write("story.md", "agent edit\n")
+	write("generated.go", "package x\n")
+	write("notes.txt", "agent note\n")
+	run("commit", "-q", "-am", "agent commit")
+	write("story.md", "half done\n")
+
+	require.NoError(t, RestoreSnapshot(dir, snap))
+
+	head, err := gitOutput(dir, "rev-parse", "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, snap.Head, head)
+	assert.Equal(t, "local edit\n", read("story.md"))
+	assert.Equal(t, "mine\n", read("notes.txt"), "untracked files get their contents back")
+	_, err = os.Stat(filepath.Join(dir, "generated.go"))
+	assert.True(t, os.IsNotExist(err), "files created by the run are removed")
+}
+
+func TestRestoreSnapshot_RequiresSnapshot(t *testing.T) {
+	assert.Error(t, RestoreSnapshot(t.TempDir(), nil))
+}
+
+func TestSnapshot_UntrackedOnly(t *testing.T) {
+	dir, _, write, read := snapshotRepo(t)
+
+	// A clean tracked tree with untracked work in progress
+	write("docs/plan.md", "draft\n")
+
+	snap, err := CreateSnapshot(dir, "exec-1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, snap.Stash, "untracked files alone still make a stash")
+	assert.Equal(t, []string{"docs/plan.md"}, snap.Untracked)
+
+	write("docs/plan.md", "rewritten by the agent\n")
+	write("story.md", "agent edit\n")
+
+	require.NoError(t, RestoreSnapshot(dir, snap))
+	assert.Equal(t, "draft\n", read("docs/plan.md"))
+	assert.Equal(t, "base\n", read("story.md"))
+}
+
+func TestDeleteSnapshotRefs(t *testing.T) {
+	dir, _, write, _ := snapshotRepo(t)
+	write("story.md", "local edit\n")
+
+	first, err := CreateSnapshot(dir, "exec-1")
+	require.NoError(t, err)
+	second, err := CreateSnapshot(dir, "exec-2")
+	require.NoError(t, err)
+
+	require.NoError(t, DeleteSnapshotRefs(dir, []string{first.Ref, SnapshotRefPrefix + "already-gone"}))
+	_, err = gitOutput(dir, "rev-parse", "--verify", "--quiet", first.Ref)
+	assert.Error(t, err, "the deleted ref is gone")
+	_, err = gitOutput(dir, "rev-parse", "--verify", "--quiet", second.Ref)
+	assert.NoError(t, err, "other snapshots are kept")
+
+	assert.Error(t, DeleteSnapshotRefs(dir, []string{"refs/heads/main"}))
+	assert.NoError(t, DeleteSnapshotRefs(dir, nil))
+}
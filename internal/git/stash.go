@@ -0,0 +1,40 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StashChanges stashes uncommitted changes in workDir, untracked files
+// included, leaving a clean tree. It returns the stash commit, or "" when
+// there was nothing to stash.
+func StashChanges(workDir, message string) (string, error) {
+	// refs/stash is missing until the first stash, so errors read as none
+	before, _ := gitOutput(workDir, "rev-parse", "--verify", "--quiet", "refs/stash")
+	if _, err := gitOutput(workDir, "stash", "push", "--include-untracked", "--quiet", "-m", message); err != nil {
+		return "", err
+	}
+	after, _ := gitOutput(workDir, "rev-parse", "--verify", "--quiet", "refs/stash")
+	if after == before {
+		return "", nil
+	}
+	return after, nil
+}
+
+// PopStash reapplies the stash commit made by StashChanges and drops it.
+// When the changes do not apply cleanly the stash is kept, so it can be
+// recovered with "git stash apply <commit>".
+func PopStash(workDir, commit string) error {
+	list, err := gitOutput(workDir, "stash", "list", "--format=%H %gd")
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(list, "\n") {
+		hash, ref, ok := strings.Cut(line, " ")
+		if ok && hash == commit {
+			_, err := gitOutput(workDir, "stash", "pop", "--index", "--quiet", ref)
+			return err
+		}
+	}
+	return fmt.Errorf("stash %s not found", commit)
+}
@@ -0,0 +1,77 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStashChanges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(data)
+	}
+	run("init", "-q", "-b", "main")
+	write("story.md", "base\n")
+	write("other.md", "other\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "base")
+
+	// A clean tree has nothing to stash
+	commit, err := StashChanges(dir, "bmad auto-stash")
+	require.NoError(t, err)
+	assert.Empty(t, commit)
+
+	// Tracked and untracked changes are set aside and come back afterwards
+	write("story.md", "local edit\n")
+	write("scratch.txt", "notes\n")
+	commit, err = StashChanges(dir, "bmad auto-stash")
+	require.NoError(t, err)
+	assert.NotEmpty(t, commit)
+	assert.True(t, GetStatus(dir).IsClean)
+
+	write("other.md", "story work\n")
+	run("commit", "-q", "-am", "story work")
+	require.NoError(t, PopStash(dir, commit))
+	assert.Equal(t, "local edit\n", read("story.md"))
+	assert.Equal(t, "notes\n", read("scratch.txt"))
+	_, err = gitOutput(dir, "rev-parse", "--verify", "--quiet", "refs/stash")
+	assert.Error(t, err, "stash is dropped once restored")
+
+	// A conflicting run leaves the stash in place
+	commit, err = StashChanges(dir, "bmad auto-stash")
+	require.NoError(t, err)
+	write("story.md", "story edit\n")
+	run("commit", "-q", "-am", "conflicting work")
+	assert.Error(t, PopStash(dir, commit))
+	stash, err := gitOutput(dir, "rev-parse", "--verify", "--quiet", "refs/stash")
+	require.NoError(t, err)
+	assert.Equal(t, commit, stash)
+
+	assert.Error(t, PopStash(dir, "0000000000000000000000000000000000000000"))
+}
@@ -0,0 +1,33 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StoryDiff returns what was changed for a story since base. When the
+// story's branch exists it is the diff from where the branch left base to
+// its tip; otherwise it is the commits on HEAD, not on base, whose message
+// mentions storyKey, one after another.
+func StoryDiff(workDir, base, branch, storyKey string) (string, error) {
+	if _, err := gitOutput(workDir, "rev-parse", "--verify", "--quiet", base+"^{commit}"); err != nil {
+		return "", fmt.Errorf("base %q not found", base)
+	}
+
+	if branch != "" {
+		if _, err := gitOutput(workDir, "show-ref", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+			return gitOutput(workDir, "diff", base+"..."+branch)
+		}
+	}
+
+	commits, err := gitOutput(workDir, "log", "--reverse", "--format=%H", "--fixed-strings",
+		"--grep="+storyKey, base+"..HEAD")
+	if err != nil {
+		return "", err
+	}
+	if commits == "" {
+		return "", fmt.Errorf("no branch or commits for %s since %s", storyKey, base)
+	}
+	args := append([]string{"show", "--format=commit %h %s"}, strings.Fields(commits)...)
+	return gitOutput(workDir, args...)
+}
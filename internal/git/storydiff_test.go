@@ -0,0 +1,75 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoryDiff(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	commit := func(file, content, message string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+		run("add", ".")
+		run("commit", "-q", "-m", message)
+	}
+	run("init", "-q", "-b", "main")
+	commit("base.txt", "base\n", "base")
+
+	// A story on its own branch, with main moving on meanwhile
+	run("checkout", "-q", "-b", "story/1-1-login")
+	commit("login.go", "package login\n", "Implement 1-1-login")
+	run("checkout", "-q", "main")
+	commit("base.txt", "changed\n", "unrelated")
+
+	t.Run("story branch", func(t *testing.T) {
+		out, err := StoryDiff(dir, "main", "story/1-1-login", "1-1-login")
+		require.NoError(t, err)
+		assert.Contains(t, out, "+++ b/login.go")
+		assert.NotContains(t, out, "base.txt", "changes on base after the branch are left out")
+	})
+
+	// Stories committed straight to a feature branch
+	run("checkout", "-q", "-b", "feature", "main~1")
+	commit("signup.go", "package signup\n", "Implement 1-2-signup")
+	commit("other.go", "package other\n", "Something else")
+	commit("signup.go", "package signup\n\nfunc Signup() {}\n", "Review fixes for 1-2-signup")
+
+	t.Run("commits mentioning the story", func(t *testing.T) {
+		out, err := StoryDiff(dir, "main", "story/1-2-signup", "1-2-signup")
+		require.NoError(t, err)
+		assert.Contains(t, out, "Implement 1-2-signup")
+		assert.Contains(t, out, "Review fixes for 1-2-signup")
+		assert.NotContains(t, out, "other.go")
+	})
+
+	t.Run("nothing for the story", func(t *testing.T) {
+		_, err := StoryDiff(dir, "main", "", "9-9-missing")
+		assert.ErrorContains(t, err, "no branch or commits for 9-9-missing since main")
+	})
+
+	t.Run("unknown base", func(t *testing.T) {
+		_, err := StoryDiff(dir, "develop", "", "1-2-signup")
+		assert.ErrorContains(t, err, `base "develop" not found`)
+	})
+}
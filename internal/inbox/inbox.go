@@ -0,0 +1,266 @@
+// Package inbox lets other systems queue stories by dropping JSON files into
+// a directory, for tools that can write files but cannot call the API.
+package inbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Archive subdirectories of the inbox
+const (
+	ProcessedDir = "processed" // Files that were consumed
+	FailedDir    = "failed"    // Files that could not be read or parsed
+)
+
+// settleDelay is how long the inbox waits after the last change before
+// reading files, so a writer can finish
+const settleDelay = 250 * time.Millisecond
+
+// Request is the content of a dropped file
+type Request struct {
+	Keys  []string `json:"keys"`  // Story keys to add to the queue
+	Start bool     `json:"start"` // Start the queue once they are added
+}
+
+// DropMsg is sent for every file consumed from the inbox
+type DropMsg struct {
+	File    string // Name of the dropped file
+	Request Request
+	Error   error // Set when the file could not be read or parsed
+}
+
+// Inbox watches a directory for dropped requests
+type Inbox struct {
+	dir string
+
+	mu      sync.Mutex
+	program *tea.Program
+	watcher *fsnotify.Watcher
+	running bool
+	stopCh  chan struct{}
+}
+
+// New creates an inbox for dir
+func New(dir string) *Inbox {
+	return &Inbox{dir: dir}
+}
+
+// Dir returns the watched directory
+func (i *Inbox) Dir() string {
+	return i.dir
+}
+
+// SetProgram sets the tea.Program that receives DropMsg
+func (i *Inbox) SetProgram(p *tea.Program) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.program = p
+}
+
+// Start creates the inbox directories, consumes files already waiting and
+// watches for new ones
+func (i *Inbox) Start() error {
+	i.mu.Lock()
+	if i.running {
+		i.mu.Unlock()
+		return nil
+	}
+
+	for _, dir := range []string{i.dir, filepath.Join(i.dir, ProcessedDir), filepath.Join(i.dir, FailedDir)} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			i.mu.Unlock()
+			return fmt.Errorf("failed to create inbox: %w", err)
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		i.mu.Unlock()
+		return fmt.Errorf("failed to watch inbox: %w", err)
+	}
+	if err := watcher.Add(i.dir); err != nil {
+		watcher.Close()
+		i.mu.Unlock()
+		return fmt.Errorf("failed to watch inbox: %w", err)
+	}
+
+	i.watcher = watcher
+	i.running = true
+	i.stopCh = make(chan struct{})
+	i.mu.Unlock()
+
+	go i.run()
+	return nil
+}
+
+// Stop stops watching the inbox
+func (i *Inbox) Stop() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if !i.running {
+		return nil
+	}
+	i.running = false
+	close(i.stopCh)
+	return i.watcher.Close()
+}
+
+// IsRunning returns whether the inbox is being watched
+func (i *Inbox) IsRunning() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.running
+}
+
+// run consumes waiting files, then again each time the directory settles
+// after a change
+func (i *Inbox) run() {
+	i.drain()
+
+	settle := time.NewTimer(settleDelay)
+	settle.Stop()
+
+	for {
+		select {
+		case <-i.stopCh:
+			settle.Stop()
+			return
+
+		case event, ok := <-i.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write) != 0 && isRequestFile(filepath.Base(event.Name)) {
+				settle.Reset(settleDelay)
+			}
+
+		case <-settle.C:
+			i.drain()
+
+		case _, ok := <-i.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// drain consumes every waiting file and reports each one
+func (i *Inbox) drain() {
+	names, err := Pending(i.dir)
+	if err != nil {
+		i.send(DropMsg{Error: err})
+		return
+	}
+	for _, name := range names {
+		req, err := Consume(i.dir, name)
+		i.send(DropMsg{File: name, Request: req, Error: err})
+	}
+}
+
+// send delivers a message to the program
+func (i *Inbox) send(msg tea.Msg) {
+	i.mu.Lock()
+	program := i.program
+	i.mu.Unlock()
+
+	if program != nil {
+		program.Send(msg)
+	}
+}
+
+// Pending returns the names of the request files waiting in dir, oldest
+// name first
+func Pending(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inbox: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && isRequestFile(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Drop writes req into dir as a new request file and returns its name. The
+// file is written under a hidden name and renamed, so a watching inbox never
+// reads it half written.
+func Drop(dir, source string, req Request) (string, error) {
+	if len(req.Keys) == 0 {
+		return "", fmt.Errorf("no keys to queue")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create inbox: %w", err)
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%s.json", time.Now().Format("20060102-150405.000000000"), source)
+	tmp := filepath.Join(dir, "."+name)
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write request: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, name)); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to write request: %w", err)
+	}
+	return name, nil
+}
+
+// Consume reads and parses the request file name in dir, then moves it to
+// the processed directory, or to the failed one when it is invalid
+func Consume(dir, name string) (Request, error) {
+	path := filepath.Join(dir, name)
+	req, err := readRequest(path)
+
+	archive := ProcessedDir
+	if err != nil {
+		archive = FailedDir
+	}
+	archived := filepath.Join(dir, archive, time.Now().Format("20060102-150405")+"-"+name)
+	if moveErr := os.Rename(path, archived); moveErr != nil && err == nil {
+		err = fmt.Errorf("failed to archive %s: %w", name, moveErr)
+	}
+	return req, err
+}
+
+// readRequest parses a request file
+func readRequest(path string) (Request, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Request{}, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return Request{}, fmt.Errorf("invalid request in %s: %w", filepath.Base(path), err)
+	}
+	if len(req.Keys) == 0 {
+		return Request{}, fmt.Errorf("no keys in %s", filepath.Base(path))
+	}
+	return req, nil
+}
+
+// isRequestFile reports whether name is a request rather than a hidden or
+// partially written file
+func isRequestFile(name string) bool {
+	return strings.HasSuffix(name, ".json") && !strings.HasPrefix(name, ".")
+}
@@ -0,0 +1,96 @@
+package inbox
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInboxDir(t *testing.T) string {
+	dir := t.TempDir()
+	for _, sub := range []string{ProcessedDir, FailedDir} {
+		require.NoError(t, os.Mkdir(filepath.Join(dir, sub), 0755))
+	}
+	return dir
+}
+
+func drop(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func archived(t *testing.T, dir, sub string) []string {
+	entries, err := os.ReadDir(filepath.Join(dir, sub))
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestPending(t *testing.T) {
+	dir := newInboxDir(t)
+	drop(t, dir, "b.json", "{}")
+	drop(t, dir, "a.json", "{}")
+	drop(t, dir, ".c.json", "{}")
+	drop(t, dir, "d.json.tmp", "{}")
+
+	names, err := Pending(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.json", "b.json"}, names)
+}
+
+func TestConsume(t *testing.T) {
+	t.Run("valid requests are archived as processed", func(t *testing.T) {
+		dir := newInboxDir(t)
+		drop(t, dir, "ci.json", `{"keys": ["3-1-login", "3-2-logout"], "start": true}`)
+
+		req, err := Consume(dir, "ci.json")
+		require.NoError(t, err)
+		assert.Equal(t, Request{Keys: []string{"3-1-login", "3-2-logout"}, Start: true}, req)
+
+		assert.NoFileExists(t, filepath.Join(dir, "ci.json"))
+		processed := archived(t, dir, ProcessedDir)
+		require.Len(t, processed, 1)
+		assert.Contains(t, processed[0], "-ci.json")
+		assert.Empty(t, archived(t, dir, FailedDir))
+	})
+
+	t.Run("invalid requests are archived as failed", func(t *testing.T) {
+		dir := newInboxDir(t)
+		drop(t, dir, "broken.json", `{"keys": [`)
+		drop(t, dir, "empty.json", `{"start": true}`)
+
+		_, err := Consume(dir, "broken.json")
+		assert.ErrorContains(t, err, "invalid request in broken.json")
+		_, err = Consume(dir, "empty.json")
+		assert.ErrorContains(t, err, "no keys in empty.json")
+
+		assert.Len(t, archived(t, dir, FailedDir), 2)
+		assert.Empty(t, archived(t, dir, ProcessedDir))
+	})
+}
+
+func TestDrop(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "inbox")
+
+	name, err := Drop(dir, "mcp", Request{Keys: []string{"3-1-login"}, Start: true})
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(name, "-mcp.json"))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "no temporary file is left behind")
+	assert.Equal(t, name, entries[0].Name())
+
+	req, err := readRequest(filepath.Join(dir, name))
+	require.NoError(t, err)
+	assert.Equal(t, Request{Keys: []string{"3-1-login"}, Start: true}, req)
+
+	_, err = Drop(dir, "mcp", Request{})
+	assert.Error(t, err)
+}
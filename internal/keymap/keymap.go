@@ -0,0 +1,178 @@
+// Package keymap lists the keyboard shortcuts of every view. It is the
+// source the help overlay and the onboarding tour render, so a key added
+// to or changed in a handler should be updated here too.
+package keymap
+
+import "github.com/robertguss/bmad-automate-go/internal/domain"
+
+// Binding is one shortcut
+type Binding struct {
+	Keys string // As shown to the user, e.g. "Shift+K"
+	Help string
+}
+
+// Group is a titled set of bindings
+type Group struct {
+	Title    string
+	Bindings []Binding
+}
+
+// Global bindings work in every view unless the view uses the key itself
+var Global = []Binding{
+	{"d", "Dashboard"},
+	{"s", "Story list"},
+	{"q", "Queue manager"},
+	{"h", "History"},
+	{"a", "Statistics"},
+	{"o", "Settings"},
+	{"x", "Back to the running execution"},
+	{"Ctrl+P", "Command palette"},
+	{"Ctrl+F", "Search stories, history and output"},
+	{"?", "Toggle this help"},
+	{"Esc", "Go back"},
+	{"Ctrl+C", "Quit"},
+}
+
+// views holds the bindings specific to each view
+var views = map[domain.View][]Binding{
+	domain.ViewDashboard: {
+		{"e", "Epic overview"},
+		{"r", "Reload stories and statistics"},
+	},
+	domain.ViewStoryList: {
+		{"Up/Down", "Navigate"},
+		{"Shift+Up/Down", "Extend the selection"},
+		{"V", "Toggle visual selection"},
+		{"Space", "Toggle selection"},
+		{"a", "Select all visible"},
+		{"n", "Deselect all"},
+		{"/", "Select stories matching a query"},
+		{"e", "Cycle epic filter"},
+		{"f", "Cycle status filter"},
+		{"S", "Sort by health (least healthy first)"},
+		{"Enter", "Execute the story"},
+		{"p", "Preview and edit the steps, then run"},
+		{"D", "Diff the story against the base branch"},
+		{"q", "Add selected to queue"},
+		{"x", "Execute selected now"},
+		{"r", "Reload stories"},
+	},
+	domain.ViewQueue: {
+		{"Up/Down", "Navigate"},
+		{"Shift+K/J", "Move item up/down"},
+		{"Delete/x", "Remove from queue"},
+		{"Shift+C", "Clear queue"},
+		{"Shift+D", "Set a deadline for the item"},
+		{"Shift+B", "Set a deadline for the queue"},
+		{"Shift+O", "Earliest deadline first"},
+		{"Shift+T", "Tag the item's execution"},
+		{"Enter", "Start the queue"},
+		{"p", "Pause"},
+		{"r", "Resume"},
+		{"c", "Cancel"},
+		{"t", "Timeline"},
+	},
+	domain.ViewExecution: {
+		{"p", "Pause"},
+		{"r", "Resume, or retry from the failed step"},
+		{"k", "Skip the current step"},
+		{"c", "Cancel"},
+		{"m", "Minimize to the status bar"},
+		{"v", "Compare with the pre-run snapshot"},
+		{"L", "Full output with search (when finished)"},
+		{"u", "Restore the pre-run workspace (press twice)"},
+		{"t", "Browse attempts of retried steps"},
+		{"[/]", "Previous/next attempt"},
+		{"x", "Diff the attempt against the previous one"},
+		{"Up/Down, PgUp/PgDn", "Scroll"},
+		{"Home/End", "Jump to top/bottom"},
+		{"Enter", "Back to stories when finished"},
+	},
+	domain.ViewTimeline: {
+		{"Up/Down", "Scroll"},
+		{"Home/End", "Jump to top/bottom"},
+		{"g", "Bars on a shared time axis, or by duration"},
+	},
+	domain.ViewDiff: {
+		{"Up/Down, PgUp/PgDn", "Scroll"},
+		{"Home/End", "Jump to top/bottom"},
+		{"w/i/c", "Unstaged, staged or last commit changes"},
+		{"[/]", "Previous/next changed file"},
+		{"f", "Show or hide the file list"},
+		{"s", "Side-by-side or unified layout"},
+		{"p", "Toggle syntax highlighting"},
+	},
+	domain.ViewHistory: {
+		{"Up/Down, PgUp/PgDn", "Navigate"},
+		{"Enter", "View execution details"},
+		{"Space", "Expand or collapse a group"},
+		{"g", "Group by story, day or epic"},
+		{"l", "Show a shareable link"},
+		{"L", "Full output with search"},
+		{"D", "Diff the story against the base branch"},
+		{"n", "Add or edit a note"},
+		{"/", "Filter by story, or tag:name"},
+		{"c", "Clear the filter"},
+		{"r", "Reload"},
+	},
+	domain.ViewStats: {
+		{"Up/Down", "Scroll"},
+		{"7/3/9", "Last 7, 30 or 90 days"},
+		{"0", "All time"},
+		{"c", "Custom date range"},
+		{"x", "Export as an HTML report"},
+		{"r", "Reload"},
+	},
+	domain.ViewSettings: {
+		{"Up/Down", "Navigate"},
+		{"Left/Right", "Change the value"},
+		{"Enter/Space", "Toggle or cycle"},
+		{"p", "Preview usage metrics"},
+	},
+	domain.ViewPipelines: {
+		{"Up/Down", "Scroll"},
+		{"r", "Reload"},
+	},
+	domain.ViewLogs: {
+		{"Up/Down, PgUp/PgDn", "Scroll"},
+		{"Home/End", "Jump to top/bottom"},
+		{"/", "Search as you type"},
+		{"n/N", "Next/previous match"},
+		{"Esc", "Cancel the search, or go back"},
+	},
+	domain.ViewSchedules: {
+		{"Up/Down", "Navigate"},
+		{"Enter", "Run the schedule now"},
+		{"Space", "Enable or disable"},
+		{"n", "New schedule"},
+		{"Shift+D", "Delete (press twice)"},
+		{"r", "Reload the schedules file"},
+	},
+	domain.ViewEpics: {
+		{"Up/Down", "Navigate"},
+		{"Space", "Show or hide the epic's stories"},
+		{"q/Enter", "Queue every story of the epic not done or blocked"},
+		{"r", "Reload stories"},
+	},
+	domain.ViewSearch: {
+		{"Type", "Search as you type"},
+		{"Up/Down, PgUp/PgDn", "Navigate"},
+		{"Enter", "Open in the story list, execution or log view"},
+		{"Esc", "Clear the query, or go back"},
+	},
+}
+
+// For returns the bindings of view
+func For(view domain.View) []Binding {
+	return views[view]
+}
+
+// Groups returns the help sections for view: its own bindings first, then
+// the global ones
+func Groups(view domain.View) []Group {
+	var groups []Group
+	if bindings := For(view); len(bindings) > 0 {
+		groups = append(groups, Group{Title: view.String(), Bindings: bindings})
+	}
+	return append(groups, Group{Title: "Global", Bindings: Global})
+}
@@ -0,0 +1,48 @@
+package keymap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestEveryViewHasBindings(t *testing.T) {
+	for view := domain.ViewDashboard; view <= domain.ViewEpics; view++ {
+		assert.NotEmpty(t, For(view), view.String())
+	}
+}
+
+func TestNoDuplicateKeys(t *testing.T) {
+	groups := [][]Binding{Global}
+	for _, bindings := range views {
+		groups = append(groups, bindings)
+	}
+	for _, bindings := range groups {
+		seen := make(map[string]bool)
+		for _, b := range bindings {
+			assert.False(t, seen[b.Keys], "duplicate binding %q", b.Keys)
+			seen[b.Keys] = true
+		}
+	}
+}
+
+func TestGroups(t *testing.T) {
+	groups := Groups(domain.ViewQueue)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "Queue", groups[0].Title)
+	assert.Equal(t, "Global", groups[1].Title)
+}
+
+func TestTour(t *testing.T) {
+	seen := make(map[domain.View]bool)
+	for _, stop := range Tour {
+		assert.False(t, seen[stop.View], "%s is visited twice", stop.View)
+		seen[stop.View] = true
+		assert.NotEmpty(t, stop.Title)
+		assert.NotEmpty(t, stop.About, stop.Title)
+		assert.NotEmpty(t, stop.Bindings(), stop.Title)
+	}
+	assert.Equal(t, Global, Tour[0].Bindings(), "the tour starts with the global keys")
+}
@@ -0,0 +1,104 @@
+package keymap
+
+import "github.com/robertguss/bmad-automate-go/internal/domain"
+
+// Stop is one step of the onboarding tour: a view, what it is for and the
+// concepts behind it. Its keys come from the same bindings as the help
+// overlay.
+type Stop struct {
+	View  domain.View
+	Title string
+	About []string
+	// Global shows the global bindings instead of the view's own
+	Global bool
+}
+
+// Bindings returns the keys shown at the stop
+func (s Stop) Bindings() []Binding {
+	if s.Global {
+		return Global
+	}
+	return For(s.View)
+}
+
+// Tour walks through the views in the order a new user meets them
+var Tour = []Stop{
+	{
+		View:  domain.ViewDashboard,
+		Title: "Welcome to BMAD Automate",
+		About: []string{
+			"BMAD Automate runs the BMAD story workflow with Claude: each story goes",
+			"through create, develop, review and commit steps without you typing them.",
+			"The dashboard sums up the sprint. These keys work from every view.",
+		},
+		Global: true,
+	},
+	{
+		View:  domain.ViewStoryList,
+		Title: "Stories",
+		About: []string{
+			"Stories come from sprint-status.yaml. Filter by epic or status, select",
+			"stories with Space or a query such as \"ready-for-dev epic:3\", then run",
+			"one now or add the selection to the queue.",
+		},
+	},
+	{
+		View:  domain.ViewEpics,
+		Title: "Epics",
+		About: []string{
+			"Opened with e from the dashboard, this groups stories by epic with",
+			"how far along each is. An epic's open stories can be queued at once.",
+		},
+	},
+	{
+		View:  domain.ViewQueue,
+		Title: "The Queue",
+		About: []string{
+			"The queue runs stories one after another, in order, with an estimate",
+			"of when each finishes. It survives restarts, and can be paused, resumed",
+			"or started later from the palette.",
+		},
+	},
+	{
+		View:  domain.ViewExecution,
+		Title: "Execution",
+		About: []string{
+			"A run streams each step's output live. The steps come from the active",
+			"workflow: the built-in one, or a custom workflow defined in",
+			".bmad/workflows. Failed steps retry, and can be resumed by hand.",
+		},
+	},
+	{
+		View:  domain.ViewHistory,
+		Title: "History",
+		About: []string{
+			"Every run is saved with its steps and output. Open one for details,",
+			"group runs by story, day or epic, or search the full log.",
+		},
+	},
+	{
+		View:  domain.ViewStats,
+		Title: "Statistics",
+		About: []string{
+			"Success rates, step durations and how well estimates matched, built",
+			"from history. The queue uses the same numbers for its ETAs.",
+		},
+	},
+	{
+		View:  domain.ViewSchedules,
+		Title: "Schedules",
+		About: []string{
+			"Schedules start the queue with the stories matching a query at cron",
+			"times, such as every ready-for-dev story at 2am.",
+		},
+	},
+	{
+		View:  domain.ViewSettings,
+		Title: "Settings and Profiles",
+		About: []string{
+			"Change the theme, timeouts and retries here. A profile in .bmad/profiles",
+			"keeps settings per project, with its own paths and workflow. Every",
+			"action is also in the command palette (Ctrl+P).",
+		},
+	},
+}
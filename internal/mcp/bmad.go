@@ -0,0 +1,401 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/inbox"
+	"github.com/robertguss/bmad-automate-go/internal/parser"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// Limits on how much history one call returns
+const (
+	defaultExecutionLimit = 20
+	maxExecutionLimit     = 100
+	maxOutputLines        = 500
+)
+
+const instructions = `BMAD Automate runs BMAD stories through Claude: create-story, dev-story, code-review and git-commit.
+Use list_stories to find work, queue_stories to hand stories to the running bmad instance, and list_executions and get_execution to see how past runs went.`
+
+// New returns a server exposing the stories of cfg and the execution
+// history in store
+func New(cfg *config.Config, store storage.Storage) *Server {
+	b := &bmad{cfg: cfg, store: store}
+	s := &Server{Name: "bmad", Version: cfg.Version, Instructions: instructions}
+
+	s.AddTool(Tool{
+		Name:        "list_stories",
+		Description: "List the stories in the sprint, optionally of one epic or status (backlog, ready-for-dev, in-progress, review, done, blocked).",
+		InputSchema: object(map[string]any{
+			"epic":   property("integer", "Only stories of this epic"),
+			"status": property("string", "Only stories with this status"),
+		}),
+		Call: b.listStories,
+	})
+	s.AddTool(Tool{
+		Name:        "get_story",
+		Description: "Get a story and its most recent executions.",
+		InputSchema: object(map[string]any{
+			"key": property("string", "Story key, such as 3-1-user-auth"),
+		}, "key"),
+		Call: b.getStory,
+	})
+	s.AddTool(Tool{
+		Name: "queue_stories",
+		Description: "Add stories to the queue of the running bmad instance, and optionally start it. " +
+			"The request goes through bmad's inbox, which the instance must have enabled (BMAD_INBOX=1).",
+		InputSchema: object(map[string]any{
+			"keys":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Story keys to queue, in order"},
+			"start": property("boolean", "Start the queue once the stories are added"),
+		}, "keys"),
+		Call: b.queueStories,
+	})
+	s.AddTool(Tool{
+		Name:        "list_executions",
+		Description: "List past executions, newest first.",
+		InputSchema: object(map[string]any{
+			"story":  property("string", "Only executions of stories whose key contains this"),
+			"epic":   property("integer", "Only executions of this epic"),
+			"status": property("string", "Only executions with this status (completed, failed, cancelled)"),
+			"limit":  property("integer", fmt.Sprintf("Most executions to return (default %d, at most %d)", defaultExecutionLimit, maxExecutionLimit)),
+		}),
+		Call: b.listExecutions,
+	})
+	s.AddTool(Tool{
+		Name:        "get_execution",
+		Description: "Get an execution with its steps, and optionally the last lines of each step's output.",
+		InputSchema: object(map[string]any{
+			"id":           property("string", "Execution ID, or an unambiguous prefix of at least 8 characters"),
+			"output_lines": property("integer", fmt.Sprintf("Last output lines to include per step (default 0, at most %d)", maxOutputLines)),
+		}, "id"),
+		Call: b.getExecution,
+	})
+	s.AddTool(Tool{
+		Name:        "get_stats",
+		Description: "Get success rates and durations across all executions and per step.",
+		InputSchema: object(nil),
+		Call: func(ctx context.Context, _ json.RawMessage) (any, error) {
+			return b.stats(ctx)
+		},
+	})
+
+	s.AddResource(Resource{
+		URI:         "bmad://stories",
+		Name:        "Stories",
+		Description: "Every story in the sprint",
+		Read: func(ctx context.Context) (any, error) {
+			return b.listStories(ctx, json.RawMessage("{}"))
+		},
+	})
+	s.AddResource(Resource{
+		URI:         "bmad://executions",
+		Name:        "Recent executions",
+		Description: fmt.Sprintf("The last %d executions", defaultExecutionLimit),
+		Read: func(ctx context.Context) (any, error) {
+			return b.listExecutions(ctx, json.RawMessage("{}"))
+		},
+	})
+	s.AddResource(Resource{
+		URI:         "bmad://stats",
+		Name:        "Statistics",
+		Description: "Success rates and durations",
+		Read:        b.stats,
+	})
+	s.AddResourceTemplate(ResourceTemplate{
+		URITemplate: "bmad://executions/{id}",
+		Prefix:      "bmad://executions/",
+		Name:        "Execution",
+		Description: "One execution with its steps",
+		Read: func(ctx context.Context, id string) (any, error) {
+			return b.execution(ctx, id, 0)
+		},
+	})
+	return s
+}
+
+// object builds the JSON Schema of an arguments object
+func object(properties map[string]any, required ...string) map[string]any {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func property(typ, description string) map[string]any {
+	return map[string]any{"type": typ, "description": description}
+}
+
+// bmad implements the tools
+type bmad struct {
+	cfg   *config.Config
+	store storage.Storage
+}
+
+// storyView is a story as agents see it
+type storyView struct {
+	Key        string `json:"key"`
+	Epic       int    `json:"epic"`
+	Status     string `json:"status"`
+	Title      string `json:"title,omitempty"`
+	FileExists bool   `json:"file_exists"`
+}
+
+// executionView summarizes an execution
+type executionView struct {
+	ID        string     `json:"id"`
+	Story     string     `json:"story"`
+	Epic      int        `json:"epic"`
+	Status    string     `json:"status"`
+	StartTime time.Time  `json:"start_time"`
+	Duration  float64    `json:"duration_seconds"`
+	Error     string     `json:"error,omitempty"`
+	Steps     []stepView `json:"steps,omitempty"`
+}
+
+// stepView is one step of an execution
+type stepView struct {
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Attempts int      `json:"attempts"`
+	Duration float64  `json:"duration_seconds"`
+	Error    string   `json:"error,omitempty"`
+	Output   []string `json:"output,omitempty"`
+}
+
+func (b *bmad) stories(ctx context.Context) ([]domain.Story, error) {
+	stories, err := parser.LoadStories(ctx, b.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stories: %w", err)
+	}
+	return stories, nil
+}
+
+func (b *bmad) listStories(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		Epic   int    `json:"epic"`
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	stories, err := b.stories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]storyView, 0, len(stories))
+	for _, story := range stories {
+		if args.Epic != 0 && story.Epic != args.Epic {
+			continue
+		}
+		if args.Status != "" && string(story.Status) != args.Status {
+			continue
+		}
+		views = append(views, viewStory(story))
+	}
+	return views, nil
+}
+
+func (b *bmad) getStory(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	stories, err := b.stories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, story := range stories {
+		if story.Key != args.Key {
+			continue
+		}
+		records, err := b.store.GetExecutionsByStory(ctx, story.Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 5 {
+			records = records[:5]
+		}
+		return struct {
+			storyView
+			Executions []executionView `json:"recent_executions"`
+		}{viewStory(story), viewExecutions(records)}, nil
+	}
+	return nil, fmt.Errorf("story %q not found", args.Key)
+}
+
+func (b *bmad) queueStories(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args inbox.Request
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if len(args.Keys) == 0 {
+		return nil, fmt.Errorf("no story keys given")
+	}
+	stories, err := b.stories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(stories))
+	for _, story := range stories {
+		known[story.Key] = true
+	}
+	for _, key := range args.Keys {
+		if !known[key] {
+			return nil, fmt.Errorf("story %q not found", key)
+		}
+	}
+
+	name, err := inbox.Drop(b.cfg.InboxDir(), "mcp", args)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"queued":  args.Keys,
+		"start":   args.Start,
+		"request": name,
+		"note":    "The running bmad instance adds the stories when it reads its inbox, which needs BMAD_INBOX=1.",
+	}, nil
+}
+
+func (b *bmad) listExecutions(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		Story  string `json:"story"`
+		Epic   int    `json:"epic"`
+		Status string `json:"status"`
+		Limit  int    `json:"limit"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	filter := &storage.ExecutionFilter{
+		StoryKey: args.Story,
+		Status:   domain.ExecutionStatus(args.Status),
+		Limit:    min(max(args.Limit, 0), maxExecutionLimit),
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultExecutionLimit
+	}
+	if args.Epic != 0 {
+		filter.Epic = &args.Epic
+	}
+
+	records, err := b.store.ListExecutions(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return viewExecutions(records), nil
+}
+
+func (b *bmad) getExecution(ctx context.Context, raw json.RawMessage) (any, error) {
+	var args struct {
+		ID          string `json:"id"`
+		OutputLines int    `json:"output_lines"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	return b.execution(ctx, args.ID, min(max(args.OutputLines, 0), maxOutputLines))
+}
+
+// execution loads an execution with its steps and the last outputLines of
+// each step's output
+func (b *bmad) execution(ctx context.Context, idOrPrefix string, outputLines int) (*executionView, error) {
+	id, err := b.store.ResolveExecutionID(ctx, idOrPrefix)
+	if err != nil {
+		return nil, err
+	}
+	var record *storage.ExecutionRecord
+	if outputLines > 0 {
+		record, err = b.store.GetExecutionWithOutput(ctx, id)
+	} else {
+		record, err = b.store.GetExecution(ctx, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	view := viewExecution(record)
+	for _, step := range record.Steps {
+		sv := stepView{
+			Name:     string(step.StepName),
+			Status:   string(step.Status),
+			Attempts: step.Attempt,
+			Duration: step.Duration.Seconds(),
+			Error:    step.Error,
+		}
+		if outputLines > 0 {
+			sv.Output = step.Output[max(len(step.Output)-outputLines, 0):]
+		}
+		view.Steps = append(view.Steps, sv)
+	}
+	return &view, nil
+}
+
+func (b *bmad) stats(ctx context.Context) (any, error) {
+	stats, err := b.store.GetStats(ctx, domain.DateRange{})
+	if err != nil {
+		return nil, err
+	}
+
+	type stepStats struct {
+		Runs        int     `json:"runs"`
+		SuccessRate float64 `json:"success_rate"`
+		AvgDuration float64 `json:"avg_duration_seconds"`
+	}
+	steps := make(map[string]stepStats, len(stats.StepStats))
+	for name, s := range stats.StepStats {
+		steps[string(name)] = stepStats{Runs: s.TotalCount, SuccessRate: s.SuccessRate, AvgDuration: s.AvgDuration.Seconds()}
+	}
+	return map[string]any{
+		"executions":           stats.TotalExecutions,
+		"completed":            stats.SuccessfulCount,
+		"failed":               stats.FailedCount,
+		"cancelled":            stats.CancelledCount,
+		"success_rate":         stats.SuccessRate,
+		"avg_duration_seconds": stats.AvgDuration.Seconds(),
+		"steps":                steps,
+	}, nil
+}
+
+func viewStory(story domain.Story) storyView {
+	return storyView{
+		Key:        story.Key,
+		Epic:       story.Epic,
+		Status:     string(story.Status),
+		Title:      story.Title,
+		FileExists: story.FileExists,
+	}
+}
+
+func viewExecution(record *storage.ExecutionRecord) executionView {
+	return executionView{
+		ID:        record.ID,
+		Story:     record.StoryKey,
+		Epic:      record.StoryEpic,
+		Status:    string(record.Status),
+		StartTime: record.StartTime,
+		Duration:  record.Duration.Seconds(),
+		Error:     record.Error,
+	}
+}
+
+func viewExecutions(records []*storage.ExecutionRecord) []executionView {
+	views := make([]executionView, 0, len(records))
+	for _, record := range records {
+		views = append(views, viewExecution(record))
+	}
+	return views
+}
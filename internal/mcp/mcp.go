@@ -0,0 +1,301 @@
+// Package mcp serves BMAD over the Model Context Protocol, so agents such
+// as Claude can look up stories, queue work and read execution history.
+// The server speaks JSON-RPC 2.0 over stdio, one message per line.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ProtocolVersions are the protocol revisions the server speaks, newest
+// first
+var ProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is a function agents can call
+type Tool struct {
+	Name        string
+	Description string
+	InputSchema map[string]any // JSON Schema of the arguments
+
+	// Call runs the tool. The result is returned to the agent as JSON; an
+	// error is reported as a failed call rather than a protocol error.
+	Call func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Resource is a document agents can read
+type Resource struct {
+	URI         string
+	Name        string
+	Description string
+	Read        func(ctx context.Context) (any, error)
+}
+
+// ResourceTemplate is a family of resources whose URIs start with Prefix,
+// such as bmad://executions/{id}
+type ResourceTemplate struct {
+	URITemplate string
+	Prefix      string
+	Name        string
+	Description string
+	Read        func(ctx context.Context, rest string) (any, error)
+}
+
+// Server answers MCP requests
+type Server struct {
+	Name         string
+	Version      string
+	Instructions string // Told to the agent when it connects
+
+	tools     []Tool
+	resources []Resource
+	templates []ResourceTemplate
+}
+
+// AddTool registers a tool
+func (s *Server) AddTool(t Tool) {
+	s.tools = append(s.tools, t)
+}
+
+// AddResource registers a resource
+func (s *Server) AddResource(r Resource) {
+	s.resources = append(s.resources, r)
+}
+
+// AddResourceTemplate registers a resource template
+func (s *Server) AddResourceTemplate(t ResourceTemplate) {
+	s.templates = append(s.templates, t)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Serve reads requests from r and writes responses to w until r is
+// exhausted or ctx is done
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	in := bufio.NewReader(r)
+	out := json.NewEncoder(w)
+	for ctx.Err() == nil {
+		line, err := in.ReadBytes('\n')
+		if len(strings.TrimSpace(string(line))) > 0 {
+			if resp := s.handle(ctx, line); resp != nil {
+				if err := out.Encode(resp); err != nil {
+					return fmt.Errorf("failed to write response: %w", err)
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read request: %w", err)
+		}
+	}
+	return ctx.Err()
+}
+
+// handle answers one message. Notifications get no response.
+func (s *Server) handle(ctx context.Context, line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: "parse error"}}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: "invalid request"}}
+	}
+
+	result, err := s.dispatch(ctx, req.Method, req.Params)
+	resp := &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		resp.Result, resp.Error = nil, rerr
+	}
+	return resp
+}
+
+func (s *Server) dispatch(ctx context.Context, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		var p struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		version := ProtocolVersions[0]
+		if slices.Contains(ProtocolVersions, p.ProtocolVersion) {
+			version = p.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities": map[string]any{
+				"tools":     map[string]any{},
+				"resources": map[string]any{},
+			},
+			"serverInfo":   map[string]string{"name": s.Name, "version": s.Version},
+			"instructions": s.Instructions,
+		}, nil
+
+	case "ping":
+		return map[string]any{}, nil
+
+	case "tools/list":
+		tools := make([]map[string]any, 0, len(s.tools))
+		for _, t := range s.tools {
+			tools = append(tools, map[string]any{
+				"name":        t.Name,
+				"description": t.Description,
+				"inputSchema": t.InputSchema,
+			})
+		}
+		return map[string]any{"tools": tools}, nil
+
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, p.Name, p.Arguments)
+
+	case "resources/list":
+		resources := make([]map[string]string, 0, len(s.resources))
+		for _, r := range s.resources {
+			resources = append(resources, map[string]string{
+				"uri":         r.URI,
+				"name":        r.Name,
+				"description": r.Description,
+				"mimeType":    "application/json",
+			})
+		}
+		return map[string]any{"resources": resources}, nil
+
+	case "resources/templates/list":
+		templates := make([]map[string]string, 0, len(s.templates))
+		for _, t := range s.templates {
+			templates = append(templates, map[string]string{
+				"uriTemplate": t.URITemplate,
+				"name":        t.Name,
+				"description": t.Description,
+				"mimeType":    "application/json",
+			})
+		}
+		return map[string]any{"resourceTemplates": templates}, nil
+
+	case "resources/read":
+		var p struct {
+			URI string `json:"uri"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.readResource(ctx, p.URI)
+
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+func (s *Server) callTool(ctx context.Context, name string, args json.RawMessage) (any, error) {
+	i := slices.IndexFunc(s.tools, func(t Tool) bool { return t.Name == name })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown tool %q", name)
+	}
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+
+	result, err := s.tools[i].Call(ctx, args)
+	if err != nil {
+		return map[string]any{
+			"content": []map[string]string{{"type": "text", "text": err.Error()}},
+			"isError": true,
+		}, nil
+	}
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": string(text)}},
+		"isError": false,
+	}, nil
+}
+
+func (s *Server) readResource(ctx context.Context, uri string) (any, error) {
+	var data any
+	var err error
+	if i := slices.IndexFunc(s.resources, func(r Resource) bool { return r.URI == uri }); i >= 0 {
+		data, err = s.resources[i].Read(ctx)
+	} else if i := slices.IndexFunc(s.templates, func(t ResourceTemplate) bool {
+		return strings.HasPrefix(uri, t.Prefix) && len(uri) > len(t.Prefix)
+	}); i >= 0 {
+		data, err = s.templates[i].Read(ctx, strings.TrimPrefix(uri, s.templates[i].Prefix))
+	} else {
+		return nil, fmt.Errorf("unknown resource %q", uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"contents": []map[string]string{{"uri": uri, "mimeType": "application/json", "text": string(text)}},
+	}, nil
+}
+
+// decodeParams decodes request params, which may be absent
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
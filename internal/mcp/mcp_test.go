@@ -0,0 +1,174 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/inbox"
+	"github.com/robertguss/bmad-automate-go/internal/testutil"
+)
+
+// exchange sends requests to s, one per line, and decodes the responses
+func exchange(t *testing.T, s *Server, requests ...string) []map[string]any {
+	t.Helper()
+	var out bytes.Buffer
+	require.NoError(t, s.Serve(context.Background(), strings.NewReader(strings.Join(requests, "\n")), &out))
+
+	var responses []map[string]any
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp map[string]any
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// toolText calls a tool and returns its text content and whether it failed
+func toolText(t *testing.T, s *Server, name, args string) (string, bool) {
+	t.Helper()
+	responses := exchange(t, s, `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"`+name+`","arguments":`+args+`}}`)
+	require.Len(t, responses, 1)
+	result := responses[0]["result"].(map[string]any)
+	content := result["content"].([]any)[0].(map[string]any)
+	return content["text"].(string), result["isError"].(bool)
+}
+
+func TestServer_Protocol(t *testing.T) {
+	s := &Server{Name: "test", Version: "1.0"}
+	s.AddTool(Tool{
+		Name:        "echo",
+		InputSchema: object(nil),
+		Call: func(_ context.Context, args json.RawMessage) (any, error) {
+			return json.RawMessage(args), nil
+		},
+	})
+
+	responses := exchange(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":"two","method":"ping"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"unknown"}`,
+		`not json`,
+	)
+	require.Len(t, responses, 5, "notifications are not answered")
+
+	init := responses[0]["result"].(map[string]any)
+	assert.Equal(t, "2024-11-05", init["protocolVersion"], "a supported client version is kept")
+	assert.Equal(t, "test", init["serverInfo"].(map[string]any)["name"])
+
+	assert.Equal(t, "two", responses[1]["id"])
+	assert.Equal(t, map[string]any{}, responses[1]["result"])
+
+	tools := responses[2]["result"].(map[string]any)["tools"].([]any)
+	require.Len(t, tools, 1)
+	assert.Equal(t, "echo", tools[0].(map[string]any)["name"])
+
+	assert.Equal(t, float64(codeMethodNotFound), responses[3]["error"].(map[string]any)["code"])
+	assert.Equal(t, float64(codeParseError), responses[4]["error"].(map[string]any)["code"])
+
+	responses = exchange(t, s, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"1999-01-01"}}`)
+	assert.Equal(t, ProtocolVersions[0], responses[0]["result"].(map[string]any)["protocolVersion"])
+}
+
+func newTestServer(t *testing.T) (*Server, *config.Config, string) {
+	t.Helper()
+	cfg := testutil.NewTestConfig(t)
+	require.NoError(t, os.WriteFile(cfg.SprintStatusPath, []byte(`development_status:
+  3-1-user-auth: ready-for-dev
+  3-2-user-profile: backlog
+  4-1-dashboard: ready-for-dev
+`), 0644))
+
+	store := testutil.NewTestStorage(t)
+	execution := testutil.CreateCompletedExecution(domain.Story{Key: "3-1-user-auth", Epic: 3})
+	execution.Steps[0].Output = []string{"first", "second", "third"}
+	require.NoError(t, store.SaveExecution(context.Background(), execution))
+
+	return New(cfg, store), cfg, execution.ID
+}
+
+func TestBMAD_Stories(t *testing.T) {
+	s, _, _ := newTestServer(t)
+
+	text, failed := toolText(t, s, "list_stories", `{"status":"ready-for-dev"}`)
+	require.False(t, failed)
+	var stories []storyView
+	require.NoError(t, json.Unmarshal([]byte(text), &stories))
+	require.Len(t, stories, 2)
+	assert.Equal(t, "3-1-user-auth", stories[0].Key)
+	assert.Equal(t, "4-1-dashboard", stories[1].Key)
+
+	text, failed = toolText(t, s, "get_story", `{"key":"3-1-user-auth"}`)
+	require.False(t, failed)
+	assert.Contains(t, text, `"recent_executions"`)
+	assert.Contains(t, text, `"status": "completed"`)
+
+	text, failed = toolText(t, s, "get_story", `{"key":"9-9-missing"}`)
+	assert.True(t, failed)
+	assert.Contains(t, text, "not found")
+}
+
+func TestBMAD_QueueStories(t *testing.T) {
+	s, cfg, _ := newTestServer(t)
+
+	text, failed := toolText(t, s, "queue_stories", `{"keys":["3-1-user-auth","4-1-dashboard"],"start":true}`)
+	require.False(t, failed, text)
+	var result struct {
+		Request string `json:"request"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &result))
+
+	names, err := inbox.Pending(cfg.InboxDir())
+	require.NoError(t, err)
+	assert.Equal(t, []string{result.Request}, names)
+	data, err := os.ReadFile(filepath.Join(cfg.InboxDir(), result.Request))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"keys":["3-1-user-auth","4-1-dashboard"],"start":true}`, string(data))
+
+	_, failed = toolText(t, s, "queue_stories", `{"keys":["9-9-missing"]}`)
+	assert.True(t, failed, "unknown stories are rejected")
+	_, failed = toolText(t, s, "queue_stories", `{"keys":[]}`)
+	assert.True(t, failed)
+
+	names, err = inbox.Pending(cfg.InboxDir())
+	require.NoError(t, err)
+	assert.Len(t, names, 1, "rejected calls queue nothing")
+}
+
+func TestBMAD_History(t *testing.T) {
+	s, _, id := newTestServer(t)
+
+	text, failed := toolText(t, s, "list_executions", `{"story":"3-1"}`)
+	require.False(t, failed)
+	var executions []executionView
+	require.NoError(t, json.Unmarshal([]byte(text), &executions))
+	require.Len(t, executions, 1)
+	assert.Equal(t, id, executions[0].ID)
+
+	text, failed = toolText(t, s, "get_execution", `{"id":"`+id[:8]+`","output_lines":2}`)
+	require.False(t, failed, text)
+	var execution executionView
+	require.NoError(t, json.Unmarshal([]byte(text), &execution))
+	assert.Equal(t, id, execution.ID)
+	require.NotEmpty(t, execution.Steps)
+	assert.Equal(t, []string{"second", "third"}, execution.Steps[0].Output)
+
+	responses := exchange(t, s, `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"bmad://executions/`+id+`"}}`)
+	contents := responses[0]["result"].(map[string]any)["contents"].([]any)
+	assert.Contains(t, contents[0].(map[string]any)["text"], `"story": "3-1-user-auth"`)
+
+	responses = exchange(t, s, `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"bmad://nothing"}}`)
+	assert.NotNil(t, responses[0]["error"])
+}
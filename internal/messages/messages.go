@@ -54,8 +54,22 @@ type ExecutionStartedMsg struct {
 	Execution *domain.Execution
 }
 
+// StepRef identifies the execution a step message belongs to, so output
+// of stories running side by side is never attributed to another one
+type StepRef struct {
+	ExecutionID string
+	StoryKey    string
+}
+
+// Belongs reports whether the message is for execution. Messages without
+// a reference predate it and are taken to belong.
+func (r StepRef) Belongs(execution *domain.Execution) bool {
+	return r.ExecutionID == "" || execution == nil || r.ExecutionID == execution.ID
+}
+
 // StepStartedMsg is sent when a step begins execution
 type StepStartedMsg struct {
+	StepRef
 	StepIndex int
 	StepName  domain.StepName
 	Command   string
@@ -64,6 +78,7 @@ type StepStartedMsg struct {
 
 // StepOutputMsg is sent when a step produces output
 type StepOutputMsg struct {
+	StepRef
 	StepIndex int
 	Line      string
 	IsStderr  bool
@@ -71,10 +86,32 @@ type StepOutputMsg struct {
 
 // StepCompletedMsg is sent when a step finishes
 type StepCompletedMsg struct {
+	StepRef
 	StepIndex int
 	Status    domain.StepStatus
 	Duration  time.Duration
 	Error     string
+	TimedOut  bool         // The last attempt was stopped at the step timeout
+	Usage     domain.Usage // Tokens and cost of the step's agent calls, if tracked
+}
+
+// StepStalledMsg is sent when a running step has produced no output for
+// the configured stall timeout
+type StepStalledMsg struct {
+	StepRef
+	StepIndex int
+	StepName  domain.StepName
+	Idle      time.Duration
+	Killed    bool // True if the step was killed to be retried
+}
+
+// StepWaitingMsg is sent when a wait step pauses the run until the user
+// resumes it
+type StepWaitingMsg struct {
+	StepRef
+	StepIndex int
+	StepName  domain.StepName
+	Message   string
 }
 
 // ExecutionCompletedMsg is sent when all steps are done
@@ -129,6 +166,20 @@ type QueueMoveDownMsg struct {
 	Index int
 }
 
+// QueueDeadlineMsg requests setting the deadline of a queued story, or of
+// the whole queue when Key is empty. Spec is parsed by domain.ParseDeadline.
+type QueueDeadlineMsg struct {
+	Key  string
+	Spec string
+}
+
+// QueueTagsMsg requests replacing the tags of a queued story; empty Tags
+// clears them
+type QueueTagsMsg struct {
+	Key  string
+	Tags []string
+}
+
 // QueueStartMsg requests starting queue execution
 type QueueStartMsg struct{}
 
@@ -163,9 +214,16 @@ type QueueCompletedMsg struct {
 	TotalItems    int
 	SuccessCount  int
 	FailedCount   int
+	ConflictCount int // Stories parked with unresolved merge conflicts
 	TotalDuration time.Duration
 }
 
+// QueueWindowPausedMsg is sent when the queue pauses at a story boundary
+// because the run window has closed or the next story would outlast it
+type QueueWindowPausedMsg struct {
+	ResumeAt time.Time // When the run window next opens (zero = never)
+}
+
 // QueueUpdatedMsg is sent when queue state changes
 type QueueUpdatedMsg struct {
 	Queue *domain.Queue
@@ -190,6 +248,8 @@ type HistoryExecution struct {
 	Duration  time.Duration
 	StepCount int
 	ErrorMsg  string
+	Tags      []string
+	Note      string
 }
 
 // HistoryFilterMsg requests filtering history
@@ -207,6 +267,57 @@ type HistoryDetailMsg struct {
 	ID string
 }
 
+// HistoryNoteMsg requests saving the note of an execution; an empty Note
+// removes it
+type HistoryNoteMsg struct {
+	ID   string
+	Note string
+}
+
+// HistoryLinkMsg requests a shareable link to an execution
+type HistoryLinkMsg struct {
+	ID string
+}
+
+// HistoryGroupMsg requests grouped history ("story", "day", "epic", or ""
+// for the flat list)
+type HistoryGroupMsg struct {
+	GroupBy string
+}
+
+// HistoryGroupsLoadedMsg is sent when grouped history is loaded
+type HistoryGroupsLoadedMsg struct {
+	GroupBy string
+	Groups  []*HistoryGroup
+	Error   error
+}
+
+// HistoryGroup holds aggregate statistics for one history group
+type HistoryGroup struct {
+	Key           string
+	Count         int
+	SuccessCount  int
+	FailedCount   int
+	SuccessRate   float64
+	AvgDuration   time.Duration
+	TotalDuration time.Duration
+	LastRun       time.Time
+}
+
+// HistoryGroupExpandMsg requests the executions in one history group
+type HistoryGroupExpandMsg struct {
+	GroupBy string
+	Key     string
+}
+
+// HistoryGroupExecutionsMsg is sent when a group's executions are loaded
+type HistoryGroupExecutionsMsg struct {
+	GroupBy    string
+	Key        string
+	Executions []*HistoryExecution
+	Error      error
+}
+
 // ========== Statistics Messages ==========
 
 // StatsLoadedMsg is sent when statistics are loaded
@@ -215,8 +326,18 @@ type StatsLoadedMsg struct {
 	Error error
 }
 
+// StatsRangeMsg requests statistics over a different date range
+type StatsRangeMsg struct {
+	Range domain.DateRange
+}
+
+// StatsExportMsg requests an HTML report of the statistics over the range
+// the stats view shows
+type StatsExportMsg struct{}
+
 // StatsData contains all statistics for display
 type StatsData struct {
+	Range            domain.DateRange // Executions started in it are counted
 	TotalExecutions  int
 	SuccessfulCount  int
 	FailedCount      int
@@ -227,6 +348,64 @@ type StatsData struct {
 	StepStats        map[domain.StepName]*StepStatsData
 	ExecutionsByDay  map[string]int
 	ExecutionsByEpic map[int]int
+	Calibration      *CalibrationData        // nil until predictions have been recorded
+	Usage            *UsageData              // nil until token usage has been recorded
+	Deadlines        *DeadlineData           // nil until a run has had a deadline
+	DurationTrend    []*WeeklyDurationData   // Oldest first, weeks with a completed run only
+	Unreliable       []*StoryReliabilityData // Stories that failed or retried most, worst first
+}
+
+// StoryReliabilityData counts the failed and retried runs of a story
+type StoryReliabilityData struct {
+	StoryKey    string
+	Runs        int
+	Failures    int
+	Retries     int
+	FailureRate float64
+	LastFailure time.Time
+}
+
+// WeeklyDurationData is the average duration of the runs completed in a
+// week, overall and per step
+type WeeklyDurationData struct {
+	Week  time.Time
+	Count int
+	Avg   time.Duration
+	Steps map[domain.StepName]time.Duration
+}
+
+// DeadlineData is how often runs with a deadline finished by it
+type DeadlineData struct {
+	Total   int
+	Met     int
+	HitRate float64
+}
+
+// UsageData is the recorded token usage and cost of executions
+type UsageData struct {
+	Executions   int
+	Total        domain.Usage
+	PerExecution domain.Usage
+	Steps        map[domain.StepName]domain.Usage
+}
+
+// CalibrationData compares predicted durations with actual ones
+type CalibrationData struct {
+	Samples      int
+	MeanAbsError float64   // Mean absolute percentage error
+	Bias         float64   // Mean signed percentage error; positive = estimates too low
+	ErrorTrend   []float64 // Percentage error per story, oldest first
+	Steps        map[domain.StepName]*StepCalibrationData
+}
+
+// StepCalibrationData contains estimate accuracy for a single step
+type StepCalibrationData struct {
+	StepName     domain.StepName
+	Samples      int
+	AvgPredicted time.Duration
+	AvgActual    time.Duration
+	MeanAbsError float64
+	Bias         float64
 }
 
 // StepStatsData contains statistics for a single step
@@ -240,16 +419,138 @@ type StepStatsData struct {
 	AvgDuration  time.Duration
 	MinDuration  time.Duration
 	MaxDuration  time.Duration
+	// DurationHistogram holds bucketed counts of successful run durations
+	DurationHistogram []int
+	// Retries is nil until attempts have been recorded for the step
+	Retries *RetryStatsData
+}
+
+// RetryStatsData contains retry statistics for a single step
+type RetryStatsData struct {
+	Attempts       int
+	Runs           int
+	Retried        int // Runs that needed more than one attempt
+	Recovered      int // Retried runs that eventually succeeded
+	FirstTryRate   float64
+	AttemptsPerRun float64
 }
 
 // StatsRefreshMsg requests refreshing statistics
 type StatsRefreshMsg struct{}
 
+// ========== Pipeline Messages ==========
+
+// PipelineRunsLoadedMsg is sent when recent pipeline runs are loaded
+type PipelineRunsLoadedMsg struct {
+	Runs  []*domain.PipelineRun
+	Error error
+}
+
+// PipelinesRefreshMsg requests reloading pipeline runs
+type PipelinesRefreshMsg struct{}
+
+// ========== Schedule Messages ==========
+
+// ScheduleEntry is a schedule as shown in the schedules view
+type ScheduleEntry struct {
+	Name       string
+	Cron       string
+	Select     string
+	Enabled    bool
+	Matching   int       // Stories a run would queue now
+	NextRun    time.Time // Zero when disabled
+	LastRun    time.Time // Zero when it has not run
+	LastResult string
+}
+
+// SchedulesLoadedMsg is sent when the schedules are loaded
+type SchedulesLoadedMsg struct {
+	Schedules []ScheduleEntry
+	Error     error
+}
+
+// SchedulesRefreshMsg requests reloading the schedules file
+type SchedulesRefreshMsg struct{}
+
+// ScheduleRunMsg requests running a schedule now
+type ScheduleRunMsg struct {
+	Name string
+}
+
+// ScheduleToggleMsg requests enabling or disabling a schedule
+type ScheduleToggleMsg struct {
+	Name    string
+	Enabled bool
+}
+
+// ScheduleDeleteMsg requests deleting a schedule
+type ScheduleDeleteMsg struct {
+	Name string
+}
+
+// ScheduleAddMsg requests adding a schedule written as
+// "<name> <cron expression> <story query>"
+type ScheduleAddMsg struct {
+	Spec string
+}
+
+// ========== Log Messages ==========
+
+// LogsRequestMsg requests the saved output of an execution
+type LogsRequestMsg struct {
+	ID string
+
+	// Optional: search the output for Find, starting at the first line
+	// equal to Line
+	Find string
+	Line string
+}
+
+// LogsLoadedMsg is sent when an execution's saved output is loaded
+type LogsLoadedMsg struct {
+	ExecutionID string
+	StoryKey    string
+	Lines       []string
+	Error       error
+}
+
+// ========== Search Messages ==========
+
+// SearchRequestMsg requests stories, executions and output matching Query
+type SearchRequestMsg struct {
+	Query string
+}
+
+// SearchResultsMsg is sent when a search finishes
+type SearchResultsMsg struct {
+	Query      string
+	Stories    []domain.Story
+	Executions []*HistoryExecution
+	Output     []*OutputHit
+	Error      error
+}
+
+// OutputHit is a saved output line matching a search
+type OutputHit struct {
+	ExecutionID string
+	StoryKey    string
+	StepName    domain.StepName
+	Line        string
+	StartTime   time.Time
+}
+
+// StoryFocusMsg requests showing a story in the story list
+type StoryFocusMsg struct {
+	Key string
+}
+
 // ========== Diff Messages ==========
 
 // DiffLoadedMsg is sent when diff content is loaded
 type DiffLoadedMsg struct {
 	StoryKey string
+	Base     string // Set for story diffs, the branch or commit compared against
+	Source   string // What a working tree diff compared, as in DiffRequestMsg
 	Content  string
 	Error    error
 }
@@ -257,6 +558,11 @@ type DiffLoadedMsg struct {
 // DiffRequestMsg requests loading diff for a story
 type DiffRequestMsg struct {
 	StoryKey string
+	Base     string // Commit or ref to compare the working tree with (default: the index)
+	Source   string // Without Base: git.DiffUnstaged (default), git.DiffStaged or git.DiffHead
+	// Story shows the changes of the story's branch or commits since the
+	// configured diff base instead of the working tree
+	Story bool
 }
 
 // ========== Phase 6: Profile Messages ==========
@@ -10,6 +10,7 @@ import (
 // Notifier handles desktop notifications
 type Notifier struct {
 	enabled bool
+	focused bool // The terminal has focus, so the user already sees the event
 }
 
 // New creates a new notifier
@@ -27,9 +28,16 @@ func (n *Notifier) IsEnabled() bool {
 	return n.enabled
 }
 
+// SetFocused records whether the terminal has focus. Notifications are
+// held back while it does. Terminals that do not report focus never call
+// this, so they keep getting every notification.
+func (n *Notifier) SetFocused(focused bool) {
+	n.focused = focused
+}
+
 // Notify sends a desktop notification
 func (n *Notifier) Notify(title, message string) error {
-	if !n.enabled {
+	if !n.enabled || n.focused {
 		return nil
 	}
 
@@ -70,6 +78,15 @@ func (n *Notifier) NotifyQueueComplete(total, succeeded, failed int) error {
 	return n.Notify(title, message)
 }
 
+// NotifyStepFailed sends notification when a step fails after its retries
+func (n *Notifier) NotifyStepFailed(storyKey, step, reason string) error {
+	message := fmt.Sprintf("%s: %s failed", storyKey, step)
+	if reason != "" {
+		message += " - " + reason
+	}
+	return n.Notify("Step Failed", message)
+}
+
 // NotifyStoryComplete sends notification when a story completes
 func (n *Notifier) NotifyStoryComplete(storyKey string, success bool) error {
 	var title, message string
@@ -85,6 +102,30 @@ func (n *Notifier) NotifyStoryComplete(storyKey string, success bool) error {
 	return n.Notify(title, message)
 }
 
+// maxListedStories is how many story keys a notification names before
+// summarizing the rest
+const maxListedStories = 5
+
+// NotifyStoriesReady sends notification when watch mode sees stories become
+// ready-for-dev
+func (n *Notifier) NotifyStoriesReady(keys []string) error {
+	title := "New Stories Ready"
+	if len(keys) == 1 {
+		title = "New Story Ready"
+	}
+
+	listed := keys
+	if len(listed) > maxListedStories {
+		listed = listed[:maxListedStories]
+	}
+	message := strings.Join(listed, ", ")
+	if more := len(keys) - len(listed); more > 0 {
+		message += fmt.Sprintf(" and %d more", more)
+	}
+
+	return n.Notify(title, message)
+}
+
 // notifyMacOS sends notification using osascript on macOS
 func (n *Notifier) notifyMacOS(title, message string) error {
 	// Escape quotes in title and message
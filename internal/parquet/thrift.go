@@ -0,0 +1,100 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Thrift compact protocol type IDs used by the Parquet metadata
+const (
+	thriftI32    byte = 5
+	thriftI64    byte = 6
+	thriftBinary byte = 8
+	thriftList   byte = 9
+	thriftStruct byte = 12
+)
+
+// thriftWriter encodes structs with the Thrift compact protocol, which is
+// how Parquet serializes page headers and the file footer
+type thriftWriter struct {
+	buf    bytes.Buffer
+	lastID int16
+	stack  []int16
+}
+
+func (t *thriftWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	t.buf.Write(b[:n])
+}
+
+func (t *thriftWriter) zigzag32(v int32) {
+	t.uvarint(uint64(uint32((v << 1) ^ (v >> 31))))
+}
+
+func (t *thriftWriter) zigzag64(v int64) {
+	t.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+// field writes a field header, using the short delta form when possible
+func (t *thriftWriter) field(id int16, typ byte) {
+	if delta := id - t.lastID; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.zigzag32(int32(id))
+	}
+	t.lastID = id
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.zigzag32(v)
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.zigzag64(v)
+}
+
+func (t *thriftWriter) string(id int16, v string) {
+	t.field(id, thriftBinary)
+	t.rawString(v)
+}
+
+func (t *thriftWriter) rawString(v string) {
+	t.uvarint(uint64(len(v)))
+	t.buf.WriteString(v)
+}
+
+// list writes a field header followed by a list header
+func (t *thriftWriter) list(id int16, elem byte, size int) {
+	t.field(id, thriftList)
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elem)
+		return
+	}
+	t.buf.WriteByte(0xF0 | elem)
+	t.uvarint(uint64(size))
+}
+
+// structField starts a nested struct field; close it with end
+func (t *thriftWriter) structField(id int16) {
+	t.field(id, thriftStruct)
+	t.begin()
+}
+
+// begin starts a struct, e.g. a list element
+func (t *thriftWriter) begin() {
+	t.stack = append(t.stack, t.lastID)
+	t.lastID = 0
+}
+
+// end writes the stop byte for the current struct
+func (t *thriftWriter) end() {
+	t.buf.WriteByte(0)
+	if n := len(t.stack); n > 0 {
+		t.lastID = t.stack[n-1]
+		t.stack = t.stack[:n-1]
+	}
+}
@@ -0,0 +1,360 @@
+// Package parquet writes flat tables as Apache Parquet files for analysis in
+// tools such as DuckDB and pandas. It covers what BMAD exports need: required
+// or optional columns of a few primitive types, plain encoded and
+// uncompressed, in a single row group.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// magic starts and ends every Parquet file
+const magic = "PAR1"
+
+// CreatedBy is recorded in the footer of written files
+const CreatedBy = "bmad-automate"
+
+// Type is a column's value type
+type Type int
+
+const (
+	Int64     Type = iota // int64 or int
+	Double                // float64
+	String                // string, stored as UTF-8
+	Bool                  // bool
+	Timestamp             // time.Time, stored as milliseconds since the epoch
+)
+
+// Parquet physical types, converted types and encodings used here
+const (
+	physicalBoolean   int32 = 0
+	physicalInt64     int32 = 2
+	physicalDouble    int32 = 5
+	physicalByteArray int32 = 6
+
+	convertedUTF8            int32 = 0
+	convertedTimestampMillis int32 = 9
+
+	repetitionRequired int32 = 0
+	repetitionOptional int32 = 1
+
+	encodingPlain int32 = 0
+	encodingRLE   int32 = 3
+
+	pageTypeData int32 = 0
+)
+
+// Column describes one column of a table
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool // Allows nil values
+}
+
+// Writer buffers rows and writes them as a Parquet file on Close
+type Writer struct {
+	out     io.Writer
+	columns []Column
+	values  [][]interface{} // Per column, nil for nulls
+	rows    int
+}
+
+// NewWriter creates a writer for a table with the given columns
+func NewWriter(out io.Writer, columns []Column) *Writer {
+	return &Writer{
+		out:     out,
+		columns: columns,
+		values:  make([][]interface{}, len(columns)),
+	}
+}
+
+// Write appends a row with one value per column. Use nil for a missing
+// value in an optional column.
+func (w *Writer) Write(row ...interface{}) error {
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("row has %d values, table has %d columns", len(row), len(w.columns))
+	}
+
+	normalized := make([]interface{}, len(row))
+	for i, v := range row {
+		col := w.columns[i]
+		if v == nil {
+			if !col.Optional {
+				return fmt.Errorf("column %s is required", col.Name)
+			}
+			continue
+		}
+
+		nv, ok := normalize(col.Type, v)
+		if !ok {
+			return fmt.Errorf("column %s: unexpected %T", col.Name, v)
+		}
+		normalized[i] = nv
+	}
+
+	for i, v := range normalized {
+		w.values[i] = append(w.values[i], v)
+	}
+	w.rows++
+	return nil
+}
+
+// normalize converts a value to the Go type stored for a column type
+func normalize(t Type, v interface{}) (interface{}, bool) {
+	switch t {
+	case Int64:
+		switch n := v.(type) {
+		case int64:
+			return n, true
+		case int:
+			return int64(n), true
+		}
+	case Double:
+		f, ok := v.(float64)
+		return f, ok
+	case String:
+		s, ok := v.(string)
+		return s, ok
+	case Bool:
+		b, ok := v.(bool)
+		return b, ok
+	case Timestamp:
+		if ts, ok := v.(time.Time); ok {
+			return ts.UnixMilli(), true
+		}
+	}
+	return nil, false
+}
+
+// Rows returns the number of rows written so far
+func (w *Writer) Rows() int {
+	return w.rows
+}
+
+// columnChunk records where a column's data was written
+type columnChunk struct {
+	offset int64
+	size   int64
+}
+
+// Close writes the file. The underlying writer is not closed.
+func (w *Writer) Close() error {
+	var file bytes.Buffer
+	file.WriteString(magic)
+
+	var chunks []columnChunk
+	if w.rows > 0 {
+		for i, col := range w.columns {
+			page := encodePage(col, w.values[i])
+			header := pageHeader(len(page), w.rows)
+
+			chunks = append(chunks, columnChunk{
+				offset: int64(file.Len()),
+				size:   int64(len(header) + len(page)),
+			})
+			file.Write(header)
+			file.Write(page)
+		}
+	}
+
+	footer := w.fileMetadata(chunks)
+	file.Write(footer)
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	file.Write(length[:])
+	file.WriteString(magic)
+
+	_, err := w.out.Write(file.Bytes())
+	return err
+}
+
+// encodePage encodes a v1 data page: definition levels for optional
+// columns, then the non-null values
+func encodePage(col Column, values []interface{}) []byte {
+	var page bytes.Buffer
+
+	if col.Optional {
+		levels := make([]bool, len(values))
+		for i, v := range values {
+			levels[i] = v != nil
+		}
+		encoded := encodeLevels(levels)
+
+		var length [4]byte
+		binary.LittleEndian.PutUint32(length[:], uint32(len(encoded)))
+		page.Write(length[:])
+		page.Write(encoded)
+	}
+
+	switch col.Type {
+	case Bool:
+		var packed []byte
+		n := 0
+		for _, v := range values {
+			if v == nil {
+				continue
+			}
+			if n%8 == 0 {
+				packed = append(packed, 0)
+			}
+			if v.(bool) {
+				packed[n/8] |= 1 << (n % 8)
+			}
+			n++
+		}
+		page.Write(packed)
+
+	default:
+		var b [8]byte
+		for _, v := range values {
+			switch x := v.(type) {
+			case nil:
+			case int64:
+				binary.LittleEndian.PutUint64(b[:], uint64(x))
+				page.Write(b[:])
+			case float64:
+				binary.LittleEndian.PutUint64(b[:], math.Float64bits(x))
+				page.Write(b[:])
+			case string:
+				binary.LittleEndian.PutUint32(b[:4], uint32(len(x)))
+				page.Write(b[:4])
+				page.WriteString(x)
+			}
+		}
+	}
+
+	return page.Bytes()
+}
+
+// encodeLevels encodes definition levels (bit width 1) as RLE runs of the
+// RLE/bit-packing hybrid encoding
+func encodeLevels(levels []bool) []byte {
+	var out []byte
+	var b [binary.MaxVarintLen64]byte
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		n := binary.PutUvarint(b[:], uint64(j-i)<<1)
+		out = append(out, b[:n]...)
+		if levels[i] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i = j
+	}
+	return out
+}
+
+// pageHeader encodes the header of an uncompressed data page
+func pageHeader(size, rows int) []byte {
+	var t thriftWriter
+	t.i32(1, pageTypeData)
+	t.i32(2, int32(size)) // Uncompressed size
+	t.i32(3, int32(size)) // Compressed size
+	t.structField(5)      // DataPageHeader
+	t.i32(1, int32(rows))
+	t.i32(2, encodingPlain)
+	t.i32(3, encodingRLE) // Definition levels
+	t.i32(4, encodingRLE) // Repetition levels
+	t.end()
+	t.end()
+	return t.buf.Bytes()
+}
+
+// fileMetadata encodes the footer describing the schema and row group
+func (w *Writer) fileMetadata(chunks []columnChunk) []byte {
+	var t thriftWriter
+	t.i32(1, 1) // Format version
+
+	// Schema: a root group followed by one leaf per column
+	t.list(2, thriftStruct, len(w.columns)+1)
+	t.begin()
+	t.string(4, "schema")
+	t.i32(5, int32(len(w.columns)))
+	t.end()
+	for _, col := range w.columns {
+		physical, converted := col.Type.physical()
+		repetition := repetitionRequired
+		if col.Optional {
+			repetition = repetitionOptional
+		}
+
+		t.begin()
+		t.i32(1, physical)
+		t.i32(3, repetition)
+		t.string(4, col.Name)
+		if converted >= 0 {
+			t.i32(6, converted)
+		}
+		t.end()
+	}
+
+	t.i64(3, int64(w.rows))
+
+	// Row groups: one holding every row, or none for an empty table
+	if len(chunks) == 0 {
+		t.list(4, thriftStruct, 0)
+	} else {
+		var total int64
+		for _, c := range chunks {
+			total += c.size
+		}
+
+		t.list(4, thriftStruct, 1)
+		t.begin()
+		t.list(1, thriftStruct, len(chunks))
+		for i, col := range w.columns {
+			physical, _ := col.Type.physical()
+			c := chunks[i]
+
+			t.begin()
+			t.i64(2, c.offset) // File offset
+			t.structField(3)   // ColumnMetaData
+			t.i32(1, physical)
+			t.list(2, thriftI32, 2)
+			t.zigzag32(encodingPlain)
+			t.zigzag32(encodingRLE)
+			t.list(3, thriftBinary, 1)
+			t.rawString(col.Name)
+			t.i32(4, 0) // Uncompressed
+			t.i64(5, int64(w.rows))
+			t.i64(6, c.size)
+			t.i64(7, c.size)
+			t.i64(9, c.offset) // Data page offset
+			t.end()
+			t.end()
+		}
+		t.i64(2, total)
+		t.i64(3, int64(w.rows))
+		t.end()
+	}
+
+	t.string(6, CreatedBy)
+	t.end()
+	return t.buf.Bytes()
+}
+
+// physical returns the Parquet physical type and converted type (-1 for none)
+func (t Type) physical() (int32, int32) {
+	switch t {
+	case Double:
+		return physicalDouble, -1
+	case String:
+		return physicalByteArray, convertedUTF8
+	case Bool:
+		return physicalBoolean, -1
+	case Timestamp:
+		return physicalInt64, convertedTimestampMillis
+	default:
+		return physicalInt64, -1
+	}
+}
@@ -0,0 +1,250 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// thriftReader decodes compact protocol structs into maps keyed by field
+// ID, so tests can check the written metadata without a Parquet library
+type thriftReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *thriftReader) byte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case 1:
+		return true
+	case 2:
+		return false
+	case thriftI32, thriftI64:
+		return r.zigzag()
+	case thriftBinary:
+		n := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case thriftList:
+		header := r.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0F)
+		}
+		return list
+	case thriftStruct:
+		return r.readStruct()
+	}
+	panic(fmt.Sprintf("unexpected thrift type %d", typ))
+}
+
+func (r *thriftReader) readStruct() map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var last int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.zigzag())
+		}
+		fields[id] = r.value(header & 0x0F)
+		last = id
+	}
+}
+
+// readFooter checks the file framing and decodes the FileMetaData
+func readFooter(t *testing.T, data []byte) map[int16]interface{} {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(data, []byte(magic)))
+	require.True(t, bytes.HasSuffix(data, []byte(magic)))
+
+	n := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	r := &thriftReader{data: data[len(data)-8-n : len(data)-8]}
+	meta := r.readStruct()
+	require.Equal(t, n, r.pos, "footer length")
+	return meta
+}
+
+// readColumn decodes the values of a column chunk, with nil for nulls
+func readColumn(t *testing.T, data []byte, chunk map[int16]interface{}, optional bool) []interface{} {
+	t.Helper()
+	meta := chunk[3].(map[int16]interface{})
+	offset := int(meta[9].(int64))
+	rows := int(meta[5].(int64))
+
+	r := &thriftReader{data: data, pos: offset}
+	header := r.readStruct()
+	size := int(header[3].(int64))
+	assert.Equal(t, header[2], header[3], "uncompressed pages")
+	assert.Equal(t, meta[6].(int64), int64(r.pos-offset+size), "chunk size")
+	page := data[r.pos : r.pos+size]
+
+	present := make([]bool, rows)
+	for i := range present {
+		present[i] = true
+	}
+	if optional {
+		n := int(binary.LittleEndian.Uint32(page))
+		lr := &thriftReader{data: page[4 : 4+n]}
+		present = present[:0]
+		for lr.pos < n {
+			run := lr.uvarint()
+			require.Zero(t, run&1, "expected RLE runs")
+			v := lr.byte() == 1
+			for i := uint64(0); i < run>>1; i++ {
+				present = append(present, v)
+			}
+		}
+		page = page[4+n:]
+	}
+	require.Len(t, present, rows)
+
+	values := make([]interface{}, rows)
+	bit := 0
+	for i := range values {
+		if !present[i] {
+			continue
+		}
+		switch int32(meta[1].(int64)) {
+		case physicalInt64:
+			values[i] = int64(binary.LittleEndian.Uint64(page))
+			page = page[8:]
+		case physicalDouble:
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(page))
+			page = page[8:]
+		case physicalByteArray:
+			n := int(binary.LittleEndian.Uint32(page))
+			values[i] = string(page[4 : 4+n])
+			page = page[4+n:]
+		case physicalBoolean:
+			values[i] = page[bit/8]&(1<<(bit%8)) != 0
+			bit++
+		}
+	}
+	return values
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: String},
+		{Name: "count", Type: Int64},
+		{Name: "rate", Type: Double, Optional: true},
+		{Name: "ok", Type: Bool},
+		{Name: "at", Type: Timestamp, Optional: true},
+	}
+	at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, columns)
+	require.NoError(t, w.Write("a", 1, 0.5, true, at))
+	require.NoError(t, w.Write("b", int64(2), nil, false, nil))
+	require.NoError(t, w.Write("ç", 3, nil, true, at.Add(time.Second)))
+	require.NoError(t, w.Close())
+	data := buf.Bytes()
+
+	meta := readFooter(t, data)
+	assert.Equal(t, int64(3), meta[3], "num_rows")
+	assert.Equal(t, CreatedBy, meta[6])
+
+	schema := meta[2].([]interface{})
+	require.Len(t, schema, len(columns)+1)
+	assert.Equal(t, int64(len(columns)), schema[0].(map[int16]interface{})[5])
+	for i, col := range columns {
+		el := schema[i+1].(map[int16]interface{})
+		assert.Equal(t, col.Name, el[4])
+	}
+	assert.Equal(t, int64(convertedUTF8), schema[1].(map[int16]interface{})[6])
+	assert.Equal(t, int64(convertedTimestampMillis), schema[5].(map[int16]interface{})[6])
+
+	groups := meta[4].([]interface{})
+	require.Len(t, groups, 1)
+	chunks := groups[0].(map[int16]interface{})[1].([]interface{})
+	require.Len(t, chunks, len(columns))
+
+	read := func(i int) []interface{} {
+		return readColumn(t, data, chunks[i].(map[int16]interface{}), columns[i].Optional)
+	}
+	assert.Equal(t, []interface{}{"a", "b", "ç"}, read(0))
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, read(1))
+	assert.Equal(t, []interface{}{0.5, nil, nil}, read(2))
+	assert.Equal(t, []interface{}{true, false, true}, read(3))
+	assert.Equal(t, []interface{}{at.UnixMilli(), nil, at.UnixMilli() + 1000}, read(4))
+}
+
+func TestWriter_EmptyTable(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []Column{{Name: "id", Type: String}})
+	require.NoError(t, w.Close())
+
+	meta := readFooter(t, buf.Bytes())
+	assert.Equal(t, int64(0), meta[3])
+	assert.Empty(t, meta[4])
+}
+
+func TestWriter_ManyColumnsAndRows(t *testing.T) {
+	// More than 14 list elements and long runs exercise the long headers
+	var columns []Column
+	for i := 0; i < 20; i++ {
+		columns = append(columns, Column{Name: fmt.Sprintf("c%d", i), Type: Int64, Optional: true})
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, columns)
+	for r := 0; r < 300; r++ {
+		row := make([]interface{}, len(columns))
+		for c := range row {
+			if r%100 != 0 {
+				row[c] = r * c
+			}
+		}
+		require.NoError(t, w.Write(row...))
+	}
+	require.NoError(t, w.Close())
+
+	meta := readFooter(t, buf.Bytes())
+	assert.Len(t, meta[2], 21)
+	chunks := meta[4].([]interface{})[0].(map[int16]interface{})[1].([]interface{})
+	values := readColumn(t, buf.Bytes(), chunks[19].(map[int16]interface{}), true)
+	assert.Nil(t, values[200])
+	assert.Equal(t, int64(199*19), values[199])
+}
+
+func TestWriter_RejectsBadRows(t *testing.T) {
+	w := NewWriter(&bytes.Buffer{}, []Column{{Name: "id", Type: String}, {Name: "n", Type: Int64}})
+
+	assert.Error(t, w.Write("only one"))
+	assert.Error(t, w.Write(nil, 1), "required column")
+	assert.Error(t, w.Write("a", "not a number"))
+	assert.Equal(t, 0, w.Rows())
+}
@@ -0,0 +1,181 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// githubPageSize is how many issues are requested per page (the API maximum)
+const githubPageSize = 100
+
+// githubTimeout bounds loading all pages of issues
+const githubTimeout = 30 * time.Second
+
+type githubIssue struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	State     string `json:"state"`
+	Milestone *struct {
+		Number int `json:"number"`
+	} `json:"milestone"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest json.RawMessage `json:"pull_request"` // Set when the issue is a pull request
+}
+
+type githubMilestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// githubStatusLabels are the labels that set an open issue's story status.
+// Open issues without one are in the backlog.
+var githubStatusLabels = map[string]domain.StoryStatus{
+	string(domain.StatusInProgress):  domain.StatusInProgress,
+	"in progress":                    domain.StatusInProgress,
+	string(domain.StatusReadyForDev): domain.StatusReadyForDev,
+	"ready for dev":                  domain.StatusReadyForDev,
+	string(domain.StatusBlocked):     domain.StatusBlocked,
+}
+
+// FetchGitHubStories loads the issues of the configured repository as
+// stories. Each story's epic is its milestone number.
+func FetchGitHubStories(ctx context.Context, cfg *config.Config) ([]domain.Story, error) {
+	owner, repo, ok := strings.Cut(cfg.GitHubRepo, "/")
+	if !ok || owner == "" || repo == "" {
+		return nil, fmt.Errorf("github story source needs BMAD_GITHUB_REPO as owner/name")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, githubTimeout)
+	defer cancel()
+
+	state := cfg.GitHubState
+	if state == "" {
+		state = "open"
+	}
+	query := url.Values{}
+	query.Set("state", state)
+	query.Set("per_page", strconv.Itoa(githubPageSize))
+	if cfg.GitHubLabels != "" {
+		query.Set("labels", cfg.GitHubLabels)
+	}
+	if cfg.GitHubMilestone != "" {
+		number, err := githubMilestoneNumber(ctx, cfg, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("milestone", number)
+	}
+
+	var stories []domain.Story
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var issues []githubIssue
+		if err := githubGet(ctx, cfg, "/repos/"+owner+"/"+repo+"/issues?"+query.Encode(), &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.PullRequest != nil {
+				continue
+			}
+			stories = append(stories, githubStory(cfg, repo, issue))
+		}
+		if len(issues) < githubPageSize {
+			break
+		}
+	}
+
+	sortIssueStories(stories)
+	return stories, nil
+}
+
+// githubMilestoneNumber resolves the configured milestone, given as a
+// number or a title, to the number the issues endpoint filters by
+func githubMilestoneNumber(ctx context.Context, cfg *config.Config, owner, repo string) (string, error) {
+	if _, err := strconv.Atoi(cfg.GitHubMilestone); err == nil {
+		return cfg.GitHubMilestone, nil
+	}
+
+	var milestones []githubMilestone
+	endpoint := fmt.Sprintf("/repos/%s/%s/milestones?state=all&per_page=%d", owner, repo, githubPageSize)
+	if err := githubGet(ctx, cfg, endpoint, &milestones); err != nil {
+		return "", err
+	}
+	for _, m := range milestones {
+		if strings.EqualFold(m.Title, cfg.GitHubMilestone) {
+			return strconv.Itoa(m.Number), nil
+		}
+	}
+	return "", fmt.Errorf("github milestone %q not found in %s", cfg.GitHubMilestone, cfg.GitHubRepo)
+}
+
+// githubGet requests an API endpoint and decodes the JSON response into v
+func githubGet(ctx context.Context, cfg *config.Config, endpoint string, v any) error {
+	base := cfg.GitHubAPIURL
+	if base == "" {
+		base = config.DefaultGitHubAPIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create github request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if cfg.GitHubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.GitHubToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query github: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("github query failed: %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode github response: %w", err)
+	}
+	return nil
+}
+
+// githubStory converts an issue into a story keyed "<repo>-<number>"
+func githubStory(cfg *config.Config, repo string, issue githubIssue) domain.Story {
+	key := fmt.Sprintf("%s-%d", repo, issue.Number)
+	story := domain.Story{
+		Key:        key,
+		Title:      issue.Title,
+		Status:     githubStatus(issue),
+		FilePath:   cfg.StoryFilePath(key),
+		FileExists: cfg.StoryFileExists(key),
+	}
+	if issue.Milestone != nil {
+		story.Epic = issue.Milestone.Number
+	}
+	return story
+}
+
+// githubStatus maps an issue to a story status: closed issues are done,
+// open ones take their status from a status label
+func githubStatus(issue githubIssue) domain.StoryStatus {
+	if issue.State == "closed" {
+		return domain.StatusDone
+	}
+	for _, label := range issue.Labels {
+		if status, ok := githubStatusLabels[strings.ToLower(label.Name)]; ok {
+			return status
+		}
+	}
+	return domain.StatusBacklog
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestFetchGitHubStories(t *testing.T) {
+	issues := []map[string]any{
+		{"number": 12, "title": "Login form", "state": "open", "milestone": map[string]any{"number": 2},
+			"labels": []map[string]any{{"name": "bug"}, {"name": "In-Progress"}}},
+		{"number": 9, "title": "Signup", "state": "open", "milestone": map[string]any{"number": 2},
+			"labels": []map[string]any{{"name": "ready-for-dev"}}},
+		{"number": 3, "title": "Old", "state": "closed", "milestone": map[string]any{"number": 1}},
+		{"number": 5, "title": "Someday", "state": "open"},
+		{"number": 7, "title": "A pull request", "state": "open", "pull_request": map[string]any{"url": "x"}},
+	}
+
+	var issueQueries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer ghp_test", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/repos/acme/shop/milestones":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"number": 1, "title": "v1"}, {"number": 2, "title": "v2"}})
+		case "/repos/acme/shop/issues":
+			issueQueries = append(issueQueries, r.URL.RawQuery)
+			_ = json.NewEncoder(w).Encode(issues)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{
+		StorySource:     config.StorySourceGitHub,
+		StoryDir:        t.TempDir(),
+		GitHubRepo:      "acme/shop",
+		GitHubToken:     "ghp_test",
+		GitHubLabels:    "bmad",
+		GitHubMilestone: "V2",
+		GitHubState:     "all",
+		GitHubAPIURL:    srv.URL,
+	}
+
+	stories, err := LoadStories(context.Background(), cfg)
+	require.NoError(t, err)
+
+	require.Len(t, issueQueries, 1, "a short page ends pagination")
+	assert.Contains(t, issueQueries[0], "labels=bmad")
+	assert.Contains(t, issueQueries[0], "milestone=2")
+	assert.Contains(t, issueQueries[0], "state=all")
+
+	var keys []string
+	byKey := make(map[string]domain.Story)
+	for _, s := range stories {
+		keys = append(keys, s.Key)
+		byKey[s.Key] = s
+	}
+	assert.Equal(t, []string{"shop-5", "shop-3", "shop-9", "shop-12"}, keys)
+	assert.Equal(t, domain.StatusInProgress, byKey["shop-12"].Status)
+	assert.Equal(t, domain.StatusReadyForDev, byKey["shop-9"].Status)
+	assert.Equal(t, domain.StatusDone, byKey["shop-3"].Status)
+	assert.Equal(t, domain.StatusBacklog, byKey["shop-5"].Status)
+	assert.Equal(t, 2, byKey["shop-12"].Epic)
+	assert.Equal(t, "Login form", byKey["shop-12"].Title)
+}
+
+func TestFetchGitHubStories_Errors(t *testing.T) {
+	_, err := FetchGitHubStories(context.Background(), &config.Config{GitHubRepo: "shop"})
+	assert.ErrorContains(t, err, "owner/name")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+	_, err = FetchGitHubStories(context.Background(), &config.Config{
+		GitHubRepo: "acme/shop", GitHubMilestone: "v9", GitHubAPIURL: srv.URL,
+	})
+	assert.ErrorContains(t, err, `milestone "v9" not found`)
+}
@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// jiraPageSize is how many issues are requested per page
+const jiraPageSize = 100
+
+// jiraTimeout bounds loading all pages of a Jira query
+const jiraTimeout = 30 * time.Second
+
+// jiraSearchResponse is a page of the search and board issue endpoints
+type jiraSearchResponse struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Issues     []jiraIssue `json:"issues"`
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name     string `json:"name"`
+			Category struct {
+				Key string `json:"key"` // new, indeterminate or done
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Parent *struct {
+			Key    string `json:"key"`
+			Fields struct {
+				IssueType struct {
+					Name string `json:"name"`
+				} `json:"issuetype"`
+			} `json:"fields"`
+		} `json:"parent"`
+	} `json:"fields"`
+}
+
+// jiraStatusNames maps common Jira workflow status names to story statuses.
+// Anything else falls back to the status category.
+var jiraStatusNames = map[string]domain.StoryStatus{
+	"backlog":                  domain.StatusBacklog,
+	"to do":                    domain.StatusReadyForDev,
+	"selected for development": domain.StatusReadyForDev,
+	"ready for dev":            domain.StatusReadyForDev,
+	"ready for development":    domain.StatusReadyForDev,
+	"blocked":                  domain.StatusBlocked,
+	"on hold":                  domain.StatusBlocked,
+}
+
+// FetchJiraStories loads stories from the Jira JQL query or board in the
+// configuration
+func FetchJiraStories(ctx context.Context, cfg *config.Config) ([]domain.Story, error) {
+	if cfg.JiraURL == "" {
+		return nil, fmt.Errorf("jira story source needs BMAD_JIRA_URL")
+	}
+	if cfg.JiraJQL == "" && cfg.JiraBoard == "" {
+		return nil, fmt.Errorf("jira story source needs BMAD_JIRA_JQL or BMAD_JIRA_BOARD")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jiraTimeout)
+	defer cancel()
+
+	var stories []domain.Story
+	for startAt := 0; ; {
+		page, err := fetchJiraPage(ctx, cfg, startAt)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range page.Issues {
+			stories = append(stories, jiraStory(cfg, issue))
+		}
+
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+
+	sortIssueStories(stories)
+	return stories, nil
+}
+
+// fetchJiraPage requests one page of issues
+func fetchJiraPage(ctx context.Context, cfg *config.Config, startAt int) (*jiraSearchResponse, error) {
+	endpoint := cfg.JiraURL + "/rest/api/2/search"
+	if cfg.JiraBoard != "" {
+		endpoint = cfg.JiraURL + "/rest/agile/1.0/board/" + url.PathEscape(cfg.JiraBoard) + "/issue"
+	}
+
+	query := url.Values{}
+	if cfg.JiraJQL != "" {
+		query.Set("jql", cfg.JiraJQL)
+	}
+	query.Set("fields", "summary,status,parent")
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(jiraPageSize))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jira request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if cfg.JiraEmail != "" {
+		req.SetBasicAuth(cfg.JiraEmail, cfg.JiraToken)
+	} else if cfg.JiraToken != "" {
+		req.Header.Set("Authorization", "Bearer "+cfg.JiraToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jira: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jira query failed: %s", resp.Status)
+	}
+
+	var page jiraSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to decode jira response: %w", err)
+	}
+	return &page, nil
+}
+
+// jiraStory converts a Jira issue into a story. The epic is the number of
+// the issue's parent epic, or 0 when it has none.
+func jiraStory(cfg *config.Config, issue jiraIssue) domain.Story {
+	story := domain.Story{
+		Key:        issue.Key,
+		Title:      issue.Fields.Summary,
+		Status:     jiraStatus(cfg, issue.Fields.Status.Name, issue.Fields.Status.Category.Key),
+		FilePath:   cfg.StoryFilePath(issue.Key),
+		FileExists: cfg.StoryFileExists(issue.Key),
+	}
+	if parent := issue.Fields.Parent; parent != nil && strings.EqualFold(parent.Fields.IssueType.Name, "epic") {
+		story.Epic = issueNumber(parent.Key)
+	}
+	return story
+}
+
+// jiraStatus maps a Jira status to a story status: configured overrides
+// first, then well-known status names, then the status category
+func jiraStatus(cfg *config.Config, name, category string) domain.StoryStatus {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if status, ok := cfg.JiraStatusMap[name]; ok {
+		return domain.StoryStatus(status)
+	}
+	if status, ok := jiraStatusNames[name]; ok {
+		return status
+	}
+
+	switch category {
+	case "indeterminate":
+		return domain.StatusInProgress
+	case "done":
+		return domain.StatusDone
+	default:
+		return domain.StatusBacklog
+	}
+}
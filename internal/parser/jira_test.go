@@ -0,0 +1,126 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func jiraTestIssue(key, summary, status, category, epic string) map[string]any {
+	fields := map[string]any{
+		"summary": summary,
+		"status":  map[string]any{"name": status, "statusCategory": map[string]any{"key": category}},
+	}
+	if epic != "" {
+		fields["parent"] = map[string]any{
+			"key":    epic,
+			"fields": map[string]any{"issuetype": map[string]any{"name": "Epic"}},
+		}
+	}
+	return map[string]any{"key": key, "fields": fields}
+}
+
+func TestFetchJiraStories(t *testing.T) {
+	issues := []map[string]any{
+		jiraTestIssue("PROJ-12", "Login form", "In Review", "indeterminate", "PROJ-2"),
+		jiraTestIssue("PROJ-9", "Signup", "To Do", "new", "PROJ-2"),
+		jiraTestIssue("PROJ-30", "Reports", "Done", "done", "PROJ-1"),
+		jiraTestIssue("PROJ-40", "Spike", "QA", "indeterminate", ""),
+		jiraTestIssue("PROJ-41", "Later", "Open", "new", ""),
+	}
+
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		// Serve two issues per page to exercise pagination
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		end := min(startAt+2, len(issues))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"startAt": startAt, "maxResults": 2, "total": len(issues), "issues": issues[startAt:end],
+		})
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{
+		StorySource:   config.StorySourceJira,
+		StoryDir:      t.TempDir(),
+		JiraURL:       srv.URL,
+		JiraEmail:     "dev@example.com",
+		JiraToken:     "secret",
+		JiraJQL:       "project = PROJ",
+		JiraStatusMap: map[string]string{"qa": "done"},
+	}
+
+	stories, err := LoadStories(context.Background(), cfg)
+	require.NoError(t, err)
+
+	require.Len(t, requests, 3)
+	assert.Equal(t, "/rest/api/2/search", requests[0].URL.Path)
+	assert.Equal(t, "project = PROJ", requests[0].URL.Query().Get("jql"))
+	user, pass, ok := requests[0].BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "dev@example.com", user)
+	assert.Equal(t, "secret", pass)
+
+	var keys []string
+	byKey := make(map[string]domain.Story)
+	for _, s := range stories {
+		keys = append(keys, s.Key)
+		byKey[s.Key] = s
+	}
+	assert.Equal(t, []string{"PROJ-40", "PROJ-41", "PROJ-30", "PROJ-9", "PROJ-12"}, keys)
+
+	assert.Equal(t, domain.StatusInProgress, byKey["PROJ-12"].Status)
+	assert.Equal(t, domain.StatusReadyForDev, byKey["PROJ-9"].Status)
+	assert.Equal(t, domain.StatusDone, byKey["PROJ-30"].Status)
+	assert.Equal(t, domain.StatusDone, byKey["PROJ-40"].Status, "status map overrides the category")
+	assert.Equal(t, domain.StatusBacklog, byKey["PROJ-41"].Status)
+	assert.Equal(t, 2, byKey["PROJ-12"].Epic)
+	assert.Equal(t, 0, byKey["PROJ-40"].Epic)
+	assert.Equal(t, "Login form", byKey["PROJ-12"].Title)
+}
+
+func TestFetchJiraStories_Board(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		_, _ = w.Write([]byte(`{"startAt":0,"maxResults":100,"total":0,"issues":[]}`))
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{StoryDir: t.TempDir(), JiraURL: srv.URL, JiraToken: "pat", JiraBoard: "7"}
+	stories, err := FetchJiraStories(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Empty(t, stories)
+	assert.Equal(t, "/rest/agile/1.0/board/7/issue", got.URL.Path)
+	assert.Equal(t, "Bearer pat", got.Header.Get("Authorization"))
+}
+
+func TestFetchJiraStories_Errors(t *testing.T) {
+	_, err := FetchJiraStories(context.Background(), &config.Config{JiraJQL: "x"})
+	assert.ErrorContains(t, err, "BMAD_JIRA_URL")
+
+	_, err = FetchJiraStories(context.Background(), &config.Config{JiraURL: "http://jira"})
+	assert.ErrorContains(t, err, "BMAD_JIRA_JQL")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	_, err = FetchJiraStories(context.Background(), &config.Config{JiraURL: srv.URL, JiraJQL: "x"})
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestLoadStories_UnknownSource(t *testing.T) {
+	_, err := LoadStories(context.Background(), &config.Config{StorySource: "trello"})
+	assert.ErrorContains(t, err, "unknown story source")
+}
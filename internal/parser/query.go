@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// FilterStoriesByQuery returns the stories matching query, in order. Terms
+// are "epic:N", "status:S" or a bare status (prefixes such as "ready" work),
+// and any other word must appear in the story key or title. A phrase like
+// "ready-for-dev epic:4" works as is. An unknown "status:" matches nothing
+// rather than everything.
+func FilterStoriesByQuery(stories []domain.Story, query string) []domain.Story {
+	var epic int
+	var statuses []domain.StoryStatus
+	var words []string
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if value, ok := strings.CutPrefix(term, "epic:"); ok {
+			epic, _ = strconv.Atoi(value)
+			continue
+		}
+		value, explicit := strings.CutPrefix(term, "status:")
+		if status, ok := matchStatus(value); ok {
+			statuses = append(statuses, status)
+			continue
+		}
+		if explicit {
+			return nil
+		}
+		words = append(words, term)
+	}
+
+	var matched []domain.Story
+	for _, s := range stories {
+		if epic > 0 && s.Epic != epic {
+			continue
+		}
+		if len(statuses) > 0 && !containsStatus(statuses, s.Status) {
+			continue
+		}
+		text := strings.ToLower(s.Key + " " + s.Title)
+		ok := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
+
+// matchStatus resolves a status name or unambiguous prefix
+func matchStatus(value string) (domain.StoryStatus, bool) {
+	if value == "" {
+		return "", false
+	}
+	var found domain.StoryStatus
+	for _, status := range []domain.StoryStatus{
+		domain.StatusInProgress,
+		domain.StatusReadyForDev,
+		domain.StatusBacklog,
+		domain.StatusDone,
+		domain.StatusBlocked,
+	} {
+		if string(status) == value {
+			return status, true
+		}
+		if strings.HasPrefix(string(status), value) {
+			if found != "" {
+				return "", false
+			}
+			found = status
+		}
+	}
+	return found, found != ""
+}
+
+func containsStatus(statuses []domain.StoryStatus, status domain.StoryStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
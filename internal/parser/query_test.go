@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestFilterStoriesByQuery(t *testing.T) {
+	stories := []domain.Story{
+		{Key: "3-1-user-auth", Epic: 3, Status: domain.StatusReadyForDev, Title: "User login"},
+		{Key: "3-2-password-reset", Epic: 3, Status: domain.StatusBacklog},
+		{Key: "4-1-billing", Epic: 4, Status: domain.StatusReadyForDev},
+		{Key: "4-2-invoices", Epic: 4, Status: domain.StatusDone},
+	}
+	keys := func(stories []domain.Story) []string {
+		var keys []string
+		for _, s := range stories {
+			keys = append(keys, s.Key)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"ready-for-dev", []string{"3-1-user-auth", "4-1-billing"}},
+		{"ready epic:4", []string{"4-1-billing"}},
+		{"status:done", []string{"4-2-invoices"}},
+		{"backlog done", []string{"3-2-password-reset", "4-2-invoices"}},
+		{"LOGIN", []string{"3-1-user-auth"}},
+		{"epic:3 reset", []string{"3-2-password-reset"}},
+		{"status:nope", nil},
+		{"", []string{"3-1-user-auth", "3-2-password-reset", "4-1-billing", "4-2-invoices"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, keys(FilterStoriesByQuery(stories, tt.query)))
+		})
+	}
+}
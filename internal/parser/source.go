@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// LoadStories loads stories from the configured story source
+func LoadStories(ctx context.Context, cfg *config.Config) ([]domain.Story, error) {
+	switch cfg.StorySource {
+	case "", config.StorySourceSprintStatus:
+		return ParseSprintStatus(cfg)
+	case config.StorySourceJira:
+		return FetchJiraStories(ctx, cfg)
+	case config.StorySourceGitHub:
+		return FetchGitHubStories(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unknown story source %q", cfg.StorySource)
+	}
+}
+
+// sortStories orders stories by epic and then by key
+func sortStories(stories []domain.Story) {
+	sort.Slice(stories, func(i, j int) bool {
+		if stories[i].Epic != stories[j].Epic {
+			return stories[i].Epic < stories[j].Epic
+		}
+		return stories[i].Key < stories[j].Key
+	})
+}
+
+// sortIssueStories orders issue tracker stories by epic and then by issue
+// number, so PROJ-9 comes before PROJ-10
+func sortIssueStories(stories []domain.Story) {
+	sort.Slice(stories, func(i, j int) bool {
+		if stories[i].Epic != stories[j].Epic {
+			return stories[i].Epic < stories[j].Epic
+		}
+		return issueNumber(stories[i].Key) < issueNumber(stories[j].Key)
+	})
+}
+
+// issueNumber returns the number at the end of an issue key
+// ("PROJ-42" -> 42), or 0 if there is none
+func issueNumber(key string) int {
+	if i := strings.LastIndex(key, "-"); i >= 0 {
+		if n, err := strconv.Atoi(key[i+1:]); err == nil {
+			return n
+		}
+	}
+	return 0
+}
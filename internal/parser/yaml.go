@@ -3,6 +3,7 @@ package parser
 import (
 	"os"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,7 +15,38 @@ import (
 
 // SprintStatus represents the structure of sprint-status.yaml
 type SprintStatus struct {
-	DevelopmentStatus map[string]string `yaml:"development_status"`
+	DevelopmentStatus map[string]string  `yaml:"development_status"`
+	Dependencies      map[string]keyList `yaml:"dependencies"`
+}
+
+// keyList is a list of story keys, written either as a YAML list or as
+// one comma-separated string
+type keyList []string
+
+func (l *keyList) UnmarshalYAML(node *yaml.Node) error {
+	var keys []string
+	if node.Kind == yaml.SequenceNode {
+		if err := node.Decode(&keys); err != nil {
+			return err
+		}
+	} else {
+		var text string
+		if err := node.Decode(&text); err != nil {
+			return err
+		}
+		keys = strings.Split(text, ",")
+	}
+	for _, key := range keys {
+		if key = strings.TrimSpace(key); key != "" {
+			*l = append(*l, key)
+		}
+	}
+	return nil
+}
+
+// storyFrontmatter is the part of a story file's YAML frontmatter read here
+type storyFrontmatter struct {
+	DependsOn keyList `yaml:"depends_on"`
 }
 
 // storyKeyPattern matches story keys like "3-1-user-auth"
@@ -47,20 +79,48 @@ func ParseSprintStatus(cfg *config.Config) ([]domain.Story, error) {
 			FileExists: cfg.StoryFileExists(key),
 		}
 
+		deps := status.Dependencies[key]
+		if story.FileExists {
+			deps = append(deps, readDependsOn(story.FilePath)...)
+		}
+		for _, dep := range deps {
+			if dep != key && status.DevelopmentStatus[dep] != string(domain.StatusDone) &&
+				!slices.Contains(story.DependsOn, dep) {
+				story.DependsOn = append(story.DependsOn, dep)
+			}
+		}
+
 		stories = append(stories, story)
 	}
 
-	// Sort stories by epic and then by key
-	sort.Slice(stories, func(i, j int) bool {
-		if stories[i].Epic != stories[j].Epic {
-			return stories[i].Epic < stories[j].Epic
-		}
-		return stories[i].Key < stories[j].Key
-	})
+	sortStories(stories)
 
 	return stories, nil
 }
 
+// readDependsOn returns the depends_on of the story file at path, or nil
+// when it has no frontmatter or cannot be read
+func readDependsOn(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return nil
+	}
+	end := strings.Index(text[3:], "\n---")
+	if end <= 0 {
+		return nil
+	}
+
+	var front storyFrontmatter
+	if err := yaml.Unmarshal([]byte(text[4:3+end]), &front); err != nil {
+		return nil
+	}
+	return front.DependsOn
+}
+
 // extractEpic extracts the epic number from a story key (e.g., "3-1-story" -> 3)
 func extractEpic(key string) int {
 	parts := strings.SplitN(key, "-", 2)
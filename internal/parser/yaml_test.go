@@ -126,6 +126,44 @@ func TestParseSprintStatus(t *testing.T) {
 		require.Len(t, stories, 1)
 		assert.Equal(t, domain.StatusInProgress, stories[0].Status)
 	})
+
+	t.Run("reads dependencies", func(t *testing.T) {
+		cfg := createTestConfig(t, `development_status:
+  3-1-base: done
+  3-2-api: ready-for-dev
+  3-3-ui: backlog
+  3-4-docs: backlog
+dependencies:
+  3-3-ui: [3-1-base, 3-2-api]
+  3-4-docs: 3-3-ui, 3-2-api
+`)
+		story := "---\ntitle: Docs\ndepends_on:\n  - 3-2-api\n  - 3-4-docs\n  - 3-9-later\n---\n# Docs\n"
+		require.NoError(t, os.WriteFile(cfg.StoryFilePath("3-4-docs"), []byte(story), 0644))
+
+		stories, err := ParseSprintStatus(cfg)
+		require.NoError(t, err)
+		require.Len(t, stories, 4)
+		assert.Empty(t, stories[0].DependsOn)
+		assert.Empty(t, stories[1].DependsOn)
+		assert.Equal(t, []string{"3-2-api"}, stories[2].DependsOn, "done stories are dropped")
+		assert.Equal(t, []string{"3-3-ui", "3-2-api", "3-9-later"}, stories[3].DependsOn)
+	})
+}
+
+func TestReadDependsOn(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	assert.Equal(t, []string{"3-1-a", "3-2-b"},
+		readDependsOn(write("string.md", "---\r\ndepends_on: 3-1-a, 3-2-b\r\n---\nbody\n")))
+	assert.Nil(t, readDependsOn(write("none.md", "# Story\n\n---\ndepends_on: 3-1-a\n")))
+	assert.Nil(t, readDependsOn(write("empty.md", "---\n---\n")))
+	assert.Nil(t, readDependsOn(write("open.md", "---\ndepends_on: 3-1-a\n")))
+	assert.Nil(t, readDependsOn(filepath.Join(dir, "missing.md")))
 }
 
 func TestExtractEpic(t *testing.T) {
@@ -0,0 +1,180 @@
+// Package pipeline chains queues: a pipeline is a named list of stages that
+// run in order, each a set of stories run with a workflow, and a stage only
+// starts once the previous one succeeded.
+package pipeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// Pipeline is a named chain of stages, stored as YAML in
+// <data dir>/pipelines/<name>.yaml
+type Pipeline struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Stages      []*Stage `yaml:"stages"`
+}
+
+// Stage selects the stories one step of a pipeline runs. Exactly one of
+// Epic, Keys and Story is set.
+type Stage struct {
+	Name     string   `yaml:"name"`
+	Workflow string   `yaml:"workflow,omitempty"` // Default: the active workflow
+	Epic     int      `yaml:"epic,omitempty"`     // Every story of the epic that is not done
+	Keys     []string `yaml:"keys,omitempty"`     // These stories, in order
+	Story    string   `yaml:"story,omitempty"`    // A synthetic story, e.g. for an integration test workflow
+	Title    string   `yaml:"title,omitempty"`    // Title of the synthetic story
+
+	// ContinueOnFailure runs the next stage even when a story of this one
+	// failed
+	ContinueOnFailure bool `yaml:"continue_on_failure,omitempty"`
+}
+
+// Parse decodes and validates a pipeline from YAML. defaultName is used
+// when the YAML does not set a name.
+func Parse(data []byte, defaultName string) (*Pipeline, error) {
+	var p Pipeline
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid pipeline YAML: %w", err)
+	}
+	if p.Name == "" {
+		p.Name = defaultName
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks the pipeline has a name and every stage selects stories
+// one way
+func (p *Pipeline) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("pipeline name is required")
+	}
+	if len(p.Stages) == 0 {
+		return fmt.Errorf("pipeline %q has no stages", p.Name)
+	}
+	for i, stage := range p.Stages {
+		if stage.Name == "" {
+			stage.Name = fmt.Sprintf("stage-%d", i+1)
+		}
+		selectors := 0
+		if stage.Epic > 0 {
+			selectors++
+		}
+		if len(stage.Keys) > 0 {
+			selectors++
+		}
+		if stage.Story != "" {
+			selectors++
+		}
+		if selectors != 1 {
+			return fmt.Errorf("stage %q needs exactly one of epic, keys or story", stage.Name)
+		}
+	}
+	return nil
+}
+
+// Stories returns the stories a stage runs, looked up in stories
+func (s *Stage) Stories(stories []domain.Story) ([]domain.Story, error) {
+	switch {
+	case s.Story != "":
+		return []domain.Story{{Key: s.Story, Title: s.Title, Status: domain.StatusReadyForDev}}, nil
+
+	case s.Epic > 0:
+		var selected []domain.Story
+		for _, story := range stories {
+			if story.Epic == s.Epic && story.Status != domain.StatusDone {
+				selected = append(selected, story)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("stage %q: epic %d has no stories left to run", s.Name, s.Epic)
+		}
+		return selected, nil
+
+	default:
+		byKey := make(map[string]domain.Story, len(stories))
+		for _, story := range stories {
+			byKey[story.Key] = story
+		}
+		selected := make([]domain.Story, 0, len(s.Keys))
+		var missing []string
+		for _, key := range s.Keys {
+			if story, ok := byKey[key]; ok {
+				selected = append(selected, story)
+			} else {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("stage %q: unknown stories %s", s.Name, strings.Join(missing, ", "))
+		}
+		return selected, nil
+	}
+}
+
+// Store loads pipeline definitions
+type Store struct {
+	dir       string
+	pipelines map[string]*Pipeline
+}
+
+// NewStore creates a store for the pipelines in <dataDir>/pipelines
+func NewStore(dataDir string) *Store {
+	return &Store{
+		dir:       filepath.Join(dataDir, "pipelines"),
+		pipelines: make(map[string]*Pipeline),
+	}
+}
+
+// Dir returns the directory pipelines are loaded from
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Load reads every pipeline file. A missing directory means no pipelines.
+func (s *Store) Load() error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("failed to list pipelines: %w", err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("failed to read pipeline: %w", err)
+		}
+		p, err := Parse(data, strings.TrimSuffix(filepath.Base(file), ".yaml"))
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+		s.pipelines[p.Name] = p
+	}
+	return nil
+}
+
+// Get returns a pipeline by name
+func (s *Store) Get(name string) (*Pipeline, bool) {
+	p, ok := s.pipelines[name]
+	return p, ok
+}
+
+// List returns the pipeline names, sorted
+func (s *Store) List() []string {
+	names := make([]string, 0, len(s.pipelines))
+	for name := range s.pipelines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,181 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+var testStories = []domain.Story{
+	{Key: "4-1-cart", Epic: 4, Status: domain.StatusReadyForDev},
+	{Key: "4-2-checkout", Epic: 4, Status: domain.StatusBacklog},
+	{Key: "4-3-receipts", Epic: 4, Status: domain.StatusDone},
+	{Key: "5-1-search", Epic: 5, Status: domain.StatusReadyForDev},
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(`
+stages:
+  - epic: 4
+  - name: integration
+    story: integration-tests
+    workflow: integration
+`), "release")
+	require.NoError(t, err)
+	assert.Equal(t, "release", p.Name)
+	require.Len(t, p.Stages, 2)
+	assert.Equal(t, "stage-1", p.Stages[0].Name)
+	assert.Equal(t, "integration", p.Stages[1].Workflow)
+
+	t.Run("stages select stories one way", func(t *testing.T) {
+		_, err := Parse([]byte("stages:\n  - name: both\n    epic: 4\n    story: x\n"), "bad")
+		assert.ErrorContains(t, err, `stage "both" needs exactly one of epic, keys or story`)
+
+		_, err = Parse([]byte("stages:\n  - name: none\n"), "bad")
+		assert.Error(t, err)
+
+		_, err = Parse([]byte("description: empty\n"), "bad")
+		assert.ErrorContains(t, err, "has no stages")
+	})
+}
+
+func TestStage_Stories(t *testing.T) {
+	t.Run("epic selects the stories not done", func(t *testing.T) {
+		stories, err := (&Stage{Name: "epic", Epic: 4}).Stories(testStories)
+		require.NoError(t, err)
+		assert.Len(t, stories, 2)
+
+		_, err = (&Stage{Name: "empty", Epic: 9}).Stories(testStories)
+		assert.ErrorContains(t, err, "epic 9 has no stories left")
+	})
+
+	t.Run("keys keep their order", func(t *testing.T) {
+		stories, err := (&Stage{Name: "keys", Keys: []string{"5-1-search", "4-1-cart"}}).Stories(testStories)
+		require.NoError(t, err)
+		assert.Equal(t, "5-1-search", stories[0].Key)
+
+		_, err = (&Stage{Name: "keys", Keys: []string{"4-1-cart", "nope"}}).Stories(testStories)
+		assert.ErrorContains(t, err, "unknown stories nope")
+	})
+
+	t.Run("story is synthetic", func(t *testing.T) {
+		stories, err := (&Stage{Name: "it", Story: "integration-tests", Title: "Integration"}).Stories(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Story{{Key: "integration-tests", Title: "Integration", Status: domain.StatusReadyForDev}}, stories)
+	})
+}
+
+func TestStore_Load(t *testing.T) {
+	dataDir := t.TempDir()
+	store := NewStore(dataDir)
+	require.NoError(t, store.Load(), "a missing directory means no pipelines")
+	assert.Empty(t, store.List())
+
+	require.NoError(t, os.MkdirAll(store.Dir(), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(store.Dir(), "release.yaml"), []byte("stages:\n  - epic: 4\n"), 0644))
+	require.NoError(t, store.Load())
+	assert.Equal(t, []string{"release"}, store.List())
+	_, ok := store.Get("release")
+	assert.True(t, ok)
+}
+
+// recorder keeps what a run saves
+type recorder struct {
+	mu         sync.Mutex
+	executions []*domain.Execution
+	saves      int
+	last       domain.PipelineRun
+}
+
+func (r *recorder) SaveExecution(_ context.Context, exec *domain.Execution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.executions = append(r.executions, exec)
+	return nil
+}
+
+func (r *recorder) SavePipelineRun(_ context.Context, run *domain.PipelineRun) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saves++
+	r.last = *run
+	return nil
+}
+
+func TestRunner_Run(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell steps need sh")
+	}
+
+	cfg := config.New()
+	cfg.WorkingDir = t.TempDir()
+	cfg.DataDir = t.TempDir()
+	cfg.Retries = 0
+	cfg.WorkspaceSnapshots = false
+
+	workflows := workflow.NewWorkflowStore(cfg.DataDir)
+	require.NoError(t, workflows.Load())
+	for name, command := range map[string]string{"pass": "true", "fail": "exit 1"} {
+		require.NoError(t, workflows.Save(&workflow.Workflow{Name: name, Steps: []*workflow.StepDefinition{
+			{Name: "check", StepName: "check", Type: workflow.StepTypeShell, Command: command},
+		}}))
+	}
+
+	p := &Pipeline{Name: "release", Stages: []*Stage{
+		{Name: "epic-4", Epic: 4, Workflow: "pass"},
+		{Name: "integration", Story: "integration-tests", Workflow: "fail"},
+		{Name: "deploy", Story: "deploy", Workflow: "pass"},
+	}}
+	require.NoError(t, p.Validate())
+
+	rec := &recorder{}
+	runner := NewRunner(cfg, workflows, rec)
+	var stageMsgs []tea.Msg
+	runner.SetMessageHandler(func(msg tea.Msg) {
+		switch msg.(type) {
+		case StageStartedMsg, StageCompletedMsg:
+			stageMsgs = append(stageMsgs, msg)
+		}
+	})
+
+	run := runner.Run(p, testStories)
+
+	assert.Equal(t, domain.ExecutionFailed, run.Status)
+	assert.Equal(t, domain.ExecutionCompleted, run.Stages[0].Status)
+	assert.Equal(t, 2, run.Stages[0].Succeeded)
+	assert.Len(t, run.Stages[0].ExecutionIDs, 2)
+	assert.Equal(t, domain.ExecutionFailed, run.Stages[1].Status)
+	assert.Equal(t, 1, run.Stages[1].Failed)
+	assert.Equal(t, domain.ExecutionPending, run.Stages[2].Status, "a failed stage stops the pipeline")
+	assert.False(t, run.EndTime.IsZero())
+
+	assert.Len(t, rec.executions, 3)
+	assert.Equal(t, domain.ExecutionFailed, rec.last.Status)
+	assert.Greater(t, rec.saves, 2)
+	assert.Len(t, stageMsgs, 4)
+
+	t.Run("continue_on_failure runs the next stage", func(t *testing.T) {
+		p.Stages[1].ContinueOnFailure = true
+		run := NewRunner(cfg, workflows, nil).Run(p, testStories)
+		assert.Equal(t, domain.ExecutionFailed, run.Status)
+		assert.Equal(t, domain.ExecutionCompleted, run.Stages[2].Status)
+	})
+
+	t.Run("an unknown workflow fails the stage", func(t *testing.T) {
+		p := &Pipeline{Name: "broken", Stages: []*Stage{{Name: "x", Story: "x", Workflow: "missing"}}}
+		run := NewRunner(cfg, workflows, nil).Run(p, nil)
+		assert.Equal(t, domain.ExecutionFailed, run.Status)
+		assert.Contains(t, run.Error, `workflow "missing" not found`)
+	})
+}
@@ -0,0 +1,244 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+// StageStartedMsg is sent when a stage begins
+type StageStartedMsg struct {
+	RunID   string
+	Index   int
+	Name    string
+	Stories int
+}
+
+// StageCompletedMsg is sent when a stage ends
+type StageCompletedMsg struct {
+	RunID     string
+	Index     int
+	Name      string
+	Status    domain.ExecutionStatus
+	Succeeded int
+	Failed    int
+}
+
+// Recorder persists what a run produces; storage.Storage satisfies it
+type Recorder interface {
+	SaveExecution(ctx context.Context, exec *domain.Execution) error
+	SavePipelineRun(ctx context.Context, run *domain.PipelineRun) error
+}
+
+// Runner runs pipelines one story at a time, recording every execution
+// and the run's progress as it goes
+type Runner struct {
+	cfg       *config.Config
+	workflows *workflow.WorkflowStore
+	recorder  Recorder // nil = nothing is recorded
+	handler   func(tea.Msg)
+
+	mu        sync.Mutex
+	current   *executor.Executor
+	cancelled bool
+}
+
+// NewRunner creates a runner. Stage workflows are looked up in workflows.
+func NewRunner(cfg *config.Config, workflows *workflow.WorkflowStore, recorder Recorder) *Runner {
+	return &Runner{cfg: cfg, workflows: workflows, recorder: recorder}
+}
+
+// SetMessageHandler receives stage messages and the executor's messages
+func (r *Runner) SetMessageHandler(handler func(tea.Msg)) {
+	r.handler = handler
+}
+
+// Cancel stops the running story and skips the remaining ones
+func (r *Runner) Cancel() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cancelled = true
+	if r.current != nil {
+		r.current.Cancel()
+	}
+}
+
+// Resume continues the running story past a wait step
+func (r *Runner) Resume() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current != nil {
+		r.current.Resume()
+	}
+}
+
+func (r *Runner) isCancelled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cancelled
+}
+
+func (r *Runner) send(msg tea.Msg) {
+	if r.handler != nil {
+		r.handler(msg)
+	}
+}
+
+// save records the run's progress; failures are reported, not fatal
+func (r *Runner) save(run *domain.PipelineRun) {
+	if r.recorder == nil {
+		return
+	}
+	if err := r.recorder.SavePipelineRun(context.Background(), run); err != nil {
+		r.send(messages.ErrorMsg{Error: fmt.Errorf("failed to record pipeline run: %w", err)})
+	}
+}
+
+// Run runs p's stages in order against stories and returns the finished
+// run. A stage with a failed story stops the pipeline unless it is marked
+// continue_on_failure.
+func (r *Runner) Run(p *Pipeline, stories []domain.Story) *domain.PipelineRun {
+	stages := make([]*domain.PipelineStage, len(p.Stages))
+	for i, stage := range p.Stages {
+		stages[i] = &domain.PipelineStage{Name: stage.Name, Workflow: stage.Workflow}
+	}
+	run := domain.NewPipelineRun(p.Name, stages)
+	run.Status = domain.ExecutionRunning
+	run.StartTime = time.Now()
+	r.save(run)
+
+	run.Status = domain.ExecutionCompleted
+	for i, stage := range p.Stages {
+		if r.isCancelled() {
+			run.Status = domain.ExecutionCancelled
+			break
+		}
+		status, err := r.runStage(run, i, stage, stories)
+		if err != nil {
+			run.Error = err.Error()
+		}
+		if status == domain.ExecutionCancelled {
+			run.Status = domain.ExecutionCancelled
+			break
+		}
+		if status == domain.ExecutionFailed {
+			run.Status = domain.ExecutionFailed
+			if !stage.ContinueOnFailure {
+				break
+			}
+		}
+	}
+
+	run.EndTime = time.Now()
+	run.Duration = run.EndTime.Sub(run.StartTime)
+	r.save(run)
+	return run
+}
+
+// runStage runs the stories of one stage and returns the stage's status
+func (r *Runner) runStage(run *domain.PipelineRun, index int, stage *Stage, stories []domain.Story) (domain.ExecutionStatus, error) {
+	result := run.Stages[index]
+	result.Status = domain.ExecutionRunning
+
+	selected, err := stage.Stories(stories)
+	if err == nil {
+		err = r.startExecutor(stage)
+	}
+	if err != nil {
+		result.Status = domain.ExecutionFailed
+		r.save(run)
+		r.send(StageCompletedMsg{RunID: run.ID, Index: index, Name: stage.Name, Status: result.Status})
+		return result.Status, err
+	}
+
+	r.send(StageStartedMsg{RunID: run.ID, Index: index, Name: stage.Name, Stories: len(selected)})
+	r.save(run)
+
+	for _, story := range selected {
+		if r.isCancelled() {
+			break
+		}
+		execution := r.runStory(story)
+		if execution == nil {
+			result.Failed++
+			continue
+		}
+		result.ExecutionIDs = append(result.ExecutionIDs, execution.ID)
+		if execution.Status == domain.ExecutionCompleted {
+			result.Succeeded++
+		} else if execution.Status != domain.ExecutionCancelled {
+			result.Failed++
+		}
+		r.save(run)
+	}
+
+	switch {
+	case r.isCancelled():
+		result.Status = domain.ExecutionCancelled
+	case result.Failed > 0:
+		result.Status = domain.ExecutionFailed
+	default:
+		result.Status = domain.ExecutionCompleted
+	}
+	r.save(run)
+	r.send(StageCompletedMsg{
+		RunID:     run.ID,
+		Index:     index,
+		Name:      stage.Name,
+		Status:    result.Status,
+		Succeeded: result.Succeeded,
+		Failed:    result.Failed,
+	})
+	return result.Status, nil
+}
+
+// startExecutor creates the executor for a stage's workflow
+func (r *Runner) startExecutor(stage *Stage) error {
+	exec := executor.New(r.cfg)
+	name := stage.Workflow
+	if name == "" {
+		name = r.cfg.ActiveWorkflow
+	}
+	if name != "" && r.workflows != nil {
+		w, ok := r.workflows.Get(name)
+		if !ok {
+			return fmt.Errorf("stage %q: workflow %q not found", stage.Name, name)
+		}
+		exec.SetWorkflow(w)
+	}
+	exec.SetMessageHandler(r.send)
+
+	r.mu.Lock()
+	r.current = exec
+	r.mu.Unlock()
+	return nil
+}
+
+// runStory runs one story to completion and records it. It returns nil
+// when the story could not be started.
+func (r *Runner) runStory(story domain.Story) *domain.Execution {
+	r.mu.Lock()
+	exec := r.current
+	r.mu.Unlock()
+
+	if msg, ok := exec.Execute(story)().(messages.ErrorMsg); ok {
+		r.send(msg)
+		return nil
+	}
+	execution := exec.GetExecution()
+	if r.recorder != nil {
+		if err := r.recorder.SaveExecution(context.Background(), execution); err != nil {
+			r.send(messages.ErrorMsg{Error: fmt.Errorf("failed to record %s: %w", story.Key, err)})
+		}
+	}
+	return execution
+}
@@ -0,0 +1,67 @@
+package preflight
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+)
+
+// checkDiskSpace verifies the data and working directories have at least
+// cfg.MinFreeDiskMB free, so a long queue does not stop halfway when the
+// database or the project fills the disk
+func checkDiskSpace(cfg *config.Config) CheckResult {
+	result := CheckResult{Name: "Disk Space"}
+
+	if cfg.MinFreeDiskMB <= 0 {
+		result.Passed = true
+		result.Message = "Not checked"
+		return result
+	}
+
+	lowest := uint64(0)
+	checked := false
+	for _, dir := range []string{cfg.DataDir, cfg.WorkingDir} {
+		if dir == "" {
+			continue
+		}
+		free, err := freeSpace(existingDir(dir))
+		if err != nil {
+			continue
+		}
+		freeMB := free / (1 << 20)
+		if freeMB < uint64(cfg.MinFreeDiskMB) {
+			result.Passed = false
+			result.Error = fmt.Sprintf("Only %d MB free for %s (need %d MB)", freeMB, dir, cfg.MinFreeDiskMB)
+			return result
+		}
+		if !checked || freeMB < lowest {
+			lowest = freeMB
+		}
+		checked = true
+	}
+
+	result.Passed = true
+	if !checked {
+		result.Message = "Unable to check"
+		return result
+	}
+	result.Message = fmt.Sprintf("%d MB free", lowest)
+	return result
+}
+
+// existingDir returns dir, or its closest parent that exists when dir has
+// not been created yet, as the data directory is on first start
+func existingDir(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
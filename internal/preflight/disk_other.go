@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !windows
+
+package preflight
+
+import "errors"
+
+// freeSpace is not implemented on this platform, so the disk space check
+// is skipped
+func freeSpace(dir string) (uint64, error) {
+	return 0, errors.New("free space unknown on this platform")
+}
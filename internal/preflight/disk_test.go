@@ -0,0 +1,52 @@
+package preflight
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+)
+
+func TestCheckDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := freeSpace(dir); err != nil {
+		t.Skip("free space unknown on this platform")
+	}
+
+	t.Run("passes with room to spare", func(t *testing.T) {
+		cfg := &config.Config{DataDir: filepath.Join(dir, ".bmad"), WorkingDir: dir, MinFreeDiskMB: 1}
+
+		result := checkDiskSpace(cfg)
+
+		assert.True(t, result.Passed)
+		assert.Equal(t, "Disk Space", result.Name)
+		assert.Contains(t, result.Message, "MB free")
+	})
+
+	t.Run("fails below the minimum", func(t *testing.T) {
+		cfg := &config.Config{WorkingDir: dir, MinFreeDiskMB: 1 << 40}
+
+		result := checkDiskSpace(cfg)
+
+		assert.False(t, result.Passed)
+		assert.Contains(t, result.Error, "MB free for "+dir)
+	})
+
+	t.Run("skipped when disabled", func(t *testing.T) {
+		cfg := &config.Config{WorkingDir: dir}
+
+		result := checkDiskSpace(cfg)
+
+		assert.True(t, result.Passed)
+		assert.Equal(t, "Not checked", result.Message)
+	})
+}
+
+func TestExistingDir(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.Equal(t, dir, existingDir(dir))
+	assert.Equal(t, dir, existingDir(filepath.Join(dir, "data", ".bmad")))
+}
@@ -1,10 +1,14 @@
 package preflight
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/robertguss/bmad-automate-go/internal/config"
 )
@@ -31,7 +35,7 @@ func RunAll(cfg *config.Config) *Results {
 	}
 
 	// Check Claude CLI
-	results.addCheck(checkClaudeCLI())
+	results.addCheck(checkClaudeCLI(cfg.MinClaudeVersion))
 
 	// Check sprint-status.yaml exists
 	results.addCheck(checkSprintStatus(cfg))
@@ -42,6 +46,14 @@ func RunAll(cfg *config.Config) *Results {
 	// Check working directory is a git repo
 	results.addCheck(checkGitRepo(cfg))
 
+	// Check there is room for the database and the project to grow
+	results.addCheck(checkDiskSpace(cfg))
+
+	// Check the API key has credit left, when asked to
+	if cfg.QuotaCheck {
+		results.addCheck(checkQuota(cfg))
+	}
+
 	// Check for uncommitted changes (warning only)
 	gitCheck := checkGitClean(cfg)
 	results.addCheck(gitCheck)
@@ -52,12 +64,28 @@ func RunAll(cfg *config.Config) *Results {
 // addCheck adds a check result and updates AllPass
 func (r *Results) addCheck(check CheckResult) {
 	r.Checks = append(r.Checks, check)
-	if !check.Passed && check.Name != "Git Clean" {
-		// Git clean is a warning, not a blocker
+	if check.Blocks() {
 		r.AllPass = false
 	}
 }
 
+// Blocks reports whether the check failed in a way that stops stories from
+// running. Git clean is a warning, not a blocker.
+func (c CheckResult) Blocks() bool {
+	return !c.Passed && c.Name != "Git Clean"
+}
+
+// Blocking returns the failed checks that stop stories from running
+func (r *Results) Blocking() []CheckResult {
+	var blocking []CheckResult
+	for _, check := range r.Checks {
+		if check.Blocks() {
+			blocking = append(blocking, check)
+		}
+	}
+	return blocking
+}
+
 // PassedCount returns the number of passed checks
 func (r *Results) PassedCount() int {
 	count := 0
@@ -80,38 +108,84 @@ func (r *Results) FailedChecks() []CheckResult {
 	return failed
 }
 
-// checkClaudeCLI verifies the Claude CLI is installed and accessible
-func checkClaudeCLI() CheckResult {
-	result := CheckResult{Name: "Claude CLI"}
+// claudeVersionTimeout bounds "claude --version", so a CLI that hangs
+// fails the check instead of blocking startup
+const claudeVersionTimeout = 10 * time.Second
 
-	cmd := exec.Command("which", "claude")
-	output, err := cmd.Output()
+// versionPattern matches the dotted version in "claude --version" output,
+// e.g. "1.0.3 (Claude Code)"
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// checkClaudeCLI verifies the Claude CLI is installed, answers and is at
+// least minVersion. Output without a recognizable version is accepted.
+func checkClaudeCLI(minVersion string) CheckResult {
+	result := CheckResult{Name: "Claude CLI"}
 
+	path, err := exec.LookPath("claude")
 	if err != nil {
 		result.Passed = false
 		result.Error = "Claude CLI not found in PATH"
 		return result
 	}
 
-	path := strings.TrimSpace(string(output))
-	result.Passed = true
-	result.Message = fmt.Sprintf("Found at %s", path)
+	ctx, cancel := context.WithTimeout(context.Background(), claudeVersionTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		result.Passed = false
+		result.Error = fmt.Sprintf("claude --version failed: %v", err)
+		return result
+	}
 
-	// Try to get version
-	versionCmd := exec.Command("claude", "--version")
-	versionOutput, err := versionCmd.Output()
-	if err == nil {
-		version := strings.TrimSpace(string(versionOutput))
-		result.Message = fmt.Sprintf("v%s", version)
+	version := versionPattern.FindString(string(output))
+	if version == "" {
+		result.Passed = true
+		result.Message = fmt.Sprintf("Found at %s (version unknown)", path)
+		return result
+	}
+	if compareVersions(version, minVersion) < 0 {
+		result.Passed = false
+		result.Error = fmt.Sprintf("Claude CLI %s is older than the required %s; update it with \"claude update\"", version, minVersion)
+		return result
 	}
 
+	result.Passed = true
+	result.Message = fmt.Sprintf("v%s", version)
 	return result
 }
 
+// compareVersions compares dotted versions part by part, returning -1, 0
+// or 1. Missing parts count as 0, so "1.2" equals "1.2.0".
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 // checkSprintStatus verifies the sprint-status.yaml file exists
 func checkSprintStatus(cfg *config.Config) CheckResult {
 	result := CheckResult{Name: "Sprint Status"}
 
+	if cfg.StorySource != "" && cfg.StorySource != config.StorySourceSprintStatus {
+		result.Passed = true
+		result.Message = fmt.Sprintf("Not used (stories from %s)", cfg.StorySource)
+		return result
+	}
+
 	if _, err := os.Stat(cfg.SprintStatusPath); os.IsNotExist(err) {
 		result.Passed = false
 		result.Error = fmt.Sprintf("File not found: %s", cfg.SprintStatusPath)
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -128,6 +129,87 @@ func TestResults_AddCheck(t *testing.T) {
 	})
 }
 
+func TestResults_Blocking(t *testing.T) {
+	r := &Results{Checks: []CheckResult{
+		{Name: "Claude CLI", Passed: false},
+		{Name: "Sprint Status", Passed: true},
+		{Name: "Git Clean", Passed: false},
+	}}
+
+	blocking := r.Blocking()
+	require.Len(t, blocking, 1)
+	assert.Equal(t, "Claude CLI", blocking[0].Name)
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0.3", "1.0.3", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.0.3", "1.0.10", -1},
+		{"2.0.0", "1.9.9", 1},
+		{"0.2.9", "1.0.0", -1},
+		{"1.0.0", "0", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.expected, compareVersions(tt.a, tt.b))
+		})
+	}
+}
+
+func TestCheckClaudeCLI(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake CLI is a shell script")
+	}
+	// fakeClaude puts a claude on PATH that prints output for --version
+	fakeClaude := func(t *testing.T, output string) {
+		dir := t.TempDir()
+		script := "#!/bin/sh\necho '" + output + "'\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "claude"), []byte(script), 0755))
+		t.Setenv("PATH", dir)
+	}
+
+	t.Run("passes at the minimum version", func(t *testing.T) {
+		fakeClaude(t, "1.0.3 (Claude Code)")
+
+		result := checkClaudeCLI("1.0.3")
+
+		assert.True(t, result.Passed)
+		assert.Equal(t, "v1.0.3", result.Message)
+	})
+
+	t.Run("fails below the minimum version", func(t *testing.T) {
+		fakeClaude(t, "0.2.9 (Claude Code)")
+
+		result := checkClaudeCLI("1.0.0")
+
+		assert.False(t, result.Passed)
+		assert.Contains(t, result.Error, "0.2.9 is older than the required 1.0.0")
+	})
+
+	t.Run("accepts an unrecognized version", func(t *testing.T) {
+		fakeClaude(t, "dev build")
+
+		result := checkClaudeCLI("1.0.0")
+
+		assert.True(t, result.Passed)
+		assert.Contains(t, result.Message, "version unknown")
+	})
+
+	t.Run("fails when not installed", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+
+		result := checkClaudeCLI("1.0.0")
+
+		assert.False(t, result.Passed)
+		assert.Contains(t, result.Error, "not found")
+	})
+}
+
 func TestCheckSprintStatus(t *testing.T) {
 	t.Run("passes when file exists", func(t *testing.T) {
 		tempDir := t.TempDir()
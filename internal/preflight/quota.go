@@ -0,0 +1,116 @@
+package preflight
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+)
+
+const (
+	// quotaTimeout bounds the request the quota check makes
+	quotaTimeout = 15 * time.Second
+
+	// quotaProbeModel answers the one-token request the quota check sends
+	quotaProbeModel = "claude-haiku-4-5"
+
+	// anthropicVersion is the API version the quota check requests
+	anthropicVersion = "2023-06-01"
+)
+
+// quotaClient sends the quota check's request
+var quotaClient = &http.Client{Timeout: quotaTimeout}
+
+// checkQuota asks the Anthropic API for a single token, which is the
+// cheapest request that reports the key's credit and rate limits. A key
+// out of credit, rejected or rate limited fails the check; an API that is
+// down or overloaded only adds a note, as it may recover before the run.
+func checkQuota(cfg *config.Config) CheckResult {
+	result := CheckResult{Name: "API Quota"}
+
+	if cfg.AnthropicAPIKey == "" {
+		result.Passed = true
+		result.Message = "Not checked (ANTHROPIC_API_KEY not set)"
+		return result
+	}
+
+	body, _ := json.Marshal(map[string]any{
+		"model":      quotaProbeModel,
+		"max_tokens": 1,
+		"messages":   []map[string]string{{"role": "user", "content": "ping"}},
+	})
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(cfg.AnthropicBaseURL, "/")+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		result.Passed = false
+		result.Error = fmt.Sprintf("Invalid API URL: %v", err)
+		return result
+	}
+	req.Header.Set("x-api-key", cfg.AnthropicAPIKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+	req.Header.Set("content-type", "application/json")
+
+	resp, err := quotaClient.Do(req)
+	if err != nil {
+		result.Passed = false
+		result.Error = fmt.Sprintf("Cannot reach the Anthropic API: %v", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		result.Passed = true
+		result.Message = rateLimitSummary(resp.Header)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		result.Passed = false
+		result.Error = "Rate limited by the Anthropic API"
+		if after := resp.Header.Get("retry-after"); after != "" {
+			result.Error += fmt.Sprintf(" (retry after %ss)", after)
+		}
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		result.Passed = false
+		result.Error = "ANTHROPIC_API_KEY was rejected: " + apiErrorMessage(resp)
+	case resp.StatusCode >= 500:
+		result.Passed = true
+		result.Message = fmt.Sprintf("Not checked (API returned %s)", resp.Status)
+	default:
+		// Running out of credit is reported as a bad request
+		result.Passed = false
+		result.Error = "Anthropic API: " + apiErrorMessage(resp)
+	}
+	return result
+}
+
+// rateLimitSummary describes what is left of the key's per-minute limits,
+// e.g. "49 requests, 39000 tokens left this minute"
+func rateLimitSummary(h http.Header) string {
+	var parts []string
+	if n := h.Get("anthropic-ratelimit-requests-remaining"); n != "" {
+		parts = append(parts, n+" requests")
+	}
+	if n := h.Get("anthropic-ratelimit-tokens-remaining"); n != "" {
+		parts = append(parts, n+" tokens")
+	}
+	if len(parts) == 0 {
+		return "OK"
+	}
+	return strings.Join(parts, ", ") + " left this minute"
+}
+
+// apiErrorMessage returns the message of an API error response, or its
+// status when the body has none
+func apiErrorMessage(resp *http.Response) string {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Error.Message == "" {
+		return resp.Status
+	}
+	return body.Error.Message
+}
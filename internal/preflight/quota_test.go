@@ -0,0 +1,85 @@
+package preflight
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+)
+
+func TestCheckQuota(t *testing.T) {
+	// serve answers the quota request with status, headers and body
+	serve := func(t *testing.T, status int, headers map[string]string, body string) *config.Config {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/messages", r.URL.Path)
+			assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+			for k, v := range headers {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(srv.Close)
+		return &config.Config{AnthropicAPIKey: "test-key", AnthropicBaseURL: srv.URL}
+	}
+
+	t.Run("reports remaining limits", func(t *testing.T) {
+		cfg := serve(t, http.StatusOK, map[string]string{
+			"anthropic-ratelimit-requests-remaining": "49",
+			"anthropic-ratelimit-tokens-remaining":   "39000",
+		}, `{}`)
+
+		result := checkQuota(cfg)
+
+		assert.True(t, result.Passed)
+		assert.Equal(t, "49 requests, 39000 tokens left this minute", result.Message)
+	})
+
+	t.Run("fails without credit", func(t *testing.T) {
+		cfg := serve(t, http.StatusBadRequest, nil,
+			`{"type":"error","error":{"type":"invalid_request_error","message":"Your credit balance is too low"}}`)
+
+		result := checkQuota(cfg)
+
+		assert.False(t, result.Passed)
+		assert.Equal(t, "Anthropic API: Your credit balance is too low", result.Error)
+	})
+
+	t.Run("fails when rate limited", func(t *testing.T) {
+		cfg := serve(t, http.StatusTooManyRequests, map[string]string{"retry-after": "30"}, `{}`)
+
+		result := checkQuota(cfg)
+
+		assert.False(t, result.Passed)
+		assert.Contains(t, result.Error, "retry after 30s")
+	})
+
+	t.Run("fails with a rejected key", func(t *testing.T) {
+		cfg := serve(t, http.StatusUnauthorized, nil, `not json`)
+
+		result := checkQuota(cfg)
+
+		assert.False(t, result.Passed)
+		assert.Contains(t, result.Error, "401")
+	})
+
+	t.Run("passes when the API is overloaded", func(t *testing.T) {
+		cfg := serve(t, 529, nil, `{}`)
+
+		result := checkQuota(cfg)
+
+		assert.True(t, result.Passed)
+		assert.Contains(t, result.Message, "Not checked")
+	})
+
+	t.Run("skipped without a key", func(t *testing.T) {
+		result := checkQuota(&config.Config{})
+
+		require.True(t, result.Passed)
+		assert.Contains(t, result.Message, "ANTHROPIC_API_KEY")
+	})
+}
@@ -21,6 +21,7 @@ type Profile struct {
 	Theme            string `yaml:"theme,omitempty"`
 	Workflow         string `yaml:"workflow,omitempty"` // Name of custom workflow to use
 	MaxWorkers       int    `yaml:"max_workers,omitempty"`
+	FailureReport    string `yaml:"failure_report,omitempty"` // off, github or file; overrides BMAD_FAILURE_REPORT
 }
 
 // ProfileStore manages profile persistence
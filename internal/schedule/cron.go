@@ -0,0 +1,191 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month,
+// month and day of week, in local time
+type Cron struct {
+	spec    string
+	minutes [60]bool
+	hours   [24]bool
+	days    [32]bool // Indexed by day of month, 1-31
+	months  [13]bool // Indexed by time.Month
+	weekday [7]bool  // Indexed by time.Weekday
+
+	// Cron matches a day when either the day of month or the day of week
+	// matches, unless one of them is "*"
+	anyDay, anyWeekday bool
+}
+
+// Shorthands for common schedules
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@nightly": "0 2 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// maxCronSearch bounds how far ahead Next looks: every valid expression
+// matches within four years (29 February)
+const maxCronSearch = 4 * 366 * 24 * time.Hour
+
+// ParseCron parses a cron expression such as "0 2 * * mon-fri". Fields take
+// "*", numbers, ranges ("1-5"), steps ("*/15", "0-30/10") and lists
+// ("1,15"); months and days of the week also take names. Sunday is 0 or 7.
+// The macros @hourly, @daily, @nightly (02:00), @weekly and @monthly are
+// accepted too.
+func ParseCron(spec string) (Cron, error) {
+	spec = strings.TrimSpace(spec)
+	expr := spec
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return Cron{}, fmt.Errorf("cron expression %q: want 5 fields, got %d", spec, len(fields))
+	}
+
+	c := Cron{spec: spec}
+	var err error
+	if err = parseField(fields[0], 0, 59, nil, c.minutes[:]); err != nil {
+		return Cron{}, fmt.Errorf("cron minute: %w", err)
+	}
+	if err = parseField(fields[1], 0, 23, nil, c.hours[:]); err != nil {
+		return Cron{}, fmt.Errorf("cron hour: %w", err)
+	}
+	if err = parseField(fields[2], 1, 31, nil, c.days[:]); err != nil {
+		return Cron{}, fmt.Errorf("cron day of month: %w", err)
+	}
+	if err = parseField(fields[3], 1, 12, monthNames, c.months[:]); err != nil {
+		return Cron{}, fmt.Errorf("cron month: %w", err)
+	}
+
+	var weekdays [8]bool
+	names := make(map[string]int, len(dayNames))
+	for name, day := range dayNames {
+		names[name] = int(day)
+	}
+	if err = parseField(fields[4], 0, 7, names, weekdays[:]); err != nil {
+		return Cron{}, fmt.Errorf("cron day of week: %w", err)
+	}
+	copy(c.weekday[:], weekdays[:7])
+	c.weekday[time.Sunday] = c.weekday[time.Sunday] || weekdays[7]
+
+	c.anyDay = strings.HasPrefix(fields[2], "*")
+	c.anyWeekday = strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+// parseField sets set[v] for every value the field selects
+func parseField(field string, lo, hi int, names map[string]int, set []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		first, last := lo, hi
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if first, err = fieldValue(from, lo, hi, names); err != nil {
+				return err
+			}
+			last = first
+			if isRange {
+				if last, err = fieldValue(to, lo, hi, names); err != nil {
+					return err
+				}
+			} else if hasStep {
+				last = hi // "5/15" means from 5 to the end
+			}
+			if last < first {
+				return fmt.Errorf("invalid range %q", rangePart)
+			}
+		}
+
+		for v := first; v <= last; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// fieldValue parses a number or name within lo-hi
+func fieldValue(s string, lo, hi int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < lo || v > hi {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, lo, hi)
+	}
+	return v, nil
+}
+
+// String returns the expression as it was written
+func (c Cron) String() string {
+	return c.spec
+}
+
+// Next returns the first time after t the expression matches, to the
+// minute, or the zero time if it never does (such as "0 0 31 2 *")
+func (c Cron) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronSearch)
+
+	for next.Before(limit) {
+		if !c.months[next.Month()] {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.hours[next.Hour()] {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !c.minutes[next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+func (c Cron) matchesDay(t time.Time) bool {
+	day, weekday := c.days[t.Day()], c.weekday[t.Weekday()]
+	switch {
+	case c.anyDay && c.anyWeekday:
+		return true
+	case c.anyDay:
+		return weekday
+	case c.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, spec := range []string{"* * * * *", "0 2 * * *", "*/15 9-17 * * mon-fri", "0 0 1,15 jan-jun *", "5/20 * * * 7", "@nightly", "@Weekly"} {
+		c, err := ParseCron(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, spec, c.String())
+	}
+
+	for _, spec := range []string{"", "0 2 * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "x * * * *", "@sometimes"} {
+		_, err := ParseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	// Monday 2024-01-15 10:30
+	now := at(time.Monday, "10:30")
+
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"* * * * *", now.Add(time.Minute)},
+		{"0 2 * * *", at(time.Tuesday, "02:00")},
+		{"@nightly", at(time.Tuesday, "02:00")},
+		{"30 10 * * *", at(time.Tuesday, "10:30")},
+		{"*/15 * * * *", at(time.Monday, "10:45")},
+		{"0 9 * * sat,sun", at(time.Saturday, "09:00")},
+		{"0 9 * * 7", time.Date(2024, 1, 21, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 20 * fri", at(time.Friday, "12:00")},
+		{"0 12 16 * fri", at(time.Tuesday, "12:00")},
+		{"0 0 31 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			c, err := ParseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, c.Next(now))
+		})
+	}
+
+	t.Run("seconds are ignored", func(t *testing.T) {
+		c, err := ParseCron("31 10 * * *")
+		require.NoError(t, err)
+		assert.Equal(t, at(time.Monday, "10:31"), c.Next(now.Add(30*time.Second)))
+	})
+}
@@ -0,0 +1,248 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/parser"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// FileName is the schedules file in the data directory
+const FileName = "schedules.yaml"
+
+// ErrNotFound is returned for a schedule name that is not defined
+var ErrNotFound = errors.New("schedule not found")
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Schedule queues the stories matching a query at the times of a cron
+// expression, e.g. every ready-for-dev story at 02:00
+type Schedule struct {
+	Name     string `yaml:"name" json:"name"`
+	Cron     string `yaml:"cron" json:"cron"`
+	Select   string `yaml:"select" json:"select"` // Story list query, e.g. "ready-for-dev epic:3"
+	Disabled bool   `yaml:"disabled,omitempty" json:"disabled"`
+}
+
+// Validate checks the name, cron expression and query
+func (s Schedule) Validate() error {
+	if !validName.MatchString(s.Name) {
+		return fmt.Errorf("schedule name %q: use lowercase letters, digits, - and _", s.Name)
+	}
+	if _, err := ParseCron(s.Cron); err != nil {
+		return fmt.Errorf("schedule %s: %w", s.Name, err)
+	}
+	if s.Select == "" {
+		return fmt.Errorf("schedule %s: select is required", s.Name)
+	}
+	return nil
+}
+
+// Next returns the first run time after t, or the zero time if the
+// schedule is disabled or its expression never matches
+func (s Schedule) Next(t time.Time) time.Time {
+	c, err := ParseCron(s.Cron)
+	if s.Disabled || err != nil {
+		return time.Time{}
+	}
+	return c.Next(t)
+}
+
+// Stories returns the stories a run would queue: those matching the query
+// that are not done yet
+func (s Schedule) Stories(stories []domain.Story) []domain.Story {
+	var selected []domain.Story
+	for _, story := range parser.FilterStoriesByQuery(stories, s.Select) {
+		if story.Status != domain.StatusDone {
+			selected = append(selected, story)
+		}
+	}
+	return selected
+}
+
+// Store keeps the schedules in <data dir>/schedules.yaml
+type Store struct {
+	mu        sync.Mutex
+	path      string
+	schedules []Schedule
+}
+
+type scheduleFile struct {
+	Schedules []Schedule `yaml:"schedules"`
+}
+
+// NewStore creates a store for the schedules file in dataDir
+func NewStore(dataDir string) *Store {
+	return &Store{path: filepath.Join(dataDir, FileName)}
+}
+
+// Path returns the schedules file path
+func (st *Store) Path() string {
+	return st.path
+}
+
+// Load reads the schedules file. A missing file means no schedules.
+func (st *Store) Load() error {
+	data, err := os.ReadFile(st.path)
+	if os.IsNotExist(err) {
+		st.mu.Lock()
+		st.schedules = nil
+		st.mu.Unlock()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	var file scheduleFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", st.path, err)
+	}
+	seen := make(map[string]bool)
+	for _, s := range file.Schedules {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", st.path, err)
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("%s: schedule %s is defined twice", st.path, s.Name)
+		}
+		seen[s.Name] = true
+	}
+
+	st.mu.Lock()
+	st.schedules = file.Schedules
+	st.mu.Unlock()
+	return nil
+}
+
+// save writes the schedules file; callers hold st.mu
+func (st *Store) save() error {
+	data, err := yaml.Marshal(scheduleFile{Schedules: st.schedules})
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedules: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := os.WriteFile(st.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+	return nil
+}
+
+// List returns the schedules sorted by name
+func (st *Store) List() []Schedule {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	list := make([]Schedule, len(st.schedules))
+	copy(list, st.schedules)
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Get returns a schedule by name
+func (st *Store) Get(name string) (Schedule, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if i := st.index(name); i >= 0 {
+		return st.schedules[i], nil
+	}
+	return Schedule{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+}
+
+// Add validates and saves a new schedule
+func (st *Store) Add(s Schedule) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if st.index(s.Name) >= 0 {
+		return fmt.Errorf("schedule %s already exists", s.Name)
+	}
+	st.schedules = append(st.schedules, s)
+	if err := st.save(); err != nil {
+		st.schedules = st.schedules[:len(st.schedules)-1]
+		return err
+	}
+	return nil
+}
+
+// Remove deletes a schedule
+func (st *Store) Remove(name string) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	i := st.index(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	removed := st.schedules[i]
+	st.schedules = append(st.schedules[:i], st.schedules[i+1:]...)
+	if err := st.save(); err != nil {
+		st.schedules = append(st.schedules[:i], append([]Schedule{removed}, st.schedules[i:]...)...)
+		return err
+	}
+	return nil
+}
+
+// SetEnabled turns a schedule on or off
+func (st *Store) SetEnabled(name string, enabled bool) error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	i := st.index(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	previous := st.schedules[i].Disabled
+	st.schedules[i].Disabled = !enabled
+	if err := st.save(); err != nil {
+		st.schedules[i].Disabled = previous
+		return err
+	}
+	return nil
+}
+
+func (st *Store) index(name string) int {
+	for i, s := range st.schedules {
+		if s.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Run records the outcome of a schedule's last run
+type Run struct {
+	At      time.Time `json:"at"`
+	Queued  int       `json:"queued"`            // Stories added to the queue
+	Skipped string    `json:"skipped,omitempty"` // Why nothing was queued
+}
+
+// SaveRun stores the last run of a schedule in the app state
+func SaveRun(ctx context.Context, s storage.Storage, name string, run Run) error {
+	return storage.SetStateJSON(ctx, s, storage.StateSchedulePrefix+name, run)
+}
+
+// LoadRun returns the last run of a schedule, if it has run
+func LoadRun(ctx context.Context, s storage.Storage, name string) (Run, bool, error) {
+	var run Run
+	ok, err := storage.GetStateJSON(ctx, s, storage.StateSchedulePrefix+name, &run)
+	return run, ok, err
+}
@@ -0,0 +1,114 @@
+package schedule
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	assert.NoError(t, Schedule{Name: "nightly-dev", Cron: "@nightly", Select: "ready-for-dev"}.Validate())
+
+	for _, s := range []Schedule{
+		{Name: "", Cron: "@daily", Select: "ready"},
+		{Name: "../etc", Cron: "@daily", Select: "ready"},
+		{Name: "Nightly", Cron: "@daily", Select: "ready"},
+		{Name: "nightly", Cron: "0 2 * *", Select: "ready"},
+		{Name: "nightly", Cron: "@daily", Select: ""},
+	} {
+		assert.Error(t, s.Validate(), "%+v", s)
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	now := at(time.Monday, "10:30")
+	s := Schedule{Name: "nightly", Cron: "@nightly", Select: "ready"}
+
+	assert.Equal(t, at(time.Tuesday, "02:00"), s.Next(now))
+
+	s.Disabled = true
+	assert.True(t, s.Next(now).IsZero())
+}
+
+func TestSchedule_Stories(t *testing.T) {
+	stories := []domain.Story{
+		{Key: "1-1-login", Epic: 1, Status: domain.StatusReadyForDev},
+		{Key: "1-2-logout", Epic: 1, Status: domain.StatusDone},
+		{Key: "2-1-search", Epic: 2, Status: domain.StatusReadyForDev},
+		{Key: "2-2-filters", Epic: 2, Status: domain.StatusBacklog},
+	}
+
+	s := Schedule{Select: "epic:2 ready"}
+	selected := s.Stories(stories)
+	require.Len(t, selected, 1)
+	assert.Equal(t, "2-1-search", selected[0].Key)
+
+	s.Select = "epic:1"
+	selected = s.Stories(stories)
+	require.Len(t, selected, 1, "done stories are left out")
+	assert.Equal(t, "1-1-login", selected[0].Key)
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	st := NewStore(dir)
+
+	require.NoError(t, st.Load(), "a missing file means no schedules")
+	assert.Empty(t, st.List())
+
+	require.NoError(t, st.Add(Schedule{Name: "weekly", Cron: "@weekly", Select: "backlog"}))
+	require.NoError(t, st.Add(Schedule{Name: "nightly", Cron: "0 2 * * *", Select: "ready-for-dev"}))
+	assert.Error(t, st.Add(Schedule{Name: "nightly", Cron: "@daily", Select: "ready"}), "duplicate name")
+	assert.Error(t, st.Add(Schedule{Name: "bad", Cron: "nope", Select: "ready"}))
+
+	require.NoError(t, st.SetEnabled("weekly", false))
+	assert.ErrorIs(t, st.SetEnabled("missing", true), ErrNotFound)
+
+	reloaded := NewStore(dir)
+	require.NoError(t, reloaded.Load())
+	list := reloaded.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, "nightly", list[0].Name)
+	assert.Equal(t, "weekly", list[1].Name)
+	assert.True(t, list[1].Disabled)
+
+	require.NoError(t, reloaded.Remove("weekly"))
+	_, err := reloaded.Get("weekly")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, reloaded.Remove("weekly"), ErrNotFound)
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		dir := t.TempDir()
+		data := "schedules:\n  - name: a\n    cron: \"@daily\"\n    select: ready\n  - name: a\n    cron: \"@hourly\"\n    select: backlog\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte(data), 0644))
+		assert.Error(t, NewStore(dir).Load())
+	})
+}
+
+func TestRuns(t *testing.T) {
+	s, err := storage.NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	_, ok, err := LoadRun(ctx, s, "nightly")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	run := Run{At: at(time.Tuesday, "02:00").UTC(), Queued: 3}
+	require.NoError(t, SaveRun(ctx, s, "nightly", run))
+
+	loaded, ok, err := LoadRun(ctx, s, "nightly")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, run.At.Equal(loaded.At))
+	assert.Equal(t, 3, loaded.Queued)
+}
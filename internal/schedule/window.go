@@ -0,0 +1,238 @@
+// Package schedule decides when queued work may run.
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Window is a daily time range on some days of the week. A window whose
+// end is not after its start runs past midnight, e.g. 22:00-06:00; its days
+// are the days it starts on.
+type Window struct {
+	Start time.Duration // Offset from midnight
+	End   time.Duration
+	Days  [7]bool // Indexed by time.Weekday
+}
+
+// Windows are the times runs are allowed. No windows means any time.
+type Windows []Window
+
+var dayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseWindows parses windows separated by ";", each "HH:MM-HH:MM" followed
+// by optional days: "weekdays", "weekends", "daily", day names such as
+// "mon,wed" or ranges such as "mon-fri". Without days a window applies
+// every day.
+func ParseWindows(spec string) (Windows, error) {
+	var windows Windows
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		w, err := parseWindow(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid run window %q: %w", part, err)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+func parseWindow(s string) (Window, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, "–", "-"))
+	var w Window
+
+	from, to, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		return w, fmt.Errorf("want HH:MM-HH:MM")
+	}
+	var err error
+	if w.Start, err = parseClock(from); err != nil {
+		return w, err
+	}
+	if w.End, err = parseClock(to); err != nil {
+		return w, err
+	}
+	if w.Start == w.End {
+		return w, fmt.Errorf("start and end are the same")
+	}
+
+	if len(fields) == 1 {
+		w.Days = [7]bool{true, true, true, true, true, true, true}
+		return w, nil
+	}
+	for _, field := range fields[1:] {
+		for _, days := range strings.Split(strings.ToLower(field), ",") {
+			if err := w.addDays(days); err != nil {
+				return w, err
+			}
+		}
+	}
+	return w, nil
+}
+
+// addDays marks a day name, a range of days or a keyword
+func (w *Window) addDays(s string) error {
+	switch s {
+	case "":
+		return nil
+	case "daily":
+		return w.addDays("sun-sat")
+	case "weekdays":
+		return w.addDays("mon-fri")
+	case "weekends":
+		return w.addDays("sat,sun")
+	}
+	if strings.Contains(s, ",") {
+		for _, day := range strings.Split(s, ",") {
+			if err := w.addDays(day); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	from, to, isRange := strings.Cut(s, "-")
+	first, ok := dayNames[truncate(from, 3)]
+	if !ok {
+		return fmt.Errorf("unknown day %q", from)
+	}
+	last := first
+	if isRange {
+		if last, ok = dayNames[truncate(to, 3)]; !ok {
+			return fmt.Errorf("unknown day %q", to)
+		}
+	}
+	for day := first; ; day = (day + 1) % 7 {
+		w.Days[day] = true
+		if day == last {
+			return nil
+		}
+	}
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// String formats the window like its spec
+func (w Window) String() string {
+	clock := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	s := clock(w.Start) + "-" + clock(w.End)
+
+	var days []string
+	for _, day := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		if w.Days[day] {
+			days = append(days, strings.ToLower(day.String()[:3]))
+		}
+	}
+	switch len(days) {
+	case 7:
+		return s
+	case 0:
+		return s + " never"
+	}
+	return s + " " + strings.Join(days, ",")
+}
+
+// String formats the windows like their spec
+func (ws Windows) String() string {
+	parts := make([]string, len(ws))
+	for i, w := range ws {
+		parts[i] = w.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// interval is one occurrence of a window
+type interval struct {
+	start, end time.Time
+}
+
+// occurrences returns the occurrences of the windows that start between the
+// day before t and a week after it, in t's location
+func (ws Windows) occurrences(t time.Time) []interval {
+	var out []interval
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	for offset := -1; offset <= 7; offset++ {
+		day := midnight.AddDate(0, 0, offset)
+		for _, w := range ws {
+			if !w.Days[day.Weekday()] {
+				continue
+			}
+			start := day.Add(w.Start)
+			end := day.Add(w.End)
+			if w.End <= w.Start {
+				end = day.AddDate(0, 0, 1).Add(w.End)
+			}
+			out = append(out, interval{start, end})
+		}
+	}
+	return out
+}
+
+// Open reports whether runs are allowed at t and, if so, when that stops.
+// Overlapping or touching windows count as one. The end is zero when there
+// are no windows.
+func (ws Windows) Open(t time.Time) (time.Time, bool) {
+	if len(ws) == 0 {
+		return time.Time{}, true
+	}
+
+	occurrences := ws.occurrences(t)
+	var end time.Time
+	for _, o := range occurrences {
+		if !o.start.After(t) && o.end.After(t) && o.end.After(end) {
+			end = o.end
+		}
+	}
+	if end.IsZero() {
+		return end, false
+	}
+
+	// Extend through windows that start before the open one ends
+	for extended := true; extended; {
+		extended = false
+		for _, o := range occurrences {
+			if !o.start.After(end) && o.end.After(end) {
+				end, extended = o.end, true
+			}
+		}
+	}
+	return end, true
+}
+
+// NextOpen returns when runs are next allowed at or after t: t itself when
+// a window is open, otherwise the start of the next window. It returns the
+// zero time when no window ever opens.
+func (ws Windows) NextOpen(t time.Time) time.Time {
+	if _, open := ws.Open(t); open {
+		return t
+	}
+	var next time.Time
+	for _, o := range ws.occurrences(t) {
+		if o.start.After(t) && (next.IsZero() || o.start.Before(next)) {
+			next = o.start
+		}
+	}
+	return next
+}
@@ -0,0 +1,81 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// at returns a time in the week of Monday 2024-01-15
+func at(day time.Weekday, clock string) time.Time {
+	t, _ := time.Parse("15:04", clock)
+	return time.Date(2024, 1, 14+int(day), t.Hour(), t.Minute(), 0, 0, time.UTC)
+}
+
+func TestParseWindows(t *testing.T) {
+	ws, err := ParseWindows("22:00-06:00 weekdays; 09:00–17:00 sat,sun ; 12:00-13:00")
+	require.NoError(t, err)
+	require.Len(t, ws, 3)
+	assert.Equal(t, 22*time.Hour, ws[0].Start)
+	assert.Equal(t, 6*time.Hour, ws[0].End)
+	assert.Equal(t, "22:00-06:00 mon,tue,wed,thu,fri; 09:00-17:00 sat,sun; 12:00-13:00", ws.String())
+
+	ws, err = ParseWindows("08:00-10:00 fri-mon")
+	require.NoError(t, err)
+	assert.Equal(t, "08:00-10:00 mon,fri,sat,sun", ws.String(), "ranges wrap around the week")
+
+	ws, err = ParseWindows("")
+	require.NoError(t, err)
+	assert.Empty(t, ws)
+
+	for _, spec := range []string{"22:00", "25:00-06:00", "10:00-10:00", "22:00-06:00 someday"} {
+		_, err := ParseWindows(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestWindows_Open(t *testing.T) {
+	ws, err := ParseWindows("22:00-06:00 weekdays")
+	require.NoError(t, err)
+
+	end, open := ws.Open(at(time.Monday, "23:30"))
+	assert.True(t, open)
+	assert.Equal(t, at(time.Tuesday, "06:00"), end)
+
+	end, open = ws.Open(at(time.Saturday, "05:00"))
+	assert.True(t, open, "Friday's window runs into Saturday")
+	assert.Equal(t, at(time.Saturday, "06:00"), end)
+
+	_, open = ws.Open(at(time.Saturday, "23:00"))
+	assert.False(t, open)
+	_, open = ws.Open(at(time.Tuesday, "06:00"))
+	assert.False(t, open, "the end is exclusive")
+
+	t.Run("touching windows merge", func(t *testing.T) {
+		ws, err := ParseWindows("20:00-22:00; 22:00-23:00")
+		require.NoError(t, err)
+		end, open := ws.Open(at(time.Monday, "21:00"))
+		assert.True(t, open)
+		assert.Equal(t, at(time.Monday, "23:00"), end)
+	})
+
+	t.Run("no windows is always open", func(t *testing.T) {
+		end, open := Windows(nil).Open(at(time.Monday, "12:00"))
+		assert.True(t, open)
+		assert.True(t, end.IsZero())
+	})
+}
+
+func TestWindows_NextOpen(t *testing.T) {
+	ws, err := ParseWindows("22:00-06:00 weekdays")
+	require.NoError(t, err)
+
+	assert.Equal(t, at(time.Monday, "22:00"), ws.NextOpen(at(time.Monday, "12:00")))
+	assert.Equal(t, at(time.Monday, "23:00"), ws.NextOpen(at(time.Monday, "23:00")), "open now")
+	assert.Equal(t, at(time.Monday, "22:00").AddDate(0, 0, 7), ws.NextOpen(at(time.Saturday, "12:00")), "skips the weekend")
+
+	never := Windows{{Start: time.Hour, End: 2 * time.Hour}}
+	assert.True(t, never.NextOpen(at(time.Monday, "12:00")).IsZero())
+}
@@ -1,7 +1,9 @@
 package sound
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 )
 
@@ -16,14 +18,51 @@ const (
 	SoundComplete
 )
 
+// Events lists the sound events in the order settings show them
+var Events = []SoundType{SoundComplete, SoundWarning, SoundError, SoundSuccess, SoundNotification}
+
+// String returns the event name custom sound files and settings use,
+// e.g. "failure" for SoundError
+func (t SoundType) String() string {
+	switch t {
+	case SoundSuccess:
+		return "success"
+	case SoundError:
+		return "failure"
+	case SoundWarning:
+		return "warning"
+	case SoundComplete:
+		return "complete"
+	default:
+		return "notification"
+	}
+}
+
+// ParseEvent returns the sound event with the given name
+func ParseEvent(name string) (SoundType, bool) {
+	for _, t := range Events {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// customExtensions are the audio files looked for in the sounds directory,
+// in order of preference
+var customExtensions = []string{".wav", ".oga", ".ogg", ".aiff", ".mp3"}
+
 // Player handles sound playback
 type Player struct {
 	enabled bool
+	focused bool               // The terminal has focus, so sounds are not needed
+	dir     string             // Directory of custom sound files, "" for system sounds only
+	muted   map[SoundType]bool // Events switched off on their own
 }
 
 // New creates a new sound player
 func New(enabled bool) *Player {
-	return &Player{enabled: enabled}
+	return &Player{enabled: enabled, muted: make(map[SoundType]bool)}
 }
 
 // SetEnabled enables or disables sound
@@ -36,9 +75,31 @@ func (p *Player) IsEnabled() bool {
 	return p.enabled
 }
 
+// SetFocused records whether the terminal has focus. Sounds only play
+// while it does not.
+func (p *Player) SetFocused(focused bool) {
+	p.focused = focused
+}
+
+// SetDir sets the directory custom sound files are loaded from. A file
+// named after an event, such as failure.wav, replaces its system sound.
+func (p *Player) SetDir(dir string) {
+	p.dir = dir
+}
+
+// SetMuted switches a single event's sound off or back on
+func (p *Player) SetMuted(soundType SoundType, muted bool) {
+	p.muted[soundType] = muted
+}
+
+// IsMuted returns whether an event's sound is switched off
+func (p *Player) IsMuted(soundType SoundType) bool {
+	return p.muted[soundType]
+}
+
 // Play plays a sound of the given type
 func (p *Player) Play(soundType SoundType) error {
-	if !p.enabled {
+	if !p.enabled || p.focused || p.muted[soundType] {
 		return nil
 	}
 
@@ -78,11 +139,26 @@ func (p *Player) PlayComplete() error {
 	return p.Play(SoundComplete)
 }
 
+// customSoundPath returns the user's sound file for an event, or "" when
+// there is none
+func (p *Player) customSoundPath(soundType SoundType) string {
+	if p.dir == "" {
+		return ""
+	}
+	for _, ext := range customExtensions {
+		path := filepath.Join(p.dir, soundType.String()+ext)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
 // playMacOS plays system sounds on macOS using afplay
 func (p *Player) playMacOS(soundType SoundType) error {
-	soundPath := getMacOSSoundPath(soundType)
+	soundPath := p.customSoundPath(soundType)
 	if soundPath == "" {
-		return nil
+		soundPath = getMacOSSoundPath(soundType)
 	}
 
 	// Run in background so it doesn't block
@@ -112,9 +188,9 @@ func getMacOSSoundPath(soundType SoundType) string {
 
 // playLinux plays sounds on Linux using paplay
 func (p *Player) playLinux(soundType SoundType) error {
-	soundPath := getLinuxSoundPath(soundType)
+	soundPath := p.customSoundPath(soundType)
 	if soundPath == "" {
-		return nil
+		soundPath = getLinuxSoundPath(soundType)
 	}
 
 	cmd := exec.Command("paplay", soundPath)
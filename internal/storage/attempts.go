@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// AttemptStats summarizes the retry behaviour of one step
+type AttemptStats struct {
+	Attempts       int     // Attempts made in total
+	Runs           int     // Step runs with attempt records
+	Retried        int     // Runs that needed more than one attempt
+	Recovered      int     // Retried runs that eventually succeeded
+	FirstTryRate   float64 // Percentage of runs that succeeded on their first attempt
+	AttemptsPerRun float64
+}
+
+// insertAttempts records every attempt of a step. The final attempt's
+// output is already stored in step_output_blobs, so only earlier attempts keep
+// their output here, capped at maxLines with the most recent lines kept.
+func insertAttempts(ctx context.Context, tx *sql.Tx, stepID string, step *domain.StepExecution, maxLines int) error {
+	attempts := step.Attempts()
+	for i, a := range attempts {
+		var output []string
+		if i < len(attempts)-1 {
+			output = a.Output
+			if len(output) > maxLines {
+				output = output[len(output)-maxLines:]
+			}
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO attempt_executions (step_execution_id, attempt, status, start_time, duration_ms, error, output)
+			VALUES (?, ?, ?, ?, ?, ?, ?)
+		`, stepID, a.Number, string(a.Status), nullableTime(a.StartTime), a.Duration.Milliseconds(), a.Error, strings.Join(output, "\n"))
+		if err != nil {
+			return fmt.Errorf("failed to insert attempt: %w", err)
+		}
+	}
+	return nil
+}
+
+// getAttempts returns the recorded attempts of a step, oldest first
+func (s *SQLiteStorage) getAttempts(ctx context.Context, stepID string) ([]*domain.StepAttempt, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT attempt, status, start_time, duration_ms, error, output FROM attempt_executions
+		WHERE step_execution_id = ?
+		ORDER BY attempt
+	`, stepID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []*domain.StepAttempt
+	for rows.Next() {
+		var a domain.StepAttempt
+		var status, output string
+		var startTime sql.NullString
+		var durationMs int64
+		if err := rows.Scan(&a.Number, &status, &startTime, &durationMs, &a.Error, &output); err != nil {
+			return nil, err
+		}
+		a.Status = domain.StepStatus(status)
+		if startTime.Valid {
+			a.StartTime, _ = time.Parse(time.RFC3339, startTime.String)
+		}
+		a.Duration = time.Duration(durationMs) * time.Millisecond
+		if output != "" {
+			a.Output = strings.Split(output, "\n")
+		}
+		attempts = append(attempts, &a)
+	}
+	return attempts, rows.Err()
+}
+
+// previousAttempts returns the attempts before the step's final one
+func (s *SQLiteStorage) previousAttempts(ctx context.Context, step *StepRecord) ([]*domain.StepAttempt, error) {
+	attempts, err := s.getAttempts(ctx, step.ID)
+	if err != nil {
+		return nil, err
+	}
+	var previous []*domain.StepAttempt
+	for _, a := range attempts {
+		if a.Number < step.Attempt {
+			previous = append(previous, a)
+		}
+	}
+	return previous, nil
+}
+
+// getAttemptStats returns retry statistics per step from the attempt records
+// of executions started within r
+func (s *SQLiteStorage) getAttemptStats(ctx context.Context, r domain.DateRange) (map[domain.StepName]*AttemptStats, error) {
+	inRange, args := rangeCondition(r)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT
+			se.step_name,
+			COUNT(*) as attempts,
+			COUNT(DISTINCT a.step_execution_id) as runs,
+			COUNT(DISTINCT CASE WHEN a.attempt > 1 THEN a.step_execution_id END) as retried,
+			COUNT(DISTINCT CASE WHEN a.attempt > 1 AND a.status = 'success' THEN a.step_execution_id END) as recovered,
+			COALESCE(SUM(CASE WHEN a.attempt = 1 AND a.status = 'success' THEN 1 ELSE 0 END), 0) as first_try
+		FROM attempt_executions a
+		JOIN step_executions se ON se.id = a.step_execution_id
+		WHERE se.execution_id IN (SELECT id FROM executions WHERE `+inRange+`)
+		GROUP BY se.step_name
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempt stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[domain.StepName]*AttemptStats)
+	for rows.Next() {
+		var name string
+		var as AttemptStats
+		var firstTry int
+		if err := rows.Scan(&name, &as.Attempts, &as.Runs, &as.Retried, &as.Recovered, &firstTry); err != nil {
+			return nil, err
+		}
+		if as.Runs > 0 {
+			as.FirstTryRate = float64(firstTry) / float64(as.Runs) * 100
+			as.AttemptsPerRun = float64(as.Attempts) / float64(as.Runs)
+		}
+		stats[domain.StepName(name)] = &as
+	}
+	return stats, rows.Err()
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// retriedExecution returns a completed execution whose first step needed
+// three attempts
+func retriedExecution(key string) *domain.Execution {
+	exec := createCompletedExecution(createTestStory(key, 1, domain.StatusDone))
+	retried := exec.Steps[0]
+	retried.Attempt = 3
+	retried.Output = []string{"ok"}
+	retried.PreviousAttempts = []*domain.StepAttempt{
+		{Number: 1, Status: domain.StepFailed, StartTime: retried.StartTime.Add(-2 * time.Minute),
+			Duration: time.Second, Error: "exit status 1", Output: []string{"FAIL a", "FAIL b"}},
+		{Number: 2, Status: domain.StepFailed, StartTime: retried.StartTime.Add(-time.Minute),
+			Duration: 2 * time.Second, Error: "timeout after 60s"},
+	}
+	return exec
+}
+
+func TestSQLiteStorage_PreviousAttempts(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	exec := retriedExecution("1-1-test")
+	retried := exec.Steps[0]
+	require.NoError(t, s.SaveExecution(ctx, exec))
+
+	rec, err := s.GetExecutionWithOutput(ctx, exec.ID)
+	require.NoError(t, err)
+
+	var found bool
+	for _, step := range rec.Steps {
+		if step.StepName != retried.Name {
+			assert.Empty(t, step.PreviousAttempts)
+			continue
+		}
+		found = true
+		assert.Equal(t, []string{"ok"}, step.Output)
+		require.Len(t, step.PreviousAttempts, 2)
+		for i, want := range retried.PreviousAttempts {
+			got := step.PreviousAttempts[i]
+			assert.Equal(t, want.Number, got.Number)
+			assert.Equal(t, want.Status, got.Status)
+			assert.Equal(t, want.Duration, got.Duration)
+			assert.Equal(t, want.Error, got.Error)
+			assert.Equal(t, want.Output, got.Output)
+			assert.WithinDuration(t, want.StartTime, got.StartTime, time.Second)
+		}
+	}
+	assert.True(t, found)
+
+	// Every attempt has a row, including the final one of each step
+	var count int
+	require.NoError(t, s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM attempt_executions").Scan(&count))
+	assert.Equal(t, len(exec.Steps)+2, count)
+
+	// Attempts go with their execution
+	require.NoError(t, s.DeleteExecution(ctx, exec.ID))
+	require.NoError(t, s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM attempt_executions").Scan(&count))
+	assert.Zero(t, count)
+}
+
+func TestSQLiteStorage_AttemptStats(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveExecution(ctx, retriedExecution("1-1-test")))
+	require.NoError(t, s.SaveExecution(ctx, createCompletedExecution(createTestStory("1-2-test", 1, domain.StatusDone))))
+
+	stats, err := s.GetStats(ctx, domain.DateRange{})
+	require.NoError(t, err)
+
+	first := stats.StepStats[domain.AllSteps()[0]].Attempts
+	require.NotNil(t, first)
+	assert.Equal(t, 4, first.Attempts)
+	assert.Equal(t, 2, first.Runs)
+	assert.Equal(t, 1, first.Retried)
+	assert.Equal(t, 1, first.Recovered)
+	assert.InDelta(t, 50.0, first.FirstTryRate, 0.01)
+	assert.InDelta(t, 2.0, first.AttemptsPerRun, 0.01)
+
+	other := stats.StepStats[domain.AllSteps()[1]].Attempts
+	require.NotNil(t, other)
+	assert.Equal(t, 0, other.Retried)
+	assert.InDelta(t, 100.0, other.FirstTryRate, 0.01)
+}
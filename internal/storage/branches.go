@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// insertBranch records the story branch an execution's steps ran on
+func insertBranch(ctx context.Context, tx *sql.Tx, execID, branch string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO story_branches (execution_id, branch) VALUES (?, ?)
+	`, execID, branch)
+	if err != nil {
+		return fmt.Errorf("failed to insert story branch: %w", err)
+	}
+	return nil
+}
+
+// getBranch returns the story branch of an execution, or "" if it ran on
+// whatever was checked out
+func (s *SQLiteStorage) getBranch(ctx context.Context, execID string) (string, error) {
+	var branch string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT branch FROM story_branches WHERE execution_id = ?
+	`, execID).Scan(&branch)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get story branch: %w", err)
+	}
+	return branch, nil
+}
+
+// insertStash records the auto-stash commit an execution could not restore
+func insertStash(ctx context.Context, tx *sql.Tx, execID, commit string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO auto_stashes (execution_id, stash_commit) VALUES (?, ?)
+	`, execID, commit)
+	if err != nil {
+		return fmt.Errorf("failed to insert auto-stash: %w", err)
+	}
+	return nil
+}
+
+// getStash returns the auto-stash commit an execution left unrestored, or ""
+func (s *SQLiteStorage) getStash(ctx context.Context, execID string) (string, error) {
+	var commit string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT stash_commit FROM auto_stashes WHERE execution_id = ?
+	`, execID).Scan(&commit)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get auto-stash: %w", err)
+	}
+	return commit, nil
+}
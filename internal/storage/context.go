@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// insertContext records the environment an execution started in
+func insertContext(ctx context.Context, tx *sql.Tx, execID string, ec *domain.ExecutionContext) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO execution_context (execution_id, git_branch, git_commit, git_dirty, claude_version,
+			bmad_version, os, arch, config_digest, workflow, profile)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, execID, ec.GitBranch, ec.GitCommit, ec.GitDirty, ec.ClaudeVersion,
+		ec.BmadVersion, ec.OS, ec.Arch, ec.ConfigDigest, ec.Workflow, ec.Profile)
+	if err != nil {
+		return fmt.Errorf("failed to insert execution context: %w", err)
+	}
+	return nil
+}
+
+// getContext returns the recorded environment of an execution, or nil
+func (s *SQLiteStorage) getContext(ctx context.Context, execID string) (*domain.ExecutionContext, error) {
+	var ec domain.ExecutionContext
+	err := s.db.QueryRowContext(ctx, `
+		SELECT git_branch, git_commit, git_dirty, claude_version, bmad_version, os, arch,
+			config_digest, workflow, profile
+		FROM execution_context WHERE execution_id = ?
+	`, execID).Scan(&ec.GitBranch, &ec.GitCommit, &ec.GitDirty, &ec.ClaudeVersion, &ec.BmadVersion,
+		&ec.OS, &ec.Arch, &ec.ConfigDigest, &ec.Workflow, &ec.Profile)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get execution context: %w", err)
+	}
+	return &ec, nil
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_ExecutionContext(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	withContext := createCompletedExecution(createTestStory("1-1-test", 1, domain.StatusDone))
+	withContext.Context = &domain.ExecutionContext{
+		GitBranch:     "main",
+		GitCommit:     "0123456789abcdef0123456789abcdef01234567",
+		GitDirty:      true,
+		ClaudeVersion: "1.0.3 (Claude Code)",
+		BmadVersion:   "v0.4.0",
+		OS:            "linux",
+		Arch:          "amd64",
+		ConfigDigest:  "a1b2c3d4e5f6",
+		Workflow:      "default",
+		Profile:       "work",
+	}
+	withContext.Branch = "story/1-1-test"
+	withContext.Stash = "89abcdef0123456789abcdef0123456789abcdef"
+	without := createCompletedExecution(createTestStory("1-2-test", 1, domain.StatusDone))
+	require.NoError(t, s.SaveExecution(ctx, withContext))
+	require.NoError(t, s.SaveExecution(ctx, without))
+
+	rec, err := s.GetExecution(ctx, withContext.ID)
+	require.NoError(t, err)
+	assert.Equal(t, withContext.Context, rec.Context)
+	assert.Equal(t, "story/1-1-test", rec.Branch)
+	assert.Equal(t, withContext.Stash, rec.Stash)
+
+	rec, err = s.GetExecution(ctx, without.ID)
+	require.NoError(t, err)
+	assert.Nil(t, rec.Context)
+	assert.Empty(t, rec.Branch)
+	assert.Empty(t, rec.Stash)
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// ErrInvalidCursor is returned for a cursor that ListExecutions did not hand out
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor returns the token that continues a listing after this execution.
+// Unlike an offset it stays put when new executions are recorded between
+// pages. It is empty for records not loaded by ListExecutions.
+func (r *ExecutionRecord) Cursor() string {
+	if r.createdAt == "" {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(r.createdAt + "|" + r.ID))
+}
+
+// decodeCursor returns the created_at and ID a cursor points after
+func decodeCursor(cursor string) (createdAt, id string, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(data), "|")
+	if !ok || createdAt == "" || id == "" {
+		return "", "", ErrInvalidCursor
+	}
+	return createdAt, id, nil
+}
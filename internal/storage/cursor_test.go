@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_ListExecutionsCursor(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	// Saved within the same second, so only the IDs order them
+	for i := 0; i < 5; i++ {
+		exec := createCompletedExecution(createTestStory(fmt.Sprintf("3-%d-story", i), 3, domain.StatusDone))
+		require.NoError(t, s.SaveExecution(ctx, exec))
+	}
+	all, err := s.ListExecutions(ctx, &ExecutionFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+
+	var paged []string
+	filter := &ExecutionFilter{Limit: 2}
+	for page := 0; page < 5; page++ {
+		records, err := s.ListExecutions(ctx, filter)
+		require.NoError(t, err)
+		if len(records) == 0 {
+			break
+		}
+		for _, rec := range records {
+			paged = append(paged, rec.ID)
+		}
+		filter.Cursor = records[len(records)-1].Cursor()
+
+		// Executions recorded between pages do not shift the next one
+		if page == 0 {
+			newer := createCompletedExecution(createTestStory("4-1-new", 4, domain.StatusDone))
+			require.NoError(t, s.SaveExecution(ctx, newer))
+		}
+	}
+
+	var want []string
+	for _, rec := range all {
+		want = append(want, rec.ID)
+	}
+	assert.Equal(t, want, paged)
+
+	t.Run("counts what follows the cursor", func(t *testing.T) {
+		count, err := s.CountExecutions(ctx, &ExecutionFilter{Cursor: all[1].Cursor()})
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("rejects cursors it did not hand out", func(t *testing.T) {
+		_, err := s.ListExecutions(ctx, &ExecutionFilter{Cursor: "not a cursor"})
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+		assert.Empty(t, (&ExecutionRecord{ID: "x"}).Cursor())
+	})
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// DeadlineStats counts the executions that ran with a deadline and those
+// that completed by it
+type DeadlineStats struct {
+	Total int
+	Met   int
+}
+
+// HitRate returns the percentage of deadlines met, 0 without any
+func (d *DeadlineStats) HitRate() float64 {
+	if d.Total == 0 {
+		return 0
+	}
+	return float64(d.Met) / float64(d.Total) * 100
+}
+
+// insertDeadline records the deadline an execution ran against and
+// whether it was met
+func insertDeadline(ctx context.Context, tx *sql.Tx, execID string, exec *domain.Execution) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO deadlines (execution_id, deadline, met) VALUES (?, ?, ?)
+	`, execID, exec.Deadline.Format(time.RFC3339), exec.MetDeadline())
+	if err != nil {
+		return fmt.Errorf("failed to insert deadline: %w", err)
+	}
+	return nil
+}
+
+// GetDeadlineStats returns how many executions had a deadline and how many
+// of them met it
+func (s *SQLiteStorage) GetDeadlineStats(ctx context.Context) (*DeadlineStats, error) {
+	stats := &DeadlineStats{}
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*), COALESCE(SUM(met), 0) FROM deadlines
+	`).Scan(&stats.Total, &stats.Met)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deadline stats: %w", err)
+	}
+	return stats, nil
+}
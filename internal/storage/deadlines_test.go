@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_DeadlineStats(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	stats, err := s.GetDeadlineStats(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, stats.Total)
+	assert.Zero(t, stats.HitRate())
+
+	story := createTestStory("1-1-test", 1, domain.StatusDone)
+
+	met := createCompletedExecution(story)
+	met.Deadline = met.EndTime.Add(time.Minute)
+	require.NoError(t, s.SaveExecution(ctx, met))
+
+	late := createCompletedExecution(story)
+	late.Deadline = late.EndTime.Add(-time.Minute)
+	require.NoError(t, s.SaveExecution(ctx, late))
+
+	failed := createCompletedExecution(story)
+	failed.Status = domain.ExecutionFailed
+	failed.Deadline = failed.EndTime.Add(time.Hour)
+	require.NoError(t, s.SaveExecution(ctx, failed))
+
+	// Runs without a deadline are not counted
+	require.NoError(t, s.SaveExecution(ctx, createCompletedExecution(story)))
+
+	stats, err = s.GetDeadlineStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, 1, stats.Met)
+	assert.InDelta(t, 33.3, stats.HitRate(), 0.1)
+}
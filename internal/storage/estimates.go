@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// CalibrationWindow is how many recent completed stories GetCalibration
+// compares against their predictions
+const CalibrationWindow = 50
+
+// EstimateRecord is a predicted duration next to the actual one
+type EstimateRecord struct {
+	ExecutionID string
+	StoryKey    string
+	StepName    domain.StepName // Empty for the whole story
+	StartTime   time.Time
+	Predicted   time.Duration
+	Actual      time.Duration
+}
+
+// PercentError returns how far off the prediction was (positive = ran longer)
+func (r *EstimateRecord) PercentError() float64 {
+	return domain.PercentError(r.Predicted, r.Actual)
+}
+
+// Calibration summarizes how well predicted durations matched actual ones
+type Calibration struct {
+	Stories      []*EstimateRecord // Recent completed stories, oldest first
+	MeanAbsError float64           // Mean absolute percentage error of Stories
+	Bias         float64           // Mean signed percentage error; positive = estimates too low
+	Steps        map[domain.StepName]*StepCalibration
+}
+
+// StepCalibration summarizes estimate accuracy for one step
+type StepCalibration struct {
+	StepName     domain.StepName
+	Samples      int
+	AvgPredicted time.Duration
+	AvgActual    time.Duration
+	MeanAbsError float64
+	Bias         float64
+}
+
+// insertEstimate records a prediction made when an execution started
+func insertEstimate(ctx context.Context, tx *sql.Tx, execID string, step domain.StepName, predicted, actual time.Duration, status string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO estimates (execution_id, step_name, predicted_ms, actual_ms, status)
+		VALUES (?, ?, ?, ?, ?)
+	`, execID, string(step), predicted.Milliseconds(), actual.Milliseconds(), status)
+	if err != nil {
+		return fmt.Errorf("failed to insert estimate: %w", err)
+	}
+	return nil
+}
+
+// GetStepEstimates builds an exponentially weighted estimate per step from
+// the durations of successful runs, oldest first
+func (s *SQLiteStorage) GetStepEstimates(ctx context.Context) (map[domain.StepName]domain.Estimate, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT step_name, duration_ms
+		FROM step_executions
+		WHERE status = 'success' AND duration_ms > 0
+		ORDER BY start_time
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query step durations: %w", err)
+	}
+	defer rows.Close()
+
+	estimates := make(map[domain.StepName]domain.Estimate)
+	for rows.Next() {
+		var name string
+		var ms int64
+		if err := rows.Scan(&name, &ms); err != nil {
+			return nil, err
+		}
+		step := domain.StepName(name)
+		estimates[step] = estimates[step].Observe(time.Duration(ms) * time.Millisecond)
+	}
+
+	return estimates, rows.Err()
+}
+
+// GetCalibration compares recorded predictions with actual durations for
+// recent completed stories and their successful steps
+func (s *SQLiteStorage) GetCalibration(ctx context.Context) (*Calibration, error) {
+	cal := &Calibration{Steps: make(map[domain.StepName]*StepCalibration)}
+
+	stories, err := s.queryEstimates(ctx, `
+		SELECT x.execution_id, e.story_key, x.step_name, e.start_time, x.predicted_ms, x.actual_ms
+		FROM estimates x JOIN executions e ON e.id = x.execution_id
+		WHERE x.step_name = '' AND x.status = 'completed'
+		ORDER BY e.start_time DESC
+		LIMIT ?
+	`, CalibrationWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	// Oldest first, so the trend reads left to right
+	for i := len(stories) - 1; i >= 0; i-- {
+		cal.Stories = append(cal.Stories, stories[i])
+	}
+	cal.MeanAbsError, cal.Bias = errorSummary(cal.Stories)
+
+	steps, err := s.queryEstimates(ctx, `
+		SELECT x.execution_id, e.story_key, x.step_name, e.start_time, x.predicted_ms, x.actual_ms
+		FROM estimates x JOIN executions e ON e.id = x.execution_id
+		WHERE x.step_name != '' AND x.status = 'success'
+		ORDER BY e.start_time DESC
+		LIMIT ?
+	`, CalibrationWindow*len(domain.AllSteps()))
+	if err != nil {
+		return nil, err
+	}
+
+	byStep := make(map[domain.StepName][]*EstimateRecord)
+	for _, rec := range steps {
+		byStep[rec.StepName] = append(byStep[rec.StepName], rec)
+	}
+	for name, recs := range byStep {
+		sc := &StepCalibration{StepName: name, Samples: len(recs)}
+		var predicted, actual time.Duration
+		for _, rec := range recs {
+			predicted += rec.Predicted
+			actual += rec.Actual
+		}
+		sc.AvgPredicted = predicted / time.Duration(len(recs))
+		sc.AvgActual = actual / time.Duration(len(recs))
+		sc.MeanAbsError, sc.Bias = errorSummary(recs)
+		cal.Steps[name] = sc
+	}
+
+	return cal, nil
+}
+
+// queryEstimates runs a query returning estimate rows
+func (s *SQLiteStorage) queryEstimates(ctx context.Context, query string, args ...interface{}) ([]*EstimateRecord, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query estimates: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*EstimateRecord
+	for rows.Next() {
+		var rec EstimateRecord
+		var step, startTime string
+		var predictedMs, actualMs int64
+		if err := rows.Scan(&rec.ExecutionID, &rec.StoryKey, &step, &startTime, &predictedMs, &actualMs); err != nil {
+			return nil, err
+		}
+		rec.StepName = domain.StepName(step)
+		rec.StartTime, _ = time.Parse(time.RFC3339, startTime)
+		rec.Predicted = time.Duration(predictedMs) * time.Millisecond
+		rec.Actual = time.Duration(actualMs) * time.Millisecond
+		records = append(records, &rec)
+	}
+
+	return records, rows.Err()
+}
+
+// errorSummary returns the mean absolute and mean signed percentage error
+func errorSummary(records []*EstimateRecord) (meanAbs, bias float64) {
+	if len(records) == 0 {
+		return 0, 0
+	}
+	for _, rec := range records {
+		pct := rec.PercentError()
+		meanAbs += math.Abs(pct)
+		bias += pct
+	}
+	n := float64(len(records))
+	return meanAbs / n, bias / n
+}
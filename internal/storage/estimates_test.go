@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_GetStepEstimates(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	story := createTestStory("1-1-test", 1, domain.StatusDone)
+	for _, d := range []time.Duration{time.Minute, 2 * time.Minute} {
+		exec := createCompletedExecution(story)
+		for _, step := range exec.Steps {
+			step.Duration = d
+		}
+		require.NoError(t, s.SaveExecution(ctx, exec))
+	}
+
+	estimates, err := s.GetStepEstimates(ctx)
+	require.NoError(t, err)
+
+	dev := estimates[domain.StepDevStory]
+	assert.Equal(t, 2, dev.Samples)
+	assert.Equal(t, domain.EstimateFrom([]time.Duration{time.Minute, 2 * time.Minute}).Mean, dev.Mean)
+}
+
+func TestSQLiteStorage_GetCalibration(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	t.Run("no predictions", func(t *testing.T) {
+		cal, err := s.GetCalibration(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, cal.Stories)
+		assert.Empty(t, cal.Steps)
+	})
+
+	story := createTestStory("1-1-test", 1, domain.StatusDone)
+
+	// Predicted 4m, took 5m
+	slow := createCompletedExecution(story)
+	slow.StartTime = time.Now().Add(-time.Hour)
+	slow.Predicted = 4 * time.Minute
+	slow.Duration = 5 * time.Minute
+	for _, step := range slow.Steps {
+		step.Predicted = 30 * time.Second
+	}
+	require.NoError(t, s.SaveExecution(ctx, slow))
+
+	// Predicted 4m, took 3m
+	fast := createCompletedExecution(story)
+	fast.Predicted = 4 * time.Minute
+	fast.Duration = 3 * time.Minute
+	require.NoError(t, s.SaveExecution(ctx, fast))
+
+	// Failed runs are not part of the calibration
+	failed := createCompletedExecution(story)
+	failed.Status = domain.ExecutionFailed
+	failed.Predicted = time.Minute
+	require.NoError(t, s.SaveExecution(ctx, failed))
+
+	cal, err := s.GetCalibration(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, cal.Stories, 2)
+	assert.Equal(t, slow.ID, cal.Stories[0].ExecutionID, "oldest first")
+	assert.InDelta(t, 25.0, cal.Stories[0].PercentError(), 0.001)
+	assert.InDelta(t, 25.0, cal.MeanAbsError, 0.001)
+	assert.InDelta(t, 0.0, cal.Bias, 0.001)
+
+	dev := cal.Steps[domain.StepDevStory]
+	require.NotNil(t, dev)
+	assert.Equal(t, 1, dev.Samples)
+	assert.Equal(t, 30*time.Second, dev.AvgPredicted)
+	assert.Equal(t, time.Minute, dev.AvgActual)
+	assert.InDelta(t, 100.0, dev.Bias, 0.001)
+}
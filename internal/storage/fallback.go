@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sidecarSuffixes are the extra files SQLite keeps next to a database
+var sidecarSuffixes = []string{"-wal", "-shm", "-journal"}
+
+// OpenWithFallback opens the SQLite database at dbPath. If it cannot be
+// opened (locked, corrupt, unwritable) it returns an in-memory storage along
+// with the original error, so the app keeps working without persistence.
+func OpenWithFallback(dbPath string) (*SQLiteStorage, error) {
+	s, err := NewSQLiteStorage(dbPath)
+	if err == nil {
+		return s, nil
+	}
+
+	mem, memErr := NewInMemoryStorage()
+	if memErr != nil {
+		return nil, fmt.Errorf("failed to open in-memory fallback: %w (database error: %v)", memErr, err)
+	}
+	return mem, err
+}
+
+// IsLockedError reports whether err means another process holds the database
+func IsLockedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "sqlite_busy")
+}
+
+// IsCorruptError reports whether err means the database file is damaged or
+// is not a SQLite database at all
+func IsCorruptError(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	// Extended result codes keep the primary code in the low byte
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_CORRUPT, sqlite3.SQLITE_NOTADB:
+		return true
+	}
+	return false
+}
+
+// RepairDatabase tries to reopen dbPath. If the database is corrupt, either
+// failing to open with SQLITE_CORRUPT/SQLITE_NOTADB or failing its integrity
+// check, the file and its WAL/SHM sidecars are moved aside to
+// <dbPath>.corrupt-<timestamp> and a fresh database is created in its place.
+// Any other error, such as a lock or a permission problem, is returned with
+// the file left where it is. It returns the new storage and the backup path
+// ("" if nothing was moved).
+func RepairDatabase(dbPath string) (*SQLiteStorage, string, error) {
+	s, err := NewSQLiteStorage(dbPath)
+	if err == nil {
+		problems, checkErr := s.integrityProblems(context.Background())
+		if checkErr == nil && len(problems) == 0 {
+			return s, "", nil
+		}
+		s.Close()
+		if checkErr != nil && !IsCorruptError(checkErr) {
+			return nil, "", fmt.Errorf("failed to check integrity: %w", checkErr)
+		}
+	} else if !IsCorruptError(err) {
+		if IsLockedError(err) {
+			return nil, "", fmt.Errorf("database is locked by another process: %w", err)
+		}
+		return nil, "", err
+	}
+
+	backup := fmt.Sprintf("%s.corrupt-%s", dbPath, time.Now().Format("20060102-150405"))
+	if err := os.Rename(dbPath, backup); err != nil && !os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("failed to move database aside: %w", err)
+	}
+	for _, suffix := range sidecarSuffixes {
+		if err := os.Rename(dbPath+suffix, backup+suffix); err != nil && !os.IsNotExist(err) {
+			return nil, "", fmt.Errorf("failed to move %s aside: %w", suffix, err)
+		}
+	}
+
+	s, err = NewSQLiteStorage(dbPath)
+	if err != nil {
+		return nil, backup, fmt.Errorf("failed to create fresh database: %w", err)
+	}
+	return s, backup, nil
+}
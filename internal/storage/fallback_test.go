@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCorruptDatabase(t *testing.T) string {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "bmad.db")
+	require.NoError(t, os.WriteFile(dbPath, []byte("this is not a sqlite database, just garbage bytes"), 0644))
+	return dbPath
+}
+
+func TestOpenWithFallback(t *testing.T) {
+	t.Run("opens a healthy database", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "bmad.db")
+
+		s, err := OpenWithFallback(dbPath)
+		require.NoError(t, err)
+		defer s.Close()
+
+		_, statErr := os.Stat(dbPath)
+		assert.NoError(t, statErr)
+	})
+
+	t.Run("falls back to memory for a corrupt database", func(t *testing.T) {
+		dbPath := writeCorruptDatabase(t)
+
+		s, err := OpenWithFallback(dbPath)
+		assert.Error(t, err)
+		require.NotNil(t, s)
+		defer s.Close()
+
+		// The fallback is fully usable
+		require.NoError(t, s.SetState(context.Background(), "ui.test", []byte("ok")))
+	})
+}
+
+func TestIsLockedError(t *testing.T) {
+	assert.False(t, IsLockedError(nil))
+	assert.True(t, IsLockedError(errors.New("failed to set pragma: database is locked (5) (SQLITE_BUSY)")))
+	assert.False(t, IsLockedError(errors.New("file is not a database")))
+}
+
+func TestRepairDatabase(t *testing.T) {
+	t.Run("reopens a healthy database without moving it", func(t *testing.T) {
+		dbPath := filepath.Join(t.TempDir(), "bmad.db")
+		s, err := NewSQLiteStorage(dbPath)
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+
+		repaired, backup, err := RepairDatabase(dbPath)
+		require.NoError(t, err)
+		defer repaired.Close()
+		assert.Empty(t, backup)
+	})
+
+	t.Run("moves a corrupt database aside", func(t *testing.T) {
+		dbPath := writeCorruptDatabase(t)
+
+		repaired, backup, err := RepairDatabase(dbPath)
+		require.NoError(t, err)
+		defer repaired.Close()
+
+		assert.NotEmpty(t, backup)
+		data, err := os.ReadFile(backup)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "garbage bytes")
+
+		count, err := repaired.CountExecutions(context.Background(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("leaves a database it cannot open but is not corrupt", func(t *testing.T) {
+		// A directory cannot be opened, which is not a sign of corruption
+		dbPath := filepath.Join(t.TempDir(), "bmad.db")
+		require.NoError(t, os.Mkdir(dbPath, 0755))
+
+		repaired, backup, err := RepairDatabase(dbPath)
+		assert.Error(t, err)
+		assert.Nil(t, repaired)
+		assert.Empty(t, backup)
+
+		info, statErr := os.Stat(dbPath)
+		require.NoError(t, statErr)
+		assert.True(t, info.IsDir())
+	})
+}
+
+func TestIsCorruptError(t *testing.T) {
+	assert.False(t, IsCorruptError(nil))
+	assert.False(t, IsCorruptError(errors.New("file is not a database")))
+
+	_, err := NewSQLiteStorage(writeCorruptDatabase(t))
+	require.Error(t, err)
+	assert.True(t, IsCorruptError(err))
+}
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GroupBy selects how GroupExecutions aggregates history
+type GroupBy string
+
+const (
+	GroupByStory GroupBy = "story"
+	GroupByDay   GroupBy = "day"
+	GroupByEpic  GroupBy = "epic"
+)
+
+// ExecutionGroup holds aggregate statistics for one group of executions
+type ExecutionGroup struct {
+	Key           string // Story key, "2006-01-02" day or epic number
+	Count         int
+	SuccessCount  int
+	FailedCount   int
+	SuccessRate   float64
+	AvgDuration   time.Duration
+	TotalDuration time.Duration
+	LastRun       time.Time
+}
+
+// groupExpr returns the SQL expression executions are grouped on. Days
+// come from the stored start time, so they are in the recording timezone.
+func groupExpr(by GroupBy) (string, error) {
+	switch by {
+	case GroupByStory:
+		return "story_key", nil
+	case GroupByDay:
+		return "substr(start_time, 1, 10)", nil
+	case GroupByEpic:
+		return "CAST(story_epic AS TEXT)", nil
+	default:
+		return "", fmt.Errorf("unknown history grouping %q", by)
+	}
+}
+
+// GroupExecutions aggregates the executions matching filter by story, day
+// or epic, most recently run group first. filter.Limit caps the number of
+// groups; filter.GroupBy and GroupKey are ignored.
+func (s *SQLiteStorage) GroupExecutions(ctx context.Context, by GroupBy, filter *ExecutionFilter) ([]*ExecutionGroup, error) {
+	expr, err := groupExpr(by)
+	if err != nil {
+		return nil, err
+	}
+
+	var f ExecutionFilter
+	if filter != nil {
+		f = *filter
+	}
+	f.GroupBy, f.GroupKey = "", ""
+
+	query := fmt.Sprintf(`
+		SELECT %s AS group_key,
+			COUNT(*),
+			SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN status = 'failed' THEN 1 ELSE 0 END),
+			COALESCE(AVG(duration_ms), 0),
+			COALESCE(SUM(duration_ms), 0),
+			MAX(start_time)
+		FROM executions
+	`, expr)
+	where, args := buildWhereClause(&f)
+	if where != "" {
+		query += " WHERE " + where
+	}
+	query += " GROUP BY group_key ORDER BY MAX(start_time) DESC"
+
+	limit := f.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", limit, f.Offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to group executions: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*ExecutionGroup
+	for rows.Next() {
+		var g ExecutionGroup
+		var avgMs float64
+		var totalMs int64
+		var lastRun string
+		if err := rows.Scan(&g.Key, &g.Count, &g.SuccessCount, &g.FailedCount, &avgMs, &totalMs, &lastRun); err != nil {
+			return nil, err
+		}
+		if g.Count > 0 {
+			g.SuccessRate = float64(g.SuccessCount) / float64(g.Count) * 100
+		}
+		g.AvgDuration = time.Duration(avgMs) * time.Millisecond
+		g.TotalDuration = time.Duration(totalMs) * time.Millisecond
+		g.LastRun, _ = time.Parse(time.RFC3339, lastRun)
+		groups = append(groups, &g)
+	}
+
+	return groups, rows.Err()
+}
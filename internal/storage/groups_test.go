@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_GroupExecutions(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	day1 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	save := func(key string, epic int, status domain.ExecutionStatus, start time.Time, d time.Duration) {
+		exec := createCompletedExecution(createTestStory(key, epic, domain.StatusInProgress))
+		exec.Status = status
+		exec.StartTime = start
+		exec.Duration = d
+		require.NoError(t, s.SaveExecution(ctx, exec))
+	}
+	save("1-1-a", 1, domain.ExecutionFailed, day1, 2*time.Minute)
+	save("1-1-a", 1, domain.ExecutionCompleted, day1.Add(time.Hour), 4*time.Minute)
+	save("1-2-b", 1, domain.ExecutionCompleted, day2, 6*time.Minute)
+	save("2-1-c", 2, domain.ExecutionCancelled, day2.Add(time.Hour), time.Minute)
+
+	t.Run("by story", func(t *testing.T) {
+		groups, err := s.GroupExecutions(ctx, GroupByStory, nil)
+		require.NoError(t, err)
+		require.Len(t, groups, 3)
+		assert.Equal(t, []string{"2-1-c", "1-2-b", "1-1-a"}, groupKeys(groups), "most recent first")
+
+		a := groups[2]
+		assert.Equal(t, 2, a.Count)
+		assert.Equal(t, 1, a.SuccessCount)
+		assert.Equal(t, 1, a.FailedCount)
+		assert.InDelta(t, 50.0, a.SuccessRate, 0.01)
+		assert.Equal(t, 3*time.Minute, a.AvgDuration)
+		assert.Equal(t, 6*time.Minute, a.TotalDuration)
+		assert.True(t, a.LastRun.Equal(day1.Add(time.Hour)))
+	})
+
+	t.Run("by day and epic", func(t *testing.T) {
+		groups, err := s.GroupExecutions(ctx, GroupByDay, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2026-03-02", "2026-03-01"}, groupKeys(groups))
+
+		groups, err = s.GroupExecutions(ctx, GroupByEpic, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2", "1"}, groupKeys(groups))
+		assert.Equal(t, 3, groups[1].Count)
+	})
+
+	t.Run("applies the filter", func(t *testing.T) {
+		groups, err := s.GroupExecutions(ctx, GroupByDay, &ExecutionFilter{Status: domain.ExecutionCompleted})
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+		assert.Equal(t, 1, groups[0].Count)
+	})
+
+	t.Run("lists one group", func(t *testing.T) {
+		records, err := s.ListExecutions(ctx, &ExecutionFilter{GroupBy: GroupByStory, GroupKey: "1-1-a"})
+		require.NoError(t, err)
+		assert.Len(t, records, 2)
+
+		count, err := s.CountExecutions(ctx, &ExecutionFilter{GroupBy: GroupByDay, GroupKey: "2026-03-02"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("unknown grouping", func(t *testing.T) {
+		_, err := s.GroupExecutions(ctx, "week", nil)
+		assert.Error(t, err)
+	})
+}
+
+func groupKeys(groups []*ExecutionGroup) []string {
+	keys := make([]string, len(groups))
+	for i, g := range groups {
+		keys[i] = g.Key
+	}
+	return keys
+}
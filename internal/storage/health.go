@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// GetStoryHealth scores every story with history from its most recent
+// runs, keyed by story key
+func (s *SQLiteStorage) GetStoryHealth(ctx context.Context) (map[string]domain.StoryHealth, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT e.story_key, e.status, e.duration_ms,
+			COALESCE(x.predicted_ms, 0),
+			EXISTS (SELECT 1 FROM step_executions s WHERE s.execution_id = e.id AND s.attempt > 1)
+		FROM (
+			SELECT id, story_key, status, duration_ms, start_time,
+				ROW_NUMBER() OVER (PARTITION BY story_key ORDER BY start_time DESC) AS recent
+			FROM executions
+			WHERE status IN ('completed', 'failed', 'conflict', 'cancelled')
+		) e
+		LEFT JOIN estimates x ON x.execution_id = e.id AND x.step_name = ''
+		WHERE e.recent <= ?
+		ORDER BY e.story_key, e.start_time DESC
+	`, domain.HealthWindow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story health: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make(map[string][]domain.HealthRun)
+	for rows.Next() {
+		var key string
+		var run domain.HealthRun
+		var durationMs, predictedMs int64
+		if err := rows.Scan(&key, &run.Status, &durationMs, &predictedMs, &run.Retried); err != nil {
+			return nil, err
+		}
+		run.Duration = time.Duration(durationMs) * time.Millisecond
+		run.Predicted = time.Duration(predictedMs) * time.Millisecond
+		runs[key] = append(runs[key], run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	health := make(map[string]domain.StoryHealth, len(runs))
+	for key, storyRuns := range runs {
+		health[key] = domain.NewStoryHealth(storyRuns)
+	}
+	return health, nil
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_GetStoryHealth(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	t.Run("no history", func(t *testing.T) {
+		health, err := s.GetStoryHealth(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, health)
+	})
+
+	flaky := createTestStory("1-1-flaky", 1, domain.StatusInProgress)
+
+	failed := createCompletedExecution(flaky)
+	failed.StartTime = time.Now().Add(-3 * time.Hour)
+	failed.Status = domain.ExecutionFailed
+	require.NoError(t, s.SaveExecution(ctx, failed))
+
+	retried := createCompletedExecution(flaky)
+	retried.StartTime = time.Now().Add(-2 * time.Hour)
+	retried.Steps[0].Attempt = 2
+	retried.Predicted = 10 * time.Minute
+	retried.Duration = 15 * time.Minute
+	require.NoError(t, s.SaveExecution(ctx, retried))
+
+	cancelled := createCompletedExecution(flaky)
+	cancelled.StartTime = time.Now().Add(-time.Hour)
+	cancelled.Status = domain.ExecutionCancelled
+	require.NoError(t, s.SaveExecution(ctx, cancelled))
+
+	stable := createTestStory("1-2-stable", 1, domain.StatusDone)
+	require.NoError(t, s.SaveExecution(ctx, createCompletedExecution(stable)))
+
+	health, err := s.GetStoryHealth(ctx)
+	require.NoError(t, err)
+	require.Len(t, health, 2)
+
+	h := health["1-1-flaky"]
+	assert.Equal(t, 2, h.Runs)
+	assert.Equal(t, 0.5, h.FailureRate)
+	assert.Equal(t, 0.5, h.Flakiness)
+	assert.Equal(t, 0.5, h.Overrun)
+	assert.Equal(t, 50, h.Score)
+
+	assert.Equal(t, 100, health["1-2-stable"].Score)
+
+	t.Run("only recent runs count", func(t *testing.T) {
+		for i := 0; i < domain.HealthWindow; i++ {
+			exec := createCompletedExecution(flaky)
+			exec.StartTime = time.Now().Add(time.Duration(i) * time.Minute)
+			require.NoError(t, s.SaveExecution(ctx, exec))
+		}
+
+		health, err := s.GetStoryHealth(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, domain.HealthWindow, health["1-1-flaky"].Runs)
+		assert.Equal(t, 100, health["1-1-flaky"].Score)
+	})
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// MinExecutionIDPrefix is the shortest ID prefix ResolveExecutionID accepts
+const MinExecutionIDPrefix = 6
+
+var (
+	// ErrExecutionNotFound is returned when no execution matches an ID
+	ErrExecutionNotFound = errors.New("execution not found")
+
+	// ErrAmbiguousExecutionID is returned when an ID prefix matches
+	// more than one execution
+	ErrAmbiguousExecutionID = errors.New("execution ID prefix is ambiguous")
+)
+
+// ResolveExecutionID expands a full or shortened execution ID (at least
+// MinExecutionIDPrefix characters) to the full ID of a stored execution
+func (s *SQLiteStorage) ResolveExecutionID(ctx context.Context, idOrPrefix string) (string, error) {
+	if len(idOrPrefix) < MinExecutionIDPrefix {
+		return "", fmt.Errorf("execution ID %q is too short (need at least %d characters)", idOrPrefix, MinExecutionIDPrefix)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id FROM executions
+		WHERE id LIKE ? ESCAPE '\'
+		LIMIT 2
+	`, escapeLikeWildcards(idOrPrefix)+"%")
+	if err != nil {
+		return "", fmt.Errorf("failed to look up execution: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return "", err
+		}
+		if id == idOrPrefix {
+			return id, nil
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", ErrExecutionNotFound, idOrPrefix)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrAmbiguousExecutionID, idOrPrefix)
+	}
+}
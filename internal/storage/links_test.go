@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_ResolveExecutionID(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	story := createTestStory("3-1-test", 3, domain.StatusInProgress)
+	for _, id := range []string{"abcdef12-0001", "abcdef34-0002"} {
+		exec := createMinimalExecution(story)
+		exec.ID = id
+		require.NoError(t, s.SaveExecution(ctx, exec))
+	}
+
+	t.Run("full ID", func(t *testing.T) {
+		id, err := s.ResolveExecutionID(ctx, "abcdef12-0001")
+		require.NoError(t, err)
+		assert.Equal(t, "abcdef12-0001", id)
+	})
+
+	t.Run("unique prefix", func(t *testing.T) {
+		id, err := s.ResolveExecutionID(ctx, "abcdef3")
+		require.NoError(t, err)
+		assert.Equal(t, "abcdef34-0002", id)
+	})
+
+	t.Run("ambiguous prefix", func(t *testing.T) {
+		_, err := s.ResolveExecutionID(ctx, "abcdef")
+		assert.True(t, errors.Is(err, ErrAmbiguousExecutionID))
+	})
+
+	t.Run("unknown ID", func(t *testing.T) {
+		_, err := s.ResolveExecutionID(ctx, "ffffffff")
+		assert.True(t, errors.Is(err, ErrExecutionNotFound))
+	})
+
+	t.Run("prefix too short", func(t *testing.T) {
+		_, err := s.ResolveExecutionID(ctx, "abc")
+		assert.Error(t, err)
+	})
+
+	t.Run("wildcards are literal", func(t *testing.T) {
+		_, err := s.ResolveExecutionID(ctx, "abcdef%_")
+		assert.True(t, errors.Is(err, ErrExecutionNotFound))
+	})
+}
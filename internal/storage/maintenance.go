@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaintenanceResult reports what Maintenance did
+type MaintenanceResult struct {
+	SizeBefore int64 // Database size in bytes, free pages included
+	SizeAfter  int64
+	// Problems lists what integrity_check found; when it is not empty the
+	// database was left as it was
+	Problems []string
+}
+
+// Reclaimed returns how many bytes VACUUM gave back
+func (r *MaintenanceResult) Reclaimed() int64 {
+	if r.SizeAfter >= r.SizeBefore {
+		return 0
+	}
+	return r.SizeBefore - r.SizeAfter
+}
+
+// OK reports whether the integrity check passed
+func (r *MaintenanceResult) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Maintenance checks the database's integrity, then rebuilds it with VACUUM
+// to give free pages back to the filesystem and refreshes the query
+// planner's statistics with ANALYZE. A database that fails the check is not
+// rebuilt, since VACUUM could lose what a repair might still recover.
+func (s *SQLiteStorage) Maintenance(ctx context.Context) (*MaintenanceResult, error) {
+	before, err := s.fileBytes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database size: %w", err)
+	}
+	result := &MaintenanceResult{SizeBefore: before, SizeAfter: before}
+
+	if result.Problems, err = s.integrityProblems(ctx); err != nil {
+		return nil, fmt.Errorf("failed to check integrity: %w", err)
+	}
+	if !result.OK() {
+		return result, nil
+	}
+
+	for _, stmt := range []string{"VACUUM", "ANALYZE", "PRAGMA wal_checkpoint(TRUNCATE)"} {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return nil, fmt.Errorf("failed to run %s: %w", stmt, err)
+		}
+	}
+
+	if result.SizeAfter, err = s.fileBytes(ctx); err != nil {
+		return nil, fmt.Errorf("failed to read database size: %w", err)
+	}
+	return result, nil
+}
+
+// integrityProblems runs integrity_check and returns every line it
+// reported other than "ok"
+func (s *SQLiteStorage) integrityProblems(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	return problems, rows.Err()
+}
+
+// fileBytes returns the size of the database, free pages included
+func (s *SQLiteStorage) fileBytes(ctx context.Context) (int64, error) {
+	var pages, pageSize int64
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pages); err != nil {
+		return 0, err
+	}
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, err
+	}
+	return pages * pageSize, nil
+}
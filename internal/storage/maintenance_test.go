@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStorage_Maintenance(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "bmad.db"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	saveExecutions(t, s, 40, 50)
+	_, err = s.Prune(ctx, RetentionPolicy{MaxRows: 1})
+	require.NoError(t, err)
+
+	result, err := s.Maintenance(ctx)
+	require.NoError(t, err)
+	assert.True(t, result.OK())
+	assert.Greater(t, result.Reclaimed(), int64(0), "pruned pages are given back")
+	assert.Equal(t, result.SizeBefore-result.SizeAfter, result.Reclaimed())
+
+	records, err := s.ListExecutions(ctx, &ExecutionFilter{})
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	t.Run("nothing to reclaim", func(t *testing.T) {
+		result, err := s.Maintenance(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.OK())
+		assert.Zero(t, result.Reclaimed())
+	})
+}
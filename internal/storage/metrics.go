@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// MetricsBuckets are the upper bounds of the step duration histogram
+var MetricsBuckets = []time.Duration{
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	20 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+}
+
+// Metrics holds the running totals of everything recorded in history
+type Metrics struct {
+	Executions map[domain.ExecutionStatus]int
+	Steps      map[domain.StepName]*StepMetrics
+}
+
+// StepMetrics holds the totals for one step. Skipped runs are counted in
+// Runs but left out of the duration histogram.
+type StepMetrics struct {
+	Runs     map[domain.StepStatus]int
+	Retries  int           // Attempts beyond the first
+	Buckets  []int         // Cumulative run counts per MetricsBuckets bound
+	Count    int           // Runs in the histogram
+	Duration time.Duration // Total duration of the runs in the histogram
+}
+
+// GetMetrics totals executions by status, and step runs, retries and
+// durations by step
+func (s *SQLiteStorage) GetMetrics(ctx context.Context) (*Metrics, error) {
+	m := &Metrics{
+		Executions: make(map[domain.ExecutionStatus]int),
+		Steps:      make(map[domain.StepName]*StepMetrics),
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT status, COUNT(*) FROM executions GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count executions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		m.Executions[domain.ExecutionStatus(status)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	buckets := make([]string, len(MetricsBuckets))
+	args := make([]any, len(MetricsBuckets))
+	for i, bound := range MetricsBuckets {
+		buckets[i] = "SUM(CASE WHEN duration_ms <= ? THEN 1 ELSE 0 END)"
+		args[i] = bound.Milliseconds()
+	}
+	stepRows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT step_name, status, COUNT(*), COALESCE(SUM(duration_ms), 0),
+			COALESCE(SUM(CASE WHEN attempt > 1 THEN attempt - 1 ELSE 0 END), 0), %s
+		FROM step_executions
+		GROUP BY step_name, status
+	`, strings.Join(buckets, ", ")), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get step metrics: %w", err)
+	}
+	defer stepRows.Close()
+	for stepRows.Next() {
+		var name, status string
+		var count, retries int
+		var totalMs int64
+		counts := make([]int, len(MetricsBuckets))
+		dest := []any{&name, &status, &count, &totalMs, &retries}
+		for i := range counts {
+			dest = append(dest, &counts[i])
+		}
+		if err := stepRows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		sm := m.Steps[domain.StepName(name)]
+		if sm == nil {
+			sm = &StepMetrics{
+				Runs:    make(map[domain.StepStatus]int),
+				Buckets: make([]int, len(MetricsBuckets)),
+			}
+			m.Steps[domain.StepName(name)] = sm
+		}
+		sm.Runs[domain.StepStatus(status)] += count
+		sm.Retries += retries
+		if domain.StepStatus(status) == domain.StepSkipped {
+			continue
+		}
+		sm.Count += count
+		sm.Duration += time.Duration(totalMs) * time.Millisecond
+		for i, c := range counts {
+			sm.Buckets[i] += c
+		}
+	}
+	return m, stepRows.Err()
+}
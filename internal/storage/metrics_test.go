@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_GetMetrics(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	done := createCompletedExecution(createTestStory("1-1-test", 1, domain.StatusDone))
+	done.Steps[0].Status = domain.StepSkipped
+	done.Steps[0].Duration = 0
+	done.Steps[1].Attempt = 3
+	done.Steps[1].Duration = 45 * time.Second
+	require.NoError(t, s.SaveExecution(ctx, done))
+
+	failed := createCompletedExecution(createTestStory("1-2-test", 1, domain.StatusDone))
+	failed.Status = domain.ExecutionFailed
+	failed.Steps[1].Status = domain.StepFailed
+	failed.Steps[1].Duration = 90 * time.Second
+	require.NoError(t, s.SaveExecution(ctx, failed))
+
+	m, err := s.GetMetrics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.Executions[domain.ExecutionCompleted])
+	assert.Equal(t, 1, m.Executions[domain.ExecutionFailed])
+
+	create := m.Steps[domain.StepCreateStory]
+	require.NotNil(t, create)
+	assert.Equal(t, 1, create.Runs[domain.StepSkipped])
+	assert.Equal(t, 1, create.Count, "skipped runs are not in the histogram")
+
+	dev := m.Steps[domain.StepDevStory]
+	require.NotNil(t, dev)
+	assert.Equal(t, 1, dev.Runs[domain.StepSuccess])
+	assert.Equal(t, 1, dev.Runs[domain.StepFailed])
+	assert.Equal(t, 2, dev.Retries)
+	assert.Equal(t, 2, dev.Count)
+	assert.Equal(t, 135*time.Second, dev.Duration)
+	assert.Equal(t, []int{0, 0, 1, 2, 2, 2, 2, 2, 2}, dev.Buckets)
+}
@@ -0,0 +1,515 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// migration is one numbered change to the schema. Databases created before
+// versions were tracked report version 1 whatever they hold, so Up uses IF
+// NOT EXISTS throughout.
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string // Undoes Up; run by tests moving between versions
+
+	// Migrate moves existing rows after Up when SQL alone cannot, and
+	// Revert moves them back before Down. Both are optional.
+	Migrate func(ctx context.Context, tx *sql.Tx) error
+	Revert  func(ctx context.Context, tx *sql.Tx) error
+}
+
+// migrations are applied in order. Add new ones at the end with the next
+// version; never edit one that has shipped.
+var migrations = []migration{
+	{
+		Version: 1,
+		Name:    "initial", // Executions, steps and their output
+		Up: `
+CREATE TABLE IF NOT EXISTS executions (
+    id TEXT PRIMARY KEY,
+    story_key TEXT NOT NULL,
+    story_epic INTEGER NOT NULL,
+    story_status TEXT NOT NULL,
+    story_title TEXT,
+    status TEXT NOT NULL,
+    start_time TEXT NOT NULL,
+    end_time TEXT,
+    duration_ms INTEGER DEFAULT 0,
+    error TEXT,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE TABLE IF NOT EXISTS step_executions (
+    id TEXT PRIMARY KEY,
+    execution_id TEXT NOT NULL,
+    step_name TEXT NOT NULL,
+    status TEXT NOT NULL,
+    start_time TEXT,
+    end_time TEXT,
+    duration_ms INTEGER DEFAULT 0,
+    attempt INTEGER DEFAULT 1,
+    command TEXT,
+    error TEXT,
+    output_size INTEGER DEFAULT 0,
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+
+` + stepOutputsTable + `
+CREATE TABLE IF NOT EXISTS step_averages (
+    step_name TEXT PRIMARY KEY,
+    avg_duration_ms INTEGER NOT NULL,
+    success_count INTEGER DEFAULT 0,
+    failure_count INTEGER DEFAULT 0,
+    total_count INTEGER DEFAULT 0,
+    last_updated TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_executions_story_key ON executions(story_key);
+CREATE INDEX IF NOT EXISTS idx_executions_status ON executions(status);
+CREATE INDEX IF NOT EXISTS idx_executions_start_time ON executions(start_time DESC);
+CREATE INDEX IF NOT EXISTS idx_executions_created_at ON executions(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_step_executions_execution_id ON step_executions(execution_id);
+CREATE INDEX IF NOT EXISTS idx_step_executions_step_name ON step_executions(step_name);
+`,
+		Down: `
+DROP TABLE IF EXISTS step_averages;
+DROP TABLE IF EXISTS step_outputs;
+DROP TABLE IF EXISTS step_executions;
+DROP TABLE IF EXISTS executions;
+`,
+	},
+	{
+		Version: 2,
+		Name:    "app_state", // Key/value UI state, queue snapshots and schedules
+		Up: `
+CREATE TABLE IF NOT EXISTS app_state (
+    key TEXT PRIMARY KEY,
+    value BLOB NOT NULL,
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS app_state;
+`,
+	},
+	{
+		Version: 3,
+		Name:    "estimates", // Predicted against actual durations
+		Up: `
+CREATE TABLE IF NOT EXISTS estimates (
+    execution_id TEXT NOT NULL,
+    step_name TEXT NOT NULL DEFAULT '',
+    predicted_ms INTEGER NOT NULL,
+    actual_ms INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    PRIMARY KEY (execution_id, step_name),
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS estimates;
+`,
+	},
+	{
+		Version: 4,
+		Name:    "snapshots", // Workspace snapshots taken before a run
+		Up: `
+CREATE TABLE IF NOT EXISTS snapshots (
+    execution_id TEXT PRIMARY KEY,
+    ref TEXT NOT NULL,
+    head TEXT NOT NULL,
+    stash TEXT NOT NULL DEFAULT '',
+    untracked TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS snapshots;
+`,
+	},
+	{
+		Version: 5,
+		Name:    "attempts", // Every attempt of a retried step
+		Up: `
+CREATE TABLE IF NOT EXISTS attempt_executions (
+    step_execution_id TEXT NOT NULL,
+    attempt INTEGER NOT NULL,
+    status TEXT NOT NULL,
+    start_time TEXT,
+    duration_ms INTEGER DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    output TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (step_execution_id, attempt),
+    FOREIGN KEY (step_execution_id) REFERENCES step_executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS attempt_executions;
+`,
+	},
+	{
+		Version: 6,
+		Name:    "execution_context", // Environment each execution ran in
+		Up: `
+CREATE TABLE IF NOT EXISTS execution_context (
+    execution_id TEXT PRIMARY KEY,
+    git_branch TEXT NOT NULL DEFAULT '',
+    git_commit TEXT NOT NULL DEFAULT '',
+    git_dirty INTEGER NOT NULL DEFAULT 0,
+    claude_version TEXT NOT NULL DEFAULT '',
+    bmad_version TEXT NOT NULL DEFAULT '',
+    os TEXT NOT NULL DEFAULT '',
+    arch TEXT NOT NULL DEFAULT '',
+    config_digest TEXT NOT NULL DEFAULT '',
+    workflow TEXT NOT NULL DEFAULT '',
+    profile TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS execution_context;
+`,
+	},
+	{
+		Version: 7,
+		Name:    "story_branches", // Branch of a branch-per-story run
+		Up: `
+CREATE TABLE IF NOT EXISTS story_branches (
+    execution_id TEXT PRIMARY KEY,
+    branch TEXT NOT NULL,
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS story_branches;
+`,
+	},
+	{
+		Version: 8,
+		Name:    "pipelines", // Pipeline runs and their stages
+		Up: `
+CREATE TABLE IF NOT EXISTS pipeline_runs (
+    id TEXT PRIMARY KEY,
+    pipeline TEXT NOT NULL,
+    status TEXT NOT NULL,
+    start_time TEXT NOT NULL,
+    end_time TEXT,
+    duration_ms INTEGER DEFAULT 0,
+    error TEXT
+);
+
+CREATE TABLE IF NOT EXISTS pipeline_stages (
+    run_id TEXT NOT NULL,
+    idx INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    workflow TEXT,
+    status TEXT NOT NULL,
+    succeeded INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    execution_ids TEXT, -- Comma-separated, in run order
+    PRIMARY KEY (run_id, idx),
+    FOREIGN KEY (run_id) REFERENCES pipeline_runs(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS pipeline_stages;
+DROP TABLE IF EXISTS pipeline_runs;
+`,
+	},
+	{
+		Version: 9,
+		Name:    "step_usage", // Token usage and cost of agent steps
+		Up: `
+CREATE TABLE IF NOT EXISTS step_usage (
+    step_execution_id TEXT PRIMARY KEY,
+    input_tokens INTEGER NOT NULL DEFAULT 0,
+    output_tokens INTEGER NOT NULL DEFAULT 0,
+    cache_read_tokens INTEGER NOT NULL DEFAULT 0,
+    cache_write_tokens INTEGER NOT NULL DEFAULT 0,
+    cost_usd REAL NOT NULL DEFAULT 0,
+    FOREIGN KEY (step_execution_id) REFERENCES step_executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS step_usage;
+`,
+	},
+	{
+		Version: 10,
+		Name:    "deadlines", // Deadline of each run and whether it was met
+		Up: `
+CREATE TABLE IF NOT EXISTS deadlines (
+    execution_id TEXT PRIMARY KEY,
+    deadline TEXT NOT NULL,
+    met INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS deadlines;
+`,
+	},
+	{
+		Version: 11,
+		Name:    "output_search", // Full-text index of step output
+		Up:      outputSearchMigration,
+		Down: `
+DROP TRIGGER IF EXISTS step_outputs_fts_delete;
+DROP TRIGGER IF EXISTS step_outputs_fts_insert;
+DROP TABLE IF EXISTS step_outputs_fts;
+`,
+	},
+	{
+		Version: 12,
+		Name:    "step_prompts", // Prompts edited before a run
+		Up: `
+CREATE TABLE IF NOT EXISTS step_prompts (
+    step_execution_id TEXT PRIMARY KEY,
+    prompt TEXT NOT NULL,
+    FOREIGN KEY (step_execution_id) REFERENCES step_executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS step_prompts;
+`,
+	},
+	{
+		Version: 13,
+		Name:    "step_output_files", // Full output of steps truncated by the size guard
+		Up: `
+CREATE TABLE IF NOT EXISTS step_output_files (
+    step_execution_id TEXT PRIMARY KEY,
+    path TEXT NOT NULL,
+    FOREIGN KEY (step_execution_id) REFERENCES step_executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS step_output_files;
+`,
+	},
+	{
+		Version: 14,
+		Name:    "compressed_output", // Step output as one gzip blob per step
+		Up:      compressedOutputMigration,
+		Down: `
+DROP TRIGGER IF EXISTS step_output_blobs_fts_delete;
+DROP TABLE IF EXISTS step_outputs_fts;
+DROP TABLE IF EXISTS step_output_blobs;
+` + outputSearchMigration,
+		Migrate: compressStepOutputs,
+		Revert:  expandStepOutputs,
+	},
+	{
+		Version: 15,
+		Name:    "execution_tags", // Labels attached at enqueue time
+		Up: `
+CREATE TABLE IF NOT EXISTS execution_tags (
+    execution_id TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (execution_id, tag),
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_execution_tags_tag ON execution_tags(tag);
+`,
+		Down: `
+DROP TABLE IF EXISTS execution_tags;
+`,
+	},
+	{
+		Version: 16,
+		Name:    "execution_notes", // Free text added from history
+		Up: `
+CREATE TABLE IF NOT EXISTS execution_notes (
+    execution_id TEXT PRIMARY KEY,
+    note TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS execution_notes;
+`,
+	},
+	{
+		Version: 17,
+		Name:    "auto_stashes", // Stashes a run could not restore
+		Up: `
+CREATE TABLE IF NOT EXISTS auto_stashes (
+    execution_id TEXT PRIMARY KEY,
+    stash_commit TEXT NOT NULL,
+    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
+);
+`,
+		Down: `
+DROP TABLE IF EXISTS auto_stashes;
+`,
+	},
+}
+
+// stepOutputsTable held step output one line per row until migration 14
+const stepOutputsTable = `
+CREATE TABLE IF NOT EXISTS step_outputs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    step_execution_id TEXT NOT NULL,
+    line_number INTEGER NOT NULL,
+    content TEXT NOT NULL,
+    is_stderr BOOLEAN DEFAULT FALSE,
+    FOREIGN KEY (step_execution_id) REFERENCES step_executions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_step_outputs_step_id ON step_outputs(step_execution_id);
+`
+
+// outputSearchMigration indexes step output for full-text search. The index
+// reads its text from step_outputs and is kept in step by triggers; it is
+// filled from the output already stored.
+const outputSearchMigration = `
+CREATE VIRTUAL TABLE IF NOT EXISTS step_outputs_fts USING fts5(
+    content,
+    content='step_outputs',
+    content_rowid='id'
+);
+
+CREATE TRIGGER IF NOT EXISTS step_outputs_fts_insert AFTER INSERT ON step_outputs BEGIN
+    INSERT INTO step_outputs_fts(rowid, content) VALUES (new.id, new.content);
+END;
+
+CREATE TRIGGER IF NOT EXISTS step_outputs_fts_delete AFTER DELETE ON step_outputs BEGIN
+    INSERT INTO step_outputs_fts(step_outputs_fts, rowid, content) VALUES ('delete', old.id, old.content);
+END;
+
+INSERT INTO step_outputs_fts(step_outputs_fts) VALUES ('rebuild');
+`
+
+// compressedOutputMigration replaces the line-per-row step_outputs with one
+// compressed blob per step. The search index keeps its own copy of the text
+// (it can no longer read it from a table) under rowids built by
+// outputRowID, and drops a blob's lines when the blob is deleted.
+// compressStepOutputs moves the existing output over.
+const compressedOutputMigration = `
+CREATE TABLE IF NOT EXISTS step_output_blobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    step_execution_id TEXT NOT NULL UNIQUE,
+    lines INTEGER NOT NULL,
+    stderr_lines INTEGER NOT NULL DEFAULT 0,
+    bytes INTEGER NOT NULL DEFAULT 0,
+    last_line TEXT NOT NULL DEFAULT '',
+    data BLOB NOT NULL,
+    FOREIGN KEY (step_execution_id) REFERENCES step_executions(id) ON DELETE CASCADE
+);
+
+DROP TRIGGER IF EXISTS step_outputs_fts_insert;
+DROP TRIGGER IF EXISTS step_outputs_fts_delete;
+DROP TABLE IF EXISTS step_outputs_fts;
+
+CREATE VIRTUAL TABLE step_outputs_fts USING fts5(
+    content,
+    content='',
+    contentless_delete=1
+);
+
+CREATE TRIGGER IF NOT EXISTS step_output_blobs_fts_delete AFTER DELETE ON step_output_blobs BEGIN
+    DELETE FROM step_outputs_fts
+    WHERE rowid >= old.id * 1048576 AND rowid < (old.id + 1) * 1048576;
+END;
+`
+
+// schemaVersionTable records each migration applied to the database
+const schemaVersionTable = `
+CREATE TABLE IF NOT EXISTS schema_version (
+    version INTEGER PRIMARY KEY,
+    applied_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+`
+
+// latestVersion returns the version of the newest migration
+func latestVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// migrate applies the pending migrations
+func (s *SQLiteStorage) migrate() error {
+	ctx := context.Background()
+	current, err := s.schemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if current > latestVersion() {
+		return fmt.Errorf("database schema version %d is newer than this build supports (%d)", current, latestVersion())
+	}
+	return s.migrateTo(ctx, latestVersion())
+}
+
+// schemaVersion returns the version of the newest migration applied, 0 for
+// a new database
+func (s *SQLiteStorage) schemaVersion(ctx context.Context) (int, error) {
+	if _, err := s.db.ExecContext(ctx, schemaVersionTable); err != nil {
+		return 0, fmt.Errorf("failed to create schema_version: %w", err)
+	}
+	var version int
+	if err := s.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// migrateTo moves the schema up or down to version, one migration per
+// transaction
+func (s *SQLiteStorage) migrateTo(ctx context.Context, version int) error {
+	current, err := s.schemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.Version > current && m.Version <= version {
+			if err := s.applyMigration(ctx, m, true); err != nil {
+				return err
+			}
+		}
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.Version <= current && m.Version > version {
+			if err := s.applyMigration(ctx, m, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyMigration runs m up or down and records it in schema_version
+func (s *SQLiteStorage) applyMigration(ctx context.Context, m migration, up bool) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %d: %w", m.Version, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	record := "INSERT INTO schema_version (version) VALUES (?)"
+	if up {
+		if _, err = tx.ExecContext(ctx, m.Up); err == nil && m.Migrate != nil {
+			err = m.Migrate(ctx, tx)
+		}
+	} else {
+		record = "DELETE FROM schema_version WHERE version = ?"
+		if m.Revert != nil {
+			err = m.Revert(ctx, tx)
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, m.Down)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to migrate %d_%s: %w", m.Version, m.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, record, m.Version); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// tableNames returns the tables in the database, sqlite's own left out
+func tableNames(t *testing.T, s *SQLiteStorage) []string {
+	t.Helper()
+	rows, err := s.db.Query(`SELECT name FROM sqlite_master
+		WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name NOT LIKE 'step_outputs_fts_%'
+		ORDER BY name`)
+	require.NoError(t, err)
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	require.NoError(t, rows.Err())
+	return names
+}
+
+func TestMigrations_Numbering(t *testing.T) {
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version, "migration %s", m.Name)
+		assert.NotEmpty(t, m.Up, "migration %d", m.Version)
+		assert.NotEmpty(t, m.Down, "migration %d", m.Version)
+	}
+}
+
+func TestSQLiteStorage_Migrate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("a new database is at the latest version", func(t *testing.T) {
+		s, err := NewInMemoryStorage()
+		require.NoError(t, err)
+		defer s.Close()
+
+		version, err := s.schemaVersion(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latestVersion(), version)
+
+		var applied int
+		require.NoError(t, s.db.QueryRow("SELECT COUNT(*) FROM schema_version").Scan(&applied))
+		assert.Equal(t, len(migrations), applied)
+	})
+
+	t.Run("every migration goes down and back up", func(t *testing.T) {
+		s, err := NewInMemoryStorage()
+		require.NoError(t, err)
+		defer s.Close()
+		latest := tableNames(t, s)
+
+		for v := latestVersion() - 1; v >= 0; v-- {
+			require.NoError(t, s.migrateTo(ctx, v), "down to %d", v)
+			version, err := s.schemaVersion(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, v, version)
+		}
+		assert.Equal(t, []string{"schema_version"}, tableNames(t, s))
+
+		require.NoError(t, s.migrate())
+		assert.Equal(t, latest, tableNames(t, s))
+		require.NoError(t, s.SaveExecution(ctx, createCompletedExecution(createTestStory("1-1-a", 1, domain.StatusDone))))
+	})
+
+	t.Run("upgrades a database from before versioning", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bmad.db")
+		s, err := NewSQLiteStorage(path)
+		require.NoError(t, err)
+		exec := createCompletedExecution(createTestStory("2-1-b", 2, domain.StatusDone))
+		require.NoError(t, s.SaveExecution(ctx, exec))
+
+		// Old builds recorded version 1 while creating every table they knew
+		require.NoError(t, s.migrateTo(ctx, 1))
+		require.NoError(t, s.migrateTo(ctx, 5))
+		_, err = s.db.Exec("DELETE FROM schema_version WHERE version > 1")
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+
+		s, err = NewSQLiteStorage(path)
+		require.NoError(t, err)
+		defer s.Close()
+		version, err := s.schemaVersion(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, latestVersion(), version)
+
+		rec, err := s.GetExecution(ctx, exec.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "2-1-b", rec.StoryKey, "history survives the upgrade")
+	})
+
+	t.Run("refuses a database from a newer build", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bmad.db")
+		s, err := NewSQLiteStorage(path)
+		require.NoError(t, err)
+		_, err = s.db.Exec("INSERT INTO schema_version (version) VALUES (?)", latestVersion()+1)
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+
+		_, err = NewSQLiteStorage(path)
+		assert.ErrorContains(t, err, "newer than this build")
+	})
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SetNote sets the note of an execution, replacing any it had. A blank
+// note removes it.
+func (s *SQLiteStorage) SetNote(ctx context.Context, executionID, note string) error {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM execution_notes WHERE execution_id = ?", executionID); err != nil {
+			return fmt.Errorf("failed to delete note: %w", err)
+		}
+		return nil
+	}
+
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO execution_notes (execution_id, note, updated_at)
+		SELECT id, ?, ? FROM executions WHERE id = ?
+		ON CONFLICT(execution_id) DO UPDATE SET note = excluded.note, updated_at = excluded.updated_at
+	`, note, time.Now().Format(time.RFC3339), executionID)
+	if err != nil {
+		return fmt.Errorf("failed to save note: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("execution not found: %s", executionID)
+	}
+	return nil
+}
+
+// getNotesBatch returns the notes of several executions in one query,
+// keyed by execution ID
+func (s *SQLiteStorage) getNotesBatch(ctx context.Context, executionIDs []string) (map[string]string, error) {
+	placeholders := make([]string, len(executionIDs))
+	args := make([]any, len(executionIDs))
+	for i, id := range executionIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT execution_id, note FROM execution_notes
+		WHERE execution_id IN (`+strings.Join(placeholders, ",")+`)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := make(map[string]string)
+	for rows.Next() {
+		var id, note string
+		if err := rows.Scan(&id, &note); err != nil {
+			return nil, err
+		}
+		notes[id] = note
+	}
+	return notes, rows.Err()
+}
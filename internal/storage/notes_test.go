@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_SetNote(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	exec := createCompletedExecution(createTestStory("1-1-test", 1, domain.StatusDone))
+	require.NoError(t, s.SaveExecution(ctx, exec))
+
+	note := func() string {
+		rec, err := s.GetExecution(ctx, exec.ID)
+		require.NoError(t, err)
+		return rec.Note
+	}
+	assert.Empty(t, note())
+
+	require.NoError(t, s.SetNote(ctx, exec.ID, "  failed due to flaky test "))
+	assert.Equal(t, "failed due to flaky test", note())
+
+	require.NoError(t, s.SetNote(ctx, exec.ID, "rerun passed"))
+	assert.Equal(t, "rerun passed", note(), "a second note replaces the first")
+
+	records, err := s.ListExecutions(ctx, &ExecutionFilter{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "rerun passed", records[0].Note)
+
+	require.NoError(t, s.SetNote(ctx, exec.ID, " "))
+	assert.Empty(t, note(), "a blank note removes it")
+
+	assert.Error(t, s.SetNote(ctx, "missing", "note"))
+}
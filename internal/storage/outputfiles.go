@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+const (
+	// maxOutputLines is how many of a step's last output lines are stored
+	maxOutputLines = 1000
+
+	// TruncatedOutputLines is how many are stored while output is truncated
+	TruncatedOutputLines = 50
+)
+
+// SetOutputDir sets the directory full step output is written to while
+// output is truncated. Prune removes the files of executions it deletes.
+func (s *SQLiteStorage) SetOutputDir(dir string) {
+	s.outputMu.Lock()
+	defer s.outputMu.Unlock()
+	s.outputDir = dir
+}
+
+// TruncateOutput switches new step output to truncated mode, or back.
+// While on, steps keep only their last TruncatedOutputLines lines in the
+// database and output longer than that is written in full to a file under
+// the output directory, when one is set.
+func (s *SQLiteStorage) TruncateOutput(on bool) {
+	s.outputMu.Lock()
+	defer s.outputMu.Unlock()
+	s.truncateOutput = on
+}
+
+// Size returns the size of the database file in bytes, free pages included
+func (s *SQLiteStorage) Size(ctx context.Context) (int64, error) {
+	size, err := s.fileBytes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read database size: %w", err)
+	}
+	return size, nil
+}
+
+// outputLimit returns how many output lines a step keeps in the database,
+// and the directory its full output goes to ("" to drop what is cut)
+func (s *SQLiteStorage) outputLimit() (int, string) {
+	s.outputMu.Lock()
+	defer s.outputMu.Unlock()
+	if !s.truncateOutput {
+		return maxOutputLines, ""
+	}
+	return TruncatedOutputLines, s.outputDir
+}
+
+// writeOutputFile writes the full output of the index'th step of an
+// execution under dir and returns the file's path
+func writeOutputFile(dir, execID string, index int, step *domain.StepExecution) (string, error) {
+	execDir := filepath.Join(dir, execID)
+	if err := os.MkdirAll(execDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	path := filepath.Join(execDir, fmt.Sprintf("%02d-%s.log", index+1, step.Name))
+	if err := os.WriteFile(path, []byte(strings.Join(step.Output, "\n")+"\n"), 0644); err != nil {
+		return "", fmt.Errorf("failed to write step output: %w", err)
+	}
+	return path, nil
+}
+
+// insertOutputFile records where a truncated step's full output was written
+func insertOutputFile(ctx context.Context, tx *sql.Tx, stepID, path string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO step_output_files (step_execution_id, path) VALUES (?, ?)
+	`, stepID, path)
+	if err != nil {
+		return fmt.Errorf("failed to insert output file: %w", err)
+	}
+	return nil
+}
+
+// removeOrphanOutput deletes the output files of executions no longer in
+// the database
+func (s *SQLiteStorage) removeOrphanOutput(ctx context.Context) error {
+	s.outputMu.Lock()
+	dir := s.outputDir
+	s.outputMu.Unlock()
+	if dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		var exists bool
+		err := s.db.QueryRowContext(ctx,
+			"SELECT EXISTS (SELECT 1 FROM executions WHERE id = ?)", entry.Name()).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStorage_TruncateOutput(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	dir := t.TempDir()
+	s.SetOutputDir(dir)
+
+	full := saveExecutions(t, s, 1, 200)[0]
+
+	s.TruncateOutput(true)
+	truncated := saveExecutions(t, s, 1, 200)[0]
+	short := saveExecutions(t, s, 1, 10)[0]
+
+	t.Run("keeps all lines until the guard trips", func(t *testing.T) {
+		rec, err := s.GetExecutionWithOutput(ctx, full)
+		require.NoError(t, err)
+		assert.Len(t, rec.Steps[0].Output, 200)
+		assert.Empty(t, rec.Steps[0].OutputFile)
+	})
+
+	t.Run("writes long output to a file", func(t *testing.T) {
+		rec, err := s.GetExecutionWithOutput(ctx, truncated)
+		require.NoError(t, err)
+		step := rec.Steps[0]
+		assert.Len(t, step.Output, TruncatedOutputLines)
+		assert.Equal(t, 200, step.OutputSize)
+		require.NotEmpty(t, step.OutputFile)
+		assert.Equal(t, filepath.Join(dir, truncated), filepath.Dir(step.OutputFile))
+
+		data, err := os.ReadFile(step.OutputFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), step.Output[0])
+	})
+
+	t.Run("short output needs no file", func(t *testing.T) {
+		rec, err := s.GetExecutionWithOutput(ctx, short)
+		require.NoError(t, err)
+		assert.Len(t, rec.Steps[0].Output, 10)
+		assert.Empty(t, rec.Steps[0].OutputFile)
+	})
+
+	t.Run("pruning removes the files", func(t *testing.T) {
+		_, err := s.Prune(ctx, RetentionPolicy{MaxRows: 1})
+		require.NoError(t, err)
+		assert.NoDirExists(t, filepath.Join(dir, truncated))
+	})
+
+	s.TruncateOutput(false)
+	id := saveExecutions(t, s, 1, 200)[0]
+	rec, err := s.GetExecutionWithOutput(ctx, id)
+	require.NoError(t, err)
+	assert.Len(t, rec.Steps[0].Output, 200, "full output is stored again")
+}
+
+func TestSQLiteStorage_Size(t *testing.T) {
+	s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "bmad.db"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	before, err := s.Size(context.Background())
+	require.NoError(t, err)
+	saveExecutions(t, s, 20, 50)
+	after, err := s.Size(context.Background())
+	require.NoError(t, err)
+	assert.Greater(t, after, before)
+}
@@ -0,0 +1,247 @@
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// OutputSummary describes the stored output of one step without its lines
+type OutputSummary struct {
+	StepID      string
+	ExecutionID string
+	StepName    domain.StepName
+	Lines       int
+	StderrLines int
+	Bytes       int64
+	LastLine    string
+}
+
+// outputLineBits is how much of a search index rowid is the line number;
+// the rest is the id of the line's blob in step_output_blobs. Migration 14
+// and SearchOutput spell out the matching 1<<20.
+const outputLineBits = 20
+
+// outputRowID returns the search index rowid of line n of a blob
+func outputRowID(blobID int64, n int) int64 {
+	return blobID<<outputLineBits | int64(n)
+}
+
+// compressLines gzips lines joined by newlines
+func compressLines(lines []string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(zw, strings.Join(lines, "\n")); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressLines reverses compressLines
+func decompressLines(data []byte) ([]string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	text, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(string(text), "\n"), nil
+}
+
+// insertOutput stores the output of a step as one compressed blob, with
+// the counts ListOutputSummaries reports, and indexes each line for search
+func insertOutput(ctx context.Context, tx *sql.Tx, stepID string, lines []string) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	if len(lines) >= 1<<outputLineBits {
+		lines = lines[len(lines)-(1<<outputLineBits-1):]
+	}
+
+	data, err := compressLines(lines)
+	if err != nil {
+		return fmt.Errorf("failed to compress output: %w", err)
+	}
+	var stderr int
+	var size int64
+	for _, line := range lines {
+		// Stderr lines are stored with the "[stderr] " prefix the executor adds
+		if strings.HasPrefix(line, "[stderr] ") {
+			stderr++
+		}
+		size += int64(len(line))
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO step_output_blobs (step_execution_id, lines, stderr_lines, bytes, last_line, data)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, stepID, len(lines), stderr, size, lines[len(lines)-1], data)
+	if err != nil {
+		return err
+	}
+	blobID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	// SQLite allows 999 variables per statement by default; each line
+	// takes 2
+	const maxRowsPerBatch = 400
+	for start := 0; start < len(lines); start += maxRowsPerBatch {
+		end := min(start+maxRowsPerBatch, len(lines))
+		var query strings.Builder
+		query.WriteString("INSERT INTO step_outputs_fts (rowid, content) VALUES ")
+		args := make([]any, 0, (end-start)*2)
+		for n := start; n < end; n++ {
+			if n > start {
+				query.WriteString(",")
+			}
+			query.WriteString("(?,?)")
+			args = append(args, outputRowID(blobID, n), lines[n])
+		}
+		if _, err := tx.ExecContext(ctx, query.String(), args...); err != nil {
+			return fmt.Errorf("failed to index output: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetStepOutput retrieves output lines for a step
+func (s *SQLiteStorage) GetStepOutput(ctx context.Context, stepID string) ([]string, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx,
+		"SELECT data FROM step_output_blobs WHERE step_execution_id = ?", stepID,
+	).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	lines, err := decompressLines(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress output of step %s: %w", stepID, err)
+	}
+	return lines, nil
+}
+
+// ListOutputSummaries returns a summary of the stored output of every step
+// that produced any
+func (s *SQLiteStorage) ListOutputSummaries(ctx context.Context) ([]*OutputSummary, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT b.step_execution_id, st.execution_id, st.step_name,
+			b.lines, b.stderr_lines, b.bytes, b.last_line
+		FROM step_output_blobs b
+		JOIN step_executions st ON st.id = b.step_execution_id
+		ORDER BY st.execution_id, b.step_execution_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query step outputs: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []*OutputSummary
+	for rows.Next() {
+		var sum OutputSummary
+		var stepName string
+		if err := rows.Scan(&sum.StepID, &sum.ExecutionID, &stepName, &sum.Lines, &sum.StderrLines, &sum.Bytes, &sum.LastLine); err != nil {
+			return nil, err
+		}
+		sum.StepName = domain.StepName(stepName)
+		summaries = append(summaries, &sum)
+	}
+
+	return summaries, rows.Err()
+}
+
+// compressStepOutputs is migration 14's move of the line-per-row output
+// into blobs. The old table goes once its output is copied.
+func compressStepOutputs(ctx context.Context, tx *sql.Tx) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT step_execution_id, content FROM step_outputs
+		ORDER BY step_execution_id, line_number
+	`)
+	if err != nil {
+		return err
+	}
+	outputs := make(map[string][]string)
+	var order []string
+	for rows.Next() {
+		var stepID, line string
+		if err := rows.Scan(&stepID, &line); err != nil {
+			rows.Close()
+			return err
+		}
+		if _, ok := outputs[stepID]; !ok {
+			order = append(order, stepID)
+		}
+		outputs[stepID] = append(outputs[stepID], line)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, stepID := range order {
+		if err := insertOutput(ctx, tx, stepID, outputs[stepID]); err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx, "DROP TABLE step_outputs")
+	return err
+}
+
+// expandStepOutputs undoes compressStepOutputs, before migration 14's Down
+// restores the index over step_outputs
+func expandStepOutputs(ctx context.Context, tx *sql.Tx) error {
+	if _, err := tx.ExecContext(ctx, stepOutputsTable); err != nil {
+		return err
+	}
+	rows, err := tx.QueryContext(ctx, "SELECT step_execution_id, data FROM step_output_blobs ORDER BY id")
+	if err != nil {
+		return err
+	}
+	blobs := make(map[string][]byte)
+	var order []string
+	for rows.Next() {
+		var stepID string
+		var data []byte
+		if err := rows.Scan(&stepID, &data); err != nil {
+			rows.Close()
+			return err
+		}
+		blobs[stepID] = data
+		order = append(order, stepID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, stepID := range order {
+		lines, err := decompressLines(blobs[stepID])
+		if err != nil {
+			return fmt.Errorf("failed to decompress output of step %s: %w", stepID, err)
+		}
+		for n, line := range lines {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO step_outputs (step_execution_id, line_number, content) VALUES (?, ?, ?)
+			`, stepID, n, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_ListOutputSummaries(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	exec := createCompletedExecution(createTestStory("1-1-test", 1, domain.StatusDone))
+	exec.Steps[0].Output = []string{"building", "[stderr] warning: slow", "done"}
+	require.NoError(t, s.SaveExecution(ctx, exec))
+
+	summaries, err := s.ListOutputSummaries(ctx)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1, "steps without output are left out")
+
+	sum := summaries[0]
+	assert.NotEmpty(t, sum.StepID)
+	assert.Equal(t, exec.ID, sum.ExecutionID)
+	assert.Equal(t, exec.Steps[0].Name, sum.StepName)
+	assert.Equal(t, 3, sum.Lines)
+	assert.Equal(t, 1, sum.StderrLines)
+	assert.Equal(t, int64(len("building")+len("[stderr] warning: slow")+len("done")), sum.Bytes)
+	assert.Equal(t, "done", sum.LastLine)
+}
+
+func TestSQLiteStorage_CompressedOutput(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	exec := createCompletedExecution(createTestStory("1-1-test", 1, domain.StatusDone))
+	var output []string
+	for i := 0; i < 500; i++ {
+		output = append(output, fmt.Sprintf("ok  	github.com/example/pkg%d	0.%03ds", i%20, i))
+	}
+	output = append(output, "", "needle in the output")
+	exec.Steps[0].Output = output
+	require.NoError(t, s.SaveExecution(ctx, exec))
+
+	summaries, err := s.ListOutputSummaries(ctx)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	stepID := summaries[0].StepID
+
+	t.Run("stores one blob smaller than the text", func(t *testing.T) {
+		var stored int64
+		require.NoError(t, s.db.QueryRow(
+			"SELECT length(data) FROM step_output_blobs WHERE step_execution_id = ?", stepID,
+		).Scan(&stored))
+		assert.Less(t, stored, summaries[0].Bytes/4)
+	})
+
+	t.Run("reads back every line", func(t *testing.T) {
+		lines, err := s.GetStepOutput(ctx, stepID)
+		require.NoError(t, err)
+		assert.Equal(t, output, lines)
+	})
+
+	t.Run("search finds the line", func(t *testing.T) {
+		matches, err := s.SearchOutput(ctx, "needle", 10)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.Equal(t, len(output)-1, matches[0].LineNumber)
+		assert.Equal(t, "needle in the output", matches[0].Line)
+	})
+
+	t.Run("existing output is compressed by the migration", func(t *testing.T) {
+		require.NoError(t, s.migrateTo(ctx, 13))
+		var rows int
+		require.NoError(t, s.db.QueryRow("SELECT COUNT(*) FROM step_outputs").Scan(&rows))
+		assert.Equal(t, len(output), rows)
+
+		require.NoError(t, s.migrate())
+		lines, err := s.GetStepOutput(ctx, stepID)
+		require.NoError(t, err)
+		assert.Equal(t, output, lines)
+		matches, err := s.SearchOutput(ctx, "needle", 10)
+		require.NoError(t, err)
+		assert.Len(t, matches, 1)
+	})
+
+	t.Run("deleting the execution drops its index entries", func(t *testing.T) {
+		require.NoError(t, s.DeleteExecution(ctx, exec.ID))
+		var indexed int
+		require.NoError(t, s.db.QueryRow("SELECT COUNT(*) FROM step_outputs_fts").Scan(&indexed))
+		assert.Zero(t, indexed)
+	})
+}
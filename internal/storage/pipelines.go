@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// SavePipelineRun records a pipeline run and its stages, replacing what was
+// saved for it before, so a runner can save as it progresses
+func (s *SQLiteStorage) SavePipelineRun(ctx context.Context, run *domain.PipelineRun) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO pipeline_runs (id, pipeline, status, start_time, end_time, duration_ms, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			status = excluded.status,
+			end_time = excluded.end_time,
+			duration_ms = excluded.duration_ms,
+			error = excluded.error
+	`,
+		run.ID,
+		run.Pipeline,
+		string(run.Status),
+		run.StartTime.Format(time.RFC3339),
+		nullableTime(run.EndTime),
+		run.Duration.Milliseconds(),
+		nullableString(run.Error),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save pipeline run: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM pipeline_stages WHERE run_id = ?", run.ID); err != nil {
+		return fmt.Errorf("failed to save pipeline stages: %w", err)
+	}
+	for i, stage := range run.Stages {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO pipeline_stages (run_id, idx, name, workflow, status, succeeded, failed, execution_ids)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			run.ID,
+			i,
+			stage.Name,
+			nullableString(stage.Workflow),
+			string(stage.Status),
+			stage.Succeeded,
+			stage.Failed,
+			nullableString(strings.Join(stage.ExecutionIDs, ",")),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save pipeline stage: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListPipelineRuns returns the most recent pipeline runs with their stages,
+// newest first
+func (s *SQLiteStorage) ListPipelineRuns(ctx context.Context, limit int) ([]*domain.PipelineRun, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, pipeline, status, start_time, end_time, duration_ms, error
+		FROM pipeline_runs
+		ORDER BY start_time DESC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pipeline runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*domain.PipelineRun
+	for rows.Next() {
+		run := &domain.PipelineRun{}
+		var startTime string
+		var endTime, runErr sql.NullString
+		var durationMS int64
+		if err := rows.Scan(&run.ID, &run.Pipeline, &run.Status, &startTime, &endTime, &durationMS, &runErr); err != nil {
+			return nil, err
+		}
+		run.StartTime, _ = time.Parse(time.RFC3339, startTime)
+		if endTime.Valid {
+			run.EndTime, _ = time.Parse(time.RFC3339, endTime.String)
+		}
+		run.Duration = time.Duration(durationMS) * time.Millisecond
+		run.Error = runErr.String
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, run := range runs {
+		if run.Stages, err = s.getPipelineStages(ctx, run.ID); err != nil {
+			return nil, err
+		}
+	}
+	return runs, nil
+}
+
+// getPipelineStages returns the stages of a pipeline run in order
+func (s *SQLiteStorage) getPipelineStages(ctx context.Context, runID string) ([]*domain.PipelineStage, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT name, workflow, status, succeeded, failed, execution_ids
+		FROM pipeline_stages
+		WHERE run_id = ?
+		ORDER BY idx
+	`, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pipeline stages: %w", err)
+	}
+	defer rows.Close()
+
+	var stages []*domain.PipelineStage
+	for rows.Next() {
+		stage := &domain.PipelineStage{}
+		var workflow, executionIDs sql.NullString
+		if err := rows.Scan(&stage.Name, &workflow, &stage.Status, &stage.Succeeded, &stage.Failed, &executionIDs); err != nil {
+			return nil, err
+		}
+		stage.Workflow = workflow.String
+		if executionIDs.String != "" {
+			stage.ExecutionIDs = strings.Split(executionIDs.String, ",")
+		}
+		stages = append(stages, stage)
+	}
+	return stages, rows.Err()
+}
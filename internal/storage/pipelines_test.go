@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_PipelineRuns(t *testing.T) {
+	store, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+	ctx := context.Background()
+
+	older := domain.NewPipelineRun("nightly", []*domain.PipelineStage{{Name: "epic-3"}})
+	older.StartTime = time.Date(2024, 1, 14, 9, 0, 0, 0, time.UTC)
+	older.Status = domain.ExecutionCompleted
+	require.NoError(t, store.SavePipelineRun(ctx, older))
+
+	run := domain.NewPipelineRun("release", []*domain.PipelineStage{
+		{Name: "epic-4"},
+		{Name: "integration", Workflow: "integration-tests"},
+	})
+	run.Status = domain.ExecutionRunning
+	run.StartTime = time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, store.SavePipelineRun(ctx, run))
+
+	// Saved again as it progresses
+	run.Stages[0].Status = domain.ExecutionCompleted
+	run.Stages[0].Succeeded = 2
+	run.Stages[0].ExecutionIDs = []string{"exec-1", "exec-2"}
+	run.Stages[1].Status = domain.ExecutionFailed
+	run.Stages[1].Failed = 1
+	run.Status = domain.ExecutionFailed
+	run.EndTime = run.StartTime.Add(90 * time.Minute)
+	run.Duration = 90 * time.Minute
+	run.Error = "stage \"integration\" failed"
+	require.NoError(t, store.SavePipelineRun(ctx, run))
+
+	runs, err := store.ListPipelineRuns(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+
+	got := runs[0]
+	assert.Equal(t, run.ID, got.ID)
+	assert.Equal(t, "release", got.Pipeline)
+	assert.Equal(t, domain.ExecutionFailed, got.Status)
+	assert.Equal(t, 90*time.Minute, got.Duration)
+	assert.Equal(t, run.EndTime, got.EndTime)
+	assert.Equal(t, run.Error, got.Error)
+	require.Len(t, got.Stages, 2)
+	assert.Equal(t, []string{"exec-1", "exec-2"}, got.Stages[0].ExecutionIDs)
+	assert.Equal(t, 2, got.Stages[0].Succeeded)
+	assert.Equal(t, "integration-tests", got.Stages[1].Workflow)
+	assert.Equal(t, domain.ExecutionFailed, got.Stages[1].Status)
+	assert.Nil(t, got.Stages[1].ExecutionIDs)
+
+	assert.Equal(t, older.ID, runs[1].ID)
+
+	runs, err = store.ListPipelineRuns(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+}
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// insertPrompt records the prompt a step ran with in place of its
+// workflow's, when it was edited before the run
+func insertPrompt(ctx context.Context, tx *sql.Tx, stepID, prompt string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO step_prompts (step_execution_id, prompt) VALUES (?, ?)
+	`, stepID, prompt)
+	if err != nil {
+		return fmt.Errorf("failed to insert step prompt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// StoryReliability counts how often the runs of a story failed or needed
+// retries
+type StoryReliability struct {
+	StoryKey    string
+	Runs        int
+	Failures    int       // Runs that failed or stopped on a merge conflict
+	Retries     int       // Step attempts beyond the first, over all runs
+	LastFailure time.Time // Start of the latest failed run, zero without one
+}
+
+// FailureRate returns the percentage of runs that failed
+func (sr *StoryReliability) FailureRate() float64 {
+	if sr.Runs == 0 {
+		return 0
+	}
+	return float64(sr.Failures) / float64(sr.Runs) * 100
+}
+
+// GetStoryReliability returns up to limit stories whose runs within r
+// failed or retried, the most failures first and then the most retries
+func (s *SQLiteStorage) GetStoryReliability(ctx context.Context, r domain.DateRange, limit int) ([]*StoryReliability, error) {
+	inRange, args := rangeCondition(r)
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT e.story_key,
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN e.status IN ('failed', 'conflict') THEN 1 ELSE 0 END), 0) AS failures,
+			COALESCE(SUM(r.retries), 0) AS retries,
+			MAX(CASE WHEN e.status IN ('failed', 'conflict') THEN datetime(e.start_time) END)
+		FROM executions e
+		LEFT JOIN (
+			SELECT execution_id, SUM(attempt - 1) AS retries
+			FROM step_executions
+			WHERE attempt > 1
+			GROUP BY execution_id
+		) r ON r.execution_id = e.id
+		WHERE `+inRange+`
+		GROUP BY e.story_key
+		HAVING failures > 0 OR retries > 0
+		ORDER BY failures DESC, retries DESC, e.story_key
+		LIMIT ?
+	`, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story reliability: %w", err)
+	}
+	defer rows.Close()
+
+	var stories []*StoryReliability
+	for rows.Next() {
+		sr := &StoryReliability{}
+		var lastFailure sql.NullString
+		if err := rows.Scan(&sr.StoryKey, &sr.Runs, &sr.Failures, &sr.Retries, &lastFailure); err != nil {
+			return nil, err
+		}
+		if lastFailure.Valid {
+			sr.LastFailure, _ = time.Parse(time.DateTime, lastFailure.String)
+		}
+		stories = append(stories, sr)
+	}
+	return stories, rows.Err()
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_StoryReliability(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	flaky := createTestStory("1-1-flaky", 1, domain.StatusInProgress)
+	retried := createTestStory("1-2-retried", 1, domain.StatusDone)
+	steady := createTestStory("1-3-steady", 1, domain.StatusDone)
+
+	failedAt := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		exec := createCompletedExecution(flaky)
+		exec.Status = domain.ExecutionFailed
+		exec.StartTime = failedAt.AddDate(0, 0, -i)
+		require.NoError(t, s.SaveExecution(ctx, exec))
+	}
+	require.NoError(t, s.SaveExecution(ctx, createCompletedExecution(flaky)))
+
+	exec := createCompletedExecution(retried)
+	exec.Steps[1].Attempt = 3
+	require.NoError(t, s.SaveExecution(ctx, exec))
+
+	require.NoError(t, s.SaveExecution(ctx, createCompletedExecution(steady)))
+
+	t.Run("worst stories first", func(t *testing.T) {
+		stories, err := s.GetStoryReliability(ctx, domain.DateRange{}, 10)
+		require.NoError(t, err)
+		require.Len(t, stories, 2, "stories that never failed or retried are left out")
+
+		assert.Equal(t, "1-1-flaky", stories[0].StoryKey)
+		assert.Equal(t, 3, stories[0].Runs)
+		assert.Equal(t, 2, stories[0].Failures)
+		assert.InDelta(t, 66.7, stories[0].FailureRate(), 0.1)
+		assert.Equal(t, failedAt, stories[0].LastFailure)
+
+		assert.Equal(t, "1-2-retried", stories[1].StoryKey)
+		assert.Equal(t, 2, stories[1].Retries)
+		assert.Zero(t, stories[1].Failures)
+		assert.True(t, stories[1].LastFailure.IsZero())
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		stories, err := s.GetStoryReliability(ctx, domain.DateRange{}, 1)
+		require.NoError(t, err)
+		assert.Len(t, stories, 1)
+	})
+
+	t.Run("limits to the date range", func(t *testing.T) {
+		stories, err := s.GetStoryReliability(ctx, domain.DateRange{Since: failedAt.Add(time.Hour)}, 10)
+		require.NoError(t, err)
+		require.Len(t, stories, 1)
+		assert.Equal(t, "1-2-retried", stories[0].StoryKey)
+	})
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetentionPolicy limits how much execution history is kept. Zero fields
+// are unlimited.
+type RetentionPolicy struct {
+	MaxAge   time.Duration // Executions older than this are pruned
+	MaxRows  int           // Only the newest MaxRows executions are kept
+	MaxBytes int64         // Oldest executions are pruned until the data fits
+}
+
+// NewRetentionPolicy builds a policy from the configured day, row and
+// megabyte limits
+func NewRetentionPolicy(maxAgeDays, maxRows, maxSizeMB int) RetentionPolicy {
+	return RetentionPolicy{
+		MaxAge:   time.Duration(maxAgeDays) * 24 * time.Hour,
+		MaxRows:  maxRows,
+		MaxBytes: int64(maxSizeMB) << 20,
+	}
+}
+
+// IsZero reports whether the policy keeps everything
+func (p RetentionPolicy) IsZero() bool {
+	return p.MaxAge <= 0 && p.MaxRows <= 0 && p.MaxBytes <= 0
+}
+
+// DefaultPruneInterval is how often StartPruning applies its policy
+const DefaultPruneInterval = time.Hour
+
+// pruneSizeBatch is how many of the oldest executions are deleted at a time
+// while the database is over MaxBytes
+const pruneSizeBatch = 50
+
+// Prune deletes the executions, with their steps, output files and
+// snapshot refs, that fall outside policy and returns how many were
+// deleted. Deleted pages are reused by new rows; the file itself only
+// shrinks on VACUUM.
+func (s *SQLiteStorage) Prune(ctx context.Context, policy RetentionPolicy) (deleted int, err error) {
+	var refs []string
+	defer func() {
+		// Refs of executions already deleted go even if a later pass fails
+		if refErr := s.removeSnapshotRefs(refs); refErr != nil && err == nil {
+			err = fmt.Errorf("failed to remove snapshot refs: %w", refErr)
+		}
+	}()
+
+	if policy.MaxAge > 0 {
+		n, err := s.deleteExecutions(ctx, &refs,
+			"SELECT id FROM executions WHERE created_at < datetime('now', ?)",
+			fmt.Sprintf("-%d seconds", int64(policy.MaxAge.Seconds())))
+		if err != nil {
+			return deleted, fmt.Errorf("failed to prune by age: %w", err)
+		}
+		deleted += n
+	}
+
+	if policy.MaxRows > 0 {
+		n, err := s.deleteExecutions(ctx, &refs, `
+			SELECT id FROM executions ORDER BY created_at DESC, rowid DESC LIMIT -1 OFFSET ?`,
+			policy.MaxRows)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to prune by count: %w", err)
+		}
+		deleted += n
+	}
+
+	lastSize := int64(-1)
+	for policy.MaxBytes > 0 {
+		size, err := s.usedBytes(ctx)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to read database size: %w", err)
+		}
+		if size <= policy.MaxBytes {
+			break
+		}
+		if lastSize >= 0 && size >= lastSize {
+			break // The last pass freed nothing; pruning more will not help
+		}
+		lastSize = size
+		n, err := s.deleteExecutions(ctx, &refs, `
+			SELECT id FROM executions ORDER BY created_at ASC, rowid ASC LIMIT ?`,
+			pruneSizeBatch)
+		if err != nil {
+			return deleted, fmt.Errorf("failed to prune by size: %w", err)
+		}
+		if n == 0 {
+			break // Nothing left to prune; the rest is other data
+		}
+		deleted += n
+	}
+
+	if deleted > 0 {
+		if err := s.removeOrphanOutput(ctx); err != nil {
+			return deleted, fmt.Errorf("failed to remove output files: %w", err)
+		}
+	}
+	return deleted, nil
+}
+
+// deleteExecutions deletes the executions whose IDs selectIDs returns and
+// appends the snapshot refs they recorded to refs
+func (s *SQLiteStorage) deleteExecutions(ctx context.Context, refs *[]string, selectIDs string, args ...any) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Both statements run in one transaction, so they see the same rows
+	rows, err := tx.QueryContext(ctx, "SELECT ref FROM snapshots WHERE execution_id IN ("+selectIDs+")", args...)
+	if err != nil {
+		return 0, err
+	}
+	var found []string
+	for rows.Next() {
+		var ref string
+		if err := rows.Scan(&ref); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		found = append(found, ref)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM executions WHERE id IN ("+selectIDs+")", args...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	*refs = append(*refs, found...)
+	return int(n), nil
+}
+
+// usedBytes returns the size of the database pages in use, leaving out
+// pages freed by deletes
+func (s *SQLiteStorage) usedBytes(ctx context.Context) (int64, error) {
+	var pages, free, pageSize int64
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pages); err != nil {
+		return 0, err
+	}
+	if err := s.db.QueryRowContext(ctx, "PRAGMA freelist_count").Scan(&free); err != nil {
+		return 0, err
+	}
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, err
+	}
+	return (pages - free) * pageSize, nil
+}
+
+// StartPruning applies policy now and then every interval until the
+// storage is closed. report, when not nil, is called after each pass that
+// deleted something or failed.
+func (s *SQLiteStorage) StartPruning(policy RetentionPolicy, interval time.Duration, report func(deleted int, err error)) {
+	if policy.IsZero() {
+		return
+	}
+	s.pruneMu.Lock()
+	defer s.pruneMu.Unlock()
+	if s.pruneStop != nil {
+		return // Already pruning
+	}
+	s.pruneStop = make(chan struct{})
+	go s.pruneLoop(policy, interval, report, s.pruneStop)
+}
+
+// stopPruning ends the StartPruning loop, if any
+func (s *SQLiteStorage) stopPruning() {
+	s.pruneMu.Lock()
+	defer s.pruneMu.Unlock()
+	if s.pruneStop == nil {
+		return
+	}
+	select {
+	case <-s.pruneStop: // Already stopped
+	default:
+		close(s.pruneStop)
+	}
+}
+
+func (s *SQLiteStorage) pruneLoop(policy RetentionPolicy, interval time.Duration, report func(int, error), stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		deleted, err := s.Prune(context.Background(), policy)
+		select {
+		case <-stop:
+			return // Closed mid-pass; an error here is expected
+		default:
+		}
+		if report != nil && (deleted > 0 || err != nil) {
+			report(deleted, err)
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// saveExecutions records n executions, the first one oldest
+func saveExecutions(t *testing.T, s *SQLiteStorage, n int, outputLines int) []string {
+	t.Helper()
+	ctx := context.Background()
+	var ids []string
+	for i := 0; i < n; i++ {
+		exec := createCompletedExecution(createTestStory(fmt.Sprintf("5-%d-story", i), 5, domain.StatusDone))
+		for j := 0; j < outputLines; j++ {
+			exec.Steps[0].Output = append(exec.Steps[0].Output, strings.Repeat("output ", 20))
+		}
+		require.NoError(t, s.SaveExecution(ctx, exec))
+		ids = append(ids, exec.ID)
+	}
+	return ids
+}
+
+func remainingIDs(t *testing.T, s *SQLiteStorage) []string {
+	t.Helper()
+	records, err := s.ListExecutions(context.Background(), &ExecutionFilter{})
+	require.NoError(t, err)
+	var ids []string
+	for _, rec := range records {
+		ids = append(ids, rec.ID)
+	}
+	return ids
+}
+
+func TestSQLiteStorage_Prune(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("keeps everything with an empty policy", func(t *testing.T) {
+		s, err := NewInMemoryStorage()
+		require.NoError(t, err)
+		defer s.Close()
+		saveExecutions(t, s, 3, 0)
+
+		deleted, err := s.Prune(ctx, RetentionPolicy{})
+		require.NoError(t, err)
+		assert.Zero(t, deleted)
+		assert.True(t, RetentionPolicy{}.IsZero())
+	})
+
+	t.Run("deletes executions older than MaxAge", func(t *testing.T) {
+		s, err := NewInMemoryStorage()
+		require.NoError(t, err)
+		defer s.Close()
+		ids := saveExecutions(t, s, 3, 2)
+		_, err = s.db.ExecContext(ctx, "UPDATE executions SET created_at = datetime('now', '-40 days') WHERE id = ?", ids[0])
+		require.NoError(t, err)
+
+		deleted, err := s.Prune(ctx, RetentionPolicy{MaxAge: 30 * 24 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+		assert.ElementsMatch(t, ids[1:], remainingIDs(t, s))
+
+		var steps int
+		require.NoError(t, s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM step_executions WHERE execution_id = ?", ids[0]).Scan(&steps))
+		assert.Zero(t, steps, "steps go with their execution")
+	})
+
+	t.Run("keeps the newest MaxRows executions", func(t *testing.T) {
+		s, err := NewInMemoryStorage()
+		require.NoError(t, err)
+		defer s.Close()
+		ids := saveExecutions(t, s, 5, 0)
+
+		deleted, err := s.Prune(ctx, RetentionPolicy{MaxRows: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 3, deleted)
+		assert.Equal(t, []string{ids[4], ids[3]}, remainingIDs(t, s))
+	})
+
+	t.Run("deletes the oldest executions until under MaxBytes", func(t *testing.T) {
+		s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "bmad.db"))
+		require.NoError(t, err)
+		defer s.Close()
+		saveExecutions(t, s, 1, 0)
+		base, err := s.usedBytes(ctx)
+		require.NoError(t, err)
+		ids := saveExecutions(t, s, 60, 50)
+		full, err := s.usedBytes(ctx)
+		require.NoError(t, err)
+
+		limit := base + (full-base)/2
+		deleted, err := s.Prune(ctx, RetentionPolicy{MaxBytes: limit})
+		require.NoError(t, err)
+		assert.Greater(t, deleted, 0)
+
+		size, err := s.usedBytes(ctx)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, size, limit)
+		assert.Contains(t, remainingIDs(t, s), ids[len(ids)-1], "the newest run is kept")
+	})
+
+	t.Run("cascades to steps on a second pooled connection", func(t *testing.T) {
+		s, err := NewSQLiteStorage(filepath.Join(t.TempDir(), "bmad.db"))
+		require.NoError(t, err)
+		defer s.Close()
+		saveExecutions(t, s, 1, 0)
+		base, err := s.usedBytes(ctx)
+		require.NoError(t, err)
+		ids := saveExecutions(t, s, 60, 50)
+		full, err := s.usedBytes(ctx)
+		require.NoError(t, err)
+
+		// Hold the first connection so Prune runs on a fresh one
+		busy, err := s.db.Conn(ctx)
+		require.NoError(t, err)
+		defer busy.Close()
+
+		limit := base + (full-base)/2
+		deleted, err := s.Prune(ctx, RetentionPolicy{MaxBytes: limit})
+		require.NoError(t, err)
+		assert.Greater(t, deleted, 0)
+		assert.Less(t, deleted, len(ids), "pruning stops once the steps are gone too")
+
+		var orphans int
+		require.NoError(t, s.db.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM step_executions
+			WHERE execution_id NOT IN (SELECT id FROM executions)`).Scan(&orphans))
+		assert.Zero(t, orphans)
+	})
+}
+
+func TestSQLiteStorage_StartPruning(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	saveExecutions(t, s, 4, 0)
+
+	reported := make(chan int, 1)
+	s.StartPruning(RetentionPolicy{MaxRows: 1}, time.Hour, func(deleted int, err error) {
+		assert.NoError(t, err)
+		reported <- deleted
+	})
+
+	select {
+	case deleted := <-reported:
+		assert.Equal(t, 3, deleted)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pruning did not run")
+	}
+	require.NoError(t, s.Close())
+}
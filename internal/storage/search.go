@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// OutputMatch is a stored output line matching a search
+type OutputMatch struct {
+	ExecutionID string
+	StoryKey    string
+	StepName    domain.StepName
+	StepID      string
+	LineNumber  int
+	Line        string
+	StartTime   time.Time // Of the execution
+}
+
+// SearchOutput returns stored output lines containing every word of query,
+// best matches first. Words match as prefixes, so "migrat" finds
+// "migration".
+func (s *SQLiteStorage) SearchOutput(ctx context.Context, query string, limit int) ([]*OutputMatch, error) {
+	match := ftsQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	// The index holds no text of its own, so each match is looked up by
+	// its rowid in its step's blob
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT e.id, e.story_key, se.step_name, se.id, f.rowid, e.start_time
+		FROM step_outputs_fts f
+		JOIN step_output_blobs b ON b.id = f.rowid >> 20
+		JOIN step_executions se ON se.id = b.step_execution_id
+		JOIN executions e ON e.id = se.execution_id
+		WHERE step_outputs_fts MATCH ?
+		ORDER BY f.rank, e.start_time DESC
+		LIMIT ?
+	`, match, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search output: %w", err)
+	}
+	defer rows.Close()
+
+	var matches []*OutputMatch
+	for rows.Next() {
+		var m OutputMatch
+		var rowID int64
+		var start sql.NullString
+		if err := rows.Scan(&m.ExecutionID, &m.StoryKey, &m.StepName, &m.StepID, &rowID, &start); err != nil {
+			return nil, err
+		}
+		m.LineNumber = int(rowID & (1<<outputLineBits - 1))
+		if start.Valid {
+			m.StartTime, _ = time.Parse(time.RFC3339, start.String)
+		}
+		matches = append(matches, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	outputs := make(map[string][]string)
+	for _, m := range matches {
+		lines, ok := outputs[m.StepID]
+		if !ok {
+			if lines, err = s.GetStepOutput(ctx, m.StepID); err != nil {
+				return nil, fmt.Errorf("failed to read matched output: %w", err)
+			}
+			outputs[m.StepID] = lines
+		}
+		if m.LineNumber < len(lines) {
+			m.Line = lines[m.LineNumber]
+		}
+	}
+	return matches, nil
+}
+
+// ftsQuery turns typed text into an FTS5 query matching every word as a
+// prefix. Words are quoted so characters such as - or : are not read as
+// query syntax.
+func ftsQuery(text string) string {
+	var terms []string
+	for _, word := range strings.Fields(text) {
+		terms = append(terms, `"`+strings.ReplaceAll(word, `"`, `""`)+`"*`)
+	}
+	return strings.Join(terms, " ")
+}
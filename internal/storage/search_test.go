@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_SearchOutput(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	exec := createCompletedExecution(createTestStory("3-1-user-auth", 3, domain.StatusDone))
+	exec.Steps[1].Output = []string{"Running migrations", "FAIL: TestLogin expected 200", "ok  auth/session"}
+	require.NoError(t, s.SaveExecution(ctx, exec))
+
+	t.Run("matches every word as a prefix", func(t *testing.T) {
+		matches, err := s.SearchOutput(ctx, "fail testlog", 10)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.Equal(t, exec.ID, matches[0].ExecutionID)
+		assert.Equal(t, "3-1-user-auth", matches[0].StoryKey)
+		assert.Equal(t, exec.Steps[1].Name, matches[0].StepName)
+		assert.Equal(t, "FAIL: TestLogin expected 200", matches[0].Line)
+		assert.Equal(t, 1, matches[0].LineNumber)
+	})
+
+	t.Run("query syntax is taken literally", func(t *testing.T) {
+		matches, err := s.SearchOutput(ctx, `auth/session "OR`, 10)
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+
+		matches, err = s.SearchOutput(ctx, "auth/session", 10)
+		require.NoError(t, err)
+		assert.Len(t, matches, 1)
+	})
+
+	t.Run("blank queries match nothing", func(t *testing.T) {
+		matches, err := s.SearchOutput(ctx, "  ", 10)
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+
+	t.Run("deleted executions leave the index", func(t *testing.T) {
+		require.NoError(t, s.DeleteExecution(ctx, exec.ID))
+		matches, err := s.SearchOutput(ctx, "migrations", 10)
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+}
+
+func TestSQLiteStorage_SearchOutputIndexesExistingOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bmad.db")
+	s, err := NewSQLiteStorage(path)
+	require.NoError(t, err)
+	exec := createCompletedExecution(createTestStory("3-1-user-auth", 3, domain.StatusDone))
+	exec.Steps[0].Output = []string{"created story file"}
+	require.NoError(t, s.SaveExecution(context.Background(), exec))
+
+	// A database from before the index has its output indexed on open
+	require.NoError(t, s.migrateTo(context.Background(), 10))
+	require.NoError(t, s.Close())
+
+	s, err = NewSQLiteStorage(path)
+	require.NoError(t, err)
+	defer s.Close()
+	matches, err := s.SearchOutput(context.Background(), "story file", 10)
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+}
+
+func TestListExecutions_TextFilter(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	failed := createCompletedExecution(createTestStory("3-1-user-auth", 3, domain.StatusDone))
+	failed.Status = domain.ExecutionFailed
+	failed.Error = "timeout after 600s"
+	require.NoError(t, s.SaveExecution(ctx, failed))
+	titled := createCompletedExecution(domain.Story{Key: "4-1-dashboard", Epic: 4, Title: "Admin dashboard"})
+	require.NoError(t, s.SaveExecution(ctx, titled))
+
+	for text, want := range map[string]string{"user-auth": failed.ID, "timeout": failed.ID, "admin": titled.ID} {
+		records, err := s.ListExecutions(ctx, &ExecutionFilter{Text: text})
+		require.NoError(t, err)
+		require.Len(t, records, 1, text)
+		assert.Equal(t, want, records[0].ID, text)
+	}
+}
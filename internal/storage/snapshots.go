@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/git"
+)
+
+// SetRepoDir sets the git working directory snapshot refs are kept in.
+// Prune deletes the refs of executions it deletes; with no directory set
+// the refs are left alone.
+func (s *SQLiteStorage) SetRepoDir(dir string) {
+	s.repoMu.Lock()
+	defer s.repoMu.Unlock()
+	s.repoDir = dir
+}
+
+// removeSnapshotRefs deletes refs from the repository set by SetRepoDir
+func (s *SQLiteStorage) removeSnapshotRefs(refs []string) error {
+	s.repoMu.Lock()
+	dir := s.repoDir
+	s.repoMu.Unlock()
+	if dir == "" || len(refs) == 0 {
+		return nil
+	}
+	return git.DeleteSnapshotRefs(dir, refs)
+}
+
+// insertSnapshot records the pre-run workspace snapshot of an execution
+func insertSnapshot(ctx context.Context, tx *sql.Tx, execID string, snap *domain.WorkspaceSnapshot) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO snapshots (execution_id, ref, head, stash, untracked)
+		VALUES (?, ?, ?, ?, ?)
+	`, execID, snap.Ref, snap.Head, snap.Stash, strings.Join(snap.Untracked, "\n"))
+	if err != nil {
+		return fmt.Errorf("failed to insert snapshot: %w", err)
+	}
+	return nil
+}
+
+// getSnapshot returns the pre-run snapshot of an execution, or nil
+func (s *SQLiteStorage) getSnapshot(ctx context.Context, execID string) (*domain.WorkspaceSnapshot, error) {
+	var snap domain.WorkspaceSnapshot
+	var untracked string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT ref, head, stash, untracked FROM snapshots WHERE execution_id = ?
+	`, execID).Scan(&snap.Ref, &snap.Head, &snap.Stash, &untracked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+
+	if untracked != "" {
+		snap.Untracked = strings.Split(untracked, "\n")
+	}
+	return &snap, nil
+}
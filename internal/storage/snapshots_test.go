@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/git"
+)
+
+func TestSQLiteStorage_Snapshot(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	withSnap := createCompletedExecution(createTestStory("1-1-test", 1, domain.StatusDone))
+	withSnap.Snapshot = &domain.WorkspaceSnapshot{
+		Ref:       "refs/bmad/snapshots/" + withSnap.ID,
+		Head:      "abc123",
+		Stash:     "def456",
+		Untracked: []string{"notes.txt", "tmp/out.log"},
+	}
+	without := createCompletedExecution(createTestStory("1-2-test", 1, domain.StatusDone))
+	require.NoError(t, s.SaveExecution(ctx, withSnap))
+	require.NoError(t, s.SaveExecution(ctx, without))
+
+	rec, err := s.GetExecution(ctx, withSnap.ID)
+	require.NoError(t, err)
+	assert.Equal(t, withSnap.Snapshot, rec.Snapshot)
+
+	rec, err = s.GetExecution(ctx, without.ID)
+	require.NoError(t, err)
+	assert.Nil(t, rec.Snapshot)
+}
+
+func TestSQLiteStorage_PruneDeletesSnapshotRefs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, kv := range [][2]string{
+		{"GIT_AUTHOR_NAME", "test"}, {"GIT_AUTHOR_EMAIL", "test@example.com"},
+		{"GIT_COMMITTER_NAME", "test"}, {"GIT_COMMITTER_EMAIL", "test@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	dir := t.TempDir()
+	gitCmd := func(args ...string) error {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		return cmd.Run()
+	}
+	require.NoError(t, gitCmd("init", "-q", "-b", "main"))
+	require.NoError(t, gitCmd("commit", "-q", "--allow-empty", "-m", "base"))
+
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	s.SetRepoDir(dir)
+	ctx := context.Background()
+
+	var refs []string
+	for i := 0; i < 2; i++ {
+		execution := createCompletedExecution(createTestStory(fmt.Sprintf("1-%d-test", i), 1, domain.StatusDone))
+		snap, err := git.CreateSnapshot(dir, execution.ID)
+		require.NoError(t, err)
+		execution.Snapshot = snap
+		require.NoError(t, s.SaveExecution(ctx, execution))
+		refs = append(refs, snap.Ref)
+	}
+
+	deleted, err := s.Prune(ctx, RetentionPolicy{MaxRows: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	assert.Error(t, gitCmd("rev-parse", "--verify", "--quiet", refs[0]), "the pruned execution's ref is deleted")
+	assert.NoError(t, gitCmd("rev-parse", "--verify", "--quiet", refs[1]), "the kept execution's ref stays")
+}
@@ -4,41 +4,69 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	_ "modernc.org/sqlite"
 
 	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/util"
 )
 
 // SQLiteStorage implements Storage using SQLite
 type SQLiteStorage struct {
 	db *sql.DB
+
+	pruneMu   sync.Mutex
+	pruneStop chan struct{} // Closed to stop StartPruning
+
+	outputMu       sync.Mutex
+	outputDir      string // Full output of truncated steps goes here
+	truncateOutput bool   // Size guard tripped: store only the last lines
+
+	repoMu  sync.Mutex
+	repoDir string // Git working directory holding the snapshot refs
+}
+
+// connPragmas are applied to every pooled connection through the DSN.
+// foreign_keys in particular is per connection, and the ON DELETE CASCADE
+// cleanup of steps and output depends on it.
+var connPragmas = []string{
+	"foreign_keys(1)",
+	"synchronous(NORMAL)",
+	"cache_size(-64000)", // 64MB cache
+	"temp_store(MEMORY)",
+}
+
+// sqliteDSN appends connPragmas to dbPath as _pragma query parameters
+func sqliteDSN(dbPath string) string {
+	params := make(url.Values)
+	for _, pragma := range connPragmas {
+		params.Add("_pragma", pragma)
+	}
+	return dbPath + "?" + params.Encode()
 }
 
 // NewSQLiteStorage creates a new SQLite storage instance
 func NewSQLiteStorage(dbPath string) (*SQLiteStorage, error) {
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", sqliteDSN(dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
-
-	// Enable foreign keys and WAL mode for better performance
-	pragmas := []string{
-		"PRAGMA foreign_keys = ON",
-		"PRAGMA journal_mode = WAL",
-		"PRAGMA synchronous = NORMAL",
-		"PRAGMA cache_size = -64000", // 64MB cache
-		"PRAGMA temp_store = MEMORY",
+	if dbPath == ":memory:" {
+		// Every connection to ":memory:" gets its own empty database, so the
+		// pool must never open a second one
+		db.SetMaxOpenConns(1)
 	}
-	for _, pragma := range pragmas {
-		if _, err := db.Exec(pragma); err != nil {
-			db.Close()
-			return nil, fmt.Errorf("failed to set pragma: %w", err)
-		}
+
+	// WAL mode is stored in the database file, so setting it once is enough
+	if _, err := db.Exec("PRAGMA journal_mode = WAL"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to set pragma: %w", err)
 	}
 
 	s := &SQLiteStorage{db: db}
@@ -52,88 +80,15 @@ func NewSQLiteStorage(dbPath string) (*SQLiteStorage, error) {
 	return s, nil
 }
 
-// NewInMemoryStorage creates an in-memory SQLite storage (for testing)
+// NewInMemoryStorage creates an in-memory SQLite storage, used for tests
+// and as the fallback when the database file cannot be opened
 func NewInMemoryStorage() (*SQLiteStorage, error) {
 	return NewSQLiteStorage(":memory:")
 }
 
-// migrate runs database migrations
-func (s *SQLiteStorage) migrate() error {
-	_, err := s.db.Exec(initialMigration)
-	if err != nil {
-		return fmt.Errorf("failed to execute migration: %w", err)
-	}
-
-	return nil
-}
-
-// initialMigration is the fallback migration SQL
-const initialMigration = `
-CREATE TABLE IF NOT EXISTS executions (
-    id TEXT PRIMARY KEY,
-    story_key TEXT NOT NULL,
-    story_epic INTEGER NOT NULL,
-    story_status TEXT NOT NULL,
-    story_title TEXT,
-    status TEXT NOT NULL,
-    start_time TEXT NOT NULL,
-    end_time TEXT,
-    duration_ms INTEGER DEFAULT 0,
-    error TEXT,
-    created_at TEXT NOT NULL DEFAULT (datetime('now'))
-);
-
-CREATE TABLE IF NOT EXISTS step_executions (
-    id TEXT PRIMARY KEY,
-    execution_id TEXT NOT NULL,
-    step_name TEXT NOT NULL,
-    status TEXT NOT NULL,
-    start_time TEXT,
-    end_time TEXT,
-    duration_ms INTEGER DEFAULT 0,
-    attempt INTEGER DEFAULT 1,
-    command TEXT,
-    error TEXT,
-    output_size INTEGER DEFAULT 0,
-    FOREIGN KEY (execution_id) REFERENCES executions(id) ON DELETE CASCADE
-);
-
-CREATE TABLE IF NOT EXISTS step_outputs (
-    id INTEGER PRIMARY KEY AUTOINCREMENT,
-    step_execution_id TEXT NOT NULL,
-    line_number INTEGER NOT NULL,
-    content TEXT NOT NULL,
-    is_stderr BOOLEAN DEFAULT FALSE,
-    FOREIGN KEY (step_execution_id) REFERENCES step_executions(id) ON DELETE CASCADE
-);
-
-CREATE TABLE IF NOT EXISTS step_averages (
-    step_name TEXT PRIMARY KEY,
-    avg_duration_ms INTEGER NOT NULL,
-    success_count INTEGER DEFAULT 0,
-    failure_count INTEGER DEFAULT 0,
-    total_count INTEGER DEFAULT 0,
-    last_updated TEXT NOT NULL
-);
-
-CREATE INDEX IF NOT EXISTS idx_executions_story_key ON executions(story_key);
-CREATE INDEX IF NOT EXISTS idx_executions_status ON executions(status);
-CREATE INDEX IF NOT EXISTS idx_executions_start_time ON executions(start_time DESC);
-CREATE INDEX IF NOT EXISTS idx_executions_created_at ON executions(created_at DESC);
-CREATE INDEX IF NOT EXISTS idx_step_executions_execution_id ON step_executions(execution_id);
-CREATE INDEX IF NOT EXISTS idx_step_executions_step_name ON step_executions(step_name);
-CREATE INDEX IF NOT EXISTS idx_step_outputs_step_id ON step_outputs(step_execution_id);
-
-CREATE TABLE IF NOT EXISTS schema_version (
-    version INTEGER PRIMARY KEY,
-    applied_at TEXT NOT NULL DEFAULT (datetime('now'))
-);
-
-INSERT OR IGNORE INTO schema_version (version) VALUES (1);
-`
-
 // Close closes the database connection
 func (s *SQLiteStorage) Close() error {
+	s.stopPruning()
 	return s.db.Close()
 }
 
@@ -145,7 +100,10 @@ func (s *SQLiteStorage) SaveExecution(ctx context.Context, exec *domain.Executio
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	execID := uuid.New().String()
+	execID := exec.ID
+	if execID == "" {
+		execID = uuid.New().String()
+	}
 
 	// Insert execution
 	_, err = tx.ExecContext(ctx, `
@@ -168,7 +126,8 @@ func (s *SQLiteStorage) SaveExecution(ctx context.Context, exec *domain.Executio
 	}
 
 	// Insert steps
-	for _, step := range exec.Steps {
+	maxLines, outputDir := s.outputLimit()
+	for i, step := range exec.Steps {
 		stepID := uuid.New().String()
 
 		_, err = tx.ExecContext(ctx, `
@@ -191,21 +150,88 @@ func (s *SQLiteStorage) SaveExecution(ctx context.Context, exec *domain.Executio
 			return fmt.Errorf("failed to insert step: %w", err)
 		}
 
+		if step.Predicted > 0 {
+			if err := insertEstimate(ctx, tx, execID, step.Name, step.Predicted, step.Duration, string(step.Status)); err != nil {
+				return err
+			}
+		}
+
 		// Insert step output lines (limit to prevent huge databases)
-		maxLines := 1000
 		outputLines := step.Output
 		if len(outputLines) > maxLines {
 			outputLines = outputLines[len(outputLines)-maxLines:]
+			if outputDir != "" {
+				path, err := writeOutputFile(outputDir, execID, i, step)
+				if err != nil {
+					return err
+				}
+				if err := insertOutputFile(ctx, tx, stepID, path); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := insertOutput(ctx, tx, stepID, outputLines); err != nil {
+			return fmt.Errorf("failed to insert output lines: %w", err)
+		}
+
+		if err := insertAttempts(ctx, tx, stepID, step, maxLines); err != nil {
+			return err
+		}
+
+		if !step.Usage.IsZero() {
+			if err := insertUsage(ctx, tx, stepID, step.Usage); err != nil {
+				return err
+			}
 		}
 
-		// PERF-002 fix: Use bulk INSERT for step outputs
-		if len(outputLines) > 0 {
-			if err := s.bulkInsertStepOutputs(ctx, tx, stepID, outputLines); err != nil {
-				return fmt.Errorf("failed to insert output lines: %w", err)
+		if step.PromptOverride != "" {
+			if err := insertPrompt(ctx, tx, stepID, step.PromptOverride); err != nil {
+				return err
 			}
 		}
 	}
 
+	if exec.Predicted > 0 {
+		if err := insertEstimate(ctx, tx, execID, "", exec.Predicted, exec.Duration, string(exec.Status)); err != nil {
+			return err
+		}
+	}
+
+	if exec.Snapshot != nil {
+		if err := insertSnapshot(ctx, tx, execID, exec.Snapshot); err != nil {
+			return err
+		}
+	}
+
+	if exec.Context != nil {
+		if err := insertContext(ctx, tx, execID, exec.Context); err != nil {
+			return err
+		}
+	}
+
+	if exec.Branch != "" {
+		if err := insertBranch(ctx, tx, execID, exec.Branch); err != nil {
+			return err
+		}
+	}
+
+	if exec.Stash != "" {
+		if err := insertStash(ctx, tx, execID, exec.Stash); err != nil {
+			return err
+		}
+	}
+
+	if !exec.Deadline.IsZero() {
+		if err := insertDeadline(ctx, tx, execID, exec); err != nil {
+			return err
+		}
+	}
+
+	if err := insertTags(ctx, tx, execID, exec.Tags); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -232,6 +258,38 @@ func (s *SQLiteStorage) GetExecution(ctx context.Context, id string) (*Execution
 	}
 	rec.Steps = steps
 
+	rec.Snapshot, err = s.getSnapshot(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.Context, err = s.getContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.Branch, err = s.getBranch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.Stash, err = s.getStash(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.getTagsBatch(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	rec.Tags = tags[id]
+
+	notes, err := s.getNotesBatch(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	rec.Note = notes[id]
+
 	return rec, nil
 }
 
@@ -249,6 +307,11 @@ func (s *SQLiteStorage) GetExecutionWithOutput(ctx context.Context, id string) (
 			return nil, err
 		}
 		step.Output = output
+
+		step.PreviousAttempts, err = s.previousAttempts(ctx, step)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return rec, nil
@@ -257,6 +320,12 @@ func (s *SQLiteStorage) GetExecutionWithOutput(ctx context.Context, id string) (
 // ListExecutions retrieves executions matching the filter
 // PERF-001 fix: Uses batch loading instead of N+1 queries
 func (s *SQLiteStorage) ListExecutions(ctx context.Context, filter *ExecutionFilter) ([]*ExecutionRecord, error) {
+	if filter.Cursor != "" {
+		if _, _, err := decodeCursor(filter.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
 	query := `
 		SELECT id, story_key, story_epic, story_status, story_title, status, start_time, end_time, duration_ms, error, created_at
 		FROM executions
@@ -265,7 +334,9 @@ func (s *SQLiteStorage) ListExecutions(ctx context.Context, filter *ExecutionFil
 	if where != "" {
 		query += " WHERE " + where
 	}
-	query += " ORDER BY created_at DESC"
+	// Insertion order breaks ties between executions recorded in the same
+	// second, so a cursor continues exactly where its page ended
+	query += " ORDER BY created_at DESC, rowid DESC"
 
 	limit := filter.Limit
 	if limit <= 0 {
@@ -303,6 +374,19 @@ func (s *SQLiteStorage) ListExecutions(ctx context.Context, filter *ExecutionFil
 		for _, rec := range records {
 			rec.Steps = stepsByExecution[rec.ID]
 		}
+
+		tagsByExecution, err := s.getTagsBatch(ctx, executionIDs)
+		if err != nil {
+			return nil, err
+		}
+		notesByExecution, err := s.getNotesBatch(ctx, executionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			rec.Tags = tagsByExecution[rec.ID]
+			rec.Note = notesByExecution[rec.ID]
+		}
 	}
 
 	return records, nil
@@ -327,32 +411,12 @@ func (s *SQLiteStorage) DeleteExecution(ctx context.Context, id string) error {
 	return err
 }
 
-// GetStepOutput retrieves output lines for a step
-func (s *SQLiteStorage) GetStepOutput(ctx context.Context, stepID string) ([]string, error) {
-	rows, err := s.db.QueryContext(ctx, `
-		SELECT content FROM step_outputs
-		WHERE step_execution_id = ?
-		ORDER BY line_number
-	`, stepID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var output []string
-	for rows.Next() {
-		var line string
-		if err := rows.Scan(&line); err != nil {
-			return nil, err
-		}
-		output = append(output, line)
-	}
-
-	return output, rows.Err()
-}
+// GetStats returns aggregate statistics over the executions started within
+// r; the zero range covers all of them
+func (s *SQLiteStorage) GetStats(ctx context.Context, r domain.DateRange) (*Stats, error) {
+	inRange, args := rangeCondition(r)
+	stepsInRange := "execution_id IN (SELECT id FROM executions WHERE " + inRange + ")"
 
-// GetStats returns aggregate statistics
-func (s *SQLiteStorage) GetStats(ctx context.Context) (*Stats, error) {
 	stats := &Stats{
 		StepStats:        make(map[domain.StepName]*StepStats),
 		ExecutionsByDay:  make(map[string]int),
@@ -369,7 +433,7 @@ func (s *SQLiteStorage) GetStats(ctx context.Context) (*Stats, error) {
 			COALESCE(AVG(duration_ms), 0) as avg_duration,
 			COALESCE(SUM(duration_ms), 0) as total_duration
 		FROM executions
-	`).Scan(
+		WHERE `+inRange, args...).Scan(
 		&stats.TotalExecutions,
 		&stats.SuccessfulCount,
 		&stats.FailedCount,
@@ -402,8 +466,9 @@ func (s *SQLiteStorage) GetStats(ctx context.Context) (*Stats, error) {
 			COALESCE(MIN(CASE WHEN status = 'success' THEN duration_ms END), 0) as min_duration,
 			COALESCE(MAX(CASE WHEN status = 'success' THEN duration_ms END), 0) as max_duration
 		FROM step_executions
+		WHERE `+stepsInRange+`
 		GROUP BY step_name
-	`)
+	`, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get step stats: %w", err)
 	}
@@ -426,14 +491,56 @@ func (s *SQLiteStorage) GetStats(ctx context.Context) (*Stats, error) {
 		stats.StepStats[ss.StepName] = &ss
 	}
 
-	// Executions by day (last 30 days)
+	attemptStats, err := s.getAttemptStats(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	for name, as := range attemptStats {
+		if ss, ok := stats.StepStats[name]; ok {
+			ss.Attempts = as
+		}
+	}
+
+	// Step duration distributions (successful runs only)
+	durationRows, err := s.db.QueryContext(ctx, `
+		SELECT step_name, duration_ms
+		FROM step_executions
+		WHERE status = 'success' AND `+stepsInRange, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get step durations: %w", err)
+	}
+	defer durationRows.Close()
+
+	stepDurations := make(map[domain.StepName][]time.Duration)
+	for durationRows.Next() {
+		var stepName string
+		var durationMs int64
+		if err := durationRows.Scan(&stepName, &durationMs); err != nil {
+			return nil, err
+		}
+		name := domain.StepName(stepName)
+		stepDurations[name] = append(stepDurations[name], time.Duration(durationMs)*time.Millisecond)
+	}
+	for name, durations := range stepDurations {
+		if ss, ok := stats.StepStats[name]; ok {
+			ss.DurationHistogram = util.DurationHistogram(durations, StepHistogramBuckets)
+		}
+	}
+
+	// Executions by day, over the range or the last 30 days when it has
+	// no start
+	dayRange := r
+	if dayRange.Since.IsZero() {
+		dayRange.Since = time.Now().AddDate(0, 0, -30)
+	}
+	inDayRange, dayArgs := rangeCondition(dayRange)
 	dayRows, err := s.db.QueryContext(ctx, `
 		SELECT date(created_at) as day, COUNT(*) as count
 		FROM executions
-		WHERE created_at >= datetime('now', '-30 days')
+		WHERE `+inDayRange+`
 		GROUP BY day
 		ORDER BY day DESC
-	`)
+	`, dayArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get executions by day: %w", err)
 	}
@@ -452,9 +559,10 @@ func (s *SQLiteStorage) GetStats(ctx context.Context) (*Stats, error) {
 	epicRows, err := s.db.QueryContext(ctx, `
 		SELECT story_epic, COUNT(*) as count
 		FROM executions
+		WHERE `+inRange+`
 		GROUP BY story_epic
 		ORDER BY story_epic
-	`)
+	`, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get executions by epic: %w", err)
 	}
@@ -469,7 +577,15 @@ func (s *SQLiteStorage) GetStats(ctx context.Context) (*Stats, error) {
 	}
 
 	// Recent executions (last 10)
-	stats.RecentExecutions, err = s.GetRecentExecutions(ctx, 10)
+	recent := &ExecutionFilter{Limit: 10}
+	if !r.Since.IsZero() {
+		recent.StartAfter = &r.Since
+	}
+	if !r.Until.IsZero() {
+		until := r.Until.Add(-time.Second)
+		recent.StartBefore = &until
+	}
+	stats.RecentExecutions, err = s.ListExecutions(ctx, recent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get recent executions: %w", err)
 	}
@@ -477,6 +593,23 @@ func (s *SQLiteStorage) GetStats(ctx context.Context) (*Stats, error) {
 	return stats, nil
 }
 
+// rangeCondition returns the condition on the executions table that limits
+// it to executions started within r, and its arguments. Times are compared
+// with datetime() so the zone they were stored in does not matter.
+func rangeCondition(r domain.DateRange) (string, []any) {
+	conditions := []string{"1 = 1"}
+	var args []any
+	if !r.Since.IsZero() {
+		conditions = append(conditions, "datetime(start_time) >= datetime(?)")
+		args = append(args, r.Since.Format(time.RFC3339))
+	}
+	if !r.Until.IsZero() {
+		conditions = append(conditions, "datetime(start_time) < datetime(?)")
+		args = append(args, r.Until.Format(time.RFC3339))
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
 // GetStepAverages returns historical averages for each step
 func (s *SQLiteStorage) GetStepAverages(ctx context.Context) (map[domain.StepName]*StepAverage, error) {
 	rows, err := s.db.QueryContext(ctx, `
@@ -537,12 +670,17 @@ func (s *SQLiteStorage) GetExecutionsByStory(ctx context.Context, storyKey strin
 
 // Helper functions
 
+// getSteps loads an execution's steps in the order they ran; step IDs are
+// random, so rows are ordered by insertion
 func (s *SQLiteStorage) getSteps(ctx context.Context, executionID string, includeOutput bool) ([]*StepRecord, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, execution_id, step_name, status, start_time, end_time, duration_ms, attempt, command, error, output_size
-		FROM step_executions
-		WHERE execution_id = ?
-		ORDER BY id
+		SELECT `+stepColumns+`
+		FROM step_executions s
+		LEFT JOIN step_usage u ON u.step_execution_id = s.id
+		LEFT JOIN step_prompts p ON p.step_execution_id = s.id
+		LEFT JOIN step_output_files f ON f.step_execution_id = s.id
+		WHERE s.execution_id = ?
+		ORDER BY s.rowid
 	`, executionID)
 	if err != nil {
 		return nil, err
@@ -576,10 +714,13 @@ func (s *SQLiteStorage) getStepsBatch(ctx context.Context, executionIDs []string
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, execution_id, step_name, status, start_time, end_time, duration_ms, attempt, command, error, output_size
-		FROM step_executions
-		WHERE execution_id IN (%s)
-		ORDER BY execution_id, id
+		SELECT `+stepColumns+`
+		FROM step_executions s
+		LEFT JOIN step_usage u ON u.step_execution_id = s.id
+		LEFT JOIN step_prompts p ON p.step_execution_id = s.id
+		LEFT JOIN step_output_files f ON f.step_execution_id = s.id
+		WHERE s.execution_id IN (%s)
+		ORDER BY s.execution_id, s.rowid
 	`, strings.Join(placeholders, ","))
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
@@ -639,6 +780,7 @@ func scanExecution(row *sql.Row) (*ExecutionRecord, error) {
 	}
 	if createdAt.Valid {
 		rec.CreatedAt, _ = time.Parse(time.RFC3339, createdAt.String)
+		rec.createdAt = createdAt.String
 	}
 	if errStr.Valid {
 		rec.Error = errStr.String
@@ -683,6 +825,7 @@ func scanExecutionFromRows(rows *sql.Rows) (*ExecutionRecord, error) {
 	}
 	if createdAt.Valid {
 		rec.CreatedAt, _ = time.Parse(time.RFC3339, createdAt.String)
+		rec.createdAt = createdAt.String
 	}
 	if errStr.Valid {
 		rec.Error = errStr.String
@@ -691,6 +834,14 @@ func scanExecutionFromRows(rows *sql.Rows) (*ExecutionRecord, error) {
 	return &rec, nil
 }
 
+// stepColumns are the columns scanStep reads, from step_executions s joined
+// with step_usage u, step_prompts p and step_output_files f
+const stepColumns = `s.id, s.execution_id, s.step_name, s.status, s.start_time, s.end_time,
+	s.duration_ms, s.attempt, s.command, s.error, s.output_size,
+	COALESCE(u.input_tokens, 0), COALESCE(u.output_tokens, 0),
+	COALESCE(u.cache_read_tokens, 0), COALESCE(u.cache_write_tokens, 0), COALESCE(u.cost_usd, 0),
+	COALESCE(p.prompt, ''), COALESCE(f.path, '')`
+
 func scanStep(rows *sql.Rows) (*StepRecord, error) {
 	var step StepRecord
 	var startTime, endTime sql.NullString
@@ -710,6 +861,13 @@ func scanStep(rows *sql.Rows) (*StepRecord, error) {
 		&cmd,
 		&errStr,
 		&step.OutputSize,
+		&step.Usage.InputTokens,
+		&step.Usage.OutputTokens,
+		&step.Usage.CacheReadTokens,
+		&step.Usage.CacheWriteTokens,
+		&step.Usage.CostUSD,
+		&step.PromptOverride,
+		&step.OutputFile,
 	)
 	if err != nil {
 		return nil, err
@@ -757,6 +915,11 @@ func buildWhereClause(filter *ExecutionFilter) (string, []any) {
 		conditions = append(conditions, "story_key LIKE ? ESCAPE '\\'")
 		args = append(args, "%"+escapeLikeWildcards(filter.StoryKey)+"%")
 	}
+	if filter.Text != "" {
+		pattern := "%" + escapeLikeWildcards(filter.Text) + "%"
+		conditions = append(conditions, "(story_key LIKE ? ESCAPE '\\' OR story_title LIKE ? ESCAPE '\\' OR error LIKE ? ESCAPE '\\')")
+		args = append(args, pattern, pattern, pattern)
+	}
 	if filter.Epic != nil {
 		conditions = append(conditions, "story_epic = ?")
 		args = append(args, *filter.Epic)
@@ -765,6 +928,10 @@ func buildWhereClause(filter *ExecutionFilter) (string, []any) {
 		conditions = append(conditions, "status = ?")
 		args = append(args, string(filter.Status))
 	}
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, "id IN (SELECT execution_id FROM execution_tags WHERE tag = ?)")
+		args = append(args, tag)
+	}
 	if filter.StartAfter != nil {
 		conditions = append(conditions, "start_time >= ?")
 		args = append(args, filter.StartAfter.Format(time.RFC3339))
@@ -773,6 +940,18 @@ func buildWhereClause(filter *ExecutionFilter) (string, []any) {
 		conditions = append(conditions, "start_time <= ?")
 		args = append(args, filter.StartBefore.Format(time.RFC3339))
 	}
+	if filter.GroupBy != "" {
+		if expr, err := groupExpr(filter.GroupBy); err == nil {
+			conditions = append(conditions, expr+" = ?")
+			args = append(args, filter.GroupKey)
+		}
+	}
+	if filter.Cursor != "" {
+		if createdAt, id, err := decodeCursor(filter.Cursor); err == nil {
+			conditions = append(conditions, "(created_at < ? OR (created_at = ? AND rowid < (SELECT rowid FROM executions WHERE id = ?)))")
+			args = append(args, createdAt, createdAt, id)
+		}
+	}
 
 	return strings.Join(conditions, " AND "), args
 }
@@ -791,46 +970,6 @@ func nullableString(s string) any {
 	return s
 }
 
-// bulkInsertStepOutputs inserts multiple step output lines in batches (PERF-002 fix)
-// SQLite has a limit on the number of variables (default 999), so we batch the inserts
-func (s *SQLiteStorage) bulkInsertStepOutputs(ctx context.Context, tx *sql.Tx, stepID string, lines []string) error {
-	if len(lines) == 0 {
-		return nil
-	}
-
-	// SQLite's default SQLITE_MAX_VARIABLE_NUMBER is 999
-	// Each row uses 4 parameters, so max rows per batch is 249
-	const maxRowsPerBatch = 200
-
-	for batchStart := 0; batchStart < len(lines); batchStart += maxRowsPerBatch {
-		batchEnd := batchStart + maxRowsPerBatch
-		if batchEnd > len(lines) {
-			batchEnd = len(lines)
-		}
-		batch := lines[batchStart:batchEnd]
-
-		// Build the multi-value INSERT query
-		var queryBuilder strings.Builder
-		queryBuilder.WriteString("INSERT INTO step_outputs (step_execution_id, line_number, content, is_stderr) VALUES ")
-
-		args := make([]any, 0, len(batch)*4)
-		for i, line := range batch {
-			if i > 0 {
-				queryBuilder.WriteString(",")
-			}
-			queryBuilder.WriteString("(?,?,?,?)")
-			args = append(args, stepID, batchStart+i, line, false)
-		}
-
-		_, err := tx.ExecContext(ctx, queryBuilder.String(), args...)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 // GetDatabasePath returns the default database path
 func GetDatabasePath(dataDir string) string {
 	return filepath.Join(dataDir, "bmad.db")
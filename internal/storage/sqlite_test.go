@@ -70,6 +70,29 @@ func TestNewInMemoryStorage(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, s)
 	defer s.Close()
+
+	t.Run("shares one database across concurrent callers", func(t *testing.T) {
+		ctx := context.Background()
+		// The transaction holds a connection, so the count needs another one
+		tx, err := s.db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := s.CountExecutions(ctx, nil)
+			done <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, tx.Commit())
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("count did not finish")
+		}
+		assert.Equal(t, 1, s.db.Stats().MaxOpenConnections)
+	})
 }
 
 func TestSQLiteStorage_SaveExecution(t *testing.T) {
@@ -89,6 +112,20 @@ func TestSQLiteStorage_SaveExecution(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
+	t.Run("keeps the execution ID", func(t *testing.T) {
+		s, _ := NewInMemoryStorage()
+		defer s.Close()
+
+		exec := createMinimalExecution(createTestStory("3-1-test", 3, domain.StatusInProgress))
+		exec.ID = "0b7c1d2e-trailer-id"
+
+		require.NoError(t, s.SaveExecution(context.Background(), exec))
+
+		record, err := s.GetExecution(context.Background(), exec.ID)
+		require.NoError(t, err)
+		assert.Equal(t, exec.ID, record.ID)
+	})
+
 	t.Run("saves execution with steps", func(t *testing.T) {
 		s, _ := NewInMemoryStorage()
 		defer s.Close()
@@ -106,6 +143,20 @@ func TestSQLiteStorage_SaveExecution(t *testing.T) {
 		assert.Len(t, records[0].Steps, 4)
 	})
 
+	t.Run("saves prompts edited for the run", func(t *testing.T) {
+		s, _ := NewInMemoryStorage()
+		defer s.Close()
+
+		exec := createCompletedExecution(createTestStory("3-1-test", 3, domain.StatusInProgress))
+		exec.Steps[1].PromptOverride = "Only fix the login test"
+		require.NoError(t, s.SaveExecution(context.Background(), exec))
+
+		record, err := s.GetExecution(context.Background(), exec.ID)
+		require.NoError(t, err)
+		assert.Empty(t, record.Steps[0].PromptOverride)
+		assert.Equal(t, "Only fix the login test", record.Steps[1].PromptOverride)
+	})
+
 	t.Run("saves execution with output", func(t *testing.T) {
 		s, _ := NewInMemoryStorage()
 		defer s.Close()
@@ -182,6 +233,15 @@ func TestSQLiteStorage_GetExecution(t *testing.T) {
 		assert.Equal(t, domain.ExecutionCompleted, rec.Status)
 	})
 
+	t.Run("returns steps in the order they ran", func(t *testing.T) {
+		rec, err := s.GetExecution(ctx, execID)
+		require.NoError(t, err)
+		require.Len(t, rec.Steps, len(exec.Steps))
+		for i, step := range exec.Steps {
+			assert.Equal(t, step.Name, rec.Steps[i].StepName)
+		}
+	})
+
 	t.Run("returns error for non-existent ID", func(t *testing.T) {
 		_, err := s.GetExecution(ctx, "non-existent-id")
 		assert.Error(t, err)
@@ -406,7 +466,7 @@ func TestSQLiteStorage_GetStats(t *testing.T) {
 	}
 
 	t.Run("calculates overall stats", func(t *testing.T) {
-		stats, err := s.GetStats(ctx)
+		stats, err := s.GetStats(ctx, domain.DateRange{})
 		require.NoError(t, err)
 
 		assert.Equal(t, 4, stats.TotalExecutions)
@@ -417,19 +477,62 @@ func TestSQLiteStorage_GetStats(t *testing.T) {
 	})
 
 	t.Run("includes step stats", func(t *testing.T) {
-		stats, err := s.GetStats(ctx)
+		stats, err := s.GetStats(ctx, domain.DateRange{})
 		require.NoError(t, err)
 
 		assert.NotEmpty(t, stats.StepStats)
 	})
 
+	t.Run("includes step duration histograms", func(t *testing.T) {
+		stats, err := s.GetStats(ctx, domain.DateRange{})
+		require.NoError(t, err)
+
+		for _, ss := range stats.StepStats {
+			if ss.SuccessCount == 0 {
+				continue
+			}
+			require.Len(t, ss.DurationHistogram, StepHistogramBuckets)
+			total := 0
+			for _, c := range ss.DurationHistogram {
+				total += c
+			}
+			assert.Equal(t, ss.SuccessCount, total)
+		}
+	})
+
 	t.Run("includes executions by epic", func(t *testing.T) {
-		stats, err := s.GetStats(ctx)
+		stats, err := s.GetStats(ctx, domain.DateRange{})
 		require.NoError(t, err)
 
 		assert.NotEmpty(t, stats.ExecutionsByEpic)
 		assert.Equal(t, 4, stats.ExecutionsByEpic[3])
 	})
+
+	t.Run("limits to the date range", func(t *testing.T) {
+		old := createCompletedExecution(createTestStory("4-1-old", 4, domain.StatusDone))
+		old.StartTime = time.Now().AddDate(0, 0, -40).UTC()
+		old.Status = domain.ExecutionFailed
+		require.NoError(t, s.SaveExecution(ctx, old))
+
+		all, err := s.GetStats(ctx, domain.DateRange{})
+		require.NoError(t, err)
+		assert.Equal(t, 5, all.TotalExecutions)
+
+		recent, err := s.GetStats(ctx, domain.LastDays(30, time.Now()))
+		require.NoError(t, err)
+		assert.Equal(t, 4, recent.TotalExecutions)
+		assert.Equal(t, 1, recent.FailedCount)
+		assert.Zero(t, recent.ExecutionsByEpic[4])
+		assert.Len(t, recent.RecentExecutions, 4)
+		assert.Equal(t, 4, recent.StepStats[domain.StepDevStory].TotalCount)
+
+		before, err := s.GetStats(ctx, domain.DateRange{Until: time.Now().AddDate(0, 0, -30)})
+		require.NoError(t, err)
+		assert.Equal(t, 1, before.TotalExecutions)
+		assert.Equal(t, 1, before.ExecutionsByEpic[4])
+		require.Len(t, before.RecentExecutions, 1)
+		assert.Equal(t, "4-1-old", before.RecentExecutions[0].StoryKey)
+	})
 }
 
 func TestSQLiteStorage_GetStepAverages(t *testing.T) {
@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrStateNotFound is returned by GetState when a key has no value
+var ErrStateNotFound = errors.New("state not found")
+
+// State key namespaces. Subsystems keep their keys under one of these
+// prefixes so ListStateKeys can enumerate them.
+const (
+	StateUIPrefix       = "ui."
+	StateQueuePrefix    = "queue."
+	StateSchedulePrefix = "schedule."
+	StateFeaturePrefix  = "feature."
+)
+
+// SetState stores a value under key, replacing any existing value
+func (s *SQLiteStorage) SetState(ctx context.Context, key string, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO app_state (key, value, updated_at) VALUES (?, ?, datetime('now'))
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at
+	`, key, value)
+	if err != nil {
+		return fmt.Errorf("failed to set state %q: %w", key, err)
+	}
+	return nil
+}
+
+// GetState returns the value stored under key, or ErrStateNotFound
+func (s *SQLiteStorage) GetState(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM app_state WHERE key = ?", key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrStateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state %q: %w", key, err)
+	}
+	return value, nil
+}
+
+// DeleteState removes key. Deleting a missing key is not an error.
+func (s *SQLiteStorage) DeleteState(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM app_state WHERE key = ?", key)
+	if err != nil {
+		return fmt.Errorf("failed to delete state %q: %w", key, err)
+	}
+	return nil
+}
+
+// ListStateKeys returns all keys starting with prefix, sorted
+func (s *SQLiteStorage) ListStateKeys(ctx context.Context, prefix string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT key FROM app_state
+		WHERE key LIKE ? ESCAPE '\'
+		ORDER BY key
+	`, escapeLikeWildcards(prefix)+"%")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list state keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// SetStateJSON stores v as JSON under key
+func SetStateJSON(ctx context.Context, s Storage, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode state %q: %w", key, err)
+	}
+	return s.SetState(ctx, key, data)
+}
+
+// GetStateJSON decodes the JSON stored under key into v. It reports false
+// without an error when the key does not exist.
+func GetStateJSON(ctx context.Context, s Storage, key string, v any) (bool, error) {
+	data, err := s.GetState(ctx, key)
+	if errors.Is(err, ErrStateNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("failed to decode state %q: %w", key, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStorage_State(t *testing.T) {
+	store, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	t.Run("missing key returns ErrStateNotFound", func(t *testing.T) {
+		_, err := store.GetState(ctx, "ui.missing")
+		assert.ErrorIs(t, err, ErrStateNotFound)
+	})
+
+	t.Run("set and get round trip", func(t *testing.T) {
+		require.NoError(t, store.SetState(ctx, "ui.active_view", []byte("queue")))
+
+		value, err := store.GetState(ctx, "ui.active_view")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("queue"), value)
+	})
+
+	t.Run("set overwrites existing value", func(t *testing.T) {
+		require.NoError(t, store.SetState(ctx, "feature.sparkles", []byte("on")))
+		require.NoError(t, store.SetState(ctx, "feature.sparkles", []byte("off")))
+
+		value, err := store.GetState(ctx, "feature.sparkles")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("off"), value)
+	})
+
+	t.Run("nil value is stored as empty", func(t *testing.T) {
+		require.NoError(t, store.SetState(ctx, "ui.empty", nil))
+
+		value, err := store.GetState(ctx, "ui.empty")
+		require.NoError(t, err)
+		assert.Empty(t, value)
+	})
+
+	t.Run("delete removes key", func(t *testing.T) {
+		require.NoError(t, store.SetState(ctx, "queue.snapshot", []byte("{}")))
+		require.NoError(t, store.DeleteState(ctx, "queue.snapshot"))
+
+		_, err := store.GetState(ctx, "queue.snapshot")
+		assert.ErrorIs(t, err, ErrStateNotFound)
+
+		// Deleting again is a no-op
+		assert.NoError(t, store.DeleteState(ctx, "queue.snapshot"))
+	})
+
+	t.Run("list keys by prefix", func(t *testing.T) {
+		require.NoError(t, store.SetState(ctx, "schedule.nightly", []byte("0 2 * * *")))
+		require.NoError(t, store.SetState(ctx, "schedule.weekly", []byte("0 3 * * 1")))
+		require.NoError(t, store.SetState(ctx, "schedulex", []byte("not in namespace")))
+
+		keys, err := store.ListStateKeys(ctx, StateSchedulePrefix)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"schedule.nightly", "schedule.weekly"}, keys)
+	})
+
+	t.Run("prefix wildcards are escaped", func(t *testing.T) {
+		require.NoError(t, store.SetState(ctx, "ui_other", []byte("x")))
+
+		keys, err := store.ListStateKeys(ctx, "ui_")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ui_other"}, keys)
+	})
+}
+
+func TestStateJSON(t *testing.T) {
+	store, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer store.Close()
+
+	ctx := context.Background()
+
+	type snapshot struct {
+		Keys   []string `json:"keys"`
+		Paused bool     `json:"paused"`
+	}
+
+	t.Run("missing key reports not found", func(t *testing.T) {
+		var got snapshot
+		found, err := GetStateJSON(ctx, store, "queue.none", &got)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("round trips a struct", func(t *testing.T) {
+		want := snapshot{Keys: []string{"3-1-auth", "3-2-reset"}, Paused: true}
+		require.NoError(t, SetStateJSON(ctx, store, "queue.current", want))
+
+		var got snapshot
+		found, err := GetStateJSON(ctx, store, "queue.current", &got)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("invalid JSON returns error", func(t *testing.T) {
+		require.NoError(t, store.SetState(ctx, "queue.corrupt", []byte("{not json")))
+
+		var got snapshot
+		_, err := GetStateJSON(ctx, store, "queue.corrupt", &got)
+		assert.Error(t, err)
+	})
+}
@@ -21,6 +21,14 @@ type ExecutionRecord struct {
 	Error       string
 	CreatedAt   time.Time
 	Steps       []*StepRecord
+	Snapshot    *domain.WorkspaceSnapshot // Pre-run workspace, loaded by GetExecution
+	Context     *domain.ExecutionContext  // Environment at start, loaded by GetExecution
+	Branch      string                    // Story branch the steps ran on, loaded by GetExecution
+	Stash       string                    // Auto-stash left unrestored, loaded by GetExecution
+	Tags        []string                  // Labels attached at enqueue time
+	Note        string                    // Added from history afterwards, "" if none
+
+	createdAt string // created_at as stored, for Cursor
 }
 
 // StepRecord represents a stored step execution
@@ -36,7 +44,20 @@ type StepRecord struct {
 	Command     string
 	Error       string
 	OutputSize  int
-	Output      []string // Loaded on demand
+	Output      []string     // Loaded on demand
+	Usage       domain.Usage // Zero unless usage tracking was on
+
+	// PromptOverride is the prompt edited before the run, "" when the
+	// workflow's prompt was sent
+	PromptOverride string
+
+	// OutputFile holds the step's full output when only its last lines
+	// were stored, "" otherwise
+	OutputFile string
+
+	// PreviousAttempts holds the attempts before the final one, loaded
+	// with the output
+	PreviousAttempts []*domain.StepAttempt
 }
 
 // StepAverage represents historical averages for a step
@@ -52,12 +73,17 @@ type StepAverage struct {
 // ExecutionFilter provides filtering options for listing executions
 type ExecutionFilter struct {
 	StoryKey    string                 // Filter by story key (partial match)
+	Text        string                 // Filter by story key, title or error (partial match)
 	Epic        *int                   // Filter by epic number
 	Status      domain.ExecutionStatus // Filter by status
+	Tags        []string               // Filter by tags (all must be present)
 	StartAfter  *time.Time             // Filter by start time
 	StartBefore *time.Time             // Filter by start time
-	Limit       int                    // Max results (default 100)
-	Offset      int                    // Pagination offset
+	GroupBy     GroupBy                // With GroupKey, limit to one group from GroupExecutions
+	GroupKey    string
+	Limit       int    // Max results (default 100)
+	Offset      int    // Pagination offset
+	Cursor      string // Only executions listed after the one whose Cursor this is
 }
 
 // Stats represents aggregate statistics
@@ -86,8 +112,16 @@ type StepStats struct {
 	AvgDuration  time.Duration
 	MinDuration  time.Duration
 	MaxDuration  time.Duration
+	// DurationHistogram holds counts of successful runs bucketed between
+	// MinDuration and MaxDuration (StepHistogramBuckets bins)
+	DurationHistogram []int
+	// Attempts summarizes retries, nil when no attempts have been recorded
+	Attempts *AttemptStats
 }
 
+// StepHistogramBuckets is the number of bins in StepStats.DurationHistogram
+const StepHistogramBuckets = 8
+
 // Storage defines the interface for persistence operations
 type Storage interface {
 	// Lifecycle
@@ -99,17 +133,53 @@ type Storage interface {
 	GetExecutionWithOutput(ctx context.Context, id string) (*ExecutionRecord, error)
 	ListExecutions(ctx context.Context, filter *ExecutionFilter) ([]*ExecutionRecord, error)
 	CountExecutions(ctx context.Context, filter *ExecutionFilter) (int, error)
+	GroupExecutions(ctx context.Context, by GroupBy, filter *ExecutionFilter) ([]*ExecutionGroup, error)
 	DeleteExecution(ctx context.Context, id string) error
+	Prune(ctx context.Context, policy RetentionPolicy) (int, error)
+	Maintenance(ctx context.Context) (*MaintenanceResult, error)
+	Size(ctx context.Context) (int64, error)
+	ResolveExecutionID(ctx context.Context, idOrPrefix string) (string, error)
+
+	// Notes: free text added to an execution after it ran
+	SetNote(ctx context.Context, executionID, note string) error
 
 	// Step output (loaded separately for performance)
 	GetStepOutput(ctx context.Context, stepID string) ([]string, error)
+	ListOutputSummaries(ctx context.Context) ([]*OutputSummary, error)
+	SearchOutput(ctx context.Context, query string, limit int) ([]*OutputMatch, error)
 
 	// Statistics
-	GetStats(ctx context.Context) (*Stats, error)
+	GetStats(ctx context.Context, r domain.DateRange) (*Stats, error)
+	GetDurationTrend(ctx context.Context, r domain.DateRange) ([]*WeeklyDuration, error)
+	GetStoryReliability(ctx context.Context, r domain.DateRange, limit int) ([]*StoryReliability, error)
 	GetStepAverages(ctx context.Context) (map[domain.StepName]*StepAverage, error)
 	UpdateStepAverages(ctx context.Context) error
+	GetMetrics(ctx context.Context) (*Metrics, error)
+
+	// Estimation: weighted step estimates and predicted-vs-actual accuracy
+	GetStepEstimates(ctx context.Context) (map[domain.StepName]domain.Estimate, error)
+	GetCalibration(ctx context.Context) (*Calibration, error)
+
+	// Token usage and cost
+	GetUsageStats(ctx context.Context) (*UsageStats, error)
+
+	// Deadlines: how many runs with one finished in time
+	GetDeadlineStats(ctx context.Context) (*DeadlineStats, error)
+
+	// Health: per-story score from recent failures, retries and overruns
+	GetStoryHealth(ctx context.Context) (map[string]domain.StoryHealth, error)
 
 	// Recent activity
 	GetRecentExecutions(ctx context.Context, limit int) ([]*ExecutionRecord, error)
 	GetExecutionsByStory(ctx context.Context, storyKey string) ([]*ExecutionRecord, error)
+
+	// Pipeline runs and the progress of their stages
+	SavePipelineRun(ctx context.Context, run *domain.PipelineRun) error
+	ListPipelineRuns(ctx context.Context, limit int) ([]*domain.PipelineRun, error)
+
+	// Key/value app state (UI state, queue snapshots, schedules, feature flags)
+	SetState(ctx context.Context, key string, value []byte) error
+	GetState(ctx context.Context, key string) ([]byte, error)
+	DeleteState(ctx context.Context, key string) error
+	ListStateKeys(ctx context.Context, prefix string) ([]string, error)
 }
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// insertTags records the tags of an execution
+func insertTags(ctx context.Context, tx *sql.Tx, execID string, tags []string) error {
+	for _, tag := range tags {
+		_, err := tx.ExecContext(ctx, `
+			INSERT OR IGNORE INTO execution_tags (execution_id, tag) VALUES (?, ?)
+		`, execID, tag)
+		if err != nil {
+			return fmt.Errorf("failed to insert tag: %w", err)
+		}
+	}
+	return nil
+}
+
+// getTagsBatch returns the tags of several executions in one query, keyed
+// by execution ID and sorted
+func (s *SQLiteStorage) getTagsBatch(ctx context.Context, executionIDs []string) (map[string][]string, error) {
+	placeholders := make([]string, len(executionIDs))
+	args := make([]any, len(executionIDs))
+	for i, id := range executionIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT execution_id, tag FROM execution_tags
+		WHERE execution_id IN (`+strings.Join(placeholders, ",")+`)
+		ORDER BY execution_id, tag
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := make(map[string][]string)
+	for rows.Next() {
+		var id, tag string
+		if err := rows.Scan(&id, &tag); err != nil {
+			return nil, err
+		}
+		tags[id] = append(tags[id], tag)
+	}
+	return tags, rows.Err()
+}
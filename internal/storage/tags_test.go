@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_Tags(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	hotfix := createCompletedExecution(createTestStory("1-1-fix", 1, domain.StatusDone))
+	hotfix.Tags = []string{"hotfix", "sprint-12"}
+	sprint := createCompletedExecution(createTestStory("1-2-feature", 1, domain.StatusDone))
+	sprint.Tags = []string{"sprint-12"}
+	untagged := createCompletedExecution(createTestStory("1-3-other", 1, domain.StatusDone))
+	for _, exec := range []*domain.Execution{hotfix, sprint, untagged} {
+		require.NoError(t, s.SaveExecution(ctx, exec))
+	}
+
+	t.Run("loaded with the execution", func(t *testing.T) {
+		rec, err := s.GetExecution(ctx, hotfix.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hotfix", "sprint-12"}, rec.Tags)
+
+		rec, err = s.GetExecution(ctx, untagged.ID)
+		require.NoError(t, err)
+		assert.Empty(t, rec.Tags)
+	})
+
+	t.Run("filter matches every tag", func(t *testing.T) {
+		keys := func(tags ...string) []string {
+			records, err := s.ListExecutions(ctx, &ExecutionFilter{Tags: tags})
+			require.NoError(t, err)
+			var keys []string
+			for _, rec := range records {
+				keys = append(keys, rec.StoryKey)
+			}
+			return keys
+		}
+		assert.ElementsMatch(t, []string{"1-1-fix", "1-2-feature"}, keys("sprint-12"))
+		assert.Equal(t, []string{"1-1-fix"}, keys("sprint-12", "hotfix"))
+		assert.Empty(t, keys("missing"))
+
+		count, err := s.CountExecutions(ctx, &ExecutionFilter{Tags: []string{"sprint-12"}})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("listed with their tags", func(t *testing.T) {
+		records, err := s.ListExecutions(ctx, &ExecutionFilter{StoryKey: "1-2"})
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, []string{"sprint-12"}, records[0].Tags)
+	})
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// weekOf returns the SQL expression for the Monday of the week the time in
+// column falls in
+func weekOf(column string) string {
+	return "date(datetime(" + column + "), 'weekday 0', '-6 days')"
+}
+
+// WeeklyDuration is how long the executions that completed in a week took
+// on average
+type WeeklyDuration struct {
+	Week  time.Time // Monday the week starts on, in UTC
+	Count int       // Completed executions
+	Avg   time.Duration
+	Steps map[domain.StepName]time.Duration // Average of each step's successful runs
+}
+
+// GetDurationTrend returns the average duration of completed executions,
+// and of each step, per week over r. Weeks are oldest first and only those
+// with a completed execution are included.
+func (s *SQLiteStorage) GetDurationTrend(ctx context.Context, r domain.DateRange) ([]*WeeklyDuration, error) {
+	inRange, args := rangeCondition(r)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+weekOf("start_time")+` AS week, COUNT(*), AVG(duration_ms)
+		FROM executions
+		WHERE status = 'completed' AND `+inRange+`
+		GROUP BY week
+		ORDER BY week
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get duration trend: %w", err)
+	}
+	defer rows.Close()
+
+	var trend []*WeeklyDuration
+	byWeek := make(map[string]*WeeklyDuration)
+	for rows.Next() {
+		var week string
+		var avgMs float64
+		wd := &WeeklyDuration{Steps: make(map[domain.StepName]time.Duration)}
+		if err := rows.Scan(&week, &wd.Count, &avgMs); err != nil {
+			return nil, err
+		}
+		if wd.Week, err = time.Parse(time.DateOnly, week); err != nil {
+			return nil, fmt.Errorf("failed to parse week %q: %w", week, err)
+		}
+		wd.Avg = time.Duration(avgMs) * time.Millisecond
+		trend = append(trend, wd)
+		byWeek[week] = wd
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Steps are counted in the week of the execution they ran in
+	stepRows, err := s.db.QueryContext(ctx, `
+		SELECT `+weekOf("e.start_time")+` AS week, s.step_name, AVG(s.duration_ms)
+		FROM step_executions s
+		JOIN executions e ON e.id = s.execution_id
+		WHERE s.status = 'success' AND e.status = 'completed'
+			AND s.execution_id IN (SELECT id FROM executions WHERE `+inRange+`)
+		GROUP BY week, s.step_name
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get step duration trend: %w", err)
+	}
+	defer stepRows.Close()
+
+	for stepRows.Next() {
+		var week, stepName string
+		var avgMs float64
+		if err := stepRows.Scan(&week, &stepName, &avgMs); err != nil {
+			return nil, err
+		}
+		if wd, ok := byWeek[week]; ok {
+			wd.Steps[domain.StepName(stepName)] = time.Duration(avgMs) * time.Millisecond
+		}
+	}
+	return trend, stepRows.Err()
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_DurationTrend(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	trend, err := s.GetDurationTrend(ctx, domain.DateRange{})
+	require.NoError(t, err)
+	assert.Empty(t, trend)
+
+	story := createTestStory("1-1-test", 1, domain.StatusDone)
+	// A Wednesday and the Monday after it
+	wednesday := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, 3, 9, 10, 0, 0, 0, time.UTC)
+	save := func(start time.Time, d time.Duration, status domain.ExecutionStatus) {
+		exec := createCompletedExecution(story)
+		exec.Status = status
+		exec.StartTime = start
+		exec.Duration = d
+		for _, step := range exec.Steps {
+			step.Duration = d / time.Duration(len(exec.Steps))
+		}
+		require.NoError(t, s.SaveExecution(ctx, exec))
+	}
+	save(wednesday, 4*time.Minute, domain.ExecutionCompleted)
+	save(wednesday.AddDate(0, 0, 1), 8*time.Minute, domain.ExecutionCompleted)
+	save(wednesday, time.Hour, domain.ExecutionFailed) // Not counted
+	save(monday, 12*time.Minute, domain.ExecutionCompleted)
+
+	t.Run("groups completed executions by week", func(t *testing.T) {
+		trend, err := s.GetDurationTrend(ctx, domain.DateRange{})
+		require.NoError(t, err)
+		require.Len(t, trend, 2)
+
+		assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), trend[0].Week)
+		assert.Equal(t, 2, trend[0].Count)
+		assert.Equal(t, 6*time.Minute, trend[0].Avg)
+		assert.Equal(t, 90*time.Second, trend[0].Steps[domain.StepDevStory])
+
+		assert.Equal(t, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), trend[1].Week)
+		assert.Equal(t, 12*time.Minute, trend[1].Avg)
+		assert.Equal(t, 3*time.Minute, trend[1].Steps[domain.StepDevStory])
+	})
+
+	t.Run("limits to the date range", func(t *testing.T) {
+		trend, err := s.GetDurationTrend(ctx, domain.DateRange{Since: monday.Add(-time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, trend, 1)
+		assert.Equal(t, 1, trend[0].Count)
+	})
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// UsageStats adds up the recorded token usage of all executions
+type UsageStats struct {
+	Executions int // Executions with recorded usage
+	Total      domain.Usage
+	Steps      map[domain.StepName]domain.Usage
+}
+
+// PerExecution returns the mean usage of an execution with recorded usage
+func (u *UsageStats) PerExecution() domain.Usage {
+	if u.Executions == 0 {
+		return domain.Usage{}
+	}
+	n := int64(u.Executions)
+	return domain.Usage{
+		InputTokens:      u.Total.InputTokens / n,
+		OutputTokens:     u.Total.OutputTokens / n,
+		CacheReadTokens:  u.Total.CacheReadTokens / n,
+		CacheWriteTokens: u.Total.CacheWriteTokens / n,
+		CostUSD:          u.Total.CostUSD / float64(u.Executions),
+	}
+}
+
+// insertUsage records the token usage of a step
+func insertUsage(ctx context.Context, tx *sql.Tx, stepID string, u domain.Usage) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO step_usage (step_execution_id, input_tokens, output_tokens, cache_read_tokens, cache_write_tokens, cost_usd)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, stepID, u.InputTokens, u.OutputTokens, u.CacheReadTokens, u.CacheWriteTokens, u.CostUSD)
+	if err != nil {
+		return fmt.Errorf("failed to insert step usage: %w", err)
+	}
+	return nil
+}
+
+// GetUsageStats returns the token usage and cost of all executions, in
+// total and per step
+func (s *SQLiteStorage) GetUsageStats(ctx context.Context) (*UsageStats, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT s.step_name, SUM(u.input_tokens), SUM(u.output_tokens),
+			SUM(u.cache_read_tokens), SUM(u.cache_write_tokens), SUM(u.cost_usd)
+		FROM step_usage u
+		JOIN step_executions s ON s.id = u.step_execution_id
+		GROUP BY s.step_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &UsageStats{Steps: make(map[domain.StepName]domain.Usage)}
+	for rows.Next() {
+		var name string
+		var u domain.Usage
+		if err := rows.Scan(&name, &u.InputTokens, &u.OutputTokens,
+			&u.CacheReadTokens, &u.CacheWriteTokens, &u.CostUSD); err != nil {
+			return nil, err
+		}
+		stats.Steps[domain.StepName(name)] = u
+		stats.Total.Add(u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT s.execution_id)
+		FROM step_usage u
+		JOIN step_executions s ON s.id = u.step_execution_id
+	`).Scan(&stats.Executions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count executions with usage: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestSQLiteStorage_Usage(t *testing.T) {
+	s, err := NewInMemoryStorage()
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	t.Run("no usage recorded", func(t *testing.T) {
+		stats, err := s.GetUsageStats(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, stats.Executions)
+		assert.True(t, stats.Total.IsZero())
+		assert.True(t, stats.PerExecution().IsZero())
+	})
+
+	story := createTestStory("1-1-test", 1, domain.StatusDone)
+	dev := domain.Usage{InputTokens: 1000, OutputTokens: 500, CacheReadTokens: 2000, CacheWriteTokens: 100, CostUSD: 0.25}
+
+	first := createCompletedExecution(story)
+	for _, step := range first.Steps {
+		if step.Name == domain.StepDevStory {
+			step.Usage = dev
+		}
+	}
+	require.NoError(t, s.SaveExecution(ctx, first))
+
+	second := createCompletedExecution(story)
+	for _, step := range second.Steps {
+		if step.Name == domain.StepDevStory || step.Name == domain.StepCodeReview {
+			step.Usage = dev
+		}
+	}
+	require.NoError(t, s.SaveExecution(ctx, second))
+
+	// Runs without usage tracking are left out
+	require.NoError(t, s.SaveExecution(ctx, createCompletedExecution(story)))
+
+	t.Run("steps are loaded with their usage", func(t *testing.T) {
+		rec, err := s.GetExecution(ctx, first.ID)
+		require.NoError(t, err)
+		for _, step := range rec.Steps {
+			if step.StepName == domain.StepDevStory {
+				assert.Equal(t, dev, step.Usage)
+			} else {
+				assert.True(t, step.Usage.IsZero())
+			}
+		}
+	})
+
+	t.Run("stats add up usage", func(t *testing.T) {
+		stats, err := s.GetUsageStats(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Executions)
+		assert.Equal(t, int64(3000), stats.Total.InputTokens)
+		assert.InDelta(t, 0.75, stats.Total.CostUSD, 1e-9)
+		assert.Equal(t, int64(2000), stats.Steps[domain.StepDevStory].InputTokens)
+		assert.Equal(t, int64(1000), stats.Steps[domain.StepCodeReview].InputTokens)
+		assert.InDelta(t, 0.375, stats.PerExecution().CostUSD, 1e-9)
+	})
+}
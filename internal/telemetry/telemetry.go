@@ -0,0 +1,294 @@
+// Package telemetry collects opt-in, anonymous usage counts. Nothing is
+// recorded unless the user enables it, and the payload holds only aggregate
+// counts: no story keys, paths, commands, output or identifiers.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// SchemaVersion identifies the payload layout for the receiving end
+const SchemaVersion = 1
+
+// sendTimeout bounds a single report upload
+const sendTimeout = 10 * time.Second
+
+// Failure categories recorded for failed steps and executions
+const (
+	FailureTimeout   = "timeout"
+	FailureStalled   = "stalled"
+	FailureCancelled = "cancelled"
+	FailureExitCode  = "exit_code"
+	FailureConflict  = "merge_conflict"
+	FailureOther     = "other"
+)
+
+// Feature names counted once per run in which they are enabled
+const (
+	FeatureParallel       = "parallel"
+	FeatureWatch          = "watch"
+	FeatureAPI            = "api"
+	FeatureCustomWorkflow = "custom_workflow"
+	FeatureProfile        = "profile"
+	FeatureStallAutoRetry = "stall_auto_retry"
+	FeatureAdaptiveRetry  = "adaptive_retry"
+	FeatureSound          = "sound"
+	FeatureNotifications  = "notifications"
+)
+
+// Report is the payload sent to the telemetry endpoint
+type Report struct {
+	Schema     int            `json:"schema"`
+	OS         string         `json:"os"`
+	Arch       string         `json:"arch"`
+	Executions ExecutionCount `json:"executions"`
+	Steps      StepCount      `json:"steps"`
+	Failures   map[string]int `json:"failure_categories"`
+	Features   map[string]int `json:"feature_usage"`
+}
+
+// ExecutionCount counts finished executions by outcome
+type ExecutionCount struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Cancelled int `json:"cancelled"`
+	Parked    int `json:"parked"`
+}
+
+// StepCount counts finished steps by outcome
+type StepCount struct {
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// Recorder accumulates usage counts until they are sent
+type Recorder struct {
+	mu       sync.Mutex
+	enabled  bool
+	endpoint string
+	client   *http.Client
+	report   Report
+}
+
+// New creates a recorder. Counts are only kept while enabled.
+func New(enabled bool, endpoint string) *Recorder {
+	r := &Recorder{
+		enabled:  enabled,
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: sendTimeout},
+	}
+	r.reset()
+	return r
+}
+
+func (r *Recorder) reset() {
+	r.report = Report{
+		Schema:   SchemaVersion,
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		Failures: map[string]int{},
+		Features: map[string]int{},
+	}
+}
+
+// SetEnabled turns recording on or off. Turning it off discards anything
+// not yet sent.
+func (r *Recorder) SetEnabled(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enabled = enabled
+	if !enabled {
+		r.reset()
+	}
+}
+
+// IsEnabled returns whether recording is on
+func (r *Recorder) IsEnabled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled
+}
+
+// Endpoint returns where reports are sent
+func (r *Recorder) Endpoint() string {
+	return r.endpoint
+}
+
+// RecordStep counts a finished step, with errMsg categorized for failures
+func (r *Recorder) RecordStep(status domain.StepStatus, errMsg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return
+	}
+
+	switch status {
+	case domain.StepSuccess:
+		r.report.Steps.Succeeded++
+	case domain.StepFailed:
+		r.report.Steps.Failed++
+		r.report.Failures[Categorize(errMsg)]++
+	}
+}
+
+// RecordExecution counts a finished execution
+func (r *Recorder) RecordExecution(status domain.ExecutionStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return
+	}
+
+	r.report.Executions.Total++
+	switch status {
+	case domain.ExecutionCompleted:
+		r.report.Executions.Completed++
+	case domain.ExecutionFailed:
+		r.report.Executions.Failed++
+	case domain.ExecutionCancelled:
+		r.report.Executions.Cancelled++
+	case domain.ExecutionConflict:
+		r.report.Executions.Parked++
+		r.report.Failures[FailureConflict]++
+	}
+}
+
+// RecordFeatures counts each named feature once
+func (r *Recorder) RecordFeatures(features ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return
+	}
+
+	for _, f := range features {
+		r.report.Features[f]++
+	}
+}
+
+// Categorize maps a step error to a failure category. Only the category
+// is reported, never the message.
+func Categorize(errMsg string) string {
+	switch {
+	case strings.HasPrefix(errMsg, "timeout"):
+		return FailureTimeout
+	case strings.HasPrefix(errMsg, "stalled"):
+		return FailureStalled
+	case errMsg == "cancelled":
+		return FailureCancelled
+	case strings.Contains(errMsg, "exit status"):
+		return FailureExitCode
+	case strings.HasPrefix(errMsg, "merge conflict"):
+		return FailureConflict
+	default:
+		return FailureOther
+	}
+}
+
+// Snapshot returns a copy of the counts recorded so far
+func (r *Recorder) Snapshot() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := r.report
+	report.Failures = copyCounts(r.report.Failures)
+	report.Features = copyCounts(r.report.Features)
+	return report
+}
+
+func copyCounts(src map[string]int) map[string]int {
+	dst := make(map[string]int, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// Empty reports whether nothing has been recorded yet
+func (rep Report) Empty() bool {
+	return rep.Executions.Total == 0 && rep.Steps.Succeeded == 0 && rep.Steps.Failed == 0 &&
+		len(rep.Features) == 0
+}
+
+// Preview returns the exact JSON that the next report would send
+func (r *Recorder) Preview() string {
+	data, err := json.MarshalIndent(r.Snapshot(), "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// Send uploads the counts recorded so far and clears them. It does nothing
+// when disabled, when no endpoint is configured, or when there is nothing
+// to report. Counts are kept for the next attempt if the upload fails.
+func (r *Recorder) Send(ctx context.Context) error {
+	if !r.IsEnabled() || r.endpoint == "" {
+		return nil
+	}
+	report := r.Snapshot()
+	if report.Empty() {
+		return nil
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage report: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create usage report request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send usage report: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("usage report rejected: %s", resp.Status)
+	}
+
+	r.subtract(report)
+	return nil
+}
+
+// subtract removes sent counts, keeping anything recorded during the upload
+func (r *Recorder) subtract(sent Report) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return // Disabling already cleared the counts
+	}
+
+	e := &r.report.Executions
+	e.Total -= sent.Executions.Total
+	e.Completed -= sent.Executions.Completed
+	e.Failed -= sent.Executions.Failed
+	e.Cancelled -= sent.Executions.Cancelled
+	e.Parked -= sent.Executions.Parked
+	r.report.Steps.Succeeded -= sent.Steps.Succeeded
+	r.report.Steps.Failed -= sent.Steps.Failed
+	subtractCounts(r.report.Failures, sent.Failures)
+	subtractCounts(r.report.Features, sent.Features)
+}
+
+func subtractCounts(dst, sent map[string]int) {
+	for k, n := range sent {
+		if dst[k] -= n; dst[k] <= 0 {
+			delete(dst, k)
+		}
+	}
+}
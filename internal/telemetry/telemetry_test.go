@@ -0,0 +1,96 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func TestRecorder_DisabledRecordsNothing(t *testing.T) {
+	r := New(false, "")
+	r.RecordExecution(domain.ExecutionCompleted)
+	r.RecordStep(domain.StepFailed, "timeout after 600s")
+	r.RecordFeatures(FeatureParallel)
+
+	assert.True(t, r.Snapshot().Empty())
+}
+
+func TestRecorder_Counts(t *testing.T) {
+	r := New(true, "")
+	r.RecordFeatures(FeatureParallel, FeatureWatch)
+	r.RecordStep(domain.StepSuccess, "")
+	r.RecordStep(domain.StepFailed, "timeout after 600s")
+	r.RecordExecution(domain.ExecutionFailed)
+	r.RecordExecution(domain.ExecutionConflict)
+
+	rep := r.Snapshot()
+	assert.Equal(t, ExecutionCount{Total: 2, Failed: 1, Parked: 1}, rep.Executions)
+	assert.Equal(t, StepCount{Succeeded: 1, Failed: 1}, rep.Steps)
+	assert.Equal(t, map[string]int{FailureTimeout: 1, FailureConflict: 1}, rep.Failures)
+	assert.Equal(t, map[string]int{FeatureParallel: 1, FeatureWatch: 1}, rep.Features)
+
+	r.SetEnabled(false)
+	assert.True(t, r.Snapshot().Empty(), "opting out discards unsent counts")
+}
+
+func TestCategorize(t *testing.T) {
+	tests := map[string]string{
+		"timeout after 600s":           FailureTimeout,
+		"stalled: no output for 60s":   FailureStalled,
+		"cancelled":                    FailureCancelled,
+		"exit status 1":                FailureExitCode,
+		"merge conflict in: a.go":      FailureConflict,
+		"story 1-1 is already running": FailureOther,
+	}
+	for msg, want := range tests {
+		assert.Equal(t, want, Categorize(msg), msg)
+	}
+}
+
+func TestRecorder_PreviewMatchesPayload(t *testing.T) {
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		received, _ = json.MarshalIndent(body, "", "  ")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	r := New(true, server.URL)
+	r.RecordExecution(domain.ExecutionCompleted)
+	preview := r.Preview()
+
+	require.NoError(t, r.Send(context.Background()))
+	assert.JSONEq(t, preview, string(received))
+	assert.NotContains(t, preview, "story")
+	assert.True(t, r.Snapshot().Empty(), "sent counts are cleared")
+}
+
+func TestRecorder_SendFailureKeepsCounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	r := New(true, server.URL)
+	r.RecordExecution(domain.ExecutionCompleted)
+
+	assert.Error(t, r.Send(context.Background()))
+	assert.Equal(t, 1, r.Snapshot().Executions.Total)
+}
+
+func TestRecorder_SendWithoutEndpoint(t *testing.T) {
+	r := New(true, "")
+	r.RecordExecution(domain.ExecutionCompleted)
+
+	assert.NoError(t, r.Send(context.Background()))
+	assert.Equal(t, 1, r.Snapshot().Executions.Total)
+}
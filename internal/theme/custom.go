@@ -0,0 +1,156 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// customThemes holds the themes loaded from theme files, keyed by file
+// name without its extension
+var customThemes = map[string]Theme{}
+
+// colorPattern matches the colors a theme file may use: hex colors such as
+// "#1e1e2e" or "#fff", and ANSI color numbers from 0 to 255 such as "63"
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])$`)
+
+// LoadThemeDir registers every .yaml and .yml file in dir as a theme named
+// after the file, so ocean.yaml becomes "ocean". A theme loaded earlier
+// under the same name is replaced, and built-in names cannot be taken. A
+// missing directory is not an error; files that do not load are skipped
+// and reported together in the error.
+func LoadThemeDir(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded []string
+	var errs []error
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		name := strings.ToLower(strings.TrimSuffix(entry.Name(), ext))
+		if _, err := RegisterThemeFile(name, filepath.Join(dir, entry.Name())); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		loaded = append(loaded, name)
+	}
+	return loaded, errors.Join(errs...)
+}
+
+// RegisterThemeFile loads a theme file and makes it available as name
+func RegisterThemeFile(name, path string) (Theme, error) {
+	if slices.Contains(builtinThemes, name) {
+		return Theme{}, fmt.Errorf("%s: %q is a built-in theme", path, name)
+	}
+	t, err := readThemeFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+	if t.Name == "" {
+		t.Name = name
+	}
+	customThemes[name] = t
+	return t, nil
+}
+
+// CustomThemes returns the names of the loaded theme files, sorted
+func CustomThemes() []string {
+	names := make([]string, 0, len(customThemes))
+	for name := range customThemes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// CustomTheme returns the loaded theme file registered as name
+func CustomTheme(name string) (Theme, bool) {
+	t, ok := customThemes[name]
+	return t, ok
+}
+
+// readThemeFile parses a theme file. Colors it leaves out are taken from
+// the default theme, so a file only needs the colors it changes.
+func readThemeFile(path string) (Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Theme{}, err
+	}
+
+	var themeYAML ThemeYAML
+	if err := yaml.Unmarshal(data, &themeYAML); err != nil {
+		return Theme{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	t := Catppuccin
+	t.Name = themeYAML.Name
+	for key, value := range themeYAML.colors() {
+		if value == "" {
+			continue
+		}
+		if !colorPattern.MatchString(value) {
+			return Theme{}, fmt.Errorf("%s: %s: %q is not a hex color or ANSI color number", path, key, value)
+		}
+	}
+	fill := func(dst *lipgloss.Color, value string) {
+		if value != "" {
+			*dst = lipgloss.Color(value)
+		}
+	}
+	fill(&t.Background, themeYAML.Background)
+	fill(&t.Foreground, themeYAML.Foreground)
+	fill(&t.Subtle, themeYAML.Subtle)
+	fill(&t.Highlight, themeYAML.Highlight)
+	fill(&t.Success, themeYAML.Success)
+	fill(&t.Warning, themeYAML.Warning)
+	fill(&t.Error, themeYAML.Error)
+	fill(&t.Info, themeYAML.Info)
+	fill(&t.Primary, themeYAML.Primary)
+	fill(&t.Secondary, themeYAML.Secondary)
+	fill(&t.Accent, themeYAML.Accent)
+	fill(&t.Border, themeYAML.Border)
+	fill(&t.Selection, themeYAML.Selection)
+	fill(&t.ActiveTab, themeYAML.ActiveTab)
+	fill(&t.InactiveTab, themeYAML.InactiveTab)
+	fill(&t.StatusBar, themeYAML.StatusBar)
+	fill(&t.HeaderBg, themeYAML.HeaderBg)
+	return t, nil
+}
+
+// colors returns the file's colors keyed by their YAML names
+func (y ThemeYAML) colors() map[string]string {
+	return map[string]string{
+		"background":   y.Background,
+		"foreground":   y.Foreground,
+		"subtle":       y.Subtle,
+		"highlight":    y.Highlight,
+		"success":      y.Success,
+		"warning":      y.Warning,
+		"error":        y.Error,
+		"info":         y.Info,
+		"primary":      y.Primary,
+		"secondary":    y.Secondary,
+		"accent":       y.Accent,
+		"border":       y.Border,
+		"selection":    y.Selection,
+		"active_tab":   y.ActiveTab,
+		"inactive_tab": y.InactiveTab,
+		"status_bar":   y.StatusBar,
+		"header_bg":    y.HeaderBg,
+	}
+}
@@ -0,0 +1,170 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetCustomThemes clears the loaded theme files for the rest of the test
+func resetCustomThemes(t *testing.T) {
+	t.Helper()
+	saved := customThemes
+	customThemes = map[string]Theme{}
+	t.Cleanup(func() { customThemes = saved })
+}
+
+func writeThemeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestColorPattern(t *testing.T) {
+	tests := []struct {
+		color string
+		valid bool
+	}{
+		{"#1e1e2e", true},
+		{"#FFF", true},
+		{"0", true},
+		{"63", true},
+		{"199", true},
+		{"255", true},
+		{"256", false},
+		{"999", false},
+		{"007", false},
+		{"#12345", false},
+		{"#gggggg", false},
+		{"red", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.color, func(t *testing.T) {
+			assert.Equal(t, tt.valid, colorPattern.MatchString(tt.color))
+		})
+	}
+}
+
+func TestRegisterThemeFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		theme   string
+		content string
+		wantErr string
+		check   func(t *testing.T, th Theme)
+	}{
+		{
+			name:    "valid theme fills missing colors from the default",
+			theme:   "ocean",
+			content: "name: Ocean\nbackground: \"#001122\"\nprimary: \"63\"\n",
+			check: func(t *testing.T, th Theme) {
+				assert.Equal(t, "Ocean", th.Name)
+				assert.Equal(t, lipgloss.Color("#001122"), th.Background)
+				assert.Equal(t, lipgloss.Color("63"), th.Primary)
+				assert.Equal(t, Catppuccin.Error, th.Error)
+			},
+		},
+		{
+			name:    "theme without a name is named after the file",
+			theme:   "forest",
+			content: "success: \"#00ff00\"\n",
+			check: func(t *testing.T, th Theme) {
+				assert.Equal(t, "forest", th.Name)
+			},
+		},
+		{
+			name:    "invalid color",
+			theme:   "broken",
+			content: "error: red\n",
+			wantErr: `error: "red" is not a hex color`,
+		},
+		{
+			name:    "ANSI number out of range",
+			theme:   "broken",
+			content: "accent: \"300\"\n",
+			wantErr: `accent: "300" is not a hex color`,
+		},
+		{
+			name:    "invalid YAML",
+			theme:   "broken",
+			content: "background: [unterminated\n",
+			wantErr: "broken.yaml",
+		},
+		{
+			name:    "built-in name is refused",
+			theme:   "dracula",
+			content: "background: \"#000000\"\n",
+			wantErr: `"dracula" is a built-in theme`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCustomThemes(t)
+			path := writeThemeFile(t, t.TempDir(), tt.theme+".yaml", tt.content)
+
+			th, err := RegisterThemeFile(tt.theme, path)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				_, ok := CustomTheme(tt.theme)
+				assert.False(t, ok, "a theme that fails to load is not registered")
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, th)
+			registered, ok := CustomTheme(tt.theme)
+			require.True(t, ok)
+			assert.Equal(t, th, registered)
+		})
+	}
+}
+
+func TestLoadThemeDir(t *testing.T) {
+	t.Run("missing directory is not an error", func(t *testing.T) {
+		resetCustomThemes(t)
+		loaded, err := LoadThemeDir(filepath.Join(t.TempDir(), "missing"))
+		assert.NoError(t, err)
+		assert.Empty(t, loaded)
+	})
+
+	t.Run("loads valid files and reports the rest", func(t *testing.T) {
+		resetCustomThemes(t)
+		dir := t.TempDir()
+		writeThemeFile(t, dir, "Ocean.yaml", "primary: \"#0077be\"\n")
+		writeThemeFile(t, dir, "forest.yml", "primary: \"28\"\n")
+		writeThemeFile(t, dir, "nord.yaml", "primary: \"#000000\"\n")
+		writeThemeFile(t, dir, "bad.yaml", "primary: \"1000\"\n")
+		writeThemeFile(t, dir, "notes.txt", "not a theme")
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "sub.yaml"), 0755))
+
+		loaded, err := LoadThemeDir(dir)
+		assert.ElementsMatch(t, []string{"ocean", "forest"}, loaded)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"nord" is a built-in theme`)
+		assert.Contains(t, err.Error(), `"1000" is not a hex color`)
+		assert.Equal(t, []string{"forest", "ocean"}, CustomThemes())
+	})
+
+	t.Run("a later directory replaces a theme of the same name", func(t *testing.T) {
+		resetCustomThemes(t)
+		first, second := t.TempDir(), t.TempDir()
+		writeThemeFile(t, first, "ocean.yaml", "primary: \"#111111\"\n")
+		writeThemeFile(t, second, "ocean.yaml", "primary: \"#222222\"\n")
+
+		_, err := LoadThemeDir(first)
+		require.NoError(t, err)
+		_, err = LoadThemeDir(second)
+		require.NoError(t, err)
+
+		th, ok := CustomTheme("ocean")
+		require.True(t, ok)
+		assert.Equal(t, lipgloss.Color("#222222"), th.Primary)
+		assert.Equal(t, []string{"ocean"}, CustomThemes())
+	})
+}
@@ -0,0 +1,81 @@
+package theme
+
+import "github.com/robertguss/bmad-automate-go/internal/domain"
+
+// Status glyphs give every state its own symbol so that status is never
+// conveyed by color alone. All glyphs are a single cell wide.
+const (
+	GlyphPending   = "○"
+	GlyphRunning   = "▶"
+	GlyphSuccess   = "✓"
+	GlyphFailed    = "✗"
+	GlyphSkipped   = "↷"
+	GlyphPaused    = "‖"
+	GlyphCancelled = "⊘"
+	GlyphConflict  = "⇄"
+)
+
+// Health glyphs fill up as a story gets healthier
+const (
+	GlyphHealthGood    = "●"
+	GlyphHealthFair    = "◐"
+	GlyphHealthPoor    = "○"
+	GlyphHealthUnknown = "·"
+)
+
+// Fill patterns for bar segments, distinguishable without color
+const (
+	PatternSuccess = "█"
+	PatternFailed  = "▚"
+	PatternEmpty   = "·"
+)
+
+// StepGlyph returns the status glyph for a step
+func StepGlyph(status domain.StepStatus) string {
+	switch status {
+	case domain.StepRunning:
+		return GlyphRunning
+	case domain.StepSuccess:
+		return GlyphSuccess
+	case domain.StepFailed:
+		return GlyphFailed
+	case domain.StepSkipped:
+		return GlyphSkipped
+	default:
+		return GlyphPending
+	}
+}
+
+// ExecutionGlyph returns the status glyph for an execution or queue item
+func ExecutionGlyph(status domain.ExecutionStatus) string {
+	switch status {
+	case domain.ExecutionRunning:
+		return GlyphRunning
+	case domain.ExecutionPaused:
+		return GlyphPaused
+	case domain.ExecutionCompleted:
+		return GlyphSuccess
+	case domain.ExecutionFailed:
+		return GlyphFailed
+	case domain.ExecutionCancelled:
+		return GlyphCancelled
+	case domain.ExecutionConflict:
+		return GlyphConflict
+	default:
+		return GlyphPending
+	}
+}
+
+// HealthGlyph returns the glyph for a story health level
+func HealthGlyph(level domain.HealthLevel) string {
+	switch level {
+	case domain.HealthGood:
+		return GlyphHealthGood
+	case domain.HealthFair:
+		return GlyphHealthFair
+	case domain.HealthPoor:
+		return GlyphHealthPoor
+	default:
+		return GlyphHealthUnknown
+	}
+}
@@ -1,10 +1,10 @@
 package theme
 
 import (
-	"os"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"gopkg.in/yaml.v3"
 )
 
 // Theme defines the color palette and styles for the application
@@ -127,28 +127,167 @@ var Nord = Theme{
 	HeaderBg:    lipgloss.Color("#242933"),
 }
 
+// ColorBlind theme based on the Okabe-Ito palette. Success and error use
+// blue and vermillion, which stay distinct under the common forms of
+// color vision deficiency.
+var ColorBlind = Theme{
+	Name: "Color Blind Safe",
+
+	// Base colors
+	Background: lipgloss.Color("#1c1c1c"),
+	Foreground: lipgloss.Color("#e8e8e8"),
+	Subtle:     lipgloss.Color("#8a8a8a"),
+	Highlight:  lipgloss.Color("#f0e442"),
+
+	// Status colors
+	Success: lipgloss.Color("#56b4e9"),
+	Warning: lipgloss.Color("#f0e442"),
+	Error:   lipgloss.Color("#d55e00"),
+	Info:    lipgloss.Color("#cc79a7"),
+
+	// Accent colors
+	Primary:   lipgloss.Color("#e69f00"),
+	Secondary: lipgloss.Color("#cc79a7"),
+	Accent:    lipgloss.Color("#009e73"),
+
+	// UI element colors
+	Border:      lipgloss.Color("#3a3a3a"),
+	Selection:   lipgloss.Color("#444444"),
+	ActiveTab:   lipgloss.Color("#e69f00"),
+	InactiveTab: lipgloss.Color("#8a8a8a"),
+	StatusBar:   lipgloss.Color("#121212"),
+	HeaderBg:    lipgloss.Color("#121212"),
+}
+
+// Latte is the light Catppuccin flavor, the default on light terminals
+var Latte = Theme{
+	Name: "Catppuccin Latte",
+
+	// Base colors
+	Background: lipgloss.Color("#eff1f5"),
+	Foreground: lipgloss.Color("#4c4f69"),
+	Subtle:     lipgloss.Color("#8c8fa1"),
+	Highlight:  lipgloss.Color("#dc8a78"),
+
+	// Status colors
+	Success: lipgloss.Color("#40a02b"),
+	Warning: lipgloss.Color("#df8e1d"),
+	Error:   lipgloss.Color("#d20f39"),
+	Info:    lipgloss.Color("#1e66f5"),
+
+	// Accent colors
+	Primary:   lipgloss.Color("#8839ef"),
+	Secondary: lipgloss.Color("#ea76cb"),
+	Accent:    lipgloss.Color("#179299"),
+
+	// UI element colors
+	Border:      lipgloss.Color("#ccd0da"),
+	Selection:   lipgloss.Color("#bcc0cc"),
+	ActiveTab:   lipgloss.Color("#8839ef"),
+	InactiveTab: lipgloss.Color("#8c8fa1"),
+	StatusBar:   lipgloss.Color("#e6e9ef"),
+	HeaderBg:    lipgloss.Color("#e6e9ef"),
+}
+
 // Current is the active theme
 var Current = Catppuccin
 
-// AvailableThemes returns a list of built-in theme names
+// Accessible enables screen-reader friendly rendering: box-drawing
+// characters are replaced with whitespace so readers don't announce them
+var Accessible bool
+
+// BoxBorder returns the border used for boxed panels
+func BoxBorder() lipgloss.Border {
+	if Accessible {
+		return lipgloss.HiddenBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// OverlayBorder returns the heavier border used for floating overlays
+func OverlayBorder() lipgloss.Border {
+	if Accessible {
+		return lipgloss.HiddenBorder()
+	}
+	return lipgloss.DoubleBorder()
+}
+
+// Rule returns a horizontal separator line of the given width
+func Rule(width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if Accessible {
+		return strings.Repeat(" ", width)
+	}
+	return strings.Repeat("─", width)
+}
+
+// builtinThemes are the names of the themes compiled in
+var builtinThemes = []string{"catppuccin", "latte", "dracula", "nord", "colorblind"}
+
+// Default returns the name of the theme used when none is configured: the
+// light Latte theme on a light background, Catppuccin Mocha otherwise
+func Default(dark bool) string {
+	if dark {
+		return "catppuccin"
+	}
+	return "latte"
+}
+
+// AvailableThemes returns the built-in theme names followed by those of the
+// loaded theme files
 func AvailableThemes() []string {
-	return []string{"catppuccin", "dracula", "nord"}
+	return append(slices.Clone(builtinThemes), CustomThemes()...)
 }
 
-// SetTheme sets the current theme by name
+// SetTheme sets the current theme by name. Unknown names select the
+// default theme.
 func SetTheme(name string) {
 	switch name {
 	case "dracula":
 		Current = Dracula
 	case "nord":
 		Current = Nord
+	case "colorblind":
+		Current = ColorBlind
+	case "latte":
+		Current = Latte
 	case "catppuccin":
-		fallthrough
+		Current = Catppuccin
 	default:
+		if t, ok := customThemes[name]; ok {
+			Current = t
+			return
+		}
 		Current = Catppuccin
 	}
 }
 
+// Palette returns the theme's colors keyed by their ThemeYAML names, for
+// clients outside the terminal such as the web dashboard
+func (t Theme) Palette() map[string]string {
+	return map[string]string{
+		"background":   string(t.Background),
+		"foreground":   string(t.Foreground),
+		"subtle":       string(t.Subtle),
+		"highlight":    string(t.Highlight),
+		"success":      string(t.Success),
+		"warning":      string(t.Warning),
+		"error":        string(t.Error),
+		"info":         string(t.Info),
+		"primary":      string(t.Primary),
+		"secondary":    string(t.Secondary),
+		"accent":       string(t.Accent),
+		"border":       string(t.Border),
+		"selection":    string(t.Selection),
+		"active_tab":   string(t.ActiveTab),
+		"inactive_tab": string(t.InactiveTab),
+		"status_bar":   string(t.StatusBar),
+		"header_bg":    string(t.HeaderBg),
+	}
+}
+
 // ThemeYAML represents a theme configuration in YAML format
 type ThemeYAML struct {
 	Name string `yaml:"name"`
@@ -179,39 +318,14 @@ type ThemeYAML struct {
 	HeaderBg    string `yaml:"header_bg"`
 }
 
-// LoadThemeFromYAML loads a custom theme from a YAML file
+// LoadThemeFromYAML loads a custom theme from a YAML file and makes it the
+// current theme
 func LoadThemeFromYAML(path string) error {
-	data, err := os.ReadFile(path)
+	t, err := readThemeFile(path)
 	if err != nil {
 		return err
 	}
-
-	var themeYAML ThemeYAML
-	if err := yaml.Unmarshal(data, &themeYAML); err != nil {
-		return err
-	}
-
-	Current = Theme{
-		Name:        themeYAML.Name,
-		Background:  lipgloss.Color(themeYAML.Background),
-		Foreground:  lipgloss.Color(themeYAML.Foreground),
-		Subtle:      lipgloss.Color(themeYAML.Subtle),
-		Highlight:   lipgloss.Color(themeYAML.Highlight),
-		Success:     lipgloss.Color(themeYAML.Success),
-		Warning:     lipgloss.Color(themeYAML.Warning),
-		Error:       lipgloss.Color(themeYAML.Error),
-		Info:        lipgloss.Color(themeYAML.Info),
-		Primary:     lipgloss.Color(themeYAML.Primary),
-		Secondary:   lipgloss.Color(themeYAML.Secondary),
-		Accent:      lipgloss.Color(themeYAML.Accent),
-		Border:      lipgloss.Color(themeYAML.Border),
-		Selection:   lipgloss.Color(themeYAML.Selection),
-		ActiveTab:   lipgloss.Color(themeYAML.ActiveTab),
-		InactiveTab: lipgloss.Color(themeYAML.InactiveTab),
-		StatusBar:   lipgloss.Color(themeYAML.StatusBar),
-		HeaderBg:    lipgloss.Color(themeYAML.HeaderBg),
-	}
-
+	Current = t
 	return nil
 }
 
@@ -345,7 +459,7 @@ func NewStyles() Styles {
 
 		// Borders
 		BorderedBox: lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
+			Border(BoxBorder()).
 			BorderForeground(t.Border).
 			Padding(1, 2),
 
@@ -0,0 +1,109 @@
+package util
+
+import (
+	"strings"
+	"time"
+)
+
+// sparkLevels are the eight vertical block glyphs used for sparklines,
+// from lowest to highest.
+var sparkLevels = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// barEighths are the partial horizontal block glyphs used for bars,
+// indexed by the number of eighths filled (1-7).
+var barEighths = []rune{' ', '▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// Sparkline renders values as a single line of unicode block characters,
+// one glyph per value, scaled relative to the largest value.
+// Zero values render as a blank to keep quiet periods visible.
+func Sparkline(values []int) string {
+	maxVal := 0
+	for _, v := range values {
+		if v > maxVal {
+			maxVal = v
+		}
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if v <= 0 || maxVal == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		level := v * (len(sparkLevels) - 1) / maxVal
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+// HBar renders a horizontal bar of the given width using eighth-block
+// characters, giving eight times the resolution of a plain character bar.
+// The result is always exactly width runes wide.
+func HBar(value, maxVal float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if maxVal <= 0 || value <= 0 {
+		return strings.Repeat(" ", width)
+	}
+	if value > maxVal {
+		value = maxVal
+	}
+
+	eighths := int(value / maxVal * float64(width*8))
+	full := eighths / 8
+	partial := eighths % 8
+
+	var b strings.Builder
+	b.WriteString(strings.Repeat("█", full))
+	used := full
+	if partial > 0 && used < width {
+		b.WriteRune(barEighths[partial])
+		used++
+	}
+	b.WriteString(strings.Repeat(" ", width-used))
+	return b.String()
+}
+
+// DurationHistogram buckets durations into n equal-width bins spanning the
+// minimum to maximum value and returns the count per bin.
+func DurationHistogram(durations []time.Duration, n int) []int {
+	if n <= 0 || len(durations) == 0 {
+		return nil
+	}
+
+	minD, maxD := durations[0], durations[0]
+	for _, d := range durations[1:] {
+		if d < minD {
+			minD = d
+		}
+		if d > maxD {
+			maxD = d
+		}
+	}
+
+	buckets := make([]int, n)
+	span := maxD - minD
+	for _, d := range durations {
+		idx := 0
+		if span > 0 {
+			idx = int(int64(d-minD) * int64(n) / int64(span))
+		}
+		if idx >= n {
+			idx = n - 1
+		}
+		buckets[idx]++
+	}
+	return buckets
+}
+
+// DailyCounts converts a map keyed by "2006-01-02" dates into a slice of
+// counts for the last n days ending at now, oldest first.
+func DailyCounts(byDay map[string]int, n int, now time.Time) []int {
+	counts := make([]int, n)
+	for i := 0; i < n; i++ {
+		day := now.AddDate(0, 0, -(n - 1 - i)).Format("2006-01-02")
+		counts[i] = byDay[day]
+	}
+	return counts
+}
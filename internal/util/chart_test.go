@@ -0,0 +1,94 @@
+package util
+
+import (
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		expected string
+	}{
+		{"empty", nil, ""},
+		{"all zero", []int{0, 0, 0}, "   "},
+		{"single value", []int{5}, "█"},
+		{"ascending", []int{0, 1, 2, 3, 4, 5, 6, 7}, " ▂▃▄▅▆▇█"},
+		{"scaled to max", []int{10, 5, 10}, "█▄█"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Sparkline(tt.values))
+		})
+	}
+}
+
+func TestHBar(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		max      float64
+		width    int
+		expected string
+	}{
+		{"zero width", 5, 10, 0, ""},
+		{"zero value", 0, 10, 4, "    "},
+		{"zero max", 5, 0, 4, "    "},
+		{"full", 10, 10, 4, "████"},
+		{"half", 5, 10, 4, "██  "},
+		{"partial block", 5, 16, 4, "█▎  "},
+		{"clamped above max", 20, 10, 3, "███"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HBar(tt.value, tt.max, tt.width)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.width, utf8.RuneCountInString(result))
+		})
+	}
+}
+
+func TestDurationHistogram(t *testing.T) {
+	t.Run("empty input", func(t *testing.T) {
+		assert.Nil(t, DurationHistogram(nil, 4))
+	})
+
+	t.Run("zero buckets", func(t *testing.T) {
+		assert.Nil(t, DurationHistogram([]time.Duration{time.Second}, 0))
+	})
+
+	t.Run("identical durations land in first bucket", func(t *testing.T) {
+		result := DurationHistogram([]time.Duration{time.Second, time.Second}, 3)
+		assert.Equal(t, []int{2, 0, 0}, result)
+	})
+
+	t.Run("spreads across buckets", func(t *testing.T) {
+		durations := []time.Duration{
+			0,
+			1 * time.Second,
+			2 * time.Second,
+			3 * time.Second,
+			4 * time.Second,
+		}
+		result := DurationHistogram(durations, 4)
+		assert.Equal(t, []int{1, 1, 1, 2}, result)
+	})
+}
+
+func TestDailyCounts(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.UTC)
+	byDay := map[string]int{
+		"2024-03-10": 4,
+		"2024-03-08": 2,
+		"2024-02-01": 9, // outside the window
+	}
+
+	result := DailyCounts(byDay, 3, now)
+	assert.Equal(t, []int{2, 0, 4}, result)
+}
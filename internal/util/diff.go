@@ -0,0 +1,60 @@
+package util
+
+// DiffOp says whether a line of a line diff is shared, removed or added
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffRemoved
+	DiffAdded
+)
+
+// DiffLine is one line of a line diff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines returns the line diff that turns a into b, based on their
+// longest common subsequence. Removed lines come before added ones where
+// both occur at the same position.
+func DiffLines(a, b []string) []DiffLine {
+	// lcs[i][j] is the common subsequence length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]DiffLine, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, DiffLine{DiffEqual, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, DiffLine{DiffRemoved, a[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{DiffAdded, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{DiffRemoved, a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, DiffLine{DiffAdded, b[j]})
+	}
+	return diff
+}
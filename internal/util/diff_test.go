@@ -0,0 +1,31 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     []string
+		expected []DiffLine
+	}{
+		{"both empty", nil, nil, []DiffLine{}},
+		{"identical", []string{"x", "y"}, []string{"x", "y"},
+			[]DiffLine{{DiffEqual, "x"}, {DiffEqual, "y"}}},
+		{"all added", nil, []string{"x"}, []DiffLine{{DiffAdded, "x"}}},
+		{"all removed", []string{"x"}, nil, []DiffLine{{DiffRemoved, "x"}}},
+		{"changed line", []string{"build", "FAIL", "done"}, []string{"build", "ok", "done"},
+			[]DiffLine{{DiffEqual, "build"}, {DiffRemoved, "FAIL"}, {DiffAdded, "ok"}, {DiffEqual, "done"}}},
+		{"inserted line", []string{"a", "c"}, []string{"a", "b", "c"},
+			[]DiffLine{{DiffEqual, "a"}, {DiffAdded, "b"}, {DiffEqual, "c"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DiffLines(tt.a, tt.b))
+		})
+	}
+}
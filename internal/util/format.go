@@ -102,3 +102,44 @@ func FormatDurationLong(d time.Duration) string {
 	}
 	return fmt.Sprintf("%d %s %d %s", hours, hourUnit, mins, minUnit)
 }
+
+// FormatTokens formats a token count compactly.
+// - Under 1,000: "950"
+// - Under 1,000,000: "12.3k"
+// - 1,000,000 or more: "1.2M"
+func FormatTokens(n int64) string {
+	switch {
+	case n < 1000:
+		return fmt.Sprintf("%d", n)
+	case n < 999950: // Rounds to at most 999.9k
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	default:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	}
+}
+
+// FormatCost formats a US dollar amount, with more precision below a cent.
+// - Under $0.01: "$0.004"
+// - Otherwise: "$1.25"
+func FormatCost(usd float64) string {
+	if usd > 0 && usd < 0.01 {
+		return fmt.Sprintf("$%.3f", usd)
+	}
+	return fmt.Sprintf("$%.2f", usd)
+}
+
+// FormatBytes formats a size in binary units.
+// - Under 1 KiB: "512 B"
+// - Otherwise: "12.3 KB", "4.0 MB", "1.2 GB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 2; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMG"[exp])
+}
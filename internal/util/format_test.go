@@ -117,3 +117,40 @@ func TestFormatDurationLong(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatTokens(t *testing.T) {
+	tests := []struct {
+		tokens   int64
+		expected string
+	}{
+		{0, "0"},
+		{950, "950"},
+		{1000, "1.0k"},
+		{12345, "12.3k"},
+		{999999, "1.0M"},
+		{1200000, "1.2M"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatTokens(tt.tokens))
+		})
+	}
+}
+
+func TestFormatCost(t *testing.T) {
+	assert.Equal(t, "$0.00", FormatCost(0))
+	assert.Equal(t, "$0.004", FormatCost(0.0042))
+	assert.Equal(t, "$0.42", FormatCost(0.42))
+	assert.Equal(t, "$12.35", FormatCost(12.345))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "0 B", FormatBytes(0))
+	assert.Equal(t, "512 B", FormatBytes(512))
+	assert.Equal(t, "1.0 KB", FormatBytes(1024))
+	assert.Equal(t, "12.3 KB", FormatBytes(12595))
+	assert.Equal(t, "4.0 MB", FormatBytes(4<<20))
+	assert.Equal(t, "1.5 GB", FormatBytes(3<<29))
+	assert.Equal(t, "2048.0 GB", FormatBytes(2<<40))
+}
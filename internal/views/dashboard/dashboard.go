@@ -3,20 +3,29 @@ package dashboard
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/robertguss/bmad-automate-go/internal/domain"
 	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/preflight"
 	"github.com/robertguss/bmad-automate-go/internal/theme"
+	"github.com/robertguss/bmad-automate-go/internal/util"
 )
 
+// activityDays is the number of days shown in the recent activity sparkline
+const activityDays = 14
+
 // Model represents the dashboard view
 type Model struct {
 	width   int
 	height  int
 	stories []domain.Story
+	stats   *messages.StatsData
 	styles  theme.Styles
+
+	preflight *preflight.Results
 }
 
 // New creates a new dashboard model
@@ -56,6 +65,22 @@ func (m *Model) SetStories(stories []domain.Story) {
 	m.stories = stories
 }
 
+// SetStats sets the execution statistics used for the activity summary
+func (m *Model) SetStats(stats *messages.StatsData) {
+	m.stats = stats
+}
+
+// SetPreflight sets the pre-flight check results, whose blocking failures
+// are shown above the overview
+func (m *Model) SetPreflight(results *preflight.Results) {
+	m.preflight = results
+}
+
+// Stats returns the statistics currently shown on the dashboard
+func (m Model) Stats() *messages.StatsData {
+	return m.stats
+}
+
 // View renders the dashboard
 func (m Model) View() string {
 	t := theme.Current
@@ -101,7 +126,7 @@ func (m Model) View() string {
 	rows = append(rows, totalRow)
 
 	overviewBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Padding(1, 2).
 		Width(40).
@@ -119,6 +144,7 @@ func (m Model) View() string {
 		desc string
 	}{
 		{"s", "View story list"},
+		{"e", "View epics"},
 		{"q", "View queue"},
 		{"Enter", "Start processing"},
 		{"h", "View history"},
@@ -139,26 +165,23 @@ func (m Model) View() string {
 	}
 
 	actionsBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Padding(1, 2).
 		Width(35).
 		Render(lipgloss.JoinVertical(lipgloss.Left, append([]string{actionsTitle}, actionRows...)...))
 
-	// Recent activity placeholder
+	// Recent activity
 	recentTitle := lipgloss.NewStyle().
 		Foreground(t.Primary).
 		Bold(true).
 		MarginBottom(1).
 		Render("Recent Activity")
 
-	recentContent := lipgloss.NewStyle().
-		Foreground(t.Subtle).
-		Italic(true).
-		Render("No recent activity")
+	recentContent := m.renderRecentActivity()
 
 	recentBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Padding(1, 2).
 		Width(35).
@@ -176,10 +199,16 @@ func (m Model) View() string {
 		MarginBottom(2).
 		Render("Welcome to BMAD Automate - your AI-powered development workflow assistant.")
 
+	sections := []string{welcome}
+	if blocked := m.renderPreflightErrors(); blocked != "" {
+		sections = append(sections, blocked, "")
+	}
+	sections = append(sections, content)
+
 	// Wrap in container
 	container := lipgloss.NewStyle().
 		Padding(1, 2).
-		Render(lipgloss.JoinVertical(lipgloss.Left, welcome, content))
+		Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
 
 	// Add bottom padding to fill space
 	lines := strings.Count(container, "\n") + 1
@@ -189,3 +218,68 @@ func (m Model) View() string {
 
 	return container
 }
+
+// renderRecentActivity renders a sparkline of executions over recent days
+func (m Model) renderRecentActivity() string {
+	t := theme.Current
+
+	if m.stats == nil || m.stats.TotalExecutions == 0 {
+		return lipgloss.NewStyle().
+			Foreground(t.Subtle).
+			Italic(true).
+			Render("No recent activity")
+	}
+
+	counts := util.DailyCounts(m.stats.ExecutionsByDay, activityDays, time.Now())
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+
+	sparkline := lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Render(util.Sparkline(counts))
+
+	summary := lipgloss.NewStyle().
+		Foreground(t.Subtle).
+		Render(fmt.Sprintf("%d runs in %d days", total, activityDays))
+
+	rate := lipgloss.NewStyle().
+		Foreground(t.Foreground).
+		Render(fmt.Sprintf("%.0f%% success overall", m.stats.SuccessRate))
+
+	return lipgloss.JoinVertical(lipgloss.Left, sparkline, summary, rate)
+}
+
+// renderPreflightErrors renders the pre-flight checks that stop stories
+// from running, or "" when none do
+func (m Model) renderPreflightErrors() string {
+	if m.preflight == nil {
+		return ""
+	}
+	blocking := m.preflight.Blocking()
+	if len(blocking) == 0 {
+		return ""
+	}
+	t := theme.Current
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Error).
+		Bold(true).
+		Render("Stories cannot run until these checks pass")
+	rows := []string{title, ""}
+	for _, check := range blocking {
+		name := lipgloss.NewStyle().Foreground(t.Error).Bold(true).Render("✗ " + check.Name + ":")
+		rows = append(rows, name+" "+lipgloss.NewStyle().Foreground(t.Foreground).Render(check.Error))
+	}
+	rows = append(rows, "", lipgloss.NewStyle().
+		Foreground(t.Subtle).
+		Render("Fix them, then pick \"Run Pre-flight Checks\" in the palette (ctrl+p)"))
+
+	return lipgloss.NewStyle().
+		Border(theme.BoxBorder()).
+		BorderForeground(t.Error).
+		Padding(0, 2).
+		Width(79). // Spans the overview and the boxes beside it
+		Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
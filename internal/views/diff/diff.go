@@ -4,30 +4,41 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/alecthomas/chroma/v2"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/robertguss/bmad-automate-go/internal/git"
 	"github.com/robertguss/bmad-automate-go/internal/messages"
 	"github.com/robertguss/bmad-automate-go/internal/theme"
 )
 
 // Model represents the diff preview view state
 type Model struct {
-	width    int
-	height   int
-	styles   theme.Styles
-	storyKey string
-	content  string
-	lines    []diffLine
-	scroll   int
-	loading  bool
-	errorMsg string
+	width      int
+	height     int
+	styles     theme.Styles
+	storyKey   string
+	base       string // Branch or commit a story diff compares against
+	source     string // What a working tree diff compares, a git.Diff* value
+	content    string
+	lines      []diffLine
+	files      []diffFile
+	pairs      []sideRow // lines laid out side by side
+	scroll     int
+	loading    bool
+	errorMsg   string
+	plain      bool          // Syntax highlighting off
+	showFiles  bool          // File list shown, when wide enough
+	sideBySide bool          // Side-by-side layout asked for, used when wide enough
+	codeStyle  *chroma.Style // Colors of highlighted code, following the theme
 }
 
 // diffLine represents a parsed diff line
 type diffLine struct {
 	content  string
 	lineType lineType
+	lexer    chroma.Lexer // Language of the file the line is in, nil when unknown
 }
 
 // lineType represents the type of diff line
@@ -45,8 +56,10 @@ const (
 // New creates a new diff view model
 func New() Model {
 	return Model{
-		styles: theme.NewStyles(),
-		lines:  make([]diffLine, 0),
+		styles:    theme.NewStyles(),
+		lines:     make([]diffLine, 0),
+		codeStyle: codeStyle(),
+		showFiles: true,
 	}
 }
 
@@ -72,10 +85,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 		}
 		m.storyKey = msg.StoryKey
-		m.content = msg.Content
-		m.lines = parseDiff(msg.Content)
+		m.base = msg.Base
+		m.source = msg.Source
+		m.setContent(msg.Content)
 		m.errorMsg = ""
-		m.scroll = 0
 	}
 
 	return m, nil
@@ -97,6 +110,39 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 	case "home":
 		m.scroll = 0
 
+	case "p":
+		m.plain = !m.plain
+
+	case "w":
+		return m.requestSource(git.DiffUnstaged)
+
+	case "i":
+		return m.requestSource(git.DiffStaged)
+
+	case "c":
+		return m.requestSource(git.DiffHead)
+
+	case "]":
+		m.jumpToFile(m.currentFile() + 1)
+
+	case "[":
+		current := m.currentFile()
+		// A file scrolled past its start goes back to it first
+		if current >= 0 && m.rowOf(m.files[current].line) < m.scroll {
+			m.jumpToFile(current)
+		} else {
+			m.jumpToFile(current - 1)
+		}
+
+	case "f":
+		m.showFiles = !m.showFiles
+
+	case "s":
+		// Keep the line at the top in view across layouts
+		top := m.rowLine(m.scroll)
+		m.sideBySide = !m.sideBySide
+		m.scroll = min(m.rowOf(top), m.maxScroll())
+
 	case "end":
 		m.scroll = m.maxScroll()
 
@@ -184,6 +230,12 @@ func (m Model) renderHeader() string {
 		subtitle = lipgloss.NewStyle().
 			Foreground(t.Secondary).
 			Render(fmt.Sprintf(" - %s", m.storyKey))
+		if m.base != "" {
+			subtitle += lipgloss.NewStyle().Foreground(t.Subtle).Render(" vs " + m.base)
+		}
+	}
+	if label := sourceLabels[m.source]; label != "" && m.base == "" {
+		subtitle += lipgloss.NewStyle().Foreground(t.Accent).Render(" [" + label + "]")
 	}
 
 	stats := m.getDiffStats()
@@ -198,19 +250,11 @@ func (m Model) renderDiffContent() string {
 	t := theme.Current
 	contentHeight := m.contentHeight()
 
-	// Calculate visible range
-	start := m.scroll
-	end := start + contentHeight
-	if end > len(m.lines) {
-		end = len(m.lines)
-	}
-
-	var renderedLines []string
-	for i := start; i < end; i++ {
-		line := m.lines[i]
-		rendered := m.renderDiffLine(line, i+1) // 1-based line numbers
-		renderedLines = append(renderedLines, rendered)
+	width := m.width - 4
+	if m.filePaneActive() {
+		width -= filePaneWidth + 1
 	}
+	renderedLines := m.renderRows(width)
 
 	// If not enough lines to fill the view, pad with empty lines
 	for len(renderedLines) < contentHeight {
@@ -218,15 +262,19 @@ func (m Model) renderDiffContent() string {
 	}
 
 	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
-		Width(m.width - 4).
+		Width(width).
 		Render(strings.Join(renderedLines, "\n"))
 
+	if m.filePaneActive() {
+		return lipgloss.JoinHorizontal(lipgloss.Top, m.renderFilePane(contentHeight), " ", box)
+	}
 	return box
 }
 
-func (m Model) renderDiffLine(line diffLine, lineNum int) string {
+// renderDiffLine renders a line of the diff in width columns
+func (m Model) renderDiffLine(line diffLine, lineNum int, width int) string {
 	t := theme.Current
 
 	// Line number
@@ -277,7 +325,7 @@ func (m Model) renderDiffLine(line diffLine, lineNum int) string {
 	}
 
 	// Truncate content if too wide
-	maxWidth := m.width - 12 // Account for line number and padding
+	maxWidth := width - 8 // Account for line number and padding
 	content := line.content
 	if len(content) > maxWidth && maxWidth > 3 {
 		content = content[:maxWidth-3] + "..."
@@ -288,7 +336,20 @@ func (m Model) renderDiffLine(line diffLine, lineNum int) string {
 		content = prefix + content
 	}
 
-	contentStr := contentStyle.Render(content)
+	// Code lines are highlighted after their prefix, keeping the line's
+	// background
+	var contentStr string
+	switch line.lineType {
+	case lineAdded, lineRemoved, lineContext:
+		if line.lexer != nil && !m.plain {
+			base := lipgloss.NewStyle().Background(contentStyle.GetBackground())
+			contentStr = contentStyle.Render(content[:1]) + highlight(line.lexer, m.codeStyle, content[1:], base)
+			break
+		}
+		fallthrough
+	default:
+		contentStr = contentStyle.Render(content)
+	}
 
 	return lipgloss.JoinHorizontal(lipgloss.Left, lineNumStr, " ", contentStr)
 }
@@ -298,20 +359,53 @@ func (m Model) renderFooter() string {
 
 	// Scroll indicator
 	var scrollInfo string
-	if len(m.lines) > m.contentHeight() {
+	if m.rowCount() > m.contentHeight() {
 		scrollInfo = fmt.Sprintf(" [%d-%d of %d lines]",
 			m.scroll+1,
-			min(m.scroll+m.contentHeight(), len(m.lines)),
-			len(m.lines),
+			min(m.scroll+m.contentHeight(), m.rowCount()),
+			m.rowCount(),
 		)
 	}
 
-	help := lipgloss.NewStyle().
+	help := []string{"Up/Down/PgUp/PgDown: Scroll", "w/i/c: Unstaged/staged/last commit"}
+	if len(m.files) > 1 {
+		help = append(help, "[/]: Prev/next file", "f: Files")
+	}
+	switch {
+	case m.width < sideBySideMinWidth:
+		// Too narrow to offer side by side
+	case m.sideBySide:
+		help = append(help, "s: Unified")
+	default:
+		help = append(help, "s: Side by side")
+	}
+	if m.plain {
+		help = append(help, "p: Syntax colors")
+	} else {
+		help = append(help, "p: Plain text")
+	}
+
+	return lipgloss.NewStyle().
 		Foreground(t.Subtle).
 		Padding(1, 0, 0, 0).
-		Render(fmt.Sprintf("Up/Down/PgUp/PgDown: Scroll%s", scrollInfo))
+		Render(strings.Join(help, " | ") + scrollInfo)
+}
 
-	return help
+// sourceLabels names the working tree diffs in the header
+var sourceLabels = map[string]string{
+	git.DiffUnstaged: "unstaged",
+	git.DiffStaged:   "staged",
+	git.DiffHead:     "last commit",
+}
+
+// requestSource reloads the diff with the working tree changes source
+// names
+func (m Model) requestSource(source string) (Model, tea.Cmd) {
+	m.loading = true
+	storyKey := m.storyKey
+	return m, func() tea.Msg {
+		return messages.DiffRequestMsg{StoryKey: storyKey, Source: source}
+	}
 }
 
 // SetSize updates the view dimensions
@@ -323,15 +417,15 @@ func (m *Model) SetSize(width, height int) {
 // RefreshStyles rebuilds styles after theme change
 func (m *Model) RefreshStyles() {
 	m.styles = theme.NewStyles()
+	m.codeStyle = codeStyle()
 }
 
 // SetDiff sets the diff content
 func (m *Model) SetDiff(storyKey, content string) {
 	m.storyKey = storyKey
-	m.content = content
-	m.lines = parseDiff(content)
+	m.base = ""
+	m.setContent(content)
 	m.loading = false
-	m.scroll = 0
 }
 
 // SetLoading sets the loading state
@@ -342,8 +436,16 @@ func (m *Model) SetLoading(loading bool) {
 // Clear clears the diff content
 func (m *Model) Clear() {
 	m.storyKey = ""
-	m.content = ""
-	m.lines = nil
+	m.base = ""
+	m.setContent("")
+}
+
+// setContent parses a diff and scrolls back to its top
+func (m *Model) setContent(content string) {
+	m.content = content
+	m.lines = parseDiff(content)
+	m.files = diffFiles(m.lines)
+	m.pairs = sideRows(m.lines)
 	m.scroll = 0
 }
 
@@ -360,10 +462,10 @@ func (m Model) contentHeight() int {
 // maxScroll returns the maximum scroll position
 func (m Model) maxScroll() int {
 	contentHeight := m.contentHeight()
-	if len(m.lines) <= contentHeight {
+	if m.rowCount() <= contentHeight {
 		return 0
 	}
-	return len(m.lines) - contentHeight
+	return m.rowCount() - contentHeight
 }
 
 // diffStats holds diff statistics
@@ -395,18 +497,21 @@ func parseDiff(content string) []diffLine {
 	rawLines := strings.Split(content, "\n")
 	lines := make([]diffLine, 0, len(rawLines))
 
+	var lexer chroma.Lexer
 	for _, raw := range rawLines {
 		line := diffLine{content: raw}
 
 		switch {
 		case strings.HasPrefix(raw, "diff --git"):
 			line.lineType = lineHeader
+			lexer = nil
 		case strings.HasPrefix(raw, "index "):
 			line.lineType = lineHeader
-		case strings.HasPrefix(raw, "---"):
-			line.lineType = lineHeader
-		case strings.HasPrefix(raw, "+++"):
+		case strings.HasPrefix(raw, "---"), strings.HasPrefix(raw, "+++"):
 			line.lineType = lineHeader
+			if path := diffPath(raw); path != "" {
+				lexer = lexerFor(path)
+			}
 		case strings.HasPrefix(raw, "@@"):
 			line.lineType = lineHunk
 		case strings.HasPrefix(raw, "+"):
@@ -418,6 +523,9 @@ func parseDiff(content string) []diffLine {
 		default:
 			line.lineType = lineNormal
 		}
+		if line.lineType != lineHeader && line.lineType != lineHunk {
+			line.lexer = lexer
+		}
 
 		lines = append(lines, line)
 	}
@@ -0,0 +1,78 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+// fallbackStyle colors code when chroma has no style named after the theme
+const fallbackStyle = "monokai"
+
+// lexerFor returns the lexer for a file in the diff, nil when its language
+// is not known
+func lexerFor(path string) chroma.Lexer {
+	lexer := lexers.Match(path)
+	if lexer == nil {
+		return nil
+	}
+	return chroma.Coalesce(lexer)
+}
+
+// codeStyle returns the chroma style matching the current theme, so
+// "Catppuccin Mocha" uses catppuccin-mocha
+func codeStyle() *chroma.Style {
+	name := strings.ReplaceAll(strings.ToLower(theme.Current.Name), " ", "-")
+	if style, ok := styles.Registry[name]; ok {
+		return style
+	}
+	return styles.Get(fallbackStyle)
+}
+
+// highlight colors code token by token. Tokens take their foreground from
+// style and everything else from base, which carries the line's background.
+func highlight(lexer chroma.Lexer, style *chroma.Style, code string, base lipgloss.Style) string {
+	tokens, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return base.Render(code)
+	}
+
+	var b strings.Builder
+	for _, token := range tokens.Tokens() {
+		value := strings.TrimRight(token.Value, "\n")
+		if value == "" {
+			continue
+		}
+		tokenStyle := base
+		entry := style.Get(token.Type)
+		if entry.Colour.IsSet() {
+			tokenStyle = tokenStyle.Foreground(lipgloss.Color(entry.Colour.String()))
+		}
+		if entry.Bold == chroma.Yes {
+			tokenStyle = tokenStyle.Bold(true)
+		}
+		if entry.Italic == chroma.Yes {
+			tokenStyle = tokenStyle.Italic(true)
+		}
+		b.WriteString(tokenStyle.Render(value))
+	}
+	return b.String()
+}
+
+// diffPath returns the path a "--- a/path" or "+++ b/path" header names,
+// empty for /dev/null
+func diffPath(header string) string {
+	path := strings.TrimSpace(header[len("+++"):])
+	if path == "/dev/null" {
+		return ""
+	}
+	if _, after, ok := strings.Cut(path, "/"); ok && (strings.HasPrefix(path, "a/") || strings.HasPrefix(path, "b/")) {
+		return after
+	}
+	return path
+}
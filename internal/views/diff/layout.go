@@ -0,0 +1,243 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+const (
+	// sideBySideMinWidth is the narrowest terminal side-by-side diffs are
+	// drawn in; narrower ones stay unified
+	sideBySideMinWidth = 140
+
+	// filePaneMinWidth is the narrowest terminal the file list is shown in
+	filePaneMinWidth = 100
+
+	// filePaneWidth is the width of the file list, borders included
+	filePaneWidth = 34
+)
+
+// diffFile is a changed file in the diff
+type diffFile struct {
+	path    string
+	line    int // Index of its "diff --git" line
+	added   int
+	removed int
+}
+
+// diffFiles lists the files in lines in the order they appear. A file
+// changed by several commits of a story diff is listed once per commit.
+func diffFiles(lines []diffLine) []diffFile {
+	var files []diffFile
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line.content, "diff --git"):
+			path := line.content
+			if _, after, ok := strings.Cut(path, " b/"); ok {
+				path = after
+			}
+			files = append(files, diffFile{path: path, line: i})
+		case len(files) == 0:
+		case line.lineType == lineAdded:
+			files[len(files)-1].added++
+		case line.lineType == lineRemoved:
+			files[len(files)-1].removed++
+		}
+	}
+	return files
+}
+
+// sideRow is a row of the side-by-side layout, holding the indexes of the
+// lines shown on its left and right, -1 for an empty half. Lines that are
+// not code, such as headers, span both halves.
+type sideRow struct {
+	left, right int
+	span        bool
+}
+
+// first returns the index of the first line shown in the row
+func (r sideRow) first() int {
+	if r.left < 0 {
+		return r.right
+	}
+	return r.left
+}
+
+// sideRows pairs each run of removed lines with the added lines after it,
+// so old and new versions of a change sit next to each other
+func sideRows(lines []diffLine) []sideRow {
+	var rows []sideRow
+	for i := 0; i < len(lines); {
+		switch lines[i].lineType {
+		case lineRemoved, lineAdded:
+			var removed, added []int
+			for ; i < len(lines) && lines[i].lineType == lineRemoved; i++ {
+				removed = append(removed, i)
+			}
+			for ; i < len(lines) && lines[i].lineType == lineAdded; i++ {
+				added = append(added, i)
+			}
+			for j := 0; j < max(len(removed), len(added)); j++ {
+				row := sideRow{left: -1, right: -1}
+				if j < len(removed) {
+					row.left = removed[j]
+				}
+				if j < len(added) {
+					row.right = added[j]
+				}
+				rows = append(rows, row)
+			}
+		case lineContext:
+			rows = append(rows, sideRow{left: i, right: i})
+			i++
+		default:
+			rows = append(rows, sideRow{left: i, right: i, span: true})
+			i++
+		}
+	}
+	return rows
+}
+
+// sideBySideActive reports whether the diff is drawn side by side: it was
+// asked for and the terminal is wide enough
+func (m Model) sideBySideActive() bool {
+	return m.sideBySide && m.width >= sideBySideMinWidth
+}
+
+// filePaneActive reports whether the file list is shown
+func (m Model) filePaneActive() bool {
+	return m.showFiles && len(m.files) > 1 && m.width >= filePaneMinWidth
+}
+
+// rowCount returns how many rows the diff takes in the current layout
+func (m Model) rowCount() int {
+	if m.sideBySideActive() {
+		return len(m.pairs)
+	}
+	return len(m.lines)
+}
+
+// rowLine returns the index of the first line shown in row
+func (m Model) rowLine(row int) int {
+	if m.sideBySideActive() && row < len(m.pairs) {
+		return m.pairs[row].first()
+	}
+	return row
+}
+
+// rowOf returns the row a line is shown in
+func (m Model) rowOf(line int) int {
+	if !m.sideBySideActive() {
+		return line
+	}
+	for i, row := range m.pairs {
+		if row.first() >= line {
+			return i
+		}
+	}
+	return len(m.pairs) - 1
+}
+
+// currentFile returns the index of the file at the top of the view, -1
+// before the first file
+func (m Model) currentFile() int {
+	top := m.rowLine(m.scroll)
+	current := -1
+	for i, f := range m.files {
+		if f.line > top {
+			break
+		}
+		current = i
+	}
+	return current
+}
+
+// jumpToFile scrolls so that file i starts at the top of the view
+func (m *Model) jumpToFile(i int) {
+	if i < 0 || i >= len(m.files) {
+		return
+	}
+	m.scroll = min(m.rowOf(m.files[i].line), m.maxScroll())
+}
+
+// renderRows renders the visible rows of the diff width columns wide
+func (m Model) renderRows(width int) []string {
+	end := min(m.scroll+m.contentHeight(), m.rowCount())
+	var rendered []string
+	if !m.sideBySideActive() {
+		for i := m.scroll; i < end; i++ {
+			rendered = append(rendered, m.renderDiffLine(m.lines[i], i+1, width)) // 1-based line numbers
+		}
+		return rendered
+	}
+
+	t := theme.Current
+	half := (width - 1) / 2
+	cell := lipgloss.NewStyle().Width(half).MaxWidth(half)
+	divider := lipgloss.NewStyle().Foreground(t.Border).Render("│")
+	for _, row := range m.pairs[m.scroll:end] {
+		if row.span {
+			rendered = append(rendered, m.renderDiffLine(m.lines[row.left], row.left+1, width))
+			continue
+		}
+		halves := make([]string, 2)
+		for i, idx := range []int{row.left, row.right} {
+			if idx >= 0 {
+				halves[i] = m.renderDiffLine(m.lines[idx], idx+1, half)
+			}
+			halves[i] = cell.Render(halves[i])
+		}
+		rendered = append(rendered, halves[0]+divider+halves[1])
+	}
+	return rendered
+}
+
+// renderFilePane lists the changed files with their added and removed line
+// counts, marking the one at the top of the view
+func (m Model) renderFilePane(height int) string {
+	t := theme.Current
+	current := m.currentFile()
+	inner := filePaneWidth - 2
+
+	// Keep the current file in sight when the list is taller than the pane
+	start := 0
+	if current >= height {
+		start = current - height + 1
+	}
+	end := min(start+height, len(m.files))
+
+	var rows []string
+	for i := start; i < end; i++ {
+		f := m.files[i]
+		counts := lipgloss.NewStyle().Foreground(t.Success).Render(fmt.Sprintf("+%d ", f.added)) +
+			lipgloss.NewStyle().Foreground(t.Error).Render(fmt.Sprintf("-%d", f.removed))
+		countsWidth := lipgloss.Width(counts)
+
+		marker, nameStyle := "  ", lipgloss.NewStyle().Foreground(t.Foreground)
+		if i == current {
+			marker, nameStyle = "▸ ", lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+		}
+
+		// Long paths keep their end, where the file name is
+		path := f.path
+		room := inner - 2 - countsWidth - 1
+		if runes := []rune(path); room > 1 && len(runes) > room {
+			path = "…" + string(runes[len(runes)-room+1:])
+		}
+		gap := strings.Repeat(" ", max(inner-2-lipgloss.Width(path)-countsWidth, 1))
+		rows = append(rows, marker+nameStyle.Render(path)+gap+counts)
+	}
+	for len(rows) < height {
+		rows = append(rows, "")
+	}
+
+	return lipgloss.NewStyle().
+		Border(theme.BoxBorder()).
+		BorderForeground(t.Border).
+		Width(inner).
+		Render(strings.Join(rows, "\n"))
+}
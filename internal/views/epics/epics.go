@@ -0,0 +1,264 @@
+package epics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+	"github.com/robertguss/bmad-automate-go/internal/util"
+)
+
+// barWidth is the width of each epic's progress bar
+const barWidth = 24
+
+// epic is the stories of one epic, in sprint-status order
+type epic struct {
+	number  int
+	stories []domain.Story
+	counts  map[domain.StoryStatus]int
+}
+
+// Model represents the epic overview
+type Model struct {
+	width    int
+	height   int
+	epics    []epic
+	cursor   int
+	expanded map[int]bool
+	styles   theme.Styles
+}
+
+// New creates a new epic overview model
+func New() Model {
+	return Model{
+		expanded: make(map[int]bool),
+		styles:   theme.NewStyles(),
+	}
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+
+	case messages.StoriesLoadedMsg:
+		if msg.Error == nil {
+			m.SetStories(msg.Stories)
+		}
+
+	case messages.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	}
+	return m, nil
+}
+
+func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.cursor = max(m.cursor-1, 0)
+	case "down", "j":
+		m.cursor = min(m.cursor+1, max(len(m.epics)-1, 0))
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = max(len(m.epics)-1, 0)
+	case " ", "right", "left":
+		if e, ok := m.selected(); ok {
+			m.expanded[e.number] = !m.expanded[e.number]
+		}
+	}
+	return m, nil
+}
+
+// SetSize sets the view dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// RefreshStyles rebuilds the styles after a theme change
+func (m *Model) RefreshStyles() {
+	m.styles = theme.NewStyles()
+}
+
+// SetStories groups stories by epic, keeping the cursor on its epic
+func (m *Model) SetStories(stories []domain.Story) {
+	current, hadCurrent := m.selected()
+
+	byNumber := make(map[int]*epic)
+	var numbers []int
+	for _, s := range stories {
+		e, ok := byNumber[s.Epic]
+		if !ok {
+			e = &epic{number: s.Epic, counts: make(map[domain.StoryStatus]int)}
+			byNumber[s.Epic] = e
+			numbers = append(numbers, s.Epic)
+		}
+		e.stories = append(e.stories, s)
+		e.counts[s.Status]++
+	}
+	sort.Ints(numbers)
+
+	m.epics = nil
+	for _, n := range numbers {
+		m.epics = append(m.epics, *byNumber[n])
+	}
+
+	m.cursor = min(m.cursor, max(len(m.epics)-1, 0))
+	if hadCurrent {
+		for i, e := range m.epics {
+			if e.number == current.number {
+				m.cursor = i
+			}
+		}
+	}
+}
+
+// Selected returns the number of the epic under the cursor
+func (m Model) Selected() (int, bool) {
+	e, ok := m.selected()
+	return e.number, ok
+}
+
+func (m Model) selected() (epic, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.epics) {
+		return epic{}, false
+	}
+	return m.epics[m.cursor], true
+}
+
+// View renders the epic overview
+func (m Model) View() string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		Padding(0, 0, 1, 0).
+		Render("Epics")
+
+	if len(m.epics) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, title, muted.Render("No stories loaded"))
+	}
+
+	var rows []string
+	for i, e := range m.epics {
+		rows = append(rows, m.renderEpic(e, i == m.cursor))
+		if m.expanded[e.number] {
+			for _, s := range e.stories {
+				rows = append(rows, m.renderStory(s))
+			}
+		}
+	}
+
+	// Keep the selected epic in view; the title and footer take 4 lines
+	visible := max(m.height-4, 1)
+	start := 0
+	for i := 0; i < m.cursor; i++ {
+		start++
+		if m.expanded[m.epics[i].number] {
+			start += len(m.epics[i].stories)
+		}
+	}
+	if start < visible-1 {
+		start = 0
+	} else {
+		start -= visible / 2
+	}
+	end := min(start+visible, len(rows))
+	body := strings.Join(rows[start:end], "\n")
+
+	footer := muted.Render("Up/Down navigate | Space expand | q/Enter queue the epic | r reload")
+	return lipgloss.JoinVertical(lipgloss.Left, title, body, "", footer)
+}
+
+// renderEpic renders an epic's progress bar and its counts by status
+func (m Model) renderEpic(e epic, selected bool) string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	cursor := "  "
+	if selected {
+		cursor = lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render("> ")
+	}
+	toggle := "▸"
+	if m.expanded[e.number] {
+		toggle = "▾"
+	}
+
+	name := fmt.Sprintf("Epic %d", e.number)
+	if e.number == 0 {
+		name = "No epic"
+	}
+	name = lipgloss.NewStyle().Foreground(t.Foreground).Bold(true).Width(9).Render(name)
+
+	done := e.counts[domain.StatusDone]
+	total := len(e.stories)
+	percent := float64(done) / float64(total) * 100
+	bar := lipgloss.NewStyle().
+		Foreground(t.Success).
+		Background(t.Border).
+		Render(util.HBar(percent, 100, barWidth))
+	progress := fmt.Sprintf(" %3.0f%% %s", percent, muted.Render(fmt.Sprintf("%d/%d", done, total)))
+
+	var counts []string
+	for _, c := range []struct {
+		status domain.StoryStatus
+		label  string
+		style  lipgloss.Style
+	}{
+		{domain.StatusInProgress, "in progress", m.styles.BadgeInProgress},
+		{domain.StatusReadyForDev, "ready", m.styles.BadgeReadyForDev},
+		{domain.StatusBacklog, "backlog", m.styles.BadgeBacklog},
+		{domain.StatusBlocked, "blocked", lipgloss.NewStyle().Foreground(t.Error)},
+	} {
+		if n := e.counts[c.status]; n > 0 {
+			counts = append(counts, c.style.Render(fmt.Sprintf(" %d %s ", n, c.label)))
+		}
+	}
+	if len(counts) == 0 {
+		counts = append(counts, m.styles.BadgeDone.Render(" complete "))
+	}
+
+	row := fmt.Sprintf("%s%s %s %s%s  %s", cursor, toggle, name, bar, progress, strings.Join(counts, " "))
+	if selected {
+		row = lipgloss.NewStyle().Background(t.Selection).Width(max(m.width-4, 0)).Render(row)
+	}
+	return row
+}
+
+// renderStory renders a story under its expanded epic
+func (m Model) renderStory(s domain.Story) string {
+	t := theme.Current
+
+	var status string
+	switch s.Status {
+	case domain.StatusInProgress:
+		status = m.styles.BadgeInProgress.Render(" IN PROGRESS ")
+	case domain.StatusReadyForDev:
+		status = m.styles.BadgeReadyForDev.Render(" READY ")
+	case domain.StatusBacklog:
+		status = m.styles.BadgeBacklog.Render(" BACKLOG ")
+	case domain.StatusDone:
+		status = m.styles.BadgeDone.Render(" DONE ")
+	default:
+		status = lipgloss.NewStyle().Foreground(t.Error).Render(" " + strings.ToUpper(string(s.Status)) + " ")
+	}
+
+	key := lipgloss.NewStyle().Foreground(t.Foreground).Render(s.Key)
+	return "      " + key + " " + status
+}
@@ -28,19 +28,39 @@ type Model struct {
 	styles    theme.Styles
 	startTime time.Time
 	elapsed   time.Duration
+
+	// Historical step averages used for slow step warnings
+	stepAverages map[domain.StepName]time.Duration
+
+	// Set when mirroring another instance, which hides the run controls
+	readOnly bool
+
+	// Set when the execution is the executor's own, so a failed step can
+	// be retried
+	retryable bool
+
+	// Attempt browser: the retried step being inspected (-1 shows the
+	// live output), which of its attempts is shown, and whether that
+	// attempt is diffed against the one before it
+	attemptStep  int
+	attemptIndex int
+	attemptDiff  bool
+	attemptLines []outputLine
 }
 
 type outputLine struct {
 	text     string
 	isStderr bool
 	step     int
+	op       util.DiffOp // Set for lines of an attempt diff
 }
 
 // New creates a new execution view model
 func New() Model {
 	return Model{
-		output: make([]outputLine, 0, maxOutputLines),
-		styles: theme.NewStyles(),
+		output:      make([]outputLine, 0, maxOutputLines),
+		styles:      theme.NewStyles(),
+		attemptStep: -1,
 	}
 }
 
@@ -78,16 +98,39 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.scroll = 0
 		case "end":
 			m.scroll = m.maxScroll()
+		case "t":
+			m.nextRetriedStep()
+		case "[":
+			if m.attemptStep >= 0 && m.attemptIndex > 0 {
+				m.attemptIndex--
+				m.showAttempt()
+			}
+		case "]":
+			if m.attemptStep >= 0 && m.attemptIndex < len(m.execution.Steps[m.attemptStep].Attempts())-1 {
+				m.attemptIndex++
+				m.showAttempt()
+			}
+		case "x":
+			if m.attemptStep >= 0 && m.attemptIndex > 0 {
+				m.attemptDiff = !m.attemptDiff
+				m.showAttempt()
+			}
 		}
 
 	case messages.ExecutionStartedMsg:
 		m.execution = msg.Execution
 		m.output = make([]outputLine, 0, maxOutputLines)
+		m.attemptStep = -1
 		m.scroll = 0
 		m.startTime = time.Now()
 		m.elapsed = 0
 
 	case messages.StepStartedMsg:
+		// Steps of other executions, such as stories running in parallel,
+		// are not shown here
+		if !msg.Belongs(m.execution) {
+			break
+		}
 		if m.execution != nil && msg.StepIndex < len(m.execution.Steps) {
 			step := m.execution.Steps[msg.StepIndex]
 			step.Status = domain.StepRunning
@@ -101,11 +144,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 
 	case messages.StepOutputMsg:
+		if !msg.Belongs(m.execution) {
+			break
+		}
+		if m.execution != nil && msg.StepIndex < len(m.execution.Steps) {
+			m.execution.Steps[msg.StepIndex].Stalled = false
+		}
 		m.addOutput(msg.Line, msg.IsStderr, msg.StepIndex)
 		// Auto-scroll to bottom when new output arrives
-		m.scroll = m.maxScroll()
+		m.follow()
 
 	case messages.StepCompletedMsg:
+		if !msg.Belongs(m.execution) {
+			break
+		}
 		if m.execution != nil && msg.StepIndex < len(m.execution.Steps) {
 			step := m.execution.Steps[msg.StepIndex]
 			step.Status = msg.Status
@@ -114,6 +166,33 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			if msg.Error != "" {
 				step.Error = msg.Error
 			}
+			if !msg.Usage.IsZero() {
+				step.Usage = msg.Usage
+			}
+		}
+
+	case messages.StepStalledMsg:
+		if !msg.Belongs(m.execution) {
+			break
+		}
+		if m.execution != nil && msg.StepIndex < len(m.execution.Steps) {
+			m.execution.Steps[msg.StepIndex].Stalled = true
+			line := fmt.Sprintf("*** no output for %s - step appears stalled ***", formatDuration(msg.Idle))
+			if msg.Killed {
+				line = fmt.Sprintf("*** no output for %s - killing stalled step ***", formatDuration(msg.Idle))
+			}
+			m.addOutput(line, true, msg.StepIndex)
+			m.follow()
+		}
+
+	case messages.StepWaitingMsg:
+		if !msg.Belongs(m.execution) {
+			break
+		}
+		if m.execution != nil && msg.StepIndex < len(m.execution.Steps) {
+			m.execution.Status = domain.ExecutionPaused
+			m.addOutput(fmt.Sprintf("*** waiting for approval: %s ***", msg.Message), false, msg.StepIndex)
+			m.follow()
 		}
 
 	case messages.ExecutionCompletedMsg:
@@ -150,12 +229,66 @@ func (m *Model) RefreshStyles() {
 	m.styles = theme.NewStyles()
 }
 
-// SetExecution sets the current execution
+// SetExecution sets the current execution. Output already recorded on its
+// steps is replayed so an execution joined mid-run shows what came before.
 func (m *Model) SetExecution(exec *domain.Execution) {
 	m.execution = exec
 	m.output = make([]outputLine, 0, maxOutputLines)
+	m.attemptStep = -1
 	m.scroll = 0
 	m.startTime = time.Now()
+	if exec == nil {
+		return
+	}
+	if !exec.StartTime.IsZero() {
+		m.startTime = exec.StartTime
+	}
+
+	for i, step := range exec.Steps {
+		if len(step.Output) == 0 {
+			continue
+		}
+		m.addOutput(fmt.Sprintf("--- %s (attempt %d) ---", step.Name, step.Attempt), false, i)
+		for _, line := range step.Output {
+			text, isStderr := strings.CutPrefix(line, "[stderr] ")
+			m.addOutput(text, isStderr, i)
+		}
+	}
+	m.scroll = m.maxScroll()
+}
+
+// SetReadOnly hides the pause, skip and cancel controls
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+}
+
+// SetRetryable shows the retry control when the execution fails
+func (m *Model) SetRetryable(retryable bool) {
+	m.retryable = retryable
+}
+
+// SetStepAverages sets historical step durations used to flag slow steps
+func (m *Model) SetStepAverages(averages map[domain.StepName]time.Duration) {
+	m.stepAverages = averages
+}
+
+// SlowStepWarning returns a warning when the running step has exceeded
+// domain.SlowStepRatio of its historical average, or "" otherwise
+func (m Model) SlowStepWarning() string {
+	if m.execution == nil {
+		return ""
+	}
+	step := m.execution.CurrentStep()
+	if step == nil {
+		return ""
+	}
+
+	avg := m.stepAverages[step.Name]
+	now := time.Now()
+	if !step.IsSlow(avg, now) {
+		return ""
+	}
+	return fmt.Sprintf("%s running %.1fx longer than usual", step.Name, step.OverrunRatio(avg, now))
 }
 
 // GetExecution returns the current execution
@@ -177,13 +310,86 @@ func (m *Model) addOutput(line string, isStderr bool, step int) {
 	}
 }
 
+// follow scrolls to the newest output unless an attempt is being inspected
+func (m *Model) follow() {
+	if m.attemptStep < 0 {
+		m.scroll = m.maxScroll()
+	}
+}
+
+// nextRetriedStep moves the attempt browser to the next step that ran more
+// than once, or back to the live output after the last one
+func (m *Model) nextRetriedStep() {
+	if m.execution == nil {
+		return
+	}
+	for i := m.attemptStep + 1; i < len(m.execution.Steps); i++ {
+		if attempts := m.execution.Steps[i].Attempts(); len(attempts) > 1 {
+			m.attemptStep = i
+			m.attemptIndex = len(attempts) - 1
+			m.attemptDiff = false
+			m.showAttempt()
+			return
+		}
+	}
+	m.attemptStep = -1
+	m.attemptLines = nil
+	m.scroll = m.maxScroll()
+}
+
+// showAttempt fills the output pane with the selected attempt, or with its
+// diff against the previous attempt
+func (m *Model) showAttempt() {
+	attempts := m.execution.Steps[m.attemptStep].Attempts()
+	current := attempts[m.attemptIndex]
+
+	m.attemptLines = m.attemptLines[:0]
+	if m.attemptDiff && m.attemptIndex > 0 {
+		for _, d := range util.DiffLines(attempts[m.attemptIndex-1].Output, current.Output) {
+			m.attemptLines = append(m.attemptLines, outputLine{text: d.Text, step: m.attemptStep, op: d.Op})
+		}
+	} else {
+		for _, line := range current.Output {
+			text, isStderr := strings.CutPrefix(line, "[stderr] ")
+			m.attemptLines = append(m.attemptLines, outputLine{text: text, isStderr: isStderr, step: m.attemptStep})
+		}
+	}
+	if current.Error != "" && !m.attemptDiff {
+		m.attemptLines = append(m.attemptLines, outputLine{
+			text: "*** attempt failed: " + current.Error + " ***", isStderr: true, step: m.attemptStep,
+		})
+	}
+	m.scroll = 0
+}
+
+// visibleOutput returns the lines the output pane shows
+func (m Model) visibleOutput() []outputLine {
+	if m.attemptStep >= 0 {
+		return m.attemptLines
+	}
+	return m.output
+}
+
+// hasRetriedStep reports whether any step ran more than once
+func (m Model) hasRetriedStep() bool {
+	if m.execution == nil {
+		return false
+	}
+	for _, step := range m.execution.Steps {
+		if len(step.PreviousAttempts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // maxScroll returns the maximum scroll position
 func (m Model) maxScroll() int {
 	outputHeight := m.height - 8 // Account for header, footer, borders
-	if len(m.output) <= outputHeight {
+	if len(m.visibleOutput()) <= outputHeight {
 		return 0
 	}
-	return len(m.output) - outputHeight
+	return len(m.visibleOutput()) - outputHeight
 }
 
 // View renders the execution view
@@ -198,6 +404,11 @@ func (m Model) View() string {
 	rightPaneWidth := m.width - leftPaneWidth - 5 // 5 for borders and padding
 	contentHeight := m.height - 4                 // Account for controls at bottom
 
+	contextLine := m.renderContext()
+	if contextLine != "" {
+		contentHeight--
+	}
+
 	// Render left pane (step list)
 	leftPane := m.renderStepList(leftPaneWidth, contentHeight)
 
@@ -220,13 +431,89 @@ func (m Model) View() string {
 		statusLine = lipgloss.NewStyle().
 			Foreground(t.Subtle).
 			Render(fmt.Sprintf("  %s  |  Elapsed: %s  |  Progress: %s", statusText, elapsed, progress))
+
+		if m.execution.ID != "" {
+			statusLine += lipgloss.NewStyle().
+				Foreground(t.Subtle).
+				Render("  |  ID: " + domain.ShortID(m.execution.ID))
+		}
+
+		if usage := m.execution.Usage(); !usage.IsZero() {
+			statusLine += lipgloss.NewStyle().
+				Foreground(t.Subtle).
+				Render(fmt.Sprintf("  |  Tokens: %s  |  Cost: %s",
+					util.FormatTokens(usage.Tokens()), util.FormatCost(usage.CostUSD)))
+		}
+
+		if warning := m.SlowStepWarning(); warning != "" {
+			statusLine += lipgloss.NewStyle().
+				Foreground(t.Warning).
+				Bold(true).
+				Render("  |  " + warning)
+		}
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left,
-		content,
-		statusLine,
-		controls,
-	)
+	lines := []string{content, statusLine}
+	if contextLine != "" {
+		lines = append(lines, contextLine)
+	}
+	lines = append(lines, controls)
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// renderContext renders the environment the execution started in, e.g.
+// "Env: main@1a2b3c4* | claude 1.0.3 | bmad v0.4.0 | linux/amd64 | config 9f8e7d6c5b4a".
+// A trailing * marks uncommitted changes. Branch-per-story runs start with
+// the story branch, followed by the branch they started from. An auto-stash
+// that could not be restored comes last.
+func (m Model) renderContext() string {
+	if m.execution == nil || m.execution.Context == nil {
+		return ""
+	}
+	ec := m.execution.Context
+
+	var parts []string
+	if m.execution.Branch != "" {
+		parts = append(parts, "ran on "+m.execution.Branch)
+	}
+	if ec.GitCommit != "" {
+		commit := ec.GitCommit
+		if len(commit) > 7 {
+			commit = commit[:7]
+		}
+		ref := ec.GitBranch + "@" + commit
+		if ec.GitDirty {
+			ref += "*"
+		}
+		parts = append(parts, ref)
+	}
+	if ec.ClaudeVersion != "" {
+		parts = append(parts, "claude "+ec.ClaudeVersion)
+	}
+	if ec.BmadVersion != "" {
+		parts = append(parts, "bmad "+ec.BmadVersion)
+	}
+	parts = append(parts, ec.OS+"/"+ec.Arch)
+	if ec.Workflow != "" {
+		parts = append(parts, "workflow "+ec.Workflow)
+	}
+	if ec.Profile != "" {
+		parts = append(parts, "profile "+ec.Profile)
+	}
+	if ec.ConfigDigest != "" {
+		parts = append(parts, "config "+ec.ConfigDigest)
+	}
+	if stash := m.execution.Stash; stash != "" {
+		if len(stash) > 7 {
+			stash = stash[:7]
+		}
+		parts = append(parts, "stash "+stash+" not restored")
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(theme.Current.Subtle).
+		MaxWidth(m.width).
+		Render("  Env: " + strings.Join(parts, "  |  "))
 }
 
 // renderStepList renders the step progress list
@@ -266,7 +553,7 @@ func (m Model) renderStepList(width, height int) string {
 
 	// Add border
 	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Width(width).
 		Height(height).
@@ -284,19 +571,19 @@ func (m Model) renderStep(index int, step *domain.StepExecution, width int) stri
 
 	switch step.Status {
 	case domain.StepPending:
-		indicator = lipgloss.NewStyle().Foreground(t.Subtle).Render("  ")
+		indicator = lipgloss.NewStyle().Foreground(t.Subtle).Render(theme.GlyphPending + " ")
 		nameStyle = lipgloss.NewStyle().Foreground(t.Subtle)
 	case domain.StepRunning:
-		indicator = lipgloss.NewStyle().Foreground(t.Warning).Bold(true).Render("> ")
+		indicator = lipgloss.NewStyle().Foreground(t.Warning).Bold(true).Render(theme.GlyphRunning + " ")
 		nameStyle = lipgloss.NewStyle().Foreground(t.Warning).Bold(true)
 	case domain.StepSuccess:
-		indicator = lipgloss.NewStyle().Foreground(t.Success).Render("OK")
+		indicator = lipgloss.NewStyle().Foreground(t.Success).Render(theme.GlyphSuccess + " ")
 		nameStyle = lipgloss.NewStyle().Foreground(t.Success)
 	case domain.StepFailed:
-		indicator = lipgloss.NewStyle().Foreground(t.Error).Render("XX")
+		indicator = lipgloss.NewStyle().Foreground(t.Error).Render(theme.GlyphFailed + " ")
 		nameStyle = lipgloss.NewStyle().Foreground(t.Error)
 	case domain.StepSkipped:
-		indicator = lipgloss.NewStyle().Foreground(t.Subtle).Render("--")
+		indicator = lipgloss.NewStyle().Foreground(t.Subtle).Render(theme.GlyphSkipped + " ")
 		nameStyle = lipgloss.NewStyle().Foreground(t.Subtle).Italic(true)
 	}
 
@@ -311,9 +598,19 @@ func (m Model) renderStep(index int, step *domain.StepExecution, width int) stri
 			Render(" " + formatDuration(step.Duration))
 	} else if step.Status == domain.StepRunning && !step.StartTime.IsZero() {
 		elapsed := time.Since(step.StartTime)
+		durationColor := t.Subtle
+		if step.IsSlow(m.stepAverages[step.Name], time.Now()) {
+			durationColor = t.Warning
+		}
 		duration = lipgloss.NewStyle().
-			Foreground(t.Subtle).
+			Foreground(durationColor).
 			Render(" " + formatDuration(elapsed))
+		if step.Stalled {
+			duration += lipgloss.NewStyle().
+				Foreground(t.Error).
+				Bold(true).
+				Render(" stalled")
+		}
 	}
 
 	// Attempt info
@@ -324,8 +621,23 @@ func (m Model) renderStep(index int, step *domain.StepExecution, width int) stri
 			Render(fmt.Sprintf(" [%d]", step.Attempt))
 	}
 
+	// Prompt edited in the step preview for this run
+	if step.PromptOverride != "" {
+		attempt += lipgloss.NewStyle().
+			Foreground(t.Subtle).
+			Render(" (edited)")
+	}
+
+	// Cost of the step's agent calls, when usage is tracked
+	var cost string
+	if !step.Usage.IsZero() {
+		cost = lipgloss.NewStyle().
+			Foreground(t.Subtle).
+			Render(" " + util.FormatCost(step.Usage.CostUSD))
+	}
+
 	// Highlight current step
-	row := fmt.Sprintf("%s %s%s%s", indicator, name, attempt, duration)
+	row := fmt.Sprintf("%s %s%s%s%s", indicator, name, attempt, duration, cost)
 	if m.execution != nil && index == m.execution.Current && step.Status == domain.StepRunning {
 		row = lipgloss.NewStyle().
 			Background(t.Selection).
@@ -340,17 +652,30 @@ func (m Model) renderStep(index int, step *domain.StepExecution, width int) stri
 func (m Model) renderOutput(width, height int) string {
 	t := theme.Current
 
+	output := m.visibleOutput()
+
 	// Title
+	titleText := "Output"
+	if m.attemptStep >= 0 {
+		step := m.execution.Steps[m.attemptStep]
+		attempts := step.Attempts()
+		if m.attemptDiff {
+			titleText = fmt.Sprintf("%s: attempt %d vs %d", step.Name,
+				attempts[m.attemptIndex-1].Number, attempts[m.attemptIndex].Number)
+		} else {
+			titleText = fmt.Sprintf("%s: attempt %d of %d", step.Name, attempts[m.attemptIndex].Number, len(attempts))
+		}
+	}
 	title := lipgloss.NewStyle().
 		Foreground(t.Primary).
 		Bold(true).
-		Render("Output")
+		Render(titleText)
 
 	scrollInfo := ""
-	if len(m.output) > 0 {
+	if len(output) > 0 {
 		scrollInfo = lipgloss.NewStyle().
 			Foreground(t.Subtle).
-			Render(fmt.Sprintf(" (%d/%d)", m.scroll+1, len(m.output)))
+			Render(fmt.Sprintf(" (%d/%d)", m.scroll+1, len(output)))
 	}
 
 	header := title + scrollInfo
@@ -359,21 +684,25 @@ func (m Model) renderOutput(width, height int) string {
 	outputHeight := height - 4 // Account for header and padding
 	var lines []string
 
-	if len(m.output) == 0 {
+	if len(output) == 0 {
+		empty := "Waiting for output..."
+		if m.attemptStep >= 0 {
+			empty = "No output"
+		}
 		lines = append(lines, lipgloss.NewStyle().
 			Foreground(t.Subtle).
 			Italic(true).
-			Render("Waiting for output..."))
+			Render(empty))
 	} else {
 		// Get visible lines based on scroll
 		startIdx := m.scroll
 		endIdx := startIdx + outputHeight
-		if endIdx > len(m.output) {
-			endIdx = len(m.output)
+		if endIdx > len(output) {
+			endIdx = len(output)
 		}
 
 		for i := startIdx; i < endIdx; i++ {
-			line := m.output[i]
+			line := output[i]
 			style := lipgloss.NewStyle().Foreground(t.Foreground)
 			if line.isStderr {
 				style = style.Foreground(t.Error)
@@ -381,6 +710,16 @@ func (m Model) renderOutput(width, height int) string {
 
 			// Truncate long lines
 			text := line.text
+			if m.attemptDiff {
+				switch line.op {
+				case util.DiffRemoved:
+					text, style = "- "+text, style.Foreground(t.Error)
+				case util.DiffAdded:
+					text, style = "+ "+text, style.Foreground(t.Success)
+				default:
+					text, style = "  "+text, style.Foreground(t.Subtle)
+				}
+			}
 			if len(text) > width-4 {
 				text = text[:width-7] + "..."
 			}
@@ -397,7 +736,7 @@ func (m Model) renderOutput(width, height int) string {
 
 	// Add border
 	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Width(width).
 		Height(height).
@@ -411,26 +750,50 @@ func (m Model) renderControls() string {
 
 	var controls []string
 
-	if m.execution != nil {
+	if m.readOnly {
+		controls = append(controls, renderControl("Read-only", "following another instance"))
+	} else if m.execution != nil {
 		switch m.execution.Status {
 		case domain.ExecutionRunning:
 			controls = append(controls,
 				renderControl("p", "Pause"),
 				renderControl("k", "Skip Step"),
 				renderControl("c", "Cancel"),
+				renderControl("m", "Minimize"),
 			)
 		case domain.ExecutionPaused:
 			controls = append(controls,
 				renderControl("r", "Resume"),
 				renderControl("c", "Cancel"),
+				renderControl("m", "Minimize"),
 			)
-		case domain.ExecutionCompleted, domain.ExecutionFailed, domain.ExecutionCancelled:
+		case domain.ExecutionCompleted, domain.ExecutionFailed, domain.ExecutionCancelled, domain.ExecutionConflict:
 			controls = append(controls,
 				renderControl("Enter", "Back to Stories"),
+				renderControl("L", "Full Log"),
 			)
+			if m.retryable && m.execution.CanRetry() {
+				controls = append(controls, renderControl("r", "Retry Failed Step"))
+			}
+			if m.execution.Snapshot != nil {
+				controls = append(controls, renderControl("v", "Changes"))
+				if m.execution.Status != domain.ExecutionCompleted {
+					controls = append(controls, renderControl("u", "Restore Workspace"))
+				}
+			}
 		}
 	}
 
+	if m.attemptStep >= 0 {
+		controls = append(controls, renderControl("[/]", "Attempt"))
+		if m.attemptIndex > 0 {
+			controls = append(controls, renderControl("x", "Diff Previous"))
+		}
+		controls = append(controls, renderControl("t", "Next Retried Step"))
+	} else if m.hasRetriedStep() {
+		controls = append(controls, renderControl("t", "Attempts"))
+	}
+
 	controls = append(controls,
 		renderControl("Up/Down", "Scroll"),
 		renderControl("Home/End", "Jump"),
@@ -472,9 +835,12 @@ func (m Model) renderStatusBadge() string {
 	case domain.ExecutionCancelled:
 		style = lipgloss.NewStyle().Foreground(t.Warning).Bold(true)
 		text = "CANCELLED"
+	case domain.ExecutionConflict:
+		style = lipgloss.NewStyle().Foreground(t.Warning).Bold(true)
+		text = "CONFLICT"
 	}
 
-	return style.Render(text)
+	return style.Render(theme.ExecutionGlyph(m.execution.Status) + " " + text)
 }
 
 // renderControl renders a single control hint
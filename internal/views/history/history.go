@@ -30,6 +30,34 @@ type Model struct {
 	filterEpic   *int
 	filterStatus domain.ExecutionStatus
 	filtering    bool
+
+	// Note prompt state, for the execution with noteID
+	noting    bool
+	noteID    string
+	noteInput string
+
+	// Grouping state ("" = flat list). Group executions are loaded the
+	// first time a group is expanded.
+	groupBy  string
+	groups   []*messages.HistoryGroup
+	expanded map[string]bool
+	children map[string][]*messages.HistoryExecution
+}
+
+// Grouping modes, cycled with "g"
+const (
+	GroupNone  = ""
+	GroupStory = "story"
+	GroupDay   = "day"
+	GroupEpic  = "epic"
+)
+
+var groupModes = []string{GroupNone, GroupStory, GroupDay, GroupEpic}
+
+// row is one line of the list: a group header or an execution
+type row struct {
+	group *messages.HistoryGroup
+	exec  *messages.HistoryExecution
 }
 
 // New creates a new history view model
@@ -38,6 +66,8 @@ func New() Model {
 		styles:     theme.NewStyles(),
 		executions: make([]*messages.HistoryExecution, 0),
 		loading:    true,
+		expanded:   make(map[string]bool),
+		children:   make(map[string][]*messages.HistoryExecution),
 	}
 }
 
@@ -50,6 +80,9 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.noting {
+			return m.handleNoteInput(msg)
+		}
 		if m.filtering {
 			return m.handleFilterInput(msg)
 		}
@@ -68,11 +101,32 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.executions = msg.Executions
 		m.totalCount = msg.TotalCount
 		m.errorMsg = ""
-		// Reset cursor if out of bounds
-		if m.cursor >= len(m.executions) {
-			m.cursor = 0
-			m.scroll = 0
+		m.clampCursor()
+
+	case messages.HistoryGroupsLoadedMsg:
+		if msg.GroupBy != m.groupBy {
+			return m, nil // Grouping changed while loading
+		}
+		m.loading = false
+		if msg.Error != nil {
+			m.errorMsg = msg.Error.Error()
+			return m, nil
+		}
+		m.groups = msg.Groups
+		m.expanded = make(map[string]bool)
+		m.children = make(map[string][]*messages.HistoryExecution)
+		m.errorMsg = ""
+		m.clampCursor()
+
+	case messages.HistoryGroupExecutionsMsg:
+		if msg.GroupBy != m.groupBy {
+			return m, nil
 		}
+		if msg.Error != nil {
+			m.errorMsg = msg.Error.Error()
+			return m, nil
+		}
+		m.children[msg.Key] = msg.Executions
 	}
 
 	return m, nil
@@ -89,7 +143,7 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		}
 
 	case "down":
-		if m.cursor < len(m.executions)-1 {
+		if m.cursor < len(m.rows())-1 {
 			m.cursor++
 			contentHeight := m.contentHeight()
 			if m.cursor >= m.scroll+contentHeight {
@@ -102,8 +156,8 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		m.scroll = 0
 
 	case "end":
-		if len(m.executions) > 0 {
-			m.cursor = len(m.executions) - 1
+		if n := len(m.rows()); n > 0 {
+			m.cursor = n - 1
 			contentHeight := m.contentHeight()
 			if m.cursor >= contentHeight {
 				m.scroll = m.cursor - contentHeight + 1
@@ -124,8 +178,8 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 	case "pgdown":
 		contentHeight := m.contentHeight()
 		m.cursor += contentHeight
-		if m.cursor >= len(m.executions) {
-			m.cursor = len(m.executions) - 1
+		if n := len(m.rows()); m.cursor >= n {
+			m.cursor = n - 1
 		}
 		if m.cursor < 0 {
 			m.cursor = 0
@@ -136,6 +190,44 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.scroll = maxScroll
 		}
 
+	case "l":
+		if exec := m.selected().exec; exec != nil {
+			return m, func() tea.Msg {
+				return messages.HistoryLinkMsg{ID: exec.ID}
+			}
+		}
+
+	case "L":
+		if exec := m.selected().exec; exec != nil {
+			return m, func() tea.Msg {
+				return messages.LogsRequestMsg{ID: exec.ID}
+			}
+		}
+
+	case "D":
+		if exec := m.selected().exec; exec != nil {
+			return m, func() tea.Msg {
+				return messages.DiffRequestMsg{StoryKey: exec.StoryKey, Story: true}
+			}
+		}
+
+	case "n":
+		if exec := m.selected().exec; exec != nil {
+			m.noting = true
+			m.noteID = exec.ID
+			m.noteInput = exec.Note
+		}
+
+	case "g":
+		m.groupBy = nextGroupMode(m.groupBy)
+		m.cursor = 0
+		m.scroll = 0
+		m.loading = true
+		groupBy := m.groupBy
+		return m, func() tea.Msg {
+			return messages.HistoryGroupMsg{GroupBy: groupBy}
+		}
+
 	case "/":
 		m.filtering = true
 		m.filterQuery = ""
@@ -156,9 +248,13 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 			return messages.HistoryRefreshMsg{}
 		}
 
-	case "enter":
-		if len(m.executions) > 0 && m.cursor < len(m.executions) {
-			exec := m.executions[m.cursor]
+	case "enter", " ":
+		sel := m.selected()
+		if sel.group != nil {
+			return m.toggleGroup(sel.group.Key)
+		}
+		if sel.exec != nil && msg.String() == "enter" {
+			exec := sel.exec
 			return m, func() tea.Msg {
 				return messages.HistoryDetailMsg{ID: exec.ID}
 			}
@@ -168,6 +264,77 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// toggleGroup expands or collapses a group, loading its executions the
+// first time it is expanded
+func (m Model) toggleGroup(key string) (Model, tea.Cmd) {
+	if m.expanded[key] {
+		delete(m.expanded, key)
+		m.clampCursor()
+		return m, nil
+	}
+
+	m.expanded[key] = true
+	if _, loaded := m.children[key]; loaded {
+		return m, nil
+	}
+	groupBy := m.groupBy
+	return m, func() tea.Msg {
+		return messages.HistoryGroupExpandMsg{GroupBy: groupBy, Key: key}
+	}
+}
+
+// nextGroupMode returns the grouping mode after mode
+func nextGroupMode(mode string) string {
+	for i, g := range groupModes {
+		if g == mode {
+			return groupModes[(i+1)%len(groupModes)]
+		}
+	}
+	return GroupNone
+}
+
+// rows returns the list lines: the executions in the flat list, or each
+// group followed by its executions when expanded
+func (m Model) rows() []row {
+	if m.groupBy == GroupNone {
+		rows := make([]row, len(m.executions))
+		for i, exec := range m.executions {
+			rows[i] = row{exec: exec}
+		}
+		return rows
+	}
+
+	var rows []row
+	for _, g := range m.groups {
+		rows = append(rows, row{group: g})
+		if m.expanded[g.Key] {
+			for _, exec := range m.children[g.Key] {
+				rows = append(rows, row{exec: exec})
+			}
+		}
+	}
+	return rows
+}
+
+// selected returns the row under the cursor
+func (m Model) selected() row {
+	rows := m.rows()
+	if m.cursor >= 0 && m.cursor < len(rows) {
+		return rows[m.cursor]
+	}
+	return row{}
+}
+
+// clampCursor keeps the cursor and scroll within the current rows
+func (m *Model) clampCursor() {
+	if n := len(m.rows()); m.cursor >= n {
+		m.cursor = max(n-1, 0)
+	}
+	if m.scroll > m.maxScroll() {
+		m.scroll = m.maxScroll()
+	}
+}
+
 func (m Model) handleFilterInput(msg tea.KeyMsg) (Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
@@ -199,6 +366,38 @@ func (m Model) handleFilterInput(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) handleNoteInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.noting = false
+		id, note := m.noteID, strings.TrimSpace(m.noteInput)
+		return m, func() tea.Msg {
+			return messages.HistoryNoteMsg{ID: id, Note: note}
+		}
+
+	case "esc":
+		m.noting = false
+
+	case "backspace":
+		if len(m.noteInput) > 0 {
+			runes := []rune(m.noteInput)
+			m.noteInput = string(runes[:len(runes)-1])
+		}
+
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.noteInput += string(msg.Runes)
+		}
+	}
+
+	return m, nil
+}
+
+// IsPrompting returns true while the filter or note prompt takes input
+func (m Model) IsPrompting() bool {
+	return m.filtering || m.noting
+}
+
 // View renders the history view
 func (m Model) View() string {
 	t := theme.Current
@@ -218,7 +417,12 @@ func (m Model) View() string {
 	sections = append(sections, header)
 
 	// Filter input if active
-	if m.filtering {
+	if m.noting {
+		noteInput := lipgloss.NewStyle().
+			Foreground(t.Accent).
+			Render(fmt.Sprintf("Note: %s_", m.noteInput))
+		sections = append(sections, noteInput)
+	} else if m.filtering {
 		filterInput := lipgloss.NewStyle().
 			Foreground(t.Accent).
 			Render(fmt.Sprintf("Filter: %s_", m.filterQuery))
@@ -265,15 +469,20 @@ func (m Model) renderHeader() string {
 		Bold(true).
 		Render("Execution History")
 
+	text := fmt.Sprintf("(%d executions)", m.totalCount)
+	if m.groupBy != GroupNone {
+		text = fmt.Sprintf("(%d groups by %s)", len(m.groups), m.groupBy)
+	}
 	count := lipgloss.NewStyle().
 		Foreground(t.Subtle).
-		Render(fmt.Sprintf("(%d executions)", m.totalCount))
+		Render(text)
 
 	return lipgloss.JoinHorizontal(lipgloss.Left, title, " ", count)
 }
 
 func (m Model) renderExecutionList() string {
-	if len(m.executions) == 0 {
+	rows := m.rows()
+	if len(rows) == 0 {
 		return lipgloss.NewStyle().
 			Foreground(theme.Current.Subtle).
 			Padding(1, 0).
@@ -286,14 +495,18 @@ func (m Model) renderExecutionList() string {
 	// Calculate visible range
 	start := m.scroll
 	end := start + contentHeight
-	if end > len(m.executions) {
-		end = len(m.executions)
+	if end > len(rows) {
+		end = len(rows)
 	}
 
 	var lines []string
 	for i := start; i < end; i++ {
-		exec := m.executions[i]
-		line := m.renderExecutionRow(exec, i == m.cursor)
+		var line string
+		if r := rows[i]; r.group != nil {
+			line = m.renderGroupRow(r.group, i == m.cursor)
+		} else {
+			line = m.renderExecutionRow(r.exec, i == m.cursor)
+		}
 		lines = append(lines, line)
 	}
 
@@ -301,7 +514,7 @@ func (m Model) renderExecutionList() string {
 	if m.maxScroll() > 0 {
 		scrollInfo := lipgloss.NewStyle().
 			Foreground(t.Subtle).
-			Render(fmt.Sprintf(" [%d-%d of %d]", start+1, end, len(m.executions)))
+			Render(fmt.Sprintf(" [%d-%d of %d]", start+1, end, len(rows)))
 		lines = append(lines, scrollInfo)
 	}
 
@@ -317,16 +530,19 @@ func (m Model) renderExecutionRow(exec *messages.HistoryExecution, selected bool
 	switch exec.Status {
 	case domain.ExecutionCompleted:
 		statusStyle = lipgloss.NewStyle().Foreground(t.Success)
-		statusIcon = "[OK]"
+		statusIcon = "[" + theme.GlyphSuccess + "]"
 	case domain.ExecutionFailed:
 		statusStyle = lipgloss.NewStyle().Foreground(t.Error)
-		statusIcon = "[X]"
+		statusIcon = "[" + theme.GlyphFailed + "]"
 	case domain.ExecutionCancelled:
 		statusStyle = lipgloss.NewStyle().Foreground(t.Warning)
-		statusIcon = "[!]"
+		statusIcon = "[" + theme.GlyphCancelled + "]"
+	case domain.ExecutionConflict:
+		statusStyle = lipgloss.NewStyle().Foreground(t.Warning)
+		statusIcon = "[" + theme.GlyphConflict + "]"
 	default:
 		statusStyle = lipgloss.NewStyle().Foreground(t.Subtle)
-		statusIcon = "[-]"
+		statusIcon = "[" + theme.ExecutionGlyph(exec.Status) + "]"
 	}
 
 	// Format time
@@ -357,12 +573,36 @@ func (m Model) renderExecutionRow(exec *messages.HistoryExecution, selected bool
 		Width(8).
 		Render(fmt.Sprintf("E%d", exec.StoryEpic))
 
+	// Executions inside a group are indented under it
+	indent := ""
+	if m.groupBy != GroupNone {
+		indent = "    "
+	}
+
+	var tagsCol string
+	if len(exec.Tags) > 0 {
+		tagsCol = lipgloss.NewStyle().
+			Foreground(t.Accent).
+			Render(" #" + strings.Join(exec.Tags, " #"))
+	}
+
+	var noteCol string
+	if exec.Note != "" {
+		noteCol = lipgloss.NewStyle().
+			Foreground(t.Subtle).
+			Italic(true).
+			Render("  " + truncate(exec.Note, 40))
+	}
+
 	row := lipgloss.JoinHorizontal(lipgloss.Left,
+		indent,
 		status, " ",
 		storyKey, " ",
 		epicCol, " ",
 		timeCol, " ",
 		durationCol,
+		tagsCol,
+		noteCol,
 	)
 
 	// Apply selection style
@@ -378,12 +618,64 @@ func (m Model) renderExecutionRow(exec *messages.HistoryExecution, selected bool
 	return row
 }
 
+// renderGroupRow renders a group header with its aggregate statistics
+func (m Model) renderGroupRow(g *messages.HistoryGroup, selected bool) string {
+	t := theme.Current
+
+	marker := "▸"
+	if m.expanded[g.Key] {
+		marker = "▾"
+	}
+
+	label := g.Key
+	if m.groupBy == GroupEpic {
+		label = "Epic " + g.Key
+	}
+
+	rateColor := t.Success
+	switch {
+	case g.SuccessRate < 50:
+		rateColor = t.Error
+	case g.SuccessRate < 80:
+		rateColor = t.Warning
+	}
+
+	row := lipgloss.JoinHorizontal(lipgloss.Left,
+		lipgloss.NewStyle().Foreground(t.Accent).Render(marker), " ",
+		lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Width(22).Render(truncate(label, 22)), " ",
+		lipgloss.NewStyle().Foreground(t.Subtle).Width(9).Render(pluralRuns(g.Count)), " ",
+		lipgloss.NewStyle().Foreground(rateColor).Width(8).Render(fmt.Sprintf("%.0f%%", g.SuccessRate)), " ",
+		lipgloss.NewStyle().Foreground(t.Foreground).Width(14).Render("avg "+formatDuration(g.AvgDuration)), " ",
+		lipgloss.NewStyle().Foreground(t.Foreground).Width(16).Render("total "+formatDuration(g.TotalDuration)), " ",
+		lipgloss.NewStyle().Foreground(t.Subtle).Render("last "+g.LastRun.Format("2006-01-02 15:04")),
+	)
+
+	if selected {
+		row = lipgloss.NewStyle().
+			Background(t.Selection).
+			Foreground(t.Foreground).
+			Bold(true).
+			Width(m.width - 4).
+			Render(row)
+	}
+
+	return row
+}
+
 func (m Model) renderFooter() string {
 	t := theme.Current
 
+	enter := "Enter: View Details"
+	if m.groupBy != GroupNone {
+		enter = "Enter: Expand/Details"
+	}
 	help := []string{
 		"Up/Down: Navigate",
-		"Enter: View Details",
+		enter,
+		"l: Link",
+		"n: Note",
+		"D: Diff",
+		"g: Group (" + groupName(nextGroupMode(m.groupBy)) + ")",
 		"/: Filter",
 		"r: Refresh",
 		"c: Clear Filter",
@@ -430,7 +722,7 @@ func (m *Model) GetFilter() (string, *int, domain.ExecutionStatus) {
 func (m Model) contentHeight() int {
 	// Reserve space for header (1), filter (1), footer (2), and some padding
 	reserved := 5
-	if m.filtering || m.filterQuery != "" {
+	if m.noting || m.filtering || m.filterQuery != "" {
 		reserved++
 	}
 	height := m.height - reserved
@@ -443,10 +735,24 @@ func (m Model) contentHeight() int {
 // maxScroll returns the maximum scroll position
 func (m Model) maxScroll() int {
 	contentHeight := m.contentHeight()
-	if len(m.executions) <= contentHeight {
+	n := len(m.rows())
+	if n <= contentHeight {
 		return 0
 	}
-	return len(m.executions) - contentHeight
+	return n - contentHeight
+}
+
+// GroupBy returns the grouping mode ("" for the flat list)
+func (m Model) GroupBy() string {
+	return m.groupBy
+}
+
+// groupName describes a grouping mode in the footer
+func groupName(mode string) string {
+	if mode == GroupNone {
+		return "off"
+	}
+	return "by " + mode
 }
 
 // Helper functions
@@ -455,6 +761,13 @@ func (m Model) maxScroll() int {
 // QUAL-002: Using shared utility instead of duplicated code
 var formatDuration = util.FormatDurationCompact
 
+func pluralRuns(n int) string {
+	if n == 1 {
+		return "1 run"
+	}
+	return fmt.Sprintf("%d runs", n)
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -0,0 +1,335 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+// Model represents the log viewer state: the complete saved output of one
+// execution, unlike the execution view which only keeps the latest lines
+type Model struct {
+	width    int
+	height   int
+	execID   string
+	storyKey string
+	lines    []string
+	loading  bool
+	errorMsg string
+	scroll   int
+
+	// Search state. Matches are updated as the query is typed.
+	searching  bool
+	query      string
+	searchFrom int // Scroll position when the search started
+	matches    []int
+	match      int
+
+	// Line to show once the output loads, set by Find
+	target string
+}
+
+// New creates a new log viewer model
+func New() Model {
+	return Model{}
+}
+
+// Load clears the view while the output of execution id loads
+func (m *Model) Load(id string) {
+	*m = Model{width: m.width, height: m.height, execID: id, loading: true}
+}
+
+// Find searches the output for query once it has loaded, starting at the
+// first line equal to line
+func (m *Model) Find(query, line string) {
+	m.query = query
+	m.target = line
+}
+
+// ExecutionID returns the execution shown
+func (m Model) ExecutionID() string {
+	return m.execID
+}
+
+// IsSearching returns true while the search prompt takes input
+func (m Model) IsSearching() bool {
+	return m.searching
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.searching {
+			return m.handleSearchInput(msg), nil
+		}
+		return m.handleKeyMsg(msg), nil
+
+	case messages.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.scroll = min(m.scroll, m.maxScroll())
+
+	case messages.LogsLoadedMsg:
+		if msg.ExecutionID != m.execID {
+			return m, nil // Another execution was opened while loading
+		}
+		m.loading = false
+		if msg.Error != nil {
+			m.errorMsg = msg.Error.Error()
+			return m, nil
+		}
+		m.storyKey = msg.StoryKey
+		m.lines = msg.Lines
+		m.errorMsg = ""
+		if m.query != "" {
+			m.findTarget()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) handleKeyMsg(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "up":
+		m.scroll = max(m.scroll-1, 0)
+	case "down":
+		m.scroll = min(m.scroll+1, m.maxScroll())
+	case "pgup":
+		m.scroll = max(m.scroll-m.pageHeight(), 0)
+	case "pgdown":
+		m.scroll = min(m.scroll+m.pageHeight(), m.maxScroll())
+	case "home":
+		m.scroll = 0
+	case "end":
+		m.scroll = m.maxScroll()
+	case "/":
+		m.searching = true
+		m.query = ""
+		m.matches = nil
+		m.searchFrom = m.scroll
+	case "n":
+		m.jump(1)
+	case "N":
+		m.jump(-1)
+	}
+	return m
+}
+
+func (m Model) handleSearchInput(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "enter":
+		m.searching = false
+	case "esc":
+		m.searching = false
+		m.query = ""
+		m.matches = nil
+		m.scroll = m.searchFrom
+	case "backspace":
+		if len(m.query) > 0 {
+			m.query = m.query[:len(m.query)-1]
+			m.search()
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.query += string(msg.Runes)
+			m.search()
+		}
+	}
+	return m
+}
+
+// search finds the lines containing the query, ignoring case, and shows
+// the first match at or below where the search started
+func (m *Model) search() {
+	m.matches = nil
+	m.match = 0
+	if m.query == "" {
+		m.scroll = m.searchFrom
+		return
+	}
+
+	query := strings.ToLower(m.query)
+	for i, line := range m.lines {
+		if strings.Contains(strings.ToLower(line), query) {
+			m.matches = append(m.matches, i)
+		}
+	}
+	for i, line := range m.matches {
+		if line >= m.searchFrom {
+			m.match = i
+			break
+		}
+	}
+	m.showMatch()
+}
+
+// findTarget runs the search set by Find from its target line, scrolling
+// to that line when the query itself does not appear in it
+func (m *Model) findTarget() {
+	for i, line := range m.lines {
+		if line == m.target {
+			m.searchFrom = i
+			break
+		}
+	}
+	m.search()
+	if len(m.matches) == 0 || m.matches[m.match] != m.searchFrom {
+		m.scroll = min(max(m.searchFrom-2, 0), m.maxScroll())
+	}
+}
+
+// jump moves to the next (delta 1) or previous (delta -1) match, wrapping
+// around the ends
+func (m *Model) jump(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.match = (m.match + delta + len(m.matches)) % len(m.matches)
+	m.showMatch()
+}
+
+// showMatch scrolls the current match into view, a few lines from the top
+func (m *Model) showMatch() {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.scroll = min(max(m.matches[m.match]-2, 0), m.maxScroll())
+}
+
+// pageHeight returns the number of log lines on screen
+func (m Model) pageHeight() int {
+	return max(m.height-4, 1) // Title, blank line, blank line, footer
+}
+
+func (m Model) maxScroll() int {
+	return max(len(m.lines)-m.pageHeight(), 0)
+}
+
+// View renders the log viewer
+func (m Model) View() string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	title := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render("Logs")
+	if m.storyKey != "" {
+		title += muted.Render(fmt.Sprintf("  %s · %s · %d lines", m.storyKey, shortID(m.execID), len(m.lines)))
+	}
+
+	var body string
+	switch {
+	case m.execID == "":
+		body = muted.Render("Open an execution's logs with L in the history or execution view")
+	case m.loading:
+		body = muted.Render("Loading output...")
+	case m.errorMsg != "":
+		body = lipgloss.NewStyle().Foreground(t.Error).Render("Error: " + m.errorMsg)
+	case len(m.lines) == 0:
+		body = muted.Render("No output was saved for this execution")
+	default:
+		body = m.renderLines()
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", body, "", m.renderFooter())
+}
+
+// renderLines renders the visible lines with line numbers, highlighting
+// search matches
+func (m Model) renderLines() string {
+	t := theme.Current
+	gutter := lipgloss.NewStyle().Foreground(t.Subtle)
+	text := lipgloss.NewStyle().Foreground(t.Foreground)
+	header := lipgloss.NewStyle().Foreground(t.Secondary).Bold(true)
+	hit := lipgloss.NewStyle().Foreground(t.Background).Background(t.Highlight)
+	current := -1
+	if len(m.matches) > 0 {
+		current = m.matches[m.match]
+	}
+
+	digits := len(fmt.Sprint(len(m.lines)))
+	width := max(m.width-digits-2, 10)
+	end := min(m.scroll+m.pageHeight(), len(m.lines))
+
+	rows := make([]string, 0, end-m.scroll)
+	for i := m.scroll; i < end; i++ {
+		line := m.lines[i]
+		if len(line) > width {
+			line = line[:width-3] + "..."
+		}
+
+		style := text
+		if strings.HasPrefix(line, "==> ") {
+			style = header
+		}
+		marker := " "
+		if i == current {
+			marker = lipgloss.NewStyle().Foreground(t.Highlight).Render("▶")
+		}
+		rows = append(rows, gutter.Render(fmt.Sprintf("%*d", digits, i+1))+marker+highlight(line, m.query, style, hit))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// highlight renders line in style with every occurrence of query in hit
+func highlight(line, query string, style, hit lipgloss.Style) string {
+	lower := strings.ToLower(line)
+	if query == "" || len(lower) != len(line) {
+		return style.Render(line)
+	}
+	query = strings.ToLower(query)
+
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		if i < 0 {
+			break
+		}
+		b.WriteString(style.Render(line[:i]))
+		b.WriteString(hit.Render(line[i : i+len(query)]))
+		line, lower = line[i+len(query):], lower[i+len(query):]
+	}
+	b.WriteString(style.Render(line))
+	return b.String()
+}
+
+// renderFooter renders the search prompt or the key hints
+func (m Model) renderFooter() string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	if m.searching {
+		status := ""
+		if m.query != "" {
+			status = muted.Render(fmt.Sprintf("  %d matches", len(m.matches)))
+		}
+		return lipgloss.NewStyle().Foreground(t.Primary).Render("/"+m.query+"█") + status
+	}
+
+	hints := "Up/Down/PgUp/PgDn scroll | / search | Esc back"
+	if m.query != "" {
+		if len(m.matches) == 0 {
+			return lipgloss.NewStyle().Foreground(t.Warning).Render(fmt.Sprintf("No matches for %q", m.query)) + muted.Render(" | "+hints)
+		}
+		return muted.Render(fmt.Sprintf("%q %d/%d | n/N next/previous | %s", m.query, m.match+1, len(m.matches), hints))
+	}
+	return muted.Render(hints)
+}
+
+// shortID returns the first characters of an execution ID
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,160 @@
+package pipelines
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+	"github.com/robertguss/bmad-automate-go/internal/util"
+)
+
+// Model represents the pipeline runs view state
+type Model struct {
+	width    int
+	height   int
+	runs     []*domain.PipelineRun
+	loading  bool
+	errorMsg string
+	scroll   int
+}
+
+// New creates a new pipeline runs view model
+func New() Model {
+	return Model{loading: true}
+}
+
+// SetLoading shows the loading message until runs arrive
+func (m *Model) SetLoading(loading bool) {
+	m.loading = loading
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up":
+			if m.scroll > 0 {
+				m.scroll--
+			}
+		case "down":
+			m.scroll++
+		case "home":
+			m.scroll = 0
+		case "r":
+			m.loading = true
+			return m, func() tea.Msg { return messages.PipelinesRefreshMsg{} }
+		}
+
+	case messages.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case messages.PipelineRunsLoadedMsg:
+		m.loading = false
+		m.errorMsg = ""
+		if msg.Error != nil {
+			m.errorMsg = msg.Error.Error()
+		}
+		m.runs = msg.Runs
+	}
+
+	return m, nil
+}
+
+// View renders the pipeline runs view
+func (m Model) View() string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		Padding(0, 0, 1, 0).
+		Render("Pipeline Runs")
+
+	var body string
+	switch {
+	case m.loading:
+		body = muted.Render("Loading pipeline runs...")
+	case m.errorMsg != "":
+		body = lipgloss.NewStyle().Foreground(t.Error).Render("Error: " + m.errorMsg)
+	case len(m.runs) == 0:
+		body = muted.Render("No pipeline runs yet. Define pipelines in .bmad/pipelines and run one with: bmad pipeline run <name>")
+	default:
+		var rows []string
+		for _, run := range m.runs {
+			rows = append(rows, m.renderRun(run)...)
+		}
+		body = strings.Join(rows, "\n")
+	}
+
+	footer := muted.Render("Up/Down scroll | r refresh")
+	lines := strings.Split(lipgloss.JoinVertical(lipgloss.Left, title, body, "", footer), "\n")
+	if m.scroll > 0 && m.scroll < len(lines) {
+		lines = lines[m.scroll:]
+	}
+	if m.height > 2 && len(lines) > m.height-2 {
+		lines = lines[:m.height-2]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderRun renders a run's summary line followed by one line per stage
+func (m Model) renderRun(run *domain.PipelineRun) []string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	summary := fmt.Sprintf("%s %s  %s  %s",
+		statusGlyph(run.Status),
+		lipgloss.NewStyle().Foreground(t.Foreground).Bold(true).Render(run.Pipeline),
+		muted.Render(run.StartTime.Local().Format("2006-01-02 15:04")),
+		muted.Render(string(run.Status)),
+	)
+	if run.IsFinished() && run.Duration > 0 {
+		summary += muted.Render("  " + util.FormatDurationExtended(run.Duration))
+	}
+	lines := []string{summary}
+
+	for _, stage := range run.Stages {
+		line := fmt.Sprintf("    %s %-20s", statusGlyph(stage.Status), stage.Name)
+		if stage.Workflow != "" {
+			line += muted.Render(" workflow " + stage.Workflow)
+		}
+		if stage.Succeeded+stage.Failed > 0 {
+			line += muted.Render(fmt.Sprintf("  %d ok, %d failed", stage.Succeeded, stage.Failed))
+		}
+		lines = append(lines, line)
+	}
+	if run.Error != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(t.Error).Render("    "+run.Error))
+	}
+	return append(lines, "")
+}
+
+// statusGlyph renders a status glyph in the status color
+func statusGlyph(status domain.ExecutionStatus) string {
+	t := theme.Current
+	color := t.Subtle
+	switch status {
+	case domain.ExecutionCompleted:
+		color = t.Success
+	case domain.ExecutionFailed:
+		color = t.Error
+	case domain.ExecutionCancelled:
+		color = t.Warning
+	case domain.ExecutionRunning:
+		color = t.Warning
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(theme.ExecutionGlyph(status))
+}
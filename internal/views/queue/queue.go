@@ -20,8 +20,26 @@ type Model struct {
 	queue  *domain.Queue
 	cursor int
 	styles theme.Styles
+
+	// Pending items brought back from the last session, shown as a resume
+	// prompt until the queue starts
+	restored int
+
+	// Prompt state; promptKey is empty for the whole queue
+	prompt    prompt
+	promptKey string
+	input     string
 }
 
+// prompt is what the input line at the bottom of the view asks for
+type prompt int
+
+const (
+	promptNone prompt = iota
+	promptDeadline
+	promptTags
+)
+
 // New creates a new queue manager model
 func New() Model {
 	return Model{
@@ -36,10 +54,18 @@ func (m Model) Init() tea.Cmd {
 	return nil
 }
 
+// IsPrompting returns true while the deadline or tags prompt takes input
+func (m Model) IsPrompting() bool {
+	return m.prompt != promptNone
+}
+
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.prompt != promptNone {
+			return m.handlePromptInput(msg)
+		}
 		switch msg.String() {
 		case "up":
 			if m.cursor > 0 {
@@ -70,6 +96,26 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case "C": // Shift+C to clear pending
 			m.queue.Clear()
 			m.cursor = 0
+		case "D": // Deadline for the selected story
+			if item := m.GetCurrentItem(); item != nil {
+				m.prompt = promptDeadline
+				m.promptKey = item.Story.Key
+				m.input = ""
+			}
+		case "B": // Deadline for the whole queue
+			m.prompt = promptDeadline
+			m.promptKey = ""
+			m.input = ""
+		case "T": // Tags for the selected story's execution
+			if item := m.GetCurrentItem(); item != nil && item.Status == domain.ExecutionPending {
+				m.prompt = promptTags
+				m.promptKey = item.Story.Key
+				m.input = strings.Join(item.Tags, " ")
+			}
+		case "O": // Earliest deadline first
+			if m.queue.OrderByDeadline() {
+				m.cursor = 0
+			}
 		}
 
 	case messages.QueueAddMsg:
@@ -96,6 +142,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 
 	case messages.QueueItemStartedMsg:
+		m.restored = 0
 		m.queue.Current = msg.Index
 		m.queue.Status = domain.QueueRunning
 		if msg.Index < len(m.queue.Items) {
@@ -125,6 +172,32 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) handlePromptInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prompt = promptNone
+	case "enter":
+		kind, key, spec := m.prompt, m.promptKey, strings.TrimSpace(m.input)
+		m.prompt = promptNone
+		if kind == promptTags {
+			return m, func() tea.Msg { return messages.QueueTagsMsg{Key: key, Tags: domain.ParseTags(spec)} }
+		}
+		if spec != "" {
+			return m, func() tea.Msg { return messages.QueueDeadlineMsg{Key: key, Spec: spec} }
+		}
+	case "backspace":
+		if len(m.input) > 0 {
+			runes := []rune(m.input)
+			m.input = string(runes[:len(runes)-1])
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.input += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
 // SetSize sets the view dimensions
 func (m *Model) SetSize(width, height int) {
 	m.width = width
@@ -136,6 +209,11 @@ func (m *Model) RefreshStyles() {
 	m.styles = theme.NewStyles()
 }
 
+// SetRestored shows a prompt to resume n pending items from the last session
+func (m *Model) SetRestored(n int) {
+	m.restored = n
+}
+
 // GetQueue returns the current queue
 func (m Model) GetQueue() *domain.Queue {
 	return m.queue
@@ -190,6 +268,9 @@ func (m Model) View() string {
 	// Combine all sections
 	var sections []string
 	sections = append(sections, header)
+	if prompt := m.renderRestorePrompt(); prompt != "" {
+		sections = append(sections, "", prompt)
+	}
 	if progressBar != "" {
 		sections = append(sections, progressBar)
 	}
@@ -253,10 +334,13 @@ func (m Model) renderHeader() string {
 	// ETA (if running)
 	var eta string
 	if m.queue.Status == domain.QueueRunning && m.queue.HasPending() {
-		remaining := m.queue.EstimatedTimeRemaining()
+		text := fmt.Sprintf("ETA: %s", formatDuration(m.queue.EstimatedTimeRemaining()))
+		if spread := m.queue.EstimatedTimeSpread(); spread >= time.Second {
+			text += fmt.Sprintf(" (±%s)", formatDuration(spread))
+		}
 		eta = lipgloss.NewStyle().
 			Foreground(t.Info).
-			Render(fmt.Sprintf("ETA: %s", formatDuration(remaining)))
+			Render(text)
 	}
 
 	headerLine := fmt.Sprintf("%s  %s", title, statusBadge)
@@ -264,7 +348,169 @@ func (m Model) renderHeader() string {
 		headerLine = fmt.Sprintf("%s  %s", headerLine, eta)
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, headerLine, counts)
+	lines := []string{headerLine, counts}
+	if cycle := m.pendingCycle(); cycle != nil {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(t.Error).
+			Render("Dependency cycle: "+strings.Join(cycle, " -> ")))
+	}
+	if deadlines := m.renderDeadlineSummary(); deadlines != "" {
+		lines = append(lines, deadlines)
+	}
+	if durations := m.renderDurations(); durations != "" {
+		lines = append(lines, durations)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// pendingCycle returns a dependency cycle among the pending items, which
+// will fail once the rest of the queue has run
+func (m Model) pendingCycle() []string {
+	var pending []domain.Story
+	for _, item := range m.queue.GetPending() {
+		pending = append(pending, item.Story)
+	}
+	return domain.DependencyCycle(pending)
+}
+
+// renderDurations renders a sparkline of finished item durations with the
+// min/avg/max so far, or "" until an item has finished
+func (m Model) renderDurations() string {
+	t := theme.Current
+
+	durations := m.queue.FinishedDurations()
+	if len(durations) == 0 {
+		return ""
+	}
+
+	minD, maxD, total := durations[0], durations[0], time.Duration(0)
+	for _, d := range durations {
+		minD = min(minD, d)
+		maxD = max(maxD, d)
+		total += d
+	}
+	avg := total / time.Duration(len(durations))
+
+	// Keep the most recent items when the line is too narrow for all
+	recent := durations
+	if maxPoints := m.width - 60; maxPoints > 0 && len(recent) > maxPoints {
+		recent = recent[len(recent)-maxPoints:]
+	}
+	values := make([]int, len(recent))
+	for i, d := range recent {
+		values[i] = int(d.Milliseconds())
+	}
+
+	spark := lipgloss.NewStyle().Foreground(t.Accent).Render(util.Sparkline(values))
+	stats := lipgloss.NewStyle().
+		Foreground(t.Subtle).
+		Render(fmt.Sprintf("min %s | avg %s | max %s",
+			formatDuration(minD), formatDuration(avg), formatDuration(maxD)))
+
+	return fmt.Sprintf("Durations %s  %s", spark, stats)
+}
+
+// estimatesByKey projects the unfinished items against their deadlines
+func (m Model) estimatesByKey() map[string]domain.ItemEstimate {
+	estimates := make(map[string]domain.ItemEstimate)
+	for _, est := range m.queue.EstimateItems(nil) {
+		estimates[est.Story.Key] = est
+	}
+	return estimates
+}
+
+// renderDeadlineSummary counts the items in danger of missing their
+// deadline, or returns "" when none is set
+func (m Model) renderDeadlineSummary() string {
+	if !m.queue.HasDeadlines() {
+		return ""
+	}
+	t := theme.Current
+	now := time.Now()
+
+	counts := make(map[domain.DeadlineRisk]int)
+	for _, est := range m.estimatesByKey() {
+		counts[est.Risk(now)]++
+	}
+	missed := len(m.queue.Breaches(now))
+
+	parts := []string{"Deadlines"}
+	if !m.queue.Deadline.IsZero() {
+		parts[0] = "Queue deadline " + formatDeadline(m.queue.Deadline)
+	}
+	style := lipgloss.NewStyle().Foreground(t.Success)
+	if n := counts[domain.DeadlineAtRisk]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d at risk", n))
+		style = lipgloss.NewStyle().Foreground(t.Warning)
+	}
+	if n := counts[domain.DeadlineLate]; n > 0 {
+		parts = append(parts, fmt.Sprintf("%d late", n))
+		style = lipgloss.NewStyle().Foreground(t.Error)
+	}
+	if missed > 0 {
+		parts = append(parts, fmt.Sprintf("%d missed", missed))
+		style = lipgloss.NewStyle().Foreground(t.Error).Bold(true)
+	}
+	if len(parts) == 1 {
+		parts = append(parts, "on track")
+	}
+	return style.Render(strings.Join(parts, " | "))
+}
+
+// deadlineLabel shows an item's deadline, colored by whether it is
+// expected to make it, or "" when it has none
+func (m Model) deadlineLabel(item *domain.QueueItem, estimates map[string]domain.ItemEstimate, now time.Time) string {
+	deadline := m.queue.DeadlineOf(item)
+	if deadline.IsZero() {
+		return ""
+	}
+	t := theme.Current
+
+	text := " by " + formatDeadline(deadline)
+	color := t.Subtle
+	if est, ok := estimates[item.Story.Key]; ok {
+		switch est.Risk(now) {
+		case domain.DeadlineOnTrack:
+			color = t.Success
+		case domain.DeadlineAtRisk:
+			text += " (at risk)"
+			color = t.Warning
+		case domain.DeadlineLate:
+			text += " (late)"
+			color = t.Error
+		case domain.DeadlineBreached:
+			text += " (missed)"
+			color = t.Error
+		}
+	} else if item.Execution != nil && item.Execution.IsFinished() {
+		if item.Status == domain.ExecutionCompleted && !item.Execution.EndTime.After(deadline) {
+			text += " (met)"
+			color = t.Success
+		} else if item.Status != domain.ExecutionCancelled {
+			text += " (missed)"
+			color = t.Error
+		}
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(text)
+}
+
+// renderRestorePrompt offers to resume the queue restored from the last
+// session while it is idle with stories pending
+func (m Model) renderRestorePrompt() string {
+	if m.restored == 0 || m.queue.Status != domain.QueueIdle || !m.queue.HasPending() {
+		return ""
+	}
+	t := theme.Current
+
+	stories := "stories"
+	if m.restored == 1 {
+		stories = "story"
+	}
+	return lipgloss.NewStyle().
+		Foreground(t.Info).
+		Bold(true).
+		Render(fmt.Sprintf("Restored %d pending %s from the last session - Enter to resume, C to clear",
+			m.restored, stories))
 }
 
 // renderProgressBar renders the overall progress bar
@@ -306,16 +552,18 @@ func (m Model) renderQueueList() string {
 		startIdx = m.cursor - visibleHeight + 1
 	}
 
+	estimates := m.estimatesByKey()
+	now := time.Now()
 	for i := startIdx; i < len(m.queue.Items) && i < startIdx+visibleHeight; i++ {
 		item := m.queue.Items[i]
-		rows = append(rows, m.renderQueueItem(item, i, i == m.cursor))
+		rows = append(rows, m.renderQueueItem(item, i, i == m.cursor, m.deadlineLabel(item, estimates, now)))
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
 }
 
 // renderQueueItem renders a single queue item
-func (m Model) renderQueueItem(item *domain.QueueItem, index int, isCursor bool) string {
+func (m Model) renderQueueItem(item *domain.QueueItem, index int, isCursor bool, deadline string) string {
 	t := theme.Current
 
 	// Position number
@@ -330,23 +578,26 @@ func (m Model) renderQueueItem(item *domain.QueueItem, index int, isCursor bool)
 
 	switch item.Status {
 	case domain.ExecutionPending:
-		indicator = lipgloss.NewStyle().Foreground(t.Subtle).Render("  ")
+		indicator = lipgloss.NewStyle().Foreground(t.Subtle).Render(theme.GlyphPending + " ")
 		keyStyle = lipgloss.NewStyle().Foreground(t.Foreground)
 	case domain.ExecutionRunning:
-		indicator = lipgloss.NewStyle().Foreground(t.Warning).Bold(true).Render("> ")
+		indicator = lipgloss.NewStyle().Foreground(t.Warning).Bold(true).Render(theme.GlyphRunning + " ")
 		keyStyle = lipgloss.NewStyle().Foreground(t.Warning).Bold(true)
 	case domain.ExecutionCompleted:
-		indicator = lipgloss.NewStyle().Foreground(t.Success).Render("OK")
+		indicator = lipgloss.NewStyle().Foreground(t.Success).Render(theme.GlyphSuccess + " ")
 		keyStyle = lipgloss.NewStyle().Foreground(t.Success)
 	case domain.ExecutionFailed:
-		indicator = lipgloss.NewStyle().Foreground(t.Error).Render("XX")
+		indicator = lipgloss.NewStyle().Foreground(t.Error).Render(theme.GlyphFailed + " ")
 		keyStyle = lipgloss.NewStyle().Foreground(t.Error)
 	case domain.ExecutionCancelled:
-		indicator = lipgloss.NewStyle().Foreground(t.Warning).Render("--")
+		indicator = lipgloss.NewStyle().Foreground(t.Warning).Render(theme.GlyphCancelled + " ")
 		keyStyle = lipgloss.NewStyle().Foreground(t.Subtle).Italic(true)
 	case domain.ExecutionPaused:
-		indicator = lipgloss.NewStyle().Foreground(t.Info).Render("||")
+		indicator = lipgloss.NewStyle().Foreground(t.Info).Render(theme.GlyphPaused + " ")
 		keyStyle = lipgloss.NewStyle().Foreground(t.Info)
+	case domain.ExecutionConflict:
+		indicator = lipgloss.NewStyle().Foreground(t.Warning).Render(theme.GlyphConflict + " ")
+		keyStyle = lipgloss.NewStyle().Foreground(t.Warning)
 	}
 
 	// Story key
@@ -390,6 +641,22 @@ func (m Model) renderQueueItem(item *domain.QueueItem, index int, isCursor bool)
 			Render(" [file]")
 	}
 
+	var tags string
+	if len(item.Tags) > 0 {
+		tags = lipgloss.NewStyle().
+			Foreground(t.Accent).
+			Render(" #" + strings.Join(item.Tags, " #"))
+	}
+
+	var after string
+	if item.Status == domain.ExecutionPending {
+		if blockers := m.queue.Blockers(item); len(blockers) > 0 {
+			after = lipgloss.NewStyle().
+				Foreground(t.Subtle).
+				Render(" after " + strings.Join(blockers, ", "))
+		}
+	}
+
 	// Cursor indicator
 	cursor := "  "
 	if isCursor {
@@ -399,7 +666,7 @@ func (m Model) renderQueueItem(item *domain.QueueItem, index int, isCursor bool)
 			Render("> ")
 	}
 
-	row := fmt.Sprintf("%s%s%s %s %s%s%s%s", cursor, position, indicator, key, badge, fileIndicator, progress, duration)
+	row := fmt.Sprintf("%s%s%s %s %s%s%s%s%s%s%s", cursor, position, indicator, key, badge, fileIndicator, tags, after, progress, duration, deadline)
 
 	// Highlight entire row if cursor
 	if isCursor {
@@ -416,6 +683,19 @@ func (m Model) renderQueueItem(item *domain.QueueItem, index int, isCursor bool)
 func (m Model) renderHelp() string {
 	t := theme.Current
 
+	switch m.prompt {
+	case promptDeadline:
+		label := "Queue deadline: "
+		if m.promptKey != "" {
+			label = "Deadline for " + m.promptKey + ": "
+		}
+		return lipgloss.NewStyle().Foreground(t.Primary).Render(label+m.input+"█") +
+			lipgloss.NewStyle().Foreground(t.Subtle).Render("  HH:MM, YYYY-MM-DD HH:MM, 2h or none | Enter save | Esc cancel")
+	case promptTags:
+		return lipgloss.NewStyle().Foreground(t.Primary).Render("Tags for "+m.promptKey+": "+m.input+"█") +
+			lipgloss.NewStyle().Foreground(t.Subtle).Render("  e.g. hotfix sprint-12, empty to clear | Enter save | Esc cancel")
+	}
+
 	var controls []string
 
 	if m.queue.Status == domain.QueueIdle {
@@ -439,6 +719,12 @@ func (m Model) renderHelp() string {
 		)
 	}
 
+	if m.queue.Status != domain.QueueCompleted {
+		controls = append(controls, renderControl("D/B", "Deadline Story/Queue"), renderControl("T", "Tags"))
+		if m.queue.HasDeadlines() {
+			controls = append(controls, renderControl("O", "Deadline Order"))
+		}
+	}
 	controls = append(controls, renderControl("Up/Down", "Navigate"))
 
 	return lipgloss.NewStyle().
@@ -454,6 +740,15 @@ func renderControl(key, action string) string {
 	return fmt.Sprintf("[%s] %s", keyStyle.Render(key), actionStyle.Render(action))
 }
 
+// formatDeadline shows the time of day, with the day when it is not today
+func formatDeadline(deadline time.Time) string {
+	now := time.Now()
+	if deadline.Year() == now.Year() && deadline.YearDay() == now.YearDay() {
+		return deadline.Format("15:04")
+	}
+	return deadline.Format("Mon Jan 2 15:04")
+}
+
 // formatDuration uses the shared extended duration formatter
 // QUAL-002: Using shared utility instead of duplicated code
 var formatDuration = util.FormatDurationExtended
@@ -0,0 +1,231 @@
+package schedules
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+// Model represents the schedules view state
+type Model struct {
+	width     int
+	height    int
+	path      string
+	schedules []messages.ScheduleEntry
+	loading   bool
+	errorMsg  string
+	cursor    int
+
+	// Name of the schedule waiting for a second D to delete it
+	deleteArmed string
+
+	// Add prompt state
+	adding bool
+	input  string
+}
+
+// New creates a new schedules view model
+func New(path string) Model {
+	return Model{path: path, loading: true}
+}
+
+// IsPrompting returns true while the add prompt takes input
+func (m Model) IsPrompting() bool {
+	return m.adding
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.adding {
+			return m.handlePromptInput(msg)
+		}
+		return m.handleKeyMsg(msg)
+
+	case messages.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case messages.SchedulesLoadedMsg:
+		m.loading = false
+		m.errorMsg = ""
+		if msg.Error != nil {
+			m.errorMsg = msg.Error.Error()
+		}
+		m.schedules = msg.Schedules
+		m.cursor = min(m.cursor, max(len(m.schedules)-1, 0))
+	}
+
+	return m, nil
+}
+
+func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
+	key := msg.String()
+	if key != "D" {
+		m.deleteArmed = ""
+	}
+
+	switch key {
+	case "up":
+		m.cursor = max(m.cursor-1, 0)
+	case "down":
+		m.cursor = min(m.cursor+1, max(len(m.schedules)-1, 0))
+	case "n":
+		m.adding = true
+		m.input = ""
+	case "r":
+		m.loading = true
+		return m, func() tea.Msg { return messages.SchedulesRefreshMsg{} }
+	}
+
+	selected, ok := m.selected()
+	if !ok {
+		return m, nil
+	}
+	switch key {
+	case "enter":
+		return m, func() tea.Msg { return messages.ScheduleRunMsg{Name: selected.Name} }
+	case " ":
+		return m, func() tea.Msg {
+			return messages.ScheduleToggleMsg{Name: selected.Name, Enabled: !selected.Enabled}
+		}
+	case "D":
+		if m.deleteArmed != selected.Name {
+			m.deleteArmed = selected.Name
+			return m, nil
+		}
+		m.deleteArmed = ""
+		return m, func() tea.Msg { return messages.ScheduleDeleteMsg{Name: selected.Name} }
+	}
+	return m, nil
+}
+
+func (m Model) handlePromptInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.adding = false
+	case "enter":
+		m.adding = false
+		if spec := strings.TrimSpace(m.input); spec != "" {
+			return m, func() tea.Msg { return messages.ScheduleAddMsg{Spec: spec} }
+		}
+	case "backspace":
+		if len(m.input) > 0 {
+			runes := []rune(m.input)
+			m.input = string(runes[:len(runes)-1])
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.input += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+func (m Model) selected() (messages.ScheduleEntry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.schedules) {
+		return messages.ScheduleEntry{}, false
+	}
+	return m.schedules[m.cursor], true
+}
+
+// View renders the schedules view
+func (m Model) View() string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		Padding(0, 0, 1, 0).
+		Render("Schedules")
+
+	var body string
+	switch {
+	case m.loading:
+		body = muted.Render("Loading schedules...")
+	case m.errorMsg != "":
+		body = lipgloss.NewStyle().Foreground(t.Error).Render("Error: " + m.errorMsg)
+	case len(m.schedules) == 0:
+		body = muted.Render("No schedules yet. Press n to add one, e.g.: nightly @nightly ready-for-dev\n" +
+			"Schedules are kept in " + m.path)
+	default:
+		var rows []string
+		for i, s := range m.schedules {
+			rows = append(rows, m.renderSchedule(s, i == m.cursor)...)
+		}
+		body = strings.Join(rows, "\n")
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, body, "", m.renderFooter())
+}
+
+// renderSchedule renders a schedule's summary line and its run times
+func (m Model) renderSchedule(s messages.ScheduleEntry, selected bool) []string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	cursor := "  "
+	if selected {
+		cursor = lipgloss.NewStyle().Foreground(t.Primary).Render("> ")
+	}
+	state := lipgloss.NewStyle().Foreground(t.Success).Render(theme.GlyphSuccess + " on ")
+	if !s.Enabled {
+		state = muted.Render(theme.GlyphPaused + " off")
+	}
+	name := lipgloss.NewStyle().Foreground(t.Foreground).Bold(true).Render(fmt.Sprintf("%-16s", s.Name))
+	summary := fmt.Sprintf("%s%s  %s  %s  %s", cursor, state, name,
+		lipgloss.NewStyle().Foreground(t.Secondary).Render(fmt.Sprintf("%-16s", s.Cron)),
+		s.Select)
+	summary += muted.Render(fmt.Sprintf("  (%d stories)", s.Matching))
+
+	next := "never"
+	if !s.NextRun.IsZero() {
+		next = formatRunTime(s.NextRun)
+	}
+	detail := "next " + next
+	if !s.LastRun.IsZero() {
+		detail += " | last " + formatRunTime(s.LastRun)
+		if s.LastResult != "" {
+			detail += ": " + s.LastResult
+		}
+	}
+	return []string{summary, muted.Render("         " + detail)}
+}
+
+// formatRunTime shows the time of day for today and the date otherwise
+func formatRunTime(t time.Time) string {
+	t = t.Local()
+	now := time.Now()
+	if t.Year() == now.Year() && t.YearDay() == now.YearDay() {
+		return t.Format("15:04")
+	}
+	return t.Format("Mon Jan 2 15:04")
+}
+
+// renderFooter renders the add prompt or the key hints
+func (m Model) renderFooter() string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	if m.adding {
+		return lipgloss.NewStyle().Foreground(t.Primary).Render("New schedule: "+m.input+"█") +
+			muted.Render("  <name> <cron or @nightly> <story query> | Enter save | Esc cancel")
+	}
+	if m.deleteArmed != "" {
+		return lipgloss.NewStyle().Foreground(t.Warning).Render(fmt.Sprintf("Press D again to delete %s", m.deleteArmed))
+	}
+	return muted.Render("Up/Down navigate | Enter run now | Space enable/disable | n new | D delete | r reload")
+}
@@ -0,0 +1,294 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+	"github.com/robertguss/bmad-automate-go/internal/util"
+)
+
+// debounce is how long typing has to pause before a search runs
+const debounce = 200 * time.Millisecond
+
+// tickMsg runs the search typed before it, unless the query changed since
+type tickMsg struct {
+	gen int
+}
+
+// kind is the group a result belongs to
+type kind int
+
+const (
+	kindStory kind = iota
+	kindExecution
+	kindOutput
+)
+
+// item is one selectable result, an index into its group
+type item struct {
+	kind  kind
+	index int
+}
+
+// Model represents the search view: stories, past executions and saved
+// output matching one query, grouped by what they are
+type Model struct {
+	width  int
+	height int
+
+	query    string
+	gen      int    // Bumped by every edit of the query
+	searched string // Query of the results shown
+	loading  bool
+	errorMsg string
+
+	stories    []domain.Story
+	executions []*messages.HistoryExecution
+	output     []*messages.OutputHit
+	items      []item
+	cursor     int
+}
+
+// New creates a new search view model
+func New() Model {
+	return Model{}
+}
+
+// Query returns the text typed
+func (m Model) Query() string {
+	return m.query
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		return m.handleKeyMsg(msg)
+
+	case messages.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+	case tickMsg:
+		if msg.gen == m.gen && m.query != "" {
+			query := m.query
+			return m, func() tea.Msg { return messages.SearchRequestMsg{Query: query} }
+		}
+
+	case messages.SearchResultsMsg:
+		if msg.Query != m.query {
+			return m, nil // Typing went on while searching
+		}
+		m.setResults(msg)
+	}
+
+	return m, nil
+}
+
+func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "up":
+		m.cursor = max(m.cursor-1, 0)
+	case "down":
+		m.cursor = min(m.cursor+1, max(len(m.items)-1, 0))
+	case "pgup":
+		m.cursor = max(m.cursor-m.pageHeight(), 0)
+	case "pgdown":
+		m.cursor = min(m.cursor+m.pageHeight(), max(len(m.items)-1, 0))
+	case "enter":
+		return m, m.open()
+	case "esc":
+		return m.edit(""), m.schedule()
+	case "backspace":
+		if len(m.query) > 0 {
+			runes := []rune(m.query)
+			return m.edit(string(runes[:len(runes)-1])), m.schedule()
+		}
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			return m.edit(m.query + string(msg.Runes)), m.schedule()
+		}
+	}
+	return m, nil
+}
+
+// edit changes the query, clearing the results when it is emptied
+func (m Model) edit(query string) Model {
+	m.query = query
+	m.gen++
+	if strings.TrimSpace(query) == "" {
+		m.setResults(messages.SearchResultsMsg{})
+		m.loading = false
+		return m
+	}
+	m.loading = true
+	return m
+}
+
+// schedule runs the search once typing pauses
+func (m Model) schedule() tea.Cmd {
+	if strings.TrimSpace(m.query) == "" {
+		return nil
+	}
+	gen := m.gen
+	return tea.Tick(debounce, func(time.Time) tea.Msg { return tickMsg{gen: gen} })
+}
+
+// setResults shows the results of a search, in group order
+func (m *Model) setResults(msg messages.SearchResultsMsg) {
+	m.loading = false
+	m.searched = msg.Query
+	m.errorMsg = ""
+	if msg.Error != nil {
+		m.errorMsg = msg.Error.Error()
+	}
+	m.stories, m.executions, m.output = msg.Stories, msg.Executions, msg.Output
+
+	m.items = nil
+	for i := range m.stories {
+		m.items = append(m.items, item{kindStory, i})
+	}
+	for i := range m.executions {
+		m.items = append(m.items, item{kindExecution, i})
+	}
+	for i := range m.output {
+		m.items = append(m.items, item{kindOutput, i})
+	}
+	m.cursor = 0
+}
+
+// open returns the message that shows the selected result in its own view
+func (m Model) open() tea.Cmd {
+	if m.cursor >= len(m.items) {
+		return nil
+	}
+	it := m.items[m.cursor]
+	var msg tea.Msg
+	switch it.kind {
+	case kindStory:
+		msg = messages.StoryFocusMsg{Key: m.stories[it.index].Key}
+	case kindExecution:
+		msg = messages.HistoryDetailMsg{ID: m.executions[it.index].ID}
+	case kindOutput:
+		hit := m.output[it.index]
+		msg = messages.LogsRequestMsg{ID: hit.ExecutionID, Find: m.searched, Line: hit.Line}
+	}
+	return func() tea.Msg { return msg }
+}
+
+// pageHeight returns the number of result rows on screen
+func (m Model) pageHeight() int {
+	return max(m.height-6, 1) // Title, prompt, blank lines, footer
+}
+
+// View renders the search view
+func (m Model) View() string {
+	t := theme.Current
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	title := lipgloss.NewStyle().Foreground(t.Primary).Bold(true).Render("Search")
+	prompt := lipgloss.NewStyle().Foreground(t.Primary).Render("> " + m.query + "█")
+	if m.loading {
+		prompt += muted.Render("  searching...")
+	} else if m.searched != "" && m.errorMsg == "" {
+		prompt += muted.Render(fmt.Sprintf("  %d results", len(m.items)))
+	}
+
+	var body string
+	switch {
+	case m.errorMsg != "":
+		body = lipgloss.NewStyle().Foreground(t.Error).Render("Error: " + m.errorMsg)
+	case m.query == "":
+		body = muted.Render("Type to search story keys and titles, execution history and saved output")
+	case m.searched != "" && len(m.items) == 0 && !m.loading:
+		body = lipgloss.NewStyle().Foreground(t.Warning).Render(fmt.Sprintf("Nothing matches %q", m.searched))
+	default:
+		body = m.renderResults()
+	}
+
+	footer := muted.Render("Type to search | Up/Down navigate | Enter open | Esc clear, or go back")
+	return lipgloss.JoinVertical(lipgloss.Left, title, prompt, "", body, "", footer)
+}
+
+// renderResults renders the visible part of the grouped results, keeping
+// the cursor on screen
+func (m Model) renderResults() string {
+	t := theme.Current
+	heading := lipgloss.NewStyle().Foreground(t.Secondary).Bold(true)
+
+	var rows []string
+	cursorRow := 0
+	group := kind(-1)
+	for i, it := range m.items {
+		if it.kind != group {
+			group = it.kind
+			rows = append(rows, heading.Render(m.groupTitle(group)))
+		}
+		if i == m.cursor {
+			cursorRow = len(rows)
+		}
+		rows = append(rows, m.renderItem(it, i == m.cursor))
+	}
+
+	page := m.pageHeight()
+	start := max(cursorRow-page+1, 0)
+	end := min(start+page, len(rows))
+	return strings.Join(rows[start:end], "\n")
+}
+
+func (m Model) groupTitle(k kind) string {
+	switch k {
+	case kindStory:
+		return fmt.Sprintf("Stories (%d)", len(m.stories))
+	case kindExecution:
+		return fmt.Sprintf("History (%d)", len(m.executions))
+	default:
+		return fmt.Sprintf("Output (%d)", len(m.output))
+	}
+}
+
+func (m Model) renderItem(it item, selected bool) string {
+	t := theme.Current
+	text := lipgloss.NewStyle().Foreground(t.Foreground)
+	muted := lipgloss.NewStyle().Foreground(t.Subtle)
+
+	var line string
+	switch it.kind {
+	case kindStory:
+		s := m.stories[it.index]
+		line = text.Render(s.Key) + muted.Render("  "+string(s.Status))
+		if s.Title != "" {
+			line += muted.Render("  " + s.Title)
+		}
+	case kindExecution:
+		e := m.executions[it.index]
+		line = text.Render(e.StoryKey) + muted.Render(fmt.Sprintf("  %s  %s  %s",
+			e.Status, e.StartTime.Format("2006-01-02 15:04"), util.FormatDuration(e.Duration)))
+		if e.ErrorMsg != "" {
+			line += lipgloss.NewStyle().Foreground(t.Error).Render("  " + e.ErrorMsg)
+		}
+	case kindOutput:
+		hit := m.output[it.index]
+		line = muted.Render(fmt.Sprintf("%s · %s  ", hit.StoryKey, hit.StepName)) + text.Render(strings.TrimSpace(hit.Line))
+	}
+
+	if width := m.width - 4; width > 10 && lipgloss.Width(line) > width {
+		line = lipgloss.NewStyle().MaxWidth(width).Render(line)
+	}
+	if selected {
+		return lipgloss.NewStyle().Foreground(t.Highlight).Render("▶ ") + line
+	}
+	return "  " + line
+}
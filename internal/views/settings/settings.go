@@ -19,6 +19,7 @@ const (
 	SettingTypeToggle
 	SettingTypeNumber
 	SettingTypeText
+	SettingTypeAction // Runs something on Enter rather than holding a value
 )
 
 // Setting represents a configurable option
@@ -40,6 +41,10 @@ type Model struct {
 	settings []Setting
 	cursor   int
 	styles   theme.Styles
+
+	// Usage metrics payload preview, toggled with "p"
+	usagePreview func() string
+	showPreview  bool
 }
 
 // ThemeChangedMsg is sent when the theme is changed
@@ -47,6 +52,11 @@ type ThemeChangedMsg struct {
 	Theme string
 }
 
+// ActionMsg is sent when an action row is run
+type ActionMsg struct {
+	Name string
+}
+
 // SettingChangedMsg is sent when any setting changes
 type SettingChangedMsg struct {
 	Name  string
@@ -88,18 +98,130 @@ func (m *Model) buildSettings() {
 			Min:         0,
 			Max:         5,
 		},
+		{
+			Name:        "Stall Timeout",
+			Description: "Seconds without output before a step is flagged as stalled (0 = off)",
+			Type:        SettingTypeNumber,
+			Value:       m.config.StallTimeout,
+			Min:         0,
+			Max:         3600,
+		},
+		{
+			Name:        "Stall Auto-Retry",
+			Description: "Kill and retry a stalled step automatically",
+			Type:        SettingTypeToggle,
+			Value:       m.config.StallAutoRetry,
+		},
+		{
+			Name:        "Adaptive Retries",
+			Description: "Tell the agent why the last attempt failed when retrying a step",
+			Type:        SettingTypeToggle,
+			Value:       m.config.AdaptiveRetry,
+		},
+		{
+			Name:        "Merge Conflicts",
+			Description: "Resolve conflicts with an agent step, or park the story",
+			Type:        SettingTypeSelect,
+			Options:     []string{config.ConflictResolve, config.ConflictPark},
+			Value:       m.config.ConflictStrategy,
+		},
+		{
+			Name:        "Queue Order",
+			Description: "Run the queue in order, let epics take turns, or run earliest deadlines first",
+			Type:        SettingTypeSelect,
+			Options:     []string{config.QueueOrderFIFO, config.QueueOrderRoundRobin, config.QueueOrderDeadline},
+			Value:       m.config.QueueOrder,
+		},
+		{
+			Name:        "Branch per Story",
+			Description: "Run each story on its own " + m.config.StoryBranchPrefix + "<key> branch",
+			Type:        SettingTypeToggle,
+			Value:       m.config.StoryBranches,
+		},
+		{
+			Name:        "Auto-Stash",
+			Description: "Stash uncommitted changes before a story runs and restore them afterwards",
+			Type:        SettingTypeToggle,
+			Value:       m.config.AutoStash,
+		},
+		{
+			Name:        "Preview Steps",
+			Description: "Show each step's command and prompt, for editing, before a story runs",
+			Type:        SettingTypeToggle,
+			Value:       m.config.PreviewSteps,
+		},
 		{
 			Name:        "Notifications",
 			Description: "Enable desktop notifications when tasks complete",
 			Type:        SettingTypeToggle,
 			Value:       m.config.NotificationsEnabled,
 		},
+		{
+			Name:        "Slow Step Alerts",
+			Description: "Notify when a step runs 50% longer than its average",
+			Type:        SettingTypeToggle,
+			Value:       m.config.SlowStepAlerts,
+		},
+		{
+			Name:        "Step Failure Alerts",
+			Description: "Notify as soon as a step fails, without waiting for the queue",
+			Type:        SettingTypeToggle,
+			Value:       m.config.StepFailureAlerts,
+		},
+		{
+			Name:        "Story Complete Alerts",
+			Description: "Notify when a story run on its own finishes",
+			Type:        SettingTypeToggle,
+			Value:       m.config.StoryCompleteAlerts,
+		},
+		{
+			Name:        "Stall Alerts",
+			Description: "Notify when a step is flagged as stalled",
+			Type:        SettingTypeToggle,
+			Value:       m.config.StallAlerts,
+		},
 		{
 			Name:        "Sound",
 			Description: "Enable sound feedback for events",
 			Type:        SettingTypeToggle,
 			Value:       m.config.SoundEnabled,
 		},
+		{
+			Name:        "Complete Sound",
+			Description: "Play a sound when a story or queue completes",
+			Type:        SettingTypeToggle,
+			Value:       !m.config.SoundMuted["complete"],
+		},
+		{
+			Name:        "Warning Sound",
+			Description: "Play a sound when a queue finishes with failures",
+			Type:        SettingTypeToggle,
+			Value:       !m.config.SoundMuted["warning"],
+		},
+		{
+			Name:        "Failure Sound",
+			Description: "Play a sound when a story run on its own fails",
+			Type:        SettingTypeToggle,
+			Value:       !m.config.SoundMuted["failure"],
+		},
+		{
+			Name:        "Reduced Motion",
+			Description: "Skip the confetti and redraw running timers less often",
+			Type:        SettingTypeToggle,
+			Value:       m.config.ReducedMotion,
+		},
+		{
+			Name:        "Usage Metrics",
+			Description: "Send anonymous usage counts to help prioritize development (p: preview)",
+			Type:        SettingTypeToggle,
+			Value:       m.config.TelemetryEnabled,
+		},
+		{
+			Name:        "Database",
+			Description: "Check integrity, vacuum and analyze the history database",
+			Type:        SettingTypeAction,
+			Value:       "Run maintenance",
+		},
 	}
 }
 
@@ -136,6 +258,10 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		return m.adjustValue(1)
 	case "enter", " ":
 		return m.toggleOrCycle()
+	case "p":
+		if m.settings[m.cursor].Name == "Usage Metrics" {
+			m.showPreview = !m.showPreview
+		}
 	}
 	return m, nil
 }
@@ -197,6 +323,9 @@ func (m Model) toggleOrCycle() (Model, tea.Cmd) {
 		newIdx := (current + 1) % len(options)
 		setting.Value = options[newIdx]
 		cmd = m.applySettingChange(setting)
+	case SettingTypeAction:
+		name := setting.Name
+		cmd = func() tea.Msg { return ActionMsg{Name: name} }
 	}
 
 	return m, cmd
@@ -216,10 +345,44 @@ func (m *Model) applySettingChange(setting *Setting) tea.Cmd {
 		m.config.Timeout = setting.Value.(int)
 	case "Retries":
 		m.config.Retries = setting.Value.(int)
+	case "Stall Timeout":
+		m.config.StallTimeout = setting.Value.(int)
+	case "Stall Auto-Retry":
+		m.config.StallAutoRetry = setting.Value.(bool)
+	case "Adaptive Retries":
+		m.config.AdaptiveRetry = setting.Value.(bool)
+	case "Merge Conflicts":
+		m.config.ConflictStrategy = setting.Value.(string)
+	case "Queue Order":
+		m.config.QueueOrder = setting.Value.(string)
+	case "Branch per Story":
+		m.config.StoryBranches = setting.Value.(bool)
+	case "Auto-Stash":
+		m.config.AutoStash = setting.Value.(bool)
+	case "Preview Steps":
+		m.config.PreviewSteps = setting.Value.(bool)
 	case "Notifications":
 		m.config.NotificationsEnabled = setting.Value.(bool)
+	case "Slow Step Alerts":
+		m.config.SlowStepAlerts = setting.Value.(bool)
+	case "Step Failure Alerts":
+		m.config.StepFailureAlerts = setting.Value.(bool)
+	case "Story Complete Alerts":
+		m.config.StoryCompleteAlerts = setting.Value.(bool)
+	case "Stall Alerts":
+		m.config.StallAlerts = setting.Value.(bool)
 	case "Sound":
 		m.config.SoundEnabled = setting.Value.(bool)
+	case "Complete Sound", "Warning Sound", "Failure Sound":
+		if m.config.SoundMuted == nil {
+			m.config.SoundMuted = make(map[string]bool)
+		}
+		event, _ := SoundEvent(setting.Name)
+		m.config.SoundMuted[event] = !setting.Value.(bool)
+	case "Reduced Motion":
+		m.config.ReducedMotion = setting.Value.(bool)
+	case "Usage Metrics":
+		m.config.TelemetryEnabled = setting.Value.(bool)
 	}
 
 	return func() tea.Msg {
@@ -230,6 +393,16 @@ func (m *Model) applySettingChange(setting *Setting) tea.Cmd {
 	}
 }
 
+// SoundEvent returns the event a per-event sound toggle switches on and
+// off, e.g. "failure" for "Failure Sound"
+func SoundEvent(setting string) (string, bool) {
+	event, ok := strings.CutSuffix(setting, " Sound")
+	if !ok {
+		return "", false
+	}
+	return strings.ToLower(event), true
+}
+
 // SetSize sets the view dimensions
 func (m *Model) SetSize(width, height int) {
 	m.width = width
@@ -242,6 +415,11 @@ func (m *Model) SetConfig(cfg *config.Config) {
 	m.buildSettings()
 }
 
+// SetUsagePreview sets the function that renders the usage metrics payload
+func (m *Model) SetUsagePreview(preview func() string) {
+	m.usagePreview = preview
+}
+
 // RefreshStyles rebuilds styles after theme change
 func (m *Model) RefreshStyles() {
 	m.styles = theme.NewStyles()
@@ -270,30 +448,43 @@ func (m Model) View() string {
 
 	// Settings box
 	settingsBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Padding(1, 2).
 		Width(m.width - 4).
 		Render(settingsList)
 
 	// Help text
-	help := m.styles.Muted.Render("Arrow keys: Navigate/Adjust  Enter/Space: Toggle  Esc: Back")
+	help := m.styles.Muted.Render("Arrow keys: Navigate/Adjust  Enter/Space: Toggle/Run  Esc: Back")
 
 	// Combine all elements
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
-		title,
-		"",
-		settingsBox,
-		"",
-		help,
-	)
+	sections := []string{title, "", settingsBox}
+	if preview := m.renderUsagePreview(); preview != "" {
+		sections = append(sections, "", preview)
+	}
+	sections = append(sections, "", help)
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
 	return lipgloss.NewStyle().
 		Padding(1, 2).
 		Render(content)
 }
 
+// renderUsagePreview shows exactly what the next usage report would send
+func (m Model) renderUsagePreview() string {
+	if !m.showPreview || m.usagePreview == nil || m.settings[m.cursor].Name != "Usage Metrics" {
+		return ""
+	}
+
+	t := theme.Current
+	return lipgloss.NewStyle().
+		Border(theme.BoxBorder()).
+		BorderForeground(t.Border).
+		Padding(0, 2).
+		Width(m.width - 4).
+		Render(m.styles.Subtitle.Render("Usage Report Preview") + "\n\n" + m.usagePreview())
+}
+
 func (m Model) renderSetting(index int, setting Setting) string {
 	t := theme.Current
 
@@ -348,6 +539,10 @@ func (m Model) renderSetting(index int, setting Setting) string {
 				Padding(0, 1).
 				Render("OFF")
 		}
+	case SettingTypeAction:
+		valueDisplay = lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Render("[ " + setting.Value.(string) + " ]")
 	case SettingTypeNumber:
 		val := setting.Value.(int)
 		// Show as slider-like display
@@ -2,6 +2,7 @@ package stats
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -24,16 +25,28 @@ type Model struct {
 	loading  bool
 	errorMsg string
 	scroll   int
+
+	// Date range shown, and the prompt for a custom one
+	rangeLabel   string
+	editingRange bool
+	rangeInput   string
+	rangeErr     string
 }
 
 // New creates a new statistics view model
 func New() Model {
 	return Model{
-		styles:  theme.NewStyles(),
-		loading: true,
+		styles:     theme.NewStyles(),
+		loading:    true,
+		rangeLabel: "All time",
 	}
 }
 
+// IsPrompting returns true while the custom range prompt takes input
+func (m Model) IsPrompting() bool {
+	return m.editingRange
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	return nil
@@ -43,6 +56,9 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.editingRange {
+			return m.handleRangeInput(msg)
+		}
 		return m.handleKeyMsg(msg)
 
 	case messages.WindowSizeMsg:
@@ -80,11 +96,65 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (Model, tea.Cmd) {
 		return m, func() tea.Msg {
 			return messages.StatsRefreshMsg{}
 		}
+
+	case "7":
+		return m.setRange(domain.LastDays(7, time.Now()), "Last 7 days")
+	case "3":
+		return m.setRange(domain.LastDays(30, time.Now()), "Last 30 days")
+	case "9":
+		return m.setRange(domain.LastDays(90, time.Now()), "Last 90 days")
+	case "0":
+		return m.setRange(domain.DateRange{}, "All time")
+	case "x":
+		return m, func() tea.Msg { return messages.StatsExportMsg{} }
+
+	case "c":
+		m.editingRange = true
+		m.rangeInput = ""
+		m.rangeErr = ""
 	}
 
 	return m, nil
 }
 
+func (m Model) handleRangeInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editingRange = false
+	case "enter":
+		r, err := domain.ParseDateRange(m.rangeInput, time.Now())
+		if err != nil {
+			m.rangeErr = err.Error()
+			return m, nil
+		}
+		m.editingRange = false
+		label := "All time"
+		if !r.IsAllTime() {
+			label = strings.ToUpper(r.String()[:1]) + r.String()[1:]
+		}
+		return m.setRange(r, label)
+	case "backspace":
+		if len(m.rangeInput) > 0 {
+			runes := []rune(m.rangeInput)
+			m.rangeInput = string(runes[:len(runes)-1])
+		}
+	default:
+		if msg.Type == tea.KeyRunes {
+			m.rangeInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// setRange shows the statistics of r, labelled label, once they are loaded
+func (m Model) setRange(r domain.DateRange, label string) (Model, tea.Cmd) {
+	m.rangeLabel = label
+	m.rangeErr = ""
+	m.loading = true
+	m.scroll = 0
+	return m, func() tea.Msg { return messages.StatsRangeMsg{Range: r} }
+}
+
 // View renders the statistics view
 func (m Model) View() string {
 	if m.loading {
@@ -113,6 +183,24 @@ func (m Model) View() string {
 	// Step statistics
 	sections = append(sections, m.renderStepStats())
 
+	// Average duration per week
+	sections = append(sections, m.renderDurationTrend())
+
+	// Retry behaviour per step
+	sections = append(sections, m.renderRetries())
+
+	// Stories that fail or retry most
+	sections = append(sections, m.renderUnreliable())
+
+	// Predicted vs actual durations
+	sections = append(sections, m.renderCalibration())
+
+	// Queue deadlines met
+	sections = append(sections, m.renderDeadlines())
+
+	// Tokens and cost of agent steps
+	sections = append(sections, m.renderUsage())
+
 	// Activity by day chart
 	sections = append(sections, m.renderActivityChart())
 
@@ -162,11 +250,24 @@ func (m Model) renderNoData() string {
 
 func (m Model) renderTitle() string {
 	t := theme.Current
-	return lipgloss.NewStyle().
+	title := lipgloss.NewStyle().
 		Foreground(t.Primary).
 		Bold(true).
-		Padding(0, 0, 1, 0).
 		Render("Execution Statistics")
+	title += lipgloss.NewStyle().Foreground(t.Subtle).Render("  " + m.rangeLabel)
+
+	var prompt string
+	switch {
+	case m.editingRange:
+		prompt = lipgloss.NewStyle().Foreground(t.Primary).Render("Range: "+m.rangeInput+"█") +
+			lipgloss.NewStyle().Foreground(t.Subtle).Render("  14d, 2026-03-01 or 2026-03-01..2026-03-31 | Enter apply | Esc cancel")
+		if m.rangeErr != "" {
+			prompt += "\n" + lipgloss.NewStyle().Foreground(t.Error).Render(m.rangeErr)
+		}
+	default:
+		prompt = lipgloss.NewStyle().Foreground(t.Subtle).Render("7/3/9: last 7/30/90 days | 0: all time | c: custom range | x: export HTML")
+	}
+	return lipgloss.NewStyle().Padding(0, 0, 1, 0).Render(title + "\n" + prompt)
 }
 
 func (m Model) renderOverview() string {
@@ -219,7 +320,7 @@ func (m Model) renderOverview() string {
 	))
 
 	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.BoxBorder()).
 		BorderForeground(t.Border).
 		Padding(0, 2).
 		Render(strings.Join(rows, "\n"))
@@ -267,15 +368,16 @@ func (m Model) renderStepStats() string {
 
 	var rows []string
 	headerStyle := lipgloss.NewStyle().Foreground(t.Subtle).Bold(true)
-	header := fmt.Sprintf("%-15s %8s %8s %10s %10s",
+	header := fmt.Sprintf("%-15s %8s %8s %10s %10s  %s",
 		headerStyle.Render("Step"),
 		headerStyle.Render("Success"),
 		headerStyle.Render("Failed"),
 		headerStyle.Render("Rate"),
 		headerStyle.Render("Avg Time"),
+		headerStyle.Render("Distribution"),
 	)
 	rows = append(rows, header)
-	rows = append(rows, strings.Repeat("-", 55))
+	rows = append(rows, theme.Rule(67))
 
 	for _, stepName := range stepOrder {
 		ss, ok := s.StepStats[stepName]
@@ -296,12 +398,18 @@ func (m Model) renderStepStats() string {
 			rateStyle = lipgloss.NewStyle().Foreground(t.Error)
 		}
 
-		row := fmt.Sprintf("%-15s %8s %8s %10s %10s",
+		// Duration distribution from fastest (left) to slowest (right)
+		histogram := lipgloss.NewStyle().
+			Foreground(t.Accent).
+			Render(util.Sparkline(ss.DurationHistogram))
+
+		row := fmt.Sprintf("%-15s %8s %8s %10s %10s  %s",
 			nameStyle.Render(string(ss.StepName)),
 			successStyle.Render(fmt.Sprintf("%d", ss.SuccessCount)),
 			failStyle.Render(fmt.Sprintf("%d", ss.FailureCount)),
 			rateStyle.Render(fmt.Sprintf("%.1f%%", ss.SuccessRate)),
 			formatDuration(ss.AvgDuration),
+			histogram,
 		)
 		rows = append(rows, row)
 	}
@@ -310,6 +418,417 @@ func (m Model) renderStepStats() string {
 	return lipgloss.JoinVertical(lipgloss.Left, title, table)
 }
 
+// renderRetries shows how often each step needed another attempt and how
+// often the retry paid off
+func (m Model) renderRetries() string {
+	t := theme.Current
+	s := m.stats
+
+	var rows []string
+	headerStyle := lipgloss.NewStyle().Foreground(t.Subtle).Bold(true)
+	rows = append(rows, fmt.Sprintf("%-15s %10s %8s %10s %10s",
+		headerStyle.Render("Step"),
+		headerStyle.Render("First Try"),
+		headerStyle.Render("Retried"),
+		headerStyle.Render("Recovered"),
+		headerStyle.Render("Att./Run"),
+	))
+	rows = append(rows, theme.Rule(57))
+
+	for _, stepName := range domain.AllSteps() {
+		ss, ok := s.StepStats[stepName]
+		if !ok || ss.Retries == nil {
+			continue
+		}
+		r := ss.Retries
+
+		rateStyle := lipgloss.NewStyle().Foreground(t.Success)
+		if r.FirstTryRate < 50 {
+			rateStyle = lipgloss.NewStyle().Foreground(t.Error)
+		} else if r.FirstTryRate < 80 {
+			rateStyle = lipgloss.NewStyle().Foreground(t.Warning)
+		}
+
+		recovered := "-"
+		if r.Retried > 0 {
+			recovered = fmt.Sprintf("%d/%d", r.Recovered, r.Retried)
+		}
+
+		rows = append(rows, fmt.Sprintf("%-15s %10s %8s %10s %10s",
+			lipgloss.NewStyle().Foreground(t.Primary).Render(string(stepName)),
+			rateStyle.Render(fmt.Sprintf("%.1f%%", r.FirstTryRate)),
+			lipgloss.NewStyle().Foreground(t.Warning).Render(fmt.Sprintf("%d", r.Retried)),
+			recovered,
+			fmt.Sprintf("%.2f", r.AttemptsPerRun),
+		))
+	}
+	if len(rows) == 2 {
+		return ""
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Secondary).
+		Bold(true).
+		Padding(1, 0, 0, 0).
+		Render("Retries")
+	return lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(rows, "\n"))
+}
+
+// renderUnreliable lists the stories whose runs failed or retried most
+func (m Model) renderUnreliable() string {
+	t := theme.Current
+	stories := m.stats.Unreliable
+
+	if len(stories) == 0 {
+		return ""
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Subtle).Bold(true)
+	rows := []string{
+		fmt.Sprintf("%-28s %5s %7s %7s %8s  %s",
+			headerStyle.Render("Story"),
+			headerStyle.Render("Runs"),
+			headerStyle.Render("Failed"),
+			headerStyle.Render("Retries"),
+			headerStyle.Render("Fail %"),
+			headerStyle.Render("Last Failure"),
+		),
+		theme.Rule(72),
+	}
+
+	for _, sr := range stories {
+		rateStyle := lipgloss.NewStyle().Foreground(t.Success)
+		if sr.FailureRate >= 50 {
+			rateStyle = lipgloss.NewStyle().Foreground(t.Error)
+		} else if sr.FailureRate > 0 {
+			rateStyle = lipgloss.NewStyle().Foreground(t.Warning)
+		}
+
+		lastFailure := "-"
+		if !sr.LastFailure.IsZero() {
+			lastFailure = sr.LastFailure.Local().Format("Jan 2 15:04")
+		}
+
+		rows = append(rows, fmt.Sprintf("%s %5d %7d %7d %8s  %s",
+			lipgloss.NewStyle().Foreground(t.Primary).Width(28).Render(truncate(sr.StoryKey, 28)),
+			sr.Runs,
+			sr.Failures,
+			sr.Retries,
+			rateStyle.Render(fmt.Sprintf("%.0f%%", sr.FailureRate)),
+			lipgloss.NewStyle().Foreground(t.Subtle).Render(lastFailure),
+		))
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Secondary).
+		Bold(true).
+		Padding(1, 0, 0, 0).
+		Render("Least Reliable Stories")
+	return lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(rows, "\n"))
+}
+
+// truncate shortens s to maxLen characters, marking the cut with "..."
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
+
+// renderCalibration shows how far queue estimates were from actual
+// durations, overall and per step, with the error trend per story
+func (m Model) renderCalibration() string {
+	t := theme.Current
+	c := m.stats.Calibration
+
+	if c == nil || c.Samples == 0 {
+		return ""
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Secondary).
+		Bold(true).
+		Padding(1, 0, 0, 0).
+		Render("Estimation Accuracy")
+
+	labelStyle := lipgloss.NewStyle().Foreground(t.Subtle)
+	valueStyle := lipgloss.NewStyle().Foreground(errorColor(c.MeanAbsError)).Bold(true)
+
+	// Absolute error per story, oldest to newest
+	trend := make([]int, len(c.ErrorTrend))
+	for i, pct := range c.ErrorTrend {
+		if pct < 0 {
+			pct = -pct
+		}
+		trend[i] = int(pct)
+	}
+
+	summary := fmt.Sprintf("%s %s   %s %s   %s %s",
+		labelStyle.Render("Avg error:"),
+		valueStyle.Render(fmt.Sprintf("%.0f%%", c.MeanAbsError)),
+		labelStyle.Render("Bias:"),
+		lipgloss.NewStyle().Foreground(t.Foreground).Render(describeBias(c.Bias)),
+		labelStyle.Render(fmt.Sprintf("Trend (%d stories):", c.Samples)),
+		lipgloss.NewStyle().Foreground(t.Accent).Render(util.Sparkline(trend)),
+	)
+
+	stepOrder := []domain.StepName{
+		domain.StepCreateStory,
+		domain.StepDevStory,
+		domain.StepCodeReview,
+		domain.StepGitCommit,
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Subtle).Bold(true)
+	rows := []string{
+		summary,
+		"",
+		fmt.Sprintf("%-15s %10s %10s %8s  %s",
+			headerStyle.Render("Step"),
+			headerStyle.Render("Predicted"),
+			headerStyle.Render("Actual"),
+			headerStyle.Render("Error"),
+			headerStyle.Render("Bias"),
+		),
+		theme.Rule(60),
+	}
+
+	for _, stepName := range stepOrder {
+		sc, ok := c.Steps[stepName]
+		if !ok {
+			continue
+		}
+		rows = append(rows, fmt.Sprintf("%-15s %10s %10s %8s  %s",
+			lipgloss.NewStyle().Foreground(t.Primary).Render(string(sc.StepName)),
+			formatDuration(sc.AvgPredicted),
+			formatDuration(sc.AvgActual),
+			lipgloss.NewStyle().Foreground(errorColor(sc.MeanAbsError)).Render(fmt.Sprintf("%.0f%%", sc.MeanAbsError)),
+			describeBias(sc.Bias),
+		))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(rows, "\n"))
+}
+
+// renderDurationTrend charts the average duration of completed runs per
+// week, overall and for each step, so slowdowns stand out
+func (m Model) renderDurationTrend() string {
+	t := theme.Current
+	trend := m.stats.DurationTrend
+
+	if len(trend) == 0 {
+		return ""
+	}
+	trend = trend[max(len(trend)-max(m.width-50, 8), 0):]
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Secondary).
+		Bold(true).
+		Padding(1, 0, 0, 0).
+		Render(fmt.Sprintf("Duration Trend (%d Weeks from %s)", len(trend), trend[0].Week.Format("Jan 2")))
+
+	overall := make([]time.Duration, len(trend))
+	for i, wd := range trend {
+		overall[i] = wd.Avg
+	}
+	rows := []string{m.renderTrendRow("All runs", overall)}
+
+	// Built-in steps in workflow order, then any others by name
+	seen := make(map[domain.StepName]bool)
+	for _, wd := range trend {
+		for name := range wd.Steps {
+			seen[name] = true
+		}
+	}
+	order := domain.AllSteps()
+	var others []domain.StepName
+	for name := range seen {
+		if !slices.Contains(order, name) {
+			others = append(others, name)
+		}
+	}
+	slices.Sort(others)
+
+	for _, stepName := range append(order, others...) {
+		if !seen[stepName] {
+			continue
+		}
+		// A week the step did not run in repeats the week before it
+		durations := make([]time.Duration, len(trend))
+		for i, wd := range trend {
+			d, ok := wd.Steps[stepName]
+			if !ok && i > 0 {
+				d = durations[i-1]
+			}
+			durations[i] = d
+		}
+		rows = append(rows, m.renderTrendRow(string(stepName), durations))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(rows, "\n"))
+}
+
+// renderTrendRow draws one sparkline of weekly averages with the latest
+// week's average and how it compares to the week before
+func (m Model) renderTrendRow(label string, durations []time.Duration) string {
+	t := theme.Current
+
+	seconds := make([]int, len(durations))
+	for i, d := range durations {
+		seconds[i] = int(d.Seconds())
+	}
+	latest := durations[len(durations)-1]
+
+	change := ""
+	if len(durations) > 1 && durations[len(durations)-2] > 0 {
+		pct := (float64(latest)/float64(durations[len(durations)-2]) - 1) * 100
+		color := t.Subtle
+		switch {
+		case pct >= 5:
+			color = t.Warning
+		case pct <= -5:
+			color = t.Success
+		}
+		change = lipgloss.NewStyle().Foreground(color).Render(fmt.Sprintf("%+.0f%% vs last week", pct))
+	}
+
+	return fmt.Sprintf("%s %s %8s  %s",
+		lipgloss.NewStyle().Foreground(t.Primary).Width(15).Render(label),
+		lipgloss.NewStyle().Foreground(t.Accent).Render(util.Sparkline(seconds)),
+		util.FormatDurationExtended(latest),
+		change,
+	)
+}
+
+// renderDeadlines shows the SLA hit rate: how many runs with a queue
+// deadline completed by it
+func (m Model) renderDeadlines() string {
+	t := theme.Current
+	d := m.stats.Deadlines
+
+	if d == nil || d.Total == 0 {
+		return ""
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Secondary).
+		Bold(true).
+		Padding(1, 0, 0, 0).
+		Render("Deadlines")
+
+	color := t.Error
+	switch {
+	case d.HitRate >= 90:
+		color = t.Success
+	case d.HitRate >= 70:
+		color = t.Warning
+	}
+
+	line := fmt.Sprintf("%s %s %s  %s",
+		lipgloss.NewStyle().Foreground(t.Subtle).Render("SLA hit rate:"),
+		lipgloss.NewStyle().Foreground(color).Bold(true).Render(fmt.Sprintf("%.0f%%", d.HitRate)),
+		m.renderProgressBar(d.HitRate, 20),
+		lipgloss.NewStyle().Foreground(t.Subtle).Render(fmt.Sprintf("%d of %d runs finished by their deadline", d.Met, d.Total)),
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, title, line)
+}
+
+// renderUsage shows the recorded token usage and cost, in total, per
+// execution and per step
+func (m Model) renderUsage() string {
+	t := theme.Current
+	u := m.stats.Usage
+
+	if u == nil || u.Executions == 0 {
+		return ""
+	}
+
+	title := lipgloss.NewStyle().
+		Foreground(t.Secondary).
+		Bold(true).
+		Padding(1, 0, 0, 0).
+		Render("Token Usage")
+
+	labelStyle := lipgloss.NewStyle().Foreground(t.Subtle)
+	costStyle := lipgloss.NewStyle().Foreground(t.Accent).Bold(true)
+
+	summary := fmt.Sprintf("%s %s   %s %s   %s %s",
+		labelStyle.Render(fmt.Sprintf("Total (%d executions):", u.Executions)),
+		costStyle.Render(util.FormatCost(u.Total.CostUSD)),
+		labelStyle.Render("Tokens:"),
+		util.FormatTokens(u.Total.Tokens()),
+		labelStyle.Render("Per execution:"),
+		costStyle.Render(util.FormatCost(u.PerExecution.CostUSD)),
+	)
+
+	headerStyle := lipgloss.NewStyle().Foreground(t.Subtle).Bold(true)
+	rows := []string{
+		summary,
+		"",
+		fmt.Sprintf("%-15s %8s %8s %8s %8s %9s",
+			headerStyle.Render("Step"),
+			headerStyle.Render("Input"),
+			headerStyle.Render("Output"),
+			headerStyle.Render("Cached"),
+			headerStyle.Render("Written"),
+			headerStyle.Render("Cost"),
+		),
+		theme.Rule(62),
+	}
+
+	// Built-in steps in workflow order, then any others by name
+	order := domain.AllSteps()
+	var others []domain.StepName
+	for name := range u.Steps {
+		if !slices.Contains(order, name) {
+			others = append(others, name)
+		}
+	}
+	slices.Sort(others)
+
+	for _, stepName := range append(order, others...) {
+		su, ok := u.Steps[stepName]
+		if !ok {
+			continue
+		}
+		rows = append(rows, fmt.Sprintf("%-15s %8s %8s %8s %8s %9s",
+			lipgloss.NewStyle().Foreground(t.Primary).Render(string(stepName)),
+			util.FormatTokens(su.InputTokens),
+			util.FormatTokens(su.OutputTokens),
+			util.FormatTokens(su.CacheReadTokens),
+			util.FormatTokens(su.CacheWriteTokens),
+			util.FormatCost(su.CostUSD),
+		))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(rows, "\n"))
+}
+
+// errorColor grades a mean absolute percentage error
+func errorColor(pct float64) lipgloss.Color {
+	t := theme.Current
+	switch {
+	case pct <= 20:
+		return t.Success
+	case pct <= 50:
+		return t.Warning
+	default:
+		return t.Error
+	}
+}
+
+// describeBias explains which way estimates tend to miss
+func describeBias(bias float64) string {
+	switch {
+	case bias >= 5:
+		return fmt.Sprintf("runs %.0f%% longer than estimated", bias)
+	case bias <= -5:
+		return fmt.Sprintf("runs %.0f%% shorter than estimated", -bias)
+	default:
+		return "on target"
+	}
+}
+
 func (m Model) renderActivityChart() string {
 	t := theme.Current
 	s := m.stats
@@ -318,43 +837,63 @@ func (m Model) renderActivityChart() string {
 		return ""
 	}
 
+	// The chart covers the range, or the last 30 days when it has no
+	// start, up to the last day in it
+	end := time.Now()
+	if !s.Range.Until.IsZero() && s.Range.Until.Before(end) {
+		end = s.Range.Until.Add(-time.Nanosecond)
+	}
+	days := 30
+	if !s.Range.Since.IsZero() {
+		days = int(end.Sub(s.Range.Since).Hours()/24) + 1
+	}
+	days = min(max(days, 1), max(m.width-14, 30))
+	activityTitle := fmt.Sprintf("Activity (Last %d Days)", days)
+	if !s.Range.Until.IsZero() {
+		activityTitle = fmt.Sprintf("Activity (%d Days to %s)", days, end.Format("Jan 2"))
+	}
+
 	title := lipgloss.NewStyle().
 		Foreground(t.Secondary).
 		Bold(true).
 		Padding(1, 0, 0, 0).
-		Render("Activity (Last 7 Days)")
+		Render(activityTitle)
+
+	// Sparkline over the range
+	monthly := util.DailyCounts(s.ExecutionsByDay, days, end)
+	sparkline := lipgloss.NewStyle().
+		Foreground(t.Accent).
+		Render(util.Sparkline(monthly))
+	sparkLabel := lipgloss.NewStyle().
+		Foreground(t.Subtle).
+		Width(12).
+		Render(fmt.Sprintf("%d days", days))
 
-	// Get last 7 days
-	var days []string
-	for i := 6; i >= 0; i-- {
-		day := time.Now().AddDate(0, 0, -i).Format("2006-01-02")
-		days = append(days, day)
+	rows := []string{
+		lipgloss.JoinHorizontal(lipgloss.Left, sparkLabel, sparkline),
+		"",
 	}
 
-	// Find max for scaling
+	// Last 7 days in detail
+	weekly := monthly[max(len(monthly)-7, 0):]
 	maxCount := 1
-	for _, count := range s.ExecutionsByDay {
+	for _, count := range weekly {
 		if count > maxCount {
 			maxCount = count
 		}
 	}
 
-	var rows []string
-	for _, day := range days {
-		count := s.ExecutionsByDay[day]
-		barLen := int(float64(count) / float64(maxCount) * 30)
-		if barLen < 0 {
-			barLen = 0
-		}
+	for i, count := range weekly {
+		day := end.AddDate(0, 0, -(len(weekly) - 1 - i)).Format("01-02")
 
 		bar := lipgloss.NewStyle().
 			Foreground(t.Accent).
-			Render(strings.Repeat("=", barLen))
+			Render(util.HBar(float64(count), float64(maxCount), 30))
 
 		dayLabel := lipgloss.NewStyle().
 			Foreground(t.Subtle).
 			Width(12).
-			Render(day[5:]) // Show MM-DD only
+			Render(day)
 
 		countLabel := lipgloss.NewStyle().
 			Foreground(t.Foreground).
@@ -402,14 +941,10 @@ func (m Model) renderEpicChart() string {
 	var rows []string
 	for _, epic := range epics {
 		count := s.ExecutionsByEpic[epic]
-		barLen := int(float64(count) / float64(maxCount) * 30)
-		if barLen < 0 {
-			barLen = 0
-		}
 
 		bar := lipgloss.NewStyle().
 			Foreground(t.Secondary).
-			Render(strings.Repeat("=", barLen))
+			Render(util.HBar(float64(count), float64(maxCount), 30))
 
 		epicLabel := lipgloss.NewStyle().
 			Foreground(t.Primary).
@@ -441,19 +976,10 @@ func (m Model) renderFooter() string {
 	return help
 }
 
-// renderProgressBar creates a simple progress bar
+// renderProgressBar creates a compact eighth-block progress bar
 func (m Model) renderProgressBar(percent float64, width int) string {
 	t := theme.Current
 
-	filled := int(percent / 100 * float64(width))
-	if filled > width {
-		filled = width
-	}
-	if filled < 0 {
-		filled = 0
-	}
-	empty := width - filled
-
 	var color lipgloss.Color
 	if percent >= 80 {
 		color = t.Success
@@ -463,10 +989,10 @@ func (m Model) renderProgressBar(percent float64, width int) string {
 		color = t.Error
 	}
 
-	filledBar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("=", filled))
-	emptyBar := lipgloss.NewStyle().Foreground(t.Subtle).Render(strings.Repeat("-", empty))
-
-	return fmt.Sprintf("[%s%s]", filledBar, emptyBar)
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Background(t.Border).
+		Render(util.HBar(percent, 100, width))
 }
 
 // renderLargeProgressBar creates a larger visual progress bar
@@ -2,6 +2,7 @@ package storylist
 
 import (
 	"fmt"
+	"sort"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -23,6 +24,20 @@ type Model struct {
 	filterStatus domain.StoryStatus
 	epics        []int
 	styles       theme.Styles
+
+	// Range selection: rows between anchor and the cursor are selected on
+	// top of rangeBase, the selection when the range began (anchor -1 = none)
+	anchor    int
+	rangeBase map[string]bool
+	visual    bool // V mode: moving the cursor extends the range
+
+	// Select-by-query prompt
+	querying bool
+	query    string
+
+	// Health scores from recent runs, keyed by story key
+	health       map[string]domain.StoryHealth
+	sortByHealth bool // Least healthy stories first
 }
 
 // New creates a new story list model
@@ -30,6 +45,7 @@ func New() Model {
 	return Model{
 		selected: make(map[string]bool),
 		styles:   theme.NewStyles(),
+		anchor:   -1,
 	}
 }
 
@@ -42,16 +58,28 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.querying {
+			m.handleQueryInput(msg)
+			break
+		}
+
 		switch msg.String() {
 		case "up":
-			if m.cursor > 0 {
-				m.cursor--
-			}
+			m.moveCursor(-1, m.visual)
 		case "down":
-			if m.cursor < len(m.filtered)-1 {
-				m.cursor++
+			m.moveCursor(1, m.visual)
+		case "shift+up": // Extend the selection upwards
+			m.moveCursor(-1, true)
+		case "shift+down": // Extend the selection downwards
+			m.moveCursor(1, true)
+		case "V": // Toggle visual mode
+			m.visual = !m.visual
+			m.endRange()
+			if m.visual {
+				m.extendRange()
 			}
 		case " ": // Space to toggle selection
+			m.endRange()
 			if len(m.filtered) > 0 {
 				key := m.filtered[m.cursor].Key
 				m.selected[key] = !m.selected[key]
@@ -60,15 +88,27 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				}
 			}
 		case "a": // Select all visible
+			m.endRange()
 			for _, s := range m.filtered {
 				m.selected[s.Key] = true
 			}
 		case "n": // Deselect all
+			m.endRange()
+			m.visual = false
 			m.selected = make(map[string]bool)
+		case "/": // Select stories matching a query
+			m.querying = true
+			m.query = ""
 		case "e": // Cycle epic filter
+			m.endRange()
 			m.cycleEpicFilter()
 		case "f": // Cycle status filter
+			m.endRange()
 			m.cycleStatusFilter()
+		case "S": // Toggle sorting by health
+			m.endRange()
+			m.sortByHealth = !m.sortByHealth
+			m.applyFilters()
 		}
 
 	case messages.StoriesLoadedMsg:
@@ -99,11 +139,20 @@ func (m *Model) RefreshStyles() {
 
 // SetStories sets the story data
 func (m *Model) SetStories(stories []domain.Story) {
+	m.endRange()
 	m.stories = stories
 	m.epics = parser.GetUniqueEpics(stories)
 	m.applyFilters()
 }
 
+// SetHealth sets the health scores shown next to each story
+func (m *Model) SetHealth(health map[string]domain.StoryHealth) {
+	m.health = health
+	if m.sortByHealth {
+		m.applyFilters()
+	}
+}
+
 // GetSelected returns the selected stories
 func (m Model) GetSelected() []domain.Story {
 	var selected []domain.Story
@@ -115,6 +164,88 @@ func (m Model) GetSelected() []domain.Story {
 	return selected
 }
 
+// SelectedCount returns how many stories are selected
+func (m Model) SelectedCount() int {
+	return len(m.selected)
+}
+
+// IsQuerying reports whether the select-by-query prompt has the keyboard
+func (m Model) IsQuerying() bool {
+	return m.querying
+}
+
+// moveCursor moves the cursor by delta rows, extending the range
+// selection when extend is set and ending it otherwise
+func (m *Model) moveCursor(delta int, extend bool) {
+	if extend && m.anchor < 0 {
+		m.extendRange()
+	} else if !extend {
+		m.endRange()
+	}
+
+	m.cursor = min(max(m.cursor+delta, 0), max(len(m.filtered)-1, 0))
+	if extend {
+		m.extendRange()
+	}
+}
+
+// extendRange selects the rows between the anchor and the cursor, starting
+// a range at the cursor if none is in progress
+func (m *Model) extendRange() {
+	if len(m.filtered) == 0 {
+		return
+	}
+	if m.anchor < 0 {
+		m.anchor = m.cursor
+		m.rangeBase = make(map[string]bool, len(m.selected))
+		for key := range m.selected {
+			m.rangeBase[key] = true
+		}
+	}
+
+	m.selected = make(map[string]bool, len(m.rangeBase))
+	for key := range m.rangeBase {
+		m.selected[key] = true
+	}
+	for i := min(m.anchor, m.cursor); i <= max(m.anchor, m.cursor); i++ {
+		m.selected[m.filtered[i].Key] = true
+	}
+}
+
+// endRange keeps the current selection and stops extending it
+func (m *Model) endRange() {
+	m.anchor = -1
+	m.rangeBase = nil
+}
+
+// handleQueryInput edits the select-by-query prompt
+func (m *Model) handleQueryInput(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "enter":
+		m.querying = false
+		m.endRange()
+		m.selectMatching(m.query)
+	case "esc":
+		m.querying = false
+	case "backspace":
+		if len(m.query) > 0 {
+			m.query = m.query[:len(m.query)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			m.query += msg.String()
+		}
+	}
+}
+
+// selectMatching adds every story matching query to the selection, whatever
+// the current filters (see parser.FilterStoriesByQuery)
+func (m *Model) selectMatching(query string) {
+	for _, s := range parser.FilterStoriesByQuery(m.stories, query) {
+		m.selected[s.Key] = true
+	}
+}
+
 // GetCurrent returns the currently highlighted story
 func (m Model) GetCurrent() *domain.Story {
 	if len(m.filtered) > 0 && m.cursor < len(m.filtered) {
@@ -123,6 +254,33 @@ func (m Model) GetCurrent() *domain.Story {
 	return nil
 }
 
+// Focus moves the cursor to the story with key, clearing the filters when
+// they hide it. It returns false when there is no such story.
+func (m *Model) Focus(key string) bool {
+	find := func() int {
+		for i, story := range m.filtered {
+			if story.Key == key {
+				return i
+			}
+		}
+		return -1
+	}
+
+	i := find()
+	if i < 0 {
+		m.filterEpic = 0
+		m.filterStatus = ""
+		m.applyFilters()
+		if i = find(); i < 0 {
+			return false
+		}
+	}
+	m.visual = false
+	m.endRange()
+	m.cursor = i
+	return true
+}
+
 func (m *Model) cycleEpicFilter() {
 	if len(m.epics) == 0 {
 		return
@@ -181,6 +339,19 @@ func (m *Model) applyFilters() {
 		m.filtered = parser.FilterStoriesByStatus(m.filtered, m.filterStatus)
 	}
 
+	// Least healthy first; stories without runs keep their order at the end
+	if m.sortByHealth {
+		m.filtered = append([]domain.Story(nil), m.filtered...)
+		sort.SliceStable(m.filtered, func(i, j int) bool {
+			a, aok := m.healthOf(m.filtered[i].Key)
+			b, bok := m.healthOf(m.filtered[j].Key)
+			if aok != bok {
+				return aok
+			}
+			return aok && a.Score < b.Score
+		})
+	}
+
 	// Reset cursor if out of bounds
 	if m.cursor >= len(m.filtered) {
 		m.cursor = max(0, len(m.filtered)-1)
@@ -199,6 +370,9 @@ func (m Model) View() string {
 	if m.filterStatus != "" {
 		filterInfo += fmt.Sprintf(" | %s", m.filterStatus)
 	}
+	if m.sortByHealth {
+		filterInfo += " | by health"
+	}
 
 	header := lipgloss.NewStyle().
 		Foreground(t.Primary).
@@ -218,12 +392,27 @@ func (m Model) View() string {
 			Render(fmt.Sprintf("  [%d selected]", selectedCount))
 	}
 
+	if m.visual {
+		selectedText += lipgloss.NewStyle().
+			Foreground(t.Accent).
+			Bold(true).
+			Render("  -- VISUAL --")
+	}
+
 	titleLine := header + filterText + selectedText
 
-	// Help line
+	// Help line, or the select-by-query prompt while it is open
 	help := lipgloss.NewStyle().
 		Foreground(t.Subtle).
-		Render("[Up/Down] Navigate  [Space] Select  [a] All  [n] None  [e] Epic  [f] Status  [Enter] Execute  [q] Add to Queue")
+		Render("[Up/Down] Navigate  [Space] Select  [Shift+Up/Down] Range  [V] Visual  [/] Select matching  [a] All  [n] None  [e] Epic  [f] Status  [S] Sort by health  [Enter] Execute  [q] Add to Queue")
+	if m.querying {
+		help = lipgloss.NewStyle().
+			Foreground(t.Primary).
+			Render("Select: "+m.query+"_") +
+			lipgloss.NewStyle().
+				Foreground(t.Subtle).
+				Render("  e.g. ready-for-dev epic:4  [Enter] Select  [Esc] Cancel")
+	}
 
 	// Story list
 	var rows []string
@@ -272,6 +461,7 @@ func (m Model) renderStoryRow(story domain.Story, isCursor bool) string {
 	cursorWidth := 2       // "> " or "  "
 	selIndicatorWidth := 2 // "* " or "  "
 	spacingWidth := 2      // "  " between badge and key
+	healthWidth := 6       // Glyph, score and a space, e.g. "●  87 "
 
 	// Status badge
 	var badge string
@@ -306,7 +496,7 @@ func (m Model) renderStoryRow(story domain.Story, isCursor bool) string {
 	}
 
 	// Calculate available width for story key
-	fixedWidth := cursorWidth + selIndicatorWidth + badgeWidth + spacingWidth + fileIndicatorWidth
+	fixedWidth := cursorWidth + selIndicatorWidth + healthWidth + badgeWidth + spacingWidth + fileIndicatorWidth
 	keyWidth := rowWidth - fixedWidth
 	if keyWidth < 20 {
 		keyWidth = 20
@@ -356,7 +546,7 @@ func (m Model) renderStoryRow(story domain.Story, isCursor bool) string {
 			Render(fileIndicator)
 	}
 
-	row := cursor + selIndicator + badge + "  " + key + styledFileIndicator
+	row := cursor + selIndicator + m.renderHealth(story.Key) + badge + "  " + key + styledFileIndicator
 
 	// Highlight entire row if cursor
 	if isCursor {
@@ -368,6 +558,36 @@ func (m Model) renderStoryRow(story domain.Story, isCursor bool) string {
 	return row
 }
 
+// healthOf returns the health of a story that has finished runs
+func (m Model) healthOf(key string) (domain.StoryHealth, bool) {
+	h, ok := m.health[key]
+	return h, ok && h.Runs > 0
+}
+
+// renderHealth renders the health column: a level glyph colored by level
+// and the score, or a placeholder for stories that have not run
+func (m Model) renderHealth(key string) string {
+	t := theme.Current
+
+	h, ok := m.healthOf(key)
+	if !ok {
+		return lipgloss.NewStyle().
+			Foreground(t.Subtle).
+			Render(theme.HealthGlyph(domain.HealthUnknown) + "  -- ")
+	}
+
+	color := t.Success
+	switch h.Level() {
+	case domain.HealthFair:
+		color = t.Warning
+	case domain.HealthPoor:
+		color = t.Error
+	}
+	return lipgloss.NewStyle().
+		Foreground(color).
+		Render(fmt.Sprintf("%s %3d ", theme.HealthGlyph(h.Level()), h.Score))
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
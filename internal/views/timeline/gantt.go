@@ -0,0 +1,148 @@
+package timeline
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/theme"
+)
+
+// span is the stretch of wall-clock time the Gantt bars are drawn on
+type span struct {
+	start time.Time
+	end   time.Time
+}
+
+// column returns the bar column of at, clamped to the bar
+func (s span) column(at time.Time, width int) int {
+	total := s.end.Sub(s.start)
+	if total <= 0 {
+		return 0
+	}
+	col := int(float64(at.Sub(s.start)) / float64(total) * float64(width))
+	return min(max(col, 0), width)
+}
+
+// labels renders the axis: clock times spread over width columns, the
+// first at the start and the last ending at the end
+func (s span) labels(width int) string {
+	layout := "15:04"
+	if s.end.Sub(s.start) < 10*time.Minute {
+		layout = "15:04:05"
+	}
+	if s.start.Local().YearDay() != s.end.Local().YearDay() {
+		layout = "Jan 2 15:04"
+	}
+	size := len(s.start.Format(layout))
+
+	axis := []rune(strings.Repeat(" ", width))
+	place := func(col int, at time.Time) {
+		for i, r := range at.Local().Format(layout) {
+			if col+i < width {
+				axis[col+i] = r
+			}
+		}
+	}
+
+	// One label per two label widths, leaving room for the last one
+	step := 2 * (size + 2)
+	for col := 0; col+size+2 <= width-size; col += step {
+		at := s.start.Add(time.Duration(float64(s.end.Sub(s.start)) * float64(col) / float64(width)))
+		place(col, at)
+	}
+	if width >= size {
+		place(width-size, s.end)
+	}
+	return string(axis)
+}
+
+// endOf returns when exec ended, or now while it is still running
+func endOf(exec *domain.Execution, now time.Time) time.Time {
+	switch {
+	case !exec.EndTime.IsZero():
+		return exec.EndTime
+	case exec.IsFinished() && exec.Duration > 0:
+		return exec.StartTime.Add(exec.Duration)
+	}
+	return now
+}
+
+// ganttRows returns the executions that started, in start order, with the
+// span from the first start to the last end
+func ganttRows(executions []*domain.Execution, now time.Time) ([]*domain.Execution, span) {
+	var rows []*domain.Execution
+	var s span
+	for _, exec := range executions {
+		if exec == nil || exec.StartTime.IsZero() {
+			continue
+		}
+		rows = append(rows, exec)
+		if s.start.IsZero() || exec.StartTime.Before(s.start) {
+			s.start = exec.StartTime
+		}
+		if end := endOf(exec, now); end.After(s.end) {
+			s.end = end
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].StartTime.Before(rows[j].StartTime)
+	})
+	return rows, s
+}
+
+// renderGanttRow renders an execution as a bar from its start to its end
+// on the shared axis, each step drawn where it ran. Time between steps,
+// such as waits and retries, shows as the empty pattern.
+func (m Model) renderGanttRow(exec *domain.Execution, barWidth int, axis span) string {
+	t := theme.Current
+
+	type cell struct {
+		char  string
+		color lipgloss.Color
+	}
+	cells := make([]cell, barWidth)
+	for i := range cells {
+		cells[i] = cell{" ", t.Subtle}
+	}
+
+	from := axis.column(exec.StartTime, barWidth)
+	to := max(axis.column(endOf(exec, time.Now()), barWidth), from+1)
+	for i := from; i < min(to, barWidth); i++ {
+		cells[i] = cell{theme.PatternEmpty, t.Subtle}
+	}
+
+	for _, step := range exec.Steps {
+		if step.StartTime.IsZero() || step.Status == domain.StepSkipped {
+			continue
+		}
+		end := step.EndTime
+		if end.IsZero() {
+			end = time.Now()
+		}
+		color, char := stepFill(step)
+		first := axis.column(step.StartTime, barWidth)
+		last := max(axis.column(end, barWidth), first+1)
+		for i := first; i < min(last, barWidth); i++ {
+			cells[i] = cell{char, color}
+		}
+	}
+
+	// Render runs of the same cell with one style
+	var bar strings.Builder
+	for i := 0; i < len(cells); {
+		j := i
+		for j < len(cells) && cells[j] == cells[i] {
+			j++
+		}
+		bar.WriteString(lipgloss.NewStyle().
+			Foreground(cells[i].color).
+			Render(strings.Repeat(cells[i].char, j-i)))
+		i = j
+	}
+
+	return m.renderRowLabel(exec) + bar.String()
+}
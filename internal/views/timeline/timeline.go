@@ -21,6 +21,10 @@ type Model struct {
 	executions []*domain.Execution // Historical executions for display
 	scroll     int
 	styles     theme.Styles
+
+	// gantt places the bars on a shared time axis instead of scaling each
+	// from its own start, so overlapping runs show as overlapping bars
+	gantt bool
 }
 
 // New creates a new timeline model
@@ -54,6 +58,8 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.scroll = 0
 		case "end":
 			m.scroll = m.maxScroll()
+		case "g":
+			m.gantt = !m.gantt
 		}
 
 	case messages.QueueUpdatedMsg:
@@ -141,9 +147,13 @@ func (m Model) View() string {
 	content := m.renderTimeline()
 
 	// Help
+	toggle := "[g] Time axis"
+	if m.gantt {
+		toggle = "[g] Durations"
+	}
 	help := lipgloss.NewStyle().
 		Foreground(t.Subtle).
-		Render("[Up/Down] Scroll  [Home/End] Jump")
+		Render("[Up/Down] Scroll  [Home/End] Jump  " + toggle)
 
 	// Combine all sections
 	view := lipgloss.JoinVertical(lipgloss.Left,
@@ -236,12 +246,22 @@ func (m Model) renderTimeline() string {
 
 	var rows []string
 
+	executions := m.executions
+	var axis span
+	if m.gantt {
+		executions, axis = ganttRows(m.executions, time.Now())
+	}
+
 	// Column headers
 	headerStyle := lipgloss.NewStyle().Foreground(t.Subtle).Bold(true)
+	timelineHeader := headerStyle.Render("Timeline")
+	if m.gantt {
+		timelineHeader = headerStyle.Render(axis.labels(barWidth))
+	}
 	headers := fmt.Sprintf("%s  %s  %s",
 		headerStyle.Width(keyWidth).Render("Story"),
 		headerStyle.Width(durationWidth).Render("Duration"),
-		headerStyle.Render("Timeline"),
+		timelineHeader,
 	)
 	rows = append(rows, headers)
 	rows = append(rows, strings.Repeat("-", m.width-6))
@@ -250,17 +270,21 @@ func (m Model) renderTimeline() string {
 	visibleHeight := m.height - 10
 	startIdx := m.scroll
 	endIdx := startIdx + visibleHeight
-	if endIdx > len(m.executions) {
-		endIdx = len(m.executions)
+	if endIdx > len(executions) {
+		endIdx = len(executions)
 	}
 
 	// Render each execution
 	for i := startIdx; i < endIdx; i++ {
-		exec := m.executions[i]
+		exec := executions[i]
 		if exec == nil {
 			continue
 		}
-		rows = append(rows, m.renderExecutionRow(exec, barWidth, maxDuration))
+		if m.gantt {
+			rows = append(rows, m.renderGanttRow(exec, barWidth, axis))
+		} else {
+			rows = append(rows, m.renderExecutionRow(exec, barWidth, maxDuration))
+		}
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, rows...)
@@ -268,6 +292,11 @@ func (m Model) renderTimeline() string {
 
 // renderExecutionRow renders a single execution as a timeline row
 func (m Model) renderExecutionRow(exec *domain.Execution, barWidth int, maxDuration time.Duration) string {
+	return m.renderRowLabel(exec) + m.renderStepBars(exec, barWidth, maxDuration)
+}
+
+// renderRowLabel renders the story key and duration columns of a row
+func (m Model) renderRowLabel(exec *domain.Execution) string {
 	t := theme.Current
 
 	// Story key
@@ -277,16 +306,13 @@ func (m Model) renderExecutionRow(exec *domain.Execution, barWidth int, maxDurat
 	} else if exec.Status == domain.ExecutionFailed {
 		keyStyle = keyStyle.Foreground(t.Error)
 	}
-	key := keyStyle.Width(35).Render(exec.Story.Key)
+	key := keyStyle.Width(35).Render(theme.ExecutionGlyph(exec.Status) + " " + exec.Story.Key)
 
 	// Duration
 	durationStyle := lipgloss.NewStyle().Foreground(t.Subtle)
 	duration := durationStyle.Width(12).Render(formatDuration(exec.Duration))
 
-	// Step bars
-	bar := m.renderStepBars(exec, barWidth, maxDuration)
-
-	return fmt.Sprintf("%s  %s  %s", key, duration, bar)
+	return fmt.Sprintf("%s  %s  ", key, duration)
 }
 
 // renderStepBars renders the colored step duration bars
@@ -303,14 +329,6 @@ func (m Model) renderStepBars(exec *domain.Execution, barWidth int, maxDuration
 	var bar strings.Builder
 	totalWidth := 0
 
-	// Step colors
-	stepColors := map[domain.StepName]lipgloss.Color{
-		domain.StepCreateStory: t.Info,
-		domain.StepDevStory:    t.Primary,
-		domain.StepCodeReview:  t.Warning,
-		domain.StepGitCommit:   t.Success,
-	}
-
 	for _, step := range exec.Steps {
 		if step.Status == domain.StepSkipped {
 			continue
@@ -331,20 +349,8 @@ func (m Model) renderStepBars(exec *domain.Execution, barWidth int, maxDuration
 			continue
 		}
 
-		// Get color for this step
-		color := t.Subtle
-		if c, ok := stepColors[step.Name]; ok {
-			color = c
-		}
-
-		// Add failure indicator if step failed
-		char := "="
-		if step.Status == domain.StepFailed {
-			color = t.Error
-			char = "X"
-		}
-
 		// Render the bar segment
+		color, char := stepFill(step)
 		style := lipgloss.NewStyle().Foreground(color)
 		bar.WriteString(style.Render(strings.Repeat(char, stepWidth)))
 		totalWidth += stepWidth
@@ -354,12 +360,32 @@ func (m Model) renderStepBars(exec *domain.Execution, barWidth int, maxDuration
 	if totalWidth < barWidth {
 		bar.WriteString(lipgloss.NewStyle().
 			Foreground(t.Subtle).
-			Render(strings.Repeat("-", barWidth-totalWidth)))
+			Render(strings.Repeat(theme.PatternEmpty, barWidth-totalWidth)))
 	}
 
 	return bar.String()
 }
 
+// stepFill returns the color and fill pattern of a step's bar. Failed steps
+// use a distinct pattern as well as color.
+func stepFill(step *domain.StepExecution) (lipgloss.Color, string) {
+	t := theme.Current
+	if step.Status == domain.StepFailed {
+		return t.Error, theme.PatternFailed
+	}
+	switch step.Name {
+	case domain.StepCreateStory:
+		return t.Info, theme.PatternSuccess
+	case domain.StepDevStory:
+		return t.Primary, theme.PatternSuccess
+	case domain.StepCodeReview:
+		return t.Warning, theme.PatternSuccess
+	case domain.StepGitCommit:
+		return t.Success, theme.PatternSuccess
+	}
+	return t.Subtle, theme.PatternSuccess
+}
+
 // formatDuration uses the shared extended duration formatter
 // QUAL-002: Using shared utility instead of duplicated code
 var formatDuration = util.FormatDurationExtended
@@ -7,6 +7,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/fsnotify/fsnotify"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
 )
 
 // RefreshMsg is sent when watched files change
@@ -221,3 +223,25 @@ func WatchSprintStatus(sprintStatusPath string, debounce time.Duration) *Watcher
 	w.AddPath(sprintStatusPath)
 	return w
 }
+
+// NewlyReady returns the stories of after that are ready-for-dev but were
+// not in before: their status changed, or they were added as ready. Order
+// follows after.
+func NewlyReady(before, after []domain.Story) []domain.Story {
+	previous := make(map[string]domain.StoryStatus, len(before))
+	for _, story := range before {
+		previous[story.Key] = story.Status
+	}
+
+	var ready []domain.Story
+	for _, story := range after {
+		if story.Status != domain.StatusReadyForDev {
+			continue
+		}
+		if status, ok := previous[story.Key]; ok && status == domain.StatusReadyForDev {
+			continue
+		}
+		ready = append(ready, story)
+	}
+	return ready
+}
@@ -0,0 +1,42 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+func story(key string, status domain.StoryStatus) domain.Story {
+	return domain.Story{Key: key, Status: status}
+}
+
+func keys(stories []domain.Story) []string {
+	var out []string
+	for _, s := range stories {
+		out = append(out, s.Key)
+	}
+	return out
+}
+
+func TestNewlyReady(t *testing.T) {
+	before := []domain.Story{
+		story("3-1-auth", domain.StatusBacklog),
+		story("3-2-reset", domain.StatusReadyForDev),
+		story("3-3-profile", domain.StatusInProgress),
+		story("3-4-avatar", domain.StatusReadyForDev),
+	}
+	after := []domain.Story{
+		story("3-1-auth", domain.StatusReadyForDev),    // backlog -> ready
+		story("3-2-reset", domain.StatusReadyForDev),   // unchanged
+		story("3-3-profile", domain.StatusReadyForDev), // in-progress -> ready
+		story("3-4-avatar", domain.StatusDone),         // ready -> done
+		story("3-5-search", domain.StatusReadyForDev),  // added as ready
+		story("3-6-export", domain.StatusBacklog),      // added, not ready
+	}
+
+	assert.Equal(t, []string{"3-1-auth", "3-3-profile", "3-5-search"}, keys(NewlyReady(before, after)))
+	assert.Empty(t, NewlyReady(after, after))
+	assert.Empty(t, NewlyReady(before, nil))
+}
@@ -0,0 +1,179 @@
+// Package webhook POSTs execution events as JSON to configured URLs, so
+// other tooling can react when runs start, steps finish and queues complete.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Event names sent in the payload and the X-BMAD-Event header
+const (
+	EventExecutionStarted   = "execution.started"
+	EventStepCompleted      = "step.completed"
+	EventExecutionCompleted = "execution.completed"
+	EventQueueCompleted     = "queue.completed"
+	EventStoriesReady       = "stories.ready" // Watch mode saw stories become ready-for-dev
+)
+
+// Request headers
+const (
+	EventHeader     = "X-BMAD-Event"
+	SignatureHeader = "X-BMAD-Signature" // "sha256=<hex HMAC of the body>", set when a secret is configured
+)
+
+// MaxAttempts is how many times a delivery is tried before giving up
+const MaxAttempts = 3
+
+// sendTimeout bounds a single delivery attempt
+const sendTimeout = 10 * time.Second
+
+// Event is the JSON payload of a webhook delivery
+type Event struct {
+	Event       string     `json:"event"`
+	Time        time.Time  `json:"time"`
+	ExecutionID string     `json:"execution_id,omitempty"`
+	Story       *Story     `json:"story,omitempty"`
+	Step        *Step      `json:"step,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	DurationMS  int64      `json:"duration_ms,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	Queue       *QueueInfo `json:"queue,omitempty"`
+	Stories     []Story    `json:"stories,omitempty"`
+}
+
+// Story identifies the story an event is about
+type Story struct {
+	Key   string `json:"key"`
+	Epic  int    `json:"epic"`
+	Title string `json:"title,omitempty"`
+}
+
+// Step identifies the step of a step.completed event
+type Step struct {
+	Index   int    `json:"index"`
+	Name    string `json:"name"`
+	Attempt int    `json:"attempt,omitempty"`
+}
+
+// QueueInfo summarizes a finished queue
+type QueueInfo struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Conflicts int `json:"conflicts"`
+}
+
+// Dispatcher delivers events to every configured URL
+type Dispatcher struct {
+	urls       []string
+	secret     string
+	client     *http.Client
+	retryDelay time.Duration // Wait before the second attempt; doubles after each failure
+}
+
+// New creates a dispatcher. With no URLs it sends nothing.
+func New(urls []string, secret string) *Dispatcher {
+	return &Dispatcher{
+		urls:       urls,
+		secret:     secret,
+		client:     &http.Client{Timeout: sendTimeout},
+		retryDelay: time.Second,
+	}
+}
+
+// Enabled reports whether any webhook URL is configured
+func (d *Dispatcher) Enabled() bool {
+	return d != nil && len(d.urls) > 0
+}
+
+// Send delivers event to every URL, retrying failed deliveries. It returns
+// the errors of deliveries that failed on every attempt.
+func (d *Dispatcher) Send(ctx context.Context, event Event) error {
+	if !d.Enabled() {
+		return nil
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook event: %w", err)
+	}
+
+	var errs []error
+	for _, url := range d.urls {
+		if err := d.deliver(ctx, url, event.Event, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver POSTs body to url, retrying network errors, 429s and 5xx
+// responses with a growing delay
+func (d *Dispatcher) deliver(ctx context.Context, url, name string, body []byte) error {
+	delay := d.retryDelay
+	var lastErr error
+	for attempt := 1; attempt <= MaxAttempts; attempt++ {
+		retry, err := d.post(ctx, url, name, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry || attempt == MaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("webhook %s: %w", url, ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return fmt.Errorf("webhook %s: %w", url, lastErr)
+}
+
+// post makes one delivery attempt and reports whether a failure is worth
+// retrying
+func (d *Dispatcher) post(ctx context.Context, url, name string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, name)
+	if d.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.secret, body))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("rejected: %s", resp.Status)
+	}
+	return false, nil
+}
+
+// Sign returns the signature header value for body: the hex HMAC-SHA256
+// of the body keyed with secret, prefixed with "sha256="
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
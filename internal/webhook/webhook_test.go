@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_Disabled(t *testing.T) {
+	d := New(nil, "")
+	assert.False(t, d.Enabled())
+	assert.NoError(t, d.Send(context.Background(), Event{Event: EventQueueCompleted}))
+}
+
+func TestDispatcher_SendSignsPayload(t *testing.T) {
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	d := New([]string{server.URL}, "s3cret")
+	err := d.Send(context.Background(), Event{
+		Event:       EventStepCompleted,
+		ExecutionID: "exec-1",
+		Story:       &Story{Key: "3-1-login", Epic: 3},
+		Step:        &Step{Index: 1, Name: "dev-story", Attempt: 2},
+		Status:      "success",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, EventStepCompleted, header.Get(EventHeader))
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, Sign("s3cret", body), header.Get(SignatureHeader))
+
+	var got Event
+	require.NoError(t, json.Unmarshal(body, &got))
+	assert.Equal(t, "3-1-login", got.Story.Key)
+	assert.Equal(t, "dev-story", got.Step.Name)
+	assert.False(t, got.Time.IsZero())
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	t.Run("server errors are retried", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < MaxAttempts {
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		}))
+		defer server.Close()
+
+		d := New([]string{server.URL}, "")
+		d.retryDelay = time.Millisecond
+		require.NoError(t, d.Send(context.Background(), Event{Event: EventQueueCompleted}))
+		assert.Equal(t, int32(MaxAttempts), calls.Load())
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		d := New([]string{server.URL}, "")
+		d.retryDelay = time.Millisecond
+		err := d.Send(context.Background(), Event{Event: EventQueueCompleted})
+		assert.ErrorContains(t, err, "404")
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestSign(t *testing.T) {
+	// Matches: printf 'hello' | openssl dgst -sha256 -hmac key
+	assert.Equal(t, "sha256=9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b", Sign("key", []byte("hello")))
+}
@@ -0,0 +1,207 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxWorkflowSize caps the size of a fetched workflow file
+const MaxWorkflowSize = 1024 * 1024
+
+// validName matches workflow names that are safe to use as file names
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Source is a parsed workflow location
+type Source struct {
+	Repo string // Git repository to clone ("" for files and URLs)
+	Path string // File path, URL, or path inside Repo
+}
+
+// ParseSource interprets a workflow location. Supported forms:
+//
+//	./workflows/review.yaml                          local file
+//	https://example.com/review.yaml                  plain URL
+//	https://github.com/org/repo.git#flows/review.yaml  file in a git repo
+//	git@github.com:org/repo.git#flows/review.yaml     file in a git repo
+func ParseSource(source string) (Source, error) {
+	if source == "" {
+		return Source{}, fmt.Errorf("workflow source is required")
+	}
+
+	if repo, path, ok := strings.Cut(source, "#"); ok && isGitRepo(repo) {
+		if path == "" {
+			return Source{}, fmt.Errorf("git source %q needs a file path after '#'", source)
+		}
+		return Source{Repo: repo, Path: path}, nil
+	}
+
+	if isGitRepo(source) {
+		return Source{}, fmt.Errorf("git source %q needs a file path, e.g. %s#workflows/name.yaml", source, source)
+	}
+
+	return Source{Path: source}, nil
+}
+
+// isGitRepo reports whether s looks like a git repository URL
+func isGitRepo(s string) bool {
+	return strings.HasSuffix(s, ".git") || strings.HasPrefix(s, "git@") || strings.HasPrefix(s, "git://")
+}
+
+// isURL reports whether s is an http(s) URL
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
+// Fetch reads the workflow YAML at source and returns it together with a
+// name derived from the file name
+func Fetch(ctx context.Context, source string) ([]byte, string, error) {
+	src, err := ParseSource(source)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var data []byte
+	switch {
+	case src.Repo != "":
+		data, err = fetchFromGit(ctx, src.Repo, src.Path)
+	case isURL(src.Path):
+		data, err = fetchURL(ctx, src.Path)
+	default:
+		data, err = readLimited(src.Path)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, nameFromPath(src.Path), nil
+}
+
+// nameFromPath strips the directory and YAML extension from a path or URL
+func nameFromPath(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	base := filepath.Base(filepath.FromSlash(path))
+	return strings.TrimSuffix(strings.TrimSuffix(base, ".yaml"), ".yml")
+}
+
+// fetchURL downloads a workflow over HTTP
+func fetchURL(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid workflow URL: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download workflow: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download workflow: %s", resp.Status)
+	}
+
+	return readAllLimited(resp.Body)
+}
+
+// fetchFromGit shallow-clones repo into a temporary directory and reads path
+func fetchFromGit(ctx context.Context, repo, path string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "bmad-workflow-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// "--" stops the repo URL from being read as an option
+	cmd := exec.CommandContext(ctx, "git", "clone", "--depth", "1", "--quiet", "--", repo, dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to clone %s: %w: %s", repo, err, strings.TrimSpace(string(out)))
+	}
+
+	full := filepath.Join(dir, filepath.FromSlash(path))
+	rel, err := filepath.Rel(dir, full)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return nil, fmt.Errorf("path %q is outside the repository", path)
+	}
+
+	return readLimited(full)
+}
+
+// readLimited reads a local workflow file, refusing oversized files
+func readLimited(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workflow: %w", err)
+	}
+	defer f.Close()
+	return readAllLimited(f)
+}
+
+func readAllLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxWorkflowSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow: %w", err)
+	}
+	if len(data) > MaxWorkflowSize {
+		return nil, fmt.Errorf("workflow is larger than %d bytes", MaxWorkflowSize)
+	}
+	return data, nil
+}
+
+// InstallOptions control how a workflow is installed
+type InstallOptions struct {
+	Name  string // Install under this name instead of the workflow's own
+	Force bool   // Replace an existing workflow with the same name
+}
+
+// Install validates workflow YAML and saves it into the store.
+// defaultName is used when neither opts.Name nor the YAML sets a name.
+func (ws *WorkflowStore) Install(data []byte, defaultName string, opts InstallOptions) (*Workflow, error) {
+	w, err := Parse(data, defaultName)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Name != "" {
+		w.Name = opts.Name
+	}
+
+	if !validName.MatchString(w.Name) {
+		return nil, fmt.Errorf("invalid workflow name %q: use letters, digits, '.', '_' and '-'", w.Name)
+	}
+	if w.Name == "default" {
+		return nil, fmt.Errorf("cannot replace the default workflow; install under another name")
+	}
+	if _, exists := ws.workflows[w.Name]; exists && !opts.Force {
+		return nil, fmt.Errorf("workflow %q already exists (use --force to replace it)", w.Name)
+	}
+
+	if err := ws.Save(w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Export writes a workflow as YAML, ready to be installed elsewhere
+func (ws *WorkflowStore) Export(name string, out io.Writer) error {
+	w, ok := ws.Get(name)
+	if !ok {
+		return fmt.Errorf("workflow %q not found", name)
+	}
+
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+	if err := enc.Encode(w); err != nil {
+		return fmt.Errorf("failed to encode workflow: %w", err)
+	}
+	return enc.Close()
+}
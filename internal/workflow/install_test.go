@@ -0,0 +1,189 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sharedWorkflow = `name: shared
+description: Shared review pipeline
+steps:
+  - name: dev-story
+    prompt_template: "Work on {{.Story.Key}}"
+  - name: tests
+    type: shell
+    command: make test
+`
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		source  string
+		want    Source
+		wantErr bool
+	}{
+		{"./flows/review.yaml", Source{Path: "./flows/review.yaml"}, false},
+		{"https://example.com/review.yaml", Source{Path: "https://example.com/review.yaml"}, false},
+		{"https://github.com/org/repo.git#flows/review.yaml", Source{Repo: "https://github.com/org/repo.git", Path: "flows/review.yaml"}, false},
+		{"git@github.com:org/repo.git#review.yaml", Source{Repo: "git@github.com:org/repo.git", Path: "review.yaml"}, false},
+		{"https://github.com/org/repo.git", Source{}, true},
+		{"https://github.com/org/repo.git#", Source{}, true},
+		{"", Source{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			got, err := ParseSource(tt.source)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFetch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("local file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "review.yml")
+		require.NoError(t, os.WriteFile(path, []byte(sharedWorkflow), 0644))
+
+		data, name, err := Fetch(ctx, path)
+		require.NoError(t, err)
+		assert.Equal(t, sharedWorkflow, string(data))
+		assert.Equal(t, "review", name)
+	})
+
+	t.Run("url", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/flows/review.yaml" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(sharedWorkflow))
+		}))
+		defer server.Close()
+
+		data, name, err := Fetch(ctx, server.URL+"/flows/review.yaml?raw=1")
+		require.NoError(t, err)
+		assert.Equal(t, sharedWorkflow, string(data))
+		assert.Equal(t, "review", name)
+
+		_, _, err = Fetch(ctx, server.URL+"/missing.yaml")
+		assert.Error(t, err)
+	})
+
+	t.Run("git repository", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not installed")
+		}
+		repo := filepath.Join(t.TempDir(), "flows.git")
+		require.NoError(t, os.MkdirAll(filepath.Join(repo, "flows"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "flows", "review.yaml"), []byte(sharedWorkflow), 0644))
+		for _, args := range [][]string{
+			{"init", "-q"},
+			{"add", "."},
+			{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "add workflow"},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repo
+			out, err := cmd.CombinedOutput()
+			require.NoError(t, err, string(out))
+		}
+
+		data, name, err := Fetch(ctx, repo+"#flows/review.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, sharedWorkflow, string(data))
+		assert.Equal(t, "review", name)
+
+		_, _, err = Fetch(ctx, repo+"#../outside.yaml")
+		assert.Error(t, err)
+	})
+}
+
+func TestWorkflowStore_Install(t *testing.T) {
+	newStore := func(t *testing.T) *WorkflowStore {
+		store := NewWorkflowStore(t.TempDir())
+		require.NoError(t, store.Load())
+		return store
+	}
+
+	t.Run("installs and saves a valid workflow", func(t *testing.T) {
+		store := newStore(t)
+
+		w, err := store.Install([]byte(sharedWorkflow), "review", InstallOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "shared", w.Name)
+
+		_, err = os.Stat(filepath.Join(store.workflowDir, "shared.yaml"))
+		assert.NoError(t, err)
+		_, ok := store.Get("shared")
+		assert.True(t, ok)
+	})
+
+	t.Run("name option overrides the workflow name", func(t *testing.T) {
+		store := newStore(t)
+
+		w, err := store.Install([]byte(sharedWorkflow), "review", InstallOptions{Name: "team-review"})
+		require.NoError(t, err)
+		assert.Equal(t, "team-review", w.Name)
+	})
+
+	t.Run("refuses to overwrite without force", func(t *testing.T) {
+		store := newStore(t)
+		_, err := store.Install([]byte(sharedWorkflow), "", InstallOptions{})
+		require.NoError(t, err)
+
+		_, err = store.Install([]byte(sharedWorkflow), "", InstallOptions{})
+		assert.ErrorContains(t, err, "already exists")
+
+		_, err = store.Install([]byte(sharedWorkflow), "", InstallOptions{Force: true})
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects invalid workflows and names", func(t *testing.T) {
+		store := newStore(t)
+
+		_, err := store.Install([]byte("name: bad\nsteps:\n  - name: x\n    type: shell\n"), "", InstallOptions{})
+		assert.ErrorContains(t, err, "requires command")
+
+		_, err = store.Install([]byte(sharedWorkflow), "", InstallOptions{Name: "../escape"})
+		assert.ErrorContains(t, err, "invalid workflow name")
+
+		_, err = store.Install([]byte(sharedWorkflow), "", InstallOptions{Name: "default"})
+		assert.ErrorContains(t, err, "default")
+	})
+}
+
+func TestWorkflowStore_Export(t *testing.T) {
+	store := NewWorkflowStore(t.TempDir())
+	require.NoError(t, store.Load())
+	_, err := store.Install([]byte(sharedWorkflow), "", InstallOptions{})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, store.Export("shared", &buf))
+
+	// An export installs cleanly into another store
+	other := NewWorkflowStore(t.TempDir())
+	require.NoError(t, other.Load())
+	w, err := other.Install(buf.Bytes(), "", InstallOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "shared", w.Name)
+	require.Len(t, w.Steps, 2)
+	assert.Equal(t, StepTypeShell, w.Steps[1].Kind())
+	assert.Equal(t, "make test", w.Steps[1].Command)
+
+	assert.Error(t, store.Export("missing", &buf))
+}
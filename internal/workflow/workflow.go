@@ -11,11 +11,28 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// StepType selects the runner used for a step
+type StepType string
+
+const (
+	StepTypeAgent StepType = "agent" // Claude CLI prompt (default)
+	StepTypeShell StepType = "shell" // Arbitrary shell command
+	StepTypeHTTP  StepType = "http"  // HTTP request
+	StepTypeWait  StepType = "wait"  // Manual approval gate
+)
+
 // StepDefinition defines a single step in a workflow
 type StepDefinition struct {
 	Name           string            `yaml:"name"`
+	Type           StepType          `yaml:"type,omitempty"` // Runner kind (default: agent)
 	Description    string            `yaml:"description,omitempty"`
-	PromptTemplate string            `yaml:"prompt_template"`
+	PromptTemplate string            `yaml:"prompt_template,omitempty"`
+	Command        string            `yaml:"command,omitempty"`       // Shell command (shell steps)
+	URL            string            `yaml:"url,omitempty"`           // Request URL template (http steps)
+	Method         string            `yaml:"method,omitempty"`        // HTTP method (default: GET, or POST with a body)
+	Headers        map[string]string `yaml:"headers,omitempty"`       // Request headers (http steps)
+	Body           string            `yaml:"body,omitempty"`          // Request body template (http steps)
+	Message        string            `yaml:"message,omitempty"`       // Text shown while waiting (wait steps)
 	Timeout        int               `yaml:"timeout,omitempty"`       // Override default timeout (seconds)
 	Retries        int               `yaml:"retries,omitempty"`       // Override default retries
 	SkipIf         string            `yaml:"skip_if,omitempty"`       // Condition: "file_exists"
@@ -25,6 +42,41 @@ type StepDefinition struct {
 	StepName       domain.StepName   `yaml:"-"`                       // Mapped step name for domain integration
 }
 
+// Kind returns the step type, treating an empty type as agent
+func (s *StepDefinition) Kind() StepType {
+	if s.Type == "" {
+		return StepTypeAgent
+	}
+	return StepType(strings.ToLower(string(s.Type)))
+}
+
+// Validate checks that the step has the fields its type needs
+func (s *StepDefinition) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("step name is required")
+	}
+
+	switch s.Kind() {
+	case StepTypeAgent:
+		if strings.TrimSpace(s.PromptTemplate) == "" {
+			return fmt.Errorf("agent step %q requires prompt_template", s.Name)
+		}
+	case StepTypeShell:
+		if strings.TrimSpace(s.Command) == "" {
+			return fmt.Errorf("shell step %q requires command", s.Name)
+		}
+	case StepTypeHTTP:
+		if strings.TrimSpace(s.URL) == "" {
+			return fmt.Errorf("http step %q requires url", s.Name)
+		}
+	case StepTypeWait:
+	default:
+		return fmt.Errorf("step %q has unknown type %q", s.Name, s.Type)
+	}
+
+	return nil
+}
+
 // Workflow defines a complete workflow with multiple steps
 type Workflow struct {
 	Name        string            `yaml:"name"`
@@ -82,15 +134,28 @@ func (ws *WorkflowStore) loadWorkflow(path string) (*Workflow, error) {
 		return nil, err
 	}
 
+	// Use filename as name if not specified
+	base := filepath.Base(path)
+	return Parse(data, base[:len(base)-5]) // Remove .yaml extension
+}
+
+// Parse decodes and validates a workflow from YAML. defaultName is used
+// when the YAML does not set a name.
+func Parse(data []byte, defaultName string) (*Workflow, error) {
 	var workflow Workflow
 	if err := yaml.Unmarshal(data, &workflow); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid workflow YAML: %w", err)
 	}
 
-	// Use filename as name if not specified
 	if workflow.Name == "" {
-		base := filepath.Base(path)
-		workflow.Name = base[:len(base)-5] // Remove .yaml extension
+		workflow.Name = defaultName
+	}
+	if workflow.Name == "" {
+		return nil, fmt.Errorf("workflow name is required")
+	}
+
+	if err := workflow.Validate(); err != nil {
+		return nil, err
 	}
 
 	// Map step names to domain step names
@@ -101,6 +166,29 @@ func (ws *WorkflowStore) loadWorkflow(path string) (*Workflow, error) {
 	return &workflow, nil
 }
 
+// Validate checks every step of the workflow
+func (w *Workflow) Validate() error {
+	if len(w.Steps) == 0 {
+		return fmt.Errorf("workflow %q has no steps", w.Name)
+	}
+	for i, step := range w.Steps {
+		if err := step.Validate(); err != nil {
+			return fmt.Errorf("workflow %q step %d: %w", w.Name, i+1, err)
+		}
+	}
+	return nil
+}
+
+// Step returns the definition of the step mapped to name, or nil
+func (w *Workflow) Step(name domain.StepName) *StepDefinition {
+	for _, step := range w.Steps {
+		if step.StepName == name || mapStepName(step.Name) == name {
+			return step
+		}
+	}
+	return nil
+}
+
 // mapStepName converts a string step name to domain.StepName
 func mapStepName(name string) domain.StepName {
 	switch strings.ToLower(name) {
@@ -194,16 +282,49 @@ type StoryContext struct {
 	FileExists bool
 }
 
+// NewTemplateContext builds the template context for a story
+func NewTemplateContext(story domain.Story, storyDir, workDir string, variables map[string]string) *TemplateContext {
+	return &TemplateContext{
+		Story: StoryContext{
+			Key:        story.Key,
+			Epic:       story.Epic,
+			Status:     string(story.Status),
+			Title:      story.Title,
+			FilePath:   story.FilePath,
+			FileExists: story.FileExists,
+		},
+		StoryDir:  storyDir,
+		StoryPath: filepath.Join(storyDir, story.Key+".md"),
+		WorkDir:   workDir,
+		Variables: variables,
+	}
+}
+
 // RenderPrompt renders a step's prompt template with the given context
 func (s *StepDefinition) RenderPrompt(ctx *TemplateContext) (string, error) {
-	tmpl, err := template.New("prompt").Parse(s.PromptTemplate)
+	return render("prompt", s.PromptTemplate, ctx)
+}
+
+// RenderURL renders an http step's URL template with the given context
+func (s *StepDefinition) RenderURL(ctx *TemplateContext) (string, error) {
+	return render("url", s.URL, ctx)
+}
+
+// RenderBody renders an http step's body template with the given context
+func (s *StepDefinition) RenderBody(ctx *TemplateContext) (string, error) {
+	return render("body", s.Body, ctx)
+}
+
+// render executes text as a template against ctx
+func render(name, text string, ctx *TemplateContext) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse prompt template: %w", err)
+		return "", fmt.Errorf("failed to parse %s template: %w", name, err)
 	}
 
 	var buf strings.Builder
 	if err := tmpl.Execute(&buf, ctx); err != nil {
-		return "", fmt.Errorf("failed to render prompt template: %w", err)
+		return "", fmt.Errorf("failed to render %s template: %w", name, err)
 	}
 
 	return buf.String(), nil
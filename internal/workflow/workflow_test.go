@@ -419,6 +419,111 @@ func TestStepDefinition_RenderPrompt(t *testing.T) {
 	})
 }
 
+func TestStepDefinition_Kind(t *testing.T) {
+	assert.Equal(t, StepTypeAgent, (&StepDefinition{}).Kind())
+	assert.Equal(t, StepTypeShell, (&StepDefinition{Type: "shell"}).Kind())
+	assert.Equal(t, StepTypeHTTP, (&StepDefinition{Type: "HTTP"}).Kind())
+}
+
+func TestStepDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    StepDefinition
+		wantErr string
+	}{
+		{"agent with prompt", StepDefinition{Name: "dev", PromptTemplate: "Work"}, ""},
+		{"agent without prompt", StepDefinition{Name: "dev"}, "requires prompt_template"},
+		{"shell with command", StepDefinition{Name: "tests", Type: StepTypeShell, Command: "go test ./..."}, ""},
+		{"shell without command", StepDefinition{Name: "tests", Type: StepTypeShell}, "requires command"},
+		{"http with url", StepDefinition{Name: "ping", Type: StepTypeHTTP, URL: "http://example.com"}, ""},
+		{"http without url", StepDefinition{Name: "ping", Type: StepTypeHTTP}, "requires url"},
+		{"wait", StepDefinition{Name: "approve", Type: StepTypeWait}, ""},
+		{"unknown type", StepDefinition{Name: "x", Type: "ftp"}, "unknown type"},
+		{"missing name", StepDefinition{PromptTemplate: "Work"}, "name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.step.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWorkflowStore_LoadStepTypes(t *testing.T) {
+	tempDir := t.TempDir()
+	workflowDir := filepath.Join(tempDir, "workflows")
+	require.NoError(t, os.MkdirAll(workflowDir, 0755))
+
+	pipeline := `name: pipeline
+steps:
+  - name: dev-story
+    prompt_template: "Work on {{.Story.Key}}"
+  - name: tests
+    type: shell
+    command: go test ./...
+  - name: approve
+    type: wait
+    message: Check the build
+  - name: notify
+    type: http
+    url: "http://localhost/{{.Story.Key}}"
+`
+	invalid := `name: broken
+steps:
+  - name: tests
+    type: shell
+`
+	require.NoError(t, os.WriteFile(filepath.Join(workflowDir, "pipeline.yaml"), []byte(pipeline), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(workflowDir, "broken.yaml"), []byte(invalid), 0644))
+
+	store := NewWorkflowStore(tempDir)
+	require.NoError(t, store.Load())
+
+	_, ok := store.Get("broken")
+	assert.False(t, ok, "invalid workflows are skipped")
+
+	w, ok := store.Get("pipeline")
+	require.True(t, ok)
+	require.Len(t, w.Steps, 4)
+	assert.Equal(t, StepTypeShell, w.Steps[1].Kind())
+	assert.Equal(t, "Check the build", w.Steps[2].Message)
+
+	assert.Equal(t, w.Steps[0], w.Step(domain.StepDevStory))
+	assert.Equal(t, w.Steps[1], w.Step("tests"))
+	assert.Nil(t, w.Step(domain.StepGitCommit))
+}
+
+func TestNewTemplateContext(t *testing.T) {
+	story := domain.Story{Key: "3-1-test", Epic: 3, Status: domain.StatusInProgress, Title: "Test"}
+
+	ctx := NewTemplateContext(story, "/stories", "/work", map[string]string{"a": "b"})
+
+	assert.Equal(t, "3-1-test", ctx.Story.Key)
+	assert.Equal(t, 3, ctx.Story.Epic)
+	assert.Equal(t, filepath.Join("/stories", "3-1-test.md"), ctx.StoryPath)
+	assert.Equal(t, "/work", ctx.WorkDir)
+	assert.Equal(t, "b", ctx.Variables["a"])
+}
+
+func TestStepDefinition_RenderURLAndBody(t *testing.T) {
+	step := &StepDefinition{URL: "http://ci/{{.Story.Key}}", Body: `{"epic":{{.Story.Epic}}}`}
+	ctx := &TemplateContext{Story: StoryContext{Key: "3-1-test", Epic: 3}}
+
+	url, err := step.RenderURL(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "http://ci/3-1-test", url)
+
+	body, err := step.RenderBody(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, `{"epic":3}`, body)
+}
+
 func TestCreateExampleWorkflow(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -0,0 +1,99 @@
+// Package bmad embeds BMAD Automate in other Go programs: load stories from
+// the sprint status file (or Jira or GitHub), run them through the BMAD
+// workflow one at a time or as a queue, and read the recorded history,
+// without starting the TUI or shelling out to the bmad binary.
+//
+// The types are aliases of the ones the TUI uses, so values pass between
+// this package and its callbacks unchanged. Because of that, the fields of
+// Config, Story, Execution and the other aliased types follow the internal
+// packages and may change in any release; pin a version when embedding.
+//
+// A minimal run of one story:
+//
+//	cfg := bmad.NewConfig("/path/to/project")
+//	stories, err := bmad.LoadStories(ctx, cfg)
+//	...
+//	runner := bmad.NewRunner(cfg)
+//	execution, err := runner.Run(ctx, stories[0])
+package bmad
+
+import (
+	"path/filepath"
+
+	"github.com/robertguss/bmad-automate-go/internal/config"
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+)
+
+// Config holds paths, timeouts, retries and integrations. Start from
+// NewConfig and change fields before creating a Runner or Queue.
+type Config = config.Config
+
+// Story is one story of the sprint
+type Story = domain.Story
+
+// StoryStatus is a story's development status
+type StoryStatus = domain.StoryStatus
+
+// Story statuses
+const (
+	StatusBacklog     = domain.StatusBacklog
+	StatusReadyForDev = domain.StatusReadyForDev
+	StatusInProgress  = domain.StatusInProgress
+	StatusDone        = domain.StatusDone
+	StatusBlocked     = domain.StatusBlocked
+)
+
+// Execution is one run of a story through the workflow
+type Execution = domain.Execution
+
+// ExecutionStatus is the outcome of an Execution
+type ExecutionStatus = domain.ExecutionStatus
+
+// Execution statuses
+const (
+	ExecutionRunning   = domain.ExecutionRunning
+	ExecutionCompleted = domain.ExecutionCompleted
+	ExecutionFailed    = domain.ExecutionFailed
+	ExecutionCancelled = domain.ExecutionCancelled
+	ExecutionConflict  = domain.ExecutionConflict
+)
+
+// StepExecution is one step of an Execution
+type StepExecution = domain.StepExecution
+
+// StepName names a workflow step
+type StepName = domain.StepName
+
+// The steps of the built-in workflow
+const (
+	StepCreateStory = domain.StepCreateStory
+	StepDevStory    = domain.StepDevStory
+	StepCodeReview  = domain.StepCodeReview
+	StepGitCommit   = domain.StepGitCommit
+)
+
+// StepStatus is the outcome of a step
+type StepStatus = domain.StepStatus
+
+// Step statuses
+const (
+	StepSuccess = domain.StepSuccess
+	StepFailed  = domain.StepFailed
+	StepSkipped = domain.StepSkipped
+)
+
+// NewConfig returns the configuration for the BMAD project in dir, with
+// the defaults and BMAD_* environment variables the TUI would use there.
+// An empty dir means the working directory.
+func NewConfig(dir string) *Config {
+	cfg := config.New()
+	if dir == "" {
+		return cfg
+	}
+	cfg.WorkingDir = dir
+	cfg.SprintStatusPath = filepath.Join(dir, config.DefaultSprintStatus)
+	cfg.StoryDir = filepath.Join(dir, config.DefaultStoryDir)
+	cfg.DataDir = filepath.Join(dir, config.DefaultDataDir)
+	cfg.DatabasePath = filepath.Join(cfg.DataDir, config.DefaultDBName)
+	return cfg
+}
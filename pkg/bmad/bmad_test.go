@@ -0,0 +1,73 @@
+package bmad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+func TestEventSink(t *testing.T) {
+	var events []Event
+	var sink eventSink
+	sink.setHandler(func(e Event) { events = append(events, e) })
+
+	ref := messages.StepRef{ExecutionID: "e1"}
+	sink.handle(messages.ExecutionStartedMsg{Execution: &domain.Execution{ID: "e1", Story: domain.Story{Key: "3-1-auth"}}})
+	sink.handle(messages.StepStartedMsg{StepRef: ref, StepName: domain.StepDevStory, Attempt: 1, Command: "claude ..."})
+	sink.handle(messages.StepOutputMsg{StepRef: ref, Line: "building", IsStderr: true})
+	sink.handle(messages.ExecutionTickMsg{Time: time.Now()})
+	sink.handle(messages.StepCompletedMsg{StepRef: ref, Status: domain.StepSuccess, Duration: time.Minute})
+	sink.handle(messages.ErrorMsg{Error: errors.New("badge not written")})
+	sink.handle(messages.ExecutionCompletedMsg{Status: domain.ExecutionCompleted, Duration: 2 * time.Minute})
+
+	require.Len(t, events, 6, "ticks are not passed on")
+	assert.Equal(t, Event{Kind: EventStoryStarted, Story: "3-1-auth"}, events[0])
+	assert.Equal(t, Event{Kind: EventStepStarted, Story: "3-1-auth", Step: StepDevStory, Attempt: 1, Command: "claude ..."}, events[1])
+	assert.Equal(t, Event{Kind: EventStepOutput, Story: "3-1-auth", Step: StepDevStory, Line: "building", Stderr: true}, events[2],
+		"output is attributed to the step that started last")
+	assert.Equal(t, Event{Kind: EventStepCompleted, Story: "3-1-auth", Step: StepDevStory, Status: "success", Duration: time.Minute}, events[3])
+	assert.Equal(t, Event{Kind: EventWarning, Story: "3-1-auth", Message: "badge not written"}, events[4])
+	assert.Equal(t, Event{Kind: EventStoryCompleted, Story: "3-1-auth", Status: "completed", Duration: 2 * time.Minute}, events[5])
+}
+
+func TestNewConfig(t *testing.T) {
+	cfg := NewConfig("/work/app")
+	assert.Equal(t, "/work/app", cfg.WorkingDir)
+	assert.Equal(t, "/work/app/.bmad/bmad.db", cfg.DatabasePath)
+	assert.Equal(t, "/work/app/_bmad-output/implementation-artifacts/sprint-status.yaml", cfg.SprintStatusPath)
+}
+
+func TestRunner_Run(t *testing.T) {
+	dir := t.TempDir()
+	cfg := NewConfig(dir)
+	cfg.WorkspaceSnapshots = false
+
+	runner := NewRunner(cfg)
+	runner.SetWorkflow(&Workflow{Name: "echo", Steps: []*workflow.StepDefinition{
+		{Name: "say", StepName: "say", Type: workflow.StepTypeShell, Command: "echo hello"},
+	}})
+	var lines []string
+	runner.OnEvent(func(e Event) {
+		if e.Kind == EventStepOutput {
+			lines = append(lines, e.Line)
+		}
+	})
+
+	execution, err := runner.Run(context.Background(), Story{Key: "1-1-echo", Epic: 1})
+	require.NoError(t, err)
+	assert.Equal(t, ExecutionCompleted, execution.Status)
+	assert.Equal(t, []string{"hello"}, lines)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = runner.Run(ctx, Story{Key: "1-1-echo", Epic: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,109 @@
+package bmad
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// EventKind says what an Event reports
+type EventKind string
+
+const (
+	EventStoryStarted   EventKind = "story.started"
+	EventStoryCompleted EventKind = "story.completed"
+	EventStepStarted    EventKind = "step.started"
+	EventStepOutput     EventKind = "step.output"
+	EventStepCompleted  EventKind = "step.completed"
+	EventStepStalled    EventKind = "step.stalled" // No output for the stall timeout
+	EventStepWaiting    EventKind = "step.waiting" // A wait step needs Resume or Cancel
+	EventWarning        EventKind = "warning"      // Something went wrong that did not stop the run
+)
+
+// Event reports progress of a run. Fields that do not apply to its Kind
+// are zero.
+type Event struct {
+	Kind     EventKind
+	Story    string   // Key of the story
+	Step     StepName // Step events
+	Attempt  int      // EventStepStarted, from 1
+	Command  string   // EventStepStarted, as logged
+	Line     string   // EventStepOutput
+	Stderr   bool     // EventStepOutput
+	Status   string   // A StepStatus for step events, an ExecutionStatus for stories
+	Duration time.Duration
+	Error    string
+	Message  string // EventStepWaiting's prompt, EventWarning's text
+}
+
+// eventSink turns executor messages into Events for a handler
+type eventSink struct {
+	mu      sync.Mutex
+	handler func(Event)
+	story   string   // Story of the run in progress
+	step    StepName // Step of the run in progress
+}
+
+func (s *eventSink) setHandler(fn func(Event)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handler = fn
+}
+
+// handle is the executor's message handler. It is called from the run's
+// goroutine; the handler runs without the lock held so it may call back
+// into the Runner or Queue.
+func (s *eventSink) handle(msg tea.Msg) {
+	s.mu.Lock()
+	event, ok := s.event(msg)
+	handler := s.handler
+	s.mu.Unlock()
+	if ok && handler != nil {
+		handler(event)
+	}
+}
+
+// event converts msg, tracking the story and step that later messages
+// leave out
+func (s *eventSink) event(msg tea.Msg) (Event, bool) {
+	switch msg := msg.(type) {
+	case messages.ExecutionStartedMsg:
+		s.story = msg.Execution.Story.Key
+		return Event{Kind: EventStoryStarted, Story: s.story}, true
+	case messages.ExecutionCompletedMsg:
+		return Event{Kind: EventStoryCompleted, Story: s.story, Status: string(msg.Status),
+			Duration: msg.Duration, Error: msg.Error}, true
+	case messages.StepStartedMsg:
+		s.step = msg.StepName
+		return Event{Kind: EventStepStarted, Story: s.storyOf(msg.StepRef), Step: msg.StepName,
+			Attempt: msg.Attempt, Command: msg.Command}, true
+	case messages.StepOutputMsg:
+		return Event{Kind: EventStepOutput, Story: s.storyOf(msg.StepRef), Step: s.step,
+			Line: msg.Line, Stderr: msg.IsStderr}, true
+	case messages.StepCompletedMsg:
+		return Event{Kind: EventStepCompleted, Story: s.storyOf(msg.StepRef), Step: s.step,
+			Status: string(msg.Status), Duration: msg.Duration, Error: msg.Error}, true
+	case messages.StepStalledMsg:
+		return Event{Kind: EventStepStalled, Story: s.storyOf(msg.StepRef), Step: msg.StepName,
+			Duration: msg.Idle}, true
+	case messages.StepWaitingMsg:
+		return Event{Kind: EventStepWaiting, Story: s.storyOf(msg.StepRef), Step: msg.StepName,
+			Message: msg.Message}, true
+	case messages.ErrorMsg:
+		if msg.Error == nil {
+			return Event{}, false
+		}
+		return Event{Kind: EventWarning, Story: s.story, Message: msg.Error.Error()}, true
+	}
+	return Event{}, false
+}
+
+func (s *eventSink) storyOf(ref messages.StepRef) string {
+	if ref.StoryKey != "" {
+		return ref.StoryKey
+	}
+	return s.story
+}
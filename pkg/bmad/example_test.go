@@ -0,0 +1,111 @@
+package bmad_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/robertguss/bmad-automate-go/pkg/bmad"
+)
+
+// Run the next story that is ready, printing its output as it goes
+func ExampleRunner() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cfg := bmad.NewConfig("/path/to/project")
+	cfg.Timeout = 1200 // Seconds per step
+
+	stories, err := bmad.LoadStories(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ready := bmad.FilterStories(stories, "status:ready-for-dev")
+	if len(ready) == 0 {
+		return
+	}
+
+	runner := bmad.NewRunner(cfg)
+	runner.OnEvent(func(e bmad.Event) {
+		switch e.Kind {
+		case bmad.EventStepStarted:
+			fmt.Println("==>", e.Step)
+		case bmad.EventStepOutput:
+			fmt.Println(e.Line)
+		case bmad.EventStepWaiting:
+			runner.Resume() // Approve wait steps
+		}
+	})
+
+	execution, err := runner.Run(ctx, ready[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(execution.Story.Key, execution.Status, execution.Duration)
+}
+
+// Run an epic as a queue with a custom workflow and record the results
+func ExampleQueue() {
+	ctx := context.Background()
+	cfg := bmad.NewConfig("/path/to/project")
+
+	stories, err := bmad.LoadStories(ctx, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	w, err := bmad.LoadWorkflow(cfg, "quick-dev")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	queue := bmad.NewQueue(cfg)
+	queue.SetWorkflow(w)
+	queue.Add(bmad.FilterStories(stories, "epic:3 status:ready-for-dev")...)
+	queue.OnEvent(func(e bmad.Event) {
+		if e.Kind == bmad.EventStoryCompleted {
+			fmt.Printf("%s %s in %s\n", e.Story, e.Status, e.Duration)
+		}
+	})
+
+	result, err := queue.Run(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	history, err := bmad.OpenHistory(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer history.Close()
+	for _, execution := range result.Executions {
+		if execution != nil {
+			if err := history.SaveExecution(ctx, execution); err != nil {
+				log.Print(err)
+			}
+		}
+	}
+	fmt.Printf("%d succeeded, %d failed\n", result.Succeeded, result.Failed)
+}
+
+// List the failed runs of the last week
+func ExampleOpenHistory() {
+	ctx := context.Background()
+	history, err := bmad.OpenHistory(bmad.NewConfig(""))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer history.Close()
+
+	records, err := history.ListExecutions(ctx, &bmad.ExecutionFilter{
+		Status: bmad.ExecutionFailed,
+		Limit:  20,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, r := range records {
+		fmt.Println(r.StoryKey, r.StartTime.Format("Jan 2 15:04"), r.Error)
+	}
+}
@@ -0,0 +1,42 @@
+package bmad
+
+import (
+	"fmt"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/storage"
+)
+
+// History is the execution history the TUI shows, stored in SQLite
+type History = storage.Storage
+
+// ExecutionRecord is an execution as stored in History
+type ExecutionRecord = storage.ExecutionRecord
+
+// StepRecord is a step of an ExecutionRecord
+type StepRecord = storage.StepRecord
+
+// ExecutionFilter narrows History.ListExecutions
+type ExecutionFilter = storage.ExecutionFilter
+
+// Stats are aggregate statistics over History
+type Stats = storage.Stats
+
+// DateRange limits History.GetStats to executions started within it; the
+// zero DateRange is all time
+type DateRange = domain.DateRange
+
+// OpenHistory opens the history database of cfg, creating it when needed.
+// Close it when done.
+func OpenHistory(cfg *Config) (History, error) {
+	if err := cfg.EnsureDataDir(); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	store, err := storage.NewSQLiteStorage(cfg.DatabasePath)
+	if err != nil {
+		return nil, err
+	}
+	store.SetOutputDir(cfg.OutputDir())
+	store.SetRepoDir(cfg.WorkingDir)
+	return store, nil
+}
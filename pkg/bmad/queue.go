@@ -0,0 +1,126 @@
+package bmad
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/domain"
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+)
+
+// Queue runs stories one after another, in the order set by cfg.QueueOrder
+// and within cfg's run windows, the way the TUI's queue does. A story that
+// fails does not stop the ones after it.
+type Queue struct {
+	batch *executor.BatchExecutor
+	sink  eventSink
+}
+
+// QueueResult summarizes a finished queue
+type QueueResult struct {
+	Executions []*Execution // In queue order; nil for stories that did not start
+	Succeeded  int
+	Failed     int
+	Conflicts  int // Parked with unresolved merge conflicts
+	Duration   time.Duration
+}
+
+// NewQueue creates an empty Queue for the built-in workflow
+func NewQueue(cfg *Config) *Queue {
+	q := &Queue{batch: executor.NewBatchExecutor(cfg)}
+	q.batch.SetMessageHandler(func(msg tea.Msg) { q.sink.handle(msg) })
+	return q
+}
+
+// SetWorkflow runs the queue's stories through w instead of the built-in
+// workflow
+func (q *Queue) SetWorkflow(w *Workflow) {
+	q.batch.SetWorkflow(w)
+}
+
+// OnEvent calls fn with the progress of each story. fn is called from the
+// queue's goroutine and should return quickly; it may call Resume or Cancel.
+func (q *Queue) OnEvent(fn func(Event)) {
+	q.sink.setHandler(fn)
+}
+
+// Add appends stories the queue does not already hold
+func (q *Queue) Add(stories ...Story) {
+	q.batch.AddToQueue(stories)
+}
+
+// Tag sets the tags the queued story with key is recorded with in History,
+// replacing any it had. It reports whether the story is queued.
+func (q *Queue) Tag(key string, tags ...string) bool {
+	return q.batch.SetTags(key, domain.ParseTags(strings.Join(tags, ",")))
+}
+
+// Remove takes the story with key out of the queue
+func (q *Queue) Remove(key string) bool {
+	return q.batch.RemoveFromQueue(key)
+}
+
+// Len returns how many stories are queued, finished ones included
+func (q *Queue) Len() int {
+	return q.batch.GetQueue().TotalCount()
+}
+
+// Run runs the pending stories and returns once none are left or the
+// queue is cancelled. Cancelling ctx cancels the queue. Stories added while
+// it runs are picked up.
+func (q *Queue) Run(ctx context.Context) (*QueueResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, q.batch.Cancel)
+	defer stop()
+
+	q.batch.Start()()
+
+	queue := q.batch.GetQueue()
+	result := &QueueResult{
+		Succeeded: queue.CompletedCount(),
+		Failed:    queue.FailedCount(),
+		Conflicts: queue.ConflictCount(),
+	}
+	if !queue.StartTime.IsZero() {
+		result.Duration = time.Since(queue.StartTime)
+	}
+	for _, item := range queue.Items {
+		result.Executions = append(result.Executions, item.Execution)
+	}
+	return result, nil
+}
+
+// IsRunning reports whether Run is in progress
+func (q *Queue) IsRunning() bool {
+	return q.batch.IsRunning()
+}
+
+// Pause pauses the queue at the next step boundary
+func (q *Queue) Pause() {
+	q.batch.Pause()
+}
+
+// Resume continues a paused queue, or a story waiting at a wait step
+func (q *Queue) Resume() {
+	q.batch.Resume()
+}
+
+// Cancel stops the queue, killing the running step. Stories not yet run
+// stay pending.
+func (q *Queue) Cancel() {
+	q.batch.Cancel()
+}
+
+// Pending returns the stories not yet run
+func (q *Queue) Pending() []Story {
+	var stories []Story
+	for _, item := range q.batch.GetQueue().GetPending() {
+		stories = append(stories, item.Story)
+	}
+	return stories
+}
@@ -0,0 +1,90 @@
+package bmad
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/robertguss/bmad-automate-go/internal/executor"
+	"github.com/robertguss/bmad-automate-go/internal/messages"
+)
+
+// Runner runs one story at a time through the workflow, the way the TUI's
+// Enter and bmad run do. A story already running elsewhere in the process
+// is refused.
+type Runner struct {
+	exec *executor.Executor
+	sink eventSink
+
+	mu  sync.Mutex
+	ctx context.Context // Of the Run in progress
+}
+
+// NewRunner creates a Runner for the built-in workflow
+func NewRunner(cfg *Config) *Runner {
+	r := &Runner{exec: executor.New(cfg)}
+	r.exec.SetMessageHandler(r.handle)
+	return r
+}
+
+// SetWorkflow runs later stories through w instead of the built-in workflow
+func (r *Runner) SetWorkflow(w *Workflow) {
+	r.exec.SetWorkflow(w)
+}
+
+// OnEvent calls fn with the progress of each run. fn is called from the
+// run's goroutine and should return quickly; it may call Resume or Cancel.
+func (r *Runner) OnEvent(fn func(Event)) {
+	r.sink.setHandler(fn)
+}
+
+// Run runs story and returns its execution once it finishes, whatever its
+// status. Cancelling ctx cancels the run, which still returns the
+// execution. The error is only set when the run could not start.
+func (r *Runner) Run(ctx context.Context, story Story) (*Execution, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	r.ctx = ctx
+	r.mu.Unlock()
+	stop := context.AfterFunc(ctx, r.exec.Cancel)
+	defer stop()
+
+	result := r.exec.Execute(story)()
+	if msg, ok := result.(messages.ErrorMsg); ok {
+		return nil, msg.Error
+	}
+	r.sink.handle(result)
+	return r.exec.GetExecution(), nil
+}
+
+// Pause pauses the run at the next step boundary
+func (r *Runner) Pause() {
+	r.exec.Pause()
+}
+
+// Resume continues a paused run, or one waiting at a wait step
+func (r *Runner) Resume() {
+	r.exec.Resume()
+}
+
+// Cancel stops the run, killing the running step
+func (r *Runner) Cancel() {
+	r.exec.Cancel()
+}
+
+// handle passes the executor's messages on as Events. A run whose context
+// ended before it got going is cancelled as soon as it starts.
+func (r *Runner) handle(msg tea.Msg) {
+	if _, ok := msg.(messages.ExecutionStartedMsg); ok {
+		r.mu.Lock()
+		ctx := r.ctx
+		r.mu.Unlock()
+		if ctx != nil && ctx.Err() != nil {
+			defer r.exec.Cancel()
+		}
+	}
+	r.sink.handle(msg)
+}
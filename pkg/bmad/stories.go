@@ -0,0 +1,48 @@
+package bmad
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/robertguss/bmad-automate-go/internal/parser"
+	"github.com/robertguss/bmad-automate-go/internal/workflow"
+)
+
+// Workflow is a named list of steps a story runs through
+type Workflow = workflow.Workflow
+
+// LoadStories returns the stories of cfg's story source, sorted by epic and
+// story number
+func LoadStories(ctx context.Context, cfg *Config) ([]Story, error) {
+	return parser.LoadStories(ctx, cfg)
+}
+
+// FilterStories returns the stories matching query, in the syntax of the
+// story list's select-by-query prompt, e.g. "epic:3 status:ready-for-dev"
+func FilterStories(stories []Story, query string) []Story {
+	return parser.FilterStoriesByQuery(stories, query)
+}
+
+// FindStory returns the story with key
+func FindStory(stories []Story, key string) (Story, bool) {
+	for _, s := range stories {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Story{}, false
+}
+
+// LoadWorkflow returns the built-in or custom workflow called name, from
+// the workflows saved in cfg's data directory
+func LoadWorkflow(cfg *Config, name string) (*Workflow, error) {
+	store := workflow.NewWorkflowStore(cfg.DataDir)
+	if err := store.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load workflows: %w", err)
+	}
+	w, ok := store.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("workflow %q not found", name)
+	}
+	return w, nil
+}
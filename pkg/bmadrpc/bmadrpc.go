@@ -0,0 +1,147 @@
+// Package bmadrpc is the gRPC control API of BMAD Automate: the wire types,
+// the service description and a typed client. The API serves queue
+// management, execution control and a stream of step output.
+//
+// Messages are encoded as JSON rather than protocol buffers, under the
+// "bmad-json" content subtype, so there is no .proto file to compile. Go
+// tools use Client; clients in other languages send the content type
+// application/grpc+bmad-json, with each message in the usual gRPC framing
+// (a compression flag byte and a 4-byte big-endian length) holding the
+// UTF-8 JSON object of the type below, keyed by its json tags. Durations
+// are integer nanoseconds and times RFC 3339 strings.
+//
+// The codec is registered with grpc under that subtype when this package
+// is imported. The subtype is specific to this API, so codecs registered
+// by the importing program, including one for "json", are left alone.
+package bmadrpc
+
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// ServiceName is the full gRPC service name
+const ServiceName = "bmad.v1.Control"
+
+// CodecName is the content subtype messages are encoded with
+const CodecName = "bmad-json"
+
+// Codec encodes messages as JSON
+type Codec struct{}
+
+// Marshal encodes v as JSON
+func (Codec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+// Unmarshal decodes JSON into v
+func (Codec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// Name returns CodecName
+func (Codec) Name() string { return CodecName }
+
+func init() {
+	encoding.RegisterCodec(Codec{})
+}
+
+// Empty is the request or reply of calls that carry nothing
+type Empty struct{}
+
+// Story is a story from the sprint status
+type Story struct {
+	Key    string `json:"key"`
+	Epic   int    `json:"epic"`
+	Status string `json:"status"`
+	Title  string `json:"title,omitempty"`
+}
+
+// ListStoriesRequest filters the stories returned; zero values match all
+type ListStoriesRequest struct {
+	Epic   int    `json:"epic,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// ListStoriesReply holds the matching stories in sprint status order
+type ListStoriesReply struct {
+	Stories []Story `json:"stories"`
+}
+
+// QueueItem is one story in the queue
+type QueueItem struct {
+	Story       Story     `json:"story"`
+	Status      string    `json:"status"`
+	Position    int       `json:"position"`
+	AddedAt     time.Time `json:"added_at"`
+	Deadline    time.Time `json:"deadline,omitzero"`
+	ExecutionID string    `json:"execution_id,omitempty"`
+}
+
+// Queue is the queue and its progress
+type Queue struct {
+	Status  string        `json:"status"`
+	Current int           `json:"current"` // Index of the running item, -1 if none
+	Items   []QueueItem   `json:"items"`
+	Pending int           `json:"pending"`
+	ETA     time.Duration `json:"eta"` // Estimated time until the queue finishes
+}
+
+// KeysRequest names stories by key
+type KeysRequest struct {
+	Keys []string `json:"keys"`
+}
+
+// KeyRequest names one story by key
+type KeyRequest struct {
+	Key string `json:"key"`
+}
+
+// Directions for MoveRequest
+const (
+	MoveUp   = "up"
+	MoveDown = "down"
+)
+
+// MoveRequest moves the pending item at Index one place up or down
+type MoveRequest struct {
+	Index     int    `json:"index"`
+	Direction string `json:"direction"`
+}
+
+// Step is one step of an execution
+type Step struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Attempt  int           `json:"attempt"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Execution is the execution in progress, or the last one. Running is
+// false and ID empty when nothing has run yet.
+type Execution struct {
+	ID        string    `json:"id,omitempty"`
+	Running   bool      `json:"running"`
+	Status    string    `json:"status,omitempty"`
+	Story     Story     `json:"story"`
+	Current   int       `json:"current"`
+	Steps     []Step    `json:"steps,omitempty"`
+	StartTime time.Time `json:"start_time,omitzero"`
+	Progress  float64   `json:"progress"` // Percent of steps done
+}
+
+// StreamOutputRequest selects the output to stream: one execution's, or
+// every execution's when ExecutionID is empty
+type StreamOutputRequest struct {
+	ExecutionID string `json:"execution_id,omitempty"`
+}
+
+// OutputLine is a line written by a step
+type OutputLine struct {
+	ExecutionID string    `json:"execution_id"`
+	StoryKey    string    `json:"story_key"`
+	StepIndex   int       `json:"step_index"`
+	StepName    string    `json:"step_name,omitempty"`
+	Line        string    `json:"line"`
+	IsStderr    bool      `json:"is_stderr"`
+	Time        time.Time `json:"time"`
+}
@@ -0,0 +1,164 @@
+package bmadrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// APIKeyHeader is the metadata key the API key is sent in. A bearer token
+// in "authorization" is accepted too.
+const APIKeyHeader = "x-api-key"
+
+// Client calls the Control service
+type Client struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewClient wraps an existing connection
+func NewClient(cc grpc.ClientConnInterface) *Client {
+	return &Client{cc: cc}
+}
+
+// Dial connects to a bmad gRPC server such as "localhost:8081" over plain
+// TCP, sending apiKey with every call when it is set. Close the returned
+// connection when done.
+func Dial(target, apiKey string, opts ...grpc.DialOption) (*Client, *grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	if apiKey != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(apiKeyCredentials(apiKey)))
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewClient(conn), conn, nil
+}
+
+// apiKeyCredentials sends the API key as call metadata
+type apiKeyCredentials string
+
+func (k apiKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{APIKeyHeader: string(k)}, nil
+}
+
+// RequireTransportSecurity is false, as the server listens on plain TCP
+func (apiKeyCredentials) RequireTransportSecurity() bool { return false }
+
+func (c *Client) invoke(ctx context.Context, method string, in, out any) error {
+	return c.cc.Invoke(ctx, "/"+ServiceName+"/"+method, in, out, grpc.CallContentSubtype(CodecName))
+}
+
+// ListStories returns the stories, optionally of one epic or status
+func (c *Client) ListStories(ctx context.Context, req *ListStoriesRequest) ([]Story, error) {
+	out := new(ListStoriesReply)
+	if err := c.invoke(ctx, "ListStories", req, out); err != nil {
+		return nil, err
+	}
+	return out.Stories, nil
+}
+
+// GetQueue returns the queue
+func (c *Client) GetQueue(ctx context.Context) (*Queue, error) {
+	return c.queueCall(ctx, "GetQueue", &Empty{})
+}
+
+// AddToQueue adds stories by key and returns the queue
+func (c *Client) AddToQueue(ctx context.Context, keys ...string) (*Queue, error) {
+	return c.queueCall(ctx, "AddToQueue", &KeysRequest{Keys: keys})
+}
+
+// RemoveFromQueue removes a pending story and returns the queue
+func (c *Client) RemoveFromQueue(ctx context.Context, key string) (*Queue, error) {
+	return c.queueCall(ctx, "RemoveFromQueue", &KeyRequest{Key: key})
+}
+
+// ClearQueue removes the pending stories and returns the queue
+func (c *Client) ClearQueue(ctx context.Context) (*Queue, error) {
+	return c.queueCall(ctx, "ClearQueue", &Empty{})
+}
+
+// MoveQueueItem moves the pending item at index one place in direction
+// (MoveUp or MoveDown) and returns the queue
+func (c *Client) MoveQueueItem(ctx context.Context, index int, direction string) (*Queue, error) {
+	return c.queueCall(ctx, "MoveQueueItem", &MoveRequest{Index: index, Direction: direction})
+}
+
+func (c *Client) queueCall(ctx context.Context, method string, in any) (*Queue, error) {
+	out := new(Queue)
+	if err := c.invoke(ctx, method, in, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StartQueue starts running the queue
+func (c *Client) StartQueue(ctx context.Context) error {
+	return c.invoke(ctx, "StartQueue", &Empty{}, &Empty{})
+}
+
+// StartStory runs one story outside the queue
+func (c *Client) StartStory(ctx context.Context, key string) error {
+	return c.invoke(ctx, "StartStory", &KeyRequest{Key: key}, &Empty{})
+}
+
+// Pause pauses the queue or the running story
+func (c *Client) Pause(ctx context.Context) error {
+	return c.invoke(ctx, "Pause", &Empty{}, &Empty{})
+}
+
+// Resume resumes what Pause paused
+func (c *Client) Resume(ctx context.Context) error {
+	return c.invoke(ctx, "Resume", &Empty{}, &Empty{})
+}
+
+// Cancel cancels the queue or the running story
+func (c *Client) Cancel(ctx context.Context) error {
+	return c.invoke(ctx, "Cancel", &Empty{}, &Empty{})
+}
+
+// SkipStep skips the running step
+func (c *Client) SkipStep(ctx context.Context) error {
+	return c.invoke(ctx, "SkipStep", &Empty{}, &Empty{})
+}
+
+// GetExecution returns the execution in progress, or the last one
+func (c *Client) GetExecution(ctx context.Context) (*Execution, error) {
+	out := new(Execution)
+	if err := c.invoke(ctx, "GetExecution", &Empty{}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutputStream receives step output
+type OutputStream struct {
+	stream grpc.ClientStream
+}
+
+// Recv returns the next line, or io.EOF once the server ends the stream
+func (s *OutputStream) Recv() (*OutputLine, error) {
+	line := new(OutputLine)
+	if err := s.stream.RecvMsg(line); err != nil {
+		return nil, err
+	}
+	return line, nil
+}
+
+// StreamOutput streams the output of one execution, or of all when
+// executionID is empty. Cancel ctx to stop.
+func (c *Client) StreamOutput(ctx context.Context, executionID string) (*OutputStream, error) {
+	desc := &ServiceDesc.Streams[0]
+	stream, err := c.cc.NewStream(ctx, desc, "/"+ServiceName+"/"+desc.StreamName, grpc.CallContentSubtype(CodecName))
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(&StreamOutputRequest{ExecutionID: executionID}); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return &OutputStream{stream: stream}, nil
+}
@@ -0,0 +1,109 @@
+package bmadrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ControlServer is implemented by the bmad gRPC server
+type ControlServer interface {
+	ListStories(context.Context, *ListStoriesRequest) (*ListStoriesReply, error)
+
+	// Queue management; each returns the queue after the change
+	GetQueue(context.Context, *Empty) (*Queue, error)
+	AddToQueue(context.Context, *KeysRequest) (*Queue, error)
+	RemoveFromQueue(context.Context, *KeyRequest) (*Queue, error)
+	ClearQueue(context.Context, *Empty) (*Queue, error)
+	MoveQueueItem(context.Context, *MoveRequest) (*Queue, error)
+
+	// Execution control
+	StartQueue(context.Context, *Empty) (*Empty, error)
+	StartStory(context.Context, *KeyRequest) (*Empty, error)
+	Pause(context.Context, *Empty) (*Empty, error)
+	Resume(context.Context, *Empty) (*Empty, error)
+	Cancel(context.Context, *Empty) (*Empty, error)
+	SkipStep(context.Context, *Empty) (*Empty, error)
+	GetExecution(context.Context, *Empty) (*Execution, error)
+
+	// StreamOutput sends step output as it is written, until the client
+	// goes away
+	StreamOutput(*StreamOutputRequest, OutputStreamServer) error
+}
+
+// OutputStreamServer is the server side of StreamOutput
+type OutputStreamServer interface {
+	Send(*OutputLine) error
+	grpc.ServerStream
+}
+
+// RegisterControlServer registers srv with a gRPC server
+func RegisterControlServer(s grpc.ServiceRegistrar, srv ControlServer) {
+	s.RegisterService(&ServiceDesc, srv)
+}
+
+// ServiceDesc describes the Control service
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*ControlServer)(nil),
+	Methods: []grpc.MethodDesc{
+		unary("ListStories", ControlServer.ListStories),
+		unary("GetQueue", ControlServer.GetQueue),
+		unary("AddToQueue", ControlServer.AddToQueue),
+		unary("RemoveFromQueue", ControlServer.RemoveFromQueue),
+		unary("ClearQueue", ControlServer.ClearQueue),
+		unary("MoveQueueItem", ControlServer.MoveQueueItem),
+		unary("StartQueue", ControlServer.StartQueue),
+		unary("StartStory", ControlServer.StartStory),
+		unary("Pause", ControlServer.Pause),
+		unary("Resume", ControlServer.Resume),
+		unary("Cancel", ControlServer.Cancel),
+		unary("SkipStep", ControlServer.SkipStep),
+		unary("GetExecution", ControlServer.GetExecution),
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamOutput",
+			Handler:       streamOutputHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// unary builds the descriptor of a unary method from the ControlServer
+// method implementing it
+func unary[Req, Resp any](name string, call func(ControlServer, context.Context, *Req) (*Resp, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			in := new(Req)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return call(srv.(ControlServer), ctx, req.(*Req))
+			}
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+			info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + ServiceName + "/" + name}
+			return interceptor(ctx, in, info, handler)
+		},
+	}
+}
+
+func streamOutputHandler(srv any, stream grpc.ServerStream) error {
+	in := new(StreamOutputRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(ControlServer).StreamOutput(in, &outputStreamServer{stream})
+}
+
+type outputStreamServer struct {
+	grpc.ServerStream
+}
+
+func (s *outputStreamServer) Send(line *OutputLine) error {
+	return s.ServerStream.SendMsg(line)
+}